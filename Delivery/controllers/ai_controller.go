@@ -2,6 +2,7 @@ package controllers
 
 import (
 	domain "A2SV_Starter_Project_Blog/Domain"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -20,16 +21,31 @@ type AISuggestRequest struct {
 // `omitempty` ensures that only the relevant field is included in the output.
 type AISuggestResponse struct {
 	Suggestions    []string `json:"suggestions,omitempty"`
-	RefinedContent string   `json:"refinedContent,omitempty"`
+	RefinedContent string   `json:"refined_content,omitempty"`
+}
+
+// AIStreamRequest defines the JSON body for the generation endpoints, streaming or not.
+type AIStreamRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+// AIGenerateResponse carries a generated draft. Nothing is saved: the author edits the draft and
+// creates the blog with POST /blogs/from-ai when happy with it.
+type AIGenerateResponse struct {
+	Draft string `json:"draft"`
 }
 
 type AIController struct {
 	aiUsecase domain.IAIUsecase
+	// streamBufferSize is how many events GenerateStream holds for a slow client.
+	streamBufferSize int
 }
 
-func NewAIController(usecase domain.IAIUsecase) *AIController {
+// NewAIController builds the AI controller. A streamBufferSize of zero or less means DefaultSSEBufferSize.
+func NewAIController(usecase domain.IAIUsecase, streamBufferSize int) *AIController {
 	return &AIController{
-		aiUsecase: usecase,
+		aiUsecase:        usecase,
+		streamBufferSize: streamBufferSize,
 	}
 }
 
@@ -73,3 +89,80 @@ func (ac *AIController) Suggest(c *gin.Context) {
 		c.JSON(http.StatusOK, AISuggestResponse{RefinedContent: refined})
 	}
 }
+
+// Generate is the handler for the POST /ai/generate endpoint. It returns a refined draft of the
+// given content as a preview and never persists anything, so authors can iterate freely.
+func (ac *AIController) Generate(c *gin.Context) {
+	var req AIStreamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+
+	draft, err := ac.aiUsecase.RefineBlogPost(c.Request.Context(), req.Content)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, AIGenerateResponse{Draft: draft})
+}
+
+// GenerateStream is the handler for the POST /ai/generate/stream endpoint. It refines the given
+// content and forwards the text to the client as Server-Sent Events while the model writes it:
+// a "chunk" event per piece of text, then a single "done" event, or an "error" event if the
+// generation fails part way through. A client reading too slowly to keep up is disconnected
+// and the generation stopped.
+func (ac *AIController) GenerateStream(c *gin.Context) {
+	var req AIStreamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	started := false
+	// Headers are only sent once there is something to stream, so failures up front
+	// still get a regular JSON error response.
+	start := func() {
+		if !started {
+			c.Header("Cache-Control", "no-cache")
+			c.Header("Connection", "keep-alive")
+			c.Header("X-Accel-Buffering", "no")
+			started = true
+		}
+	}
+
+	stream := newSSEStream(c, ac.streamBufferSize)
+	var err error
+	go func() {
+		defer stream.Close()
+		err = ac.aiUsecase.StreamRefineBlogPost(ctx, req.Content, func(chunk string) error {
+			// The client hung up; returning the error cancels the upstream stream.
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return stream.Send("chunk", chunk)
+		})
+	}()
+	keptUp := stream.Serve(start)
+
+	switch {
+	case !keptUp:
+		log.Printf("Disconnected a client too slow to keep up with the AI stream")
+		return
+	case ctx.Err() != nil:
+		// Nobody is listening any more.
+		return
+	case err != nil && !started:
+		HandleError(c, err)
+		return
+	case err != nil:
+		// The status line has already been sent, so report the failure in the stream itself.
+		log.Printf("Error streaming AI content: %v", err)
+		c.SSEvent("error", gin.H{"error": localize(c, CodeInternalError), "code": CodeInternalError})
+	default:
+		start()
+		c.SSEvent("done", "")
+	}
+	c.Writer.Flush()
+}
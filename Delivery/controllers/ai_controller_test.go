@@ -4,16 +4,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	. "A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
 	usecases "A2SV_Starter_Project_Blog/Usecases"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -36,6 +42,16 @@ func (m *MockAIUsecase) RefineBlogPost(ctx context.Context, content string) (str
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockAIUsecase) StreamRefineBlogPost(ctx context.Context, content string, fn func(chunk string) error) error {
+	args := m.Called(ctx, content, fn)
+	return args.Error(0)
+}
+
+func (m *MockAIUsecase) ScoreContent(ctx context.Context, text string) (float64, error) {
+	args := m.Called(ctx, text)
+	return args.Get(0).(float64), args.Error(1)
+}
+
 // --- Test Suite Setup ---
 type AIControllerTestSuite struct {
 	suite.Suite
@@ -47,7 +63,7 @@ type AIControllerTestSuite struct {
 func (s *AIControllerTestSuite) SetupTest() {
 	gin.SetMode(gin.TestMode)
 	s.mockAIUsecase = new(MockAIUsecase)
-	s.controller = NewAIController(s.mockAIUsecase)
+	s.controller = NewAIController(s.mockAIUsecase, 0)
 	s.router = gin.New()
 
 	// The endpoint is protected, so we add a dummy middleware for tests
@@ -57,6 +73,8 @@ func (s *AIControllerTestSuite) SetupTest() {
 		c.Next()
 	}
 	s.router.POST("/ai/suggest", authMiddleware, s.controller.Suggest)
+	s.router.POST("/ai/generate", authMiddleware, s.controller.Generate)
+	s.router.POST("/ai/generate/stream", authMiddleware, s.controller.GenerateStream)
 }
 
 func TestAIControllerTestSuite(t *testing.T) {
@@ -142,6 +160,7 @@ func (s *AIControllerTestSuite) TestSuggest_RefineContent() {
 		s.NoError(err)
 		s.Equal(expectedRefined, resp.RefinedContent)
 		s.Empty(resp.Suggestions)
+		s.Contains(w.Body.String(), `"refined_content":`)
 
 		s.mockAIUsecase.AssertExpectations(s.T())
 	})
@@ -187,3 +206,238 @@ func (s *AIControllerTestSuite) TestSuggest_GeneralFailures() {
 		s.mockAIUsecase.AssertExpectations(s.T())
 	})
 }
+
+// streamChunks makes the mocked usecase hand chunks to the controller's callback.
+func (s *AIControllerTestSuite) TestGenerate() {
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockAIUsecase.On("RefineBlogPost", mock.Anything, "rough draft").Return("Polished draft.", nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/ai/generate", strings.NewReader(`{"content":"rough draft"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusOK, w.Code)
+		var resp AIGenerateResponse
+		s.NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal("Polished draft.", resp.Draft)
+		s.mockAIUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure - Missing content", func() {
+		s.SetupTest()
+
+		req := httptest.NewRequest(http.MethodPost, "/ai/generate", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), `"code":"`+CodeInvalidRequestBody+`"`)
+		s.mockAIUsecase.AssertNotCalled(s.T(), "RefineBlogPost", mock.Anything, mock.Anything)
+	})
+}
+
+// TestAIDraftFlow checks that generating a draft saves nothing, and that only the explicit
+// create step turns it into a blog.
+func TestAIDraftFlow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockAI := new(MockAIUsecase)
+	mockBlogs := new(MockBlogUsecase)
+	authMiddleware := func(c *gin.Context) { c.Set("userID", "author-1"); c.Next() }
+	router := gin.New()
+	router.POST("/ai/generate", authMiddleware, NewAIController(mockAI, 0).Generate)
+	router.POST("/blogs/from-ai", authMiddleware, NewBlogController(mockBlogs).CreateFromAI)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	mockAI.On("RefineBlogPost", mock.Anything, "notes on go").Return("Go, explained.", nil).Twice()
+	require.Equal(t, http.StatusOK, post("/ai/generate", `{"content":"notes on go"}`).Code)
+	require.Equal(t, http.StatusOK, post("/ai/generate", `{"content":"notes on go"}`).Code, "regenerating is just as free")
+	mockBlogs.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	t.Run("Edited content wins over the draft", func(t *testing.T) {
+		created := &domain.Blog{ID: "blog-1", Title: "Go", Content: "Go, explained better.", AuthorID: "author-1"}
+		mockBlogs.On("Create", mock.Anything, "Go", "Go, explained better.", "author-1", []string{"go"}, (*time.Time)(nil), domain.ContentFormatMarkdown).Return(created, nil).Once()
+
+		w := post("/blogs/from-ai", `{"title":"Go","draft":"Go, explained.","content":"Go, explained better.","tags":["go"]}`)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"id":"blog-1"`)
+		mockBlogs.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("Unedited draft is used as is", func(t *testing.T) {
+		created := &domain.Blog{ID: "blog-2", Title: "Go", Content: "Go, explained.", AuthorID: "author-1"}
+		mockBlogs.On("Create", mock.Anything, "Go", "Go, explained.", "author-1", []string(nil), (*time.Time)(nil), domain.ContentFormatMarkdown).Return(created, nil).Once()
+
+		w := post("/blogs/from-ai", `{"title":"Go","draft":"Go, explained."}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("Missing draft", func(t *testing.T) {
+		w := post("/blogs/from-ai", `{"title":"Go","content":"Written by hand."}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockBlogs.AssertNumberOfCalls(t, "Create", 2)
+	})
+}
+
+func streamChunks(chunks ...string) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		fn := args.Get(2).(func(string) error)
+		for _, chunk := range chunks {
+			if fn(chunk) != nil {
+				return
+			}
+		}
+	}
+}
+
+// slowClientWriter is a connection whose client stopped reading: writes block until the server
+// gives up on it by setting a write deadline, and fail from then on.
+type slowClientWriter struct {
+	*httptest.ResponseRecorder
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (w *slowClientWriter) Write(b []byte) (int, error) {
+	<-w.closed
+	return 0, errors.New("write deadline exceeded")
+}
+
+func (w *slowClientWriter) WriteString(str string) (int, error) {
+	return w.Write([]byte(str))
+}
+
+func (w *slowClientWriter) SetWriteDeadline(time.Time) error {
+	w.closeOnce.Do(func() { close(w.closed) })
+	return nil
+}
+
+func (s *AIControllerTestSuite) TestGenerateStream() {
+	newRequest := func(content string) *http.Request {
+		body, _ := json.Marshal(AIStreamRequest{Content: content})
+		req := httptest.NewRequest(http.MethodPost, "/ai/generate/stream", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	s.Run("Success - Forwards Chunks As Events In Order", func() {
+		s.SetupTest()
+		s.mockAIUsecase.On("StreamRefineBlogPost", mock.Anything, "draft", mock.Anything).
+			Run(streamChunks("Hello", ", ", "world")).Return(nil).Once()
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, newRequest("draft"))
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("text/event-stream", w.Header().Get("Content-Type"))
+		s.Equal("event:chunk\ndata:Hello\n\nevent:chunk\ndata:, \n\nevent:chunk\ndata:world\n\nevent:done\ndata:\n\n", w.Body.String())
+	})
+
+	s.Run("Failure - Error Before Streaming Is A Regular Response", func() {
+		s.SetupTest()
+		s.mockAIUsecase.On("StreamRefineBlogPost", mock.Anything, "draft", mock.Anything).Return(usecases.ErrInternal).Once()
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, newRequest("draft"))
+
+		s.Equal(http.StatusInternalServerError, w.Code)
+		s.Contains(w.Header().Get("Content-Type"), "application/json")
+	})
+
+	s.Run("Failure - Error Mid Stream Is Sent As An Event", func() {
+		s.SetupTest()
+		s.mockAIUsecase.On("StreamRefineBlogPost", mock.Anything, "draft", mock.Anything).
+			Run(streamChunks("Hello")).Return(usecases.ErrInternal).Once()
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, newRequest("draft"))
+
+		s.Equal(http.StatusOK, w.Code)
+		s.True(strings.HasPrefix(w.Body.String(), "event:chunk\ndata:Hello\n\n"))
+		s.Contains(w.Body.String(), "event:error\n")
+		s.NotContains(w.Body.String(), "event:done")
+	})
+
+	s.Run("Client Disconnect Stops The Stream", func() {
+		s.SetupTest()
+		ctx, cancel := context.WithCancel(context.Background())
+		var callbackErr error
+		s.mockAIUsecase.On("StreamRefineBlogPost", mock.Anything, "draft", mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(2).(func(string) error)
+				_ = fn("Hello")
+				cancel()
+				callbackErr = fn("never sent")
+			}).Return(context.Canceled).Once()
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, newRequest("draft").WithContext(ctx))
+
+		s.ErrorIs(callbackErr, context.Canceled)
+		s.Equal("event:chunk\ndata:Hello\n\n", w.Body.String())
+	})
+
+	s.Run("Slow Client Is Disconnected Once Its Buffer Overflows", func() {
+		s.SetupTest()
+		router := gin.New()
+		router.POST("/ai/generate/stream", NewAIController(s.mockAIUsecase, 2).GenerateStream)
+		sent := 0
+		var callbackErr error
+		s.mockAIUsecase.On("StreamRefineBlogPost", mock.Anything, "draft", mock.Anything).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(2).(func(string) error)
+				for ; sent < 100; sent++ {
+					if callbackErr = fn("chunk"); callbackErr != nil {
+						return
+					}
+				}
+			}).Return(ErrSlowConsumer).Once()
+		w := &slowClientWriter{ResponseRecorder: httptest.NewRecorder(), closed: make(chan struct{})}
+
+		served := make(chan struct{})
+		go func() {
+			router.ServeHTTP(w, newRequest("draft"))
+			close(served)
+		}()
+		select {
+		case <-served:
+		case <-time.After(2 * time.Second):
+			s.FailNow("the handler is still serving the slow client")
+		}
+
+		s.ErrorIs(callbackErr, ErrSlowConsumer, "the generation is stopped")
+		// Two buffered, plus at most one taken by the blocked write.
+		s.LessOrEqual(sent, 3)
+		select {
+		case <-w.closed:
+		default:
+			s.Fail("the connection was not closed")
+		}
+		s.NotContains(w.Body.String(), "event:done")
+	})
+
+	s.Run("Failure - Missing Content", func() {
+		s.SetupTest()
+		w := httptest.NewRecorder()
+
+		s.router.ServeHTTP(w, newRequest(""))
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), `"code":"`+CodeInvalidRequestBody+`"`)
+		s.mockAIUsecase.AssertNotCalled(s.T(), "StreamRefineBlogPost", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- Response DTOs ---
+
+type AuditEntryResponse struct {
+	ID        string            `json:"id"`
+	ActorID   string            `json:"actor_id"`
+	Action    string            `json:"action"`
+	TargetID  string            `json:"target_id,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+type PaginatedAuditResponse struct {
+	Data       []AuditEntryResponse `json:"data"`
+	Pagination Pagination           `json:"pagination"`
+}
+
+// --- Controller ---
+
+type AuditController struct {
+	auditUsecase domain.IAuditUsecase
+}
+
+func NewAuditController(auditUsecase domain.IAuditUsecase) *AuditController {
+	return &AuditController{
+		auditUsecase: auditUsecase,
+	}
+}
+
+// Search lets admins browse the audit log, optionally narrowed by actor, action and date range.
+func (ac *AuditController) Search(c *gin.Context) {
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+	options := domain.AuditFilterOptions{Page: page, Limit: limit}
+
+	if actorID := c.Query("actorId"); actorID != "" {
+		options.ActorID = &actorID
+	}
+	if actionStr := c.Query("action"); actionStr != "" {
+		action := domain.AuditAction(actionStr)
+		if !action.IsValid() {
+			abortInvalidQueryParameter(c, "Invalid 'action' parameter")
+			return
+		}
+		options.Action = &action
+	}
+	if startDateStr := c.Query("startDate"); startDateStr != "" {
+		t, err := time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			abortInvalidQueryParameter(c, "Invalid 'startDate' format. Use RFC3339 (e.g., 2023-10-27T10:00:00Z)")
+			return
+		}
+		options.StartDate = &t
+	}
+	if endDateStr := c.Query("endDate"); endDateStr != "" {
+		t, err := time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			abortInvalidQueryParameter(c, "Invalid 'endDate' format. Use RFC3339 (e.g., 2023-10-27T10:00:00Z)")
+			return
+		}
+		options.EndDate = &t
+	}
+
+	entries, total, err := ac.auditUsecase.Search(c.Request.Context(), options)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	resp := PaginatedAuditResponse{
+		Data: make([]AuditEntryResponse, len(entries)),
+		Pagination: Pagination{
+			Total: total,
+			Page:  page,
+			Limit: limit,
+		},
+	}
+	for i, e := range entries {
+		resp.Data[i] = AuditEntryResponse{
+			ID:        e.ID,
+			ActorID:   e.ActorID,
+			Action:    string(e.Action),
+			TargetID:  e.TargetID,
+			Timestamp: e.Timestamp,
+			Details:   e.Details,
+		}
+	}
+	setLinkHeader(c, resp.Pagination)
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,105 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock IAuditUsecase ---
+type MockAuditUsecase struct {
+	mock.Mock
+}
+
+func (m *MockAuditUsecase) Search(ctx context.Context, options domain.AuditFilterOptions) ([]*domain.AuditEntry, int64, error) {
+	args := m.Called(ctx, options)
+	var entries []*domain.AuditEntry
+	if args.Get(0) != nil {
+		entries = args.Get(0).([]*domain.AuditEntry)
+	}
+	return entries, args.Get(1).(int64), args.Error(2)
+}
+
+func setupAuditRouter(mockUsecase *MockAuditUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/audit", controllers.NewAuditController(mockUsecase).Search)
+	return router
+}
+
+func TestAuditController_Search(t *testing.T) {
+	t.Run("Success - Passes Filters Through", func(t *testing.T) {
+		mockUsecase := new(MockAuditUsecase)
+		router := setupAuditRouter(mockUsecase)
+		timestamp := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		entries := []*domain.AuditEntry{{
+			ID:        "entry-1",
+			ActorID:   "admin-1",
+			Action:    domain.AuditActionRoleChange,
+			TargetID:  "user-1",
+			Timestamp: timestamp,
+			Details:   map[string]string{"from": "user", "to": "admin"},
+		}}
+
+		mockUsecase.On("Search", mock.Anything, mock.MatchedBy(func(o domain.AuditFilterOptions) bool {
+			return o.Page == 2 && o.Limit == 5 &&
+				o.ActorID != nil && *o.ActorID == "admin-1" &&
+				o.Action != nil && *o.Action == domain.AuditActionRoleChange &&
+				o.StartDate != nil && o.StartDate.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+				o.EndDate == nil
+		})).Return(entries, int64(6), nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/audit?actorId=admin-1&action=user.role_change&startDate=2024-01-01T00:00:00Z&page=2&limit=5", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp controllers.PaginatedAuditResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, int64(6), resp.Pagination.Total)
+		if assert.Len(t, resp.Data, 1) {
+			assert.Equal(t, "user.role_change", resp.Data[0].Action)
+			assert.Equal(t, "user-1", resp.Data[0].TargetID)
+			assert.Equal(t, "admin", resp.Data[0].Details["to"])
+		}
+		assert.Contains(t, w.Body.String(), `"actor_id":"admin-1"`)
+		assert.Contains(t, w.Body.String(), `"target_id":"user-1"`)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Unknown Action", func(t *testing.T) {
+		mockUsecase := new(MockAuditUsecase)
+		router := setupAuditRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/audit?action=user.delete_everything", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"`+controllers.CodeInvalidQueryParameter+`"`)
+		mockUsecase.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - Malformed Date", func(t *testing.T) {
+		mockUsecase := new(MockAuditUsecase)
+		router := setupAuditRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/audit?endDate=yesterday", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"`+controllers.CodeInvalidQueryParameter+`"`)
+		mockUsecase.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+	})
+}
@@ -3,40 +3,179 @@ package controllers
 import (
 	domain "A2SV_Starter_Project_Blog/Domain"
 	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"context"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// blogSortFields are the values the blog list accepts for sortBy.
+var blogSortFields = []string{"date", "title", "popularity", "engagementScore", "activity"}
+
 type CreateBlogRequest struct {
-	Title   string   `json:"title" binding:"required"`
-	Content string   `json:"content" binding:"required"`
-	Tags    []string `json:"tags"`
+	Title        string     `json:"title" binding:"required"`
+	Content      string     `json:"content" binding:"required"`
+	Tags         []string   `json:"tags"`
+	ScheduledFor *time.Time `json:"scheduled_for"`
+	// One of markdown (the default), plaintext or html.
+	ContentFormat domain.ContentFormat `json:"content_format"`
+}
+
+// CreateBlogFromAIRequest creates a blog from a draft returned by POST /ai/generate. Content holds
+// the author's edited version; when empty the draft is published as generated.
+type CreateBlogFromAIRequest struct {
+	Title        string     `json:"title" binding:"required"`
+	Draft        string     `json:"draft" binding:"required"`
+	Content      string     `json:"content"`
+	Tags         []string   `json:"tags"`
+	ScheduledFor *time.Time `json:"scheduled_for"`
 }
 
 type UpdateBlogRequest map[string]interface{}
 
+type BlogBatchRequest struct {
+	IDs []string `json:"ids" binding:"required"`
+}
+
 type InteractBlogRequest struct {
 	Action domain.ActionType `json:"action" binding:"required,oneof=like dislike"`
 }
 
+// InteractionResponse is where a blog's votes stand after an interaction.
+// Action is the caller's vote afterwards and is left out when they undid it.
+type InteractionResponse struct {
+	Likes    int64  `json:"likes"`
+	Dislikes int64  `json:"dislikes"`
+	Action   string `json:"action,omitempty"`
+}
+
+// InteractBatchItem is one vote in a batch, e.g. queued by a mobile client while offline.
+type InteractBatchItem struct {
+	BlogID string            `json:"blog_id"`
+	Action domain.ActionType `json:"action"`
+}
+
+// InteractBatchResult is the outcome of one batch item, keyed by its position in the request.
+// A successful item carries where the blog's votes stand after it; a failed one its error.
+type InteractBatchResult struct {
+	Index  int    `json:"index"`
+	BlogID string `json:"blog_id"`
+	*InteractionResponse
+	Error string `json:"error,omitempty"`
+	Code  string `json:"code,omitempty"`
+}
+
+type InteractBatchResponse struct {
+	Succeeded int                   `json:"succeeded"`
+	Failed    int                   `json:"failed"`
+	Results   []InteractBatchResult `json:"results"`
+}
+
+type ImportBlogRequest struct {
+	Title     string     `json:"title"`
+	Content   string     `json:"content"`
+	AuthorID  string     `json:"author_id"`
+	Tags      []string   `json:"tags"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type RetagRequest struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"`
+}
+
+type RetagResponse struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Updated int    `json:"updated"`
+}
+
+// BlogExportResponse is a blog and its whole comment thread as a single document.
+type BlogExportResponse struct {
+	Blog       BlogResponse      `json:"blog"`
+	Comments   []CommentResponse `json:"comments"`
+	ExportedAt time.Time         `json:"exported_at"`
+}
+
+// BlogDetailResponse is everything a post page needs in one response. The blog carries its table
+// of contents and the viewer's reaction, as in a single-blog fetch.
+type BlogDetailResponse struct {
+	Blog     BlogResponse             `json:"blog"`
+	Comments PaginatedCommentResponse `json:"comments"`
+	Related  []BlogResponse           `json:"related"`
+}
+
+type ImportBlogResult struct {
+	Index int    `json:"index"`
+	File  string `json:"file,omitempty"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type ImportBlogsResponse struct {
+	Imported int                `json:"imported"`
+	Failed   int                `json:"failed"`
+	Results  []ImportBlogResult `json:"results"`
+}
+
 type BlogResponse struct {
-	ID            string    `json:"id"`
-	Title         string    `json:"title"`
-	Content       string    `json:"content"`
-	AuthorID      string    `json:"author_id"`
-	Tags          []string  `json:"tags"`
-	Views         int64     `json:"views"`
-	Likes         int64     `json:"likes"`
-	Dislikes      int64     `json:"dislikes"`
-	CommentsCount int64     `json:"comments_count"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            string   `json:"id"`
+	Title         string   `json:"title"`
+	Content       string   `json:"content"`
+	ContentFormat string   `json:"content_format,omitempty"`
+	WordCount     int      `json:"word_count"`
+	AuthorID      string   `json:"author_id"`
+	Tags          []string `json:"tags"`
+	Views         int64    `json:"views"`
+	Likes         int64    `json:"likes"`
+	Dislikes      int64    `json:"dislikes"`
+	CommentsCount int64    `json:"comments_count"`
+	// The scores the "engagementScore" and "popularity" sorts rank by, so clients can tell why
+	// a blog ranks where it does. Popularity is as of the response.
+	EngagementScore float64    `json:"engagement_score"`
+	PopularityScore float64    `json:"popularity_score"`
+	ViewerAction    string     `json:"viewer_action,omitempty"`
+	Read            *bool      `json:"read,omitempty"`
+	PinnedByAuthor  bool       `json:"pinned_by_author"`
+	Status          string     `json:"status"`
+	ScheduledFor    *time.Time `json:"scheduled_for,omitempty"`
+	PublishedAt     *time.Time `json:"published_at,omitempty"`
+	LastActivityAt  time.Time  `json:"last_activity_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	// Only filled in on the blog detail response.
+	TableOfContents []TOCEntryResponse `json:"table_of_contents,omitempty"`
+}
+
+type TOCEntryResponse struct {
+	Level  int    `json:"level"`
+	Text   string `json:"text"`
+	Anchor string `json:"anchor"`
+}
+
+type BlogRevisionResponse struct {
+	ID        string    `json:"id"`
+	BlogID    string    `json:"blog_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Tags      []string  `json:"tags"`
+	EditorID  string    `json:"editor_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CommentCountCheckResponse struct {
+	BlogID string `json:"blog_id"`
+	Stored int64  `json:"stored_comments_count"`
+	Actual int64  `json:"actual_comments_count"`
+	InSync bool   `json:"in_sync"`
 }
 
 type Pagination struct {
@@ -45,11 +184,72 @@ type Pagination struct {
 	Limit int64 `json:"limit"`
 }
 
+type TagResponse struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+type BlogTitleSuggestionResponse struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type PaginatedTagResponse struct {
+	Data       []TagResponse `json:"data"`
+	Pagination Pagination    `json:"pagination"`
+}
+
 type PaginatedBlogResponse struct {
 	Data       []BlogResponse `json:"data"`
 	Pagination Pagination     `json:"pagination"`
 }
 
+type BlogReadResponse struct {
+	BlogID string    `json:"blog_id"`
+	ReadAt time.Time `json:"read_at"`
+}
+
+type PaginatedBlogReadResponse struct {
+	Data       []BlogReadResponse `json:"data"`
+	Pagination Pagination         `json:"pagination"`
+}
+
+// BlogSummaryResponse is the minimal blog info embedded in other resources.
+type BlogSummaryResponse struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	AuthorID string `json:"author_id"`
+}
+
+type InteractionHistoryResponse struct {
+	ID        string              `json:"id"`
+	Action    string              `json:"action"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	Blog      BlogSummaryResponse `json:"blog"`
+}
+
+type PaginatedInteractionHistoryResponse struct {
+	Data       []InteractionHistoryResponse `json:"data"`
+	Pagination Pagination                   `json:"pagination"`
+}
+
+// BlogInteractionResponse is one vote on a blog. The user details are empty when the account was deleted.
+type BlogInteractionResponse struct {
+	ID             string    `json:"id"`
+	UserID         string    `json:"user_id"`
+	Username       string    `json:"username,omitempty"`
+	ProfilePicture string    `json:"profile_picture,omitempty"`
+	Action         string    `json:"action"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type PaginatedBlogInteractionResponse struct {
+	Data       []BlogInteractionResponse `json:"data"`
+	Pagination Pagination                `json:"pagination"`
+}
+
 type BlogController struct {
 	blogUsecase domain.IBlogUsecase
 }
@@ -63,13 +263,35 @@ func NewBlogController(usecase domain.IBlogUsecase) *BlogController {
 func (bc *BlogController) Create(c *gin.Context) {
 	var req CreateBlogRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body: " + err.Error()})
+		HandleBindingError(c, err)
 		return
 	}
 
 	userID := c.GetString("userID")
 
-	blog, err := bc.blogUsecase.Create(c.Request.Context(), req.Title, req.Content, userID, req.Tags)
+	blog, err := bc.blogUsecase.Create(c.Request.Context(), req.Title, req.Content, userID, req.Tags, req.ScheduledFor, req.ContentFormat)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, toBlogResponse(blog))
+}
+
+// CreateFromAI is the explicit step that saves an AI draft, keeping generation and persistence apart.
+func (bc *BlogController) CreateFromAI(c *gin.Context) {
+	var req CreateBlogFromAIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+	content := req.Content
+	if strings.TrimSpace(content) == "" {
+		content = req.Draft
+	}
+
+	// AI drafts are written in markdown.
+	blog, err := bc.blogUsecase.Create(c.Request.Context(), req.Title, content, c.GetString("userID"), req.Tags, req.ScheduledFor, domain.ContentFormatMarkdown)
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -81,15 +303,110 @@ func (bc *BlogController) Create(c *gin.Context) {
 func (bc *BlogController) GetByID(c *gin.Context) {
 	blogID := c.Param("blogID")
 
-	blog, err := bc.blogUsecase.GetByID(c.Request.Context(), blogID)
+	// Set by OptionalAuth when the viewer is logged in.
+	userID := c.GetString("userID")
+
+	blog, err := bc.blogUsecase.GetByID(c.Request.Context(), blogID, userID, userRoleFromContext(c))
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
 
+	response := toBlogResponse(blog)
+	response.TableOfContents = toTOCResponse(blog.TableOfContents())
+	if userID != "" {
+		action, err := bc.blogUsecase.GetViewerAction(c.Request.Context(), blogID, userID)
+		if err != nil {
+			// Personalization is best-effort; the blog itself was found.
+			log.Printf("Failed to load viewer action for blog %s: %v", blogID, err)
+		}
+		response.ViewerAction = string(action)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBlogDetail returns a blog with the first page of its comments, related blogs and the
+// viewer's reaction, so a post page loads in one round-trip. Drafts are hidden from everyone but
+// their author and admins.
+func (bc *BlogController) GetBlogDetail(c *gin.Context) {
+	detail, err := bc.blogUsecase.GetBlogDetail(c.Request.Context(), c.Param("blogID"), c.GetString("userID"), userRoleFromContext(c))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	resp := BlogDetailResponse{
+		Blog:     toBlogResponse(detail.Blog),
+		Comments: toPaginatedCommentResponse(detail.Comments, detail.CommentsTotal, 1, usecases.DetailCommentsLimit),
+		Related:  make([]BlogResponse, len(detail.Related)),
+	}
+	resp.Blog.TableOfContents = toTOCResponse(detail.Blog.TableOfContents())
+	resp.Blog.ViewerAction = string(detail.ViewerAction)
+	for i, blog := range detail.Related {
+		resp.Related[i] = toBlogResponse(blog)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetRandom returns one published blog picked at random, optionally limited by the tag query parameter.
+func (bc *BlogController) GetRandom(c *gin.Context) {
+	blog, err := bc.blogUsecase.GetRandom(c.Request.Context(), c.Query("tag"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
 	c.JSON(http.StatusOK, toBlogResponse(blog))
 }
 
+// SuggestTitles returns published blogs whose title matches the q query parameter, for
+// autocomplete in a search box. The limit parameter can lower how many are returned.
+func (bc *BlogController) SuggestTitles(c *gin.Context) {
+	_, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+
+	suggestions, err := bc.blogUsecase.SuggestTitles(c.Request.Context(), c.Query("q"), int(limit))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	data := make([]BlogTitleSuggestionResponse, len(suggestions))
+	for i, s := range suggestions {
+		data[i] = BlogTitleSuggestionResponse{ID: s.ID, Title: s.Title}
+	}
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// GetByIDs returns the blogs with the given IDs in the order requested, for clients holding
+// lists of IDs such as bookmarks. IDs that don't exist, or are drafts the caller can't see, are left out.
+func (bc *BlogController) GetByIDs(c *gin.Context) {
+	var req BlogBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+	if len(req.IDs) == 0 || len(req.IDs) > usecases.MaxBlogBatchFetch {
+		abortInvalidBatchSize(c, "A batch must contain between 1 and "+strconv.Itoa(usecases.MaxBlogBatchFetch)+" IDs")
+		return
+	}
+
+	blogs, err := bc.blogUsecase.GetByIDs(c.Request.Context(), req.IDs, c.GetString("userID"), userRoleFromContext(c))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	data := make([]BlogResponse, len(blogs))
+	for i, blog := range blogs {
+		data[i] = toBlogResponse(blog)
+	}
+	bc.addReadFlags(c, data)
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
 func (bc *BlogController) SearchAndFilter(c *gin.Context) {
 	options := domain.BlogSearchFilterOptions{
 		GlobalLogic: domain.GlobalLogicAND, // Default to AND logic for filers
@@ -100,18 +417,11 @@ func (bc *BlogController) SearchAndFilter(c *gin.Context) {
 	// 2. Parse all optional query parameters from the request.
 
 	// Pagination
-	page, err := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid 'page' parameter"})
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
 		return
 	}
 	options.Page = page
-
-	limit, err := strconv.ParseInt(c.DefaultQuery("limit", "10"), 10, 64)
-	if err != nil || limit < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid 'limit' parameter"})
-		return
-	}
 	options.Limit = limit
 
 	// Global Options
@@ -126,6 +436,19 @@ func (bc *BlogController) SearchAndFilter(c *gin.Context) {
 	if authorName := c.Query("authorName"); authorName != "" {
 		options.AuthorName = &authorName
 	}
+	// How an author signed in is not public, so only admins may filter on it.
+	if providerStr := c.Query("authorProvider"); providerStr != "" {
+		if userRoleFromContext(c) != domain.RoleAdmin {
+			HandleError(c, domain.ErrPermissionDenied)
+			return
+		}
+		provider := domain.AuthProvider(providerStr)
+		if provider != domain.ProviderLocal && provider != domain.ProviderGoogle {
+			abortInvalidQueryParameter(c, "Invalid 'authorProvider' parameter. Must be 'local' or 'google'.")
+			return
+		}
+		options.AuthorProvider = &provider
+	}
 
 	// Tag filtering
 	if tagStr := c.Query("tags"); tagStr != "" {
@@ -141,7 +464,7 @@ func (bc *BlogController) SearchAndFilter(c *gin.Context) {
 		if t, err := time.Parse(time.RFC3339, startDateStr); err == nil {
 			options.StartDate = &t
 		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid 'startDate' format. Use RFC3339 (e.g., 2023-10-27T10:00:00Z)"})
+			abortInvalidQueryParameter(c, "Invalid 'startDate' format. Use RFC3339 (e.g., 2023-10-27T10:00:00Z)")
 			return
 		}
 	}
@@ -149,13 +472,27 @@ func (bc *BlogController) SearchAndFilter(c *gin.Context) {
 		if t, err := time.Parse(time.RFC3339, endDateStr); err == nil {
 			options.EndDate = &t
 		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid 'endDate' format. Use RFC3339 (e.g., 2023-10-27T10:00:00Z)"})
+			abortInvalidQueryParameter(c, "Invalid 'endDate' format. Use RFC3339 (e.g., 2023-10-27T10:00:00Z)")
 			return
 		}
 	}
 
+	if minWordsStr := c.Query("minWordCount"); minWordsStr != "" {
+		minWords, err := strconv.Atoi(minWordsStr)
+		if err != nil || minWords < 0 {
+			abortInvalidQueryParameter(c, "Invalid 'minWordCount' parameter: must be a non-negative integer")
+			return
+		}
+		options.MinWordCount = minWords
+	}
+
 	// Sorting
-	options.SortBy = c.Query("sortBy") // e.g., "date", "popularity", "title"
+	sortBy, ok := parseSortBy(c, blogSortFields)
+	if !ok {
+		abortInvalidQueryParameter(c, invalidSortByMessage(blogSortFields))
+		return
+	}
+	options.SortBy = sortBy
 	if strings.ToUpper(c.Query("sortOrder")) == string(domain.SortOrderASC) {
 		options.SortOrder = domain.SortOrderASC
 	}
@@ -168,17 +505,20 @@ func (bc *BlogController) SearchAndFilter(c *gin.Context) {
 	}
 
 	// 4. Return the paginated response.
-	c.JSON(http.StatusOK, toPaginatedBlogResponse(blogs, total, options.Page, options.Limit))
+	response := toPaginatedBlogResponse(blogs, total, options.Page, options.Limit)
+	bc.addReadFlags(c, response.Data)
+	setLinkHeader(c, response.Pagination)
+	c.JSON(http.StatusOK, response)
 }
 
 func (bc *BlogController) Update(c *gin.Context) {
 	blogID := c.Param("blogID")
 	userID := c.GetString("userID")
-	userRole := domain.Role(c.GetString("role"))
+	userRole := userRoleFromContext(c)
 
 	var updates UpdateBlogRequest
 	if err := c.ShouldBindJSON(&updates); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body: " + err.Error()})
+		HandleBindingError(c, err)
 		return
 	}
 
@@ -194,7 +534,7 @@ func (bc *BlogController) Update(c *gin.Context) {
 func (bc *BlogController) Delete(c *gin.Context) {
 	blogID := c.Param("blogID")
 	userID := c.GetString("userID")
-	userRole := domain.Role(c.GetString("role"))
+	userRole := userRoleFromContext(c)
 
 	err := bc.blogUsecase.Delete(c.Request.Context(), blogID, userID, userRole)
 	if err != nil {
@@ -218,82 +558,649 @@ func (bc *BlogController) InteractWithBlog(c *gin.Context) {
 	}
 
 	// 3. Call the single, consolidated usecase method with the parsed data.
-	err := bc.blogUsecase.InteractWithBlog(c.Request.Context(), blogID, userID, req.Action)
+	result, err := bc.blogUsecase.InteractWithBlog(c.Request.Context(), blogID, userID, req.Action)
 	if err != nil {
 		// The usecase will return errors like ErrNotFound, which HandleError will correctly process.
 		HandleError(c, err)
 		return
 	}
 
-	// 4. On success, return a 200 OK status with no body.
-	c.Status(http.StatusOK)
+	// 4. On success, return the updated counts so the client doesn't need to refetch the blog.
+	c.JSON(http.StatusOK, InteractionResponse{
+		Likes:    result.Likes,
+		Dislikes: result.Dislikes,
+		Action:   string(result.Action),
+	})
+}
+
+// InteractWithBlogs applies a batch of likes and dislikes in order, each as InteractWithBlog
+// would, so a vote repeated in the batch toggles just as it would sent on its own. A failing
+// item doesn't stop the others; each gets its own result.
+func (bc *BlogController) InteractWithBlogs(c *gin.Context) {
+	var req []InteractBatchItem
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+	if len(req) == 0 || len(req) > usecases.MaxInteractionBatch {
+		abortInvalidBatchSize(c, "A batch must contain between 1 and "+strconv.Itoa(usecases.MaxInteractionBatch)+" interactions")
+		return
+	}
+
+	userID := c.GetString("userID")
+	resp := InteractBatchResponse{Results: make([]InteractBatchResult, len(req))}
+	for i, item := range req {
+		result := InteractBatchResult{Index: i, BlogID: item.BlogID}
+		var err error
+		switch {
+		// As ValidateIDParams does for the single-blog route.
+		case !primitive.IsValidObjectID(item.BlogID):
+			err = usecases.ErrNotFound
+		case item.Action != domain.ActionTypeLike && item.Action != domain.ActionTypeDislike:
+			err = domain.ErrValidation
+		default:
+			var outcome *domain.InteractionResult
+			outcome, err = bc.blogUsecase.InteractWithBlog(c.Request.Context(), item.BlogID, userID, item.Action)
+			if err == nil {
+				result.InteractionResponse = &InteractionResponse{Likes: outcome.Likes, Dislikes: outcome.Dislikes, Action: string(outcome.Action)}
+			}
+		}
+
+		if err != nil {
+			_, result.Code = errorStatusAndCode(err)
+			result.Error = localize(c, result.Code)
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+		resp.Results[i] = result
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListRevisions returns the stored history of a blog, newest first.
+func (bc *BlogController) ListRevisions(c *gin.Context) {
+	blogID := c.Param("blogID")
+	userID := c.GetString("userID")
+
+	revisions, err := bc.blogUsecase.ListRevisions(c.Request.Context(), blogID, userID, userRoleFromContext(c))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	resp := make([]BlogRevisionResponse, len(revisions))
+	for i, rev := range revisions {
+		resp[i] = toBlogRevisionResponse(rev)
+	}
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
+
+func (bc *BlogController) GetRevision(c *gin.Context) {
+	blogID := c.Param("blogID")
+	revisionID := c.Param("rev")
+	userID := c.GetString("userID")
+
+	revision, err := bc.blogUsecase.GetRevision(c.Request.Context(), blogID, revisionID, userID, userRoleFromContext(c))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toBlogRevisionResponse(revision))
+}
+
+// RestoreRevision rolls a blog back to one of its revisions and returns the updated blog.
+func (bc *BlogController) RestoreRevision(c *gin.Context) {
+	blogID := c.Param("blogID")
+	revisionID := c.Param("rev")
+	userID := c.GetString("userID")
+
+	blog, err := bc.blogUsecase.RestoreRevision(c.Request.Context(), blogID, revisionID, userID, userRoleFromContext(c))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toBlogResponse(blog))
+}
+
+// ListByAuthor returns a user's published blogs for their profile, with the pinned one first.
+func (bc *BlogController) ListByAuthor(c *gin.Context) {
+	authorID := c.Param("userID")
+
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+
+	blogs, total, err := bc.blogUsecase.ListByAuthor(c.Request.Context(), authorID, page, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response := toPaginatedBlogResponse(blogs, total, page, limit)
+	bc.addReadFlags(c, response.Data)
+	setLinkHeader(c, response.Pagination)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetFollowingFeed lists the newest blogs by authors the current user follows.
+func (bc *BlogController) GetFollowingFeed(c *gin.Context) {
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+
+	blogs, total, err := bc.blogUsecase.GetFollowingFeed(c.Request.Context(), c.GetString("userID"), page, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response := toPaginatedBlogResponse(blogs, total, page, limit)
+	bc.addReadFlags(c, response.Data)
+	setLinkHeader(c, response.Pagination)
+	c.JSON(http.StatusOK, response)
+}
+
+// MarkRead records that the current user has read the blog.
+func (bc *BlogController) MarkRead(c *gin.Context) {
+	blogID := c.Param("blogID")
+	userID := c.GetString("userID")
+
+	if err := bc.blogUsecase.MarkRead(c.Request.Context(), blogID, userID); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListRead returns the blogs the current user has marked read, most recent first.
+func (bc *BlogController) ListRead(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+
+	reads, total, err := bc.blogUsecase.ListRead(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	data := make([]BlogReadResponse, len(reads))
+	for i, r := range reads {
+		data[i] = BlogReadResponse{BlogID: r.BlogID, ReadAt: r.ReadAt}
+	}
+	pagination := Pagination{Total: total, Page: page, Limit: limit}
+	setLinkHeader(c, pagination)
+	c.JSON(http.StatusOK, PaginatedBlogReadResponse{
+		Data:       data,
+		Pagination: pagination,
+	})
+}
+
+// ListInteractions returns the current user's likes and dislikes, newest first.
+func (bc *BlogController) ListInteractions(c *gin.Context) {
+	userID := c.GetString("userID")
+
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+
+	entries, total, err := bc.blogUsecase.ListInteractions(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	data := make([]InteractionHistoryResponse, len(entries))
+	for i, e := range entries {
+		data[i] = InteractionHistoryResponse{
+			ID:        e.Interaction.ID,
+			Action:    string(e.Interaction.Action),
+			CreatedAt: e.Interaction.CreatedAt,
+			UpdatedAt: e.Interaction.UpdatedAt,
+			Blog: BlogSummaryResponse{
+				ID:       e.Blog.ID,
+				Title:    e.Blog.Title,
+				AuthorID: e.Blog.AuthorID,
+			},
+		}
+	}
+	pagination := Pagination{Total: total, Page: page, Limit: limit}
+	setLinkHeader(c, pagination)
+	c.JSON(http.StatusOK, PaginatedInteractionHistoryResponse{
+		Data:       data,
+		Pagination: pagination,
+	})
+}
+
+// ListBlogInteractions returns who liked or disliked a blog, newest first. Only the blog's author
+// or an admin may see it.
+func (bc *BlogController) ListBlogInteractions(c *gin.Context) {
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+
+	entries, total, err := bc.blogUsecase.ListBlogInteractions(c.Request.Context(), c.Param("blogID"), c.GetString("userID"), userRoleFromContext(c), page, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	data := make([]BlogInteractionResponse, len(entries))
+	for i, e := range entries {
+		data[i] = BlogInteractionResponse{
+			ID:        e.Interaction.ID,
+			UserID:    e.Interaction.UserID,
+			Action:    string(e.Interaction.Action),
+			CreatedAt: e.Interaction.CreatedAt,
+			UpdatedAt: e.Interaction.UpdatedAt,
+		}
+		if e.User != nil {
+			data[i].Username = e.User.Username
+			data[i].ProfilePicture = e.User.ProfilePicture
+		}
+	}
+	pagination := Pagination{Total: total, Page: page, Limit: limit}
+	setLinkHeader(c, pagination)
+	c.JSON(http.StatusOK, PaginatedBlogInteractionResponse{
+		Data:       data,
+		Pagination: pagination,
+	})
+}
+
+// addReadFlags marks each blog in a list as read or unread for a logged-in viewer, with a single
+// lookup for the whole page. Anonymous viewers get no flag.
+func (bc *BlogController) addReadFlags(c *gin.Context, blogs []BlogResponse) {
+	userID := c.GetString("userID")
+	if userID == "" || len(blogs) == 0 {
+		return
+	}
+
+	blogIDs := make([]string, len(blogs))
+	for i, b := range blogs {
+		blogIDs[i] = b.ID
+	}
+	read, err := bc.blogUsecase.ReadFlags(c.Request.Context(), userID, blogIDs)
+	if err != nil {
+		// Personalization is best-effort; the list itself was found.
+		log.Printf("Failed to load read flags for user %s: %v", userID, err)
+		return
+	}
+	for i := range blogs {
+		isRead := read[blogs[i].ID]
+		blogs[i].Read = &isRead
+	}
+}
+
+// Pin pins a blog to the top of its author's profile, replacing any previously pinned one.
+func (bc *BlogController) Pin(c *gin.Context) {
+	bc.setPinned(c, true)
+}
+
+// Unpin removes a blog from the top of its author's profile.
+func (bc *BlogController) Unpin(c *gin.Context) {
+	bc.setPinned(c, false)
+}
+
+func (bc *BlogController) setPinned(c *gin.Context, pinned bool) {
+	blogID := c.Param("blogID")
+	userID := c.GetString("userID")
+
+	blog, err := bc.blogUsecase.SetPinned(c.Request.Context(), blogID, userID, pinned)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toBlogResponse(blog))
+}
+
+// VerifyCounts reports whether a blog's stored comment count matches its actual comments.
+// It is an admin debugging aid and doesn't repair anything.
+func (bc *BlogController) VerifyCounts(c *gin.Context) {
+	blogID := c.Param("blogID")
+
+	check, err := bc.blogUsecase.VerifyCommentCount(c.Request.Context(), blogID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CommentCountCheckResponse{
+		BlogID: check.BlogID,
+		Stored: check.Stored,
+		Actual: check.Actual,
+		InSync: check.InSync(),
+	})
+}
+
+// ListTags lists the tags used on published blogs with how many blogs carry each, most used
+// first or, with ?sort=alpha, alphabetically. The page size is capped by configuration.
+func (bc *BlogController) ListTags(c *gin.Context) {
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+
+	sort := domain.TagSort(c.Query("sort"))
+	if sort != "" && !sort.IsValid() {
+		abortInvalidQueryParameter(c, invalidTagSortMessage())
+		return
+	}
+
+	result, err := bc.blogUsecase.ListTags(c.Request.Context(), page, limit, sort)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	resp := PaginatedTagResponse{
+		Data:       make([]TagResponse, len(result.Tags)),
+		Pagination: Pagination{Total: result.Total, Page: page, Limit: result.Limit},
+	}
+	for i, t := range result.Tags {
+		resp.Data[i] = TagResponse{Tag: t.Tag, Count: t.Count}
+	}
+	setLinkHeader(c, resp.Pagination)
+	c.JSON(http.StatusOK, resp)
+}
+
+func invalidTagSortMessage() string {
+	sorts := make([]string, len(domain.TagSorts))
+	for i, sort := range domain.TagSorts {
+		sorts[i] = string(sort)
+	}
+	return fmt.Sprintf("Invalid 'sort' parameter. Must be one of: %s", strings.Join(sorts, ", "))
+}
+
+// CloneBlog copies a blog into a new draft owned by the current user and returns the draft.
+func (bc *BlogController) CloneBlog(c *gin.Context) {
+	blog, err := bc.blogUsecase.CloneBlog(c.Request.Context(), c.Param("blogID"), c.GetString("userID"), userRoleFromContext(c))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toBlogResponse(blog))
+}
+
+// PublishBlog publishes one of the current user's drafts, such as a clone, without waiting for a schedule.
+func (bc *BlogController) PublishBlog(c *gin.Context) {
+	blog, err := bc.blogUsecase.PublishDraft(c.Request.Context(), c.Param("blogID"), c.GetString("userID"), userRoleFromContext(c))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toBlogResponse(blog))
+}
+
+// ExportBlog returns a blog with all of its comments, replies nested under their parents, as a
+// downloadable JSON document. Drafts can only be exported by their author or an admin.
+func (bc *BlogController) ExportBlog(c *gin.Context) {
+	blogID := c.Param("blogID")
+
+	export, err := bc.blogUsecase.ExportBlog(c.Request.Context(), blogID, c.GetString("userID"), userRoleFromContext(c))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	resp := BlogExportResponse{
+		Blog:       toBlogResponse(export.Blog),
+		Comments:   make([]CommentResponse, len(export.Comments)),
+		ExportedAt: export.ExportedAt,
+	}
+	for i, comment := range export.Comments {
+		resp.Comments[i] = toCommentResponse(comment)
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="blog-`+blogID+`.json"`)
+	c.JSON(http.StatusOK, resp)
+}
+
+// ImportBlogs lets admins migrate blogs in bulk. Each item is validated on its own,
+// so a partially invalid payload still imports the valid entries.
+func (bc *BlogController) ImportBlogs(c *gin.Context) {
+	var req []ImportBlogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+	if len(req) == 0 || len(req) > usecases.MaxBlogImportBatch {
+		abortInvalidBatchSize(c, "An import must contain between 1 and "+strconv.Itoa(usecases.MaxBlogImportBatch)+" blogs")
+		return
+	}
+
+	items := make([]domain.BlogImportItem, len(req))
+	for i, r := range req {
+		items[i] = domain.BlogImportItem{
+			Title:     r.Title,
+			Content:   r.Content,
+			AuthorID:  r.AuthorID,
+			Tags:      r.Tags,
+			CreatedAt: r.CreatedAt,
+		}
+	}
+
+	results, err := bc.blogUsecase.ImportBlogs(c.Request.Context(), c.GetString("userID"), items)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	resp := ImportBlogsResponse{Results: make([]ImportBlogResult, len(results))}
+	for i, r := range results {
+		resp.Results[i] = toImportBlogResult(r)
+	}
+	resp.tally()
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ImportMarkdownBlogs lets admins upload markdown files with front-matter, sent as the "files"
+// fields of a multipart form. Files that fail to parse are reported alongside the import results
+// and don't stop the rest from being imported. Posts without an author_id are attributed to the admin.
+func (bc *BlogController) ImportMarkdownBlogs(c *gin.Context) {
+	if err := c.Request.ParseMultipartForm(maxMarkdownImportMemory); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+	files := c.Request.MultipartForm.File["files"]
+	if len(files) == 0 || len(files) > usecases.MaxBlogImportBatch {
+		abortInvalidBatchSize(c, "An import must contain between 1 and "+strconv.Itoa(usecases.MaxBlogImportBatch)+" files")
+		return
+	}
+
+	actorID := c.GetString("userID")
+	resp := ImportBlogsResponse{Results: make([]ImportBlogResult, len(files))}
+	var items []domain.BlogImportItem
+	var itemIndexes []int
+	for i, fh := range files {
+		resp.Results[i] = ImportBlogResult{Index: i, File: fh.Filename}
+		item, err := readMarkdownBlog(fh)
+		if err != nil {
+			resp.Results[i].Error = err.Error()
+			continue
+		}
+		if item.AuthorID == "" {
+			item.AuthorID = actorID
+		}
+		items = append(items, item)
+		itemIndexes = append(itemIndexes, i)
+	}
+
+	if len(items) > 0 {
+		results, err := bc.blogUsecase.ImportBlogs(c.Request.Context(), actorID, items)
+		if err != nil {
+			HandleError(c, err)
+			return
+		}
+		for _, r := range results {
+			i := itemIndexes[r.Index]
+			result := toImportBlogResult(r)
+			result.Index, result.File = i, files[i].Filename
+			resp.Results[i] = result
+		}
+	}
+	resp.tally()
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func toImportBlogResult(r domain.BlogImportResult) ImportBlogResult {
+	result := ImportBlogResult{Index: r.Index, ID: r.BlogID}
+	switch {
+	case r.Err == nil:
+	case errors.Is(r.Err, domain.ErrValidation):
+		result.Error = "title, content and author_id are required"
+	default:
+		result.Error = r.Err.Error()
+	}
+	return result
+}
+
+// tally fills in the imported and failed totals from the results.
+func (r *ImportBlogsResponse) tally() {
+	for _, result := range r.Results {
+		if result.Error == "" {
+			r.Imported++
+		} else {
+			r.Failed++
+		}
+	}
+}
+
+// MergeTags folds one tag into another across all blogs, e.g. "go-lang" into "golang".
+func (bc *BlogController) MergeTags(c *gin.Context) {
+	bc.retag(c, bc.blogUsecase.MergeTags)
+}
+
+// RenameTag renames a tag across all blogs. The new name must not be in use yet; use MergeTags for that.
+func (bc *BlogController) RenameTag(c *gin.Context) {
+	bc.retag(c, bc.blogUsecase.RenameTag)
+}
+
+func (bc *BlogController) retag(c *gin.Context, apply func(ctx context.Context, actorID, from, to string) (int, error)) {
+	var req RetagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+
+	updated, err := apply(c.Request.Context(), c.GetString("userID"), req.From, req.To)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, RetagResponse{From: strings.TrimSpace(req.From), To: strings.TrimSpace(req.To), Updated: updated})
 }
 
 // ===========================================
 // HELPERS
 // ===========================================
 
+// parseSortBy reads the sortBy query parameter and checks it against an allowlist.
+// An absent parameter is valid and leaves the choice of default to the repository.
+func parseSortBy(c *gin.Context, allowed []string) (string, bool) {
+	sortBy := c.Query("sortBy")
+	if sortBy == "" || slices.Contains(allowed, sortBy) {
+		return sortBy, true
+	}
+	return "", false
+}
+
+func invalidSortByMessage(allowed []string) string {
+	return fmt.Sprintf("Invalid 'sortBy' parameter. Must be one of: %s", strings.Join(allowed, ", "))
+}
+
+// HandleError maps an error to its HTTP status and responds with a stable code and a
+// message in the language requested by the Accept-Language header.
 func HandleError(c *gin.Context, err error) {
-	switch {
-	// --- 400 Bad Request ---
-	// Catch specific validation errors first
-	case errors.Is(err, domain.ErrPasswordTooShort),
-		errors.Is(err, domain.ErrInvalidEmailFormat),
-		errors.Is(err, domain.ErrInvalidRole),
-		errors.Is(err, domain.ErrUsernameEmpty),
-		errors.Is(err, domain.ErrUsernameTooLong):
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-
-	// Catch generic validation error
-	case errors.Is(err, domain.ErrValidation):
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input provided"})
-
-	// --- 401 Unauthorized ---
-	case errors.Is(err, domain.ErrAuthenticationFailed),
-		errors.Is(err, domain.ErrInvalidActivationToken),
-		errors.Is(err, domain.ErrInvalidResetToken):
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-
-	// --- 403 Forbidden ---
-	case errors.Is(err, domain.ErrPermissionDenied),
-		errors.Is(err, domain.ErrCannotChangeOwnRole), // Specific forbidden action
-		errors.Is(err, domain.ErrOAuthUser),           // Specific forbidden action
-		errors.Is(err, domain.ErrAccountNotActive):
-		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-
-	// --- 404 Not Found ---
-	case errors.Is(err, domain.ErrUserNotFound),
-		errors.Is(err, usecases.ErrNotFound):
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-	// Add other "not found" errors here if you have them (e.g., domain.ErrBlogNotFound)
-
-	// --- 409 Conflict ---
-	case errors.Is(err, domain.ErrEmailExists),
-		errors.Is(err, domain.ErrUsernameExists),
-		errors.Is(err, usecases.ErrConflict):
-		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	status, code := errorStatusAndCode(err)
+	c.JSON(status, gin.H{"error": localize(c, code), "code": code})
+}
 
-	// --- 500 Internal Server Error (Default) ---
-	default:
-		log.Printf("Internal Server Error: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "An unexpected internal error occurred. Please try again later."})
+// errorStatusAndCode looks err up in errorMappings. Unknown errors are logged and reported as
+// internal errors.
+func errorStatusAndCode(err error) (int, string) {
+	for _, mapping := range errorMappings {
+		if errors.Is(err, mapping.err) {
+			return mapping.status, mapping.code
+		}
 	}
+
+	// --- 500 Internal Server Error (Default) ---
+	log.Printf("Internal Server Error: %v", err)
+	return http.StatusInternalServerError, CodeInternalError
 }
 
 func toBlogResponse(b *domain.Blog) BlogResponse {
 	return BlogResponse{
-		ID:            b.ID,
-		Title:         b.Title,
-		Content:       b.Content,
-		AuthorID:      b.AuthorID,
-		Tags:          b.Tags,
-		Views:         b.Views,
-		Likes:         b.Likes,
-		Dislikes:      b.Dislikes,
-		CommentsCount: b.CommentsCount,
-		CreatedAt:     b.CreatedAt,
-		UpdatedAt:     b.UpdatedAt,
+		ID:              b.ID,
+		Title:           b.Title,
+		Content:         b.Content,
+		ContentFormat:   string(b.ContentFormat),
+		WordCount:       b.WordCount,
+		AuthorID:        b.AuthorID,
+		Tags:            b.Tags,
+		Views:           b.Views,
+		Likes:           b.Likes,
+		Dislikes:        b.Dislikes,
+		CommentsCount:   b.CommentsCount,
+		EngagementScore: b.EngagementScore,
+		PopularityScore: b.PopularityScore(time.Now()),
+		Status:          string(b.Status),
+		ScheduledFor:    b.ScheduledFor,
+		PublishedAt:     b.PublishedAt,
+		PinnedByAuthor:  b.PinnedByAuthor,
+		LastActivityAt:  b.LastActivityAt,
+		CreatedAt:       b.CreatedAt,
+		UpdatedAt:       b.UpdatedAt,
+	}
+}
+
+func toTOCResponse(entries []domain.TOCEntry) []TOCEntryResponse {
+	if len(entries) == 0 {
+		return nil
+	}
+	toc := make([]TOCEntryResponse, len(entries))
+	for i, entry := range entries {
+		toc[i] = TOCEntryResponse{Level: entry.Level, Text: entry.Text, Anchor: entry.Anchor}
+	}
+	return toc
+}
+
+func toBlogRevisionResponse(r *domain.BlogRevision) BlogRevisionResponse {
+	return BlogRevisionResponse{
+		ID:        r.ID,
+		BlogID:    r.BlogID,
+		Title:     r.Title,
+		Content:   r.Content,
+		Tags:      r.Tags,
+		EditorID:  r.EditorID,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// userRoleFromContext reads the role the auth middleware stored on the request.
+func userRoleFromContext(c *gin.Context) domain.Role {
+	if value, exists := c.Get("userRole"); exists {
+		if role, ok := value.(domain.Role); ok {
+			return role
+		}
 	}
+	return ""
 }
 
 func toPaginatedBlogResponse(blogs []*domain.Blog, total, page, limit int64) PaginatedBlogResponse {
@@ -3,9 +3,12 @@ package controllers_test
 import (
 	"A2SV_Starter_Project_Blog/Delivery/controllers"
 	domain "A2SV_Starter_Project_Blog/Domain"
+	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
 	usecases "A2SV_Starter_Project_Blog/Usecases"
 	"bytes"
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -24,8 +27,8 @@ type MockBlogUsecase struct {
 	mock.Mock
 }
 
-func (m *MockBlogUsecase) Create(ctx context.Context, title, content, authorID string, tags []string) (*domain.Blog, error) {
-	args := m.Called(ctx, title, content, authorID, tags)
+func (m *MockBlogUsecase) Create(ctx context.Context, title, content, authorID string, tags []string, scheduledFor *time.Time, format domain.ContentFormat) (*domain.Blog, error) {
+	args := m.Called(ctx, title, content, authorID, tags, scheduledFor, format)
 	var blog *domain.Blog
 	if args.Get(0) != nil {
 		blog = args.Get(0).(*domain.Blog)
@@ -42,8 +45,8 @@ func (m *MockBlogUsecase) SearchAndFilter(ctx context.Context, options domain.Bl
 	return blogs, args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockBlogUsecase) GetByID(ctx context.Context, id string) (*domain.Blog, error) {
-	args := m.Called(ctx, id)
+func (m *MockBlogUsecase) GetByID(ctx context.Context, id, viewerID string, viewerRole domain.Role) (*domain.Blog, error) {
+	args := m.Called(ctx, id, viewerID, viewerRole)
 	var blog *domain.Blog
 	if args.Get(0) != nil {
 		blog = args.Get(0).(*domain.Blog)
@@ -65,11 +68,214 @@ func (m *MockBlogUsecase) Delete(ctx context.Context, blogID, userID string, use
 	return args.Error(0)
 }
 
-func (m *MockBlogUsecase) InteractWithBlog(ctx context.Context, blogID, userID string, action domain.ActionType) error {
+func (m *MockBlogUsecase) GetByIDs(ctx context.Context, ids []string, viewerID string, viewerRole domain.Role) ([]*domain.Blog, error) {
+	args := m.Called(ctx, ids, viewerID, viewerRole)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Blog), args.Error(1)
+}
+
+func (m *MockBlogUsecase) InteractWithBlog(ctx context.Context, blogID, userID string, action domain.ActionType) (*domain.InteractionResult, error) {
 	args := m.Called(ctx, blogID, userID, action)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InteractionResult), args.Error(1)
+}
+
+func (m *MockBlogUsecase) GetRandom(ctx context.Context, tag string) (*domain.Blog, error) {
+	args := m.Called(ctx, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Blog), args.Error(1)
+}
+
+func (m *MockBlogUsecase) SuggestTitles(ctx context.Context, query string, limit int) ([]*domain.BlogTitleSuggestion, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.BlogTitleSuggestion), args.Error(1)
+}
+
+func (m *MockBlogUsecase) ListTags(ctx context.Context, page, limit int64, sort domain.TagSort) (*domain.TagPage, error) {
+	args := m.Called(ctx, page, limit, sort)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TagPage), args.Error(1)
+}
+
+func (m *MockBlogUsecase) ExportBlog(ctx context.Context, blogID, userID string, userRole domain.Role) (*domain.BlogExport, error) {
+	args := m.Called(ctx, blogID, userID, userRole)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BlogExport), args.Error(1)
+}
+
+func (m *MockBlogUsecase) ImportBlogs(ctx context.Context, actorID string, items []domain.BlogImportItem) ([]domain.BlogImportResult, error) {
+	args := m.Called(ctx, actorID, items)
+	var results []domain.BlogImportResult
+	if args.Get(0) != nil {
+		results = args.Get(0).([]domain.BlogImportResult)
+	}
+	return results, args.Error(1)
+}
+
+func (m *MockBlogUsecase) ListRevisions(ctx context.Context, blogID, userID string, userRole domain.Role) ([]*domain.BlogRevision, error) {
+	args := m.Called(ctx, blogID, userID, userRole)
+	var revisions []*domain.BlogRevision
+	if args.Get(0) != nil {
+		revisions = args.Get(0).([]*domain.BlogRevision)
+	}
+	return revisions, args.Error(1)
+}
+
+func (m *MockBlogUsecase) GetRevision(ctx context.Context, blogID, revisionID, userID string, userRole domain.Role) (*domain.BlogRevision, error) {
+	args := m.Called(ctx, blogID, revisionID, userID, userRole)
+	var revision *domain.BlogRevision
+	if args.Get(0) != nil {
+		revision = args.Get(0).(*domain.BlogRevision)
+	}
+	return revision, args.Error(1)
+}
+
+func (m *MockBlogUsecase) RestoreRevision(ctx context.Context, blogID, revisionID, userID string, userRole domain.Role) (*domain.Blog, error) {
+	args := m.Called(ctx, blogID, revisionID, userID, userRole)
+	var blog *domain.Blog
+	if args.Get(0) != nil {
+		blog = args.Get(0).(*domain.Blog)
+	}
+	return blog, args.Error(1)
+}
+
+func (m *MockBlogUsecase) GetViewerAction(ctx context.Context, blogID, userID string) (domain.ActionType, error) {
+	args := m.Called(ctx, blogID, userID)
+	return args.Get(0).(domain.ActionType), args.Error(1)
+}
+
+func (m *MockBlogUsecase) PublishDueBlogs(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBlogUsecase) ListByAuthor(ctx context.Context, authorID string, page, limit int64) ([]*domain.Blog, int64, error) {
+	args := m.Called(ctx, authorID, page, limit)
+	var blogs []*domain.Blog
+	if args.Get(0) != nil {
+		blogs = args.Get(0).([]*domain.Blog)
+	}
+	return blogs, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBlogUsecase) GetFollowingFeed(ctx context.Context, userID string, page, limit int64) ([]*domain.Blog, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	var blogs []*domain.Blog
+	if args.Get(0) != nil {
+		blogs = args.Get(0).([]*domain.Blog)
+	}
+	return blogs, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBlogUsecase) VerifyCommentCount(ctx context.Context, blogID string) (*domain.CommentCountCheck, error) {
+	args := m.Called(ctx, blogID)
+	var check *domain.CommentCountCheck
+	if args.Get(0) != nil {
+		check = args.Get(0).(*domain.CommentCountCheck)
+	}
+	return check, args.Error(1)
+}
+
+func (m *MockBlogUsecase) SetPinned(ctx context.Context, blogID, userID string, pinned bool) (*domain.Blog, error) {
+	args := m.Called(ctx, blogID, userID, pinned)
+	var blog *domain.Blog
+	if args.Get(0) != nil {
+		blog = args.Get(0).(*domain.Blog)
+	}
+	return blog, args.Error(1)
+}
+
+func (m *MockBlogUsecase) GetBlogDetail(ctx context.Context, blogID, viewerID string, viewerRole domain.Role) (*domain.BlogDetail, error) {
+	args := m.Called(ctx, blogID, viewerID, viewerRole)
+	var detail *domain.BlogDetail
+	if args.Get(0) != nil {
+		detail = args.Get(0).(*domain.BlogDetail)
+	}
+	return detail, args.Error(1)
+}
+
+func (m *MockBlogUsecase) CloneBlog(ctx context.Context, blogID, userID string, userRole domain.Role) (*domain.Blog, error) {
+	args := m.Called(ctx, blogID, userID, userRole)
+	var blog *domain.Blog
+	if args.Get(0) != nil {
+		blog = args.Get(0).(*domain.Blog)
+	}
+	return blog, args.Error(1)
+}
+
+func (m *MockBlogUsecase) PublishDraft(ctx context.Context, blogID, userID string, userRole domain.Role) (*domain.Blog, error) {
+	args := m.Called(ctx, blogID, userID, userRole)
+	var blog *domain.Blog
+	if args.Get(0) != nil {
+		blog = args.Get(0).(*domain.Blog)
+	}
+	return blog, args.Error(1)
+}
+
+func (m *MockBlogUsecase) MarkRead(ctx context.Context, blogID, userID string) error {
+	args := m.Called(ctx, blogID, userID)
 	return args.Error(0)
 }
 
+func (m *MockBlogUsecase) ListRead(ctx context.Context, userID string, page, limit int64) ([]*domain.BlogRead, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	var reads []*domain.BlogRead
+	if args.Get(0) != nil {
+		reads = args.Get(0).([]*domain.BlogRead)
+	}
+	return reads, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBlogUsecase) ListInteractions(ctx context.Context, userID string, page, limit int64) ([]*domain.InteractionHistoryEntry, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	var entries []*domain.InteractionHistoryEntry
+	if args.Get(0) != nil {
+		entries = args.Get(0).([]*domain.InteractionHistoryEntry)
+	}
+	return entries, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBlogUsecase) ListBlogInteractions(ctx context.Context, blogID, userID string, userRole domain.Role, page, limit int64) ([]*domain.BlogInteractionEntry, int64, error) {
+	args := m.Called(ctx, blogID, userID, userRole, page, limit)
+	var entries []*domain.BlogInteractionEntry
+	if args.Get(0) != nil {
+		entries = args.Get(0).([]*domain.BlogInteractionEntry)
+	}
+	return entries, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBlogUsecase) MergeTags(ctx context.Context, actorID, from, to string) (int, error) {
+	args := m.Called(ctx, actorID, from, to)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBlogUsecase) RenameTag(ctx context.Context, actorID, from, to string) (int, error) {
+	args := m.Called(ctx, actorID, from, to)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockBlogUsecase) ReadFlags(ctx context.Context, userID string, blogIDs []string) (map[string]bool, error) {
+	args := m.Called(ctx, userID, blogIDs)
+	var read map[string]bool
+	if args.Get(0) != nil {
+		read = args.Get(0).(map[string]bool)
+	}
+	return read, args.Error(1)
+}
+
 // --- Blog ControllerTest Suite Setup ---
 
 type BlogControllerTestSuite struct {
@@ -98,7 +304,7 @@ func (s *BlogControllerTestSuite) TestCreate() {
 
 		mockBlog, _ := domain.NewBlog("Test Title", "Test Content", "user-123", nil)
 		mockBlog.ID = "new-blog-id"
-		mockUsecase.On("Create", mock.Anything, "Test Title", "Test Content", "user-123", mock.Anything).Return(mockBlog, nil).Once()
+		mockUsecase.On("Create", mock.Anything, "Test Title", "Test Content", "user-123", mock.Anything, mock.Anything, domain.ContentFormat("")).Return(mockBlog, nil).Once()
 
 		reqBody := controllers.CreateBlogRequest{Title: "Test Title", Content: "Test Content"}
 		body, _ := json.Marshal(reqBody)
@@ -153,7 +359,7 @@ func (s *BlogControllerTestSuite) TestGetByID() {
 
 		mockBlog, _ := domain.NewBlog("Found Title", "Found Content", "author-id", nil)
 		mockBlog.ID = "found-id"
-		mockUsecase.On("GetByID", mock.Anything, "found-id").Return(mockBlog, nil).Once()
+		mockUsecase.On("GetByID", mock.Anything, "found-id", mock.Anything, mock.Anything).Return(mockBlog, nil).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/blogs/found-id", nil)
 		w := httptest.NewRecorder()
@@ -163,9 +369,38 @@ func (s *BlogControllerTestSuite) TestGetByID() {
 
 		// Assert
 		s.Equal(http.StatusOK, w.Code)
+		s.NotContains(w.Body.String(), "table_of_contents", "content without headings has no table of contents")
 		mockUsecase.AssertExpectations(s.T())
 	})
 
+	s.Run("Success_WithTableOfContents", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID", controller.GetByID)
+
+		mockBlog, _ := domain.NewBlog("Guide", "# Intro\ntext\n## Setup\n## Setup\n", "author-id", nil)
+		mockBlog.ID = "guide-id"
+		mockUsecase.On("GetByID", mock.Anything, "guide-id", mock.Anything, mock.Anything).Return(mockBlog, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/blogs/guide-id", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.BlogResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		s.Equal([]controllers.TOCEntryResponse{
+			{Level: 1, Text: "Intro", Anchor: "intro"},
+			{Level: 2, Text: "Setup", Anchor: "setup"},
+			{Level: 2, Text: "Setup", Anchor: "setup-1"},
+		}, resp.TableOfContents)
+	})
+
 	s.Run("Failure_NotFound", func() {
 		// Arrange
 		mockUsecase := new(MockBlogUsecase)
@@ -173,7 +408,7 @@ func (s *BlogControllerTestSuite) TestGetByID() {
 		router := gin.New()
 		router.GET("/blogs/:blogID", controller.GetByID)
 
-		mockUsecase.On("GetByID", mock.Anything, "not-found-id").Return(nil, usecases.ErrNotFound).Once()
+		mockUsecase.On("GetByID", mock.Anything, "not-found-id", mock.Anything, mock.Anything).Return(nil, usecases.ErrNotFound).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/blogs/not-found-id", nil)
 		w := httptest.NewRecorder()
@@ -187,8 +422,139 @@ func (s *BlogControllerTestSuite) TestGetByID() {
 	})
 }
 
+func (s *BlogControllerTestSuite) TestBlogResponse_RankingScores() {
+	// Arrange
+	mockUsecase := new(MockBlogUsecase)
+	controller := controllers.NewBlogController(mockUsecase)
+	router := gin.New()
+	router.GET("/blogs/:blogID", controller.GetByID)
+
+	mockBlog, _ := domain.NewBlog("Title", "Content", "author-id", nil)
+	mockBlog.ID = "blog-1"
+	mockBlog.EngagementScore = 420
+	mockBlog.CreatedAt = time.Now().Add(-48 * time.Hour)
+	mockUsecase.On("GetByID", mock.Anything, "blog-1", mock.Anything, mock.Anything).Return(mockBlog, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/blogs/blog-1", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	s.Equal(http.StatusOK, w.Code)
+	var resp controllers.BlogResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	s.Equal(420.0, resp.EngagementScore)
+	// The blog ages a little while the request is served, so allow for it.
+	s.InDelta(420/math.Pow(50, domain.PopularityGravity), resp.PopularityScore, 1e-6)
+}
+
+func (s *BlogControllerTestSuite) TestGetByID_OptionalAuth() {
+	jwtService := infrastructure.NewJWTService("test-secret", "test-issuer", time.Minute, time.Hour)
+	setupRouter := func(mockUsecase *MockBlogUsecase) *gin.Engine {
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID", infrastructure.OptionalAuth(jwtService), controller.GetByID)
+		return router
+	}
+	newBlog := func() *domain.Blog {
+		blog, _ := domain.NewBlog("Title", "Content", "author-id", nil)
+		blog.ID = "blog-1"
+		return blog
+	}
+
+	s.Run("Anonymous", func() {
+		mockUsecase := new(MockBlogUsecase)
+		router := setupRouter(mockUsecase)
+		mockUsecase.On("GetByID", mock.Anything, "blog-1", "", domain.Role("")).Return(newBlog(), nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/blogs/blog-1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.NotContains(w.Body.String(), "viewer_action")
+		mockUsecase.AssertNotCalled(s.T(), "GetViewerAction", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Authenticated", func() {
+		mockUsecase := new(MockBlogUsecase)
+		router := setupRouter(mockUsecase)
+		mockUsecase.On("GetByID", mock.Anything, "blog-1", "viewer-id", domain.RoleUser).Return(newBlog(), nil).Once()
+		mockUsecase.On("GetViewerAction", mock.Anything, "blog-1", "viewer-id").Return(domain.ActionTypeLike, nil).Once()
+		token, _, err := jwtService.GenerateAccessToken("viewer-id", domain.RoleUser)
+		s.Require().NoError(err)
+
+		req := httptest.NewRequest(http.MethodGet, "/blogs/blog-1", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusOK, w.Code)
+		var response controllers.BlogResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+		s.Equal("like", response.ViewerAction)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Invalid token is treated as anonymous", func() {
+		mockUsecase := new(MockBlogUsecase)
+		router := setupRouter(mockUsecase)
+		mockUsecase.On("GetByID", mock.Anything, "blog-1", mock.Anything, mock.Anything).Return(newBlog(), nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/blogs/blog-1", nil)
+		req.Header.Set("Authorization", "Bearer expired-or-forged")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusOK, w.Code)
+		mockUsecase.AssertNotCalled(s.T(), "GetViewerAction", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Personalization failure still returns the blog", func() {
+		mockUsecase := new(MockBlogUsecase)
+		router := setupRouter(mockUsecase)
+		mockUsecase.On("GetByID", mock.Anything, "blog-1", mock.Anything, mock.Anything).Return(newBlog(), nil).Once()
+		mockUsecase.On("GetViewerAction", mock.Anything, "blog-1", "viewer-id").Return(domain.ActionType(""), errors.New("db down")).Once()
+		token, _, _ := jwtService.GenerateAccessToken("viewer-id", domain.RoleUser)
+
+		req := httptest.NewRequest(http.MethodGet, "/blogs/blog-1", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.NotContains(w.Body.String(), "viewer_action")
+	})
+
+	s.Run("Draft is not found by anonymous or other users", func() {
+		mockUsecase := new(MockBlogUsecase)
+		router := setupRouter(mockUsecase)
+		// The usecase decides visibility from who is asking, so both must reach it.
+		mockUsecase.On("GetByID", mock.Anything, "draft-1", "", domain.Role("")).Return(nil, usecases.ErrNotFound).Once()
+		mockUsecase.On("GetByID", mock.Anything, "draft-1", "other-id", domain.RoleUser).Return(nil, usecases.ErrNotFound).Once()
+		token, _, err := jwtService.GenerateAccessToken("other-id", domain.RoleUser)
+		s.Require().NoError(err)
+
+		anonymous := httptest.NewRequest(http.MethodGet, "/blogs/draft-1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, anonymous)
+		s.Equal(http.StatusNotFound, w.Code)
+
+		otherUser := httptest.NewRequest(http.MethodGet, "/blogs/draft-1", nil)
+		otherUser.Header.Set("Authorization", "Bearer "+token)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, otherUser)
+		s.Equal(http.StatusNotFound, w.Code)
+
+		mockUsecase.AssertExpectations(s.T())
+		mockUsecase.AssertNotCalled(s.T(), "GetViewerAction", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 func (s *BlogControllerTestSuite) TestDelete() {
-	authMiddleware := func(c *gin.Context) { c.Set("userID", "user-123"); c.Set("role", string(domain.RoleUser)); c.Next() }
+	authMiddleware := func(c *gin.Context) { c.Set("userID", "user-123"); c.Set("userRole", domain.RoleUser); c.Next() }
 
 	s.Run("Success", func() {
 		// Arrange
@@ -229,13 +595,71 @@ func (s *BlogControllerTestSuite) TestDelete() {
 		s.Equal(http.StatusForbidden, w.Code)
 		mockUsecase.AssertExpectations(s.T())
 	})
+
+	s.Run("Admin_DeletingAnotherUsersBlog_IsAudited", func() {
+		// Arrange: the real usecase, behind the real middleware, so the role travels as it does in production.
+		jwtService := infrastructure.NewJWTService("test-secret", "test-issuer", time.Minute, time.Hour)
+		blog, _ := domain.NewBlog("Spam", "Content", "author-id", nil)
+		blog.ID = "blog-to-delete"
+		blogRepo := &deletableBlogRepository{blog: blog}
+		auditRepo := &recordingAuditRepository{}
+		usecase := usecases.NewBlogUsecase(blogRepo, nil, nil, nil, nil, nil, time.Second, usecases.WithBlogAuditLog(auditRepo))
+		router := gin.New()
+		router.DELETE("/blogs/:blogID", infrastructure.AuthMiddleware(jwtService), controllers.NewBlogController(usecase).Delete)
+		token, _, err := jwtService.GenerateAccessToken("admin-id", domain.RoleAdmin)
+		s.Require().NoError(err)
+
+		req := httptest.NewRequest(http.MethodDelete, "/blogs/blog-to-delete", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusNoContent, w.Code)
+		s.Equal([]string{"blog-to-delete"}, blogRepo.deleted)
+		s.Require().Len(auditRepo.entries, 1)
+		s.Equal("admin-id", auditRepo.entries[0].ActorID)
+		s.Equal(domain.AuditActionBlogDelete, auditRepo.entries[0].Action)
+		s.Equal("blog-to-delete", auditRepo.entries[0].TargetID)
+	})
+}
+
+// deletableBlogRepository serves one blog and records deletions. Other methods are not needed.
+type deletableBlogRepository struct {
+	domain.IBlogRepository
+	blog    *domain.Blog
+	deleted []string
+}
+
+func (r *deletableBlogRepository) GetByID(ctx context.Context, id string) (*domain.Blog, error) {
+	if id != r.blog.ID {
+		return nil, usecases.ErrNotFound
+	}
+	return r.blog, nil
+}
+
+func (r *deletableBlogRepository) Delete(ctx context.Context, id string) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+type recordingAuditRepository struct {
+	domain.IAuditRepository
+	entries []*domain.AuditEntry
+}
+
+func (r *recordingAuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
 }
 
 func (s *BlogControllerTestSuite) TestUpdate() {
 	// Middleware to simulate an authenticated user making the request
 	authMiddleware := func(c *gin.Context) {
 		c.Set("userID", "user-123")
-		c.Set("role", string(domain.RoleUser))
+		c.Set("userRole", domain.RoleUser)
 		c.Next()
 	}
 
@@ -380,6 +804,125 @@ func (s *BlogControllerTestSuite) TestSearchAndFilter() {
 		mockUsecase.AssertExpectations(s.T()) // This is the most important assertion
 	})
 
+	s.Run("Success_MinWordCount", func() {
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs", controller.SearchAndFilter)
+
+		mockUsecase.On("SearchAndFilter", mock.Anything, mock.MatchedBy(func(opts domain.BlogSearchFilterOptions) bool {
+			return opts.MinWordCount == 1000
+		})).Return([]*domain.Blog{}, int64(0), nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/blogs?minWordCount=1000", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusOK, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_InvalidMinWordCount", func() {
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs", controller.SearchAndFilter)
+
+		req := httptest.NewRequest(http.MethodGet, "/blogs?minWordCount=lots", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), `"code":"`+controllers.CodeInvalidQueryParameter+`"`)
+		s.Contains(w.Body.String(), "Invalid 'minWordCount' parameter")
+		mockUsecase.AssertNotCalled(s.T(), "SearchAndFilter", mock.Anything, mock.Anything)
+	})
+
+	s.Run("AuthorProvider", func() {
+		testCases := []struct {
+			name           string
+			role           domain.Role
+			query          string
+			expectedStatus int
+		}{
+			{name: "Admin", role: domain.RoleAdmin, query: "?authorProvider=google", expectedStatus: http.StatusOK},
+			{name: "Non-admin is forbidden", role: domain.RoleUser, query: "?authorProvider=google", expectedStatus: http.StatusForbidden},
+			{name: "Anonymous is forbidden", query: "?authorProvider=google", expectedStatus: http.StatusForbidden},
+			{name: "Unknown provider", role: domain.RoleAdmin, query: "?authorProvider=github", expectedStatus: http.StatusBadRequest},
+		}
+		for _, tc := range testCases {
+			s.Run(tc.name, func() {
+				mockUsecase := new(MockBlogUsecase)
+				controller := controllers.NewBlogController(mockUsecase)
+				router := gin.New()
+				router.GET("/blogs", func(c *gin.Context) {
+					if tc.role != "" {
+						c.Set("userRole", tc.role)
+					}
+				}, controller.SearchAndFilter)
+				if tc.expectedStatus == http.StatusOK {
+					mockUsecase.On("SearchAndFilter", mock.Anything, mock.MatchedBy(func(opts domain.BlogSearchFilterOptions) bool {
+						return opts.AuthorProvider != nil && *opts.AuthorProvider == domain.ProviderGoogle
+					})).Return([]*domain.Blog{}, int64(0), nil).Once()
+				}
+
+				req := httptest.NewRequest(http.MethodGet, "/blogs"+tc.query, nil)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				s.Equal(tc.expectedStatus, w.Code)
+				if tc.expectedStatus == http.StatusBadRequest {
+					s.Contains(w.Body.String(), `"code":"`+controllers.CodeInvalidQueryParameter+`"`)
+				}
+				if tc.expectedStatus == http.StatusOK {
+					mockUsecase.AssertExpectations(s.T())
+				} else {
+					mockUsecase.AssertNotCalled(s.T(), "SearchAndFilter", mock.Anything, mock.Anything)
+				}
+			})
+		}
+	})
+
+	s.Run("SortBy", func() {
+		testCases := []struct {
+			name           string
+			query          string
+			expectedSortBy string
+			expectedStatus int
+		}{
+			{name: "Allowed", query: "?sortBy=popularity", expectedSortBy: "popularity", expectedStatus: http.StatusOK},
+			{name: "Activity", query: "?sortBy=activity", expectedSortBy: "activity", expectedStatus: http.StatusOK},
+			{name: "Absent keeps the default", query: "", expectedSortBy: "", expectedStatus: http.StatusOK},
+			{name: "Unsupported", query: "?sortBy=views", expectedStatus: http.StatusBadRequest},
+		}
+		for _, tc := range testCases {
+			s.Run(tc.name, func() {
+				mockUsecase := new(MockBlogUsecase)
+				controller := controllers.NewBlogController(mockUsecase)
+				router := gin.New()
+				router.GET("/blogs", controller.SearchAndFilter)
+				if tc.expectedStatus == http.StatusOK {
+					mockUsecase.On("SearchAndFilter", mock.Anything, mock.MatchedBy(func(opts domain.BlogSearchFilterOptions) bool {
+						return opts.SortBy == tc.expectedSortBy
+					})).Return([]*domain.Blog{}, int64(0), nil).Once()
+				}
+
+				req := httptest.NewRequest(http.MethodGet, "/blogs"+tc.query, nil)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				s.Equal(tc.expectedStatus, w.Code)
+				if tc.expectedStatus == http.StatusBadRequest {
+					s.Contains(w.Body.String(), "Invalid 'sortBy' parameter")
+					s.Contains(w.Body.String(), `"code":"`+controllers.CodeInvalidQueryParameter+`"`)
+					mockUsecase.AssertNotCalled(s.T(), "SearchAndFilter", mock.Anything, mock.Anything)
+				} else {
+					mockUsecase.AssertExpectations(s.T())
+				}
+			})
+		}
+	})
+
 	s.Run("Failure_InvalidDateParameter", func() {
 		// This test ensures that if a parameter is badly formatted,
 		// the controller fails early and doesn't call the usecase.
@@ -403,41 +946,135 @@ func (s *BlogControllerTestSuite) TestSearchAndFilter() {
 	})
 }
 
-func (s *BlogControllerTestSuite) TestInteractWithBlog() {
-	// Middleware to simulate an authenticated user.
-	authMiddleware := func(c *gin.Context) {
-		c.Set("userID", "user-123")
-		c.Next()
-	}
-
-	s.Run("Success - Like action", func() {
-		// Arrange
+func (s *BlogControllerTestSuite) TestGetByIDs() {
+	setup := func() (*MockBlogUsecase, *gin.Engine) {
 		mockUsecase := new(MockBlogUsecase)
-		controller := controllers.NewBlogController(mockUsecase)
 		router := gin.New()
-		router.POST("/blogs/:blogID/interact", authMiddleware, controller.InteractWithBlog)
-
-		blogID := "blog-abc"
-		action := domain.ActionTypeLike
-
-		// Expect the usecase to be called with the correct parameters.
-		mockUsecase.On("InteractWithBlog", mock.Anything, blogID, "user-123", action).Return(nil).Once()
-
-		// Create the request body.
-		reqBody := controllers.InteractBlogRequest{Action: action}
-		body, _ := json.Marshal(reqBody)
-		req := httptest.NewRequest(http.MethodPost, "/blogs/"+blogID+"/interact", bytes.NewReader(body))
+		router.POST("/blogs/batch", controllers.NewBlogController(mockUsecase).GetByIDs)
+		return mockUsecase, router
+	}
+	post := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/blogs/batch", strings.NewReader(body))
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
-
-		// Act
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	s.Run("Success - Keeps the usecase's order and skips missing IDs", func() {
+		mockUsecase, router := setup()
+		ids := []string{"blog-2", "missing", "blog-1"}
+		mockUsecase.On("GetByIDs", mock.Anything, ids, "", domain.Role("")).Return([]*domain.Blog{
+			{ID: "blog-2", Title: "Second"},
+			{ID: "blog-1", Title: "First"},
+		}, nil).Once()
+
+		w := post(router, `{"ids": ["blog-2", "missing", "blog-1"]}`)
+
+		s.Equal(http.StatusOK, w.Code)
+		var resp struct {
+			Data []controllers.BlogResponse `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Require().Len(resp.Data, 2)
+		s.Equal("blog-2", resp.Data[0].ID)
+		s.Equal("blog-1", resp.Data[1].ID)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Success - Nothing found is an empty list", func() {
+		mockUsecase, router := setup()
+		mockUsecase.On("GetByIDs", mock.Anything, []string{"missing"}, "", domain.Role("")).Return([]*domain.Blog{}, nil).Once()
+
+		w := post(router, `{"ids": ["missing"]}`)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.JSONEq(`{"data": []}`, w.Body.String())
+	})
+
+	s.Run("Failure - Too many IDs", func() {
+		mockUsecase, router := setup()
+		ids := make([]string, usecases.MaxBlogBatchFetch+1)
+		for i := range ids {
+			ids[i] = "blog"
+		}
+		body, _ := json.Marshal(controllers.BlogBatchRequest{IDs: ids})
+
+		w := post(router, string(body))
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), `"code":"`+controllers.CodeInvalidBatchSize+`"`)
+		mockUsecase.AssertNotCalled(s.T(), "GetByIDs", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure - Empty or missing IDs", func() {
+		mockUsecase, router := setup()
+
+		s.Equal(http.StatusBadRequest, post(router, `{"ids": []}`).Code)
+		s.Equal(http.StatusBadRequest, post(router, `{}`).Code)
+		s.Contains(post(router, `{"ids": "blog-1"}`).Body.String(), `"code":"`+controllers.CodeInvalidRequestBody+`"`)
+		mockUsecase.AssertNotCalled(s.T(), "GetByIDs", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogControllerTestSuite) TestInteractWithBlog() {
+	// Middleware to simulate an authenticated user.
+	authMiddleware := func(c *gin.Context) {
+		c.Set("userID", "user-123")
+		c.Next()
+	}
+
+	s.Run("Success - Like action", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/interact", authMiddleware, controller.InteractWithBlog)
+
+		blogID := "blog-abc"
+		action := domain.ActionTypeLike
+
+		// Expect the usecase to be called with the correct parameters.
+		mockUsecase.On("InteractWithBlog", mock.Anything, blogID, "user-123", action).Return(&domain.InteractionResult{Likes: 5, Dislikes: 2, Action: action}, nil).Once()
+
+		// Create the request body.
+		reqBody := controllers.InteractBlogRequest{Action: action}
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/blogs/"+blogID+"/interact", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
 		router.ServeHTTP(w, req)
 
 		// Assert
 		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.InteractionResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal(controllers.InteractionResponse{Likes: 5, Dislikes: 2, Action: "like"}, resp)
 		mockUsecase.AssertExpectations(s.T())
 	})
 
+	s.Run("Success - Undo omits the action", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/interact", authMiddleware, controller.InteractWithBlog)
+		mockUsecase.On("InteractWithBlog", mock.Anything, "blog-abc", "user-123", domain.ActionTypeLike).Return(&domain.InteractionResult{Likes: 4, Dislikes: 2}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/blogs/blog-abc/interact", strings.NewReader(`{"action": "like"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		s.JSONEq(`{"likes": 4, "dislikes": 2}`, w.Body.String())
+	})
+
 	s.Run("Failure - Invalid action in body", func() {
 		// Arrange
 		mockUsecase := new(MockBlogUsecase)
@@ -471,7 +1108,7 @@ func (s *BlogControllerTestSuite) TestInteractWithBlog() {
 		action := domain.ActionTypeLike
 
 		// Expect the usecase to be called and to return an error.
-		mockUsecase.On("InteractWithBlog", mock.Anything, blogID, "user-123", action).Return(usecases.ErrNotFound).Once()
+		mockUsecase.On("InteractWithBlog", mock.Anything, blogID, "user-123", action).Return(nil, usecases.ErrNotFound).Once()
 
 		reqBody := controllers.InteractBlogRequest{Action: action}
 		body, _ := json.Marshal(reqBody)
@@ -488,3 +1125,1182 @@ func (s *BlogControllerTestSuite) TestInteractWithBlog() {
 	})
 }
 
+
+func (s *BlogControllerTestSuite) TestInteractWithBlogs() {
+	authMiddleware := func(c *gin.Context) {
+		c.Set("userID", "user-123")
+		c.Next()
+	}
+	const blogA, blogB, missing = "64b7f0c2a1b2c3d4e5f60001", "64b7f0c2a1b2c3d4e5f60002", "64b7f0c2a1b2c3d4e5f60003"
+
+	s.Run("Success - Mixed Valid And Invalid Items", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/interact/batch", authMiddleware, controller.InteractWithBlogs)
+
+		// The second like on blogA undoes the first, as it would sent on its own.
+		mockUsecase.On("InteractWithBlog", mock.Anything, blogA, "user-123", domain.ActionTypeLike).Return(&domain.InteractionResult{Likes: 3, Dislikes: 0, Action: domain.ActionTypeLike}, nil).Once()
+		mockUsecase.On("InteractWithBlog", mock.Anything, blogB, "user-123", domain.ActionTypeDislike).Return(&domain.InteractionResult{Likes: 1, Dislikes: 4, Action: domain.ActionTypeDislike}, nil).Once()
+		mockUsecase.On("InteractWithBlog", mock.Anything, missing, "user-123", domain.ActionTypeLike).Return(nil, usecases.ErrNotFound).Once()
+		mockUsecase.On("InteractWithBlog", mock.Anything, blogA, "user-123", domain.ActionTypeLike).Return(&domain.InteractionResult{Likes: 2, Dislikes: 0}, nil).Once()
+
+		body := `[
+			{"blog_id": "` + blogA + `", "action": "like"},
+			{"blog_id": "` + blogB + `", "action": "dislike"},
+			{"blog_id": "not-an-id", "action": "like"},
+			{"blog_id": "` + blogB + `", "action": "love"},
+			{"blog_id": "` + missing + `", "action": "like"},
+			{"blog_id": "` + blogA + `", "action": "like"}
+		]`
+		req := httptest.NewRequest(http.MethodPost, "/blogs/interact/batch", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		s.JSONEq(`{
+			"succeeded": 3,
+			"failed": 3,
+			"results": [
+				{"index": 0, "blog_id": "`+blogA+`", "likes": 3, "dislikes": 0, "action": "like"},
+				{"index": 1, "blog_id": "`+blogB+`", "likes": 1, "dislikes": 4, "action": "dislike"},
+				{"index": 2, "blog_id": "not-an-id", "error": "not found", "code": "NOT_FOUND"},
+				{"index": 3, "blog_id": "`+blogB+`", "error": "Invalid input provided", "code": "VALIDATION_FAILED"},
+				{"index": 4, "blog_id": "`+missing+`", "error": "not found", "code": "NOT_FOUND"},
+				{"index": 5, "blog_id": "`+blogA+`", "likes": 2, "dislikes": 0}
+			]
+		}`, w.Body.String())
+		mockUsecase.AssertExpectations(s.T())
+		mockUsecase.AssertNumberOfCalls(s.T(), "InteractWithBlog", 4)
+	})
+
+	s.Run("Failure - Batch Too Large", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/interact/batch", authMiddleware, controller.InteractWithBlogs)
+
+		items := make([]controllers.InteractBatchItem, usecases.MaxInteractionBatch+1)
+		for i := range items {
+			items[i] = controllers.InteractBatchItem{BlogID: blogA, Action: domain.ActionTypeLike}
+		}
+		body, _ := json.Marshal(items)
+		req := httptest.NewRequest(http.MethodPost, "/blogs/interact/batch", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), `"code":"`+controllers.CodeInvalidBatchSize+`"`)
+		mockUsecase.AssertNotCalled(s.T(), "InteractWithBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure - Empty Batch Or Not An Array", func() {
+		expectedCodes := map[string]string{
+			`[]`: controllers.CodeInvalidBatchSize,
+			`{"blog_id": "` + blogA + `", "action": "like"}`: controllers.CodeInvalidRequestBody,
+		}
+		for body, code := range expectedCodes {
+			// Arrange
+			mockUsecase := new(MockBlogUsecase)
+			controller := controllers.NewBlogController(mockUsecase)
+			router := gin.New()
+			router.POST("/blogs/interact/batch", authMiddleware, controller.InteractWithBlogs)
+
+			req := httptest.NewRequest(http.MethodPost, "/blogs/interact/batch", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			s.Equal(http.StatusBadRequest, w.Code, body)
+			s.Contains(w.Body.String(), `"code":"`+code+`"`, body)
+		}
+	})
+}
+
+
+func (s *BlogControllerTestSuite) TestImportBlogs() {
+	s.Run("Success_MixedResults", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/admin/blogs/import", func(c *gin.Context) { c.Set("userID", "admin-1") }, controller.ImportBlogs)
+
+		createdAt, _ := time.Parse(time.RFC3339, "2021-05-01T12:00:00Z")
+		expectedItems := []domain.BlogImportItem{
+			{Title: "Old Post", Content: "Body", AuthorID: "author-1", Tags: []string{"go"}, CreatedAt: &createdAt},
+			{Title: "", Content: "Body", AuthorID: "author-1"},
+		}
+		mockUsecase.On("ImportBlogs", mock.Anything, "admin-1", expectedItems).Return([]domain.BlogImportResult{
+			{Index: 0, BlogID: "blog-1"},
+			{Index: 1, Err: domain.ErrValidation},
+		}, nil).Once()
+
+		body := `[{"title":"Old Post","content":"Body","author_id":"author-1","tags":["go"],"created_at":"2021-05-01T12:00:00Z"},{"title":"","content":"Body","author_id":"author-1"}]`
+		req := httptest.NewRequest(http.MethodPost, "/admin/blogs/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.ImportBlogsResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal(1, resp.Imported)
+		s.Equal(1, resp.Failed)
+		s.Require().Len(resp.Results, 2)
+		s.Equal("blog-1", resp.Results[0].ID)
+		s.Empty(resp.Results[0].Error)
+		s.NotEmpty(resp.Results[1].Error)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_NotAnArray", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/admin/blogs/import", controller.ImportBlogs)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/blogs/import", strings.NewReader(`{"title":"x"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), `"code":"`+controllers.CodeInvalidRequestBody+`"`)
+		mockUsecase.AssertNotCalled(s.T(), "ImportBlogs", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure_EmptyImport", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/admin/blogs/import", controller.ImportBlogs)
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/blogs/import", strings.NewReader(`[]`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), `"code":"`+controllers.CodeInvalidBatchSize+`"`)
+		mockUsecase.AssertNotCalled(s.T(), "ImportBlogs", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogControllerTestSuite) TestListTags() {
+	setup := func() (*MockBlogUsecase, *gin.Engine) {
+		mockUsecase := new(MockBlogUsecase)
+		router := gin.New()
+		router.GET("/tags", controllers.NewBlogController(mockUsecase).ListTags)
+		return mockUsecase, router
+	}
+
+	s.Run("Success_AlphaSortWithCappedLimit", func() {
+		// Arrange
+		mockUsecase, router := setup()
+		page := &domain.TagPage{Tags: []*domain.TagCount{{Tag: "api", Count: 1}, {Tag: "go", Count: 3}}, Total: 12, Limit: 50}
+		mockUsecase.On("ListTags", mock.Anything, int64(2), int64(80), domain.TagSortAlpha).Return(page, nil).Once()
+		req := httptest.NewRequest(http.MethodGet, "/tags?sort=alpha&page=2&limit=80", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.PaginatedTagResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal([]controllers.TagResponse{{Tag: "api", Count: 1}, {Tag: "go", Count: 3}}, resp.Data)
+		s.Equal(controllers.Pagination{Total: 12, Page: 2, Limit: 50}, resp.Pagination, "the limit reported is the one applied")
+	})
+
+	s.Run("Success_DefaultSort", func() {
+		// Arrange
+		mockUsecase, router := setup()
+		mockUsecase.On("ListTags", mock.Anything, int64(1), controllers.DefaultPageLimit, domain.TagSort("")).Return(&domain.TagPage{Limit: 10}, nil).Once()
+		req := httptest.NewRequest(http.MethodGet, "/tags", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		s.JSONEq(`{"data":[],"pagination":{"total":0,"page":1,"limit":10}}`, w.Body.String())
+	})
+
+	s.Run("Failure_InvalidSort", func() {
+		// Arrange
+		mockUsecase, router := setup()
+		req := httptest.NewRequest(http.MethodGet, "/tags?sort=popular", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), "count, alpha")
+		s.Contains(w.Body.String(), `"code":"`+controllers.CodeInvalidQueryParameter+`"`)
+		mockUsecase.AssertNotCalled(s.T(), "ListTags", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure_InvalidLimit", func() {
+		// Arrange
+		mockUsecase, router := setup()
+		req := httptest.NewRequest(http.MethodGet, "/tags?limit=0", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(s.T(), "ListTags", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogControllerTestSuite) TestGetRandom() {
+	setup := func() (*MockBlogUsecase, *gin.Engine) {
+		mockUsecase := new(MockBlogUsecase)
+		router := gin.New()
+		router.GET("/blogs/random", controllers.NewBlogController(mockUsecase).GetRandom)
+		return mockUsecase, router
+	}
+
+	s.Run("Success_WithTag", func() {
+		// Arrange
+		mockUsecase, router := setup()
+		blog := &domain.Blog{ID: "blog-1", Title: "Lucky Pick", Tags: []string{"go"}, Status: domain.BlogStatusPublished}
+		mockUsecase.On("GetRandom", mock.Anything, "go").Return(blog, nil).Once()
+		req := httptest.NewRequest(http.MethodGet, "/blogs/random?tag=go", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.BlogResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal("blog-1", resp.ID)
+		s.Equal([]string{"go"}, resp.Tags)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_NoneFound", func() {
+		// Arrange
+		mockUsecase, router := setup()
+		mockUsecase.On("GetRandom", mock.Anything, "").Return(nil, usecases.ErrNotFound).Once()
+		req := httptest.NewRequest(http.MethodGet, "/blogs/random", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+func (s *BlogControllerTestSuite) TestSuggestTitles() {
+	setup := func() (*MockBlogUsecase, *gin.Engine) {
+		mockUsecase := new(MockBlogUsecase)
+		router := gin.New()
+		router.GET("/blogs/suggest", controllers.NewBlogController(mockUsecase).SuggestTitles)
+		return mockUsecase, router
+	}
+
+	s.Run("Success", func() {
+		// Arrange
+		mockUsecase, router := setup()
+		suggestions := []*domain.BlogTitleSuggestion{{ID: "blog-1", Title: "Learning Go"}, {ID: "blog-2", Title: "Go tips"}}
+		mockUsecase.On("SuggestTitles", mock.Anything, "go", 2).Return(suggestions, nil).Once()
+		req := httptest.NewRequest(http.MethodGet, "/blogs/suggest?q=go&limit=2", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp struct {
+			Data []controllers.BlogTitleSuggestionResponse `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal([]controllers.BlogTitleSuggestionResponse{{ID: "blog-1", Title: "Learning Go"}, {ID: "blog-2", Title: "Go tips"}}, resp.Data)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("DefaultLimit", func() {
+		// Arrange
+		mockUsecase, router := setup()
+		mockUsecase.On("SuggestTitles", mock.Anything, "", int(controllers.DefaultPageLimit)).Return([]*domain.BlogTitleSuggestion{}, nil).Once()
+		req := httptest.NewRequest(http.MethodGet, "/blogs/suggest", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		s.JSONEq(`{"data":[]}`, w.Body.String())
+	})
+
+	s.Run("Failure_InvalidLimit", func() {
+		// Arrange
+		mockUsecase, router := setup()
+		req := httptest.NewRequest(http.MethodGet, "/blogs/suggest?q=go&limit=abc", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(s.T(), "SuggestTitles", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogControllerTestSuite) TestExportBlog() {
+	setup := func(userID string, role domain.Role) (*MockBlogUsecase, *gin.Engine) {
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/export", func(c *gin.Context) {
+			if userID != "" {
+				c.Set("userID", userID)
+				c.Set("userRole", role)
+			}
+		}, controller.ExportBlog)
+		return mockUsecase, router
+	}
+
+	s.Run("Success_ThreadedDocument", func() {
+		// Arrange
+		mockUsecase, router := setup("", "")
+		parentID := "c1"
+		author := "author-1"
+		reply := &domain.Comment{ID: "r1", BlogID: "blog-1", AuthorID: &author, ParentID: &parentID, Content: "Reply"}
+		export := &domain.BlogExport{
+			Blog: &domain.Blog{ID: "blog-1", Title: "Title", Content: "Body", AuthorID: author, Status: domain.BlogStatusPublished},
+			Comments: []*domain.Comment{
+				{ID: "c1", BlogID: "blog-1", AuthorID: &author, Content: "Top", ReplyCount: 1, Replies: []*domain.Comment{reply}},
+				{ID: "c2", BlogID: "blog-1", AuthorID: &author, Content: "Second"},
+			},
+			ExportedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+		mockUsecase.On("ExportBlog", mock.Anything, "blog-1", "", domain.Role("")).Return(export, nil).Once()
+		req := httptest.NewRequest(http.MethodGet, "/blogs/blog-1/export", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		s.Contains(w.Header().Get("Content-Disposition"), `filename="blog-blog-1.json"`)
+		var resp controllers.BlogExportResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal("blog-1", resp.Blog.ID)
+		s.Equal("Title", resp.Blog.Title)
+		s.Require().Len(resp.Comments, 2)
+		s.Equal("c1", resp.Comments[0].ID)
+		s.Require().Len(resp.Comments[0].Replies, 1)
+		s.Equal("r1", resp.Comments[0].Replies[0].ID)
+		s.False(resp.Comments[0].HasMoreReplies)
+		s.Empty(resp.Comments[1].Replies)
+		s.True(export.ExportedAt.Equal(resp.ExportedAt))
+	})
+
+	s.Run("Failure_DraftOfAnotherUser", func() {
+		// Arrange
+		mockUsecase, router := setup("someone-else", domain.RoleUser)
+		mockUsecase.On("ExportBlog", mock.Anything, "draft-1", "someone-else", domain.RoleUser).Return(nil, usecases.ErrNotFound).Once()
+		req := httptest.NewRequest(http.MethodGet, "/blogs/draft-1/export", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusNotFound, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogControllerTestSuite) TestGetBlogDetail() {
+	setup := func(userID string, role domain.Role) (*MockBlogUsecase, *gin.Engine) {
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/full", func(c *gin.Context) {
+			if userID != "" {
+				c.Set("userID", userID)
+				c.Set("userRole", role)
+			}
+		}, controller.GetBlogDetail)
+		return mockUsecase, router
+	}
+
+	s.Run("Success_AllSections", func() {
+		// Arrange
+		mockUsecase, router := setup("viewer-1", domain.RoleUser)
+		author := "author-1"
+		detail := &domain.BlogDetail{
+			Blog:          &domain.Blog{ID: "blog-1", Title: "Title", Content: "# Intro", AuthorID: author, Status: domain.BlogStatusPublished},
+			Comments:      []*domain.Comment{{ID: "c1", BlogID: "blog-1", AuthorID: &author, Content: "First", ReplyCount: 2}},
+			CommentsTotal: 12,
+			Related:       []*domain.Blog{{ID: "blog-2", Title: "Related", AuthorID: author}},
+			ViewerAction:  domain.ActionTypeLike,
+		}
+		mockUsecase.On("GetBlogDetail", mock.Anything, "blog-1", "viewer-1", domain.RoleUser).Return(detail, nil).Once()
+		req := httptest.NewRequest(http.MethodGet, "/blogs/blog-1/full", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.BlogDetailResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal("blog-1", resp.Blog.ID)
+		s.Equal(string(domain.ActionTypeLike), resp.Blog.ViewerAction)
+		s.Require().Len(resp.Blog.TableOfContents, 1)
+		s.Equal("Intro", resp.Blog.TableOfContents[0].Text)
+		s.Require().Len(resp.Comments.Data, 1)
+		s.Equal("c1", resp.Comments.Data[0].ID)
+		s.True(resp.Comments.Data[0].HasMoreReplies)
+		s.Equal(int64(12), resp.Comments.Pagination.Total)
+		s.Equal(int64(usecases.DetailCommentsLimit), resp.Comments.Pagination.Limit)
+		s.Require().Len(resp.Related, 1)
+		s.Equal("blog-2", resp.Related[0].ID)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Success_EmptySectionsAreArrays", func() {
+		// Arrange
+		mockUsecase, router := setup("", "")
+		detail := &domain.BlogDetail{Blog: &domain.Blog{ID: "blog-1", Title: "Title", Status: domain.BlogStatusPublished}}
+		mockUsecase.On("GetBlogDetail", mock.Anything, "blog-1", "", domain.Role("")).Return(detail, nil).Once()
+		req := httptest.NewRequest(http.MethodGet, "/blogs/blog-1/full", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		s.Contains(w.Body.String(), `"related":[]`)
+		s.Contains(w.Body.String(), `"data":[]`)
+		s.NotContains(w.Body.String(), `"viewer_action"`)
+	})
+
+	s.Run("Failure_DraftOfAnotherUser", func() {
+		// Arrange
+		mockUsecase, router := setup("someone-else", domain.RoleUser)
+		mockUsecase.On("GetBlogDetail", mock.Anything, "draft-1", "someone-else", domain.RoleUser).Return(nil, usecases.ErrNotFound).Once()
+		req := httptest.NewRequest(http.MethodGet, "/blogs/draft-1/full", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusNotFound, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogControllerTestSuite) TestRevisions() {
+	authMiddleware := func(c *gin.Context) {
+		c.Set("userID", "admin-1")
+		c.Set("userRole", domain.RoleAdmin)
+		c.Next()
+	}
+
+	s.Run("List_Success", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/revisions", authMiddleware, controller.ListRevisions)
+
+		revisions := []*domain.BlogRevision{{ID: "rev-1", BlogID: "blog-1", Title: "Old Title"}}
+		mockUsecase.On("ListRevisions", mock.Anything, "blog-1", "admin-1", domain.RoleAdmin).Return(revisions, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/blogs/blog-1/revisions", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		s.Contains(w.Body.String(), `"title":"Old Title"`)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Restore_Success", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/revisions/:rev/restore", authMiddleware, controller.RestoreRevision)
+
+		restored := &domain.Blog{ID: "blog-1", Title: "Old Title"}
+		mockUsecase.On("RestoreRevision", mock.Anything, "blog-1", "rev-1", "admin-1", domain.RoleAdmin).Return(restored, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/blogs/blog-1/revisions/rev-1/restore", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.BlogResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		s.Equal("Old Title", resp.Title)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Get_NotFound", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/revisions/:rev", authMiddleware, controller.GetRevision)
+
+		mockUsecase.On("GetRevision", mock.Anything, "blog-1", "missing", "admin-1", domain.RoleAdmin).Return(nil, usecases.ErrNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/blogs/blog-1/revisions/missing", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusNotFound, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogControllerTestSuite) TestPin() {
+	authMiddleware := func(c *gin.Context) { c.Set("userID", "author-1"); c.Next() }
+
+	s.Run("Pin_Success", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/pin", authMiddleware, controller.Pin)
+
+		pinned := &domain.Blog{ID: "blog-1", AuthorID: "author-1", PinnedByAuthor: true}
+		mockUsecase.On("SetPinned", mock.Anything, "blog-1", "author-1", true).Return(pinned, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/blogs/blog-1/pin", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.BlogResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		s.True(resp.PinnedByAuthor)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Unpin_Success", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/unpin", authMiddleware, controller.Unpin)
+
+		unpinned := &domain.Blog{ID: "blog-1", AuthorID: "author-1"}
+		mockUsecase.On("SetPinned", mock.Anything, "blog-1", "author-1", false).Return(unpinned, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/blogs/blog-1/unpin", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.BlogResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		s.False(resp.PinnedByAuthor)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Pin_NotAuthor", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/pin", authMiddleware, controller.Pin)
+
+		mockUsecase.On("SetPinned", mock.Anything, "blog-2", "author-1", true).Return(nil, domain.ErrPermissionDenied).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/blogs/blog-2/pin", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusForbidden, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogControllerTestSuite) TestCloneBlog() {
+	authMiddleware := func(c *gin.Context) { c.Set("userID", "author-1"); c.Set("userRole", domain.RoleUser); c.Next() }
+
+	s.Run("Success", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/clone", authMiddleware, controller.CloneBlog)
+
+		clone := &domain.Blog{ID: "blog-2", AuthorID: "author-1", Title: "Hello (copy)", Status: domain.BlogStatusDraft}
+		mockUsecase.On("CloneBlog", mock.Anything, "blog-1", "author-1", domain.RoleUser).Return(clone, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/blogs/blog-1/clone", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusCreated, w.Code)
+		var resp controllers.BlogResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		s.Equal("blog-2", resp.ID)
+		s.Equal("Hello (copy)", resp.Title)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_NotAuthor", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/clone", authMiddleware, controller.CloneBlog)
+
+		mockUsecase.On("CloneBlog", mock.Anything, "blog-3", "author-1", domain.RoleUser).Return(nil, domain.ErrPermissionDenied).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/blogs/blog-3/clone", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusForbidden, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogControllerTestSuite) TestPublishBlog() {
+	authMiddleware := func(c *gin.Context) { c.Set("userID", "author-1"); c.Set("userRole", domain.RoleUser); c.Next() }
+
+	s.Run("Success", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/publish", authMiddleware, controller.PublishBlog)
+
+		now := time.Now().UTC()
+		blog := &domain.Blog{ID: "blog-2", AuthorID: "author-1", Title: "Hello (copy)", Status: domain.BlogStatusPublished, PublishedAt: &now}
+		mockUsecase.On("PublishDraft", mock.Anything, "blog-2", "author-1", domain.RoleUser).Return(blog, nil).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/blogs/blog-2/publish", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.BlogResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		s.Equal("blog-2", resp.ID)
+		s.Equal(string(domain.BlogStatusPublished), resp.Status)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_NotADraft", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/publish", authMiddleware, controller.PublishBlog)
+
+		mockUsecase.On("PublishDraft", mock.Anything, "blog-1", "author-1", domain.RoleUser).Return(nil, usecases.ErrConflict).Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/blogs/blog-1/publish", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusConflict, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogControllerTestSuite) TestListByAuthor() {
+	s.Run("Success_PinnedFirst", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/users/:userID/blogs", controller.ListByAuthor)
+
+		blogs := []*domain.Blog{
+			{ID: "pinned", AuthorID: "author-1", PinnedByAuthor: true},
+			{ID: "newest", AuthorID: "author-1"},
+		}
+		mockUsecase.On("ListByAuthor", mock.Anything, "author-1", int64(2), int64(5)).Return(blogs, int64(7), nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/users/author-1/blogs?page=2&limit=5", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.PaginatedBlogResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		s.Require().Len(resp.Data, 2)
+		s.Equal("pinned", resp.Data[0].ID)
+		s.True(resp.Data[0].PinnedByAuthor)
+		s.Equal(int64(7), resp.Pagination.Total)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_UnknownAuthor", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/users/:userID/blogs", controller.ListByAuthor)
+
+		mockUsecase.On("ListByAuthor", mock.Anything, "ghost", int64(1), int64(10)).Return(nil, int64(0), domain.ErrUserNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/users/ghost/blogs", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusNotFound, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_InvalidPage", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/users/:userID/blogs", controller.ListByAuthor)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/author-1/blogs?page=0", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(s.T(), "ListByAuthor", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogControllerTestSuite) TestGetFollowingFeed() {
+	s.Run("Success_WithReadFlags", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/feed", func(c *gin.Context) { c.Set("userID", "user-1") }, controller.GetFollowingFeed)
+
+		blogs := []*domain.Blog{{ID: "newest", AuthorID: "author-2"}, {ID: "older", AuthorID: "author-1"}}
+		mockUsecase.On("GetFollowingFeed", mock.Anything, "user-1", int64(2), int64(5)).Return(blogs, int64(7), nil).Once()
+		mockUsecase.On("ReadFlags", mock.Anything, "user-1", []string{"newest", "older"}).Return(map[string]bool{"older": true}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/feed?page=2&limit=5", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.PaginatedBlogResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Require().Len(resp.Data, 2)
+		s.Equal("newest", resp.Data[0].ID)
+		s.Require().NotNil(resp.Data[1].Read)
+		s.True(*resp.Data[1].Read)
+		s.Equal(controllers.Pagination{Total: 7, Page: 2, Limit: 5}, resp.Pagination)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Success_EmptyFeed", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/feed", func(c *gin.Context) { c.Set("userID", "user-1") }, controller.GetFollowingFeed)
+
+		mockUsecase.On("GetFollowingFeed", mock.Anything, "user-1", int64(1), int64(10)).Return([]*domain.Blog{}, int64(0), nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/feed", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		s.Contains(w.Body.String(), `"data":[]`)
+		mockUsecase.AssertNotCalled(s.T(), "ReadFlags", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogControllerTestSuite) TestMarkRead() {
+	authMiddleware := func(c *gin.Context) { c.Set("userID", "reader-1"); c.Next() }
+
+	s.Run("Success", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/read", authMiddleware, controller.MarkRead)
+
+		mockUsecase.On("MarkRead", mock.Anything, "blog-1", "reader-1").Return(nil).Twice()
+
+		// Act & Assert: marking twice is fine.
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/blogs/blog-1/read", nil))
+			s.Equal(http.StatusNoContent, w.Code)
+		}
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_BlogNotFound", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/blogs/:blogID/read", authMiddleware, controller.MarkRead)
+
+		mockUsecase.On("MarkRead", mock.Anything, "missing", "reader-1").Return(usecases.ErrNotFound).Once()
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/blogs/missing/read", nil))
+
+		// Assert
+		s.Equal(http.StatusNotFound, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogControllerTestSuite) TestListRead() {
+	// Arrange
+	mockUsecase := new(MockBlogUsecase)
+	controller := controllers.NewBlogController(mockUsecase)
+	router := gin.New()
+	router.GET("/users/me/read", func(c *gin.Context) { c.Set("userID", "reader-1"); c.Next() }, controller.ListRead)
+
+	readAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	reads := []*domain.BlogRead{{UserID: "reader-1", BlogID: "blog-1", ReadAt: readAt}}
+	mockUsecase.On("ListRead", mock.Anything, "reader-1", int64(1), int64(10)).Return(reads, int64(1), nil).Once()
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/me/read", nil))
+
+	// Assert
+	s.Equal(http.StatusOK, w.Code)
+	var resp controllers.PaginatedBlogReadResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	s.Require().Len(resp.Data, 1)
+	s.Equal("blog-1", resp.Data[0].BlogID)
+	s.True(readAt.Equal(resp.Data[0].ReadAt))
+	s.Equal(int64(1), resp.Pagination.Total)
+	mockUsecase.AssertExpectations(s.T())
+}
+
+func (s *BlogControllerTestSuite) TestReadFlagsInLists() {
+	blogs := []*domain.Blog{{ID: "blog-1"}, {ID: "blog-2"}}
+
+	s.Run("LoggedInViewerGetsFlags", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs", func(c *gin.Context) { c.Set("userID", "reader-1"); c.Next() }, controller.SearchAndFilter)
+
+		mockUsecase.On("SearchAndFilter", mock.Anything, mock.Anything).Return(blogs, int64(2), nil).Once()
+		mockUsecase.On("ReadFlags", mock.Anything, "reader-1", []string{"blog-1", "blog-2"}).Return(map[string]bool{"blog-1": true}, nil).Once()
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs", nil))
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.PaginatedBlogResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Require().Len(resp.Data, 2)
+		s.Require().NotNil(resp.Data[0].Read)
+		s.True(*resp.Data[0].Read)
+		s.Require().NotNil(resp.Data[1].Read)
+		s.False(*resp.Data[1].Read)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("AnonymousViewerGetsNoFlag", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs", controller.SearchAndFilter)
+
+		mockUsecase.On("SearchAndFilter", mock.Anything, mock.Anything).Return(blogs, int64(2), nil).Once()
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs", nil))
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		s.NotContains(w.Body.String(), `"read"`)
+		mockUsecase.AssertNotCalled(s.T(), "ReadFlags", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("LookupFailureStillReturnsList", func() {
+		// Arrange
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/users/:userID/blogs", func(c *gin.Context) { c.Set("userID", "reader-1"); c.Next() }, controller.ListByAuthor)
+
+		mockUsecase.On("ListByAuthor", mock.Anything, "author-1", int64(1), int64(10)).Return(blogs, int64(2), nil).Once()
+		mockUsecase.On("ReadFlags", mock.Anything, "reader-1", mock.Anything).Return(nil, errors.New("db down")).Once()
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/author-1/blogs", nil))
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.PaginatedBlogResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Len(resp.Data, 2)
+		s.Nil(resp.Data[0].Read)
+	})
+}
+
+func (s *BlogControllerTestSuite) TestListInteractions() {
+	// Arrange
+	mockUsecase := new(MockBlogUsecase)
+	controller := controllers.NewBlogController(mockUsecase)
+	router := gin.New()
+	router.GET("/users/me/interactions", func(c *gin.Context) { c.Set("userID", "user-1"); c.Next() }, controller.ListInteractions)
+
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	entries := []*domain.InteractionHistoryEntry{{
+		Interaction: &domain.BlogInteraction{ID: "i-1", BlogID: "blog-1", Action: domain.ActionTypeLike, CreatedAt: createdAt, UpdatedAt: createdAt},
+		Blog:        &domain.Blog{ID: "blog-1", Title: "A Blog", AuthorID: "author-1", Content: "not included"},
+	}}
+	mockUsecase.On("ListInteractions", mock.Anything, "user-1", int64(2), int64(5)).Return(entries, int64(6), nil).Once()
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/me/interactions?page=2&limit=5", nil))
+
+	// Assert
+	s.Equal(http.StatusOK, w.Code)
+	var resp controllers.PaginatedInteractionHistoryResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	s.Require().Len(resp.Data, 1)
+	s.Equal("i-1", resp.Data[0].ID)
+	s.Equal("like", resp.Data[0].Action)
+	s.Equal(controllers.BlogSummaryResponse{ID: "blog-1", Title: "A Blog", AuthorID: "author-1"}, resp.Data[0].Blog)
+	s.NotContains(w.Body.String(), "not included")
+	s.Equal(controllers.Pagination{Total: 6, Page: 2, Limit: 5}, resp.Pagination)
+	mockUsecase.AssertExpectations(s.T())
+}
+
+func (s *BlogControllerTestSuite) TestListBlogInteractions() {
+	setup := func(userID string, role domain.Role) (*MockBlogUsecase, *gin.Engine) {
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/interactions", func(c *gin.Context) {
+			c.Set("userID", userID)
+			c.Set("userRole", role)
+			c.Next()
+		}, controller.ListBlogInteractions)
+		return mockUsecase, router
+	}
+
+	s.Run("Success_Paginated", func() {
+		// Arrange
+		mockUsecase, router := setup("author-1", domain.RoleUser)
+		createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+		entries := []*domain.BlogInteractionEntry{
+			{
+				Interaction: &domain.BlogInteraction{ID: "i-2", UserID: "user-2", Action: domain.ActionTypeLike, CreatedAt: createdAt, UpdatedAt: createdAt},
+				User:        &domain.User{ID: "user-2", Username: "bob", ProfilePicture: "https://cdn/bob.png", Email: "bob@example.com"},
+			},
+			{
+				Interaction: &domain.BlogInteraction{ID: "i-1", UserID: "gone", Action: domain.ActionTypeDislike, CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+		}
+		mockUsecase.On("ListBlogInteractions", mock.Anything, "blog-1", "author-1", domain.RoleUser, int64(2), int64(2)).Return(entries, int64(5), nil).Once()
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/interactions?page=2&limit=2", nil))
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.PaginatedBlogInteractionResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal([]controllers.BlogInteractionResponse{
+			{ID: "i-2", UserID: "user-2", Username: "bob", ProfilePicture: "https://cdn/bob.png", Action: "like", CreatedAt: createdAt, UpdatedAt: createdAt},
+			{ID: "i-1", UserID: "gone", Action: "dislike", CreatedAt: createdAt, UpdatedAt: createdAt},
+		}, resp.Data)
+		s.NotContains(w.Body.String(), "bob@example.com")
+		s.Equal(controllers.Pagination{Total: 5, Page: 2, Limit: 2}, resp.Pagination)
+		s.Contains(w.Header().Get("Link"), `rel="next"`)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_NotOwner", func() {
+		// Arrange
+		mockUsecase, router := setup("user-9", domain.RoleUser)
+		mockUsecase.On("ListBlogInteractions", mock.Anything, "blog-1", "user-9", domain.RoleUser, int64(1), controllers.DefaultPageLimit).
+			Return(nil, int64(0), domain.ErrPermissionDenied).Once()
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/interactions", nil))
+
+		// Assert
+		s.Equal(http.StatusForbidden, w.Code)
+	})
+}
+
+func (s *BlogControllerTestSuite) TestRetag() {
+	adminMiddleware := func(c *gin.Context) { c.Set("userID", "admin-1"); c.Next() }
+	setup := func() (*MockBlogUsecase, *gin.Engine) {
+		mockUsecase := new(MockBlogUsecase)
+		controller := controllers.NewBlogController(mockUsecase)
+		router := gin.New()
+		router.POST("/admin/tags/merge", adminMiddleware, controller.MergeTags)
+		router.POST("/admin/tags/rename", adminMiddleware, controller.RenameTag)
+		return mockUsecase, router
+	}
+	post := func(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	s.Run("Merge_Success", func() {
+		mockUsecase, router := setup()
+		mockUsecase.On("MergeTags", mock.Anything, "admin-1", "go-lang", "golang").Return(3, nil).Once()
+
+		w := post(router, "/admin/tags/merge", `{"from":"go-lang","to":"golang"}`)
+
+		s.Equal(http.StatusOK, w.Code)
+		var resp controllers.RetagResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal(controllers.RetagResponse{From: "go-lang", To: "golang", Updated: 3}, resp)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Merge_UnknownTag", func() {
+		mockUsecase, router := setup()
+		mockUsecase.On("MergeTags", mock.Anything, "admin-1", "nope", "golang").Return(0, usecases.ErrNotFound).Once()
+
+		w := post(router, "/admin/tags/merge", `{"from":"nope","to":"golang"}`)
+
+		s.Equal(http.StatusNotFound, w.Code)
+	})
+
+	s.Run("Merge_MissingField", func() {
+		mockUsecase, router := setup()
+
+		w := post(router, "/admin/tags/merge", `{"from":"go-lang"}`)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(s.T(), "MergeTags", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Rename_Success", func() {
+		mockUsecase, router := setup()
+		mockUsecase.On("RenameTag", mock.Anything, "admin-1", "js", "javascript").Return(2, nil).Once()
+
+		w := post(router, "/admin/tags/rename", `{"from":"js","to":"javascript"}`)
+
+		s.Equal(http.StatusOK, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Rename_TargetExists", func() {
+		mockUsecase, router := setup()
+		mockUsecase.On("RenameTag", mock.Anything, "admin-1", "js", "javascript").Return(0, domain.ErrTagExists).Once()
+
+		w := post(router, "/admin/tags/rename", `{"from":"js","to":"javascript"}`)
+
+		s.Equal(http.StatusConflict, w.Code)
+		var body map[string]interface{}
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+		s.Equal(controllers.CodeTagExists, body["code"])
+	})
+}
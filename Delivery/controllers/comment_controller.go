@@ -1,8 +1,9 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
-	"strconv"
+	"strings"
 	"time"
 
 	domain "A2SV_Starter_Project_Blog/Domain"
@@ -26,14 +27,17 @@ type UpdateCommentRequest struct {
 // --- Response DTOs ---
 
 type CommentResponse struct {
-	ID         string    `json:"id"`
-	BlogID     string    `json:"blogId"`
-	AuthorID   *string   `json:"authorId,omitempty"` // Can be null for deleted comments
-	ParentID   *string   `json:"parentId,omitempty"`
-	Content    string    `json:"content"`
-	ReplyCount int64     `json:"replyCount"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
+	ID             string            `json:"id"`
+	BlogID         string            `json:"blog_id"`
+	AuthorID       *string           `json:"author_id,omitempty"` // Can be null for deleted comments
+	ParentID       *string           `json:"parent_id,omitempty"`
+	Content        string            `json:"content"`
+	ReplyCount     int64             `json:"reply_count"`
+	Replies        []CommentResponse `json:"replies,omitempty"` // Embedded in a blog's comment listing
+	HasMoreReplies bool              `json:"has_more_replies"`
+	Pending        bool              `json:"pending,omitempty"` // Awaiting approval under pre-moderation
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
 }
 
 type PaginatedCommentResponse struct {
@@ -41,6 +45,12 @@ type PaginatedCommentResponse struct {
 	Pagination Pagination        `json:"pagination"`
 }
 
+// CommentThreadResponse lists a comment's ancestors, from the top-level comment down to its direct parent.
+type CommentThreadResponse struct {
+	CommentID string            `json:"comment_id"`
+	Path      []CommentResponse `json:"path"`
+}
+
 type CommentController struct {
 	commentUsecase domain.ICommentUsecase
 }
@@ -57,11 +67,11 @@ func (cc *CommentController) CreateComment(c *gin.Context) {
 
 	var req CreateCommentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request: 'content' is required"})
+		HandleBindingError(c, err)
 		return
 	}
 
-	comment, err := cc.commentUsecase.CreateComment(c.Request.Context(), userID, blogID, req.Content, req.ParentID)
+	comment, err := cc.commentUsecase.CreateComment(c.Request.Context(), userID, blogID, req.Content, req.ParentID, userRoleFromContext(c))
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -76,7 +86,7 @@ func (cc *CommentController) UpdateComment(c *gin.Context) {
 
 	var req UpdateCommentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request: 'content' is required"})
+		HandleBindingError(c, err)
 		return
 	}
 
@@ -93,7 +103,7 @@ func (cc *CommentController) DeleteComment(c *gin.Context) {
 	commentID := c.Param("commentID")
 	userID := c.GetString("userID")
 
-	if err := cc.commentUsecase.DeleteComment(c.Request.Context(), userID, commentID); err != nil {
+	if err := cc.commentUsecase.DeleteComment(c.Request.Context(), userID, commentID, userRoleFromContext(c)); err != nil {
 		HandleError(c, err)
 		return
 	}
@@ -104,24 +114,35 @@ func (cc *CommentController) DeleteComment(c *gin.Context) {
 func (cc *CommentController) GetCommentsForBlog(c *gin.Context) {
 	blogID := c.Param("blogID")
 
-	// Parse pagination parameters
-	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
-	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "10"), 10, 64)
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+
+	sort := domain.CommentSort(c.Query("sort"))
+	if sort != "" && !sort.IsValid() {
+		abortInvalidQueryParameter(c, invalidCommentSortMessage())
+		return
+	}
 
-	comments, total, err := cc.commentUsecase.GetCommentsForBlog(c.Request.Context(), blogID, page, limit)
+	comments, total, err := cc.commentUsecase.GetCommentsForBlog(c.Request.Context(), blogID, page, limit, sort)
 	if err != nil {
 		HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, toPaginatedCommentResponse(comments, total, page, limit))
+	response := toPaginatedCommentResponse(comments, total, page, limit)
+	setLinkHeader(c, response.Pagination)
+	c.JSON(http.StatusOK, response)
 }
 
 func (cc *CommentController) GetRepliesForComment(c *gin.Context) {
 	commentID := c.Param("commentID")
 
-	page, _ := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
-	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "10"), 10, 64)
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
 
 	replies, total, err := cc.commentUsecase.GetRepliesForComment(c.Request.Context(), commentID, page, limit)
 	if err != nil {
@@ -129,20 +150,71 @@ func (cc *CommentController) GetRepliesForComment(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, toPaginatedCommentResponse(replies, total, page, limit))
+	response := toPaginatedCommentResponse(replies, total, page, limit)
+	setLinkHeader(c, response.Pagination)
+	c.JSON(http.StatusOK, response)
+}
+
+// SearchCommentsInBlog returns the comments and replies of a blog matching the q query parameter, best match first.
+func (cc *CommentController) SearchCommentsInBlog(c *gin.Context) {
+	blogID := c.Param("blogID")
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		abortInvalidQueryParameter(c, "Query parameter 'q' is required")
+		return
+	}
+
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+
+	comments, total, err := cc.commentUsecase.SearchCommentsInBlog(c.Request.Context(), blogID, query, page, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	response := toPaginatedCommentResponse(comments, total, page, limit)
+	setLinkHeader(c, response.Pagination)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetThreadPath returns the chain of ancestors of a comment, for deep-linking a reply.
+func (cc *CommentController) GetThreadPath(c *gin.Context) {
+	commentID := c.Param("commentID")
+
+	path, err := cc.commentUsecase.GetThreadPath(c.Request.Context(), commentID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	resp := CommentThreadResponse{CommentID: commentID, Path: make([]CommentResponse, len(path))}
+	for i, comment := range path {
+		resp.Path[i] = toCommentResponse(comment)
+	}
+	c.JSON(http.StatusOK, resp)
 }
 
 func toCommentResponse(c *domain.Comment) CommentResponse {
-	return CommentResponse{
-		ID:         c.ID,
-		BlogID:     c.BlogID,
-		AuthorID:   c.AuthorID,
-		ParentID:   c.ParentID,
-		Content:    c.Content,
-		ReplyCount: c.ReplyCount,
-		CreatedAt:  c.CreatedAt,
-		UpdatedAt:  c.UpdatedAt,
+	resp := CommentResponse{
+		ID:             c.ID,
+		BlogID:         c.BlogID,
+		AuthorID:       c.AuthorID,
+		ParentID:       c.ParentID,
+		Content:        c.Content,
+		ReplyCount:     c.ReplyCount,
+		HasMoreReplies: c.HasMoreReplies(),
+		Pending:        c.Pending,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
 	}
+	for _, reply := range c.Replies {
+		resp.Replies = append(resp.Replies, toCommentResponse(reply))
+	}
+	return resp
 }
 
 func toPaginatedCommentResponse(comments []*domain.Comment, total, page, limit int64) PaginatedCommentResponse {
@@ -159,3 +231,11 @@ func toPaginatedCommentResponse(comments []*domain.Comment, total, page, limit i
 		},
 	}
 }
+
+func invalidCommentSortMessage() string {
+	sorts := make([]string, len(domain.CommentSorts))
+	for i, sort := range domain.CommentSorts {
+		sorts[i] = string(sort)
+	}
+	return fmt.Sprintf("Invalid 'sort' parameter. Must be one of: %s", strings.Join(sorts, ", "))
+}
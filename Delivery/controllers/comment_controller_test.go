@@ -8,9 +8,11 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	. "A2SV_Starter_Project_Blog/Delivery/controllers"
 	domain "A2SV_Starter_Project_Blog/Domain"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/mock"
@@ -22,8 +24,8 @@ type MockCommentUsecase struct {
 	mock.Mock
 }
 
-func (m *MockCommentUsecase) CreateComment(ctx context.Context, userID, blogID, content string, parentID *string) (*domain.Comment, error) {
-	args := m.Called(ctx, userID, blogID, content, parentID)
+func (m *MockCommentUsecase) CreateComment(ctx context.Context, userID, blogID, content string, parentID *string, userRole domain.Role) (*domain.Comment, error) {
+	args := m.Called(ctx, userID, blogID, content, parentID, userRole)
 	var comment *domain.Comment
 	if args.Get(0) != nil {
 		comment = args.Get(0).(*domain.Comment)
@@ -38,12 +40,12 @@ func (m *MockCommentUsecase) UpdateComment(ctx context.Context, userID, commentI
 	}
 	return comment, args.Error(1)
 }
-func (m *MockCommentUsecase) DeleteComment(ctx context.Context, userID, commentID string) error {
-	args := m.Called(ctx, userID, commentID)
+func (m *MockCommentUsecase) DeleteComment(ctx context.Context, userID, commentID string, userRole domain.Role) error {
+	args := m.Called(ctx, userID, commentID, userRole)
 	return args.Error(0)
 }
-func (m *MockCommentUsecase) GetCommentsForBlog(ctx context.Context, blogID string, page, limit int64) ([]*domain.Comment, int64, error) {
-	args := m.Called(ctx, blogID, page, limit)
+func (m *MockCommentUsecase) GetCommentsForBlog(ctx context.Context, blogID string, page, limit int64, sort domain.CommentSort) ([]*domain.Comment, int64, error) {
+	args := m.Called(ctx, blogID, page, limit, sort)
 	var comments []*domain.Comment
 	if args.Get(0) != nil {
 		comments = args.Get(0).([]*domain.Comment)
@@ -59,6 +61,24 @@ func (m *MockCommentUsecase) GetRepliesForComment(ctx context.Context, parentID
 	return comments, args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockCommentUsecase) SearchCommentsInBlog(ctx context.Context, blogID, query string, page, limit int64) ([]*domain.Comment, int64, error) {
+	args := m.Called(ctx, blogID, query, page, limit)
+	var comments []*domain.Comment
+	if args.Get(0) != nil {
+		comments = args.Get(0).([]*domain.Comment)
+	}
+	return comments, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCommentUsecase) GetThreadPath(ctx context.Context, commentID string) ([]*domain.Comment, error) {
+	args := m.Called(ctx, commentID)
+	var comments []*domain.Comment
+	if args.Get(0) != nil {
+		comments = args.Get(0).([]*domain.Comment)
+	}
+	return comments, args.Error(1)
+}
+
 // --- Test Suite Setup ---
 type CommentControllerTestSuite struct {
 	suite.Suite
@@ -87,7 +107,7 @@ func (s *CommentControllerTestSuite) TestCreateComment() {
 		reqBody := CreateCommentRequest{Content: "Great post!"}
 		mockReturnedComment := &domain.Comment{ID: "new-comment-id", Content: reqBody.Content}
 
-		mockUsecase.On("CreateComment", mock.Anything, "user-123", blogID, reqBody.Content, (*string)(nil)).Return(mockReturnedComment, nil).Once()
+		mockUsecase.On("CreateComment", mock.Anything, "user-123", blogID, reqBody.Content, (*string)(nil), mock.Anything).Return(mockReturnedComment, nil).Once()
 
 		body, _ := json.Marshal(reqBody)
 		req := httptest.NewRequest(http.MethodPost, "/blogs/"+blogID+"/comments", bytes.NewReader(body))
@@ -119,7 +139,7 @@ func (s *CommentControllerTestSuite) TestCreateComment() {
 		// doesn't know about the blogID from the URL in this case. In a real scenario, the
 		// usecase would fetch the parent comment to find the blogID. We'll pass an empty string
 		// for blogID to simulate this route's behavior.
-		mockUsecase.On("CreateComment", mock.Anything, "user-123", "", reqBody.Content, &parentID).Return(mockReturnedComment, nil).Once()
+		mockUsecase.On("CreateComment", mock.Anything, "user-123", "", reqBody.Content, &parentID, mock.Anything).Return(mockReturnedComment, nil).Once()
 
 		body, _ := json.Marshal(reqBody)
 		req := httptest.NewRequest(http.MethodPost, "/comments/"+parentID+"/replies", bytes.NewReader(body))
@@ -147,10 +167,48 @@ func (s *CommentControllerTestSuite) TestCreateComment() {
 		router.ServeHTTP(w, req)
 
 		s.Equal(http.StatusBadRequest, w.Code)
+		var resp struct {
+			Fields []FieldError `json:"fields"`
+		}
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal([]FieldError{{Field: "content", Rule: "required", Message: "content is required"}}, resp.Fields)
 		mockUsecase.AssertNotCalled(s.T(), "CreateComment")
 	})
 }
 
+func (s *CommentControllerTestSuite) TestCommentResponseFieldNames() {
+	mockUsecase := new(MockCommentUsecase)
+	controller := NewCommentController(mockUsecase)
+	router := gin.New()
+	router.GET("/comments/:commentID/thread", controller.GetThreadPath)
+
+	authorID, parentID := "author-1", "parent-1"
+	createdAt := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	mockUsecase.On("GetThreadPath", mock.Anything, "comment-1").Return([]*domain.Comment{{
+		ID: "parent-1", BlogID: "blog-1", AuthorID: &authorID, ParentID: &parentID, Content: "Hi",
+		ReplyCount: 2, CreatedAt: createdAt, UpdatedAt: createdAt,
+	}}, nil).Once()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/comments/comment-1/thread", nil))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{
+		"comment_id": "comment-1",
+		"path": [{
+			"id": "parent-1",
+			"blog_id": "blog-1",
+			"author_id": "author-1",
+			"parent_id": "parent-1",
+			"content": "Hi",
+			"reply_count": 2,
+			"has_more_replies": true,
+			"created_at": "2024-05-01T09:00:00Z",
+			"updated_at": "2024-05-01T09:00:00Z"
+		}]
+	}`, w.Body.String())
+}
+
 func (s *CommentControllerTestSuite) TestUpdateComment() {
 	authMiddleware := func(c *gin.Context) { c.Set("userID", "user-123"); c.Next() }
 
@@ -194,7 +252,7 @@ func (s *CommentControllerTestSuite) TestGetCommentsForBlog() {
 		mockComments := []*domain.Comment{{ID: "c1"}, {ID: "c2"}}
 
 		// Expect a call with default page=1, limit=10
-		mockUsecase.On("GetCommentsForBlog", mock.Anything, blogID, int64(1), int64(10)).Return(mockComments, int64(2), nil).Once()
+		mockUsecase.On("GetCommentsForBlog", mock.Anything, blogID, int64(1), int64(10), domain.CommentSort("")).Return(mockComments, int64(2), nil).Once()
 
 		req := httptest.NewRequest(http.MethodGet, "/blogs/"+blogID+"/comments", nil)
 		w := httptest.NewRecorder()
@@ -210,6 +268,67 @@ func (s *CommentControllerTestSuite) TestGetCommentsForBlog() {
 		s.Equal(int64(2), resp.Pagination.Total)
 		mockUsecase.AssertExpectations(s.T())
 	})
+
+	s.Run("Embedded Replies And has_more_replies", func() {
+		mockUsecase := new(MockCommentUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/comments", NewCommentController(mockUsecase).GetCommentsForBlog)
+		comments := []*domain.Comment{
+			{ID: "c1", ReplyCount: 3, Replies: []*domain.Comment{{ID: "r1"}, {ID: "r2"}}},
+			{ID: "c2", ReplyCount: 1, Replies: []*domain.Comment{{ID: "r3"}}},
+			{ID: "c3"},
+		}
+		mockUsecase.On("GetCommentsForBlog", mock.Anything, "blog-abc", int64(1), int64(10), domain.CommentSort("")).Return(comments, int64(3), nil).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-abc/comments", nil))
+
+		s.Equal(http.StatusOK, w.Code)
+		var resp struct {
+			Data []struct {
+				ID             string `json:"id"`
+				HasMoreReplies bool   `json:"has_more_replies"`
+				Replies        []struct {
+					ID string `json:"id"`
+				} `json:"replies"`
+			} `json:"data"`
+		}
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Require().Len(resp.Data, 3)
+		s.True(resp.Data[0].HasMoreReplies, "2 of 3 replies embedded")
+		s.Len(resp.Data[0].Replies, 2)
+		s.False(resp.Data[1].HasMoreReplies, "the only reply is embedded")
+		s.Equal("r3", resp.Data[1].Replies[0].ID)
+		s.False(resp.Data[2].HasMoreReplies, "no replies at all")
+		s.NotContains(w.Body.String(), `"replies":null`)
+	})
+
+	s.Run("Passes The Sort Through", func() {
+		mockUsecase := new(MockCommentUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/comments", NewCommentController(mockUsecase).GetCommentsForBlog)
+		mockUsecase.On("GetCommentsForBlog", mock.Anything, "blog-abc", int64(1), int64(10), domain.CommentSortTop).Return([]*domain.Comment{}, int64(0), nil).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-abc/comments?sort=top", nil))
+
+		s.Equal(http.StatusOK, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Rejects An Unknown Sort", func() {
+		mockUsecase := new(MockCommentUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/comments", NewCommentController(mockUsecase).GetCommentsForBlog)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-abc/comments?sort=loudest", nil))
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), "oldest, newest, top")
+		s.Contains(w.Body.String(), `"code":"`+CodeInvalidQueryParameter+`"`)
+		mockUsecase.AssertNotCalled(s.T(), "GetCommentsForBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func (s *CommentControllerTestSuite) TestDeleteComment() {
@@ -223,7 +342,7 @@ func (s *CommentControllerTestSuite) TestDeleteComment() {
 		router.DELETE("/comments/:commentID", authMiddleware, controller.DeleteComment)
 
 		commentID := "comment-to-delete"
-		mockUsecase.On("DeleteComment", mock.Anything, "user-123", commentID).Return(nil).Once()
+		mockUsecase.On("DeleteComment", mock.Anything, "user-123", commentID, domain.Role("")).Return(nil).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/comments/"+commentID, nil)
 		w := httptest.NewRecorder()
@@ -244,7 +363,28 @@ func (s *CommentControllerTestSuite) TestDeleteComment() {
 		router.DELETE("/comments/:commentID", authMiddleware, controller.DeleteComment)
 
 		commentID := "comment-owned-by-other"
-		mockUsecase.On("DeleteComment", mock.Anything, "user-123", commentID).Return(domain.ErrPermissionDenied).Once()
+		mockUsecase.On("DeleteComment", mock.Anything, "user-123", commentID, domain.Role("")).Return(domain.ErrPermissionDenied).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/comments/"+commentID, nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusForbidden, w.Code)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure - Delete Window Expired", func() {
+		// Arrange
+		mockUsecase := new(MockCommentUsecase)
+		controller := NewCommentController(mockUsecase)
+		router := gin.New()
+		router.DELETE("/comments/:commentID", authMiddleware, controller.DeleteComment)
+
+		commentID := "old-comment"
+		mockUsecase.On("DeleteComment", mock.Anything, "user-123", commentID, domain.Role("")).Return(domain.ErrDeleteWindowExpired).Once()
 
 		req := httptest.NewRequest(http.MethodDelete, "/comments/"+commentID, nil)
 		w := httptest.NewRecorder()
@@ -254,6 +394,29 @@ func (s *CommentControllerTestSuite) TestDeleteComment() {
 
 		// Assert
 		s.Equal(http.StatusForbidden, w.Code)
+		s.Contains(w.Body.String(), CodeDeleteWindowExpired)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Success - Passes The Role Along", func() {
+		// Arrange
+		mockUsecase := new(MockCommentUsecase)
+		controller := NewCommentController(mockUsecase)
+		router := gin.New()
+		adminMiddleware := func(c *gin.Context) { c.Set("userID", "admin-1"); c.Set("userRole", domain.RoleAdmin); c.Next() }
+		router.DELETE("/comments/:commentID", adminMiddleware, controller.DeleteComment)
+
+		commentID := "someone-elses-comment"
+		mockUsecase.On("DeleteComment", mock.Anything, "admin-1", commentID, domain.RoleAdmin).Return(nil).Once()
+
+		req := httptest.NewRequest(http.MethodDelete, "/comments/"+commentID, nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusNoContent, w.Code)
 		mockUsecase.AssertExpectations(s.T())
 	})
 }
@@ -284,3 +447,116 @@ func (s *CommentControllerTestSuite) TestGetRepliesForComment() {
 		mockUsecase.AssertExpectations(s.T())
 	})
 }
+
+func (s *CommentControllerTestSuite) TestGetThreadPath() {
+	s.Run("Success", func() {
+		// Arrange
+		mockUsecase := new(MockCommentUsecase)
+		controller := NewCommentController(mockUsecase)
+		router := gin.New()
+		router.GET("/comments/:commentID/thread", controller.GetThreadPath)
+
+		rootID := "root"
+		path := []*domain.Comment{{ID: rootID}, {ID: "middle", ParentID: &rootID}}
+		mockUsecase.On("GetThreadPath", mock.Anything, "leaf").Return(path, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/comments/leaf/thread", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp CommentThreadResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal("leaf", resp.CommentID)
+		s.Require().Len(resp.Path, 2)
+		s.Equal("root", resp.Path[0].ID)
+		s.Equal("middle", resp.Path[1].ID)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Success - Top-level comment returns an empty array", func() {
+		// Arrange
+		mockUsecase := new(MockCommentUsecase)
+		controller := NewCommentController(mockUsecase)
+		router := gin.New()
+		router.GET("/comments/:commentID/thread", controller.GetThreadPath)
+		mockUsecase.On("GetThreadPath", mock.Anything, "root").Return([]*domain.Comment{}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/comments/root/thread", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		s.Contains(w.Body.String(), `"path":[]`)
+	})
+
+	s.Run("Failure - Not Found", func() {
+		// Arrange
+		mockUsecase := new(MockCommentUsecase)
+		controller := NewCommentController(mockUsecase)
+		router := gin.New()
+		router.GET("/comments/:commentID/thread", controller.GetThreadPath)
+		mockUsecase.On("GetThreadPath", mock.Anything, "missing").Return(nil, usecases.ErrNotFound).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/comments/missing/thread", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusNotFound, w.Code)
+	})
+}
+
+func (s *CommentControllerTestSuite) TestSearchCommentsInBlog() {
+	s.Run("Success", func() {
+		// Arrange
+		mockUsecase := new(MockCommentUsecase)
+		controller := NewCommentController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/comments/search", controller.SearchCommentsInBlog)
+		matches := []*domain.Comment{{ID: "c1", BlogID: "blog-abc", Content: "Goroutines are great"}}
+		mockUsecase.On("SearchCommentsInBlog", mock.Anything, "blog-abc", "goroutines", int64(2), int64(5)).Return(matches, int64(6), nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/blogs/blog-abc/comments/search?q=goroutines&page=2&limit=5", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusOK, w.Code)
+		var resp PaginatedCommentResponse
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Require().Len(resp.Data, 1)
+		s.Equal("c1", resp.Data[0].ID)
+		s.Equal(Pagination{Total: 6, Page: 2, Limit: 5}, resp.Pagination)
+		mockUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure - Missing query", func() {
+		// Arrange
+		mockUsecase := new(MockCommentUsecase)
+		controller := NewCommentController(mockUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/comments/search", controller.SearchCommentsInBlog)
+
+		req := httptest.NewRequest(http.MethodGet, "/blogs/blog-abc/comments/search?q=%20", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		s.Equal(http.StatusBadRequest, w.Code)
+		s.Contains(w.Body.String(), `"code":"`+CodeInvalidQueryParameter+`"`)
+		mockUsecase.AssertNotCalled(s.T(), "SearchCommentsInBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- Response DTOs ---
+
+type SentEmailResponse struct {
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// --- Controller ---
+
+// EmailDebugController lets admins read the emails captured by the log-only email service.
+// It is only wired up when real emails are not being sent.
+type EmailDebugController struct {
+	outbox domain.IEmailOutbox
+}
+
+func NewEmailDebugController(outbox domain.IEmailOutbox) *EmailDebugController {
+	return &EmailDebugController{
+		outbox: outbox,
+	}
+}
+
+// ListEmails returns the captured emails, most recent first.
+func (ec *EmailDebugController) ListEmails(c *gin.Context) {
+	sent := ec.outbox.Sent()
+	resp := make([]SentEmailResponse, len(sent))
+	for i, email := range sent {
+		resp[i] = SentEmailResponse{
+			To:      email.To,
+			Subject: email.Subject,
+			Body:    email.Body,
+			SentAt:  email.SentAt,
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"data": resp})
+}
@@ -0,0 +1,57 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubEmailOutbox []domain.SentEmail
+
+func (s stubEmailOutbox) Sent() []domain.SentEmail { return s }
+
+func TestEmailDebugController_ListEmails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sentAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success - Returns Captured Emails", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/admin/debug/emails", controllers.NewEmailDebugController(stubEmailOutbox{
+			{To: "alice@example.com", Subject: "Activate Your Account", Body: "token activate123", SentAt: sentAt},
+		}).ListEmails)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/debug/emails", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data []controllers.SentEmailResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Data, 1)
+		assert.Equal(t, "alice@example.com", resp.Data[0].To)
+		assert.Equal(t, "Activate Your Account", resp.Data[0].Subject)
+		assert.Equal(t, "token activate123", resp.Data[0].Body)
+		assert.True(t, sentAt.Equal(resp.Data[0].SentAt))
+	})
+
+	t.Run("Success - Empty Outbox Is An Empty List", func(t *testing.T) {
+		router := gin.New()
+		router.GET("/admin/debug/emails", controllers.NewEmailDebugController(stubEmailOutbox{}).ListEmails)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/debug/emails", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data":[]}`, w.Body.String())
+	})
+}
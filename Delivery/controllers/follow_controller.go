@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- Response DTOs ---
+
+// FollowResponse is one entry of a followers or following list: the other user and when the follow began.
+type FollowResponse struct {
+	UserID     string    `json:"user_id"`
+	FollowedAt time.Time `json:"followed_at"`
+}
+
+type PaginatedFollowResponse struct {
+	Data       []FollowResponse `json:"data"`
+	Pagination Pagination       `json:"pagination"`
+}
+
+// --- Controller ---
+
+type FollowController struct {
+	followUsecase domain.IFollowUsecase
+}
+
+func NewFollowController(followUsecase domain.IFollowUsecase) *FollowController {
+	return &FollowController{
+		followUsecase: followUsecase,
+	}
+}
+
+// Follow makes the current user follow the user in the path. Following again is a no-op.
+func (fc *FollowController) Follow(c *gin.Context) {
+	if err := fc.followUsecase.Follow(c.Request.Context(), c.GetString("userID"), c.Param("userID")); err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Unfollow stops the current user following the user in the path. Unfollowing again is a no-op.
+func (fc *FollowController) Unfollow(c *gin.Context) {
+	if err := fc.followUsecase.Unfollow(c.Request.Context(), c.GetString("userID"), c.Param("userID")); err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListFollowers returns who follows the user in the path, most recent first.
+func (fc *FollowController) ListFollowers(c *gin.Context) {
+	fc.list(c, fc.followUsecase.ListFollowers, func(f *domain.Follow) string { return f.FollowerID })
+}
+
+// ListFollowing returns who the user in the path follows, most recent first.
+func (fc *FollowController) ListFollowing(c *gin.Context) {
+	fc.list(c, fc.followUsecase.ListFollowing, func(f *domain.Follow) string { return f.FolloweeID })
+}
+
+func (fc *FollowController) list(
+	c *gin.Context,
+	fetch func(ctx context.Context, userID string, page, limit int64) ([]*domain.Follow, int64, error),
+	otherUser func(*domain.Follow) string,
+) {
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+
+	follows, total, err := fetch(c.Request.Context(), c.Param("userID"), page, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	data := make([]FollowResponse, len(follows))
+	for i, f := range follows {
+		data[i] = FollowResponse{UserID: otherUser(f), FollowedAt: f.CreatedAt}
+	}
+	pagination := Pagination{Total: total, Page: page, Limit: limit}
+	setLinkHeader(c, pagination)
+	c.JSON(http.StatusOK, PaginatedFollowResponse{
+		Data:       data,
+		Pagination: pagination,
+	})
+}
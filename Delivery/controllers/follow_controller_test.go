@@ -0,0 +1,161 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mock IFollowUsecase ---
+type MockFollowUsecase struct {
+	mock.Mock
+}
+
+func (m *MockFollowUsecase) Follow(ctx context.Context, followerID, followeeID string) error {
+	args := m.Called(ctx, followerID, followeeID)
+	return args.Error(0)
+}
+
+func (m *MockFollowUsecase) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	args := m.Called(ctx, followerID, followeeID)
+	return args.Error(0)
+}
+
+func (m *MockFollowUsecase) ListFollowers(ctx context.Context, userID string, page, limit int64) ([]*domain.Follow, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	var follows []*domain.Follow
+	if args.Get(0) != nil {
+		follows = args.Get(0).([]*domain.Follow)
+	}
+	return follows, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockFollowUsecase) ListFollowing(ctx context.Context, userID string, page, limit int64) ([]*domain.Follow, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	var follows []*domain.Follow
+	if args.Get(0) != nil {
+		follows = args.Get(0).([]*domain.Follow)
+	}
+	return follows, args.Get(1).(int64), args.Error(2)
+}
+
+func setupFollowRouter(mockUsecase *MockFollowUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	fc := controllers.NewFollowController(mockUsecase)
+	auth := func(c *gin.Context) { c.Set("userID", "user-1") }
+	router.POST("/users/:userID/follow", auth, fc.Follow)
+	router.DELETE("/users/:userID/follow", auth, fc.Unfollow)
+	router.GET("/users/:userID/followers", fc.ListFollowers)
+	router.GET("/users/:userID/following", fc.ListFollowing)
+	return router
+}
+
+func TestFollowController_Follow(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase := new(MockFollowUsecase)
+		router := setupFollowRouter(mockUsecase)
+		mockUsecase.On("Follow", mock.Anything, "user-1", "user-2").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users/user-2/follow", nil))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Cannot Follow Self", func(t *testing.T) {
+		mockUsecase := new(MockFollowUsecase)
+		router := setupFollowRouter(mockUsecase)
+		mockUsecase.On("Follow", mock.Anything, "user-1", "user-1").Return(domain.ErrCannotFollowSelf).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users/user-1/follow", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), controllers.CodeCannotFollowSelf)
+	})
+
+	t.Run("Followee Not Found", func(t *testing.T) {
+		mockUsecase := new(MockFollowUsecase)
+		router := setupFollowRouter(mockUsecase)
+		mockUsecase.On("Follow", mock.Anything, "user-1", "missing").Return(domain.ErrUserNotFound).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users/missing/follow", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestFollowController_Unfollow(t *testing.T) {
+	mockUsecase := new(MockFollowUsecase)
+	router := setupFollowRouter(mockUsecase)
+	mockUsecase.On("Unfollow", mock.Anything, "user-1", "user-2").Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/users/user-2/follow", nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestFollowController_Lists(t *testing.T) {
+	followedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Followers Show The Follower", func(t *testing.T) {
+		mockUsecase := new(MockFollowUsecase)
+		router := setupFollowRouter(mockUsecase)
+		mockUsecase.On("ListFollowers", mock.Anything, "user-1", int64(2), int64(5)).Return([]*domain.Follow{
+			{FollowerID: "user-2", FolloweeID: "user-1", CreatedAt: followedAt},
+		}, int64(6), nil).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/user-1/followers?page=2&limit=5", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp controllers.PaginatedFollowResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, []controllers.FollowResponse{{UserID: "user-2", FollowedAt: followedAt}}, resp.Data)
+		assert.Equal(t, controllers.Pagination{Total: 6, Page: 2, Limit: 5}, resp.Pagination)
+	})
+
+	t.Run("Following Shows The Followee", func(t *testing.T) {
+		mockUsecase := new(MockFollowUsecase)
+		router := setupFollowRouter(mockUsecase)
+		mockUsecase.On("ListFollowing", mock.Anything, "user-1", int64(1), int64(10)).Return([]*domain.Follow{
+			{FollowerID: "user-1", FolloweeID: "user-3", CreatedAt: followedAt},
+		}, int64(1), nil).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/user-1/following", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp controllers.PaginatedFollowResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, []controllers.FollowResponse{{UserID: "user-3", FollowedAt: followedAt}}, resp.Data)
+	})
+
+	t.Run("Empty List Is An Array", func(t *testing.T) {
+		mockUsecase := new(MockFollowUsecase)
+		router := setupFollowRouter(mockUsecase)
+		mockUsecase.On("ListFollowers", mock.Anything, "user-1", int64(1), int64(10)).Return([]*domain.Follow{}, int64(0), nil).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/user-1/followers", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"data":[]`)
+	})
+}
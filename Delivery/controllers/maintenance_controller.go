@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"net/http"
+
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- Request & Response DTOs ---
+
+type SetMaintenanceRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+type MaintenanceResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+// --- Controller ---
+
+// MaintenanceController lets admins put the whole API into read-only mode, e.g. during migrations.
+type MaintenanceController struct {
+	maintenance domain.IMaintenanceMode
+}
+
+func NewMaintenanceController(maintenance domain.IMaintenanceMode) *MaintenanceController {
+	return &MaintenanceController{
+		maintenance: maintenance,
+	}
+}
+
+// GetStatus reports whether maintenance mode is on.
+func (mc *MaintenanceController) GetStatus(c *gin.Context) {
+	enabled, err := mc.maintenance.Enabled(c.Request.Context())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, MaintenanceResponse{Enabled: enabled})
+}
+
+// SetStatus turns maintenance mode on or off for every instance.
+func (mc *MaintenanceController) SetStatus(c *gin.Context) {
+	var req SetMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+
+	if err := mc.maintenance.SetEnabled(c.Request.Context(), *req.Enabled); err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, MaintenanceResponse{Enabled: *req.Enabled})
+}
@@ -0,0 +1,91 @@
+package controllers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubMaintenanceMode struct {
+	enabled bool
+	err     error
+}
+
+func (s *stubMaintenanceMode) Enabled(ctx context.Context) (bool, error) { return s.enabled, s.err }
+
+func (s *stubMaintenanceMode) SetEnabled(ctx context.Context, enabled bool) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.enabled = enabled
+	return nil
+}
+
+func TestMaintenanceController(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setup := func(stub *stubMaintenanceMode) *gin.Engine {
+		controller := controllers.NewMaintenanceController(stub)
+		router := gin.New()
+		router.GET("/admin/maintenance", controller.GetStatus)
+		router.PUT("/admin/maintenance", controller.SetStatus)
+		return router
+	}
+
+	t.Run("Get Status", func(t *testing.T) {
+		router := setup(&stubMaintenanceMode{enabled: true})
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/maintenance", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"enabled": true}`, w.Body.String())
+	})
+
+	t.Run("Switch On And Off", func(t *testing.T) {
+		stub := &stubMaintenanceMode{}
+		router := setup(stub)
+
+		for _, enabled := range []string{"true", "false"} {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/admin/maintenance", strings.NewReader(`{"enabled": `+enabled+`}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, `{"enabled": `+enabled+`}`, w.Body.String())
+			assert.Equal(t, enabled == "true", stub.enabled)
+		}
+	})
+
+	t.Run("Missing Flag", func(t *testing.T) {
+		stub := &stubMaintenanceMode{enabled: true}
+		router := setup(stub)
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/admin/maintenance", strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"`+controllers.CodeInvalidRequestBody+`"`)
+		assert.True(t, stub.enabled, "A bad request should leave the mode unchanged")
+	})
+
+	t.Run("Store Error", func(t *testing.T) {
+		router := setup(&stubMaintenanceMode{err: errors.New("redis down")})
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/maintenance", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// maxMarkdownImportMemory is how much of a markdown upload is held in memory; the rest spills to disk.
+	maxMarkdownImportMemory = 32 << 20
+	// maxMarkdownFileSize caps a single uploaded post.
+	maxMarkdownFileSize = 1 << 20
+
+	frontMatterDelimiter = "---"
+)
+
+// frontMatterDateLayouts are the date formats accepted in the "date" key, most precise first.
+var frontMatterDateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+var (
+	errMissingFrontMatter      = errors.New("file does not start with a --- front-matter block")
+	errUnterminatedFrontMatter = errors.New("front-matter block is not closed with ---")
+	errMissingTitle            = errors.New("front-matter has no title")
+	errEmptyMarkdownBody       = errors.New("file has no content after the front-matter")
+)
+
+// ParseMarkdownBlog reads a markdown post whose metadata sits in a front-matter block, as
+// written by static site generators such as Jekyll or Hugo:
+//
+//	---
+//	title: Hello, World
+//	tags: [go, web]
+//	date: 2023-04-01
+//	---
+//	The post itself, in markdown.
+//
+// Tags may also be a comma separated string or a "- item" list. An optional author_id
+// sets the author. Keys the blog has no use for are ignored.
+func ParseMarkdownBlog(data []byte) (domain.BlogImportItem, error) {
+	var item domain.BlogImportItem
+	text := strings.TrimPrefix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\ufeff")
+	lines := strings.Split(text, "\n")
+	if strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return item, errMissingFrontMatter
+	}
+
+	end := -1
+	lastKey := ""
+	for i := 1; i < len(lines) && end < 0; i++ {
+		line := strings.TrimSpace(lines[i])
+		switch {
+		case line == frontMatterDelimiter:
+			end = i
+			continue
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "- "):
+			// A "- value" line continues the list started by a key with no inline value.
+			if lastKey != "tags" {
+				return item, fmt.Errorf("front-matter line %d: unexpected list item", i+1)
+			}
+			item.Tags = append(item.Tags, unquote(strings.TrimSpace(line[2:])))
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			return item, fmt.Errorf("front-matter line %d: expected \"key: value\"", i+1)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		lastKey = key
+
+		switch key {
+		case "title":
+			item.Title = unquote(value)
+		case "author_id":
+			item.AuthorID = unquote(value)
+		case "tags":
+			item.Tags = parseTagList(value)
+		case "date":
+			date, err := parseFrontMatterDate(unquote(value))
+			if err != nil {
+				return item, fmt.Errorf("front-matter line %d: %w", i+1, err)
+			}
+			item.CreatedAt = &date
+		}
+	}
+	if end < 0 {
+		return item, errUnterminatedFrontMatter
+	}
+
+	if item.Title == "" {
+		return item, errMissingTitle
+	}
+	item.Content = strings.TrimSpace(strings.Join(lines[end+1:], "\n"))
+	if item.Content == "" {
+		return item, errEmptyMarkdownBody
+	}
+	return item, nil
+}
+
+// parseTagList accepts both "[a, b]" and "a, b". An empty value leaves room for a "- item" list.
+func parseTagList(value string) []string {
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = unquote(strings.TrimSpace(tag)); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func parseFrontMatterDate(value string) (time.Time, error) {
+	for _, layout := range frontMatterDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("date %q is not in YYYY-MM-DD or RFC 3339 format", value)
+}
+
+// unquote strips one pair of matching single or double quotes.
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// readMarkdownBlog reads and parses one uploaded markdown file.
+func readMarkdownBlog(fh *multipart.FileHeader) (domain.BlogImportItem, error) {
+	if ext := strings.ToLower(filepath.Ext(fh.Filename)); ext != ".md" && ext != ".markdown" {
+		return domain.BlogImportItem{}, errors.New("not a markdown file: expected a .md extension")
+	}
+	if fh.Size > maxMarkdownFileSize {
+		return domain.BlogImportItem{}, fmt.Errorf("file is larger than %d bytes", maxMarkdownFileSize)
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return domain.BlogImportItem{}, errors.New("file could not be read")
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return domain.BlogImportItem{}, errors.New("file could not be read")
+	}
+	return ParseMarkdownBlog(data)
+}
@@ -0,0 +1,167 @@
+package controllers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMarkdownBlog(t *testing.T) {
+	t.Run("Inline Tags And Date", func(t *testing.T) {
+		item, err := controllers.ParseMarkdownBlog([]byte("---\ntitle: \"Hello, World\"\ntags: [go, 'web']\ndate: 2023-04-01\n---\n\n# Hello\n\nBody text.\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Hello, World", item.Title)
+		assert.Equal(t, []string{"go", "web"}, item.Tags)
+		require.NotNil(t, item.CreatedAt)
+		assert.Equal(t, time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), *item.CreatedAt)
+		assert.Equal(t, "# Hello\n\nBody text.", item.Content)
+		assert.Empty(t, item.AuthorID)
+	})
+
+	t.Run("List Tags, RFC 3339 Date, Author And CRLF", func(t *testing.T) {
+		item, err := controllers.ParseMarkdownBlog([]byte("---\r\ntitle: Old Post\r\nauthor_id: author-1\r\ntags:\r\n  - go\r\n  - testing\r\ndate: 2021-05-01T12:00:00Z\r\ndraft: false\r\n---\r\nBody\r\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Old Post", item.Title)
+		assert.Equal(t, "author-1", item.AuthorID)
+		assert.Equal(t, []string{"go", "testing"}, item.Tags)
+		assert.Equal(t, time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC), *item.CreatedAt)
+		assert.Equal(t, "Body", item.Content)
+	})
+
+	malformed := []struct {
+		name     string
+		input    string
+		contains string
+	}{
+		{"No Front-Matter", "# Just markdown\n", "does not start"},
+		{"Unterminated Front-Matter", "---\ntitle: x\n", "not closed"},
+		{"Missing Title", "---\ntags: go\n---\nBody", "no title"},
+		{"Empty Body", "---\ntitle: x\n---\n\n", "no content"},
+		{"Line Without A Key", "---\ntitle: x\njust words\n---\nBody", "line 3"},
+		{"Stray List Item", "---\ntitle: x\n- go\n---\nBody", "unexpected list item"},
+		{"Bad Date", "---\ntitle: x\ndate: 01/04/2023\n---\nBody", "date"},
+	}
+	for _, tc := range malformed {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := controllers.ParseMarkdownBlog([]byte(tc.input))
+			assert.ErrorContains(t, err, tc.contains)
+		})
+	}
+}
+
+func newMarkdownImportRequest(t *testing.T, files map[string]string, order ...string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, name := range order {
+		part, err := writer.CreateFormFile("files", name)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(files[name]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/blogs/import-markdown", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestImportMarkdownBlogs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setup := func() (*MockBlogUsecase, *gin.Engine) {
+		mockUsecase := new(MockBlogUsecase)
+		router := gin.New()
+		router.POST("/admin/blogs/import-markdown", func(c *gin.Context) { c.Set("userID", "admin-1") }, controllers.NewBlogController(mockUsecase).ImportMarkdownBlogs)
+		return mockUsecase, router
+	}
+
+	t.Run("Imports Valid Files And Reports The Rest", func(t *testing.T) {
+		mockUsecase, router := setup()
+		createdAt := time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)
+		expectedItems := []domain.BlogImportItem{
+			{Title: "First", Content: "One", AuthorID: "admin-1", Tags: []string{"go"}, CreatedAt: &createdAt},
+			{Title: "Third", Content: "Three", AuthorID: "author-9"},
+		}
+		mockUsecase.On("ImportBlogs", mock.Anything, "admin-1", expectedItems).Return([]domain.BlogImportResult{
+			{Index: 0, BlogID: "blog-1"},
+			{Index: 1, Err: domain.ErrUserNotFound},
+		}, nil).Once()
+		files := map[string]string{
+			"first.md":  "---\ntitle: First\ntags: [go]\ndate: 2023-04-01\n---\nOne",
+			"second.md": "---\ntags: [go]\n---\nTwo",
+			"third.md":  "---\ntitle: Third\nauthor_id: author-9\n---\nThree",
+			"notes.txt": "---\ntitle: Notes\n---\nText",
+		}
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, newMarkdownImportRequest(t, files, "first.md", "second.md", "third.md", "notes.txt"))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp controllers.ImportBlogsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.Imported)
+		assert.Equal(t, 3, resp.Failed)
+		require.Len(t, resp.Results, 4)
+		assert.Equal(t, controllers.ImportBlogResult{Index: 0, File: "first.md", ID: "blog-1"}, resp.Results[0])
+		assert.Equal(t, "second.md", resp.Results[1].File)
+		assert.Contains(t, resp.Results[1].Error, "no title")
+		assert.Equal(t, 2, resp.Results[2].Index)
+		assert.Equal(t, "third.md", resp.Results[2].File)
+		assert.NotEmpty(t, resp.Results[2].Error)
+		assert.Contains(t, resp.Results[3].Error, "not a markdown file")
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Every File Malformed Skips The Import", func(t *testing.T) {
+		mockUsecase, router := setup()
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, newMarkdownImportRequest(t, map[string]string{"bad.md": "no front-matter"}, "bad.md"))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp controllers.ImportBlogsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 0, resp.Imported)
+		assert.Equal(t, 1, resp.Failed)
+		mockUsecase.AssertNotCalled(t, "ImportBlogs", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("No Files", func(t *testing.T) {
+		mockUsecase, router := setup()
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, newMarkdownImportRequest(t, nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"`+controllers.CodeInvalidBatchSize+`"`)
+		mockUsecase.AssertNotCalled(t, "ImportBlogs", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Not A Multipart Form", func(t *testing.T) {
+		mockUsecase, router := setup()
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/admin/blogs/import-markdown", strings.NewReader(`{"files": []}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"`+controllers.CodeInvalidRequestBody+`"`)
+		mockUsecase.AssertNotCalled(t, "ImportBlogs", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
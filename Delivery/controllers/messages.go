@@ -0,0 +1,273 @@
+package controllers
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Machine-readable error codes. These are part of the API contract and never change
+// with the request language, so clients should branch on them rather than on messages.
+const (
+	CodePasswordTooShort       = "PASSWORD_TOO_SHORT"
+	CodeInvalidEmailFormat     = "INVALID_EMAIL_FORMAT"
+	CodeInvalidRole            = "INVALID_ROLE"
+	CodeUsernameEmpty          = "USERNAME_EMPTY"
+	CodeUsernameTooLong        = "USERNAME_TOO_LONG"
+	CodeUsernameInvalid        = "USERNAME_INVALID"
+	CodeValidationFailed       = "VALIDATION_FAILED"
+	CodeInvalidRequestBody     = "INVALID_REQUEST_BODY"
+	CodeInvalidPagination      = "INVALID_PAGINATION"
+	CodeInvalidQueryParameter  = "INVALID_QUERY_PARAMETER"
+	CodeInvalidBatchSize       = "INVALID_BATCH_SIZE"
+	CodeAuthenticationFailed   = "AUTHENTICATION_FAILED"
+	CodeInvalidActivationToken = "INVALID_ACTIVATION_TOKEN"
+	CodeInvalidResetToken      = "INVALID_RESET_TOKEN"
+	CodeInvalidEmailChange     = "INVALID_EMAIL_CHANGE_TOKEN"
+	CodeInvalidOAuthState      = "INVALID_OAUTH_STATE"
+	CodePermissionDenied       = "PERMISSION_DENIED"
+	CodeCannotChangeOwnRole    = "CANNOT_CHANGE_OWN_ROLE"
+	CodeOAuthUser              = "OAUTH_USER"
+	CodeAccountNotActive       = "ACCOUNT_NOT_ACTIVE"
+	CodeUserNotFound           = "USER_NOT_FOUND"
+	CodeNotFound               = "NOT_FOUND"
+	CodeEmailExists            = "EMAIL_EXISTS"
+	CodeUsernameExists         = "USERNAME_EXISTS"
+	CodeConflict               = "CONFLICT"
+	CodeContentRejected        = "CONTENT_REJECTED"
+	CodeCommentLimitReached    = "COMMENT_LIMIT_REACHED"
+	CodeTagExists              = "TAG_EXISTS"
+	CodeCannotFollowSelf       = "CANNOT_FOLLOW_SELF"
+	CodeTooManyRequests        = "TOO_MANY_REQUESTS"
+	CodeQuotaExceeded          = "QUOTA_EXCEEDED"
+	CodeAccountTooNew          = "ACCOUNT_TOO_NEW"
+	CodeRedirectNotAllowed     = "REDIRECT_URI_NOT_ALLOWED"
+	CodeCannotUnlink           = "CANNOT_UNLINK"
+	CodeImageHostNotAllowed    = "IMAGE_HOST_NOT_ALLOWED"
+	CodeDeleteWindowExpired    = "DELETE_WINDOW_EXPIRED"
+	CodeInternalError          = "INTERNAL_ERROR"
+)
+
+const defaultLanguage = "en"
+
+// errorMappings maps known errors to their HTTP status and code. The first match wins.
+var errorMappings = []struct {
+	err    error
+	status int
+	code   string
+}{
+	// --- 400 Bad Request ---
+	{domain.ErrPasswordTooShort, http.StatusBadRequest, CodePasswordTooShort},
+	{domain.ErrInvalidEmailFormat, http.StatusBadRequest, CodeInvalidEmailFormat},
+	{domain.ErrInvalidRole, http.StatusBadRequest, CodeInvalidRole},
+	{domain.ErrUsernameEmpty, http.StatusBadRequest, CodeUsernameEmpty},
+	{domain.ErrUsernameTooLong, http.StatusBadRequest, CodeUsernameTooLong},
+	{domain.ErrUsernameInvalid, http.StatusBadRequest, CodeUsernameInvalid},
+	{domain.ErrValidation, http.StatusBadRequest, CodeValidationFailed},
+	{domain.ErrCannotFollowSelf, http.StatusBadRequest, CodeCannotFollowSelf},
+	{domain.ErrRedirectNotAllowed, http.StatusBadRequest, CodeRedirectNotAllowed},
+
+	// --- 401 Unauthorized ---
+	{domain.ErrAuthenticationFailed, http.StatusUnauthorized, CodeAuthenticationFailed},
+	{domain.ErrInvalidActivationToken, http.StatusUnauthorized, CodeInvalidActivationToken},
+	{domain.ErrInvalidResetToken, http.StatusUnauthorized, CodeInvalidResetToken},
+	{domain.ErrInvalidEmailChangeToken, http.StatusUnauthorized, CodeInvalidEmailChange},
+	{domain.ErrInvalidOAuthState, http.StatusUnauthorized, CodeInvalidOAuthState},
+
+	// --- 403 Forbidden ---
+	{domain.ErrPermissionDenied, http.StatusForbidden, CodePermissionDenied},
+	{domain.ErrCannotChangeOwnRole, http.StatusForbidden, CodeCannotChangeOwnRole},
+	{domain.ErrOAuthUser, http.StatusForbidden, CodeOAuthUser},
+	{domain.ErrAccountNotActive, http.StatusForbidden, CodeAccountNotActive},
+	{domain.ErrAccountTooNew, http.StatusForbidden, CodeAccountTooNew},
+	{domain.ErrDeleteWindowExpired, http.StatusForbidden, CodeDeleteWindowExpired},
+
+	// --- 404 Not Found ---
+	{domain.ErrUserNotFound, http.StatusNotFound, CodeUserNotFound},
+	{usecases.ErrNotFound, http.StatusNotFound, CodeNotFound},
+
+	// --- 409 Conflict ---
+	{domain.ErrEmailExists, http.StatusConflict, CodeEmailExists},
+	{domain.ErrUsernameExists, http.StatusConflict, CodeUsernameExists},
+	{usecases.ErrConflict, http.StatusConflict, CodeConflict},
+	{domain.ErrCommentLimitReached, http.StatusConflict, CodeCommentLimitReached},
+	{domain.ErrTagExists, http.StatusConflict, CodeTagExists},
+	{domain.ErrCannotUnlink, http.StatusConflict, CodeCannotUnlink},
+
+	// --- 422 Unprocessable Entity ---
+	{domain.ErrContentRejected, http.StatusUnprocessableEntity, CodeContentRejected},
+	{domain.ErrImageHostNotAllowed, http.StatusUnprocessableEntity, CodeImageHostNotAllowed},
+
+	// --- 429 Too Many Requests ---
+	{domain.ErrTooManyRequests, http.StatusTooManyRequests, CodeTooManyRequests},
+
+	// --- 503 Service Unavailable ---
+	{domain.ErrQuotaExceeded, http.StatusServiceUnavailable, CodeQuotaExceeded},
+}
+
+// messageCatalog holds the user-facing message for each code, per language.
+// English reuses the error texts so existing clients see the same messages as before.
+var messageCatalog = map[string]map[string]string{
+	"en": {
+		CodePasswordTooShort:       domain.ErrPasswordTooShort.Error(),
+		CodeInvalidEmailFormat:     domain.ErrInvalidEmailFormat.Error(),
+		CodeInvalidRole:            domain.ErrInvalidRole.Error(),
+		CodeUsernameEmpty:          domain.ErrUsernameEmpty.Error(),
+		CodeUsernameTooLong:        domain.ErrUsernameTooLong.Error(),
+		CodeUsernameInvalid:        domain.ErrUsernameInvalid.Error(),
+		CodeValidationFailed:       "Invalid input provided",
+		CodeInvalidRequestBody:     "Invalid request body",
+		CodeInvalidPagination:      "Invalid pagination parameters",
+		CodeInvalidQueryParameter:  "Invalid query parameter",
+		CodeInvalidBatchSize:       "Invalid number of items in the batch",
+		CodeAuthenticationFailed:   domain.ErrAuthenticationFailed.Error(),
+		CodeInvalidActivationToken: domain.ErrInvalidActivationToken.Error(),
+		CodeInvalidResetToken:      domain.ErrInvalidResetToken.Error(),
+		CodeInvalidEmailChange:     domain.ErrInvalidEmailChangeToken.Error(),
+		CodeInvalidOAuthState:      domain.ErrInvalidOAuthState.Error(),
+		CodePermissionDenied:       domain.ErrPermissionDenied.Error(),
+		CodeCannotChangeOwnRole:    domain.ErrCannotChangeOwnRole.Error(),
+		CodeOAuthUser:              domain.ErrOAuthUser.Error(),
+		CodeAccountNotActive:       domain.ErrAccountNotActive.Error(),
+		CodeUserNotFound:           domain.ErrUserNotFound.Error(),
+		CodeNotFound:               usecases.ErrNotFound.Error(),
+		CodeEmailExists:            domain.ErrEmailExists.Error(),
+		CodeUsernameExists:         domain.ErrUsernameExists.Error(),
+		CodeConflict:               usecases.ErrConflict.Error(),
+		CodeContentRejected:        domain.ErrContentRejected.Error(),
+		CodeCommentLimitReached:    domain.ErrCommentLimitReached.Error(),
+		CodeTagExists:              domain.ErrTagExists.Error(),
+		CodeCannotFollowSelf:       domain.ErrCannotFollowSelf.Error(),
+		CodeTooManyRequests:        domain.ErrTooManyRequests.Error(),
+		CodeQuotaExceeded:          domain.ErrQuotaExceeded.Error(),
+		CodeAccountTooNew:          domain.ErrAccountTooNew.Error(),
+		CodeRedirectNotAllowed:     domain.ErrRedirectNotAllowed.Error(),
+		CodeCannotUnlink:           domain.ErrCannotUnlink.Error(),
+		CodeImageHostNotAllowed:    domain.ErrImageHostNotAllowed.Error(),
+		CodeDeleteWindowExpired:    domain.ErrDeleteWindowExpired.Error(),
+		CodeInternalError:          "An unexpected internal error occurred. Please try again later.",
+	},
+	"fr": {
+		CodePasswordTooShort:       "le mot de passe doit contenir au moins 8 caractères",
+		CodeInvalidEmailFormat:     "format d'e-mail invalide",
+		CodeInvalidRole:            "rôle fourni invalide",
+		CodeUsernameEmpty:          "le nom d'utilisateur ne peut pas être vide",
+		CodeUsernameTooLong:        "le nom d'utilisateur ne peut pas dépasser 50 caractères",
+		CodeUsernameInvalid:        "le nom d'utilisateur ne peut contenir que des lettres, des chiffres et des tirets bas",
+		CodeValidationFailed:       "Données fournies invalides",
+		CodeInvalidRequestBody:     "Corps de requête invalide",
+		CodeInvalidPagination:      "Paramètres de pagination invalides",
+		CodeInvalidQueryParameter:  "Paramètre de requête invalide",
+		CodeInvalidBatchSize:       "Nombre d'éléments du lot invalide",
+		CodeAuthenticationFailed:   "échec de l'authentification : identifiants invalides",
+		CodeInvalidActivationToken: "jeton d'activation invalide ou expiré",
+		CodeInvalidResetToken:      "jeton de réinitialisation du mot de passe invalide ou expiré",
+		CodeInvalidEmailChange:     "jeton de changement d'adresse e-mail invalide ou expiré",
+		CodeInvalidOAuthState:      "état OAuth invalide ou expiré",
+		CodePermissionDenied:       "permission refusée",
+		CodeCannotChangeOwnRole:    "les administrateurs ne peuvent pas modifier leur propre rôle",
+		CodeOAuthUser:              "cette action ne s'applique pas à un compte créé avec un fournisseur externe",
+		CodeAccountNotActive:       "ce compte n'a pas été activé",
+		CodeUserNotFound:           "utilisateur introuvable",
+		CodeNotFound:               "introuvable",
+		CodeEmailExists:            "un utilisateur avec cette adresse e-mail existe déjà",
+		CodeUsernameExists:         "ce nom d'utilisateur existe déjà",
+		CodeConflict:               "conflit de ressource ou ressource déjà existante",
+		CodeContentRejected:        "le contenu contient un langage non autorisé",
+		CodeCommentLimitReached:    "ce blog a atteint son nombre maximal de commentaires",
+		CodeTagExists:              "un tag portant ce nom existe déjà",
+		CodeCannotFollowSelf:       "les utilisateurs ne peuvent pas se suivre eux-mêmes",
+		CodeTooManyRequests:        "trop de requêtes, veuillez ralentir",
+		CodeQuotaExceeded:          "le service d'IA est occupé, veuillez réessayer plus tard",
+		CodeAccountTooNew:          "ce compte est trop récent pour publier pour le moment",
+		CodeRedirectNotAllowed:     "l'URI de redirection n'est pas autorisée",
+		CodeCannotUnlink:           "définissez un mot de passe avant de dissocier le fournisseur de connexion",
+		CodeImageHostNotAllowed:    "le contenu intègre une image provenant d'un hôte non autorisé",
+		CodeDeleteWindowExpired:    "ce commentaire ne peut plus être supprimé par son auteur",
+		CodeInternalError:          "Une erreur interne inattendue s'est produite. Veuillez réessayer plus tard.",
+	},
+}
+
+// localize returns the message for a code in the request's preferred language,
+// falling back to English when the language or the message is missing.
+func localize(c *gin.Context, code string) string {
+	lang := preferredLanguage(c.GetHeader("Accept-Language"))
+	if msg, ok := messageCatalog[lang][code]; ok {
+		return msg
+	}
+	return messageCatalog[defaultLanguage][code]
+}
+
+// preferredLanguage picks the supported language with the highest quality value from an
+// Accept-Language header, e.g. "fr-CA,fr;q=0.9,en;q=0.8". Region subtags are ignored.
+func preferredLanguage(header string) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if _, ok := messageCatalog[lang]; !ok {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{lang: lang, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return defaultLanguage
+	}
+	// Stable, so equal weights keep the client's order.
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
+
+// HandleBindingError responds to a request body that failed to bind or validate.
+// The binding details are passed through untranslated to help with debugging. Validation
+// failures also list each failed rule under "fields".
+func HandleBindingError(c *gin.Context, err error) {
+	body := gin.H{
+		"error":   localize(c, CodeInvalidRequestBody),
+		"code":    CodeInvalidRequestBody,
+		"details": err.Error(),
+	}
+	if fields := bindingFieldErrors(err); fields != nil {
+		body["fields"] = fields
+	}
+	c.JSON(http.StatusBadRequest, body)
+}
+
+// abortInvalidQueryParameter responds to a query parameter that failed validation. Like the
+// pagination errors, the reason goes under "details" and is not translated.
+func abortInvalidQueryParameter(c *gin.Context, details string) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+		"error":   localize(c, CodeInvalidQueryParameter),
+		"code":    CodeInvalidQueryParameter,
+		"details": details,
+	})
+}
+
+// abortInvalidBatchSize responds to a batch request with too few or too many items. The
+// details give the allowed range.
+func abortInvalidBatchSize(c *gin.Context, details string) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+		"error":   localize(c, CodeInvalidBatchSize),
+		"code":    CodeInvalidBatchSize,
+		"details": details,
+	})
+}
@@ -0,0 +1,169 @@
+package controllers_test
+
+import (
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// performWithLanguage runs a handler that fails with err and decodes the error response.
+func performWithLanguage(t *testing.T, handler gin.HandlerFunc, acceptLanguage string) (int, map[string]string) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/", handler)
+
+	req, _ := http.NewRequest(http.MethodPost, "/", nil)
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return w.Code, body
+}
+
+func TestHandleError_Localized(t *testing.T) {
+	handler := func(c *gin.Context) { controllers.HandleError(c, domain.ErrUserNotFound) }
+
+	testCases := []struct {
+		name            string
+		acceptLanguage  string
+		expectedMessage string
+	}{
+		{"No Header Defaults To English", "", domain.ErrUserNotFound.Error()},
+		{"English", "en-US", domain.ErrUserNotFound.Error()},
+		{"French", "fr-FR,fr;q=0.9,en;q=0.8", "utilisateur introuvable"},
+		{"Quality Values Are Respected", "fr;q=0.5,en;q=0.9", domain.ErrUserNotFound.Error()},
+		{"Unsupported Language Falls Back To English", "de-DE", domain.ErrUserNotFound.Error()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status, body := performWithLanguage(t, handler, tc.acceptLanguage)
+
+			assert.Equal(t, http.StatusNotFound, status)
+			assert.Equal(t, tc.expectedMessage, body["error"])
+			assert.Equal(t, controllers.CodeUserNotFound, body["code"], "The code must not depend on the language")
+		})
+	}
+}
+
+func TestHandleError_UnknownErrorIsLocalized(t *testing.T) {
+	handler := func(c *gin.Context) { controllers.HandleError(c, errors.New("database exploded")) }
+
+	_, english := performWithLanguage(t, handler, "en")
+	status, french := performWithLanguage(t, handler, "fr")
+
+	assert.Equal(t, http.StatusInternalServerError, status)
+	assert.NotEqual(t, english["error"], french["error"])
+	assert.NotContains(t, french["error"], "database exploded", "Internal details must not leak")
+	assert.Equal(t, controllers.CodeInternalError, english["code"])
+	assert.Equal(t, controllers.CodeInternalError, french["code"])
+}
+
+func TestHandleBindingError_Localized(t *testing.T) {
+	handler := func(c *gin.Context) {
+		var req struct {
+			Email string `json:"email" binding:"required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			controllers.HandleBindingError(c, err)
+		}
+	}
+
+	status, english := performWithLanguage(t, handler, "")
+	_, french := performWithLanguage(t, handler, "fr")
+
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "Invalid request body", english["error"])
+	assert.Equal(t, "Corps de requête invalide", french["error"])
+	assert.Equal(t, controllers.CodeInvalidRequestBody, english["code"])
+	assert.Equal(t, english["code"], french["code"])
+	assert.NotEmpty(t, french["details"])
+}
+
+func TestHandleBindingError_FieldErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/", func(c *gin.Context) {
+		var req struct {
+			Name   string   `json:"name" binding:"required,min=3"`
+			Status string   `json:"status" binding:"oneof=draft published"`
+			Tags   []string `json:"tags" binding:"max=2,dive,required"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			controllers.HandleBindingError(c, err)
+		}
+	})
+
+	run := func(body string) (int, map[string]interface{}) {
+		req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return w.Code, resp
+	}
+
+	t.Run("Validation failures are listed per field", func(t *testing.T) {
+		status, resp := run(`{"name":"ab","status":"archived","tags":["go",""]}`)
+
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, controllers.CodeInvalidRequestBody, resp["code"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"field": "name", "rule": "min", "message": "name must be at least 3 characters long"},
+			map[string]interface{}{"field": "status", "rule": "oneof", "message": "status must be one of: draft, published"},
+			map[string]interface{}{"field": "tags[1]", "rule": "required", "message": "tags[1] is required"},
+		}, resp["fields"])
+	})
+
+	t.Run("Malformed JSON has no field list", func(t *testing.T) {
+		status, resp := run(`{"name":`)
+
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.NotEmpty(t, resp["details"])
+		assert.NotContains(t, resp, "fields")
+	})
+}
+
+func TestHandleError_ContentRejected(t *testing.T) {
+	handler := func(c *gin.Context) { controllers.HandleError(c, domain.ErrContentRejected) }
+
+	status, body := performWithLanguage(t, handler, "")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, controllers.CodeContentRejected, body["code"])
+	assert.Equal(t, domain.ErrContentRejected.Error(), body["error"])
+}
+
+func TestHandleError_QuotaExceeded(t *testing.T) {
+	handler := func(c *gin.Context) { controllers.HandleError(c, domain.ErrQuotaExceeded) }
+
+	status, body := performWithLanguage(t, handler, "")
+
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, controllers.CodeQuotaExceeded, body["code"])
+	assert.Equal(t, domain.ErrQuotaExceeded.Error(), body["error"])
+}
+
+func TestHandleError_AccountTooNew(t *testing.T) {
+	handler := func(c *gin.Context) { controllers.HandleError(c, domain.ErrAccountTooNew) }
+
+	status, body := performWithLanguage(t, handler, "fr")
+
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, controllers.CodeAccountTooNew, body["code"])
+	assert.Equal(t, "ce compte est trop récent pour publier pour le moment", body["error"])
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"net/http"
+
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- Response DTOs ---
+
+// ModerationItemResponse carries either the blog or the comment, depending on kind.
+type ModerationItemResponse struct {
+	Kind    string           `json:"kind"`
+	Blog    *BlogResponse    `json:"blog,omitempty"`
+	Comment *CommentResponse `json:"comment,omitempty"`
+}
+
+type PaginatedModerationResponse struct {
+	Data       []ModerationItemResponse `json:"data"`
+	Pagination Pagination               `json:"pagination"`
+}
+
+// --- Controller ---
+
+type ModerationController struct {
+	moderationUsecase domain.IModerationUsecase
+}
+
+func NewModerationController(moderationUsecase domain.IModerationUsecase) *ModerationController {
+	return &ModerationController{
+		moderationUsecase: moderationUsecase,
+	}
+}
+
+// GetQueue lists the blogs, or with kind=comment the comments, waiting for approval.
+func (mc *ModerationController) GetQueue(c *gin.Context) {
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+	kind := domain.ContentKind(c.DefaultQuery("kind", string(domain.ContentKindBlog)))
+	if !kind.IsValid() {
+		abortInvalidQueryParameter(c, "Invalid 'kind' parameter: must be 'blog' or 'comment'")
+		return
+	}
+
+	items, total, err := mc.moderationUsecase.GetQueue(c.Request.Context(), kind, page, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	resp := PaginatedModerationResponse{
+		Data: make([]ModerationItemResponse, len(items)),
+		Pagination: Pagination{
+			Total: total,
+			Page:  page,
+			Limit: limit,
+		},
+	}
+	for i, item := range items {
+		resp.Data[i] = ModerationItemResponse{Kind: string(item.Kind)}
+		if item.Blog != nil {
+			blog := toBlogResponse(item.Blog)
+			resp.Data[i].Blog = &blog
+		}
+		if item.Comment != nil {
+			comment := toCommentResponse(item.Comment)
+			resp.Data[i].Comment = &comment
+		}
+	}
+	setLinkHeader(c, resp.Pagination)
+	c.JSON(http.StatusOK, resp)
+}
+
+// Approve publishes a pending blog or comment.
+func (mc *ModerationController) Approve(c *gin.Context) {
+	kind, ok := contentKindParam(c)
+	if !ok {
+		return
+	}
+	if err := mc.moderationUsecase.ApproveContent(c.Request.Context(), c.GetString("userID"), kind, c.Param("contentID")); err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Reject removes a pending blog or comment.
+func (mc *ModerationController) Reject(c *gin.Context) {
+	kind, ok := contentKindParam(c)
+	if !ok {
+		return
+	}
+	if err := mc.moderationUsecase.RejectContent(c.Request.Context(), c.GetString("userID"), kind, c.Param("contentID")); err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// contentKindParam reads the kind path parameter. It writes a 400 response and returns false
+// when the kind is unknown.
+func contentKindParam(c *gin.Context) (domain.ContentKind, bool) {
+	kind := domain.ContentKind(c.Param("kind"))
+	if !kind.IsValid() {
+		// A path parameter rather than a query one, so it is reported as a plain validation failure.
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error":   localize(c, CodeValidationFailed),
+			"code":    CodeValidationFailed,
+			"details": "Invalid 'kind' parameter: must be 'blog' or 'comment'",
+		})
+		return "", false
+	}
+	return kind, true
+}
@@ -0,0 +1,160 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Mock IModerationUsecase ---
+type MockModerationUsecase struct {
+	mock.Mock
+}
+
+func (m *MockModerationUsecase) GetQueue(ctx context.Context, kind domain.ContentKind, page, limit int64) ([]*domain.ModerationItem, int64, error) {
+	args := m.Called(ctx, kind, page, limit)
+	var items []*domain.ModerationItem
+	if args.Get(0) != nil {
+		items = args.Get(0).([]*domain.ModerationItem)
+	}
+	return items, args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockModerationUsecase) ApproveContent(ctx context.Context, actorID string, kind domain.ContentKind, id string) error {
+	args := m.Called(ctx, actorID, kind, id)
+	return args.Error(0)
+}
+
+func (m *MockModerationUsecase) RejectContent(ctx context.Context, actorID string, kind domain.ContentKind, id string) error {
+	args := m.Called(ctx, actorID, kind, id)
+	return args.Error(0)
+}
+
+func setupModerationRouter(mockUsecase *MockModerationUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", "admin-1")
+		c.Next()
+	})
+	mc := controllers.NewModerationController(mockUsecase)
+	router.GET("/admin/moderation-queue", mc.GetQueue)
+	router.POST("/admin/moderation-queue/:kind/:contentID/approve", mc.Approve)
+	router.POST("/admin/moderation-queue/:kind/:contentID/reject", mc.Reject)
+	return router
+}
+
+func TestModerationController_GetQueue(t *testing.T) {
+	t.Run("Success - Defaults To Blogs", func(t *testing.T) {
+		mockUsecase := new(MockModerationUsecase)
+		router := setupModerationRouter(mockUsecase)
+		items := []*domain.ModerationItem{{Kind: domain.ContentKindBlog, Blog: &domain.Blog{ID: "b1", Title: "Pending", Status: domain.BlogStatusPending}}}
+		mockUsecase.On("GetQueue", mock.Anything, domain.ContentKindBlog, int64(1), int64(10)).Return(items, int64(1), nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/moderation-queue", nil)
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		data := body["data"].([]any)
+		require.Len(t, data, 1)
+		item := data[0].(map[string]any)
+		assert.Equal(t, "blog", item["kind"])
+		assert.Equal(t, "b1", item["blog"].(map[string]any)["id"])
+		assert.NotContains(t, item, "comment")
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Success - Comments", func(t *testing.T) {
+		mockUsecase := new(MockModerationUsecase)
+		router := setupModerationRouter(mockUsecase)
+		items := []*domain.ModerationItem{{Kind: domain.ContentKindComment, Comment: &domain.Comment{ID: "c1", BlogID: "b1"}}}
+		mockUsecase.On("GetQueue", mock.Anything, domain.ContentKindComment, int64(1), int64(10)).Return(items, int64(1), nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/moderation-queue?kind=comment", nil)
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"comment":{"id":"c1"`)
+	})
+
+	t.Run("Failure - Unknown Kind", func(t *testing.T) {
+		mockUsecase := new(MockModerationUsecase)
+		router := setupModerationRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/moderation-queue?kind=user", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"`+controllers.CodeInvalidQueryParameter+`"`)
+		mockUsecase.AssertNotCalled(t, "GetQueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestModerationController_Approve(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase := new(MockModerationUsecase)
+		router := setupModerationRouter(mockUsecase)
+		mockUsecase.On("ApproveContent", mock.Anything, "admin-1", domain.ContentKindComment, "c1").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/moderation-queue/comment/c1/approve", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Not Pending", func(t *testing.T) {
+		mockUsecase := new(MockModerationUsecase)
+		router := setupModerationRouter(mockUsecase)
+		mockUsecase.On("ApproveContent", mock.Anything, "admin-1", domain.ContentKindBlog, "b1").Return(usecases.ErrNotFound).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/moderation-queue/blog/b1/approve", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Failure - Unknown Kind", func(t *testing.T) {
+		mockUsecase := new(MockModerationUsecase)
+		router := setupModerationRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/moderation-queue/user/u1/approve", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"`+controllers.CodeValidationFailed+`"`)
+		mockUsecase.AssertNotCalled(t, "ApproveContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestModerationController_Reject(t *testing.T) {
+	mockUsecase := new(MockModerationUsecase)
+	router := setupModerationRouter(mockUsecase)
+	mockUsecase.On("RejectContent", mock.Anything, "admin-1", domain.ContentKindBlog, "b1").Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/admin/moderation-queue/blog/b1/reject", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockUsecase.AssertExpectations(t)
+}
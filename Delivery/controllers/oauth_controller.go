@@ -9,11 +9,19 @@ import (
 
 type GoogleCallbackRequest struct {
 	Code string `json:"code" binding:"required"`
+	// The state Google sent back along with the code.
+	State string `json:"state"`
+	// Must match the redirect_uri the flow was started with. Empty means the configured default.
+	RedirectURI string `json:"redirect_uri"`
+}
+
+type AuthURLResponse struct {
+	URL string `json:"url"`
 }
 
 type AuthTokensResponse struct {
-	AccessToken  string `json:"accessToken"`
-	RefreshToken string `json:"refreshToken"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
 }
 
 type OAuthController struct {
@@ -26,6 +34,17 @@ func NewOAuthController(usecase domain.IOAuthUsecase) *OAuthController {
 	}
 }
 
+// GoogleLogin starts the flow. The client sends the user to the returned URL, and Google sends
+// them back to redirect_uri, which must be on the allowlist.
+func (oc *OAuthController) GoogleLogin(c *gin.Context) {
+	url, err := oc.oauthUsecase.GoogleAuthURL(c.Request.Context(), c.Query("redirect_uri"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, AuthURLResponse{URL: url})
+}
+
 func (oc *OAuthController) HandleGoogleCallback(c *gin.Context) {
 	// 1. Bind and validate the incoming JSON.
 	var req GoogleCallbackRequest
@@ -35,7 +54,7 @@ func (oc *OAuthController) HandleGoogleCallback(c *gin.Context) {
 	}
 
 	// 2. Pass the authorization code to the usecase to handle the entire flow.
-	accessToken, refreshToken, err := oc.oauthUsecase.HandleGoogleCallback(c.Request.Context(), req.Code)
+	accessToken, refreshToken, err := oc.oauthUsecase.HandleGoogleCallback(c.Request.Context(), req.Code, req.State, req.RedirectURI)
 	if err != nil {
 		// The usecase will return specific errors (e.g., ErrEmailExists) which
 		// our centralized HandleError function can map to appropriate HTTP statuses.
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	. "A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
 	usecases "A2SV_Starter_Project_Blog/Usecases"
 
 	"github.com/gin-gonic/gin"
@@ -22,8 +23,13 @@ type MockOAuthUsecase struct {
 	mock.Mock
 }
 
-func (m *MockOAuthUsecase) HandleGoogleCallback(ctx context.Context, code string) (string, string, error) {
-	args := m.Called(ctx, code)
+func (m *MockOAuthUsecase) GoogleAuthURL(ctx context.Context, redirectURI string) (string, error) {
+	args := m.Called(ctx, redirectURI)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOAuthUsecase) HandleGoogleCallback(ctx context.Context, code, state, redirectURI string) (string, string, error) {
+	args := m.Called(ctx, code, state, redirectURI)
 	return args.String(0), args.String(1), args.Error(2)
 }
 
@@ -41,6 +47,7 @@ func (s *OAuthControllerTestSuite) SetupTest() {
 	s.controller = NewOAuthController(s.mockOAuthUsecase)
 	s.router = gin.New()
 	// Register the endpoint for the test
+	s.router.GET("/auth/google/login", s.controller.GoogleLogin)
 	s.router.POST("/auth/google/callback", s.controller.HandleGoogleCallback)
 }
 
@@ -59,7 +66,7 @@ func (s *OAuthControllerTestSuite) TestHandleGoogleCallback() {
 		expectedRefreshToken := "our.app.refresh.token"
 
 		// Set the mock expectation for the usecase
-		s.mockOAuthUsecase.On("HandleGoogleCallback", mock.Anything, authCode).
+		s.mockOAuthUsecase.On("HandleGoogleCallback", mock.Anything, authCode, "", "").
 			Return(expectedAccessToken, expectedRefreshToken, nil).
 			Once()
 
@@ -81,6 +88,8 @@ func (s *OAuthControllerTestSuite) TestHandleGoogleCallback() {
 		s.NoError(err)
 		s.Equal(expectedAccessToken, resp.AccessToken)
 		s.Equal(expectedRefreshToken, resp.RefreshToken)
+		// Same field names as the password login endpoint.
+		s.JSONEq(`{"access_token": "our.app.access.token", "refresh_token": "our.app.refresh.token"}`, w.Body.String())
 
 		s.mockOAuthUsecase.AssertExpectations(s.T())
 	})
@@ -99,7 +108,7 @@ func (s *OAuthControllerTestSuite) TestHandleGoogleCallback() {
 		// Assert
 		s.Equal(http.StatusBadRequest, w.Code, "Expected Bad Request due to validation failure")
 		// The usecase should NOT have been called.
-		s.mockOAuthUsecase.AssertNotCalled(s.T(), "HandleGoogleCallback", mock.Anything, mock.Anything)
+		s.mockOAuthUsecase.AssertNotCalled(s.T(), "HandleGoogleCallback", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	s.Run("Failure - Usecase returns an error", func() {
@@ -108,7 +117,7 @@ func (s *OAuthControllerTestSuite) TestHandleGoogleCallback() {
 		authCode := "code-that-will-fail"
 
 		// Set the mock to return a conflict error (e.g., email already exists with local provider)
-		s.mockOAuthUsecase.On("HandleGoogleCallback", mock.Anything, authCode).
+		s.mockOAuthUsecase.On("HandleGoogleCallback", mock.Anything, authCode, "", "").
 			Return("", "", usecases.ErrConflict).
 			Once()
 
@@ -126,4 +135,70 @@ func (s *OAuthControllerTestSuite) TestHandleGoogleCallback() {
 		s.Equal(http.StatusConflict, w.Code)
 		s.mockOAuthUsecase.AssertExpectations(s.T())
 	})
+
+	s.Run("Failure - Invalid state", func() {
+		s.SetupTest()
+		s.mockOAuthUsecase.On("HandleGoogleCallback", mock.Anything, "code", "forged", "").
+			Return("", "", domain.ErrInvalidOAuthState).
+			Once()
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/google/callback", strings.NewReader(`{"code": "code", "state": "forged"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusUnauthorized, w.Code)
+		var resp map[string]any
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal(CodeInvalidOAuthState, resp["code"])
+	})
+
+	s.Run("Success - Passes the state and redirect URI through", func() {
+		s.SetupTest()
+		s.mockOAuthUsecase.On("HandleGoogleCallback", mock.Anything, "mobile-code", "mobile-state", "app://oauth").
+			Return("access", "refresh", nil).
+			Once()
+
+		body := `{"code": "mobile-code", "state": "mobile-state", "redirect_uri": "app://oauth"}`
+		req := httptest.NewRequest(http.MethodPost, "/auth/google/callback", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.mockOAuthUsecase.AssertExpectations(s.T())
+	})
+}
+
+func (s *OAuthControllerTestSuite) TestGoogleLogin() {
+	s.Run("Success - Allowed redirect URI", func() {
+		s.SetupTest()
+		s.mockOAuthUsecase.On("GoogleAuthURL", mock.Anything, "https://web.example.com/oauth").
+			Return("https://accounts.google.com/o/oauth2/auth?client_id=id", nil).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/auth/google/login?redirect_uri=https%3A%2F%2Fweb.example.com%2Foauth", nil)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.JSONEq(`{"url": "https://accounts.google.com/o/oauth2/auth?client_id=id"}`, w.Body.String())
+		s.mockOAuthUsecase.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure - Disallowed redirect URI", func() {
+		s.SetupTest()
+		s.mockOAuthUsecase.On("GoogleAuthURL", mock.Anything, "https://evil.example.com").
+			Return("", domain.ErrRedirectNotAllowed).
+			Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/auth/google/login?redirect_uri=https%3A%2F%2Fevil.example.com", nil)
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusBadRequest, w.Code)
+		var resp map[string]any
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+		s.Equal(CodeRedirectNotAllowed, resp["code"])
+	})
 }
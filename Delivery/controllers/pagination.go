@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultPageLimit int64 = 10
+	// MaxPageLimit caps the page size. Larger requested limits are lowered to it rather than rejected.
+	MaxPageLimit int64 = 100
+
+	pageContextKey  = "page"
+	limitContextKey = "limit"
+)
+
+// PaginationMiddleware validates the page and limit query parameters once for every request and
+// stores the normalized values in the context. An invalid value is answered with 400.
+func PaginationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		page, limit, ok := parsePagination(c)
+		if !ok {
+			c.Abort()
+			return
+		}
+		c.Set(pageContextKey, page)
+		c.Set(limitContextKey, limit)
+		c.Next()
+	}
+}
+
+// parsePageAndLimit returns the page and limit for a handler. They come from the Pagination
+// middleware when it ran, and are parsed here otherwise. It writes a 400 response and returns
+// false when either is invalid.
+func parsePageAndLimit(c *gin.Context) (int64, int64, bool) {
+	page, pageSet := c.Get(pageContextKey)
+	limit, limitSet := c.Get(limitContextKey)
+	if pageSet && limitSet {
+		return page.(int64), limit.(int64), true
+	}
+	return parsePagination(c)
+}
+
+func parsePagination(c *gin.Context) (int64, int64, bool) {
+	page, err := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	if err != nil || page < 1 {
+		abortInvalidPagination(c, "Invalid 'page' parameter: must be a positive integer")
+		return 0, 0, false
+	}
+
+	limit, err := strconv.ParseInt(c.DefaultQuery("limit", strconv.FormatInt(DefaultPageLimit, 10)), 10, 64)
+	if err != nil || limit < 1 {
+		abortInvalidPagination(c, "Invalid 'limit' parameter: must be a positive integer")
+		return 0, 0, false
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+	return page, limit, true
+}
+
+func abortInvalidPagination(c *gin.Context, details string) {
+	c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+		"error":   localize(c, CodeInvalidPagination),
+		"code":    CodeInvalidPagination,
+		"details": details,
+	})
+}
+
+// setLinkHeader adds an RFC 5988 Link header with the first, prev, next and last pages of a
+// paginated response. The links reuse the request's own path and query, so filters and sorting
+// carry over; only page and limit are replaced. prev and next are left out where there is no
+// such page, and a page past the end links back to the last one.
+func setLinkHeader(c *gin.Context, p Pagination) {
+	if p.Limit < 1 {
+		return
+	}
+	last := (p.Total + p.Limit - 1) / p.Limit
+	if last < 1 {
+		last = 1
+	}
+
+	link := func(page int64, rel string) string {
+		u := *c.Request.URL
+		query := u.Query()
+		query.Set("page", strconv.FormatInt(page, 10))
+		query.Set("limit", strconv.FormatInt(p.Limit, 10))
+		u.RawQuery = query.Encode()
+		return "<" + u.RequestURI() + ">; rel=\"" + rel + "\""
+	}
+
+	links := []string{link(1, "first")}
+	if p.Page > 1 {
+		links = append(links, link(min(p.Page-1, last), "prev"))
+	}
+	if p.Page < last {
+		links = append(links, link(p.Page+1, "next"))
+	}
+	links = append(links, link(last, "last"))
+	c.Header("Link", strings.Join(links, ", "))
+}
@@ -0,0 +1,183 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPaginationMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(controllers.PaginationMiddleware())
+	router.GET("/items", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"page": c.GetInt64("page"), "limit": c.GetInt64("limit")})
+	})
+
+	testCases := []struct {
+		name          string
+		query         string
+		expectedCode  int
+		expectedPage  int64
+		expectedLimit int64
+	}{
+		{"Missing values use defaults", "", http.StatusOK, 1, controllers.DefaultPageLimit},
+		{"Valid values are kept", "?page=3&limit=25", http.StatusOK, 3, 25},
+		{"Over-limit value is capped", "?limit=5000", http.StatusOK, 1, controllers.MaxPageLimit},
+		{"Non-numeric page", "?page=abc", http.StatusBadRequest, 0, 0},
+		{"Zero page", "?page=0", http.StatusBadRequest, 0, 0},
+		{"Negative limit", "?limit=-5", http.StatusBadRequest, 0, 0},
+		{"Non-numeric limit", "?limit=ten", http.StatusBadRequest, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/items"+tc.query, nil))
+
+			assert.Equal(t, tc.expectedCode, w.Code)
+			var body map[string]interface{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			if tc.expectedCode != http.StatusOK {
+				assert.Equal(t, controllers.CodeInvalidPagination, body["code"])
+				assert.NotEmpty(t, body["error"])
+				assert.NotEmpty(t, body["details"])
+				return
+			}
+			assert.Equal(t, float64(tc.expectedPage), body["page"])
+			assert.Equal(t, float64(tc.expectedLimit), body["limit"])
+		})
+	}
+}
+
+// Blog, user and comment listings must reject bad pagination the same way, with or without the middleware.
+func TestPagination_ConsistentAcrossControllers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/blogs", controllers.NewBlogController(new(MockBlogUsecase)).SearchAndFilter)
+	router.GET("/admin/users", controllers.NewUserController(new(MockUserUsecase)).SearchAndFilter)
+	router.GET("/blogs/:blogID/comments", controllers.NewCommentController(new(MockCommentUsecase)).GetCommentsForBlog)
+
+	for _, path := range []string{"/blogs", "/admin/users", "/blogs/blog-1/comments"} {
+		for _, query := range []string{"?page=abc", "?limit=0"} {
+			t.Run(path+query, func(t *testing.T) {
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path+query, nil))
+
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				var body map[string]interface{}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+				assert.Equal(t, controllers.CodeInvalidPagination, body["code"])
+			})
+		}
+	}
+
+	t.Run("Over-limit value is capped before reaching the usecase", func(t *testing.T) {
+		mockUsecase := new(MockCommentUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/comments", controllers.NewCommentController(mockUsecase).GetCommentsForBlog)
+		mockUsecase.On("GetCommentsForBlog", mock.Anything, "blog-1", int64(1), controllers.MaxPageLimit, domain.CommentSort("")).
+			Return([]*domain.Comment{}, int64(0), nil).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/comments?limit=1000", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp controllers.PaginatedCommentResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, controllers.MaxPageLimit, resp.Pagination.Limit)
+		mockUsecase.AssertExpectations(t)
+	})
+}
+
+// linkPattern matches one entry of a Link header.
+var linkPattern = regexp.MustCompile(`<([^>]*)>; rel="(\w+)"`)
+
+// parseLinks maps each rel of a Link header to its URL.
+func parseLinks(header string) map[string]string {
+	links := map[string]string{}
+	for _, m := range linkPattern.FindAllStringSubmatch(header, -1) {
+		links[m[2]] = m[1]
+	}
+	return links
+}
+
+func TestLinkHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// 35 comments at 10 per page make 4 pages.
+	get := func(t *testing.T, page int64) map[string]string {
+		mockUsecase := new(MockCommentUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/comments", controllers.NewCommentController(mockUsecase).GetCommentsForBlog)
+		mockUsecase.On("GetCommentsForBlog", mock.Anything, "blog-1", page, int64(10), domain.CommentSortNewest).
+			Return([]*domain.Comment{}, int64(35), nil).Once()
+
+		w := httptest.NewRecorder()
+		url := "/blogs/blog-1/comments?sort=newest&limit=10&page=" + strconv.FormatInt(page, 10)
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		return parseLinks(w.Header().Get("Link"))
+	}
+
+	t.Run("First page", func(t *testing.T) {
+		links := get(t, 1)
+		assert.Equal(t, map[string]string{
+			"first": "/blogs/blog-1/comments?limit=10&page=1&sort=newest",
+			"next":  "/blogs/blog-1/comments?limit=10&page=2&sort=newest",
+			"last":  "/blogs/blog-1/comments?limit=10&page=4&sort=newest",
+		}, links)
+	})
+
+	t.Run("Middle page", func(t *testing.T) {
+		links := get(t, 2)
+		assert.Equal(t, map[string]string{
+			"first": "/blogs/blog-1/comments?limit=10&page=1&sort=newest",
+			"prev":  "/blogs/blog-1/comments?limit=10&page=1&sort=newest",
+			"next":  "/blogs/blog-1/comments?limit=10&page=3&sort=newest",
+			"last":  "/blogs/blog-1/comments?limit=10&page=4&sort=newest",
+		}, links)
+	})
+
+	t.Run("Last page", func(t *testing.T) {
+		links := get(t, 4)
+		assert.Equal(t, map[string]string{
+			"first": "/blogs/blog-1/comments?limit=10&page=1&sort=newest",
+			"prev":  "/blogs/blog-1/comments?limit=10&page=3&sort=newest",
+			"last":  "/blogs/blog-1/comments?limit=10&page=4&sort=newest",
+		}, links)
+	})
+
+	t.Run("Past the last page links back to it", func(t *testing.T) {
+		links := get(t, 9)
+		assert.Equal(t, "/blogs/blog-1/comments?limit=10&page=4&sort=newest", links["prev"])
+		assert.NotContains(t, links, "next")
+	})
+
+	t.Run("Empty listing has a single page", func(t *testing.T) {
+		mockUsecase := new(MockCommentUsecase)
+		router := gin.New()
+		router.GET("/blogs/:blogID/comments", controllers.NewCommentController(mockUsecase).GetCommentsForBlog)
+		mockUsecase.On("GetCommentsForBlog", mock.Anything, "blog-1", int64(1), controllers.DefaultPageLimit, domain.CommentSort("")).
+			Return([]*domain.Comment{}, int64(0), nil).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/blog-1/comments", nil))
+
+		assert.Equal(t, map[string]string{
+			"first": "/blogs/blog-1/comments?limit=10&page=1",
+			"last":  "/blogs/blog-1/comments?limit=10&page=1",
+		}, parseLinks(w.Header().Get("Link")))
+	})
+}
@@ -0,0 +1,25 @@
+package controllers
+
+import (
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ValidateIDParams answers 404 for any of the named path parameters that isn't a well-formed ID.
+// Such an ID can never match a stored resource, so the request is stopped before it reaches a
+// repository, where some lookups would otherwise surface it as a 500.
+// Parameters missing from the matched route are ignored, so it can be applied to a whole group.
+func ValidateIDParams(names ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, name := range names {
+			if id := c.Param(name); id != "" && !primitive.IsValidObjectID(id) {
+				HandleError(c, usecases.ErrNotFound)
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
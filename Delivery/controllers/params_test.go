@@ -0,0 +1,102 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupIDValidationRouter mirrors how the main router guards blog-addressed endpoints.
+// The mocks have no expectations, so any call that reaches a usecase fails the test.
+func setupIDValidationRouter(blogUsecase *MockBlogUsecase, commentUsecase *MockCommentUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	blogController := controllers.NewBlogController(blogUsecase)
+	commentController := controllers.NewCommentController(commentUsecase)
+
+	blogs := router.Group("/blogs")
+	blogs.Use(func(c *gin.Context) { c.Set("userID", "user-123") }, controllers.ValidateIDParams("blogID", "rev"))
+	{
+		blogs.GET("/:blogID", blogController.GetByID)
+		blogs.PUT("/:blogID", blogController.Update)
+		blogs.DELETE("/:blogID", blogController.Delete)
+		blogs.POST("/:blogID/interact", blogController.InteractWithBlog)
+		blogs.POST("/:blogID/pin", blogController.Pin)
+		blogs.POST("/:blogID/unpin", blogController.Unpin)
+		blogs.GET("/:blogID/revisions", blogController.ListRevisions)
+		blogs.GET("/:blogID/revisions/:rev", blogController.GetRevision)
+		blogs.POST("/:blogID/revisions/:rev/restore", blogController.RestoreRevision)
+		blogs.GET("/:blogID/comments", commentController.GetCommentsForBlog)
+		blogs.POST("/:blogID/comments", commentController.CreateComment)
+	}
+	return router
+}
+
+func TestValidateIDParams_BlogEndpoints(t *testing.T) {
+	const validID = "64b7f0c2e1a4b5c6d7e8f901"
+
+	cases := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/blogs/not-a-valid-id", ""},
+		{http.MethodPut, "/blogs/not-a-valid-id", `{"title":"New"}`},
+		{http.MethodDelete, "/blogs/not-a-valid-id", ""},
+		{http.MethodPost, "/blogs/not-a-valid-id/interact", `{"action":"like"}`},
+		{http.MethodPost, "/blogs/not-a-valid-id/pin", ""},
+		{http.MethodPost, "/blogs/not-a-valid-id/unpin", ""},
+		{http.MethodGet, "/blogs/not-a-valid-id/revisions", ""},
+		{http.MethodGet, "/blogs/not-a-valid-id/revisions/" + validID, ""},
+		{http.MethodGet, "/blogs/" + validID + "/revisions/zzz", ""},
+		{http.MethodPost, "/blogs/" + validID + "/revisions/zzz/restore", ""},
+		{http.MethodGet, "/blogs/not-a-valid-id/comments", ""},
+		{http.MethodPost, "/blogs/not-a-valid-id/comments", `{"content":"Nice"}`},
+		// 24 characters, but not hex.
+		{http.MethodGet, "/blogs/zzzzzzzzzzzzzzzzzzzzzzzz", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			blogUsecase := new(MockBlogUsecase)
+			commentUsecase := new(MockCommentUsecase)
+			router := setupIDValidationRouter(blogUsecase, commentUsecase)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			var resp map[string]interface{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, controllers.CodeNotFound, resp["code"])
+			blogUsecase.AssertExpectations(t)
+			commentUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidateIDParams_ValidIDPassesThrough(t *testing.T) {
+	const validID = "64b7f0c2e1a4b5c6d7e8f901"
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	reached := false
+	router.GET("/blogs/:blogID", controllers.ValidateIDParams("blogID", "rev"), func(c *gin.Context) {
+		reached = true
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/blogs/"+validID, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, reached)
+}
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultSSEBufferSize is how many events a stream holds for a slow client unless configured otherwise.
+const DefaultSSEBufferSize = 64
+
+// ErrSlowConsumer is returned to the producer of a stream whose client stopped keeping up.
+var ErrSlowConsumer = errors.New("client is not reading the stream fast enough")
+
+type sseEvent struct {
+	name string
+	data any
+}
+
+// sseStream sends Server-Sent Events to one client through a buffer of fixed size, so a client
+// that reads slowly can't make the producer, and whatever it holds open upstream, wait on it.
+// A client that lets the buffer fill up is disconnected rather than sent a stream with events
+// missing, since every event carries part of a text. The producer calls Send from its own
+// goroutine and Close when done; the handler goroutine writes the events with Serve.
+type sseStream struct {
+	c      *gin.Context
+	events chan sseEvent
+	cutOff atomic.Bool
+}
+
+func newSSEStream(c *gin.Context, bufferSize int) *sseStream {
+	if bufferSize <= 0 {
+		bufferSize = DefaultSSEBufferSize
+	}
+	return &sseStream{c: c, events: make(chan sseEvent, bufferSize)}
+}
+
+// Send queues an event without waiting. It returns ErrSlowConsumer, and disconnects the client,
+// when the buffer is full.
+func (s *sseStream) Send(name string, data any) error {
+	if s.cutOff.Load() {
+		return ErrSlowConsumer
+	}
+	select {
+	case s.events <- sseEvent{name: name, data: data}:
+		return nil
+	default:
+	}
+
+	s.cutOff.Store(true)
+	// Fails a write blocked on the client, so Serve stops too. Writers that can't set a
+	// deadline are left to the server's own write timeout.
+	_ = http.NewResponseController(s.c.Writer).SetWriteDeadline(time.Now())
+	return ErrSlowConsumer
+}
+
+// Close tells Serve that no more events are coming.
+func (s *sseStream) Close() {
+	close(s.events)
+}
+
+// Serve writes events as they arrive until Close, calling before ahead of each one. It
+// reports whether the client kept up; if not, the remaining events were discarded.
+func (s *sseStream) Serve(before func()) bool {
+	for event := range s.events {
+		// Keep reading after a cut-off, so Serve only returns once the producer is done.
+		if s.cutOff.Load() {
+			continue
+		}
+		before()
+		s.c.SSEvent(event.name, event.data)
+		s.c.Writer.Flush()
+	}
+	return !s.cutOff.Load()
+}
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// --- Response DTOs ---
+
+type DashboardSummaryResponse struct {
+	TotalUsers        int64     `json:"total_users"`
+	ActiveUsers       int64     `json:"active_users"`
+	TotalBlogs        int64     `json:"total_blogs"`
+	TotalComments     int64     `json:"total_comments"`
+	TotalInteractions int64     `json:"total_interactions"`
+	GeneratedAt       time.Time `json:"generated_at"`
+}
+
+// --- Controller ---
+
+type SummaryController struct {
+	summaryUsecase domain.ISummaryUsecase
+}
+
+func NewSummaryController(summaryUsecase domain.ISummaryUsecase) *SummaryController {
+	return &SummaryController{
+		summaryUsecase: summaryUsecase,
+	}
+}
+
+// GetSummary returns the headline numbers for the admin dashboard. They may be a few seconds old.
+func (sc *SummaryController) GetSummary(c *gin.Context) {
+	summary, err := sc.summaryUsecase.GetSummary(c.Request.Context())
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, DashboardSummaryResponse{
+		TotalUsers:        summary.TotalUsers,
+		ActiveUsers:       summary.ActiveUsers,
+		TotalBlogs:        summary.TotalBlogs,
+		TotalComments:     summary.TotalComments,
+		TotalInteractions: summary.TotalInteractions,
+		GeneratedAt:       summary.GeneratedAt,
+	})
+}
@@ -0,0 +1,82 @@
+package controllers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- Mock ISummaryUsecase ---
+type MockSummaryUsecase struct {
+	mock.Mock
+}
+
+func (m *MockSummaryUsecase) GetSummary(ctx context.Context) (*domain.DashboardSummary, error) {
+	args := m.Called(ctx)
+	var summary *domain.DashboardSummary
+	if args.Get(0) != nil {
+		summary = args.Get(0).(*domain.DashboardSummary)
+	}
+	return summary, args.Error(1)
+}
+
+func setupSummaryRouter(mockUsecase *MockSummaryUsecase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/summary", controllers.NewSummaryController(mockUsecase).GetSummary)
+	return router
+}
+
+func TestSummaryController_GetSummary(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		router := setupSummaryRouter(mockUsecase)
+		generatedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		mockUsecase.On("GetSummary", mock.Anything).Return(&domain.DashboardSummary{
+			TotalUsers:        10,
+			ActiveUsers:       7,
+			TotalBlogs:        25,
+			TotalComments:     40,
+			TotalInteractions: 90,
+			GeneratedAt:       generatedAt,
+		}, nil).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/summary", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp controllers.DashboardSummaryResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, controllers.DashboardSummaryResponse{
+			TotalUsers:        10,
+			ActiveUsers:       7,
+			TotalBlogs:        25,
+			TotalComments:     40,
+			TotalInteractions: 90,
+			GeneratedAt:       generatedAt,
+		}, resp)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Usecase Error", func(t *testing.T) {
+		mockUsecase := new(MockSummaryUsecase)
+		router := setupSummaryRouter(mockUsecase)
+		mockUsecase.On("GetSummary", mock.Anything).Return(nil, errors.New("db down")).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/summary", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
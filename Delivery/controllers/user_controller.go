@@ -3,6 +3,7 @@ package controllers
 import (
 	domain "A2SV_Starter_Project_Blog/Domain"
 	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"encoding/csv"
 	"fmt"
 	"log"
 	"net/http"
@@ -13,6 +14,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// userSortFields are the values the admin user list accepts for sortBy.
+var userSortFields = []string{"createdAt", "username", "email"}
+
 type UserController struct {
 	userUsecase usecases.UserUsecase
 }
@@ -51,6 +55,28 @@ type ResetPasswordRequest struct {
 	NewPassword string `json:"new_password" binding:"required"`
 }
 
+type UpdateUsernameRequest struct {
+	Username string `json:"username" binding:"required"`
+}
+
+type ChangeEmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type IntrospectRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// IntrospectResponse describes a token. Only "active" is set for a token that is not accepted.
+type IntrospectResponse struct {
+	Active    bool       `json:"active"`
+	TokenID   string     `json:"token_id,omitempty"`
+	UserID    string     `json:"user_id,omitempty"`
+	Role      string     `json:"role,omitempty"`
+	IssuedAt  *time.Time `json:"issued_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
 type SetRoleRequest struct {
 	NewRole domain.Role `json:"newRole" binding:"required"`
 }
@@ -59,6 +85,7 @@ type UserResponse struct {
 	ID             string    `json:"id"`
 	Username       string    `json:"username"`
 	Email          string    `json:"email"`
+	PendingEmail   string    `json:"pending_email,omitempty"`
 	Bio            string    `json:"bio,omitempty"`
 	ProfilePicture string    `json:"profile_picture,omitempty"`
 	Role           string    `json:"role"`
@@ -68,6 +95,37 @@ type UserResponse struct {
 	UpdatedAt      time.Time `json:"updated_at"`
 }
 
+// PublicUserResponse is what anyone may see of a user, without private details like the email.
+type PublicUserResponse struct {
+	ID             string    `json:"id"`
+	Username       string    `json:"username"`
+	Bio            string    `json:"bio,omitempty"`
+	ProfilePicture string    `json:"profile_picture,omitempty"`
+	FollowerCount  int64     `json:"follower_count"`
+	FollowingCount int64     `json:"following_count"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// UsernameSuggestionResponse is one user offered for an @mention.
+type UsernameSuggestionResponse struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// RevokeTokensResponse reports how many of a user's tokens were revoked.
+type RevokeTokensResponse struct {
+	UserID  string `json:"user_id"`
+	Revoked int64  `json:"revoked"`
+}
+
+// ImpersonationResponse carries a read-only access token for acting as another user.
+type ImpersonationResponse struct {
+	AccessToken    string    `json:"access_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	UserID         string    `json:"user_id"`
+	ImpersonatedBy string    `json:"impersonated_by"`
+}
+
 // PaginatedUserResponse defines the structure for a paginated list of users.
 type PaginatedUserResponse struct {
 	Data       []UserResponse `json:"data"`
@@ -79,6 +137,7 @@ func toUserResponse(u *domain.User) UserResponse {
 		ID:             u.ID,
 		Username:       u.Username,
 		Email:          u.Email,
+		PendingEmail:   u.PendingEmail,
 		Bio:            u.Bio,
 		ProfilePicture: u.ProfilePicture,
 		Role:           string(u.Role),
@@ -89,10 +148,22 @@ func toUserResponse(u *domain.User) UserResponse {
 	}
 }
 
+func toPublicUserResponse(p *domain.PublicProfile) PublicUserResponse {
+	return PublicUserResponse{
+		ID:             p.User.ID,
+		Username:       p.User.Username,
+		Bio:            p.User.Bio,
+		ProfilePicture: p.User.ProfilePicture,
+		FollowerCount:  p.Counts.Followers,
+		FollowingCount: p.Counts.Following,
+		CreatedAt:      p.User.CreatedAt,
+	}
+}
+
 func (ctrl *UserController) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		HandleBindingError(c, err)
 		return
 	}
 
@@ -147,7 +218,7 @@ func (ctrl *UserController) ActivateAccount(c *gin.Context) {
 func (ctrl *UserController) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		HandleBindingError(c, err)
 		return
 	}
 
@@ -180,7 +251,7 @@ func (ctrl *UserController) GetProfile(c *gin.Context) {
 		return
 	}
 
-	user, err := ctrl.userUsecase.GetProfile(c.Request.Context(), userID.(string))
+	user, err := ctrl.currentUser(c, userID.(string))
 	if err != nil {
 		if err == domain.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -193,10 +264,76 @@ func (ctrl *UserController) GetProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, toUserResponse(user))
 }
 
+// GetPublicProfile shows another user's public profile and follow counts.
+func (ctrl *UserController) GetPublicProfile(c *gin.Context) {
+	profile, err := ctrl.userUsecase.GetPublicProfile(c.Request.Context(), c.Param("userID"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPublicUserResponse(profile))
+}
+
+// SuggestUsernames completes an @mention from the q query parameter, returning only the ID and
+// username of each match. The limit parameter can lower how many are returned.
+func (ctrl *UserController) SuggestUsernames(c *gin.Context) {
+	_, limit, ok := parsePageAndLimit(c)
+	if !ok {
+		return
+	}
+
+	suggestions, err := ctrl.userUsecase.SuggestUsernames(c.Request.Context(), c.Query("q"), int(limit))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	data := make([]UsernameSuggestionResponse, len(suggestions))
+	for i, s := range suggestions {
+		data[i] = UsernameSuggestionResponse{ID: s.ID, Username: s.Username}
+	}
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// Me resolves the current session to the authenticated user.
+// It serves the same data as GetProfile but lives under /auth so frontends have a stable
+// "who am I" endpoint to call after login or on page load.
+func (ctrl *UserController) Me(c *gin.Context) {
+	userID := c.GetString("userID")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	user, err := ctrl.currentUser(c, userID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			// The token is valid but its user no longer exists.
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "An unexpected error occurred"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserResponse(user))
+}
+
+// currentUser returns the user attached by the AttachUser middleware, falling back to a lookup
+// on routes that don't use it.
+func (ctrl *UserController) currentUser(c *gin.Context, userID string) (*domain.User, error) {
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*domain.User); ok && u.ID == userID {
+			return u, nil
+		}
+	}
+	return ctrl.userUsecase.GetProfile(c.Request.Context(), userID)
+}
+
 func (ctrl *UserController) RefreshToken(c *gin.Context) {
 	var req RefreshRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		HandleBindingError(c, err)
 		return
 	}
 
@@ -209,10 +346,46 @@ func (ctrl *UserController) RefreshToken(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"access_token": newAccessToken, "refresh_token": newRefreshToken})
 }
 
+// IntrospectToken reports whether an access token is active and, if so, its claims.
+func (ctrl *UserController) IntrospectToken(c *gin.Context) {
+	var req IntrospectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+
+	introspection, err := ctrl.userUsecase.IntrospectToken(c.Request.Context(), req.Token)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toIntrospectResponse(introspection))
+}
+
+func toIntrospectResponse(i *domain.TokenIntrospection) IntrospectResponse {
+	if !i.Active {
+		return IntrospectResponse{Active: false}
+	}
+	resp := IntrospectResponse{
+		Active:  true,
+		TokenID: i.TokenID,
+		UserID:  i.UserID,
+		Role:    string(i.Role),
+	}
+	if !i.IssuedAt.IsZero() {
+		resp.IssuedAt = &i.IssuedAt
+	}
+	if !i.ExpiresAt.IsZero() {
+		resp.ExpiresAt = &i.ExpiresAt
+	}
+	return resp
+}
+
 func (ctrl *UserController) Logout(c *gin.Context) {
 	var req LogoutRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		HandleBindingError(c, err)
 		return
 	}
 
@@ -229,7 +402,7 @@ func (ctrl *UserController) Logout(c *gin.Context) {
 func (ctrl *UserController) ForgetPassword(c *gin.Context) {
 	var req ForgetPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		HandleBindingError(c, err)
 		return
 	}
 
@@ -246,7 +419,7 @@ func (ctrl *UserController) ForgetPassword(c *gin.Context) {
 func (ctrl *UserController) ResetPassword(c *gin.Context) {
 	var req ResetPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		HandleBindingError(c, err)
 		return
 	}
 
@@ -309,6 +482,116 @@ func (ctrl *UserController) UpdateProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, toUserResponse(updatedUser))
 }
 
+// UnlinkProvider detaches Google (or another provider) from the logged-in user's account.
+func (ctrl *UserController) UnlinkProvider(c *gin.Context) {
+	if err := ctrl.userUsecase.UnlinkProvider(c.Request.Context(), c.GetString("userID")); err != nil {
+		HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateUsername changes the logged-in user's username.
+func (ctrl *UserController) UpdateUsername(c *gin.Context) {
+	var req UpdateUsernameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+
+	updatedUser, err := ctrl.userUsecase.UpdateUsername(c.Request.Context(), c.GetString("userID"), req.Username)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUserResponse(updatedUser))
+}
+
+// RequestEmailChange sends a confirmation link to the address the logged-in user wants to switch to.
+func (ctrl *UserController) RequestEmailChange(c *gin.Context) {
+	var req ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		HandleBindingError(c, err)
+		return
+	}
+
+	if err := ctrl.userUsecase.RequestEmailChange(c.Request.Context(), c.GetString("userID"), req.Email); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "a confirmation link has been sent to the new email address"})
+}
+
+// ConfirmEmailChange completes an email change using the token from the confirmation link.
+func (ctrl *UserController) ConfirmEmailChange(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "email change token is required"})
+		return
+	}
+
+	if err := ctrl.userUsecase.ConfirmEmailChange(c.Request.Context(), token); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email address updated successfully."})
+}
+
+// RevokeTokens handles requests to force-logout a user by revoking all of their tokens.
+func (ctrl *UserController) RevokeTokens(c *gin.Context) {
+	targetUserID := c.Param("userID")
+	if targetUserID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Target user ID is required in the URL path."})
+		return
+	}
+
+	actorRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication role not found."})
+		return
+	}
+
+	revoked, err := ctrl.userUsecase.RevokeTokens(c.Request.Context(), c.GetString("userID"), actorRole.(domain.Role), targetUserID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, RevokeTokensResponse{UserID: targetUserID, Revoked: revoked})
+}
+
+// Impersonate handles requests from an admin for a read-only token to act as another user.
+func (ctrl *UserController) Impersonate(c *gin.Context) {
+	targetUserID := c.Param("userID")
+	if targetUserID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Target user ID is required in the URL path."})
+		return
+	}
+
+	actorUserID := c.GetString("userID")
+	actorRole, exists := c.Get("userRole")
+	if actorUserID == "" || !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication details not found."})
+		return
+	}
+
+	token, expiresAt, err := ctrl.userUsecase.Impersonate(c.Request.Context(), actorUserID, actorRole.(domain.Role), targetUserID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ImpersonationResponse{
+		AccessToken:    token,
+		ExpiresAt:      expiresAt,
+		UserID:         targetUserID,
+		ImpersonatedBy: actorUserID,
+	})
+}
+
 // SearchAndFilter handles requests for searching and filtering users.
 // This is intended for admin use.
 func (ctrl *UserController) SearchAndFilter(c *gin.Context) {
@@ -318,20 +601,36 @@ func (ctrl *UserController) SearchAndFilter(c *gin.Context) {
 	// This code is very similar to your blog search controller, demonstrating pattern reuse.
 
 	// Pagination
-	page, err := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
-	if err != nil || page < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'page' parameter"})
+	page, limit, ok := parsePageAndLimit(c)
+	if !ok {
 		return
 	}
 	options.Page = page
+	options.Limit = limit
 
-	limit, err := strconv.ParseInt(c.DefaultQuery("limit", "10"), 10, 64)
-	if err != nil || limit < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'limit' parameter"})
+	// Search and Filter criteria
+	if !parseUserFilterOptions(c, &options) {
 		return
 	}
-	options.Limit = limit
 
+	// 2. Call the usecase with the populated options struct.
+	users, total, err := ctrl.userUsecase.SearchAndFilter(c.Request.Context(), options)
+	if err != nil {
+		// Using a generic error handler is good practice
+		log.Printf("Error searching users: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred while searching for users."})
+		return
+	}
+
+	// 3. Format and return the paginated response.
+	response := toPaginatedUserResponse(users, total, options.Page, options.Limit)
+	setLinkHeader(c, response.Pagination)
+	c.JSON(http.StatusOK, response)
+}
+
+// parseUserFilterOptions fills the filter and sort fields shared by the admin user search
+// and export endpoints. It writes a 400 response and returns false on invalid input.
+func parseUserFilterOptions(c *gin.Context, options *domain.UserSearchFilterOptions) bool {
 	// Search and Filter criteria (using pointers for optional fields)
 	if username := c.Query("username"); username != "" {
 		options.Username = &username
@@ -342,16 +641,16 @@ func (ctrl *UserController) SearchAndFilter(c *gin.Context) {
 	if roleStr := c.Query("role"); roleStr != "" {
 		role := domain.Role(roleStr)
 		if !role.IsValid() {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'role' parameter. Must be 'user' or 'admin'."})
-			return
+			abortInvalidQueryParameter(c, "Invalid 'role' parameter. Must be 'user' or 'admin'.")
+			return false
 		}
 		options.Role = &role
 	}
 	if isActiveStr := c.Query("isActive"); isActiveStr != "" {
 		isActive, err := strconv.ParseBool(isActiveStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'isActive' parameter. Must be 'true' or 'false'."})
-			return
+			abortInvalidQueryParameter(c, "Invalid 'isActive' parameter. Must be 'true' or 'false'.")
+			return false
 		}
 		options.IsActive = &isActive
 	}
@@ -372,38 +671,80 @@ func (ctrl *UserController) SearchAndFilter(c *gin.Context) {
 		if t, err := time.Parse(time.RFC3339, startDateStr); err == nil {
 			options.StartDate = &t
 		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'startDate' format. Use RFC3339 (e.g., 2023-10-27T10:00:00Z)"})
-			return
+			abortInvalidQueryParameter(c, "Invalid 'startDate' format. Use RFC3339 (e.g., 2023-10-27T10:00:00Z)")
+			return false
 		}
 	}
 	if endDateStr := c.Query("endDate"); endDateStr != "" {
 		if t, err := time.Parse(time.RFC3339, endDateStr); err == nil {
 			options.EndDate = &t
 		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'endDate' format. Use RFC3339 (e.g., 2023-10-27T10:00:00Z)"})
-			return
+			abortInvalidQueryParameter(c, "Invalid 'endDate' format. Use RFC3339 (e.g., 2023-10-27T10:00:00Z)")
+			return false
 		}
 	}
 
 	// Sorting
-	options.SortBy = c.Query("sortBy") // e.g., "username", "email", "createdAt"
+	sortBy, ok := parseSortBy(c, userSortFields)
+	if !ok {
+		abortInvalidQueryParameter(c, invalidSortByMessage(userSortFields))
+		return false
+	}
+	options.SortBy = sortBy
 	if strings.ToUpper(c.Query("sortOrder")) == string(domain.SortOrderASC) {
 		options.SortOrder = domain.SortOrderASC
 	} else {
 		options.SortOrder = domain.SortOrderDESC // Default to DESC
 	}
 
-	// 2. Call the usecase with the populated options struct.
-	users, total, err := ctrl.userUsecase.SearchAndFilter(c.Request.Context(), options)
-	if err != nil {
-		// Using a generic error handler is good practice
-		log.Printf("Error searching users: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "An internal error occurred while searching for users."})
+	return true
+}
+
+// ExportUsers streams the users matching the admin search filters as a CSV file.
+// Pagination parameters are ignored; every matching user is written.
+func (ctrl *UserController) ExportUsers(c *gin.Context) {
+	var options domain.UserSearchFilterOptions
+	if !parseUserFilterOptions(c, &options) {
 		return
 	}
 
-	// 3. Format and return the paginated response.
-	c.JSON(http.StatusOK, toPaginatedUserResponse(users, total, options.Page, options.Limit))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="users.csv"`)
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	// Password hashes are deliberately never part of the export.
+	if err := writer.Write([]string{"id", "username", "email", "role", "provider", "isActive", "createdAt"}); err != nil {
+		log.Printf("Error writing users CSV header: %v", err)
+		return
+	}
+
+	rows := 0
+	err := ctrl.userUsecase.ExportUsers(c.Request.Context(), options, func(u *domain.User) error {
+		if err := writer.Write([]string{
+			u.ID,
+			u.Username,
+			u.Email,
+			string(u.Role),
+			string(u.Provider),
+			strconv.FormatBool(u.IsActive),
+			u.CreatedAt.UTC().Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+		// Flush periodically so the client receives the file as it is produced.
+		rows++
+		if rows%100 == 0 {
+			writer.Flush()
+			c.Writer.Flush()
+		}
+		return writer.Error()
+	})
+	writer.Flush()
+	if err != nil {
+		// The status line has already been sent, so the best we can do is log and truncate.
+		log.Printf("Error exporting users after %d rows: %v", rows, err)
+	}
 }
 
 // toPaginatedUserResponse is a helper to format the paginated response.
@@ -448,7 +789,7 @@ func (ctrl *UserController) SetUserRole(c *gin.Context) {
 	// 3. Bind and validate the JSON request body.
 	var req SetRoleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		HandleBindingError(c, err)
 		return
 	}
 
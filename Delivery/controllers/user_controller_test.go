@@ -3,16 +3,19 @@ package controllers_test
 import (
 	"A2SV_Starter_Project_Blog/Delivery/controllers"
 	domain "A2SV_Starter_Project_Blog/Domain"
+	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
 	usecases "A2SV_Starter_Project_Blog/Usecases"
 	"bytes"
 	"context"
 	"io"
 	"mime/multipart"
 
+	"encoding/csv"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -40,6 +43,13 @@ func (m *MockUserUsecase) RefreshAccessToken(ctx context.Context, oldAccess, old
 	args := m.Called(ctx, oldAccess, oldRefresh)
 	return args.String(0), args.String(1), args.Error(2)
 }
+func (m *MockUserUsecase) IntrospectToken(ctx context.Context, accessToken string) (*domain.TokenIntrospection, error) {
+	args := m.Called(ctx, accessToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TokenIntrospection), args.Error(1)
+}
 func (m *MockUserUsecase) ForgetPassword(ctx context.Context, email string) error {
 	args := m.Called(ctx, email)
 	return args.Error(0)
@@ -55,6 +65,21 @@ func (m *MockUserUsecase) UpdateProfile(ctx context.Context, userID, bio string,
 	}
 	return args.Get(0).(*domain.User), args.Error(1)
 }
+func (m *MockUserUsecase) RequestEmailChange(ctx context.Context, userID, newEmail string) error {
+	args := m.Called(ctx, userID, newEmail)
+	return args.Error(0)
+}
+func (m *MockUserUsecase) ConfirmEmailChange(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+func (m *MockUserUsecase) UpdateUsername(ctx context.Context, userID, newUsername string) (*domain.User, error) {
+	args := m.Called(ctx, userID, newUsername)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
 func (m *MockUserUsecase) ActivateAccount(ctx context.Context, token string) error {
 	args := m.Called(ctx, token)
 	return args.Error(0)
@@ -66,6 +91,24 @@ func (m *MockUserUsecase) GetProfile(ctx context.Context, userID string) (*domai
 	}
 	return args.Get(0).(*domain.User), args.Error(1)
 }
+func (m *MockUserUsecase) UnlinkProvider(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+func (m *MockUserUsecase) GetPublicProfile(ctx context.Context, userID string) (*domain.PublicProfile, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PublicProfile), args.Error(1)
+}
+func (m *MockUserUsecase) SuggestUsernames(ctx context.Context, prefix string, limit int) ([]*domain.UsernameSuggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.UsernameSuggestion), args.Error(1)
+}
 func (m *MockUserUsecase) SearchAndFilter(ctx context.Context, options domain.UserSearchFilterOptions) ([]*domain.User, int64, error) {
 	args := m.Called(ctx, options)
 	if args.Get(0) == nil {
@@ -80,6 +123,26 @@ func (m *MockUserUsecase) SetUserRole(ctx context.Context, actorUserID string, a
 	}
 	return args.Get(0).(*domain.User), args.Error(1)
 }
+func (m *MockUserUsecase) Impersonate(ctx context.Context, actorUserID string, actorRole domain.Role, targetUserID string) (string, time.Time, error) {
+	args := m.Called(ctx, actorUserID, actorRole, targetUserID)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
+func (m *MockUserUsecase) RevokeTokens(ctx context.Context, actorUserID string, actorRole domain.Role, targetUserID string) (int64, error) {
+	args := m.Called(ctx, actorUserID, actorRole, targetUserID)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockUserUsecase) ExportUsers(ctx context.Context, options domain.UserSearchFilterOptions, fn func(*domain.User) error) error {
+	args := m.Called(ctx, options, fn)
+	if users, ok := args.Get(0).([]*domain.User); ok {
+		for _, u := range users {
+			if err := fn(u); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
 
 // --- USER ROUTER SETUP HELPER ---
 func setupUserRouter(uc usecases.UserUsecase) *gin.Engine {
@@ -91,9 +154,11 @@ func setupUserRouter(uc usecases.UserUsecase) *gin.Engine {
 	{
 		auth.POST("/register", userController.Register)
 		auth.GET("/activate", userController.ActivateAccount)
+		auth.GET("/email/confirm", userController.ConfirmEmailChange)
 		auth.POST("/login", userController.Login)
 		auth.POST("/logout", userController.Logout)
 		auth.POST("/refresh", userController.RefreshToken)
+		auth.POST("/introspect", userController.IntrospectToken)
 	}
 	password := router.Group("/password")
 	{
@@ -107,6 +172,9 @@ func setupUserRouter(uc usecases.UserUsecase) *gin.Engine {
 			c.Next()
 		})
 		profile.PUT("", userController.UpdateProfile)
+		profile.PATCH("/username", userController.UpdateUsername)
+		profile.POST("/email", userController.RequestEmailChange)
+		profile.DELETE("/oauth", userController.UnlinkProvider)
 	}
 	admin := router.Group("/admin")
 	{
@@ -116,8 +184,13 @@ func setupUserRouter(uc usecases.UserUsecase) *gin.Engine {
 			c.Next()
 		})
 		admin.GET("/users", userController.SearchAndFilter)
+		admin.GET("/users/export", userController.ExportUsers)
 		admin.PATCH("/users/:userID/role", userController.SetUserRole)
+		admin.POST("/users/:userID/revoke-tokens", userController.RevokeTokens)
+		admin.POST("/users/:userID/impersonate", userController.Impersonate)
 	}
+	router.GET("/users/suggest", userController.SuggestUsernames)
+	router.GET("/users/:userID", userController.GetPublicProfile)
 	return router
 }
 
@@ -159,6 +232,30 @@ func TestUserController_Register(t *testing.T) {
 		assert.Equal(t, http.StatusConflict, w.Code)
 		mockUsecase.AssertExpectations(t)
 	})
+
+	t.Run("Failure - Lists Every Invalid Field", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/auth/register", bytes.NewBufferString(`{"email":"not-an-email"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var resp struct {
+			Code   string                   `json:"code"`
+			Fields []controllers.FieldError `json:"fields"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, controllers.CodeInvalidRequestBody, resp.Code)
+		assert.Equal(t, []controllers.FieldError{
+			{Field: "username", Rule: "required", Message: "username is required"},
+			{Field: "email", Rule: "email", Message: "email must be a valid email address"},
+			{Field: "password", Rule: "required", Message: "password is required"},
+		}, resp.Fields)
+		mockUsecase.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+	})
 }
 
 func TestUserController_Login(t *testing.T) {
@@ -224,6 +321,79 @@ func TestUserController_RefreshToken(t *testing.T) {
 	})
 }
 
+func TestUserController_IntrospectToken(t *testing.T) {
+	t.Run("Success - Active Token", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		expiresAt := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+		introspection := &domain.TokenIntrospection{Active: true, TokenID: "jti-1", UserID: "user-123", Role: domain.RoleUser, ExpiresAt: expiresAt}
+		mockUsecase.On("IntrospectToken", mock.Anything, "access.token").Return(introspection, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/auth/introspect", bytes.NewBufferString(`{"token":"access.token"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response controllers.IntrospectResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.Active)
+		assert.Equal(t, "user-123", response.UserID)
+		assert.Equal(t, "user", response.Role)
+		assert.Equal(t, expiresAt, *response.ExpiresAt)
+		assert.Nil(t, response.IssuedAt)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Success - Inactive Token Reveals Nothing", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("IntrospectToken", mock.Anything, "expired.token").Return(&domain.TokenIntrospection{Active: false}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/auth/introspect", bytes.NewBufferString(`{"token":"expired.token"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"active":false}`, w.Body.String())
+	})
+
+	t.Run("Failure - Missing Token", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/auth/introspect", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(t, "IntrospectToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - Non-admin is rejected by the role middleware", func(t *testing.T) {
+		jwtService := infrastructure.NewJWTService("test-secret", "test-issuer", time.Minute, time.Hour)
+		mockUsecase := new(MockUserUsecase)
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST("/auth/introspect",
+			infrastructure.AuthMiddleware(jwtService), infrastructure.RequireRole(domain.RoleAdmin),
+			controllers.NewUserController(mockUsecase).IntrospectToken)
+		token, _, err := jwtService.GenerateAccessToken("user-123", domain.RoleUser)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/auth/introspect", bytes.NewBufferString(`{"token":"`+token+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockUsecase.AssertNotCalled(t, "IntrospectToken", mock.Anything, mock.Anything)
+	})
+}
+
 func TestUserController_ForgetPassword(t *testing.T) {
 	mockUsecase := new(MockUserUsecase)
 	router := setupUserRouter(mockUsecase)
@@ -379,6 +549,173 @@ func TestUserController_UpdateProfile(t *testing.T) {
 	})
 }
 
+func TestUserController_UpdateUsername(t *testing.T) {
+	patch := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPatch, "/profile/username", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("UpdateUsername", mock.Anything, "test-user-id", "new_name").
+			Return(&domain.User{ID: "test-user-id", Username: "new_name"}, nil).Once()
+
+		w := patch(router, `{"username":"new_name"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"new_name"`)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Invalid Format", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("UpdateUsername", mock.Anything, "test-user-id", "bad name!").Return(nil, domain.ErrUsernameInvalid).Once()
+
+		w := patch(router, `{"username":"bad name!"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), controllers.CodeUsernameInvalid)
+	})
+
+	t.Run("Failure - Username Taken", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("UpdateUsername", mock.Anything, "test-user-id", "taken").Return(nil, domain.ErrUsernameExists).Once()
+
+		w := patch(router, `{"username":"taken"}`)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), controllers.CodeUsernameExists)
+	})
+
+	t.Run("Failure - Missing Username", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+
+		w := patch(router, `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(t, "UpdateUsername", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserController_UnlinkProvider(t *testing.T) {
+	unlink := func(router *gin.Engine) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/profile/oauth", nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("UnlinkProvider", mock.Anything, "test-user-id").Return(nil).Once()
+
+		w := unlink(router)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Failure - No Password", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("UnlinkProvider", mock.Anything, "test-user-id").Return(domain.ErrCannotUnlink).Once()
+
+		w := unlink(router)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), controllers.CodeCannotUnlink)
+	})
+}
+
+func TestUserController_EmailChange(t *testing.T) {
+	t.Run("Request - Success", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("RequestEmailChange", mock.Anything, "test-user-id", "new@example.com").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/profile/email", bytes.NewBufferString(`{"email":"new@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Request - Email Taken", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("RequestEmailChange", mock.Anything, "test-user-id", "taken@example.com").Return(domain.ErrEmailExists).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/profile/email", bytes.NewBufferString(`{"email":"taken@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), controllers.CodeEmailExists)
+	})
+
+	t.Run("Request - Invalid Email", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/profile/email", bytes.NewBufferString(`{"email":"not-an-email"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(t, "RequestEmailChange", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Confirm - Success", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("ConfirmEmailChange", mock.Anything, "change-token").Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/auth/email/confirm?token=change-token", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Confirm - Invalid Token", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("ConfirmEmailChange", mock.Anything, "stale").Return(domain.ErrInvalidEmailChangeToken).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/auth/email/confirm?token=stale", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), controllers.CodeInvalidEmailChange)
+	})
+
+	t.Run("Confirm - Missing Token", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/auth/email/confirm", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(t, "ConfirmEmailChange", mock.Anything, mock.Anything)
+	})
+}
+
 func TestUserController_SearchAndFilter(t *testing.T) {
 	mockUsecase := new(MockUserUsecase)
 	router := setupUserRouter(mockUsecase)
@@ -462,6 +799,35 @@ func TestUserController_SearchAndFilter(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		assert.Contains(t, w.Body.String(), "Invalid 'role' parameter")
 	})
+	t.Run("Success - Allowed SortBy", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("SearchAndFilter", mock.Anything, mock.MatchedBy(func(opts domain.UserSearchFilterOptions) bool {
+			return opts.SortBy == "email"
+		})).Return(sampleUsers, int(totalUsers), nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/users?sortBy=email", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Unsupported SortBy", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/users?sortBy=password", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid 'sortBy' parameter")
+		assert.Contains(t, w.Body.String(), "createdAt, username, email")
+		assert.Contains(t, w.Body.String(), `"code":"`+controllers.CodeInvalidQueryParameter+`"`)
+		mockUsecase.AssertNotCalled(t, "SearchAndFilter", mock.Anything, mock.Anything)
+	})
 }
 
 func TestUserController_SetUserRole(t *testing.T) {
@@ -533,3 +899,327 @@ func TestUserController_SetUserRole(t *testing.T) {
 		mockUsecase.AssertNotCalled(t, "SetUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 }
+
+func TestUserController_RevokeTokens(t *testing.T) {
+	targetUserID := "user-to-revoke"
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("RevokeTokens", mock.Anything, "admin-id-123", domain.RoleAdmin, targetUserID).Return(int64(3), nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/users/"+targetUserID+"/revoke-tokens", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response controllers.RevokeTokensResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, targetUserID, response.UserID)
+		assert.Equal(t, int64(3), response.Revoked)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Target user not found", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("RevokeTokens", mock.Anything, "admin-id-123", domain.RoleAdmin, targetUserID).Return(int64(0), domain.ErrUserNotFound).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/users/"+targetUserID+"/revoke-tokens", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Non-admin is rejected by the role middleware", func(t *testing.T) {
+		// Use the real middleware chain the router applies to the admin group.
+		jwtService := infrastructure.NewJWTService("test-secret", "test-issuer", time.Minute, time.Hour)
+		mockUsecase := new(MockUserUsecase)
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST("/admin/users/:userID/revoke-tokens",
+			infrastructure.AuthMiddleware(jwtService), infrastructure.RequireRole(domain.RoleAdmin),
+			controllers.NewUserController(mockUsecase).RevokeTokens)
+		token, _, err := jwtService.GenerateAccessToken("user-123", domain.RoleUser)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/users/"+targetUserID+"/revoke-tokens", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockUsecase.AssertNotCalled(t, "RevokeTokens", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserController_Impersonate(t *testing.T) {
+	targetUserID := "user-to-impersonate"
+
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		expiresAt := time.Now().Add(15 * time.Minute).UTC().Truncate(time.Second)
+		mockUsecase.On("Impersonate", mock.Anything, "admin-id-123", domain.RoleAdmin, targetUserID).
+			Return("impersonation.token", expiresAt, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/users/"+targetUserID+"/impersonate", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response controllers.ImpersonationResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "impersonation.token", response.AccessToken)
+		assert.Equal(t, targetUserID, response.UserID)
+		assert.Equal(t, "admin-id-123", response.ImpersonatedBy)
+		assert.True(t, expiresAt.Equal(response.ExpiresAt))
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Target cannot be impersonated", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("Impersonate", mock.Anything, "admin-id-123", domain.RoleAdmin, targetUserID).
+			Return("", time.Time{}, domain.ErrPermissionDenied).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/users/"+targetUserID+"/impersonate", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Impersonation token cannot write", func(t *testing.T) {
+		// An impersonation token must not reach any write handler, this one included.
+		jwtService := infrastructure.NewJWTService("test-secret", "test-issuer", time.Minute, time.Hour)
+		mockUsecase := new(MockUserUsecase)
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.PUT("/profile", infrastructure.AuthMiddleware(jwtService), controllers.NewUserController(mockUsecase).UpdateProfile)
+		token, _, err := jwtService.GenerateImpersonationToken("user-123", domain.RoleUser, "admin-id-123", time.Minute)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/profile", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockUsecase.AssertNotCalled(t, "UpdateProfile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserController_ExportUsers(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	router := setupUserRouter(mockUsecase)
+
+	t.Run("Success - Filtered Export", func(t *testing.T) {
+		role := domain.RoleAdmin
+		expectedOptions := domain.UserSearchFilterOptions{
+			Role:        &role,
+			GlobalLogic: domain.GlobalLogicAND,
+			SortOrder:   domain.SortOrderDESC,
+		}
+		hash := "secret-hash"
+		createdAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+		admins := []*domain.User{
+			{ID: "user-1", Username: "admin1", Email: "admin1@test.com", Role: domain.RoleAdmin, Provider: domain.ProviderLocal, IsActive: true, Password: &hash, CreatedAt: createdAt},
+			{ID: "user-2", Username: "admin2", Email: "admin2@test.com", Role: domain.RoleAdmin, Provider: domain.ProviderGoogle, CreatedAt: createdAt},
+		}
+		mockUsecase.On("ExportUsers", mock.Anything, expectedOptions, mock.Anything).Return(admins, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/users/export?role=admin&page=3", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "users.csv")
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"id", "username", "email", "role", "provider", "isActive", "createdAt"},
+			{"user-1", "admin1", "admin1@test.com", "admin", "local", "true", "2024-03-01T09:30:00Z"},
+			{"user-2", "admin2", "admin2@test.com", "admin", "google", "false", "2024-03-01T09:30:00Z"},
+		}, records)
+		assert.NotContains(t, w.Body.String(), hash, "Password hashes must never be exported")
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Invalid Filter", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/users/export?isActive=maybe", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(t, "ExportUsers", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserController_Me(t *testing.T) {
+	// Use the real auth middleware so the test covers token resolution end to end.
+	jwtService := infrastructure.NewJWTService("test-secret", "test-issuer", time.Minute, time.Hour)
+	setupMeRouter := func(uc usecases.UserUsecase) *gin.Engine {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		userController := controllers.NewUserController(uc)
+		router.GET("/auth/me", infrastructure.AuthMiddleware(jwtService), userController.Me)
+		return router
+	}
+
+	t.Run("Success - Authenticated", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupMeRouter(mockUsecase)
+		user := &domain.User{ID: "user-123", Username: "me", Email: "me@test.com", Role: domain.RoleUser}
+		mockUsecase.On("GetProfile", mock.Anything, "user-123").Return(user, nil).Once()
+		token, _, err := jwtService.GenerateAccessToken("user-123", domain.RoleUser)
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/auth/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response controllers.UserResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "user-123", response.ID)
+		assert.Equal(t, "me", response.Username)
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Success - Uses Attached User", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		userController := controllers.NewUserController(mockUsecase)
+		router.GET("/auth/me", func(c *gin.Context) {
+			c.Set("userID", "user-123")
+			c.Set("user", &domain.User{ID: "user-123", Username: "attached"})
+			c.Next()
+		}, userController.Me)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/auth/me", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"username":"attached"`)
+		mockUsecase.AssertNotCalled(t, "GetProfile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - No Token", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupMeRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/auth/me", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockUsecase.AssertNotCalled(t, "GetProfile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - Invalid Token", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupMeRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/auth/me", nil)
+		req.Header.Set("Authorization", "Bearer not-a-jwt")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockUsecase.AssertNotCalled(t, "GetProfile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - User Deleted", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupMeRouter(mockUsecase)
+		mockUsecase.On("GetProfile", mock.Anything, "ghost").Return(nil, domain.ErrUserNotFound).Once()
+		token, _, _ := jwtService.GenerateAccessToken("ghost", domain.RoleUser)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/auth/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestUserController_SuggestUsernames(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("SuggestUsernames", mock.Anything, "ali", 3).Return([]*domain.UsernameSuggestion{
+			{ID: "user-1", Username: "alice"},
+			{ID: "user-2", Username: "alicia"},
+		}, nil).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/suggest?q=ali&limit=3", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data":[{"id":"user-1","username":"alice"},{"id":"user-2","username":"alicia"}]}`, w.Body.String())
+		mockUsecase.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Limit", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/suggest?q=ali&limit=0", nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUsecase.AssertNotCalled(t, "SuggestUsernames", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserController_GetPublicProfile(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		mockUsecase.On("GetPublicProfile", mock.Anything, "user-1").Return(&domain.PublicProfile{
+			User:   &domain.User{ID: "user-1", Username: "alice", Email: "alice@test.com", Bio: "hi", CreatedAt: createdAt},
+			Counts: domain.FollowCounts{Followers: 3, Following: 5},
+		}, nil).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/user-1", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp controllers.PublicUserResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, controllers.PublicUserResponse{
+			ID:             "user-1",
+			Username:       "alice",
+			Bio:            "hi",
+			FollowerCount:  3,
+			FollowingCount: 5,
+			CreatedAt:      createdAt,
+		}, resp)
+		assert.NotContains(t, w.Body.String(), "alice@test.com")
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockUsecase := new(MockUserUsecase)
+		router := setupUserRouter(mockUsecase)
+		mockUsecase.On("GetPublicProfile", mock.Anything, "ghost").Return(nil, domain.ErrUserNotFound).Once()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/ghost", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes one validation rule a request field failed, so clients can show
+// the message next to the right form field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func init() {
+	// Report fields by their JSON names, which are the names clients know them by.
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
+	}
+}
+
+// bindingFieldErrors lists the failed rules of a validation error. It returns nil for
+// errors that aren't about validation, such as malformed JSON.
+func bindingFieldErrors(err error) []FieldError {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+
+	fields := make([]FieldError, len(validationErrs))
+	for i, fe := range validationErrs {
+		// The namespace starts with the struct name; drop it but keep the path to nested fields.
+		field := fe.Namespace()
+		if _, rest, found := strings.Cut(field, "."); found {
+			field = rest
+		}
+		fields[i] = FieldError{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Message: fieldErrorMessage(field, fe),
+		}
+	}
+	return fields
+}
+
+func fieldErrorMessage(field string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return field + " is required"
+	case "email":
+		return field + " must be a valid email address"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "min", "max", "len":
+		limit := map[string]string{"min": "at least", "max": "at most", "len": "exactly"}[fe.Tag()]
+		switch fe.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("%s must be %s %s characters long", field, limit, fe.Param())
+		case reflect.Slice, reflect.Map, reflect.Array:
+			return fmt.Sprintf("%s must contain %s %s items", field, limit, fe.Param())
+		default:
+			return fmt.Sprintf("%s must be %s %s", field, limit, fe.Param())
+		}
+	default:
+		return fmt.Sprintf("%s failed the %s rule", field, fe.Tag())
+	}
+}
@@ -3,12 +3,18 @@ package main
 import (
 	"A2SV_Starter_Project_Blog/Delivery/controllers"
 	"A2SV_Starter_Project_Blog/Delivery/routers"
+	domain "A2SV_Starter_Project_Blog/Domain"
 	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
 	repositories "A2SV_Starter_Project_Blog/Repositories"
 	usecases "A2SV_Starter_Project_Blog/Usecases"
 	"A2SV_Starter_Project_Blog/config"
 	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
@@ -18,6 +24,9 @@ import (
 func main() {
 	// --- Load Configuration ---
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("FATAL: Invalid configuration: %v", err)
+	}
 
 	// --- Log important warnings based on the loaded config ---
 	if cfg.GeminiAPIKey == "" {
@@ -47,9 +56,39 @@ func main() {
 
 	// --- Infrastructure Services ---
 	// Pass values from the cfg struct to the service constructors.
-	passwordService := infrastructure.NewPasswordService()
-	jwtService := infrastructure.NewJWTService(cfg.JWTSecret, cfg.JWTIssuer, cfg.JWTAccessTTL, cfg.JWTRefreshTTL)
-	emailService := infrastructure.NewSMTPEmailService(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPass, cfg.SMTPFrom)
+	passwordService := infrastructure.NewPasswordService(infrastructure.WithCost(cfg.BcryptCost))
+	jwtOptions := []infrastructure.JWTServiceOption{infrastructure.WithKeyID(cfg.JWTKeyID)}
+	for kid, secret := range cfg.JWTPreviousKeys {
+		jwtOptions = append(jwtOptions, infrastructure.WithVerificationKey(kid, secret))
+	}
+	if cfg.JWTAlgorithm == "RS256" {
+		privateKey, err := infrastructure.LoadRSAPrivateKey(cfg.JWTPrivateKeyFile)
+		if err != nil {
+			log.Fatalf("FATAL: Failed to load JWT private key: %v", err)
+		}
+		jwtOptions = append(jwtOptions, infrastructure.WithRSAKey(privateKey))
+		for kid, path := range cfg.JWTPublicKeyFiles {
+			publicKey, err := infrastructure.LoadRSAPublicKey(path)
+			if err != nil {
+				log.Fatalf("FATAL: Failed to load JWT public key %q: %v", kid, err)
+			}
+			jwtOptions = append(jwtOptions, infrastructure.WithRSAVerificationKey(kid, publicKey))
+		}
+	}
+	jwtService := infrastructure.NewJWTService(cfg.JWTSecret, cfg.JWTIssuer, cfg.JWTAccessTTL, cfg.JWTRefreshTTL, jwtOptions...)
+	var emailService infrastructure.EmailService
+	var emailOutbox domain.IEmailOutbox
+	if cfg.EmailMode == "log" {
+		log.Println("Email log-only mode: emails are captured in memory and never sent.")
+		logEmailService := infrastructure.NewLogEmailService(infrastructure.DefaultEmailOutboxSize)
+		emailService, emailOutbox = logEmailService, logEmailService
+	} else {
+		emailService = infrastructure.NewSMTPEmailService(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPass, cfg.SMTPFrom,
+			infrastructure.WithSMTPTLSMode(infrastructure.SMTPTLSMode(cfg.SMTPTLSMode)),
+			infrastructure.WithSMTPTimeouts(cfg.SMTPDialTimeout, cfg.SMTPSendTimeout),
+			infrastructure.WithSMTPPool(cfg.SMTPPoolSize, cfg.SMTPIdleTimeout),
+		)
+	}
 	aiService, err := infrastructure.NewGeminiAIService(cfg.GeminiAPIKey, cfg.GeminiModel)
 	if err != nil {
 		log.Printf("WARN: Failed to initialize AI service: %v. AI features will be unavailable.", err)
@@ -68,6 +107,14 @@ func main() {
 	}
 	defer redisService.Close()
 	rateLimiter := infrastructure.NewRateLimiter(redisService)
+	maintenance := infrastructure.NewMaintenanceMode(redisService)
+	// The config can only switch maintenance mode on; it is switched off through the admin endpoint.
+	if cfg.MaintenanceMode {
+		if err := maintenance.SetEnabled(context.Background(), true); err != nil {
+			log.Fatalf("FATAL: Failed to enable maintenance mode: %v", err)
+		}
+		log.Println("Maintenance mode is on: the API is read-only.")
+	}
 	cacheService := infrastructure.NewRedisCacheService(redisService)
 
 	// --- Repositories & Caching Decorators ---
@@ -78,7 +125,23 @@ func main() {
 	tokenRepo := repositories.NewCachingTokenRepository(mongoTokenRepo, cacheService)
 
 	mongoBlogRepo := repositories.NewBlogRepository(db.Collection("blogs"))
-	blogRepo := repositories.NewCachingBlogRepository(mongoBlogRepo, cacheService)
+	if cfg.QueryProfiling && cfg.AppEnv != "production" {
+		log.Printf("Query profiling enabled: explaining reads slower than %s", cfg.SlowQueryThreshold)
+		mongoBlogRepo.SetQueryProfiler(repositories.NewQueryProfiler(cfg.SlowQueryThreshold, nil))
+	}
+	var blogRepo domain.IBlogRepository = repositories.NewCachingBlogRepository(mongoBlogRepo, cacheService, repositories.WithSearchCacheTTL(cfg.SearchCacheTTL))
+	var viewBatcher *repositories.ViewBatchingBlogRepository
+	if cfg.ViewFlushInterval > 0 {
+		viewBatcher = repositories.NewViewBatchingBlogRepository(blogRepo, cfg.ViewFlushMaxPending)
+		blogRepo = viewBatcher
+	}
+
+	revisionRepo := repositories.NewBlogRevisionRepository(db.Collection("blog_revisions"))
+
+	readRepo := repositories.NewBlogReadRepository(db.Collection("read_blogs"))
+	followRepo := repositories.NewFollowRepository(db.Collection("follows"), db.Collection("follow_counts"))
+
+	auditRepo := repositories.NewAuditRepository(db.Collection("audit_log"))
 
 	mongoInteractionRepo := repositories.NewInteractionRepository(db.Collection("interactions"))
 	interactionRepo := repositories.NewCachingInteractionRepository(mongoInteractionRepo, cacheService)
@@ -86,6 +149,8 @@ func main() {
 	mongoCommentRepo := repositories.NewCommentRepository(db.Collection("blog_comments"))
 	commentRepo := repositories.NewCachingCommentRepository(mongoCommentRepo, cacheService)
 
+	statsRepo := repositories.NewStatsRepository(db.Collection("users"), db.Collection("blogs"), db.Collection("blog_comments"), db.Collection("interactions"))
+
 	// --- Database Index Initialization ---
 	log.Println("Initializing database indexes...")
 	indexCtx, indexCancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -102,28 +167,123 @@ func main() {
 	handleIndexError("user", mongoUserRepo.CreateUserIndexes(indexCtx))
 	handleIndexError("token", mongoTokenRepo.CreateTokenIndexes(indexCtx))
 	handleIndexError("blog", mongoBlogRepo.CreateBlogIndexes(indexCtx))
+	handleIndexError("blog revision", revisionRepo.CreateRevisionIndexes(indexCtx))
 	handleIndexError("interaction", mongoInteractionRepo.CreateInteractionIndexes(indexCtx))
 	handleIndexError("comment", mongoCommentRepo.CreateCommentIndexes(indexCtx))
+	handleIndexError("blog read", readRepo.CreateBlogReadIndexes(indexCtx))
+	handleIndexError("follow", followRepo.CreateFollowIndexes(indexCtx))
+	handleIndexError("audit", auditRepo.CreateAuditIndexes(indexCtx))
 	log.Println("Database index initialization complete.")
 
+	profanityWords := cfg.ProfanityWords
+	if cfg.ProfanityWordsFile != "" {
+		fileWords, err := infrastructure.LoadWordList(cfg.ProfanityWordsFile)
+		if err != nil {
+			log.Fatalf("FATAL: Failed to load profanity word list: %v", err)
+		}
+		profanityWords = append(profanityWords, fileWords...)
+	}
+	profanityFilter := domain.NewProfanityFilter(profanityWords, domain.ProfanityMode(cfg.ProfanityMode))
+	imageHostPolicy := domain.NewImageHostPolicy(cfg.ImageAllowedHosts, domain.ImageHostMode(cfg.ImageHostMode))
+
 	// --- Usecases ---
-	userUsecase := usecases.NewUserUsecase(userRepo, passwordService, jwtService, tokenRepo, emailService, imageUploadService, cfg.UsecaseTimeout)
-	blogUsecase := usecases.NewBlogUsecase(blogRepo, userRepo, interactionRepo, cfg.UsecaseTimeout)
-	aiUsecase := usecases.NewAIUsecase(aiService, 10*cfg.UsecaseTimeout)
-	commentUsecase := usecases.NewCommentUsecase(blogRepo, commentRepo, cfg.UsecaseTimeout)
-	oauthUsecase := usecases.NewOAuthUsecase(userRepo, tokenRepo, jwtService, googleOAuth2Service, cfg.UsecaseTimeout)
+	userUsecase := usecases.NewUserUsecase(userRepo, passwordService, jwtService, tokenRepo, emailService, imageUploadService, cfg.UsecaseTimeout,
+		usecases.WithActivationTokenTTL(cfg.ActivationTokenTTL), usecases.WithResetTokenTTL(cfg.ResetTokenTTL), usecases.WithUserAuditLog(auditRepo),
+		usecases.WithFollowCounts(followRepo))
+	aiOptions := []usecases.AIUsecaseOption{usecases.WithPromptLanguage(cfg.AIPromptLanguage),
+		usecases.WithMaxConcurrentRequests(cfg.AIMaxConcurrent, cfg.AIQueueTimeout), usecases.WithToxicityScoreCache(cacheService)}
+	if cfg.AIPromptDir != "" {
+		prompts, err := usecases.LoadPromptTemplates(os.DirFS(cfg.AIPromptDir))
+		if err != nil {
+			log.Fatalf("FATAL: Failed to load AI prompt templates: %v", err)
+		}
+		aiOptions = append(aiOptions, usecases.WithPromptTemplates(prompts))
+	}
+	aiUsecase := usecases.NewAIUsecase(aiService, 10*cfg.UsecaseTimeout, aiOptions...)
+	blogUsecase := usecases.NewBlogUsecase(blogRepo, userRepo, interactionRepo, revisionRepo, commentRepo, readRepo, cfg.UsecaseTimeout,
+		usecases.WithMaxRevisions(cfg.MaxBlogRevisions), usecases.WithBlogProfanityFilter(profanityFilter),
+		usecases.WithImageHostPolicy(imageHostPolicy), usecases.WithBlogAuditLog(auditRepo),
+		usecases.WithMaxTagListLimit(cfg.MaxTagListLimit), usecases.WithFollowingFeed(followRepo, cfg.FeedTrendingFallback),
+		usecases.WithDuplicateWindow(cfg.BlogDuplicateWindow), usecases.WithMinAccountAge(cfg.MinAccountAge),
+		usecases.WithPreModeration(cfg.PreModeration), usecases.WithBlogSimilarityWindow(cacheService, cfg.SimilarityWindow, cfg.SimilarityMaxWords),
+		usecases.WithBlogToxicityThreshold(aiUsecase, cfg.ToxicityThreshold, domain.ToxicityAction(cfg.ToxicityAction)),
+		usecases.WithDefaultBlogSort(cfg.BlogDefaultSort))
+	commentUsecase := usecases.NewCommentUsecase(blogRepo, commentRepo, userRepo, emailService, cfg.UsecaseTimeout,
+		usecases.WithCommentLengthLimits(cfg.CommentMinLength, cfg.CommentMaxLength), usecases.WithCommentProfanityFilter(profanityFilter),
+		usecases.WithMaxCommentsPerBlog(cfg.MaxCommentsPerBlog), usecases.WithCommentCooldown(cacheService, cfg.CommentCooldown),
+		usecases.WithEmbeddedReplies(cfg.EmbeddedReplies), usecases.WithCommentMinAccountAge(cfg.MinAccountAge),
+		usecases.WithCommentPreModeration(cfg.PreModeration), usecases.WithCommentSimilarityWindow(cacheService, cfg.SimilarityWindow, cfg.SimilarityMaxWords),
+		usecases.WithCommentToxicityThreshold(aiUsecase, cfg.ToxicityThreshold, domain.ToxicityAction(cfg.ToxicityAction)),
+		usecases.WithCommentDeletePolicy(domain.CommentDeletePolicy(cfg.CommentDeletePolicy)),
+		usecases.WithCommentDeleteWindow(cfg.CommentDeleteWindow))
+	oauthUsecase := usecases.NewOAuthUsecase(userRepo, tokenRepo, jwtService, googleOAuth2Service, cacheService, cfg.UsecaseTimeout,
+		usecases.WithAllowedRedirectURIs(cfg.GoogleRedirectURIs...))
+	auditUsecase := usecases.NewAuditUsecase(auditRepo, cfg.UsecaseTimeout)
+	summaryUsecase := usecases.NewSummaryUsecase(statsRepo, cacheService, usecases.DefaultSummaryCacheTTL, cfg.UsecaseTimeout)
+	followUsecase := usecases.NewFollowUsecase(followRepo, userRepo, cfg.UsecaseTimeout, usecases.WithFollowNotifications(emailService))
+	moderationUsecase := usecases.NewModerationUsecase(blogRepo, commentRepo, cfg.UsecaseTimeout, usecases.WithModerationAuditLog(auditRepo))
+
+	// --- Background Workers ---
+	go usecases.RunScheduledPublisher(context.Background(), blogUsecase, cfg.PublishInterval)
+	go usecases.RunExpiredTokenCleanup(context.Background(), tokenRepo, cfg.CleanupInterval)
+	if viewBatcher != nil {
+		go viewBatcher.Run(context.Background(), cfg.ViewFlushInterval)
+	}
 
 	// --- Controllers & Router ---
 	userController := controllers.NewUserController(userUsecase)
 	blogController := controllers.NewBlogController(blogUsecase)
-	aiController := controllers.NewAIController(aiUsecase)
+	aiController := controllers.NewAIController(aiUsecase, cfg.SSEBufferSize)
 	commentController := controllers.NewCommentController(commentUsecase)
 	oauthController := controllers.NewOAuthController(oauthUsecase)
+	auditController := controllers.NewAuditController(auditUsecase)
+	summaryController := controllers.NewSummaryController(summaryUsecase)
+	followController := controllers.NewFollowController(followUsecase)
+	var emailDebugController *controllers.EmailDebugController
+	if emailOutbox != nil {
+		emailDebugController = controllers.NewEmailDebugController(emailOutbox)
+	}
+
+	maintenanceController := controllers.NewMaintenanceController(maintenance)
+	moderationController := controllers.NewModerationController(moderationUsecase)
+
+	router := routers.SetupRouter(userController, blogController, aiController, commentController, oauthController, auditController, summaryController, followController, emailDebugController, maintenanceController, moderationController, jwtService, userRepo, rateLimiter, maintenance, routers.CORSConfig{
+		Public: infrastructure.CORSPolicy{
+			AllowedOrigins: cfg.CORSAllowedOrigins,
+			AllowedMethods: cfg.CORSAllowedMethods,
+			AllowedHeaders: cfg.CORSAllowedHeaders,
+			MaxAge:         cfg.CORSMaxAge,
+		},
+		Admin: infrastructure.CORSPolicy{
+			AllowedOrigins: cfg.CORSAdminAllowedOrigins,
+			AllowedMethods: cfg.CORSAdminAllowedMethods,
+			AllowedHeaders: cfg.CORSAllowedHeaders,
+			MaxAge:         cfg.CORSMaxAge,
+		},
+	}, routers.RateLimit{Limit: int64(cfg.AISuggestRateLimit), Period: cfg.AISuggestRateWindow})
 
-	router := routers.SetupRouter(userController, blogController, aiController, commentController, oauthController, jwtService, rateLimiter)
+	// Stop on SIGINT or SIGTERM so in-flight requests finish and buffered views are saved.
+	stopCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	log.Printf("Server starting on port %s...", cfg.ServerPort)
-	if err := router.Run(":" + cfg.ServerPort); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	server := &http.Server{Addr: ":" + cfg.ServerPort, Handler: router}
+	go func() {
+		log.Printf("Server starting on port %s...", cfg.ServerPort)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Server failed to start: %v", err)
+		}
+	}()
+
+	<-stopCtx.Done()
+	log.Println("Shutting down...")
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer shutdownCancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server did not shut down cleanly: %v", err)
+	}
+	if viewBatcher != nil {
+		if err := viewBatcher.Flush(shutdownCtx); err != nil {
+			log.Printf("Failed to save buffered blog views: %v", err)
+		}
 	}
 }
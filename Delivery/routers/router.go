@@ -2,13 +2,34 @@ package routers
 
 import (
 	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
 	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// adminPathPrefix is where the admin route group is mounted.
+const adminPathPrefix = "/api/v1/admin"
+
+// maintenancePath is where admins switch maintenance mode on and off.
+const maintenancePath = adminPathPrefix + "/maintenance"
+
+// CORSConfig holds the CORS policy of each route group. The admin group normally gets a
+// stricter one than the public routes, e.g. a fixed list of origins and fewer methods.
+type CORSConfig struct {
+	Public infrastructure.CORSPolicy
+	Admin  infrastructure.CORSPolicy
+}
+
+// RateLimit is a number of requests allowed per period. A zero Limit means no limit.
+type RateLimit struct {
+	Limit  int64
+	Period time.Duration
+}
+
 // SetupRouter sets up all API routes for the blog platform
 func SetupRouter(
 	userController *controllers.UserController,
@@ -16,16 +37,45 @@ func SetupRouter(
 	aiController *controllers.AIController,
 	commentController *controllers.CommentController,
 	oauthController *controllers.OAuthController,
+	auditController *controllers.AuditController,
+	summaryController *controllers.SummaryController,
+	followController *controllers.FollowController,
+	emailDebugController *controllers.EmailDebugController,
+	maintenanceController *controllers.MaintenanceController,
+	moderationController *controllers.ModerationController,
 	jwtService infrastructure.JWTService,
+	userLoader infrastructure.UserLoader,
 	rateLimiter *infrastructure.RateLimiter,
+	maintenance *infrastructure.MaintenanceMode,
+	corsConfig CORSConfig,
+	aiSuggestLimit RateLimit,
 ) *gin.Engine {
 
 	router := gin.Default()
 
+	// CORS is applied on the engine rather than on the groups, because preflight requests
+	// match no route and group middleware would never see them.
+	publicCORS := infrastructure.CORSMiddleware(corsConfig.Public)
+	adminCORS := infrastructure.CORSMiddleware(corsConfig.Admin)
+	router.Use(func(c *gin.Context) {
+		if strings.HasPrefix(c.Request.URL.Path+"/", adminPathPrefix+"/") {
+			adminCORS(c)
+			return
+		}
+		publicCORS(c)
+	})
+
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
+	// Publish the public keys so other services can verify our tokens. Only RS256 has any.
+	if jwks := jwtService.JWKS(); jwks != nil {
+		router.GET("/.well-known/jwks.json", func(c *gin.Context) {
+			c.JSON(http.StatusOK, jwks)
+		})
+	}
+
 	// --- Rate Limiting ---
 	// General api limit for all routes
 	generalAPILimiter := rateLimiter.LimiterMiddleware(200, 1*time.Minute, "userID")
@@ -34,7 +84,19 @@ func SetupRouter(
 	// Highest limit for expeinsive routes
 	aiAPILimiter := rateLimiter.LimiterMiddleware(10, 1*time.Hour, "userID")
 
+	// Malformed IDs in the path are answered with 404 before reaching a handler.
+	validBlogIDs := controllers.ValidateIDParams("blogID", "rev")
+	validCommentIDs := controllers.ValidateIDParams("commentID")
+	validUserIDs := controllers.ValidateIDParams("userID")
+
 	apiV1 := router.Group("/api/v1")
+	// In maintenance mode only reads get through. Admins must still be able to sign in and switch it off.
+	apiV1.Use(controllers.PaginationMiddleware(), maintenance.Middleware(
+		maintenancePath,
+		"/api/v1/auth/login",
+		"/api/v1/auth/refresh",
+		"/api/v1/blogs/batch", // A read that takes its IDs in a POST body.
+	))
 
 	// ---------------------
 	// Auth Routes (Public)
@@ -44,15 +106,21 @@ func SetupRouter(
 	{
 		auth.POST("/register", userController.Register)
 		auth.GET("/activate", userController.ActivateAccount)
+		auth.GET("/email/confirm", userController.ConfirmEmailChange)
 		auth.POST("/login", userController.Login)
 		auth.POST("/refresh", userController.RefreshToken)
 		auth.POST("/logout", userController.Logout)
 
 		google := auth.Group("/google")
 		{
+			google.GET("/login", oauthController.GoogleLogin)
 			google.POST("/callback", oauthController.HandleGoogleCallback)
 		}
 	}
+	// Called on every page load by frontends, so it gets the general limit rather than the strict one.
+	apiV1.GET("/auth/me", infrastructure.AuthMiddleware(jwtService), generalAPILimiter, infrastructure.AttachUser(userLoader), userController.Me)
+	// For debugging and service-to-service checks, so it is limited to admins.
+	apiV1.POST("/auth/introspect", infrastructure.AuthMiddleware(jwtService), infrastructure.RequireRole(domain.RoleAdmin), generalAPILimiter, userController.IntrospectToken)
 
 	// -------------------------
 	// Password Routes (Public)
@@ -68,63 +136,145 @@ func SetupRouter(
 	// Profile Routes (Private)
 	// ------------------------
 	profile := apiV1.Group("/profile")
-	profile.Use(infrastructure.AuthMiddleware(jwtService), generalAPILimiter)
+	profile.Use(infrastructure.AuthMiddleware(jwtService), generalAPILimiter, infrastructure.AttachUser(userLoader))
 	{
 		profile.GET("", userController.GetProfile)
 		profile.PUT("", userController.UpdateProfile)
+		profile.PATCH("/username", userController.UpdateUsername)
+		profile.POST("/email", userController.RequestEmailChange)
+		profile.DELETE("/oauth", userController.UnlinkProvider)
 	}
 
 	// ------------------------
 	// Admin Routes
 	// ------------------------
 	admin := apiV1.Group("/admin")
-	admin.Use(infrastructure.AuthMiddleware(jwtService), infrastructure.AdminOnlyMiddleware(), generalAPILimiter)
+	admin.Use(infrastructure.AuthMiddleware(jwtService), infrastructure.RequireRole(domain.RoleAdmin), generalAPILimiter, controllers.ValidateIDParams("blogID", "contentID"))
 	{
 		admin.GET("/users", userController.SearchAndFilter)
+		admin.GET("/users/export", userController.ExportUsers)
 		admin.PATCH("/users/:userID/role", userController.SetUserRole)
+		admin.POST("/users/:userID/revoke-tokens", userController.RevokeTokens)
+		admin.POST("/users/:userID/impersonate", userController.Impersonate)
+		admin.POST("/blogs/import", blogController.ImportBlogs)
+		admin.POST("/blogs/import-markdown", blogController.ImportMarkdownBlogs)
+		admin.POST("/tags/merge", blogController.MergeTags)
+		admin.POST("/tags/rename", blogController.RenameTag)
+		admin.GET("/blogs/:blogID/verify-counts", blogController.VerifyCounts)
+		admin.GET("/audit", auditController.Search)
+		admin.GET("/summary", summaryController.GetSummary)
+		admin.GET("/maintenance", maintenanceController.GetStatus)
+		admin.PUT("/maintenance", maintenanceController.SetStatus)
+		admin.GET("/moderation-queue", moderationController.GetQueue)
+		admin.POST("/moderation-queue/:kind/:contentID/approve", moderationController.Approve)
+		admin.POST("/moderation-queue/:kind/:contentID/reject", moderationController.Reject)
+		// Only present when emails are captured instead of sent, i.e. outside production.
+		if emailDebugController != nil {
+			admin.GET("/debug/emails", emailDebugController.ListEmails)
+		}
 	}
 
 	// ------------------------
 	// Blog Routes (Mixed)
 	// ------------------------
 	publicBlogs := apiV1.Group("/blogs")
-	publicBlogs.Use(generalAPILimiter)
+	publicBlogs.Use(generalAPILimiter, validBlogIDs)
 	{
-		publicBlogs.GET("", blogController.SearchAndFilter)
-		publicBlogs.GET("/:blogID", blogController.GetByID)
+		publicBlogs.GET("", infrastructure.OptionalAuth(jwtService), blogController.SearchAndFilter)
+		publicBlogs.GET("/random", blogController.GetRandom)
+		publicBlogs.GET("/suggest", blogController.SuggestTitles)
+		publicBlogs.GET("/:blogID", infrastructure.OptionalAuth(jwtService), blogController.GetByID)
+		publicBlogs.GET("/:blogID/full", infrastructure.OptionalAuth(jwtService), blogController.GetBlogDetail)
 		publicBlogs.GET("/:blogID/comments", commentController.GetCommentsForBlog)
+		publicBlogs.GET("/:blogID/comments/search", commentController.SearchCommentsInBlog)
+		publicBlogs.GET("/:blogID/export", infrastructure.OptionalAuth(jwtService), blogController.ExportBlog)
+		// A POST only so the ID list can go in the body.
+		publicBlogs.POST("/batch", infrastructure.OptionalAuth(jwtService), blogController.GetByIDs)
 	}
 
+	apiV1.GET("/tags", generalAPILimiter, blogController.ListTags)
+	apiV1.GET("/feed", infrastructure.AuthMiddleware(jwtService), generalAPILimiter, blogController.GetFollowingFeed)
+
 	protectedBlogs := apiV1.Group("/blogs")
-	protectedBlogs.Use(infrastructure.AuthMiddleware(jwtService), strictAPILimiter)
+	protectedBlogs.Use(infrastructure.AuthMiddleware(jwtService), strictAPILimiter, validBlogIDs)
 	{
 		protectedBlogs.POST("", blogController.Create)
+		protectedBlogs.POST("/from-ai", blogController.CreateFromAI)
 		protectedBlogs.PUT("/:blogID", blogController.Update)
 		protectedBlogs.DELETE("/:blogID", blogController.Delete)
 		protectedBlogs.POST("/:blogID/interact", blogController.InteractWithBlog)
+		protectedBlogs.POST("/interact/batch", blogController.InteractWithBlogs)
+		protectedBlogs.GET("/:blogID/interactions", blogController.ListBlogInteractions)
+		protectedBlogs.POST("/:blogID/pin", blogController.Pin)
+		protectedBlogs.POST("/:blogID/unpin", blogController.Unpin)
+		protectedBlogs.POST("/:blogID/read", blogController.MarkRead)
+		protectedBlogs.GET("/:blogID/revisions", blogController.ListRevisions)
+		protectedBlogs.GET("/:blogID/revisions/:rev", blogController.GetRevision)
+		protectedBlogs.POST("/:blogID/revisions/:rev/restore", blogController.RestoreRevision)
+		protectedBlogs.POST("/:blogID/clone", blogController.CloneBlog)
+		protectedBlogs.POST("/:blogID/publish", blogController.PublishBlog)
 		// If it is a top level comment, parent Id will be null
 		protectedBlogs.POST("/:blogID/comments", commentController.CreateComment)
 	}
 
+	// ------------------------
+	// User Routes (Public)
+	// ------------------------
+	users := apiV1.Group("/users")
+	users.Use(generalAPILimiter)
+	{
+		// Mention autocomplete is only for signed-in users, under a limit of its own so it can't
+		// be used to list every username.
+		users.GET("/suggest", infrastructure.AuthMiddleware(jwtService), rateLimiter.ScopedLimiterMiddleware("user-suggest", 60, 1*time.Minute, "userID"), userController.SuggestUsernames)
+		users.GET("/:userID", validUserIDs, userController.GetPublicProfile)
+		users.GET("/:userID/blogs", infrastructure.OptionalAuth(jwtService), blogController.ListByAuthor)
+		users.GET("/:userID/followers", validUserIDs, followController.ListFollowers)
+		users.GET("/:userID/following", validUserIDs, followController.ListFollowing)
+	}
+
+	follows := apiV1.Group("/users")
+	follows.Use(infrastructure.AuthMiddleware(jwtService), strictAPILimiter, validUserIDs)
+	{
+		follows.POST("/:userID/follow", followController.Follow)
+		follows.DELETE("/:userID/follow", followController.Unfollow)
+	}
+
+	me := apiV1.Group("/users/me")
+	me.Use(infrastructure.AuthMiddleware(jwtService), generalAPILimiter)
+	{
+		me.GET("/read", blogController.ListRead)
+		me.GET("/interactions", blogController.ListInteractions)
+	}
+
 	// ------------------------
 	// AI Routes (Protected)
 	// ------------------------
 	ai := apiV1.Group("/ai")
 	ai.Use(infrastructure.AuthMiddleware(jwtService), aiAPILimiter)
 	{
-		ai.POST("/suggest", aiController.Suggest)
+		// Suggestions are cheap to request in bulk, which makes them handy for probing the model,
+		// so they get a tighter limit of their own besides the group's.
+		suggestHandlers := []gin.HandlerFunc{aiController.Suggest}
+		if aiSuggestLimit.Limit > 0 {
+			suggestLimiter := rateLimiter.ScopedLimiterMiddleware("ai-suggest", aiSuggestLimit.Limit, aiSuggestLimit.Period, "userID")
+			suggestHandlers = append([]gin.HandlerFunc{suggestLimiter}, suggestHandlers...)
+		}
+		ai.POST("/suggest", suggestHandlers...)
+		ai.POST("/generate", aiController.Generate)
+		ai.POST("/generate/stream", aiController.GenerateStream)
 	}
 
 	// ------------------------
 	// Comment Routes
 	// ------------------------
 	comments := apiV1.Group("/comments")
-	comments.Use(generalAPILimiter)
+	comments.Use(generalAPILimiter, validCommentIDs)
 	{
 		comments.GET("/:commentID/replies", commentController.GetRepliesForComment)
+		comments.GET("/:commentID/thread", commentController.GetThreadPath)
 	}
 	protectedComments := apiV1.Group("/comments")
-	protectedComments.Use(infrastructure.AuthMiddleware(jwtService), strictAPILimiter)
+	protectedComments.Use(infrastructure.AuthMiddleware(jwtService), strictAPILimiter, validCommentIDs)
 	{
 		protectedComments.PUT("/:commentID", commentController.UpdateComment)
 		protectedComments.DELETE("/:commentID", commentController.DeleteComment)
@@ -0,0 +1,84 @@
+package routers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	"A2SV_Starter_Project_Blog/Delivery/routers"
+	domain "A2SV_Starter_Project_Blog/Domain"
+	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubBlogUsecase answers the tag listing; any other call would panic on the nil interface.
+type stubBlogUsecase struct {
+	domain.IBlogUsecase
+}
+
+func (stubBlogUsecase) ListTags(ctx context.Context, page, limit int64, sort domain.TagSort) (*domain.TagPage, error) {
+	return &domain.TagPage{Tags: []*domain.TagCount{{Tag: "go", Count: 3}}, Total: 1, Limit: limit}, nil
+}
+
+func setupTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	// Nothing listens here. The rate limiter and maintenance mode let requests through when
+	// Redis can't be reached, so the routes still answer.
+	redisService := &infrastructure.RedisService{Client: redis.NewClient(&redis.Options{
+		Addr:        "127.0.0.1:1",
+		MaxRetries:  -1,
+		DialTimeout: 100 * time.Millisecond,
+	})}
+
+	return routers.SetupRouter(
+		&controllers.UserController{},
+		controllers.NewBlogController(stubBlogUsecase{}),
+		&controllers.AIController{},
+		&controllers.CommentController{},
+		&controllers.OAuthController{},
+		&controllers.AuditController{},
+		&controllers.SummaryController{},
+		&controllers.FollowController{},
+		nil,
+		&controllers.MaintenanceController{},
+		&controllers.ModerationController{},
+		infrastructure.NewJWTService("test-secret", "test-issuer", time.Minute, time.Hour),
+		nil,
+		infrastructure.NewRateLimiter(redisService),
+		infrastructure.NewMaintenanceMode(redisService),
+		routers.CORSConfig{},
+		routers.RateLimit{},
+	)
+}
+
+func TestSetupRouter_APIVersionPrefix(t *testing.T) {
+	router := setupTestRouter()
+
+	t.Run("Served Under /api/v1", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/tags", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"tag":"go"`)
+	})
+
+	t.Run("Not Served At The Bare Path", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tags", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Health Check Stays Unversioned", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
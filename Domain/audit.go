@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// AuditAction names a sensitive admin action recorded in the audit log.
+type AuditAction string
+
+const (
+	AuditActionRoleChange   AuditAction = "user.role_change"
+	AuditActionRevokeTokens AuditAction = "user.revoke_tokens"
+	AuditActionImpersonate  AuditAction = "user.impersonate"
+	AuditActionBlogDelete   AuditAction = "blog.delete"
+	AuditActionBlogImport   AuditAction = "blog.import"
+	AuditActionTagMerge     AuditAction = "tag.merge"
+	AuditActionTagRename    AuditAction = "tag.rename"
+	AuditActionApprove      AuditAction = "content.approve"
+	AuditActionReject       AuditAction = "content.reject"
+)
+
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditActionRoleChange, AuditActionRevokeTokens, AuditActionImpersonate, AuditActionBlogDelete, AuditActionBlogImport,
+		AuditActionTagMerge, AuditActionTagRename, AuditActionApprove, AuditActionReject:
+		return true
+	}
+	return false
+}
+
+// AuditEntry records who did what to which resource, and when.
+type AuditEntry struct {
+	ID        string
+	ActorID   string
+	Action    AuditAction
+	TargetID  string // The affected user or blog. Empty for actions without a single target.
+	Timestamp time.Time
+	Details   map[string]string
+}
+
+// AuditFilterOptions narrows an audit log search. Nil fields don't filter.
+type AuditFilterOptions struct {
+	ActorID   *string
+	Action    *AuditAction
+	StartDate *time.Time
+	EndDate   *time.Time
+
+	Page  int64
+	Limit int64
+}
@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
 	"strings"
 	"time"
 )
@@ -9,16 +12,46 @@ type Blog struct {
 	ID            string
 	Title         string
 	Content       string
+	ContentFormat ContentFormat
+	WordCount     int
 	AuthorID      string
 	Tags          []string
 	Views         int64
 	Likes         int64
 	Dislikes      int64
 	CommentsCount int64
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// EngagementScore weighs the counters above together. The repository keeps it up to date.
+	EngagementScore float64
+	Status          BlogStatus
+	ScheduledFor    *time.Time
+	PublishedAt     *time.Time
+	// An author can pin at most one blog to the top of their profile.
+	PinnedByAuthor bool
+	// LastActivityAt is when the blog was created or last gained or lost a comment.
+	LastActivityAt time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
+// PopularityGravity is how fast a blog's popularity fades with age.
+const PopularityGravity = 1.8
+
+// PopularityScore is what the "popularity" sort ranks blogs by, as of now: the engagement score
+// divided by (hours since creation + 2) ^ PopularityGravity, as in Hacker News ranking.
+func (b *Blog) PopularityScore(now time.Time) float64 {
+	return b.EngagementScore / math.Pow(now.Sub(b.CreatedAt).Hours()+2, PopularityGravity)
+}
+
+type BlogStatus string
+
+const (
+	// A draft is hidden from listings. Scheduled blogs stay drafts until their time comes.
+	BlogStatusDraft     BlogStatus = "draft"
+	BlogStatusPublished BlogStatus = "published"
+	// A pending blog is hidden like a draft until an admin approves it. Only used with pre-moderation.
+	BlogStatusPending BlogStatus = "pending"
+)
+
 type GlobalLogic string
 type SortOrder string
 type ActionType string
@@ -38,6 +71,8 @@ type BlogSearchFilterOptions struct {
 	Title      *string
 	AuthorName *string
 	AuthorIDs  []string
+	// Only blogs by authors who signed up with this provider, e.g. for admin analysis.
+	AuthorProvider *AuthProvider
 	// AND or OR
 	GlobalLogic GlobalLogic
 
@@ -49,12 +84,131 @@ type BlogSearchFilterOptions struct {
 	StartDate *time.Time
 	EndDate   *time.Time
 
+	// Only blogs with at least this many words, e.g. for long-form reads. Zero disables the filter.
+	MinWordCount int
+
 	Page  int64
 	Limit int64
 
 	SortBy string
 	// ASC or DESC
 	SortOrder SortOrder
+
+	// IncludeDrafts also returns unpublished blogs. Public listings leave it false.
+	IncludeDrafts bool
+
+	// PinnedFirst puts an author's pinned blog ahead of the requested sort, for profile pages.
+	PinnedFirst bool
+
+	// IncludeContent loads the full blog body. List views leave it false so
+	// the content field is projected out of the query.
+	IncludeContent bool
+}
+
+// BlogRevision is a snapshot of a blog's editable fields, taken before an update overwrote them.
+type BlogRevision struct {
+	ID        string
+	BlogID    string
+	Title     string
+	Content   string
+	Tags      []string
+	EditorID  string
+	CreatedAt time.Time
+}
+
+// BlogImportItem is a single entry of an admin bulk import.
+// CreatedAt is optional and preserved as-is when migrating existing content.
+type BlogImportItem struct {
+	Title     string
+	Content   string
+	AuthorID  string
+	Tags      []string
+	CreatedAt *time.Time
+}
+
+// BlogImportResult reports the outcome of one import item, keyed by its position in the request.
+type BlogImportResult struct {
+	Index  int
+	BlogID string
+	Err    error
+}
+
+// TagSort is the order tags are listed in.
+type TagSort string
+
+const (
+	TagSortCount TagSort = "count" // Most used first; the default.
+	TagSortAlpha TagSort = "alpha"
+)
+
+// TagSorts lists every accepted TagSort.
+var TagSorts = []TagSort{TagSortCount, TagSortAlpha}
+
+// IsValid checks if the sort is one of the predefined values.
+func (s TagSort) IsValid() bool {
+	switch s {
+	case TagSortCount, TagSortAlpha:
+		return true
+	}
+	return false
+}
+
+// TagCount is a tag and the number of published blogs that carry it.
+type TagCount struct {
+	Tag   string
+	Count int64
+}
+
+// TagPage is one page of the tag listing. Limit is the page size actually applied,
+// which may be lower than the one requested.
+type TagPage struct {
+	Tags  []*TagCount
+	Total int64
+	Limit int64
+}
+
+// BlogTitleSuggestion is a published blog offered by title while the user types a search.
+type BlogTitleSuggestion struct {
+	ID    string
+	Title string
+}
+
+// BlogExport is a blog together with every comment on it, for backup or sharing.
+// Comments holds the top-level comments, oldest first, with all their replies nested in Replies.
+type BlogExport struct {
+	Blog       *Blog
+	Comments   []*Comment
+	ExportedAt time.Time
+}
+
+// BlogDetail is everything a post page shows, loaded in one call: the blog, the first page of its
+// top-level comments, blogs sharing a tag with it, and how the viewer reacted to it.
+type BlogDetail struct {
+	Blog          *Blog
+	Comments      []*Comment
+	CommentsTotal int64
+	Related       []*Blog
+	// ViewerAction is empty for anonymous viewers and those who haven't reacted.
+	ViewerAction ActionType
+}
+
+// CommentCountCheck compares a blog's stored comment counter with the comments that actually exist.
+type CommentCountCheck struct {
+	BlogID string
+	Stored int64
+	Actual int64
+}
+
+// InSync reports whether the stored counter matches reality.
+func (c *CommentCountCheck) InSync() bool {
+	return c.Stored == c.Actual
+}
+
+// BlogRead records that a user has read a blog. ReadAt is when they first marked it read.
+type BlogRead struct {
+	UserID string
+	BlogID string
+	ReadAt time.Time
 }
 
 type BlogInteraction struct {
@@ -66,6 +220,27 @@ type BlogInteraction struct {
 	UpdatedAt time.Time
 }
 
+// InteractionResult is where a blog's votes stand after a user interacted with it.
+// Action is the user's vote afterwards, empty when they undid it.
+type InteractionResult struct {
+	Likes    int64
+	Dislikes int64
+	Action   ActionType
+}
+
+// InteractionHistoryEntry pairs one of a user's interactions with the blog it was made on.
+type InteractionHistoryEntry struct {
+	Interaction *BlogInteraction
+	Blog        *Blog
+}
+
+// BlogInteractionEntry is one vote on a blog with the user who cast it. User is nil when the
+// account no longer exists.
+type BlogInteractionEntry struct {
+	Interaction *BlogInteraction
+	User        *User
+}
+
 func NewBlog(title, content string, authorID string, tags []string) (*Blog, error) {
 	if strings.TrimSpace(title) == "" {
 		return nil, ErrValidation
@@ -79,15 +254,96 @@ func NewBlog(title, content string, authorID string, tags []string) (*Blog, erro
 	now := time.Now().UTC()
 
 	return &Blog{
-		Title:         title,
-		Content:       content,
-		AuthorID:      authorID,
-		Tags:          tags,
-		Views:         0, // Initialize views to 0
-		Likes:         0, // Initialize likes to 0
-		Dislikes:      0, // Initialize dislikes to 0
-		CommentsCount: 0, // Initialize comments to 0
-		CreatedAt:     now,
-		UpdatedAt:     now,
+		Title:          title,
+		Content:        content,
+		ContentFormat:  ContentFormatMarkdown,
+		WordCount:      CountWords(content),
+		AuthorID:       authorID,
+		Tags:           tags,
+		Views:          0, // Initialize views to 0
+		Likes:          0, // Initialize likes to 0
+		Dislikes:       0, // Initialize dislikes to 0
+		CommentsCount:  0, // Initialize comments to 0
+		Status:         BlogStatusPublished,
+		PublishedAt:    &now,
+		LastActivityAt: now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}, nil
 }
+
+// SetContent replaces the blog's content and keeps its word count in step. Words are counted
+// in the blog's ContentFormat, so a change of format is made before the content is set.
+func (b *Blog) SetContent(content string) {
+	b.Content = content
+	b.WordCount = CountWords(ContentText(content, b.ContentFormat))
+}
+
+// TableOfContents lists the blog's headings: markdown headings, or <h1> to <h6> in HTML.
+// Plain text has no headings.
+func (b *Blog) TableOfContents() []TOCEntry {
+	switch b.ContentFormat {
+	case ContentFormatHTML:
+		return extractHTMLHeadings(b.Content)
+	case ContentFormatPlainText:
+		return nil
+	}
+	return ExtractTableOfContents(b.Content)
+}
+
+// ContentHash fingerprints a blog's title and content, so the same post submitted twice can be
+// recognised. Surrounding whitespace is ignored.
+func ContentHash(title, content string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(title) + "\x00" + strings.TrimSpace(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// CountWords counts whitespace-separated words.
+func CountWords(content string) int {
+	return len(strings.Fields(content))
+}
+
+// Schedule turns the blog into a draft that will be published at the given time.
+// The time must be in the future.
+func (b *Blog) Schedule(at time.Time) error {
+	if !at.After(time.Now()) {
+		return ErrValidation
+	}
+	at = at.UTC()
+	b.Status = BlogStatusDraft
+	b.ScheduledFor = &at
+	b.PublishedAt = nil
+	return nil
+}
+
+// Publish marks the blog as published at the given time.
+func (b *Blog) Publish(at time.Time) {
+	at = at.UTC()
+	b.Status = BlogStatusPublished
+	b.PublishedAt = &at
+}
+
+// Clone copies the blog's title, marked as a copy, content, format and tags into a new draft owned by
+// ownerID. Counters, pinning and schedule start afresh.
+func (b *Blog) Clone(ownerID string) (*Blog, error) {
+	clone, err := NewBlog(b.Title+" (copy)", b.Content, ownerID, append([]string(nil), b.Tags...))
+	if err != nil {
+		return nil, err
+	}
+	clone.ContentFormat = b.ContentFormat
+	clone.SetContent(b.Content)
+	clone.Status = BlogStatusDraft
+	clone.PublishedAt = nil
+	return clone, nil
+}
+
+// SubmitForModeration holds the blog back from the public until an admin approves it.
+func (b *Blog) SubmitForModeration() {
+	b.Status = BlogStatusPending
+	b.PublishedAt = nil
+}
+
+// IsPublished reports whether the blog is publicly visible.
+func (b *Blog) IsPublished() bool {
+	return b.Status != BlogStatusDraft && b.Status != BlogStatusPending
+}
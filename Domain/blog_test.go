@@ -2,6 +2,7 @@ package domain_test
 
 import (
 	. "A2SV_Starter_Project_Blog/Domain"
+	"math"
 	"testing"
 	"time"
 
@@ -36,6 +37,7 @@ func (s *BlogDomainTestSuite) TestNewBlog_Success() {
 	// The rest of the assertions for the happy path remain the same.
 	s.Equal(title, blog.Title)
 	s.Equal(content, blog.Content)
+	s.Equal(7, blog.WordCount)
 	s.Equal(authorID, blog.AuthorID)
 	s.Equal(tags, blog.Tags)
 	s.Empty(blog.ID)
@@ -45,6 +47,113 @@ func (s *BlogDomainTestSuite) TestNewBlog_Success() {
 	s.Equal(int64(0), blog.CommentsCount)
 	s.WithinDuration(time.Now().UTC(), blog.CreatedAt, 2*time.Second)
 	s.Equal(blog.CreatedAt, blog.UpdatedAt)
+	s.Equal(BlogStatusPublished, blog.Status)
+	s.Require().NotNil(blog.PublishedAt)
+	s.Equal(blog.CreatedAt, *blog.PublishedAt)
+	s.Equal(blog.CreatedAt, blog.LastActivityAt)
+}
+
+func (s *BlogDomainTestSuite) TestSetContent_RecomputesWordCount() {
+	blog, err := NewBlog("Title", "one two three", "author-id", nil)
+	s.Require().NoError(err)
+	s.Equal(3, blog.WordCount)
+
+	blog.SetContent("  a longer\nbody,\twith   odd spacing ")
+
+	s.Equal("  a longer\nbody,\twith   odd spacing ", blog.Content)
+	s.Equal(6, blog.WordCount, "Any run of whitespace separates words")
+}
+
+func (s *BlogDomainTestSuite) TestContentHash() {
+	hash := ContentHash("Title", "Some content")
+
+	s.Len(hash, 64)
+	s.Equal(hash, ContentHash("  Title ", "Some content\n"), "surrounding whitespace is ignored")
+	s.NotEqual(hash, ContentHash("Title", "Other content"))
+	s.NotEqual(hash, ContentHash("Title Some", "content"), "title and content are kept apart")
+}
+
+func (s *BlogDomainTestSuite) TestSchedule() {
+	s.Run("Future time makes a draft", func() {
+		blog, _ := NewBlog("Title", "Content", "author-id", nil)
+		at := time.Now().Add(time.Hour)
+
+		err := blog.Schedule(at)
+
+		s.NoError(err)
+		s.Equal(BlogStatusDraft, blog.Status)
+		s.False(blog.IsPublished())
+		s.Require().NotNil(blog.ScheduledFor)
+		s.True(at.Equal(*blog.ScheduledFor))
+		s.Nil(blog.PublishedAt)
+	})
+
+	s.Run("Past time is rejected", func() {
+		blog, _ := NewBlog("Title", "Content", "author-id", nil)
+
+		err := blog.Schedule(time.Now().Add(-time.Minute))
+
+		s.ErrorIs(err, ErrValidation)
+		s.True(blog.IsPublished(), "A rejected schedule must leave the blog untouched")
+	})
+
+	s.Run("Publish flips the status", func() {
+		blog, _ := NewBlog("Title", "Content", "author-id", nil)
+		s.Require().NoError(blog.Schedule(time.Now().Add(time.Hour)))
+		now := time.Now()
+
+		blog.Publish(now)
+
+		s.True(blog.IsPublished())
+		s.Require().NotNil(blog.PublishedAt)
+		s.True(now.Equal(*blog.PublishedAt))
+	})
+}
+
+func (s *BlogDomainTestSuite) TestSubmitForModeration() {
+	blog, _ := NewBlog("Title", "Content", "author-id", nil)
+
+	blog.SubmitForModeration()
+
+	s.Equal(BlogStatusPending, blog.Status)
+	s.False(blog.IsPublished())
+	s.Nil(blog.PublishedAt)
+
+	blog.Publish(time.Now())
+	s.True(blog.IsPublished(), "approval publishes the blog")
+}
+
+func (s *BlogDomainTestSuite) TestClone() {
+	source, _ := NewBlog("Title", "Content", "author-id", []string{"go"})
+	source.ID = "blog-1"
+	source.Views, source.Likes, source.PinnedByAuthor = 10, 3, true
+
+	clone, err := source.Clone("cloner-id")
+
+	s.Require().NoError(err)
+	s.Empty(clone.ID)
+	s.Equal("Title (copy)", clone.Title)
+	s.Equal("Content", clone.Content)
+	s.Equal("cloner-id", clone.AuthorID)
+	s.Equal(BlogStatusDraft, clone.Status)
+	s.Nil(clone.PublishedAt)
+	s.Zero(clone.Views)
+	s.Zero(clone.Likes)
+	s.False(clone.PinnedByAuthor)
+
+	clone.Tags[0] = "rust"
+	s.Equal([]string{"go"}, source.Tags, "the clone must not share the source's tags")
+}
+
+func (s *BlogDomainTestSuite) TestPopularityScore() {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	blog := &Blog{EngagementScore: 300, CreatedAt: now.Add(-10 * time.Hour)}
+
+	s.InDelta(300/math.Pow(12, PopularityGravity), blog.PopularityScore(now), 1e-9)
+
+	older := &Blog{EngagementScore: 300, CreatedAt: now.Add(-100 * time.Hour)}
+	s.Less(older.PopularityScore(now), blog.PopularityScore(now), "the same engagement counts for less as a blog ages")
+	s.Zero((&Blog{CreatedAt: now}).PopularityScore(now))
 }
 
 func (s *BlogDomainTestSuite) TestNewBlog_ValidationFailure() {
@@ -1,8 +1,10 @@
 package domain
 
 import (
+	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type Comment struct {
@@ -14,25 +16,117 @@ type Comment struct {
 	ParentID *string // nil for top level comments
 
 	ReplyCount int64
+	// Pending comments await admin approval and are hidden from everyone until then.
+	// They don't count towards their blog's or parent's counters before approval.
+	Pending bool
+	// Replies holds the first page of direct replies when they are embedded in a listing,
+	// or all of them in an export. It is never stored.
+	Replies []*Comment
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// HasMoreReplies reports whether the comment has replies beyond the ones embedded in Replies,
+// so clients know to page through the rest.
+func (c *Comment) HasMoreReplies() bool {
+	return c.ReplyCount > int64(len(c.Replies))
+}
+
+// ThreadComments nests a flat list of a blog's comments into threads by ParentID and returns
+// the top-level ones. Order within each level follows the input. A reply whose parent is
+// missing from the list is kept at the top level rather than dropped.
+func ThreadComments(comments []*Comment) []*Comment {
+	byID := make(map[string]*Comment, len(comments))
+	for _, c := range comments {
+		c.Replies = nil
+		byID[c.ID] = c
+	}
+	var roots []*Comment
+	for _, c := range comments {
+		if c.ParentID != nil {
+			if parent, ok := byID[*c.ParentID]; ok && parent != c {
+				parent.Replies = append(parent.Replies, c)
+				continue
+			}
+		}
+		roots = append(roots, c)
+	}
+	return roots
+}
+
+// CommentSort is the order top-level comments are listed in.
+type CommentSort string
+
+const (
+	CommentSortOldest CommentSort = "oldest" // Conversation order; the default.
+	CommentSortNewest CommentSort = "newest"
+	CommentSortTop    CommentSort = "top" // Most replied-to first.
+)
+
+// CommentSorts lists every accepted CommentSort.
+var CommentSorts = []CommentSort{CommentSortOldest, CommentSortNewest, CommentSortTop}
+
+// IsValid checks if the sort is one of the predefined values.
+func (s CommentSort) IsValid() bool {
+	switch s {
+	case CommentSortOldest, CommentSortNewest, CommentSortTop:
+		return true
+	}
+	return false
+}
+
+// CommentDeletePolicy decides what deleting a published comment does to it.
+type CommentDeletePolicy string
+
+const (
+	// CommentDeleteAnonymize keeps every deleted comment in place as "[deleted]" with no author; the default.
+	CommentDeleteAnonymize CommentDeletePolicy = "anonymize"
+	// CommentDeleteHard removes a comment that has no replies outright. One with replies is
+	// still anonymized, so the thread keeps its shape.
+	CommentDeleteHard CommentDeletePolicy = "hard"
+)
+
+func (p CommentDeletePolicy) IsValid() bool {
+	return p == CommentDeleteAnonymize || p == CommentDeleteHard
+}
+
+// CommentLengthLimits bounds the length of a comment's trimmed content, counted in characters.
+type CommentLengthLimits struct {
+	Min int
+	Max int
+}
+
+// DefaultCommentLengthLimits are used when no limits are configured.
+var DefaultCommentLengthLimits = CommentLengthLimits{Min: 1, Max: 2000}
+
+// Check returns ErrValidation when the trimmed content is blank or outside the limits.
+func (l CommentLengthLimits) Check(content string) error {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return ErrValidation
+	}
+	length := utf8.RuneCountInString(content)
+	if length < l.Min || length > l.Max {
+		return ErrValidation
+	}
+	return nil
+}
+
 func NewComment(blogID, authorID, content string, parentID *string) (*Comment, error) {
+	return NewCommentWithLimits(blogID, authorID, content, parentID, DefaultCommentLengthLimits)
+}
+
+// NewCommentWithLimits is NewComment with configured content length limits.
+func NewCommentWithLimits(blogID, authorID, content string, parentID *string, limits CommentLengthLimits) (*Comment, error) {
 	if strings.TrimSpace(blogID) == "" {
 		return nil, ErrValidation
 	}
 	if strings.TrimSpace(authorID) == "" {
 		return nil, ErrValidation
 	}
-	if strings.TrimSpace(content) == "" {
-		return nil, ErrValidation
-	}
-
-	const maxCommentLength = 5000
-	if len(content) > maxCommentLength {
-		return nil, ErrValidation
+	if err := limits.Check(content); err != nil {
+		return nil, err
 	}
 
 	// if parentId is invlid, save the comment as a top level comment
@@ -56,3 +150,39 @@ func NewComment(blogID, authorID, content string, parentID *string) (*Comment, e
 		UpdatedAt: now,
 	}, nil
 }
+
+// SetContent replaces the comment's content after checking it against the limits.
+func (c *Comment) SetContent(content string, limits CommentLengthLimits) error {
+	if err := limits.Check(content); err != nil {
+		return err
+	}
+	c.Content = strings.TrimSpace(content)
+	c.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// MaxMentionsPerComment caps how many users a single comment can notify.
+const MaxMentionsPerComment = 10
+
+// mentionPattern matches @username when the @ does not follow a word character,
+// so email addresses in a comment are not taken for mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@(\w{1,50})`)
+
+// ExtractMentions returns the distinct usernames mentioned in content, in order of
+// first appearance, up to MaxMentionsPerComment.
+func ExtractMentions(content string) []string {
+	var mentions []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		username := match[1]
+		if seen[username] {
+			continue
+		}
+		seen[username] = true
+		mentions = append(mentions, username)
+		if len(mentions) == MaxMentionsPerComment {
+			break
+		}
+	}
+	return mentions
+}
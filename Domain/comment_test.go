@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -75,7 +76,7 @@ func (s *CommentTestSuite) TestNewComment() {
 			{name: "Empty BlogID", blogID: " ", authorID: "user-1", content: "c"},
 			{name: "Empty AuthorID", blogID: "b-1", authorID: " ", content: "c"},
 			{name: "Empty Content", blogID: "b-1", authorID: "user-1", content: " "},
-			{name: "Content Too Long", blogID: "b-1", authorID: "user-1", content: strings.Repeat("a", 5001)},
+			{name: "Content Too Long", blogID: "b-1", authorID: "user-1", content: strings.Repeat("a", 2001)},
 		}
 
 		for _, tc := range testCases {
@@ -104,3 +105,130 @@ func (s *CommentTestSuite) TestNewComment() {
 		s.Nil(comment.ParentID, "A ParentID with only whitespace should be ignored, resulting in a top-level comment")
 	})
 }
+
+func (s *CommentTestSuite) TestCommentLengthLimits() {
+	limits := CommentLengthLimits{Min: 3, Max: 10}
+
+	testCases := []struct {
+		name    string
+		content string
+		valid   bool
+	}{
+		{name: "Empty", content: "", valid: false},
+		{name: "Whitespace Only", content: "   ", valid: false},
+		{name: "Too Short", content: "ab", valid: false},
+		{name: "Too Long", content: strings.Repeat("a", 11), valid: false},
+		{name: "Shortest Allowed", content: "abc", valid: true},
+		{name: "Longest Allowed", content: strings.Repeat("a", 10), valid: true},
+		{name: "Surrounding Whitespace Is Not Counted", content: "  " + strings.Repeat("a", 10) + "  ", valid: true},
+		{name: "Counts Characters Not Bytes", content: strings.Repeat("é", 10), valid: true},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			comment, err := NewCommentWithLimits("blog-123", "user-abc", tc.content, nil, limits)
+			if tc.valid {
+				s.NoError(err)
+				s.NotNil(comment)
+			} else {
+				s.ErrorIs(err, ErrValidation)
+				s.Nil(comment)
+			}
+		})
+	}
+
+	s.Run("Default Limits", func() {
+		_, err := NewComment("blog-123", "user-abc", strings.Repeat("a", DefaultCommentLengthLimits.Max), nil)
+		s.NoError(err)
+		_, err = NewComment("blog-123", "user-abc", strings.Repeat("a", DefaultCommentLengthLimits.Max+1), nil)
+		s.ErrorIs(err, ErrValidation)
+	})
+}
+
+func (s *CommentTestSuite) TestSetContent() {
+	comment, err := NewComment("blog-123", "user-abc", "Original", nil)
+	s.Require().NoError(err)
+	limits := CommentLengthLimits{Min: 1, Max: 10}
+
+	s.Run("Valid Content", func() {
+		s.NoError(comment.SetContent("  Updated  ", limits))
+		s.Equal("Updated", comment.Content)
+	})
+
+	s.Run("Invalid Content Leaves Comment Untouched", func() {
+		s.ErrorIs(comment.SetContent(" ", limits), ErrValidation)
+		s.ErrorIs(comment.SetContent(strings.Repeat("a", 11), limits), ErrValidation)
+		s.Equal("Updated", comment.Content)
+	})
+}
+
+func (s *CommentTestSuite) TestExtractMentions() {
+	testCases := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{name: "No Mentions", content: "Nice post", expected: nil},
+		{name: "Single Mention", content: "@alice great point", expected: []string{"alice"}},
+		{name: "Punctuation Ends The Username", content: "Thanks @alice, and @bob_2!", expected: []string{"alice", "bob_2"}},
+		{name: "Duplicates Are Dropped", content: "@alice @bob @alice", expected: []string{"alice", "bob"}},
+		{name: "Email Addresses Are Not Mentions", content: "write to alice@example.com", expected: nil},
+		{name: "Bare At Sign", content: "meet @ noon", expected: nil},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.expected, ExtractMentions(tc.content))
+		})
+	}
+
+	s.Run("Capped", func() {
+		var b strings.Builder
+		for i := 0; i < MaxMentionsPerComment+5; i++ {
+			fmt.Fprintf(&b, "@user%d ", i)
+		}
+		s.Len(ExtractMentions(b.String()), MaxMentionsPerComment)
+	})
+}
+
+func (s *CommentTestSuite) TestHasMoreReplies() {
+	replies := func(n int) []*Comment {
+		return make([]*Comment, n)
+	}
+	testCases := []struct {
+		name       string
+		replyCount int64
+		embedded   []*Comment
+		expected   bool
+	}{
+		{name: "No Replies", replyCount: 0, embedded: nil, expected: false},
+		{name: "Replies Not Embedded", replyCount: 2, embedded: nil, expected: true},
+		{name: "Some Replies Embedded", replyCount: 5, embedded: replies(3), expected: true},
+		{name: "All Replies Embedded", replyCount: 3, embedded: replies(3), expected: false},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			comment := &Comment{ReplyCount: tc.replyCount, Replies: tc.embedded}
+			s.Equal(tc.expected, comment.HasMoreReplies())
+		})
+	}
+}
+
+func (s *CommentTestSuite) TestThreadComments() {
+	id := func(v string) *string { return &v }
+	top1 := &Comment{ID: "t1"}
+	top2 := &Comment{ID: "t2"}
+	reply1 := &Comment{ID: "r1", ParentID: id("t1")}
+	nested := &Comment{ID: "n1", ParentID: id("r1")}
+	reply2 := &Comment{ID: "r2", ParentID: id("t1")}
+	orphan := &Comment{ID: "o1", ParentID: id("gone")}
+
+	roots := ThreadComments([]*Comment{top1, reply1, top2, nested, reply2, orphan})
+
+	s.Equal([]*Comment{top1, top2, orphan}, roots)
+	s.Equal([]*Comment{reply1, reply2}, top1.Replies)
+	s.Equal([]*Comment{nested}, reply1.Replies)
+	s.Empty(top2.Replies)
+	s.Empty(nested.Replies)
+}
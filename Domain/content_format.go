@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"html"
+	"net/url"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// ContentFormat is how a blog's content is written, which decides how it is cleaned before
+// storing and how headings and words are found in it.
+type ContentFormat string
+
+const (
+	ContentFormatMarkdown  ContentFormat = "markdown" // The default.
+	ContentFormatPlainText ContentFormat = "plaintext"
+	ContentFormatHTML      ContentFormat = "html"
+)
+
+// ContentFormats lists every accepted ContentFormat.
+var ContentFormats = []ContentFormat{ContentFormatMarkdown, ContentFormatPlainText, ContentFormatHTML}
+
+func (f ContentFormat) IsValid() bool {
+	switch f {
+	case ContentFormatMarkdown, ContentFormatPlainText, ContentFormatHTML:
+		return true
+	}
+	return false
+}
+
+var (
+	// allowedHTMLTags are the elements kept by SanitizeContent, with the attributes each may carry.
+	allowedHTMLTags = map[string][]string{
+		"p": nil, "br": nil, "hr": nil, "div": nil, "span": nil,
+		"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+		"strong": nil, "b": nil, "em": nil, "i": nil, "u": nil, "s": nil, "del": nil, "sub": nil, "sup": nil,
+		"blockquote": nil, "pre": nil, "code": nil,
+		"ul": nil, "ol": nil, "li": nil,
+		"table": nil, "thead": nil, "tbody": nil, "tr": nil,
+		"th": {"colspan", "rowspan"}, "td": {"colspan", "rowspan"},
+		"figure": nil, "figcaption": nil,
+		"a":   {"href", "title"},
+		"img": {"src", "alt", "title", "width", "height"},
+	}
+	// droppedHTMLTags are removed along with everything inside them. Other unknown elements
+	// only lose their tags, keeping their text.
+	droppedHTMLTags = map[string]bool{
+		"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+		"noscript": true, "template": true, "svg": true, "math": true, "textarea": true, "select": true,
+	}
+	// blockHTMLTags separate words, unlike inline tags such as <b> that may sit inside one.
+	blockHTMLTags = map[string]bool{
+		"p": true, "br": true, "hr": true, "div": true, "li": true, "tr": true, "td": true, "th": true,
+		"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+		"blockquote": true, "pre": true, "figcaption": true,
+	}
+)
+
+// SanitizeContent returns content as it may be stored in the given format. HTML keeps only a
+// known set of tags and attributes, and links only to http, https, mailto or relative URLs.
+// Plain text is HTML-escaped; entities already in it are decoded first, so escaping content
+// that comes back from the API for editing doesn't escape it twice. Markdown is stored as
+// written and left to the renderer.
+func SanitizeContent(content string, format ContentFormat) string {
+	switch format {
+	case ContentFormatHTML:
+		return sanitizeHTML(content)
+	case ContentFormatPlainText:
+		return html.EscapeString(html.UnescapeString(content))
+	}
+	return content
+}
+
+func sanitizeHTML(content string) string {
+	var b strings.Builder
+	z := xhtml.NewTokenizer(strings.NewReader(content))
+	// dropping is the element whose content is being removed, with how deeply it is nested.
+	dropping, depth := "", 0
+
+	for {
+		// Reading from a string, the only error is io.EOF at the end.
+		if z.Next() == xhtml.ErrorToken {
+			return b.String()
+		}
+		token := z.Token()
+
+		if dropping != "" {
+			switch {
+			case token.Type == xhtml.StartTagToken && token.Data == dropping:
+				depth++
+			case token.Type == xhtml.EndTagToken && token.Data == dropping:
+				if depth--; depth == 0 {
+					dropping = ""
+				}
+			}
+			continue
+		}
+
+		switch token.Type {
+		case xhtml.TextToken:
+			b.WriteString(html.EscapeString(token.Data))
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken, xhtml.EndTagToken:
+			if droppedHTMLTags[token.Data] {
+				if token.Type == xhtml.StartTagToken {
+					dropping, depth = token.Data, 1
+				}
+				continue
+			}
+			attrs, ok := allowedHTMLTags[token.Data]
+			if !ok {
+				continue
+			}
+			token.Attr = allowedAttributes(token.Attr, attrs)
+			b.WriteString(token.String())
+		}
+		// Comments and doctypes are dropped.
+	}
+}
+
+// allowedAttributes keeps the attributes named in allowed, dropping links to unsafe schemes.
+func allowedAttributes(attrs []xhtml.Attribute, allowed []string) []xhtml.Attribute {
+	var kept []xhtml.Attribute
+	for _, attr := range attrs {
+		name := strings.ToLower(attr.Key)
+		if attr.Namespace != "" || !containsString(allowed, name) {
+			continue
+		}
+		if (name == "href" || name == "src") && !isSafeURL(attr.Val) {
+			continue
+		}
+		kept = append(kept, xhtml.Attribute{Key: name, Val: attr.Val})
+	}
+	return kept
+}
+
+func isSafeURL(rawURL string) bool {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentText returns the readable text of content written in format, without markup, for
+// counting words. Markdown is returned as is.
+func ContentText(content string, format ContentFormat) string {
+	switch format {
+	case ContentFormatPlainText:
+		return html.UnescapeString(content)
+	case ContentFormatHTML:
+		var b strings.Builder
+		z := xhtml.NewTokenizer(strings.NewReader(content))
+		for z.Next() != xhtml.ErrorToken {
+			token := z.Token()
+			switch token.Type {
+			case xhtml.TextToken:
+				b.WriteString(token.Data)
+			case xhtml.StartTagToken, xhtml.SelfClosingTagToken, xhtml.EndTagToken:
+				if blockHTMLTags[token.Data] {
+					b.WriteByte(' ')
+				}
+			}
+		}
+		return b.String()
+	}
+	return content
+}
+
+// extractHTMLHeadings is ExtractTableOfContents for HTML content, reading <h1> to <h6>.
+func extractHTMLHeadings(content string) []TOCEntry {
+	var entries []TOCEntry
+	seen := make(map[string]int)
+	z := xhtml.NewTokenizer(strings.NewReader(content))
+	// heading is the level of the heading being read, zero outside one.
+	heading := 0
+	var text strings.Builder
+
+	for z.Next() != xhtml.ErrorToken {
+		token := z.Token()
+		level := headingLevel(token.Data)
+		switch {
+		case token.Type == xhtml.StartTagToken && level > 0:
+			heading = level
+			text.Reset()
+		case token.Type == xhtml.TextToken && heading > 0:
+			text.WriteString(token.Data)
+		case token.Type == xhtml.EndTagToken && level > 0 && level == heading:
+			if t := strings.Join(strings.Fields(text.String()), " "); t != "" {
+				entries = append(entries, TOCEntry{Level: heading, Text: t, Anchor: uniqueAnchor(slugify(t), seen)})
+			}
+			heading = 0
+		}
+	}
+	return entries
+}
+
+// headingLevel returns 1 to 6 for the tags h1 to h6, and 0 for any other.
+func headingLevel(tag string) int {
+	if len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6' {
+		return int(tag[1] - '0')
+	}
+	return 0
+}
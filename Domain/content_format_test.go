@@ -0,0 +1,84 @@
+package domain_test
+
+import (
+	"testing"
+
+	. "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ContentFormatTestSuite struct {
+	suite.Suite
+}
+
+func TestContentFormatTestSuite(t *testing.T) {
+	suite.Run(t, new(ContentFormatTestSuite))
+}
+
+func (s *ContentFormatTestSuite) TestIsValid() {
+	for _, format := range ContentFormats {
+		s.True(format.IsValid(), format)
+	}
+	s.False(ContentFormat("").IsValid())
+	s.False(ContentFormat("rtf").IsValid())
+	s.False(ContentFormat("HTML").IsValid())
+}
+
+func (s *ContentFormatTestSuite) TestSanitizeContent() {
+	content := `<p onclick="steal()">Hi <b>there</b> & welcome</p><script>alert("x")</script>`
+
+	s.Run("Markdown Is Stored As Written", func() {
+		s.Equal(content, SanitizeContent(content, ContentFormatMarkdown))
+	})
+
+	s.Run("Plain Text Is Escaped", func() {
+		escaped := SanitizeContent(content, ContentFormatPlainText)
+		s.Equal(`&lt;p onclick=&#34;steal()&#34;&gt;Hi &lt;b&gt;there&lt;/b&gt; &amp; welcome&lt;/p&gt;&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;`, escaped)
+		s.Equal(escaped, SanitizeContent(escaped, ContentFormatPlainText), "escaping twice changes nothing")
+	})
+
+	s.Run("HTML Is Sanitized", func() {
+		sanitized := SanitizeContent(content, ContentFormatHTML)
+		s.Equal(`<p>Hi <b>there</b> &amp; welcome</p>`, sanitized)
+		s.Equal(sanitized, SanitizeContent(sanitized, ContentFormatHTML), "sanitizing twice changes nothing")
+	})
+
+	s.Run("HTML Links And Images", func() {
+		in := `<a href="javascript:alert(1)" target="_blank">bad</a> <a href="https://example.com" title="t">good</a> ` +
+			`<img src="/uploads/a.png" alt="a" style="x"> <img src="data:image/png;base64,AAAA" alt="b">`
+		s.Equal(`<a>bad</a> <a href="https://example.com" title="t">good</a> <img src="/uploads/a.png" alt="a"> <img alt="b">`,
+			SanitizeContent(in, ContentFormatHTML))
+	})
+
+	s.Run("HTML Drops Unknown Tags But Keeps Their Text", func() {
+		in := `<section><marquee>Still here</marquee><!-- note --><iframe src="https://x"><p>gone</p></iframe></section>`
+		s.Equal(`Still here`, SanitizeContent(in, ContentFormatHTML))
+	})
+}
+
+func (s *ContentFormatTestSuite) TestContentText() {
+	s.Equal("one & two", ContentText("one &amp; two", ContentFormatPlainText))
+	s.Equal(" Title  Some bold text ", ContentText("<h1>Title</h1><p>Some <b>bold</b> text</p>", ContentFormatHTML))
+	s.Equal("# Title", ContentText("# Title", ContentFormatMarkdown))
+}
+
+func (s *ContentFormatTestSuite) TestBlogFollowsItsFormat() {
+	blog, err := NewBlog("Title", "<h2>Getting <em>Started</em></h2><p>Three words here</p>", "author-id", nil)
+	s.Require().NoError(err)
+	s.Equal(ContentFormatMarkdown, blog.ContentFormat, "markdown is the default")
+
+	blog.ContentFormat = ContentFormatHTML
+	blog.SetContent(blog.Content)
+
+	s.Equal(5, blog.WordCount, "tags are not words")
+	s.Equal([]TOCEntry{{Level: 2, Text: "Getting Started", Anchor: "getting-started"}}, blog.TableOfContents())
+
+	blog.ContentFormat = ContentFormatPlainText
+	blog.SetContent("# Not a heading")
+	s.Empty(blog.TableOfContents())
+
+	blog.ContentFormat = ContentFormatMarkdown
+	blog.SetContent("# A heading")
+	s.Equal([]TOCEntry{{Level: 1, Text: "A heading", Anchor: "a-heading"}}, blog.TableOfContents())
+}
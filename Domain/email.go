@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// SentEmail is a rendered email as it would have been handed to the mail server.
+type SentEmail struct {
+	To      string
+	Subject string
+	Body    string
+	SentAt  time.Time
+}
@@ -7,6 +7,7 @@ var (
 	// Domain validation errors
 	ErrUsernameEmpty      = errors.New("username cannot be empty")
 	ErrUsernameTooLong    = errors.New("username cannot exceed 50 characters")
+	ErrUsernameInvalid    = errors.New("username can only contain letters, digits and underscores")
 	ErrPasswordEmpty      = errors.New("password cannot be empty")
 	ErrPasswordTooShort   = errors.New("password must be at least 8 characters")
 	ErrInvalidEmailFormat = errors.New("invalid email format")
@@ -22,11 +23,27 @@ var (
 	ErrUsernameExists       = errors.New("username already exists")
 	ErrOAuthUser            = errors.New("this action is not applicable to an account created with an external provider")
 	ErrCannotChangeOwnRole  = errors.New("admins cannot change their own role")
+	ErrContentRejected      = errors.New("content contains disallowed language")
+	ErrCommentLimitReached  = errors.New("this blog has reached its maximum number of comments")
+	ErrTooManyRequests      = errors.New("too many requests, please slow down")
+	ErrTagExists            = errors.New("a tag with this name already exists")
+	ErrCannotFollowSelf     = errors.New("users cannot follow themselves")
+	ErrQuotaExceeded        = errors.New("the AI service is busy, please try again later")
+	ErrAccountTooNew        = errors.New("this account is too new to post yet")
+	ErrRedirectNotAllowed   = errors.New("redirect URI is not allowed")
+	ErrCannotUnlink         = errors.New("set a password before unlinking the sign-in provider")
+	ErrImageHostNotAllowed  = errors.New("content embeds an image from a host that is not allowed")
+	ErrDeleteWindowExpired  = errors.New("this comment can no longer be deleted by its author")
+
+	// Cache errors. A miss is reported as ErrNotFound; anything else means the cache backend failed.
+	ErrCacheUnavailable = errors.New("cache unavailable")
 
 	// Token errors
-	ErrInvalidID              = errors.New("invalid ID was used")
-	ErrInvalidResetToken      = errors.New("invalid or expired password reset token")
-	ErrCannotDemoteSelf       = errors.New("admin cannot demote themselves")
-	ErrAccountNotActive       = errors.New("this account has not been activated")
-	ErrInvalidActivationToken = errors.New("invalid or expired activation token")
+	ErrInvalidID               = errors.New("invalid ID was used")
+	ErrInvalidResetToken       = errors.New("invalid or expired password reset token")
+	ErrCannotDemoteSelf        = errors.New("admin cannot demote themselves")
+	ErrAccountNotActive        = errors.New("this account has not been activated")
+	ErrInvalidActivationToken  = errors.New("invalid or expired activation token")
+	ErrInvalidEmailChangeToken = errors.New("invalid or expired email change token")
+	ErrInvalidOAuthState       = errors.New("invalid or expired OAuth state")
 )
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Follow records that the follower gets updates from the followee.
+type Follow struct {
+	FollowerID string
+	FolloweeID string
+	CreatedAt  time.Time
+}
+
+// FollowCounts is how many users follow a user, and how many users they follow.
+type FollowCounts struct {
+	Followers int64
+	Following int64
+}
+
+// PublicProfile is what anyone may see of a user.
+type PublicProfile struct {
+	User   *User
+	Counts FollowCounts
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ImageHostMode decides what happens to content that embeds an image from a host off the allowlist.
+type ImageHostMode string
+
+const (
+	ImageHostModeReject ImageHostMode = "reject" // The content is refused with ErrImageHostNotAllowed.
+	ImageHostModeStrip  ImageHostMode = "strip"  // The image is removed; a markdown image keeps its alt text.
+)
+
+func (m ImageHostMode) IsValid() bool {
+	return m == ImageHostModeReject || m == ImageHostModeStrip
+}
+
+var (
+	// markdownImage matches an inline markdown image such as ![alt](https://host/a.png "title"),
+	// capturing the alt text and the URL.
+	markdownImage = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?([^\s)>]+)>?(?:\s+(?:"[^"]*"|'[^']*'))?\s*\)`)
+	// htmlImage matches a whole <img> tag.
+	htmlImage = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	// imageSourceAttr captures the value of a src or srcset attribute, quoted or not.
+	imageSourceAttr = regexp.MustCompile(`(?i)\b(?:src|srcset)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+)
+
+// ImageHostPolicy checks the images embedded in blog content against a list of allowed hosts,
+// so posts can't hotlink or track readers through third-party images. A listed host also
+// allows its subdomains. Relative URLs point at this site and are always allowed.
+// Reference-style markdown images are not checked, since their definitions look like links.
+// A nil policy, or one with an empty list, lets all content through.
+type ImageHostPolicy struct {
+	mode  ImageHostMode
+	hosts []string
+}
+
+func NewImageHostPolicy(hosts []string, mode ImageHostMode) *ImageHostPolicy {
+	p := &ImageHostPolicy{mode: mode}
+	for _, host := range hosts {
+		if host = strings.Trim(strings.ToLower(strings.TrimSpace(host)), "."); host != "" {
+			p.hosts = append(p.hosts, host)
+		}
+	}
+	return p
+}
+
+// Allows reports whether an image may be loaded from rawURL.
+func (p *ImageHostPolicy) Allows(rawURL string) bool {
+	if p == nil || len(p.hosts) == 0 {
+		return true
+	}
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" && u.Host == "" {
+		return true
+	}
+	// Other schemes, such as data: or javascript:, are never allowed.
+	if u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range p.hosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns the content as it may be stored: unchanged when every image is allowed,
+// with the offending images removed in strip mode, or ErrImageHostNotAllowed in reject mode.
+func (p *ImageHostPolicy) Apply(content string) (string, error) {
+	if p == nil || len(p.hosts) == 0 {
+		return content, nil
+	}
+
+	rejected := false
+	content = markdownImage.ReplaceAllStringFunc(content, func(image string) string {
+		m := markdownImage.FindStringSubmatch(image)
+		if p.Allows(m[2]) {
+			return image
+		}
+		rejected = true
+		return m[1]
+	})
+	content = htmlImage.ReplaceAllStringFunc(content, func(tag string) string {
+		if p.allowsTag(tag) {
+			return tag
+		}
+		rejected = true
+		return ""
+	})
+
+	if rejected && p.mode != ImageHostModeStrip {
+		return "", ErrImageHostNotAllowed
+	}
+	return content, nil
+}
+
+// allowsTag checks every URL an <img> tag can load, including each candidate in a srcset.
+func (p *ImageHostPolicy) allowsTag(tag string) bool {
+	for _, m := range imageSourceAttr.FindAllStringSubmatch(tag, -1) {
+		value := m[1] + m[2] + m[3]
+		for _, candidate := range strings.Split(value, ",") {
+			fields := strings.Fields(candidate)
+			if len(fields) > 0 && !p.Allows(fields[0]) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package domain_test
+
+import (
+	"testing"
+
+	. "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ImageHostPolicyTestSuite struct {
+	suite.Suite
+}
+
+func TestImageHostPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(ImageHostPolicyTestSuite))
+}
+
+func (s *ImageHostPolicyTestSuite) TestAllows() {
+	policy := NewImageHostPolicy([]string{" Cloudinary.com ", "", "images.example.org"}, ImageHostModeReject)
+
+	testCases := []struct {
+		name     string
+		url      string
+		expected bool
+	}{
+		{name: "Listed Host", url: "https://cloudinary.com/a.png", expected: true},
+		{name: "Subdomain Of Listed Host", url: "https://res.cloudinary.com/demo/image/upload/a.jpg", expected: true},
+		{name: "Host Is Case Insensitive", url: "HTTPS://RES.CLOUDINARY.COM/a.jpg", expected: true},
+		{name: "Relative URL", url: "/uploads/a.png", expected: true},
+		{name: "Unlisted Host", url: "https://tracker.example.com/pixel.gif", expected: false},
+		{name: "Lookalike Host", url: "https://evilcloudinary.com/a.png", expected: false},
+		{name: "Listed Host As Subdomain Of Another", url: "https://cloudinary.com.evil.net/a.png", expected: false},
+		{name: "Parent Of Listed Host", url: "https://example.org/a.png", expected: false},
+		{name: "Protocol Relative", url: "//tracker.example.com/a.png", expected: false},
+		{name: "Data URI", url: "data:image/png;base64,AAAA", expected: false},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.expected, policy.Allows(tc.url))
+		})
+	}
+}
+
+func (s *ImageHostPolicyTestSuite) TestApply() {
+	allowed := "Intro ![chart](https://res.cloudinary.com/demo/chart.png \"Chart\") and " +
+		`<img src="https://res.cloudinary.com/demo/b.png" alt="b">` +
+		" plus a [link](https://anywhere.example.com) and a local ![logo](/static/logo.png)."
+	external := "Look ![pixel](https://tracker.example.com/p.gif) here " +
+		`<IMG alt="x" SRC='https://tracker.example.com/q.gif'> done`
+
+	s.Run("Allowed Images Pass Through", func() {
+		for _, mode := range []ImageHostMode{ImageHostModeReject, ImageHostModeStrip} {
+			out, err := NewImageHostPolicy([]string{"cloudinary.com"}, mode).Apply(allowed)
+			s.Require().NoError(err)
+			s.Equal(allowed, out)
+		}
+	})
+
+	s.Run("Reject Mode", func() {
+		_, err := NewImageHostPolicy([]string{"cloudinary.com"}, ImageHostModeReject).Apply(external)
+		s.ErrorIs(err, ErrImageHostNotAllowed)
+	})
+
+	s.Run("Strip Mode Keeps Alt Text", func() {
+		out, err := NewImageHostPolicy([]string{"cloudinary.com"}, ImageHostModeStrip).Apply(external)
+		s.Require().NoError(err)
+		s.Equal("Look pixel here  done", out)
+	})
+
+	s.Run("Srcset Is Checked", func() {
+		tag := `<img src="https://res.cloudinary.com/a.png" srcset="https://res.cloudinary.com/a2.png 2x, https://tracker.example.com/a3.png 3x">`
+		_, err := NewImageHostPolicy([]string{"cloudinary.com"}, ImageHostModeReject).Apply(tag)
+		s.ErrorIs(err, ErrImageHostNotAllowed)
+	})
+
+	s.Run("Empty Allowlist Allows Everything", func() {
+		out, err := NewImageHostPolicy(nil, ImageHostModeReject).Apply(external)
+		s.Require().NoError(err)
+		s.Equal(external, out)
+
+		var policy *ImageHostPolicy
+		out, err = policy.Apply(external)
+		s.Require().NoError(err)
+		s.Equal(external, out)
+	})
+}
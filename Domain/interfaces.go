@@ -9,26 +9,114 @@ import (
 )
 
 type IBlogUsecase interface {
-	Create(ctx context.Context, title, content string, authorID string, tags []string) (*Blog, error)
+	// Create stores a new blog. An empty format means markdown.
+	Create(ctx context.Context, title, content string, authorID string, tags []string, scheduledFor *time.Time, format ContentFormat) (*Blog, error)
 	SearchAndFilter(ctx context.Context, options BlogSearchFilterOptions) ([]*Blog, int64, error)
-	GetByID(ctx context.Context, id string) (*Blog, error)
+	// GetByID returns a blog and counts a view. A draft is only found by its author or an admin.
+	GetByID(ctx context.Context, id, viewerID string, viewerRole Role) (*Blog, error)
+	GetViewerAction(ctx context.Context, blogID, userID string) (ActionType, error)
+	ListByAuthor(ctx context.Context, authorID string, page, limit int64) ([]*Blog, int64, error)
+	// GetFollowingFeed returns the newest blogs by authors the user follows.
+	GetFollowingFeed(ctx context.Context, userID string, page, limit int64) ([]*Blog, int64, error)
 	Update(ctx context.Context, blogID, userID string, userRole Role, updates map[string]any) (*Blog, error)
 	Delete(ctx context.Context, blogID, userID string, userRole Role) error
-	InteractWithBlog(ctx context.Context, blogID, userID string, action ActionType) error
+	InteractWithBlog(ctx context.Context, blogID, userID string, action ActionType) (*InteractionResult, error)
+	ImportBlogs(ctx context.Context, actorID string, items []BlogImportItem) ([]BlogImportResult, error)
+	ListRevisions(ctx context.Context, blogID, userID string, userRole Role) ([]*BlogRevision, error)
+	GetRevision(ctx context.Context, blogID, revisionID, userID string, userRole Role) (*BlogRevision, error)
+	RestoreRevision(ctx context.Context, blogID, revisionID, userID string, userRole Role) (*Blog, error)
+	PublishDueBlogs(ctx context.Context) (int, error)
+	SetPinned(ctx context.Context, blogID, userID string, pinned bool) (*Blog, error)
+	VerifyCommentCount(ctx context.Context, blogID string) (*CommentCountCheck, error)
+	MarkRead(ctx context.Context, blogID, userID string) error
+	ListRead(ctx context.Context, userID string, page, limit int64) ([]*BlogRead, int64, error)
+	// ListInteractions returns the user's likes and dislikes, newest first, with the blog each was on.
+	ListInteractions(ctx context.Context, userID string, page, limit int64) ([]*InteractionHistoryEntry, int64, error)
+	// ListBlogInteractions returns the likes and dislikes on a blog, newest first, with the user who
+	// cast each. Only the blog's author or an admin may list them.
+	ListBlogInteractions(ctx context.Context, blogID, userID string, userRole Role, page, limit int64) ([]*BlogInteractionEntry, int64, error)
+	// ReadFlags reports which of the given blogs the user has read.
+	ReadFlags(ctx context.Context, userID string, blogIDs []string) (map[string]bool, error)
+	// MergeTags folds the tag from into the tag to on every blog and returns how many blogs changed.
+	MergeTags(ctx context.Context, actorID, from, to string) (int, error)
+	// RenameTag renames a tag to a name no blog uses yet and returns how many blogs changed.
+	RenameTag(ctx context.Context, actorID, from, to string) (int, error)
+	// ExportBlog returns a blog with its full comment thread. Drafts are only exported for their author or an admin.
+	ExportBlog(ctx context.Context, blogID, userID string, userRole Role) (*BlogExport, error)
+	// GetByIDs returns the blogs among ids in the order requested, skipping missing ones and drafts the viewer can't see.
+	GetByIDs(ctx context.Context, ids []string, viewerID string, viewerRole Role) ([]*Blog, error)
+	// ListTags lists the tags in use on published blogs. An empty sort means TagSortCount.
+	ListTags(ctx context.Context, page, limit int64, sort TagSort) (*TagPage, error)
+	// GetRandom picks one published blog at random, from those carrying tag unless it is empty.
+	GetRandom(ctx context.Context, tag string) (*Blog, error)
+	// SuggestTitles returns published blogs whose title contains query, for a search box.
+	// A limit of zero or less, or one above the maximum, gets the maximum.
+	SuggestTitles(ctx context.Context, query string, limit int) ([]*BlogTitleSuggestion, error)
+	// CloneBlog copies a blog into a new draft owned by userID. Only the author or an admin may clone it.
+	CloneBlog(ctx context.Context, blogID, userID string, userRole Role) (*Blog, error)
+	// PublishDraft publishes a draft now, or queues it for moderation when pre-moderation is on.
+	// Only the author or an admin may do it, and a blog that isn't a draft gets ErrConflict.
+	PublishDraft(ctx context.Context, blogID, userID string, userRole Role) (*Blog, error)
+	// GetBlogDetail loads a blog with its first comments, related blogs and the viewer's reaction.
+	// Drafts are only shown to their author or an admin. viewerID is empty for anonymous viewers.
+	GetBlogDetail(ctx context.Context, blogID, viewerID string, viewerRole Role) (*BlogDetail, error)
 }
 
 type IBlogRepository interface {
 	Create(ctx context.Context, blog *Blog) error
+	CreateMany(ctx context.Context, blogs []*Blog) error
 	SearchAndFilter(ctx context.Context, options BlogSearchFilterOptions) ([]*Blog, int64, error)
 	GetByID(ctx context.Context, id string) (*Blog, error)
+	// GetByIDs returns the blogs that exist among the given IDs, in no particular order.
+	GetByIDs(ctx context.Context, ids []string) ([]*Blog, error)
+	// GetRandom samples one published blog, limited to those carrying *tag when tag is not nil.
+	// It returns ErrNotFound when no blog qualifies.
+	GetRandom(ctx context.Context, tag *string) (*Blog, error)
+	// SuggestTitles returns up to limit published blogs whose title contains query, ignoring case.
+	// Titles starting with query come first; each group is in title order.
+	SuggestTitles(ctx context.Context, query string, limit int64) ([]*BlogTitleSuggestion, error)
 	Update(ctx context.Context, blog *Blog) error
 	Delete(ctx context.Context, id string) error
 
 	IncrementLikes(ctx context.Context, blogID string, value int) error
 	IncrementDislikes(ctx context.Context, blogID string, value int) error
 	IncrementViews(ctx context.Context, blogID string) error
+	// AddViews adds count views in a single write, for views that were buffered before saving.
+	AddViews(ctx context.Context, blogID string, count int64) error
+	// IncrementCommentCount also stamps the blog's LastActivityAt with the current time.
 	IncrementCommentCount(ctx context.Context, blogId string, value int) error
 	UpdateInteractionCounts(ctx context.Context, blogID string, likesInc, dislikesInc int) error
+
+	FindDueScheduled(ctx context.Context, now time.Time, limit int64) ([]*Blog, error)
+	MarkPublished(ctx context.Context, blogID string, publishedAt time.Time) error
+	// MarkPending moves a draft into the moderation queue. It returns ErrNotFound if the blog is no longer a draft.
+	MarkPending(ctx context.Context, blogID string) error
+	// ApprovePending publishes a pending blog. It returns ErrNotFound if the blog is not pending.
+	ApprovePending(ctx context.Context, blogID string, publishedAt time.Time) error
+	// FetchPending lists blogs awaiting moderation, oldest first.
+	FetchPending(ctx context.Context, page, limit int64) ([]*Blog, int64, error)
+	// SetPinned pins or unpins an author's blog. Pinning unpins the author's other blogs.
+	SetPinned(ctx context.Context, blogID, authorID string, pinned bool) error
+	// GetCommentsCount reads the stored comment counter straight from the database.
+	GetCommentsCount(ctx context.Context, blogID string) (int64, error)
+	// GetInteractionCounts reads the stored like and dislike counters straight from the database.
+	GetInteractionCounts(ctx context.Context, blogID string) (likes, dislikes int64, err error)
+	// HasTag reports whether any blog carries the tag.
+	HasTag(ctx context.Context, tag string) (bool, error)
+	// ReplaceTag swaps the tag from for the tag to on every blog, keeping a single copy on blogs
+	// that already had both. It returns the IDs of the blogs it changed.
+	ReplaceTag(ctx context.Context, from, to string) ([]string, error)
+	// ListTags counts the tags on published blogs and returns one page of them with the number of distinct tags.
+	ListTags(ctx context.Context, page, limit int64, sort TagSort) ([]*TagCount, int64, error)
+	// ExistsByContentHash reports whether the author created a blog with this ContentHash since the given time.
+	ExistsByContentHash(ctx context.Context, authorID, hash string, since time.Time) (bool, error)
+}
+
+type IBlogRevisionRepository interface {
+	Create(ctx context.Context, revision *BlogRevision) error
+	ListByBlogID(ctx context.Context, blogID string) ([]*BlogRevision, error)
+	GetByID(ctx context.Context, blogID, revisionID string) (*BlogRevision, error)
+	PruneOldest(ctx context.Context, blogID string, keep int) error
 }
 
 type IInteractionRepository interface {
@@ -37,15 +125,90 @@ type IInteractionRepository interface {
 	Create(ctx context.Context, interaction *BlogInteraction) error
 	Update(ctx context.Context, interaction *BlogInteraction) error
 	Delete(ctx context.Context, interactionID string) error
+	// ListByUser returns the user's interactions, newest first, and the total number of them.
+	ListByUser(ctx context.Context, userID string, page, limit int64) ([]*BlogInteraction, int64, error)
+	// ListByBlog returns the interactions on a blog, newest first, and the total number of them.
+	ListByBlog(ctx context.Context, blogID string, page, limit int64) ([]*BlogInteraction, int64, error)
+}
+
+type IBlogReadRepository interface {
+	// MarkRead is idempotent: marking a blog read again keeps the original ReadAt.
+	MarkRead(ctx context.Context, userID, blogID string) error
+	IsRead(ctx context.Context, userID, blogID string) (bool, error)
+	// ListRead returns the user's reads, most recent first, and the total number of reads.
+	ListRead(ctx context.Context, userID string, page, limit int64) ([]*BlogRead, int64, error)
+	// FindRead returns the subset of blogIDs the user has read, in a single lookup.
+	FindRead(ctx context.Context, userID string, blogIDs []string) (map[string]bool, error)
+}
+
+type IFollowRepository interface {
+	// Follow is idempotent: following a user again keeps the original CreatedAt.
+	// It reports whether the follow is new, so repeats don't notify or count twice.
+	Follow(ctx context.Context, followerID, followeeID string) (bool, error)
+	// Unfollow is idempotent: unfollowing a user who isn't followed is not an error.
+	// It reports whether a follow was removed.
+	Unfollow(ctx context.Context, followerID, followeeID string) (bool, error)
+	IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error)
+	// GetCounts returns the user's follower and following counts, kept up to date by Follow and Unfollow.
+	GetCounts(ctx context.Context, userID string) (*FollowCounts, error)
+	// ListFollowers returns who follows the user, most recent first, and the total number of followers.
+	ListFollowers(ctx context.Context, userID string, page, limit int64) ([]*Follow, int64, error)
+	// ListFollowing returns who the user follows, most recent first, and the total number followed.
+	ListFollowing(ctx context.Context, userID string, page, limit int64) ([]*Follow, int64, error)
+	// ListFolloweeIDs returns the IDs of every user the follower follows, in no particular order.
+	ListFolloweeIDs(ctx context.Context, followerID string) ([]string, error)
+}
+
+type IFollowUsecase interface {
+	Follow(ctx context.Context, followerID, followeeID string) error
+	Unfollow(ctx context.Context, followerID, followeeID string) error
+	ListFollowers(ctx context.Context, userID string, page, limit int64) ([]*Follow, int64, error)
+	ListFollowing(ctx context.Context, userID string, page, limit int64) ([]*Follow, int64, error)
+}
+
+type IAuditRepository interface {
+	Create(ctx context.Context, entry *AuditEntry) error
+	// Search returns matching entries, newest first, and the total number of matches.
+	Search(ctx context.Context, options AuditFilterOptions) ([]*AuditEntry, int64, error)
+}
+
+type IAuditUsecase interface {
+	Search(ctx context.Context, options AuditFilterOptions) ([]*AuditEntry, int64, error)
+}
+
+// IStatsRepository counts documents across collections for the admin dashboard.
+type IStatsRepository interface {
+	CountUsers(ctx context.Context) (int64, error)
+	// CountActiveUsers counts users who have activated their account.
+	CountActiveUsers(ctx context.Context) (int64, error)
+	CountBlogs(ctx context.Context) (int64, error)
+	// CountComments counts comments and replies, excluding deleted ones.
+	CountComments(ctx context.Context) (int64, error)
+	CountInteractions(ctx context.Context) (int64, error)
+}
+
+type ISummaryUsecase interface {
+	GetSummary(ctx context.Context) (*DashboardSummary, error)
 }
 
 type IAIService interface {
 	GenerateCompletion(ctx context.Context, prompt string) (string, error)
+	// StreamCompletion passes the response to fn piece by piece as the model produces it.
+	// Returning an error from fn stops the stream and is returned unchanged.
+	StreamCompletion(ctx context.Context, prompt string, fn func(chunk string) error) error
 }
 
 type IAIUsecase interface {
 	GenerateBlogIdeas(ctx context.Context, keywords []string) ([]string, error)
 	RefineBlogPost(ctx context.Context, content string) (string, error)
+	StreamRefineBlogPost(ctx context.Context, content string, fn func(chunk string) error) error
+	// ScoreContent rates how toxic or spammy text is, from 0 for harmless to 1 for certainly abusive.
+	ScoreContent(ctx context.Context, text string) (float64, error)
+}
+
+// IContentScorer is the part of the AI usecase the blog and comment usecases screen new content with.
+type IContentScorer interface {
+	ScoreContent(ctx context.Context, text string) (float64, error)
 }
 
 type ICommentRepository interface {
@@ -54,21 +217,55 @@ type ICommentRepository interface {
 	Update(ctx context.Context, comment *Comment) error
 
 	Anonymize(ctx context.Context, commentID string) error // Delete a reply
-	FetchByBlogID(ctx context.Context, blogID string, page, limit int64) ([]*Comment, int64, error)
+	// DeleteIfNoReplies removes a comment outright. It returns ErrNotFound if the comment has
+	// replies, checked in the same write so a reply arriving meanwhile is never orphaned.
+	DeleteIfNoReplies(ctx context.Context, commentID string) error
+	FetchByBlogID(ctx context.Context, blogID string, page, limit int64, sort CommentSort) ([]*Comment, int64, error)
 	FetchReplies(ctx context.Context, parentID string, page, limit int64) ([]*Comment, int64, error)
 	IncrementReplyCount(ctx context.Context, parentID string, value int) error
+	// CountByBlogID counts a blog's comments and replies, excluding deleted ones.
+	CountByBlogID(ctx context.Context, blogID string) (int64, error)
+	// FetchAllByBlogID returns every comment and reply of a blog in one batch, oldest first.
+	FetchAllByBlogID(ctx context.Context, blogID string) ([]*Comment, error)
+	// SearchInBlog full-text searches a blog's comments and replies, best match first. Deleted comments never match.
+	SearchInBlog(ctx context.Context, blogID, query string, page, limit int64) ([]*Comment, int64, error)
+	// FetchPending lists comments awaiting moderation, oldest first. The other fetches leave them out.
+	FetchPending(ctx context.Context, page, limit int64) ([]*Comment, int64, error)
+	// Approve publishes a pending comment. It returns ErrNotFound if the comment is not pending.
+	Approve(ctx context.Context, commentID string) error
+	// DeletePending removes a pending comment. It returns ErrNotFound if the comment is not pending.
+	DeletePending(ctx context.Context, commentID string) error
 }
 
 type ICommentUsecase interface {
-	CreateComment(ctx context.Context, userID, blogID, content string, parentID *string) (*Comment, error)
+	// CreateComment adds a comment to a published blog. Its author and admins may also comment on it before then.
+	CreateComment(ctx context.Context, userID, blogID, content string, parentID *string, userRole Role) (*Comment, error)
 	UpdateComment(ctx context.Context, userID, commentID, content string) (*Comment, error)
-	DeleteComment(ctx context.Context, userID, commentID string) error
-	GetCommentsForBlog(ctx context.Context, blogID string, page, limit int64) ([]*Comment, int64, error)
+	// DeleteComment removes a comment for its author, within the delete window if one is set,
+	// or for an admin at any time.
+	DeleteComment(ctx context.Context, userID, commentID string, userRole Role) error
+	// GetCommentsForBlog lists a blog's top-level comments. An empty sort means CommentSortOldest.
+	GetCommentsForBlog(ctx context.Context, blogID string, page, limit int64, sort CommentSort) ([]*Comment, int64, error)
 	GetRepliesForComment(ctx context.Context, parentID string, page, limit int64) ([]*Comment, int64, error)
+	// GetThreadPath returns the ancestors of a comment, root first. It is empty for top-level comments.
+	GetThreadPath(ctx context.Context, commentID string) ([]*Comment, error)
+	// SearchCommentsInBlog finds a blog's comments and replies whose content matches query, best match first.
+	SearchCommentsInBlog(ctx context.Context, blogID, query string, page, limit int64) ([]*Comment, int64, error)
+}
+
+// IOAuthUsecase runs the Google sign-in flow. An empty redirectURI means the configured default;
+// any other value must be on the allowlist, and the callback must repeat the one the flow started with.
+// The callback must also return the single-use state issued with the consent URL.
+// IModerationUsecase works the queue of blogs and comments held back by pre-moderation.
+type IModerationUsecase interface {
+	GetQueue(ctx context.Context, kind ContentKind, page, limit int64) ([]*ModerationItem, int64, error)
+	ApproveContent(ctx context.Context, actorID string, kind ContentKind, id string) error
+	RejectContent(ctx context.Context, actorID string, kind ContentKind, id string) error
 }
 
 type IOAuthUsecase interface {
-	HandleGoogleCallback(ctx context.Context, code string) (accessToken string, refreshToken string, err error)
+	GoogleAuthURL(ctx context.Context, redirectURI string) (string, error)
+	HandleGoogleCallback(ctx context.Context, code, state, redirectURI string) (accessToken string, refreshToken string, err error)
 }
 
 type GoogleUserInfo struct {
@@ -79,17 +276,35 @@ type GoogleUserInfo struct {
 }
 
 type IGoogleOAuthService interface {
-	ExchangeCodeForToken(ctx context.Context, code string) (*oauth2.Token, error)
+	// AuthCodeURL returns Google's consent page URL. An empty redirectURI uses the configured one.
+	// codeChallenge is the PKCE S256 challenge; the exchange must present its codeVerifier.
+	AuthCodeURL(redirectURI, state, codeChallenge string) string
+	ExchangeCodeForToken(ctx context.Context, code, redirectURI, codeVerifier string) (*oauth2.Token, error)
 	GetUserInfo(ctx context.Context, token *oauth2.Token) (*GoogleUserInfo, error)
 }
 
+// IEmailOutbox exposes the emails a non-sending email service captured, most recent first.
+type IEmailOutbox interface {
+	Sent() []SentEmail
+}
+
+// IMaintenanceMode is the global read-only switch used during migrations.
+type IMaintenanceMode interface {
+	Enabled(ctx context.Context) (bool, error)
+	SetEnabled(ctx context.Context, enabled bool) error
+}
+
 type ImageUploaderService interface {
 	UploadProfilePicture(file multipart.File, fileHeader *multipart.FileHeader) (string, error)
 }
 
 type ICacheService interface {
 	Get(ctx context.Context, key string) ([]byte, error)
+	// GetAndDelete reads a key and removes it in one step, so a value can only be used once.
+	GetAndDelete(ctx context.Context, key string) ([]byte, error)
 	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
+	// SetIfAbsent sets the key only when it doesn't exist yet, and reports whether it did.
+	SetIfAbsent(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error)
 	Delete(ctx context.Context, key string) error
 	AddToSet(ctx context.Context, key string, members ...any) error
 	GetSetMembers(ctx context.Context, key string) ([]string, error)
@@ -0,0 +1,38 @@
+package domain
+
+// ContentKind names the kinds of content that can wait in the moderation queue.
+type ContentKind string
+
+const (
+	ContentKindBlog    ContentKind = "blog"
+	ContentKindComment ContentKind = "comment"
+)
+
+// IsValid checks if the kind is one of the predefined values.
+func (k ContentKind) IsValid() bool {
+	switch k {
+	case ContentKindBlog, ContentKindComment:
+		return true
+	}
+	return false
+}
+
+// ModerationItem is one entry of the moderation queue. Exactly one of Blog and Comment is set,
+// matching Kind.
+type ModerationItem struct {
+	Kind    ContentKind
+	Blog    *Blog
+	Comment *Comment
+}
+
+// ToxicityAction decides what happens to content the AI scores above the toxicity threshold.
+type ToxicityAction string
+
+const (
+	ToxicityActionReject ToxicityAction = "reject" // The content is refused with ErrContentRejected.
+	ToxicityActionQueue  ToxicityAction = "queue"  // The content waits in the moderation queue.
+)
+
+func (a ToxicityAction) IsValid() bool {
+	return a == ToxicityActionReject || a == ToxicityActionQueue
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ProfanityMode decides what happens to text that contains a listed word.
+type ProfanityMode string
+
+const (
+	ProfanityModeReject ProfanityMode = "reject" // The text is refused with ErrContentRejected.
+	ProfanityModeMask   ProfanityMode = "mask"   // Listed words are replaced with asterisks.
+)
+
+func (m ProfanityMode) IsValid() bool {
+	return m == ProfanityModeReject || m == ProfanityModeMask
+}
+
+// wordPattern splits text into words, so a listed word never matches inside a longer one.
+var wordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// ProfanityFilter checks text against a word list, matching whole words case-insensitively.
+// A nil filter, or one with an empty list, lets all text through.
+type ProfanityFilter struct {
+	mode  ProfanityMode
+	words map[string]bool
+}
+
+func NewProfanityFilter(words []string, mode ProfanityMode) *ProfanityFilter {
+	f := &ProfanityFilter{mode: mode, words: make(map[string]bool)}
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			f.words[word] = true
+		}
+	}
+	return f
+}
+
+// Contains reports whether the text has any listed word.
+func (f *ProfanityFilter) Contains(text string) bool {
+	return len(f.matches(text)) > 0
+}
+
+// Apply returns the text as it may be stored: unchanged when clean, masked in mask mode,
+// or ErrContentRejected in reject mode.
+func (f *ProfanityFilter) Apply(text string) (string, error) {
+	matches := f.matches(text)
+	if len(matches) == 0 {
+		return text, nil
+	}
+	if f.mode != ProfanityModeMask {
+		return "", ErrContentRejected
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(text[last:m[0]])
+		b.WriteString(strings.Repeat("*", utf8.RuneCountInString(text[m[0]:m[1]])))
+		last = m[1]
+	}
+	b.WriteString(text[last:])
+	return b.String(), nil
+}
+
+// matches returns the byte ranges of the listed words in text.
+func (f *ProfanityFilter) matches(text string) [][]int {
+	if f == nil || len(f.words) == 0 {
+		return nil
+	}
+	var matches [][]int
+	for _, m := range wordPattern.FindAllStringIndex(text, -1) {
+		if f.words[strings.ToLower(text[m[0]:m[1]])] {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,80 @@
+package domain_test
+
+import (
+	"testing"
+
+	. "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ProfanityFilterTestSuite struct {
+	suite.Suite
+}
+
+func TestProfanityFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(ProfanityFilterTestSuite))
+}
+
+func (s *ProfanityFilterTestSuite) TestContains() {
+	filter := NewProfanityFilter([]string{"darn", " Heck ", "", "схема"}, ProfanityModeReject)
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected bool
+	}{
+		{name: "Clean Text", text: "A perfectly polite sentence", expected: false},
+		{name: "Listed Word", text: "Well, darn it", expected: true},
+		{name: "Case Insensitive", text: "DARN", expected: true},
+		{name: "Entries Are Trimmed", text: "what the heck", expected: true},
+		{name: "Not Inside Longer Words", text: "darning socks", expected: false},
+		{name: "Non-ASCII Words", text: "это Схема!", expected: true},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.expected, filter.Contains(tc.text))
+		})
+	}
+
+	s.Run("Nil And Empty Filters Allow Everything", func() {
+		var nilFilter *ProfanityFilter
+		s.False(nilFilter.Contains("darn"))
+		s.False(NewProfanityFilter(nil, ProfanityModeReject).Contains("darn"))
+
+		text, err := nilFilter.Apply("darn")
+		s.NoError(err)
+		s.Equal("darn", text)
+	})
+}
+
+func (s *ProfanityFilterTestSuite) TestApply_RejectMode() {
+	filter := NewProfanityFilter([]string{"darn"}, ProfanityModeReject)
+
+	text, err := filter.Apply("Oh darn.")
+	s.ErrorIs(err, ErrContentRejected)
+	s.Empty(text)
+
+	text, err = filter.Apply("All good here")
+	s.NoError(err)
+	s.Equal("All good here", text)
+}
+
+func (s *ProfanityFilterTestSuite) TestApply_MaskMode() {
+	filter := NewProfanityFilter([]string{"darn", "heck", "схема"}, ProfanityModeMask)
+
+	text, err := filter.Apply("Darn, what the heck? Darning is fine. схема")
+	s.NoError(err)
+	s.Equal("****, what the ****? Darning is fine. *****", text, "Each masked word keeps its length in characters")
+
+	text, err = filter.Apply("All good here")
+	s.NoError(err)
+	s.Equal("All good here", text)
+}
+
+func (s *ProfanityFilterTestSuite) TestProfanityMode_IsValid() {
+	s.True(ProfanityModeReject.IsValid())
+	s.True(ProfanityModeMask.IsValid())
+	s.False(ProfanityMode("censor").IsValid())
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// letterRun matches the words a similarity fingerprint is built from. Digits and punctuation
+// are left out, so a spam loop can't slip past by numbering or decorating its posts.
+var letterRun = regexp.MustCompile(`\p{L}+`)
+
+// SimilarityFingerprint hashes text so that near-duplicates share a fingerprint: case, digits,
+// punctuation and spacing are ignored. With maxWords above zero only the first maxWords words
+// count, which also catches posts that differ only in a varying tail. Text without any words
+// has an empty fingerprint.
+func SimilarityFingerprint(text string, maxWords int) string {
+	words := letterRun.FindAllString(strings.ToLower(text), -1)
+	if len(words) == 0 {
+		return ""
+	}
+	if maxWords > 0 && len(words) > maxWords {
+		words = words[:maxWords]
+	}
+	sum := sha256.Sum256([]byte(strings.Join(words, " ")))
+	return hex.EncodeToString(sum[:])
+}
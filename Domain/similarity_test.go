@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"testing"
+
+	. "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimilarityFingerprint(t *testing.T) {
+	base := SimilarityFingerprint("Buy cheap watches now", 0)
+
+	testCases := []struct {
+		name     string
+		text     string
+		maxWords int
+		same     bool
+	}{
+		{name: "Case And Spacing", text: "  BUY cheap\n\twatches   NOW ", same: true},
+		{name: "Punctuation And Digits", text: "Buy cheap watches now!!! #42", same: true},
+		{name: "Different Words", text: "Buy cheap clocks now", same: false},
+		{name: "Extra Tail Counts By Default", text: "Buy cheap watches now, visit my site", same: false},
+		{name: "Extra Tail Ignored Past The Limit", text: "Buy cheap watches now, visit my site", maxWords: 4, same: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.same, SimilarityFingerprint(tc.text, tc.maxWords) == base)
+		})
+	}
+
+	t.Run("No Words", func(t *testing.T) {
+		assert.Empty(t, SimilarityFingerprint("!!! 123 ...", 0))
+	})
+}
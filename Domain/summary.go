@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// DashboardSummary holds the headline numbers for the admin dashboard.
+type DashboardSummary struct {
+	TotalUsers        int64
+	ActiveUsers       int64
+	TotalBlogs        int64
+	TotalComments     int64
+	TotalInteractions int64
+	GeneratedAt       time.Time
+}
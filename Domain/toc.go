@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TOCEntry is one heading of a blog's table of contents.
+type TOCEntry struct {
+	// Level is the heading depth, 1 for "#" through 6 for "######".
+	Level  int
+	Text   string
+	Anchor string
+}
+
+var (
+	// atxHeading matches a markdown heading line such as "## Setup", with up to three spaces of
+	// indentation and an optional closing run of hashes.
+	atxHeading = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+	// codeFence matches the line that opens or closes a fenced code block.
+	codeFence = regexp.MustCompile("^ {0,3}(```|~~~)")
+)
+
+// ExtractTableOfContents lists the markdown headings in content, in order. Headings inside
+// fenced code blocks are skipped. Anchors follow GitHub's style, and repeated headings get
+// "-1", "-2" and so on appended so every anchor is unique.
+func ExtractTableOfContents(content string) []TOCEntry {
+	var entries []TOCEntry
+	seen := make(map[string]int)
+	fence := ""
+
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if m := codeFence.FindStringSubmatch(line); m != nil {
+			switch fence {
+			case "":
+				fence = m[1]
+			case m[1]:
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		m := atxHeading.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		text := strings.TrimSpace(m[2])
+		if text == "" {
+			continue
+		}
+		entries = append(entries, TOCEntry{
+			Level:  len(m[1]),
+			Text:   text,
+			Anchor: uniqueAnchor(slugify(text), seen),
+		})
+	}
+	return entries
+}
+
+// slugify lowercases the heading, drops punctuation and joins the words with hyphens.
+func slugify(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// uniqueAnchor returns slug the first time it is seen and numbered variants after that.
+func uniqueAnchor(slug string, seen map[string]int) string {
+	anchor := slug
+	for {
+		if _, taken := seen[anchor]; !taken {
+			break
+		}
+		seen[slug]++
+		anchor = slug + "-" + strconv.Itoa(seen[slug])
+	}
+	seen[anchor] = 0
+	return anchor
+}
@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"testing"
+
+	. "A2SV_Starter_Project_Blog/Domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractTableOfContents(t *testing.T) {
+	t.Run("Nested Levels", func(t *testing.T) {
+		content := "# Getting Started\n" +
+			"Intro text.\n" +
+			"## Install Go 1.22\n" +
+			"### On macOS ###\n" +
+			"### On Linux\n" +
+			"## Usage: the `run` command!\n"
+
+		toc := ExtractTableOfContents(content)
+
+		assert.Equal(t, []TOCEntry{
+			{Level: 1, Text: "Getting Started", Anchor: "getting-started"},
+			{Level: 2, Text: "Install Go 1.22", Anchor: "install-go-122"},
+			{Level: 3, Text: "On macOS", Anchor: "on-macos"},
+			{Level: 3, Text: "On Linux", Anchor: "on-linux"},
+			{Level: 2, Text: "Usage: the `run` command!", Anchor: "usage-the-run-command"},
+		}, toc)
+	})
+
+	t.Run("Duplicate Headings Get Unique Anchors", func(t *testing.T) {
+		content := "## Example\n## Example-1\n## Example\n## Example\n"
+
+		toc := ExtractTableOfContents(content)
+
+		anchors := make([]string, len(toc))
+		for i, entry := range toc {
+			anchors[i] = entry.Anchor
+		}
+		assert.Equal(t, []string{"example", "example-1", "example-2", "example-3"}, anchors)
+	})
+
+	t.Run("Skips Code Blocks And Non-Headings", func(t *testing.T) {
+		content := "```bash\n# not a heading\n```\n" +
+			"~~~\n## nor this\n~~~\n" +
+			"#hashtag\n" +
+			"    # indented code\n" +
+			"#######  too deep\n" +
+			"\\# escaped\n" +
+			"## Real Heading\r\n"
+
+		toc := ExtractTableOfContents(content)
+
+		assert.Equal(t, []TOCEntry{{Level: 2, Text: "Real Heading", Anchor: "real-heading"}}, toc)
+	})
+
+	t.Run("No Headings", func(t *testing.T) {
+		assert.Empty(t, ExtractTableOfContents("Just a paragraph."))
+	})
+}
@@ -12,6 +12,7 @@ const (
 	TokenTypePasswordReset TokenType = "password_reset"
 	TokenTypeAccessToken   TokenType = "access"
 	TokenTypeActivation    TokenType = "activation"
+	TokenTypeEmailChange   TokenType = "email_change"
 )
 
 // Token represents a temporary token stored for a user.
@@ -25,4 +26,14 @@ type Token struct {
 
 func (t *Token) IsExpired() bool {
 	return time.Now().After(t.ExpiresAt)
-}
\ No newline at end of file
+}
+// TokenIntrospection describes an access token as the server sees it.
+// The claims are only filled in when the token is active.
+type TokenIntrospection struct {
+	Active    bool
+	TokenID   string
+	UserID    string
+	Role      Role
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
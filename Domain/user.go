@@ -18,12 +18,16 @@ const (
 
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 
+// usernameRegex matches the names comment @mentions can reach.
+var usernameRegex = regexp.MustCompile(`^\w+$`)
+
 // User represents the bare minimum for a user in the application.
 type User struct {
 	ID             string
 	Username       string
 	Password       *string
 	Email          string
+	PendingEmail   string // Requested new address; Email stays in use until it is confirmed.
 	IsActive       bool
 	Role           Role
 	Bio            string
@@ -36,6 +40,12 @@ type User struct {
 	UpdatedAt time.Time
 }
 
+// UsernameSuggestion is a user offered while someone types an @mention.
+type UsernameSuggestion struct {
+	ID       string
+	Username string
+}
+
 type UserSearchFilterOptions struct {
 	Username *string // Pointer for optional search
 	Email    *string // Pointer for optional search
@@ -68,6 +78,19 @@ func (r Role) IsValid() bool {
 	return false
 }
 
+// CheckAccountAge returns ErrAccountTooNew if the account was created less than minAge before now.
+func (u *User) CheckAccountAge(minAge time.Duration, now time.Time) error {
+	if now.Sub(u.CreatedAt) < minAge {
+		return ErrAccountTooNew
+	}
+	return nil
+}
+
+// HasPassword reports whether the user can sign in with a local password.
+func (u *User) HasPassword() bool {
+	return u.Password != nil && *u.Password != ""
+}
+
 // Validate performs intrinsic validation on the User struct fields.
 func (u *User) Validate() error {
 	if u.Username == "" {
@@ -87,11 +110,34 @@ func (u *User) Validate() error {
 		}
 	}
 
-	if _, err := mail.ParseAddress(u.Email); err != nil || !emailRegex.MatchString(u.Email) {
-		return ErrInvalidEmailFormat
+	if err := ValidateEmail(u.Email); err != nil {
+		return err
 	}
 	if u.Role != "" && !u.Role.IsValid() {
 		return ErrInvalidRole
 	}
 	return nil
 }
+
+// ValidateEmail checks that an email address is well-formed.
+func ValidateEmail(email string) error {
+	if _, err := mail.ParseAddress(email); err != nil || !emailRegex.MatchString(email) {
+		return ErrInvalidEmailFormat
+	}
+	return nil
+}
+
+// ValidateUsername checks a username chosen after registration. Besides the length limits of
+// Validate, it only allows letters, digits and underscores so the user stays @mentionable.
+func ValidateUsername(username string) error {
+	if username == "" {
+		return ErrUsernameEmpty
+	}
+	if len(username) > 50 {
+		return ErrUsernameTooLong
+	}
+	if !usernameRegex.MatchString(username) {
+		return ErrUsernameInvalid
+	}
+	return nil
+}
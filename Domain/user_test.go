@@ -2,6 +2,7 @@ package domain_test
 
 import (
 	"testing"
+	"time"
 
 	. "A2SV_Starter_Project_Blog/Domain"
 
@@ -108,6 +109,23 @@ func (s *UserDomainTestSuite) TestUser_Validate() {
 	}
 }
 
+func (s *UserDomainTestSuite) TestCheckAccountAge() {
+	now := time.Now().UTC()
+	user := createValidLocalUser()
+	user.CreatedAt = now.Add(-30 * time.Minute)
+
+	s.NoError(user.CheckAccountAge(0, now))
+	s.NoError(user.CheckAccountAge(30*time.Minute, now))
+	s.ErrorIs(user.CheckAccountAge(time.Hour, now), ErrAccountTooNew)
+}
+
+func (s *UserDomainTestSuite) TestHasPassword() {
+	empty := ""
+	s.True(createValidLocalUser().HasPassword())
+	s.False((&User{Password: nil}).HasPassword())
+	s.False((&User{Password: &empty}).HasPassword())
+}
+
 func (s *UserDomainTestSuite) TestRole_IsValid() {
 	s.Run("Valid roles", func() {
 		s.True(RoleUser.IsValid())
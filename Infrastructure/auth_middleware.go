@@ -2,6 +2,9 @@ package infrastructure
 
 import (
 	domain "A2SV_Starter_Project_Blog/Domain"
+	"context"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
 
@@ -34,16 +37,62 @@ func AuthMiddleware(jwtService JWTService) gin.HandlerFunc {
 		c.Set("userID", claims.UserID)
 		c.Set("userRole", claims.Role)
 
+		if claims.ImpersonatedBy != "" && !allowImpersonated(c, claims) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Impersonation tokens are read-only"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// allowImpersonated logs a request made with an impersonation token and reports whether
+// it may proceed. Impersonation is for seeing what a user sees, so only reads are allowed.
+func allowImpersonated(c *gin.Context, claims *JWTClaims) bool {
+	if !isReadMethod(c.Request.Method) {
+		log.Printf("AUDIT: blocked write by admin %s impersonating user %s: %s %s", claims.ImpersonatedBy, claims.UserID, c.Request.Method, c.Request.URL.Path)
+		return false
+	}
+	log.Printf("AUDIT: admin %s impersonating user %s: %s %s", claims.ImpersonatedBy, claims.UserID, c.Request.Method, c.Request.URL.Path)
+	c.Set("impersonatedBy", claims.ImpersonatedBy)
+	return true
+}
+
+// isReadMethod reports whether a request with this method only reads.
+func isReadMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// OptionalAuth is for public endpoints that personalize their response when the viewer is logged in.
+// A valid Bearer token sets "userID" and "userRole" like AuthMiddleware does; a missing, malformed
+// or expired token is ignored and the request continues anonymously.
+func OptionalAuth(jwtService JWTService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			claims, err := jwtService.ValidateToken(parts[1])
+			if err == nil && (claims.ImpersonatedBy == "" || allowImpersonated(c, claims)) {
+				c.Set("userID", claims.UserID)
+				c.Set("userRole", claims.Role)
+			}
+		}
 		c.Next()
 	}
 }
 
-// AdminOnlyMiddleware checks if the authenticated user has the 'admin' role.
-// It should be used *after* the AuthMiddleware.
-func AdminOnlyMiddleware() gin.HandlerFunc {
+// RequireRole only lets requests through whose role is one of the given roles.
+// It should be used *after* the AuthMiddleware, which sets "userRole".
+func RequireRole(roles ...domain.Role) gin.HandlerFunc {
+	allowed := make(map[domain.Role]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+
 	return func(c *gin.Context) {
-		// 1. Get the role from the context, which was set by AuthMiddleware.
-		// Note: your existing middleware uses the key "userRole".
 		role, exists := c.Get("userRole")
 		if !exists {
 			// This case should not happen if AuthMiddleware is used correctly.
@@ -52,16 +101,53 @@ func AdminOnlyMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		// 2. Check if the role is 'admin'.
 		// We cast the role to domain.Role for type safety.
 		userRole, ok := role.(domain.Role)
-		if !ok || userRole != domain.RoleAdmin {
-			// If the type assertion fails or the user is not an admin, abort the request.
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Access denied: Admin privileges required."})
+		if !ok || !allowed[userRole] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Access denied: Insufficient privileges."})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// UserLoader is the part of the user repository AttachUser needs.
+// Pass the caching repository so repeated requests are served from the cache.
+type UserLoader interface {
+	GetByID(ctx context.Context, id string) (*domain.User, error)
+}
+
+// AttachUser loads the authenticated user once and stores it in the context under "user",
+// so handlers further down the chain don't have to fetch it again.
+// It should be used *after* the AuthMiddleware, on the route groups that need the full user.
+func AttachUser(users UserLoader) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Already attached further up the chain.
+		if _, exists := c.Get("user"); exists {
+			c.Next()
+			return
+		}
+
+		userID := c.GetString("userID")
+		if userID == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+
+		user, err := users.GetByID(c.Request.Context(), userID)
+		if errors.Is(err, domain.ErrUserNotFound) || (err == nil && user == nil) {
+			// The token is valid but its user no longer exists.
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+			return
+		}
+		if err != nil {
+			log.Printf("ERROR: Failed to load user %s: %v", userID, err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "An unexpected error occurred"})
 			return
 		}
 
-		// 3. If the user is an admin, continue to the next handler.
+		c.Set("user", user)
 		c.Next()
 	}
 }
@@ -3,6 +3,8 @@ package infrastructure_test
 import (
 	domain "A2SV_Starter_Project_Blog/Domain"
 	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -100,4 +102,262 @@ func TestAuthMiddleware(t *testing.T) {
 			assert.JSONEq(t, tc.expectedBody, w.Body.String())
 		})
 	}
-}
\ No newline at end of file
+}
+// countingUserLoader records how many times each user is fetched.
+type countingUserLoader struct {
+	users map[string]*domain.User
+	err   error
+	calls int
+}
+
+func (l *countingUserLoader) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	l.calls++
+	if l.err != nil {
+		return nil, l.err
+	}
+	user, ok := l.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func TestAttachUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	user := &domain.User{ID: "user-abc-123", Username: "tester"}
+
+	// setupRouter simulates AuthMiddleware with a fixed userID and applies AttachUser
+	// at both the group and the route level, as nested groups might.
+	setupRouter := func(loader *countingUserLoader, userID string) *gin.Engine {
+		router := gin.New()
+		group := router.Group("/", func(c *gin.Context) {
+			if userID != "" {
+				c.Set("userID", userID)
+			}
+			c.Next()
+		}, infrastructure.AttachUser(loader))
+		group.GET("/test", infrastructure.AttachUser(loader), func(c *gin.Context) {
+			attached, _ := c.Get("user")
+			c.JSON(http.StatusOK, gin.H{"username": attached.(*domain.User).Username})
+		})
+		return router
+	}
+
+	t.Run("Success - User attached and fetched once", func(t *testing.T) {
+		loader := &countingUserLoader{users: map[string]*domain.User{user.ID: user}}
+		router := setupRouter(loader, user.ID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"username":"tester"}`, w.Body.String())
+		assert.Equal(t, 1, loader.calls, "The user should only be fetched once per request")
+	})
+
+	t.Run("Failure - Not authenticated", func(t *testing.T) {
+		loader := &countingUserLoader{}
+		router := setupRouter(loader, "")
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Zero(t, loader.calls)
+	})
+
+	t.Run("Failure - User no longer exists", func(t *testing.T) {
+		loader := &countingUserLoader{users: map[string]*domain.User{}}
+		router := setupRouter(loader, "deleted-user")
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Failure - Repository error", func(t *testing.T) {
+		loader := &countingUserLoader{err: errors.New("db down")}
+		router := setupRouter(loader, user.ID)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestRequireRole(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		allowed        []domain.Role
+		setRole        func(c *gin.Context)
+		expectedStatus int
+	}{
+		{
+			name:           "Success - Admin passes admin route",
+			allowed:        []domain.Role{domain.RoleAdmin},
+			setRole:        func(c *gin.Context) { c.Set("userRole", domain.RoleAdmin) },
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Success - Any listed role passes",
+			allowed:        []domain.Role{domain.RoleAdmin, domain.RoleUser},
+			setRole:        func(c *gin.Context) { c.Set("userRole", domain.RoleUser) },
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Failure - User blocked from admin route",
+			allowed:        []domain.Role{domain.RoleAdmin},
+			setRole:        func(c *gin.Context) { c.Set("userRole", domain.RoleUser) },
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Failure - Missing role",
+			allowed:        []domain.Role{domain.RoleAdmin},
+			setRole:        func(c *gin.Context) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Failure - Role of the wrong type",
+			allowed:        []domain.Role{domain.RoleAdmin},
+			setRole:        func(c *gin.Context) { c.Set("userRole", "admin") },
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/test", func(c *gin.Context) {
+				tc.setRole(c)
+				c.Next()
+			}, infrastructure.RequireRole(tc.allowed...), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestOptionalAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtService := infrastructure.NewJWTService("test-secret", "test-issuer", 1*time.Minute, 24*time.Hour)
+
+	testCases := []struct {
+		name         string
+		setupRequest func(req *http.Request)
+		expectedBody string
+	}{
+		{
+			name: "Valid token sets the user",
+			setupRequest: func(req *http.Request) {
+				token, _, _ := jwtService.GenerateAccessToken("user-abc-123", domain.RoleUser)
+				req.Header.Set("Authorization", "Bearer "+token)
+			},
+			expectedBody: `{"userID":"user-abc-123","userRole":"user"}`,
+		},
+		{
+			name:         "No header continues anonymously",
+			setupRequest: func(req *http.Request) {},
+			expectedBody: `{"userID":null,"userRole":null}`,
+		},
+		{
+			name: "Invalid token continues anonymously",
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("Authorization", "Bearer not-a-jwt")
+			},
+			expectedBody: `{"userID":null,"userRole":null}`,
+		},
+		{
+			name: "Malformed header continues anonymously",
+			setupRequest: func(req *http.Request) {
+				req.Header.Set("Authorization", "not-bearer")
+			},
+			expectedBody: `{"userID":null,"userRole":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := gin.New()
+			router.GET("/test", infrastructure.OptionalAuth(jwtService), func(c *gin.Context) {
+				id, _ := c.Get("userID")
+				role, _ := c.Get("userRole")
+				c.JSON(http.StatusOK, gin.H{"userID": id, "userRole": role})
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+			tc.setupRequest(req)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code, "OptionalAuth must never reject a request")
+			assert.JSONEq(t, tc.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestAuthMiddleware_Impersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtService := infrastructure.NewJWTService("test-secret", "test-issuer", 1*time.Minute, 24*time.Hour)
+	impersonation, _, err := jwtService.GenerateImpersonationToken("user-abc-123", domain.RoleUser, "admin-1", time.Minute)
+	assert.NoError(t, err)
+	regular, _, err := jwtService.GenerateAccessToken("user-abc-123", domain.RoleUser)
+	assert.NoError(t, err)
+
+	router := gin.New()
+	handler := func(c *gin.Context) {
+		by, _ := c.Get("impersonatedBy")
+		c.JSON(http.StatusOK, gin.H{"userID": c.GetString("userID"), "impersonatedBy": by})
+	}
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		router.Handle(method, "/test", infrastructure.AuthMiddleware(jwtService), handler)
+	}
+	router.POST("/optional", infrastructure.OptionalAuth(jwtService), handler)
+
+	serve := func(method, path, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Reads are allowed and flagged", func(t *testing.T) {
+		w := serve(http.MethodGet, "/test", impersonation)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"userID":"user-abc-123","impersonatedBy":"admin-1"}`, w.Body.String())
+	})
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		t.Run("Writes are rejected - "+method, func(t *testing.T) {
+			w := serve(method, "/test", impersonation)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.JSONEq(t, `{"error":"Impersonation tokens are read-only"}`, w.Body.String())
+		})
+	}
+
+	t.Run("Regular tokens may still write", func(t *testing.T) {
+		w := serve(http.MethodPost, "/test", regular)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"userID":"user-abc-123","impersonatedBy":null}`, w.Body.String())
+	})
+
+	t.Run("OptionalAuth treats a write under impersonation as anonymous", func(t *testing.T) {
+		w := serve(http.MethodPost, "/optional", impersonation)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"userID":"","impersonatedBy":null}`, w.Body.String())
+	})
+}
@@ -0,0 +1,103 @@
+package infrastructure
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSPolicy describes which cross-origin requests a group of routes accepts.
+type CORSPolicy struct {
+	// AllowedOrigins lists the origins allowed to call the routes. "*" allows any origin.
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	// MaxAge lets browsers cache a preflight response. Zero leaves it to the browser.
+	MaxAge time.Duration
+}
+
+func (p CORSPolicy) allowsOrigin(origin string) bool {
+	for _, allowed := range p.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p CORSPolicy) allowsMethod(method string) bool {
+	for _, allowed := range p.AllowedMethods {
+		if strings.EqualFold(allowed, method) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p CORSPolicy) allowsAnyOrigin() bool {
+	for _, allowed := range p.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// CORSMiddleware applies a CORS policy. Requests without an Origin header are not cross-origin
+// and pass through untouched. A cross-origin request from an origin or with a method the policy
+// doesn't allow is rejected with 403, and preflight requests are answered here without reaching a handler.
+func CORSMiddleware(policy CORSPolicy) gin.HandlerFunc {
+	allowedMethods := strings.Join(policy.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(policy.AllowedHeaders, ", ")
+	maxAge := strconv.Itoa(int(policy.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		// The response depends on the origin, so caches must not share it between origins.
+		c.Writer.Header().Add("Vary", "Origin")
+		if !policy.allowsOrigin(origin) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Origin not allowed"})
+			return
+		}
+
+		method := c.Request.Method
+		preflight := method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if preflight {
+			method = c.GetHeader("Access-Control-Request-Method")
+		}
+		if !policy.allowsMethod(method) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Method not allowed for cross-origin requests"})
+			return
+		}
+
+		if policy.allowsAnyOrigin() {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+
+		if !preflight {
+			// Paginated listings put their page links in a Link header, which browsers hide by default.
+			c.Header("Access-Control-Expose-Headers", "Link")
+			c.Next()
+			return
+		}
+
+		c.Header("Access-Control-Allow-Methods", allowedMethods)
+		if allowedHeaders != "" {
+			c.Header("Access-Control-Allow-Headers", allowedHeaders)
+		}
+		if policy.MaxAge > 0 {
+			c.Header("Access-Control-Max-Age", maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
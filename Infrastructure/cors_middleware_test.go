@@ -0,0 +1,115 @@
+package infrastructure_test
+
+import (
+	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	publicCORSPolicy = infrastructure.CORSPolicy{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		MaxAge:         10 * time.Minute,
+	}
+	adminCORSPolicy = infrastructure.CORSPolicy{
+		AllowedOrigins: []string{"https://admin.example.com"},
+		AllowedMethods: []string{"GET", "PATCH"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		MaxAge:         10 * time.Minute,
+	}
+)
+
+// setupCORSRouter gives a public and an admin group their own CORS policy.
+func setupCORSRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	public := router.Group("/blogs", infrastructure.CORSMiddleware(publicCORSPolicy))
+	public.GET("", ok)
+	public.OPTIONS("", ok)
+
+	admin := router.Group("/admin", infrastructure.CORSMiddleware(adminCORSPolicy))
+	admin.GET("/users", ok)
+	admin.DELETE("/users", ok)
+	admin.OPTIONS("/users", ok)
+	return router
+}
+
+func corsRequest(router *gin.Engine, method, path, origin string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCORSMiddleware_PerGroupOrigins(t *testing.T) {
+	router := setupCORSRouter()
+	origin := "https://someone-else.example.org"
+
+	t.Run("Public group allows any origin", func(t *testing.T) {
+		w := corsRequest(router, http.MethodGet, "/blogs", origin, nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "Link", w.Header().Get("Access-Control-Expose-Headers"))
+	})
+
+	t.Run("Admin group rejects the same origin", func(t *testing.T) {
+		w := corsRequest(router, http.MethodGet, "/admin/users", origin, nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("Admin group rejects the same origin on preflight", func(t *testing.T) {
+		w := corsRequest(router, http.MethodOptions, "/admin/users", origin, map[string]string{"Access-Control-Request-Method": "GET"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Admin group allows its listed origin", func(t *testing.T) {
+		w := corsRequest(router, http.MethodGet, "/admin/users", "https://admin.example.com", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "https://admin.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, w.Header().Values("Vary"), "Origin")
+	})
+
+	t.Run("Same-origin requests are untouched", func(t *testing.T) {
+		w := corsRequest(router, http.MethodGet, "/admin/users", "", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestCORSMiddleware_Preflight(t *testing.T) {
+	router := setupCORSRouter()
+
+	t.Run("Answers with the allowed methods and a max age", func(t *testing.T) {
+		w := corsRequest(router, http.MethodOptions, "/blogs", "https://app.example.com", map[string]string{"Access-Control-Request-Method": "POST"})
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, "GET, POST, PUT, DELETE", w.Header().Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "Authorization, Content-Type", w.Header().Get("Access-Control-Allow-Headers"))
+		assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+	})
+
+	t.Run("Admin group restricts methods", func(t *testing.T) {
+		w := corsRequest(router, http.MethodOptions, "/admin/users", "https://admin.example.com", map[string]string{"Access-Control-Request-Method": "DELETE"})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Admin group rejects a disallowed method on the actual request", func(t *testing.T) {
+		w := corsRequest(router, http.MethodDelete, "/admin/users", "https://admin.example.com", nil)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -10,6 +10,9 @@ import (
 type EmailService interface {
 	SendPasswordResetEmail(toEmail, username, resetToken string) error
 	SendActivationEmail(toEmail, username, activationToken string) error
+	SendMentionEmail(toEmail, username, mentionedBy, blogID string) error
+	SendNewFollowerEmail(toEmail, username, followedBy, followerID string) error
+	SendEmailChangeEmail(toEmail, username, changeToken string) error
 }
 
 // dialer interface allows mocking the gomail.Dialer
@@ -26,8 +29,10 @@ type SmtpEmailService struct {
 	dialer   dialer
 }
 
-func NewSMTPEmailService(host string, port int, username, password, from string) EmailService {
-	d := gomail.NewDialer(host, port, username, password)
+// NewSMTPEmailService creates an email service that sends through the given SMTP server,
+// reusing connections between sends. The options tune timeouts, TLS and pooling.
+func NewSMTPEmailService(host string, port int, username, password, from string, opts ...SMTPOption) EmailService {
+	d := newSMTPPool(host, port, username, password, opts...)
 
 	return &SmtpEmailService{
 		host:     host,
@@ -40,6 +45,45 @@ func NewSMTPEmailService(host string, port int, username, password, from string)
 }
 
 func (s *SmtpEmailService) SendPasswordResetEmail(toEmail, username, resetToken string) error {
+	subject, body := passwordResetEmail(username, resetToken)
+	return s.send(toEmail, subject, body)
+}
+
+func (s *SmtpEmailService) SendActivationEmail(toEmail, username, activationToken string) error {
+	subject, body := activationEmail(username, activationToken)
+	return s.send(toEmail, subject, body)
+}
+
+func (s *SmtpEmailService) SendMentionEmail(toEmail, username, mentionedBy, blogID string) error {
+	subject, body := mentionEmail(username, mentionedBy, blogID)
+	return s.send(toEmail, subject, body)
+}
+
+func (s *SmtpEmailService) SendNewFollowerEmail(toEmail, username, followedBy, followerID string) error {
+	subject, body := newFollowerEmail(username, followedBy, followerID)
+	return s.send(toEmail, subject, body)
+}
+
+func (s *SmtpEmailService) SendEmailChangeEmail(toEmail, username, changeToken string) error {
+	subject, body := emailChangeEmail(username, changeToken)
+	return s.send(toEmail, subject, body)
+}
+
+func (s *SmtpEmailService) send(to, subject, body string) error {
+	m := gomail.NewMessage()
+
+	m.SetHeader("From", s.from)
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/plain", body)
+
+	return s.dialer.DialAndSend(m)
+}
+
+// --- Email Templates ---
+// Shared by every EmailService implementation so a captured email matches the one that would be sent.
+
+func passwordResetEmail(username, resetToken string) (string, string) {
 	subject := "Reset Your Password"
 	body := fmt.Sprintf(`
 	Hi %s,
@@ -54,11 +98,10 @@ func (s *SmtpEmailService) SendPasswordResetEmail(toEmail, username, resetToken
 
 	If you did not request this, please ignore this email.
 	`, username, resetToken, resetToken)
-
-	return s.send(toEmail, subject, body)
+	return subject, body
 }
 
-func (s *SmtpEmailService) SendActivationEmail(toEmail, username, activationToken string) error {
+func activationEmail(username, activationToken string) (string, string) {
 	subject := "Activate Your Account"
 	body := fmt.Sprintf(`
 	Hi %s,
@@ -72,17 +115,49 @@ func (s *SmtpEmailService) SendActivationEmail(toEmail, username, activationToke
 	http://localhost:8080/api/v1/auth/activate?token=%s
 	If you did not create an account, ignore this email.
 	`, username, activationToken, activationToken)
+	return subject, body
+}
 
-	return s.send(toEmail, subject, body)
+func mentionEmail(username, mentionedBy, blogID string) (string, string) {
+	subject := fmt.Sprintf("%s mentioned you in a comment", mentionedBy)
+	body := fmt.Sprintf(`
+	Hi %s,
+
+	%s mentioned you in a comment.
+
+	Read it here:
+	http://localhost:8080/api/v1/blogs/%s/comments
+	`, username, mentionedBy, blogID)
+	return subject, body
 }
 
-func (s *SmtpEmailService) send(to, subject, body string) error {
-	m := gomail.NewMessage()
+func newFollowerEmail(username, followedBy, followerID string) (string, string) {
+	subject := fmt.Sprintf("%s started following you", followedBy)
+	body := fmt.Sprintf(`
+	Hi %s,
 
-	m.SetHeader("From", s.from)
-	m.SetHeader("To", to)
-	m.SetHeader("Subject", subject)
-	m.SetBody("text/plain", body)
+	%s started following you.
 
-	return s.dialer.DialAndSend(m)
+	See their profile here:
+	http://localhost:8080/api/v1/users/%s
+	`, username, followedBy, followerID)
+	return subject, body
+}
+
+func emailChangeEmail(username, changeToken string) (string, string) {
+	subject := "Confirm Your New Email Address"
+	body := fmt.Sprintf(`
+	Hi %s,
+
+	You asked to change the email address on your account to this one.
+
+	Confirm the change using the token below:
+	%s
+
+	Or click this link:
+	http://localhost:8080/api/v1/auth/email/confirm?token=%s
+
+	Until you confirm, your old address stays in use. If you did not request this, ignore this email.
+	`, username, changeToken, changeToken)
+	return subject, body
 }
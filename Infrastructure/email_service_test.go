@@ -1,9 +1,14 @@
 package infrastructure
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"net"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"gopkg.in/gomail.v2"
 )
@@ -68,6 +73,69 @@ func TestSendActivationEmail_Success(t *testing.T) {
 	}
 }
 
+func TestSendMentionEmail_Success(t *testing.T) {
+	svc, mock := newTestEmailService("test@example.com", false)
+
+	err := svc.SendMentionEmail("bob@example.com", "Bob", "alice", "blog-123")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(mock.sentMessages) != 1 {
+		t.Fatalf("expected 1 message sent, got %d", len(mock.sentMessages))
+	}
+
+	msg := mock.sentMessages[0]
+	if !strings.Contains(msg.GetHeader("Subject")[0], "alice") {
+		t.Errorf("expected mentioning user in subject")
+	}
+	if !strings.Contains(getBody(msg), "blog-123") {
+		t.Errorf("expected blog link in email body")
+	}
+}
+
+func TestSendNewFollowerEmail_Success(t *testing.T) {
+	svc, mock := newTestEmailService("test@example.com", false)
+
+	err := svc.SendNewFollowerEmail("bob@example.com", "Bob", "alice", "user-123")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(mock.sentMessages) != 1 {
+		t.Fatalf("expected 1 message sent, got %d", len(mock.sentMessages))
+	}
+
+	msg := mock.sentMessages[0]
+	if !strings.Contains(msg.GetHeader("Subject")[0], "alice") {
+		t.Errorf("expected follower in subject")
+	}
+	if !strings.Contains(getBody(msg), "users/user-123") {
+		t.Errorf("expected follower profile link in email body")
+	}
+}
+
+func TestSendEmailChangeEmail_Success(t *testing.T) {
+	svc, mock := newTestEmailService("test@example.com", false)
+
+	err := svc.SendEmailChangeEmail("new@example.com", "Alice", "change123")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(mock.sentMessages) != 1 {
+		t.Fatalf("expected 1 message sent, got %d", len(mock.sentMessages))
+	}
+
+	msg := mock.sentMessages[0]
+	if msg.GetHeader("To")[0] != "new@example.com" {
+		t.Errorf("expected the confirmation to go to the new address")
+	}
+	if !strings.Contains(getBody(msg), "change123") {
+		t.Errorf("expected confirmation link in email body")
+	}
+}
+
 func TestSendPasswordResetEmail_Failure(t *testing.T) {
 	svc, _ := newTestEmailService("test@example.com", true)
 
@@ -83,3 +151,213 @@ func getBody(msg *gomail.Message) string {
 	_, _ = msg.WriteTo(&sb)
 	return sb.String()
 }
+
+// --- Fake SMTP server ---
+
+// fakeSMTPServer speaks just enough SMTP for net/smtp, counting connections and delivered messages.
+type fakeSMTPServer struct {
+	listener net.Listener
+	// silent accepts connections but never sends the greeting.
+	silent bool
+	// stallOnData never acknowledges the end of a message.
+	stallOnData bool
+	// hangUpAfterMessage closes the connection once a message is delivered.
+	hangUpAfterMessage bool
+
+	mu          sync.Mutex
+	connections int
+	messages    []string
+}
+
+func newFakeSMTPServer(t *testing.T, configure func(*fakeSMTPServer)) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := &fakeSMTPServer{listener: listener}
+	if configure != nil {
+		configure(srv)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go srv.serve()
+	return srv
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) stats() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections, len(s.messages)
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.connections++
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	if s.silent {
+		// Hold the connection open without a word until the client gives up.
+		_, _ = conn.Read(make([]byte, 1))
+		return
+	}
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+	reply("220 fake.smtp ready")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 fake.smtp")
+		case strings.HasPrefix(cmd, "DATA"):
+			reply("354 end with <CRLF>.<CRLF>")
+			var msg strings.Builder
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				msg.WriteString(dataLine)
+			}
+			if s.stallOnData {
+				_, _ = r.ReadByte()
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, msg.String())
+			s.mu.Unlock()
+			reply("250 queued")
+			if s.hangUpAfterMessage {
+				return
+			}
+		case strings.HasPrefix(cmd, "QUIT"):
+			reply("221 bye")
+			return
+		default:
+			// MAIL, RCPT, NOOP and RSET all just succeed.
+			reply("250 ok")
+		}
+	}
+}
+
+func newFakeServerEmailService(srv *fakeSMTPServer, opts ...SMTPOption) EmailService {
+	opts = append([]SMTPOption{WithSMTPTLSMode(SMTPTLSNone)}, opts...)
+	return NewSMTPEmailService("127.0.0.1", srv.port(), "", "", "test@example.com", opts...)
+}
+
+func TestSMTPEmailService_ReusesPooledConnection(t *testing.T) {
+	srv := newFakeSMTPServer(t, nil)
+	svc := newFakeServerEmailService(srv)
+
+	for i := 0; i < 3; i++ {
+		if err := svc.SendActivationEmail("user@example.com", "Alice", fmt.Sprintf("token-%d", i)); err != nil {
+			t.Fatalf("send %d: expected no error, got %v", i, err)
+		}
+	}
+
+	connections, messages := srv.stats()
+	if messages != 3 {
+		t.Fatalf("expected 3 messages delivered, got %d", messages)
+	}
+	if connections != 1 {
+		t.Errorf("expected sends to share 1 connection, got %d", connections)
+	}
+}
+
+func TestSMTPEmailService_RedialsWhenPooledConnectionDropped(t *testing.T) {
+	srv := newFakeSMTPServer(t, func(s *fakeSMTPServer) { s.hangUpAfterMessage = true })
+	svc := newFakeServerEmailService(srv)
+
+	for i := 0; i < 2; i++ {
+		if err := svc.SendPasswordResetEmail("user@example.com", "John", "reset123"); err != nil {
+			t.Fatalf("send %d: expected no error, got %v", i, err)
+		}
+	}
+
+	connections, messages := srv.stats()
+	if messages != 2 || connections != 2 {
+		t.Errorf("expected 2 messages over 2 connections, got %d over %d", messages, connections)
+	}
+}
+
+func TestSMTPEmailService_ClosesIdleConnections(t *testing.T) {
+	srv := newFakeSMTPServer(t, nil)
+	svc := newFakeServerEmailService(srv, WithSMTPPool(1, 50*time.Millisecond))
+
+	if err := svc.SendActivationEmail("user@example.com", "Alice", "first"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if err := svc.SendActivationEmail("user@example.com", "Alice", "second"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if connections, _ := srv.stats(); connections != 2 {
+		t.Errorf("expected a fresh connection after the idle timeout, got %d connections", connections)
+	}
+}
+
+func TestSMTPEmailService_DialTimeout(t *testing.T) {
+	srv := newFakeSMTPServer(t, func(s *fakeSMTPServer) { s.silent = true })
+	svc := newFakeServerEmailService(srv, WithSMTPTimeouts(100*time.Millisecond, time.Second))
+
+	start := time.Now()
+	err := svc.SendActivationEmail("user@example.com", "Alice", "activate123")
+
+	assertTimeout(t, err, time.Since(start))
+}
+
+func TestSMTPEmailService_SendTimeout(t *testing.T) {
+	srv := newFakeSMTPServer(t, func(s *fakeSMTPServer) { s.stallOnData = true })
+	svc := newFakeServerEmailService(srv, WithSMTPTimeouts(time.Second, 100*time.Millisecond))
+
+	start := time.Now()
+	err := svc.SendActivationEmail("user@example.com", "Alice", "activate123")
+
+	assertTimeout(t, err, time.Since(start))
+}
+
+func TestSMTPEmailService_StartTLSRequired(t *testing.T) {
+	srv := newFakeSMTPServer(t, nil)
+	svc := NewSMTPEmailService("127.0.0.1", srv.port(), "", "", "test@example.com", WithSMTPTLSMode(SMTPTLSStartTLS))
+
+	err := svc.SendActivationEmail("user@example.com", "Alice", "activate123")
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("expected a STARTTLS error from a server without it, got %v", err)
+	}
+	if _, messages := srv.stats(); messages != 0 {
+		t.Errorf("expected nothing sent in plain text, got %d messages", messages)
+	}
+}
+
+func assertTimeout(t *testing.T, err error, elapsed time.Duration) {
+	t.Helper()
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+	if elapsed > 2*time.Second {
+		t.Errorf("expected the send to give up quickly, took %v", elapsed)
+	}
+}
@@ -0,0 +1,8 @@
+package infrastructure
+
+import "time"
+
+// SetClock replaces the limiter's clock, so tests can move past a window without waiting for it.
+func (rl *RateLimiter) SetClock(now func() time.Time) {
+	rl.now = now
+}
@@ -2,11 +2,13 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 
 	domain "A2SV_Starter_Project_Blog/Domain"
 	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -58,3 +60,30 @@ func (s *GeminiAIService) GenerateCompletion(ctx context.Context, prompt string)
 	log.Printf("Gemini returned a non-text part: %T", firstPart)
 	return "", fmt.Errorf("received an unexpected response type from the AI service")
 }
+
+// StreamCompletion sends a prompt to the Gemini API and hands each piece of text to fn as it arrives.
+// Cancelling ctx closes the upstream stream.
+func (s *GeminiAIService) StreamCompletion(ctx context.Context, prompt string, fn func(chunk string) error) error {
+	iter := s.model.GenerateContentStream(ctx, genai.Text(prompt))
+	for {
+		resp, err := iter.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to stream content from Gemini: %w", err)
+		}
+		if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+			continue
+		}
+		for _, part := range resp.Candidates[0].Content.Parts {
+			text, ok := part.(genai.Text)
+			if !ok || text == "" {
+				continue
+			}
+			if err := fn(string(text)); err != nil {
+				return err
+			}
+		}
+	}
+}
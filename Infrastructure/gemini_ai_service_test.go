@@ -3,6 +3,7 @@ package infrastructure_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
 	"strings"
 	"testing"
@@ -93,6 +94,32 @@ func (s *GeminiAIServiceSuite) TestGenerateCompletion() {
 	})
 }
 
+func (s *GeminiAIServiceSuite) TestStreamCompletion() {
+	s.Run("Success - Chunks Join Into The Answer", func() {
+		var chunks []string
+		err := s.service.StreamCompletion(context.Background(), "What is the capital of France?", func(chunk string) error {
+			chunks = append(chunks, chunk)
+			return nil
+		})
+
+		s.NoError(err)
+		s.NotEmpty(chunks)
+		s.Contains(strings.ToLower(strings.Join(chunks, "")), "paris")
+	})
+
+	s.Run("Stops When The Callback Fails", func() {
+		stop := errors.New("stop")
+		calls := 0
+		err := s.service.StreamCompletion(context.Background(), "Count from 1 to 200, one number per line.", func(string) error {
+			calls++
+			return stop
+		})
+
+		s.ErrorIs(err, stop)
+		s.Equal(1, calls)
+	})
+}
+
 // TestNewGeminiAIService_Failures tests the constructor's error handling.
 func (s *GeminiAIServiceSuite) TestNewGeminiAIService_Failures() {
 	s.Run("Failure - Empty API Key", func() {
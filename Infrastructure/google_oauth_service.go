@@ -50,9 +50,29 @@ func NewGoogleOAuthService(clientID, clientSecret, redirectURI string) (domain.I
 	}, nil
 }
 
+// configFor returns the OAuth config with its redirect URL swapped for redirectURI, if one is given.
+// Google requires the code exchange to name the same redirect URI as the consent request.
+func (s *GoogleOAuthService) configFor(redirectURI string) *oauth2.Config {
+	if redirectURI == "" {
+		return s.OAuthConfig
+	}
+	config := *s.OAuthConfig
+	config.RedirectURL = redirectURI
+	return &config
+}
+
+// AuthCodeURL builds the URL of Google's consent page for the given redirect URI.
+// Google hands state back unchanged on the redirect, and keeps the PKCE challenge to check the exchange against.
+func (s *GoogleOAuthService) AuthCodeURL(redirectURI, state, codeChallenge string) string {
+	return s.configFor(redirectURI).AuthCodeURL(state,
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
+	)
+}
+
 // ExchangeCodeForToken remains a thin wrapper. We trust the underlying library.
-func (s *GoogleOAuthService) ExchangeCodeForToken(ctx context.Context, code string) (*oauth2.Token, error) {
-	token, err := s.OAuthConfig.Exchange(ctx, code)
+func (s *GoogleOAuthService) ExchangeCodeForToken(ctx context.Context, code, redirectURI, codeVerifier string) (*oauth2.Token, error) {
+	token, err := s.configFor(redirectURI).Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
 	if err != nil {
 		return nil, fmt.Errorf("failed to exchange code for token: %w", err)
 	}
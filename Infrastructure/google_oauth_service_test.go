@@ -126,3 +126,60 @@ func (s *GoogleOAuthServiceUnitTestSuite) TestGetUserInfo() {
 		s.Contains(err.Error(), "failed to unmarshal user info JSON")
 	})
 }
+
+func (s *GoogleOAuthServiceUnitTestSuite) TestAuthCodeURL() {
+	service, err := NewGoogleOAuthService("id", "secret", "https://web.example.com/default")
+	s.Require().NoError(err)
+
+	s.Run("Uses the configured redirect URI by default", func() {
+		s.Contains(service.AuthCodeURL("", "state-1", "challenge"), "redirect_uri=https%3A%2F%2Fweb.example.com%2Fdefault")
+	})
+
+	s.Run("Uses the requested redirect URI without changing the default", func() {
+		s.Contains(service.AuthCodeURL("app://oauth", "state-1", "challenge"), "redirect_uri=app%3A%2F%2Foauth")
+		s.Equal("https://web.example.com/default", service.(*GoogleOAuthService).OAuthConfig.RedirectURL)
+	})
+
+	s.Run("Carries the state and the S256 PKCE challenge", func() {
+		url := service.AuthCodeURL("", "state-1", "challenge")
+		s.Contains(url, "state=state-1")
+		s.Contains(url, "code_challenge=challenge")
+		s.Contains(url, "code_challenge_method=S256")
+	})
+}
+
+func (s *GoogleOAuthServiceUnitTestSuite) TestExchangeCodeForToken_PKCE() {
+	verifier := oauth2.GenerateVerifier()
+	challenge := oauth2.S256ChallengeFromVerifier(verifier)
+
+	// A token endpoint that, like Google's, only accepts a verifier matching the challenge.
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Require().NoError(r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+		if oauth2.S256ChallengeFromVerifier(r.PostForm.Get("code_verifier")) != challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, `{"error":"invalid_grant","error_description":"Invalid code verifier."}`)
+			return
+		}
+		fmt.Fprintln(w, `{"access_token":"google-access-token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer mockServer.Close()
+
+	service, err := NewGoogleOAuthService("id", "secret", "https://web.example.com/default")
+	s.Require().NoError(err)
+	service.(*GoogleOAuthService).OAuthConfig.Endpoint = oauth2.Endpoint{TokenURL: mockServer.URL, AuthStyle: oauth2.AuthStyleInParams}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, mockServer.Client())
+
+	s.Run("Success - Matching verifier", func() {
+		token, err := service.ExchangeCodeForToken(ctx, "code", "", verifier)
+		s.Require().NoError(err)
+		s.Equal("google-access-token", token.AccessToken)
+	})
+
+	s.Run("Failure - Mismatched verifier", func() {
+		token, err := service.ExchangeCodeForToken(ctx, "code", "", oauth2.GenerateVerifier())
+		s.Error(err)
+		s.Nil(token)
+		s.Contains(err.Error(), "invalid_grant")
+	})
+}
@@ -2,8 +2,13 @@ package infrastructure
 
 import (
 	domain "A2SV_Starter_Project_Blog/Domain"
+	"crypto/rsa"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"math/big"
+	"os"
+	"sort"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -14,9 +19,13 @@ import (
 type JWTService interface {
 	GenerateAccessToken(userID string, role domain.Role) (string, *JWTClaims, error)
 	GenerateRefreshToken(userID string) (string, *JWTClaims, error)
+	// GenerateImpersonationToken issues a read-only access token for userID on behalf of an admin.
+	GenerateImpersonationToken(userID string, role domain.Role, adminID string, ttl time.Duration) (string, *JWTClaims, error)
 	ValidateToken(tokenString string) (*JWTClaims, error)
 	ParseExpiredToken(tokenString string) (*JWTClaims, error)
 	GetRefreshTokenExpiry() time.Duration
+	// JWKS returns the public verification keys, or nil when tokens are signed with a shared secret.
+	JWKS() *JWKSet
 }
 
 // JWTClaims contains the claims for the JWT.
@@ -24,24 +33,175 @@ type JWTClaims struct {
 	UserID string      `json:"user_id"`
 	Role   domain.Role `json:"role"`
 	jwt.RegisteredClaims
+	// Set only on impersonation tokens: the admin acting as UserID. Such tokens are read-only.
+	ImpersonatedBy string `json:"impersonated_by,omitempty"`
 }
 
+// JWK is the JSON Web Key representation of an RSA public key (RFC 7517).
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// DefaultKeyID is the "kid" of the signing secret when none is configured.
+const DefaultKeyID = "default"
+
 type jwtService struct {
 	secretKey       string
+	rsaKey          *rsa.PrivateKey // When set, tokens are signed with RS256 instead of HS256.
+	keyID           string
+	keys            map[string]interface{} // Verification keys by kid: []byte for HS256, *rsa.PublicKey for RS256.
 	issuer          string
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
 }
 
+// JWTServiceOption configures optional behaviour of the JWT service.
+type JWTServiceOption func(*jwtService)
+
+// WithKeyID names the signing key. The name is sent as the "kid" header of every token,
+// so the key can later be rotated out while the tokens it signed are still accepted.
+// An empty ID keeps the default.
+func WithKeyID(kid string) JWTServiceOption {
+	return func(s *jwtService) {
+		if kid != "" {
+			s.keyID = kid
+		}
+	}
+}
+
+// WithVerificationKey accepts tokens signed with a previous secret, identified by its kid.
+// Keep it configured for the overlap window of a rotation, i.e. until the last refresh token
+// signed with it has expired. It never replaces the current signing key.
+func WithVerificationKey(kid, secret string) JWTServiceOption {
+	return func(s *jwtService) {
+		s.keys[kid] = []byte(secret)
+	}
+}
+
+// WithRSAKey signs tokens with RS256 using the given private key instead of the shared secret,
+// so other services can verify them with the public key published by JWKS.
+func WithRSAKey(key *rsa.PrivateKey) JWTServiceOption {
+	return func(s *jwtService) {
+		s.rsaKey = key
+	}
+}
+
+// WithRSAVerificationKey accepts RS256 tokens signed with a previous private key, identified by its kid.
+func WithRSAVerificationKey(kid string, key *rsa.PublicKey) JWTServiceOption {
+	return func(s *jwtService) {
+		s.keys[kid] = key
+	}
+}
+
 // NewJWTService creates a new JWT service instance.
-func NewJWTService(secret, issuer string, accessTokenTTL, refreshTokenTTL time.Duration) JWTService {
+func NewJWTService(secret, issuer string, accessTokenTTL, refreshTokenTTL time.Duration, opts ...JWTServiceOption) JWTService {
 	fmt.Println(refreshTokenTTL)
-	return &jwtService{
+	s := &jwtService{
 		secretKey:       secret,
+		keyID:           DefaultKeyID,
+		keys:            map[string]interface{}{},
 		issuer:          issuer,
 		accessTokenTTL:  accessTokenTTL,
 		refreshTokenTTL: refreshTokenTTL,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.rsaKey != nil {
+		s.keys[s.keyID] = &s.rsaKey.PublicKey
+	} else {
+		s.keys[s.keyID] = []byte(secret)
+	}
+	return s
+}
+
+// sign signs the claims with the current key and names it in the "kid" header.
+func (s *jwtService) sign(claims *JWTClaims) (string, error) {
+	if s.rsaKey != nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = s.keyID
+		return token.SignedString(s.rsaKey)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = s.keyID
+	return token.SignedString([]byte(s.secretKey))
+}
+
+// verificationKey selects the key a token was signed with by its "kid" header. The token's
+// algorithm must match the key's type, so an RSA public key can never be used as an HMAC secret.
+func (s *jwtService) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		// Tokens issued before key IDs were introduced were signed with the current key.
+		kid = s.keyID
+	}
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key: %q", kid)
+	}
+
+	switch key.(type) {
+	case []byte:
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+			return key, nil
+		}
+	case *rsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+}
+
+// JWKS publishes the RSA public keys, including those still accepted from previous rotations.
+func (s *jwtService) JWKS() *JWKSet {
+	if s.rsaKey == nil {
+		return nil
+	}
+	set := &JWKSet{Keys: []JWK{}}
+	for kid, key := range s.keys {
+		if publicKey, ok := key.(*rsa.PublicKey); ok {
+			set.Keys = append(set.Keys, JWK{
+				KeyType:   "RSA",
+				Use:       "sig",
+				Algorithm: jwt.SigningMethodRS256.Alg(),
+				KeyID:     kid,
+				Modulus:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		}
+	}
+	// Keep the document stable between requests.
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
+
+// LoadRSAPrivateKey reads a PEM-encoded RSA private key from a file.
+func LoadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+}
+
+// LoadRSAPublicKey reads a PEM-encoded RSA public key from a file.
+func LoadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	pemBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseRSAPublicKeyFromPEM(pemBytes)
 }
 
 func (s *jwtService) GenerateAccessToken(userID string, role domain.Role) (string, *JWTClaims, error) {
@@ -55,18 +215,28 @@ func (s *jwtService) GenerateAccessToken(userID string, role domain.Role) (strin
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.secretKey))
+	tokenString, err := s.sign(claims)
+	return tokenString, claims, err
+}
+
+func (s *jwtService) GenerateImpersonationToken(userID string, role domain.Role, adminID string, ttl time.Duration) (string, *JWTClaims, error) {
+	claims := &JWTClaims{
+		UserID:         userID,
+		Role:           role,
+		ImpersonatedBy: adminID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        primitive.NewObjectID().Hex(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			Issuer:    s.issuer,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+	tokenString, err := s.sign(claims)
 	return tokenString, claims, err
 }
 
 func (s *jwtService) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, s.verificationKey)
 
 	if err != nil {
 		return nil, err
@@ -90,15 +260,12 @@ func (s *jwtService) GenerateRefreshToken(userID string) (string, *JWTClaims, er
 		},
 	}
 	fmt.Println(claims.RegisteredClaims.ExpiresAt.Date())
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.secretKey))
+	tokenString, err := s.sign(claims)
 	return tokenString, claims, err
 }
 
 func (s *jwtService) ParseExpiredToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(s.secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, s.verificationKey)
 
 	if claims, ok := token.Claims.(*JWTClaims); ok {
 		if err != nil && !errors.Is(err, jwt.ErrTokenExpired) {
@@ -3,9 +3,18 @@ package infrastructure_test
 import (
 	domain "A2SV_Starter_Project_Blog/Domain"
 	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -59,6 +68,30 @@ func TestJWTService_GenerateRefreshToken(t *testing.T) {
 	assert.WithinDuration(t, time.Now().Add(24*time.Hour), claims.ExpiresAt.Time, 1*time.Second)
 }
 
+func TestJWTService_GenerateImpersonationToken(t *testing.T) {
+	jwtService, _, _ := setupService()
+
+	tokenString, claims, err := jwtService.GenerateImpersonationToken("user-789", domain.RoleUser, "admin-1", 5*time.Minute)
+
+	require.NoError(t, err)
+	assert.Equal(t, "admin-1", claims.ImpersonatedBy)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), claims.ExpiresAt.Time, 1*time.Second)
+
+	// The claim survives the round trip, so the middleware can see it.
+	validated, err := jwtService.ValidateToken(tokenString)
+	require.NoError(t, err)
+	assert.Equal(t, "user-789", validated.UserID)
+	assert.Equal(t, domain.RoleUser, validated.Role)
+	assert.Equal(t, "admin-1", validated.ImpersonatedBy)
+
+	// Regular tokens don't carry the claim at all.
+	regular, _, err := jwtService.GenerateAccessToken("user-789", domain.RoleUser)
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(regular, jwt.MapClaims{})
+	require.NoError(t, err)
+	assert.NotContains(t, parsed.Claims.(jwt.MapClaims), "impersonated_by")
+}
+
 func TestJWTService_Validation(t *testing.T) {
 	jwtService, secret, _ := setupService()
 	otherService, _, _ := setupService() // Just to have another instance for testing
@@ -131,3 +164,217 @@ func TestJWTService_ParseExpiredToken(t *testing.T) {
 		assert.Contains(t, err.Error(), "signature is invalid")
 	})
 }
+
+func TestJWTService_KeyRotation(t *testing.T) {
+	userID := "user-rot"
+	userRole := domain.RoleUser
+	oldService := infrastructure.NewJWTService("old-secret", "test-issuer", 15*time.Minute, 24*time.Hour, infrastructure.WithKeyID("2024-01"))
+	// After rotating, the new secret signs while the old one is still accepted during the overlap window.
+	rotatedService := infrastructure.NewJWTService("new-secret", "test-issuer", 15*time.Minute, 24*time.Hour,
+		infrastructure.WithKeyID("2024-06"), infrastructure.WithVerificationKey("2024-01", "old-secret"))
+
+	t.Run("Success - New tokens carry the current kid", func(t *testing.T) {
+		tokenString, _, err := rotatedService.GenerateAccessToken(userID, userRole)
+		require.NoError(t, err)
+
+		token, _, err := jwt.NewParser().ParseUnverified(tokenString, &infrastructure.JWTClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, "2024-06", token.Header["kid"])
+
+		claims, err := rotatedService.ValidateToken(tokenString)
+		require.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+	})
+
+	t.Run("Success - Token signed with the old key still validates", func(t *testing.T) {
+		tokenString, _, err := oldService.GenerateRefreshToken(userID)
+		require.NoError(t, err)
+
+		claims, err := rotatedService.ValidateToken(tokenString)
+		require.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+	})
+
+	t.Run("Success - Expired token signed with the old key can still be refreshed", func(t *testing.T) {
+		expiredOldService := infrastructure.NewJWTService("old-secret", "test-issuer", -5*time.Minute, 24*time.Hour, infrastructure.WithKeyID("2024-01"))
+		tokenString, originalClaims, err := expiredOldService.GenerateAccessToken(userID, userRole)
+		require.NoError(t, err)
+
+		claims, err := rotatedService.ParseExpiredToken(tokenString)
+		require.NoError(t, err)
+		assert.Equal(t, originalClaims.ID, claims.ID)
+	})
+
+	t.Run("Failure - Old key is rejected once it leaves the overlap window", func(t *testing.T) {
+		retiredService := infrastructure.NewJWTService("new-secret", "test-issuer", 15*time.Minute, 24*time.Hour, infrastructure.WithKeyID("2024-06"))
+		tokenString, _, err := oldService.GenerateAccessToken(userID, userRole)
+		require.NoError(t, err)
+
+		_, err = retiredService.ValidateToken(tokenString)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown signing key")
+
+		_, err = retiredService.ParseExpiredToken(tokenString)
+		assert.Error(t, err)
+	})
+
+	t.Run("Failure - A kid cannot be paired with another secret", func(t *testing.T) {
+		// Forge a token that claims the old kid but is signed with a different secret.
+		forgedService := infrastructure.NewJWTService("forged-secret", "test-issuer", 15*time.Minute, 24*time.Hour, infrastructure.WithKeyID("2024-01"))
+		tokenString, _, err := forgedService.GenerateAccessToken(userID, domain.RoleAdmin)
+		require.NoError(t, err)
+
+		_, err = rotatedService.ValidateToken(tokenString)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "signature is invalid")
+	})
+
+	t.Run("Success - Tokens without a kid are verified with the current secret", func(t *testing.T) {
+		claims := &infrastructure.JWTClaims{
+			UserID:           userID,
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+		}
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("new-secret"))
+		require.NoError(t, err)
+
+		parsed, err := rotatedService.ValidateToken(tokenString)
+		require.NoError(t, err)
+		assert.Equal(t, userID, parsed.UserID)
+	})
+}
+
+func TestJWTService_RS256(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaService := infrastructure.NewJWTService("", "test-issuer", 15*time.Minute, 24*time.Hour,
+		infrastructure.WithKeyID("rsa-1"), infrastructure.WithRSAKey(privateKey))
+	userID := "user-rsa"
+
+	t.Run("Success - Signs and verifies with RS256", func(t *testing.T) {
+		tokenString, _, err := rsaService.GenerateAccessToken(userID, domain.RoleAdmin)
+		require.NoError(t, err)
+
+		token, _, err := jwt.NewParser().ParseUnverified(tokenString, &infrastructure.JWTClaims{})
+		require.NoError(t, err)
+		assert.Equal(t, "RS256", token.Header["alg"])
+		assert.Equal(t, "rsa-1", token.Header["kid"])
+
+		claims, err := rsaService.ValidateToken(tokenString)
+		require.NoError(t, err)
+		assert.Equal(t, userID, claims.UserID)
+		assert.Equal(t, domain.RoleAdmin, claims.Role)
+	})
+
+	t.Run("Success - Another service verifies with the published public key", func(t *testing.T) {
+		tokenString, _, err := rsaService.GenerateRefreshToken(userID)
+		require.NoError(t, err)
+
+		jwks := rsaService.JWKS()
+		require.NotNil(t, jwks)
+		require.Len(t, jwks.Keys, 1)
+		jwk := jwks.Keys[0]
+		assert.Equal(t, "RSA", jwk.KeyType)
+		assert.Equal(t, "RS256", jwk.Algorithm)
+		assert.Equal(t, "rsa-1", jwk.KeyID)
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.Modulus)
+		require.NoError(t, err)
+		e, err := base64.RawURLEncoding.DecodeString(jwk.Exponent)
+		require.NoError(t, err)
+		publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+
+		_, err = jwt.ParseWithClaims(tokenString, &infrastructure.JWTClaims{}, func(*jwt.Token) (interface{}, error) {
+			return publicKey, nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success - Parses an expired RS256 token", func(t *testing.T) {
+		expiredService := infrastructure.NewJWTService("", "test-issuer", -5*time.Minute, 24*time.Hour,
+			infrastructure.WithKeyID("rsa-1"), infrastructure.WithRSAKey(privateKey))
+		tokenString, originalClaims, err := expiredService.GenerateAccessToken(userID, domain.RoleUser)
+		require.NoError(t, err)
+
+		claims, err := rsaService.ParseExpiredToken(tokenString)
+		require.NoError(t, err)
+		assert.Equal(t, originalClaims.ID, claims.ID)
+	})
+
+	t.Run("Success - Previous RSA key is still accepted and published", func(t *testing.T) {
+		oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		oldService := infrastructure.NewJWTService("", "test-issuer", 15*time.Minute, 24*time.Hour,
+			infrastructure.WithKeyID("rsa-0"), infrastructure.WithRSAKey(oldKey))
+		rotatedService := infrastructure.NewJWTService("", "test-issuer", 15*time.Minute, 24*time.Hour,
+			infrastructure.WithKeyID("rsa-1"), infrastructure.WithRSAKey(privateKey),
+			infrastructure.WithRSAVerificationKey("rsa-0", &oldKey.PublicKey))
+
+		tokenString, _, err := oldService.GenerateAccessToken(userID, domain.RoleUser)
+		require.NoError(t, err)
+		_, err = rotatedService.ValidateToken(tokenString)
+		assert.NoError(t, err)
+
+		jwks := rotatedService.JWKS()
+		require.Len(t, jwks.Keys, 2)
+		assert.Equal(t, "rsa-0", jwks.Keys[0].KeyID)
+		assert.Equal(t, "rsa-1", jwks.Keys[1].KeyID)
+	})
+
+	t.Run("Failure - Rejects an HS256 token forged with the public key", func(t *testing.T) {
+		// The classic algorithm confusion attack: use the public key as an HMAC secret.
+		publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&privateKey.PublicKey)})
+		claims := &infrastructure.JWTClaims{
+			UserID:           userID,
+			Role:             domain.RoleAdmin,
+			RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+		}
+		forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		forged.Header["kid"] = "rsa-1"
+		tokenString, err := forged.SignedString(publicPEM)
+		require.NoError(t, err)
+
+		_, err = rsaService.ValidateToken(tokenString)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unexpected signing method")
+	})
+
+	t.Run("Failure - Rejects a token signed with another RSA key", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		otherService := infrastructure.NewJWTService("", "test-issuer", 15*time.Minute, 24*time.Hour,
+			infrastructure.WithKeyID("rsa-1"), infrastructure.WithRSAKey(otherKey))
+		tokenString, _, err := otherService.GenerateAccessToken(userID, domain.RoleAdmin)
+		require.NoError(t, err)
+
+		_, err = rsaService.ValidateToken(tokenString)
+		assert.Error(t, err)
+	})
+
+	t.Run("HS256 publishes no keys", func(t *testing.T) {
+		jwtService, _, _ := setupService()
+		assert.Nil(t, jwtService.JWKS())
+	})
+}
+
+func TestLoadRSAKeys(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	privatePath := filepath.Join(dir, "private.pem")
+	publicPath := filepath.Join(dir, "public.pem")
+	publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}), 0o600))
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600))
+
+	loadedPrivate, err := infrastructure.LoadRSAPrivateKey(privatePath)
+	require.NoError(t, err)
+	assert.True(t, privateKey.Equal(loadedPrivate))
+
+	loadedPublic, err := infrastructure.LoadRSAPublicKey(publicPath)
+	require.NoError(t, err)
+	assert.True(t, privateKey.PublicKey.Equal(loadedPublic))
+
+	_, err = infrastructure.LoadRSAPrivateKey(filepath.Join(dir, "missing.pem"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package infrastructure
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultEmailOutboxSize is how many captured emails the log-only service keeps.
+const DefaultEmailOutboxSize = 100
+
+// LogEmailService is an EmailService for development that never contacts a mail server.
+// Each email is rendered exactly as SMTP would send it, logged, and kept in memory so it can
+// be read back through the admin debug endpoint. Only the newest emails are kept.
+type LogEmailService struct {
+	mu     sync.Mutex
+	sent   []domain.SentEmail
+	maxLen int
+}
+
+// NewLogEmailService creates a log-only email service keeping up to size emails.
+// A non-positive size uses DefaultEmailOutboxSize.
+func NewLogEmailService(size int) *LogEmailService {
+	if size <= 0 {
+		size = DefaultEmailOutboxSize
+	}
+	return &LogEmailService{maxLen: size}
+}
+
+func (s *LogEmailService) SendPasswordResetEmail(toEmail, username, resetToken string) error {
+	subject, body := passwordResetEmail(username, resetToken)
+	return s.record(toEmail, subject, body)
+}
+
+func (s *LogEmailService) SendActivationEmail(toEmail, username, activationToken string) error {
+	subject, body := activationEmail(username, activationToken)
+	return s.record(toEmail, subject, body)
+}
+
+func (s *LogEmailService) SendMentionEmail(toEmail, username, mentionedBy, blogID string) error {
+	subject, body := mentionEmail(username, mentionedBy, blogID)
+	return s.record(toEmail, subject, body)
+}
+
+func (s *LogEmailService) SendNewFollowerEmail(toEmail, username, followedBy, followerID string) error {
+	subject, body := newFollowerEmail(username, followedBy, followerID)
+	return s.record(toEmail, subject, body)
+}
+
+func (s *LogEmailService) SendEmailChangeEmail(toEmail, username, changeToken string) error {
+	subject, body := emailChangeEmail(username, changeToken)
+	return s.record(toEmail, subject, body)
+}
+
+// Sent returns the captured emails, most recent first.
+func (s *LogEmailService) Sent() []domain.SentEmail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sent := make([]domain.SentEmail, len(s.sent))
+	for i, email := range s.sent {
+		sent[len(s.sent)-1-i] = email
+	}
+	return sent
+}
+
+func (s *LogEmailService) record(to, subject, body string) error {
+	// The body carries tokens, so it stays in memory rather than in the logs.
+	log.Printf("[EMAIL] Not sending %q to %s (log-only mode)", subject, to)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.sent) == s.maxLen {
+		s.sent = s.sent[1:]
+	}
+	s.sent = append(s.sent, domain.SentEmail{To: to, Subject: subject, Body: body, SentAt: time.Now().UTC()})
+	return nil
+}
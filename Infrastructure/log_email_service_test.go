@@ -0,0 +1,50 @@
+package infrastructure_test
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogEmailService_CapturesRenderedEmails(t *testing.T) {
+	svc := infrastructure.NewLogEmailService(0)
+	var _ infrastructure.EmailService = svc
+	var _ domain.IEmailOutbox = svc
+
+	require.NoError(t, svc.SendActivationEmail("alice@example.com", "Alice", "activate123"))
+	require.NoError(t, svc.SendPasswordResetEmail("john@example.com", "John", "reset123"))
+	require.NoError(t, svc.SendMentionEmail("bob@example.com", "Bob", "alice", "blog-123"))
+
+	sent := svc.Sent()
+	require.Len(t, sent, 3)
+
+	assert.Equal(t, "bob@example.com", sent[0].To, "most recent email comes first")
+	assert.Equal(t, "alice mentioned you in a comment", sent[0].Subject)
+	assert.Contains(t, sent[0].Body, "blog-123")
+
+	assert.Equal(t, "john@example.com", sent[1].To)
+	assert.Equal(t, "Reset Your Password", sent[1].Subject)
+	assert.Contains(t, sent[1].Body, "reset123")
+
+	assert.Equal(t, "alice@example.com", sent[2].To)
+	assert.Equal(t, "Activate Your Account", sent[2].Subject)
+	assert.Contains(t, sent[2].Body, "activate123")
+	assert.False(t, sent[2].SentAt.IsZero())
+}
+
+func TestLogEmailService_KeepsOnlyNewestEmails(t *testing.T) {
+	svc := infrastructure.NewLogEmailService(2)
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, svc.SendActivationEmail(fmt.Sprintf("user%d@example.com", i), "User", "token"))
+	}
+
+	sent := svc.Sent()
+	require.Len(t, sent, 2)
+	assert.Equal(t, "user3@example.com", sent[0].To)
+	assert.Equal(t, "user2@example.com", sent[1].To)
+}
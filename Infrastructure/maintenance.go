@@ -0,0 +1,75 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+// maintenanceKey holds the flag in Redis so that every instance sees the same mode.
+const maintenanceKey = "maintenance:enabled"
+
+// MaintenanceMode is a global read-only switch, used while data is being migrated.
+// It implements the domain.IMaintenanceMode interface.
+type MaintenanceMode struct {
+	redisClient *redis.Client
+}
+
+// NewMaintenanceMode creates a new MaintenanceMode instance.
+func NewMaintenanceMode(redisService *RedisService) *MaintenanceMode {
+	if redisService == nil || redisService.Client == nil {
+		log.Fatal("FATAL: RedisService is not initialized. Maintenance mode cannot be created.")
+	}
+	return &MaintenanceMode{
+		redisClient: redisService.Client,
+	}
+}
+
+// Enabled reports whether the API is currently read-only.
+func (m *MaintenanceMode) Enabled(ctx context.Context) (bool, error) {
+	err := m.redisClient.Get(ctx, maintenanceKey).Err()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// SetEnabled turns maintenance mode on or off for every instance.
+func (m *MaintenanceMode) SetEnabled(ctx context.Context, enabled bool) error {
+	if enabled {
+		return m.redisClient.Set(ctx, maintenanceKey, "1", 0).Err()
+	}
+	return m.redisClient.Del(ctx, maintenanceKey).Err()
+}
+
+// Middleware rejects writes with 503 while maintenance mode is on. Reads always pass, and so
+// do requests to exemptPaths, which must keep working for an admin to switch the mode off.
+func (m *MaintenanceMode) Middleware(exemptPaths ...string) gin.HandlerFunc {
+	exempt := make(map[string]bool, len(exemptPaths))
+	for _, path := range exemptPaths {
+		exempt[path] = true
+	}
+
+	return func(c *gin.Context) {
+		if isReadMethod(c.Request.Method) || exempt[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		enabled, err := m.Enabled(c.Request.Context())
+		if err != nil {
+			log.Printf("ERROR: Maintenance mode Redis error: %v. Allowing request.", err)
+			c.Next()
+			return
+		}
+		if enabled {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "The service is in read-only maintenance mode. Please try again later."})
+			return
+		}
+		c.Next()
+	}
+}
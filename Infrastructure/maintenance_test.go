@@ -0,0 +1,100 @@
+package infrastructure_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "A2SV_Starter_Project_Blog/Infrastructure"
+	"A2SV_Starter_Project_Blog/testhelper"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type MaintenanceModeTestSuite struct {
+	suite.Suite
+	maintenance *MaintenanceMode
+	router      *gin.Engine
+}
+
+func (s *MaintenanceModeTestSuite) SetupSuite() {
+	s.maintenance = NewMaintenanceMode(&RedisService{Client: testhelper.RedisClient})
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.router.Use(s.maintenance.Middleware("/admin/maintenance"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	s.router.GET("/blogs", ok)
+	s.router.HEAD("/blogs", ok)
+	s.router.POST("/blogs", ok)
+	s.router.PUT("/blogs/1", ok)
+	s.router.DELETE("/blogs/1", ok)
+	s.router.PUT("/admin/maintenance", ok)
+}
+
+func (s *MaintenanceModeTestSuite) SetupTest() {
+	s.Require().NoError(testhelper.RedisClient.FlushDB(context.Background()).Err())
+}
+
+func TestMaintenanceModeSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceModeTestSuite))
+}
+
+func (s *MaintenanceModeTestSuite) serve(method, path string) int {
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w.Code
+}
+
+func (s *MaintenanceModeTestSuite) TestEnabled() {
+	ctx := context.Background()
+
+	enabled, err := s.maintenance.Enabled(ctx)
+	s.NoError(err)
+	s.False(enabled, "Maintenance mode should be off until it is switched on")
+
+	s.Require().NoError(s.maintenance.SetEnabled(ctx, true))
+	enabled, err = s.maintenance.Enabled(ctx)
+	s.NoError(err)
+	s.True(enabled)
+
+	s.Require().NoError(s.maintenance.SetEnabled(ctx, false))
+	enabled, err = s.maintenance.Enabled(ctx)
+	s.NoError(err)
+	s.False(enabled)
+}
+
+func (s *MaintenanceModeTestSuite) TestMiddleware_Off() {
+	s.Equal(http.StatusOK, s.serve(http.MethodGet, "/blogs"))
+	s.Equal(http.StatusOK, s.serve(http.MethodPost, "/blogs"))
+	s.Equal(http.StatusOK, s.serve(http.MethodDelete, "/blogs/1"))
+}
+
+func (s *MaintenanceModeTestSuite) TestMiddleware_On() {
+	s.Require().NoError(s.maintenance.SetEnabled(context.Background(), true))
+
+	s.Run("Reads pass", func() {
+		s.Equal(http.StatusOK, s.serve(http.MethodGet, "/blogs"))
+		s.Equal(http.StatusOK, s.serve(http.MethodHead, "/blogs"))
+	})
+
+	s.Run("Writes are rejected", func() {
+		s.Equal(http.StatusServiceUnavailable, s.serve(http.MethodPost, "/blogs"))
+		s.Equal(http.StatusServiceUnavailable, s.serve(http.MethodPut, "/blogs/1"))
+		s.Equal(http.StatusServiceUnavailable, s.serve(http.MethodDelete, "/blogs/1"))
+	})
+
+	s.Run("Exempt paths pass", func() {
+		s.Equal(http.StatusOK, s.serve(http.MethodPut, "/admin/maintenance"))
+	})
+}
+
+func (s *MaintenanceModeTestSuite) TestMiddleware_SharedAcrossInstances() {
+	// Another instance switching the mode on is seen here, since the flag lives in Redis.
+	other := NewMaintenanceMode(&RedisService{Client: testhelper.RedisClient})
+	s.Require().NoError(other.SetEnabled(context.Background(), true))
+
+	s.Equal(http.StatusServiceUnavailable, s.serve(http.MethodPost, "/blogs"))
+}
@@ -5,27 +5,56 @@ import "golang.org/x/crypto/bcrypt"
 type PasswordService interface {
 	HashPassword(password string) (string, error)
 	ComparePassword(hashedPassword, password string) error
+	NeedsRehash(hashedPassword string) bool
 }
 
 // bcryptService is the concrete implementation of PasswordService using the bcrypt algorithm.
-// The struct is empty because the service is stateless.
+// Its only state is the cost used for new hashes.
+type bcryptService struct {
+	cost int
+}
 
-type bcryptService struct{}
+// PasswordServiceOption configures optional behaviour of the password service.
+type PasswordServiceOption func(*bcryptService)
+
+// WithCost sets the bcrypt cost used for new hashes, trading login latency for resistance to
+// brute force. Values outside bcrypt's range (4 to 31) are ignored, keeping bcrypt.DefaultCost.
+func WithCost(cost int) PasswordServiceOption {
+	return func(s *bcryptService) {
+		if cost >= bcrypt.MinCost && cost <= bcrypt.MaxCost {
+			s.cost = cost
+		}
+	}
+}
 
 // NewPasswordService creates a new instance of our password service.
-func NewPasswordService() PasswordService {
-	return &bcryptService{}
+func NewPasswordService(opts ...PasswordServiceOption) PasswordService {
+	s := &bcryptService{cost: bcrypt.DefaultCost}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // HashPassword generates a secure bcrypt hash of the password.
 func (s *bcryptService) HashPassword(password string) (string, error) {
-	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), s.cost)
 	if err != nil {
 		return "", err
 	}
 	return string(hashed), nil
 }
 
+// NeedsRehash reports whether a hash was made with a lower cost than the configured one,
+// so it should be replaced the next time the plain-text password is known.
+func (s *bcryptService) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	if err != nil {
+		return false // Not a bcrypt hash; ComparePassword would have rejected it anyway.
+	}
+	return cost < s.cost
+}
+
 // ComparePassword securely compares a hash with a plain-text password.
 // It returns nil on success or an error if they don't match.
 func (s *bcryptService) ComparePassword(hashedPassword, password string) error {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // PasswordServiceTestSuite groups tests for PasswordService
@@ -38,6 +39,50 @@ func (s *PasswordServiceTestSuite) TestHashPassword_EmptyInput() {
 	s.NoError(err) // bcrypt allows empty password, but returns a valid hash
 }
 
+func (s *PasswordServiceTestSuite) TestHashPassword_DefaultCost() {
+	hashed, err := s.passwordService.HashPassword("secure123")
+	s.Require().NoError(err)
+	cost, err := bcrypt.Cost([]byte(hashed))
+	s.Require().NoError(err)
+	s.Equal(bcrypt.DefaultCost, cost)
+}
+
+func (s *PasswordServiceTestSuite) TestHashPassword_ConfiguredCost() {
+	for _, cost := range []int{bcrypt.MinCost, 6, 12} {
+		service := infrastructure.NewPasswordService(infrastructure.WithCost(cost))
+		hashed, err := service.HashPassword("secure123")
+		s.Require().NoError(err)
+
+		actual, err := bcrypt.Cost([]byte(hashed))
+		s.Require().NoError(err)
+		s.Equal(cost, actual, "The hash should carry the configured cost")
+		s.NoError(service.ComparePassword(hashed, "secure123"))
+	}
+}
+
+func (s *PasswordServiceTestSuite) TestHashPassword_OutOfRangeCostKeepsDefault() {
+	for _, cost := range []int{0, bcrypt.MinCost - 1, bcrypt.MaxCost + 1} {
+		hashed, err := infrastructure.NewPasswordService(infrastructure.WithCost(cost)).HashPassword("secure123")
+		s.Require().NoError(err)
+
+		actual, err := bcrypt.Cost([]byte(hashed))
+		s.Require().NoError(err)
+		s.Equal(bcrypt.DefaultCost, actual)
+	}
+}
+
+func (s *PasswordServiceTestSuite) TestNeedsRehash() {
+	weakHash, err := infrastructure.NewPasswordService(infrastructure.WithCost(bcrypt.MinCost)).HashPassword("secure123")
+	s.Require().NoError(err)
+	strongHash, err := infrastructure.NewPasswordService(infrastructure.WithCost(bcrypt.MinCost + 2)).HashPassword("secure123")
+	s.Require().NoError(err)
+	service := infrastructure.NewPasswordService(infrastructure.WithCost(bcrypt.MinCost + 1))
+
+	s.True(service.NeedsRehash(weakHash), "A hash below the configured cost should be upgraded")
+	s.False(service.NeedsRehash(strongHash), "A hash above the configured cost must not be downgraded")
+	s.False(service.NeedsRehash("not-a-bcrypt-hash"))
+}
+
 func TestPasswordServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(PasswordServiceTestSuite))
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package infrastructure
 
 import (
+	domain "A2SV_Starter_Project_Blog/Domain"
 	"fmt"
 	"log"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 // It can handle different limits for authenticated users and anonymous IPs.
 type RateLimiter struct {
 	redisClient *redis.Client
+	now         func() time.Time
 }
 
 // NewRateLimiter creates a new RateLimiter instance.
@@ -24,14 +26,32 @@ func NewRateLimiter(redisService *RedisService) *RateLimiter {
 	}
 	return &RateLimiter{
 		redisClient: redisService.Client,
+		now:         time.Now,
 	}
 }
 
 // LimiterMiddleware returns a Gin middleware handler with the specified rate limit.
+// Admins are not limited, so their bulk operations aren't throttled. Their role is only
+// known when the limiter runs after AuthMiddleware; before it, admins count as anonymous.
 func (rl *RateLimiter) LimiterMiddleware(limit int64, period time.Duration, userIDKey string) gin.HandlerFunc {
+	return rl.ScopedLimiterMiddleware("", limit, period, userIDKey)
+}
+
+// ScopedLimiterMiddleware works like LimiterMiddleware, but counts requests separately for each
+// scope. A route can then get its own limit on top of the one its group already applies.
+func (rl *RateLimiter) ScopedLimiterMiddleware(scope string, limit int64, period time.Duration, userIDKey string) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if role, _ := c.Get("userRole"); role == domain.RoleAdmin {
+			c.Next()
+			return
+		}
+
 		key := rl.getKey(c, userIDKey)
-		now := time.Now().UnixNano()
+		current := rl.now()
+		now := current.UnixNano()
+		if scope != "" {
+			key = scope + ":" + key
+		}
 		key = fmt.Sprintf("rate-limit:%s", key)
 
 		// Use a Redis pipeline for atomic and efficient operations.
@@ -59,22 +79,23 @@ func (rl *RateLimiter) LimiterMiddleware(limit int64, period time.Duration, user
 			return
 		}
 
+		// The window resets when the oldest request in it falls out.
+		resetAt := time.Unix(0, now).Add(period)
+		if oldestTimestamps, err := oldestTimestampCmd.Result(); err == nil && len(oldestTimestamps) > 0 {
+			resetAt = time.Unix(0, int64(oldestTimestamps[0].Score)).Add(period)
+		}
+
+		remaining := limit - count
+		if remaining < 0 {
+			remaining = 0
+		}
+		c.Header("X-RateLimit-Limit", strconv.FormatInt(limit, 10))
+		c.Header("X-RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(resetAt.Unix(), 10))
+
 		if count > limit {
-			// Get the result of our command to find the oldest timestamp.
-			oldestTimestamps, _ := oldestTimestampCmd.Result()
-
-			var retryAfter time.Duration
-			if len(oldestTimestamps) > 0 {
-				// The oldest request timestamp in the current window.
-				oldestReqNano := int64(oldestTimestamps[0].Score)
-				// The time when this oldest request will fall out of the window.
-				windowResetTime := time.Unix(0, oldestReqNano).Add(period)
-				// The duration from now until that reset time.
-				retryAfter = time.Until(windowResetTime)
-			} else {
-				// Fallback if we can't determine the exact time.
-				retryAfter = period
-			}
+			// The duration from now until the window resets.
+			retryAfter := resetAt.Sub(current)
 
 			// Add the 'Retry-After' header (in seconds), which is a standard.
 			c.Header("Retry-After", strconv.FormatInt(int64(retryAfter.Seconds())+1, 10))
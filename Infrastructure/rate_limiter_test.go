@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
+	domain "A2SV_Starter_Project_Blog/Domain"
 	. "A2SV_Starter_Project_Blog/Infrastructure"
 	"A2SV_Starter_Project_Blog/testhelper"
 
@@ -19,6 +21,7 @@ import (
 type RateLimiterTestSuite struct {
 	suite.Suite
 	rateLimiter *RateLimiter
+	clock       time.Time
 }
 
 // SetupSuite starts a Redis container and creates a RateLimiter instance for the suite.
@@ -31,6 +34,9 @@ func (s *RateLimiterTestSuite) SetupTest() {
 	// Flush the entire Redis database to ensure tests are isolated from each other.
 	err := testhelper.RedisClient.FlushDB(context.Background()).Err()
 	s.Require().NoError(err)
+	// Requests are served one at a time, so tests can move the clock between them.
+	s.clock = time.Now()
+	s.rateLimiter.SetClock(func() time.Time { return s.clock })
 }
 
 // TestRateLimiterSuite is the entry point for the suite.
@@ -154,8 +160,8 @@ func (s *RateLimiterTestSuite) TestLimiterMiddleware_ResetsAfterPeriod() {
 	router.ServeHTTP(w2, req2)
 	s.Equal(http.StatusTooManyRequests, w2.Code)
 
-	// Act: Wait for the period to expire
-	time.Sleep(2 * time.Second)
+	// Act: Let the period expire
+	s.clock = s.clock.Add(2 * time.Second)
 
 	// Act & Assert: A new request should now be allowed
 	w3 := httptest.NewRecorder()
@@ -163,3 +169,117 @@ func (s *RateLimiterTestSuite) TestLimiterMiddleware_ResetsAfterPeriod() {
 	router.ServeHTTP(w3, req3)
 	s.Equal(http.StatusOK, w3.Code, "Request should be allowed after the time period has reset")
 }
+
+func (s *RateLimiterTestSuite) TestLimiterMiddleware_SetsRateLimitHeaders() {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	limiterMiddleware := s.rateLimiter.LimiterMiddleware(3, time.Minute, "userID")
+	router.GET("/test", limiterMiddleware, func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// Act & Assert: Remaining counts down with each allowed request.
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		router.ServeHTTP(w, req)
+		s.Equal(http.StatusOK, w.Code)
+		s.Equal("3", w.Header().Get("X-RateLimit-Limit"))
+		s.Equal(strconv.Itoa(2-i), w.Header().Get("X-RateLimit-Remaining"), fmt.Sprintf("Request #%d", i+1))
+
+		reset, err := strconv.ParseInt(w.Header().Get("X-RateLimit-Reset"), 10, 64)
+		s.Require().NoError(err)
+		s.Greater(reset, time.Now().Unix(), "Reset should be in the future")
+		s.LessOrEqual(reset, time.Now().Add(time.Minute).Unix())
+		s.Empty(w.Header().Get("Retry-After"), "Retry-After is only sent when throttled")
+	}
+
+	// Act & Assert: The throttled response carries the same headers plus Retry-After.
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+	router.ServeHTTP(w, req)
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.Equal("3", w.Header().Get("X-RateLimit-Limit"))
+	s.Equal("0", w.Header().Get("X-RateLimit-Remaining"))
+	s.NotEmpty(w.Header().Get("X-RateLimit-Reset"))
+	retryAfter, err := strconv.Atoi(w.Header().Get("Retry-After"))
+	s.Require().NoError(err)
+	s.Greater(retryAfter, 0)
+	s.LessOrEqual(retryAfter, 61)
+}
+
+func (s *RateLimiterTestSuite) TestLimiterMiddleware_AdminsBypassLimit() {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	limiterMiddleware := s.rateLimiter.LimiterMiddleware(1, time.Minute, "userID")
+	// serve sends one request as the given user and role, as AuthMiddleware would set them.
+	serve := func(userID string, role domain.Role) *httptest.ResponseRecorder {
+		router := gin.New()
+		router.GET("/test", func(c *gin.Context) {
+			if userID != "" {
+				c.Set("userID", userID)
+				c.Set("userRole", role)
+			}
+		}, limiterMiddleware, func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	s.Run("Admin is never throttled", func() {
+		for i := range 5 {
+			w := serve("admin-1", domain.RoleAdmin)
+			s.Equal(http.StatusOK, w.Code, fmt.Sprintf("Admin request #%d should be allowed", i+1))
+			s.Empty(w.Header().Get("X-RateLimit-Limit"), "Admin requests are not counted")
+		}
+	})
+
+	s.Run("User is throttled", func() {
+		s.Equal(http.StatusOK, serve("user-1", domain.RoleUser).Code)
+		s.Equal(http.StatusTooManyRequests, serve("user-1", domain.RoleUser).Code)
+	})
+
+	s.Run("Anonymous is throttled", func() {
+		s.Equal(http.StatusOK, serve("", "").Code)
+		s.Equal(http.StatusTooManyRequests, serve("", "").Code)
+	})
+}
+
+func (s *RateLimiterTestSuite) TestScopedLimiterMiddleware() {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	setUser := func(c *gin.Context) { c.Set("userID", "user-1") }
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	// The general limit is shared by every route; /suggest adds a tighter one of its own.
+	general := s.rateLimiter.LimiterMiddleware(10, time.Minute, "userID")
+	suggest := s.rateLimiter.ScopedLimiterMiddleware("ai-suggest", 2, 1*time.Second, "userID")
+	router.POST("/suggest", setUser, general, suggest, ok)
+	router.POST("/generate", setUser, general, ok)
+
+	send := func(path string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, path, nil)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	s.Run("Scope has its own limit", func() {
+		s.Equal(http.StatusOK, send("/suggest"))
+		s.Equal(http.StatusOK, send("/suggest"))
+		s.Equal(http.StatusTooManyRequests, send("/suggest"))
+	})
+
+	s.Run("Other routes are not affected", func() {
+		s.Equal(http.StatusOK, send("/generate"))
+	})
+
+	s.Run("Scope resets after its period", func() {
+		s.clock = s.clock.Add(1100 * time.Millisecond)
+		s.Equal(http.StatusOK, send("/suggest"))
+	})
+}
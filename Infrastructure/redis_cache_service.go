@@ -4,6 +4,7 @@ import (
 	domain "A2SV_Starter_Project_Blog/Domain"
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -22,6 +23,7 @@ func NewRedisCacheService(redisService *RedisService) domain.ICacheService {
 }
 
 // Get retrieves an item from the Redis cache.
+// A missing key returns domain.ErrNotFound; any other failure is wrapped in domain.ErrCacheUnavailable.
 func (s *RedisCacheService) Get(ctx context.Context, key string) ([]byte, error) {
 	val, err := s.client.Get(ctx, key).Bytes()
 	if err != nil {
@@ -29,7 +31,19 @@ func (s *RedisCacheService) Get(ctx context.Context, key string) ([]byte, error)
 		if errors.Is(err, redis.Nil) {
 			return nil, domain.ErrNotFound
 		}
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", domain.ErrCacheUnavailable, err)
+	}
+	return val, nil
+}
+
+// GetAndDelete retrieves an item and removes it in a single atomic GETDEL. It reports errors like Get.
+func (s *RedisCacheService) GetAndDelete(ctx context.Context, key string) ([]byte, error) {
+	val, err := s.client.GetDel(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("%w: %w", domain.ErrCacheUnavailable, err)
 	}
 	return val, nil
 }
@@ -39,6 +53,11 @@ func (s *RedisCacheService) Set(ctx context.Context, key string, value []byte, e
 	return s.client.Set(ctx, key, value, expiration).Err()
 }
 
+// SetIfAbsent adds an item only if the key is not already set, in a single atomic SETNX.
+func (s *RedisCacheService) SetIfAbsent(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, key, value, expiration).Result()
+}
+
 // Delete removes an item from the Redis cache.
 func (s *RedisCacheService) Delete(ctx context.Context, key string) error {
 	return s.client.Del(ctx, key).Err()
@@ -2,6 +2,7 @@ package infrastructure_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -74,6 +75,49 @@ func (s *RedisCacheServiceTestSuite) TestGet_NotFound() {
 	s.Nil(retrievedValue, "Value should be nil for a missing key")
 }
 
+// failingHook short-circuits every command with a fixed error, so Get's error
+// translation can be tested without depending on the state of a real server.
+type failingHook struct {
+	err error
+}
+
+func (h failingHook) BeforeProcess(ctx context.Context, _ redis.Cmder) (context.Context, error) {
+	return ctx, h.err
+}
+func (h failingHook) AfterProcess(context.Context, redis.Cmder) error { return nil }
+func (h failingHook) BeforeProcessPipeline(ctx context.Context, _ []redis.Cmder) (context.Context, error) {
+	return ctx, h.err
+}
+func (h failingHook) AfterProcessPipeline(context.Context, []redis.Cmder) error { return nil }
+
+func newFailingCacheService(err error) domain.ICacheService {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:0"})
+	client.AddHook(failingHook{err: err})
+	return NewRedisCacheService(&RedisService{Client: client})
+}
+
+func (s *RedisCacheServiceTestSuite) TestGet_RedisNilMapsToNotFound() {
+	cacheService := newFailingCacheService(redis.Nil)
+
+	value, err := cacheService.Get(context.Background(), "any-key")
+
+	s.ErrorIs(err, domain.ErrNotFound)
+	s.NotErrorIs(err, domain.ErrCacheUnavailable, "A miss must not look like a backend failure")
+	s.Nil(value)
+}
+
+func (s *RedisCacheServiceTestSuite) TestGet_BackendErrorIsWrapped() {
+	backendErr := errors.New("dial tcp: connection refused")
+	cacheService := newFailingCacheService(backendErr)
+
+	value, err := cacheService.Get(context.Background(), "any-key")
+
+	s.ErrorIs(err, domain.ErrCacheUnavailable)
+	s.ErrorIs(err, backendErr, "The original error should be preserved")
+	s.NotErrorIs(err, domain.ErrNotFound, "A backend failure must not look like a miss")
+	s.Nil(value)
+}
+
 func (s *RedisCacheServiceTestSuite) TestDelete() {
 	ctx := context.Background()
 	key := "test:to-be-deleted"
@@ -96,6 +140,32 @@ func (s *RedisCacheServiceTestSuite) TestDelete() {
 	s.ErrorIs(getErrAfterDelete, domain.ErrNotFound, "Key should not exist after deletion")
 }
 
+func (s *RedisCacheServiceTestSuite) TestGetAndDelete() {
+	ctx := context.Background()
+	key := "test:single-use"
+	s.Require().NoError(s.cacheService.Set(ctx, key, []byte("once"), time.Minute))
+
+	// Act: The first read gets the value and removes it.
+	value, err := s.cacheService.GetAndDelete(ctx, key)
+	s.Require().NoError(err)
+	s.Equal([]byte("once"), value)
+
+	// Assert: A second read finds nothing.
+	value, err = s.cacheService.GetAndDelete(ctx, key)
+	s.ErrorIs(err, domain.ErrNotFound)
+	s.Nil(value)
+}
+
+func (s *RedisCacheServiceTestSuite) TestGetAndDelete_BackendErrorIsWrapped() {
+	backendErr := errors.New("dial tcp: connection refused")
+	cacheService := newFailingCacheService(backendErr)
+
+	_, err := cacheService.GetAndDelete(context.Background(), "any-key")
+
+	s.ErrorIs(err, domain.ErrCacheUnavailable)
+	s.ErrorIs(err, backendErr)
+}
+
 func (s *RedisCacheServiceTestSuite) TestSet_WithZeroExpiration() {
 	ctx := context.Background()
 	key := "test:persistent-key"
@@ -131,6 +201,26 @@ func (s *RedisCacheServiceTestSuite) TestSet_ReplacesExistingValue() {
 	s.Equal(newValue, retrievedValue, "The value should be overwritten with the new value")
 }
 
+func (s *RedisCacheServiceTestSuite) TestSetIfAbsent() {
+	ctx := context.Background()
+	key := "test:set-if-absent"
+
+	// Act: The first call claims the key.
+	set, err := s.cacheService.SetIfAbsent(ctx, key, []byte("first"), time.Minute)
+	s.Require().NoError(err)
+	s.True(set, "An absent key should be set")
+
+	// Act: A second call must leave the existing value alone.
+	set, err = s.cacheService.SetIfAbsent(ctx, key, []byte("second"), time.Minute)
+	s.Require().NoError(err)
+	s.False(set, "An existing key should not be overwritten")
+
+	value, err := s.cacheService.Get(ctx, key)
+	s.Require().NoError(err)
+	s.Equal([]byte("first"), value)
+	s.InDelta(time.Minute, s.redisClient.TTL(ctx, key).Val(), float64(2*time.Second), "The TTL should be set with the value")
+}
+
 func (s *RedisCacheServiceTestSuite) TestAddToSetAndGetSetMembers() {
 	ctx := context.Background()
 	setKey := "test:my-set"
@@ -0,0 +1,280 @@
+package infrastructure
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"sync"
+	"time"
+
+	"gopkg.in/gomail.v2"
+)
+
+// SMTPTLSMode selects how the connection to the mail server is secured.
+type SMTPTLSMode string
+
+const (
+	// SMTPTLSStartTLS connects in plain text and upgrades with STARTTLS, which the server must offer.
+	SMTPTLSStartTLS SMTPTLSMode = "starttls"
+	// SMTPTLSImplicit speaks TLS from the first byte, as mail servers on port 465 expect.
+	SMTPTLSImplicit SMTPTLSMode = "implicit"
+	// SMTPTLSNone sends everything in plain text. Only meant for local test servers.
+	SMTPTLSNone SMTPTLSMode = "none"
+)
+
+const (
+	DefaultSMTPDialTimeout = 10 * time.Second
+	DefaultSMTPSendTimeout = 30 * time.Second
+	DefaultSMTPIdleTimeout = 30 * time.Second
+	DefaultSMTPPoolSize    = 2
+)
+
+// SMTPOption configures optional behaviour of the SMTP email service.
+type SMTPOption func(*smtpPool)
+
+// WithSMTPTimeouts bounds how long connecting (including greeting, STARTTLS and AUTH) and
+// sending a single message may take, so a slow mail server can't hang the caller.
+// Non-positive values keep the defaults.
+func WithSMTPTimeouts(dial, send time.Duration) SMTPOption {
+	return func(p *smtpPool) {
+		if dial > 0 {
+			p.dialTimeout = dial
+		}
+		if send > 0 {
+			p.sendTimeout = send
+		}
+	}
+}
+
+// WithSMTPTLSMode picks STARTTLS, implicit TLS or no TLS. An empty mode keeps the default,
+// which is implicit TLS on port 465 and STARTTLS everywhere else.
+func WithSMTPTLSMode(mode SMTPTLSMode) SMTPOption {
+	return func(p *smtpPool) {
+		if mode != "" {
+			p.tlsMode = mode
+		}
+	}
+}
+
+// WithSMTPPool keeps up to size authenticated connections open between sends, closing any
+// that sat unused for longer than idleTimeout. A size of zero dials afresh for every send.
+func WithSMTPPool(size int, idleTimeout time.Duration) SMTPOption {
+	return func(p *smtpPool) {
+		if size >= 0 {
+			p.size = size
+		}
+		if idleTimeout > 0 {
+			p.idleTimeout = idleTimeout
+		}
+	}
+}
+
+// smtpPool implements the dialer interface on top of net/smtp, reusing connections across sends.
+type smtpPool struct {
+	host        string
+	addr        string
+	username    string
+	password    string
+	tlsMode     SMTPTLSMode
+	tlsConfig   *tls.Config
+	dialTimeout time.Duration
+	sendTimeout time.Duration
+	idleTimeout time.Duration
+	size        int
+
+	mu   sync.Mutex
+	idle []*smtpConn
+}
+
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+func newSMTPPool(host string, port int, username, password string, opts ...SMTPOption) *smtpPool {
+	p := &smtpPool{
+		host:        host,
+		addr:        net.JoinHostPort(host, strconv.Itoa(port)),
+		username:    username,
+		password:    password,
+		tlsMode:     SMTPTLSStartTLS,
+		tlsConfig:   &tls.Config{ServerName: host},
+		dialTimeout: DefaultSMTPDialTimeout,
+		sendTimeout: DefaultSMTPSendTimeout,
+		idleTimeout: DefaultSMTPIdleTimeout,
+		size:        DefaultSMTPPoolSize,
+	}
+	if port == 465 {
+		p.tlsMode = SMTPTLSImplicit
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// DialAndSend sends the messages over a pooled connection, dialing a new one if none is usable.
+// A connection that fails mid-send is dropped rather than returned to the pool.
+func (p *smtpPool) DialAndSend(msgs ...*gomail.Message) error {
+	c, err := p.get()
+	if err != nil {
+		return err
+	}
+	for _, m := range msgs {
+		if err := c.send(m, p.sendTimeout); err != nil {
+			c.client.Close()
+			return err
+		}
+	}
+	p.put(c)
+	return nil
+}
+
+// get hands out the most recently used idle connection that is still alive, or dials a new one.
+func (p *smtpPool) get() (*smtpConn, error) {
+	for {
+		p.mu.Lock()
+		if len(p.idle) == 0 {
+			p.mu.Unlock()
+			return p.dial()
+		}
+		c := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		p.mu.Unlock()
+
+		if time.Since(c.lastUsed) > p.idleTimeout {
+			c.quit()
+			continue
+		}
+		// The server may have hung up on an idle connection; probe it before trusting it with a message.
+		c.conn.SetDeadline(time.Now().Add(p.sendTimeout))
+		if err := c.client.Noop(); err != nil {
+			c.client.Close()
+			continue
+		}
+		return c, nil
+	}
+}
+
+func (p *smtpPool) put(c *smtpConn) {
+	c.lastUsed = time.Now()
+	p.mu.Lock()
+	if len(p.idle) < p.size {
+		p.idle = append(p.idle, c)
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+	c.quit()
+}
+
+func (p *smtpPool) dial() (*smtpConn, error) {
+	dialer := &net.Dialer{Timeout: p.dialTimeout}
+	var conn net.Conn
+	var err error
+	switch p.tlsMode {
+	case SMTPTLSImplicit:
+		conn, err = tls.DialWithDialer(dialer, "tcp", p.addr, p.tlsConfig)
+	case SMTPTLSStartTLS, SMTPTLSNone:
+		conn, err = dialer.Dial("tcp", p.addr)
+	default:
+		return nil, fmt.Errorf("smtp: unknown TLS mode %q", p.tlsMode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The greeting, STARTTLS and AUTH share the dial timeout, so a server that accepts the
+	// connection but never answers can't hold the sender forever.
+	conn.SetDeadline(time.Now().Add(p.dialTimeout))
+	client, err := smtp.NewClient(conn, p.host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if p.tlsMode == SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, errors.New("smtp: server does not support STARTTLS")
+		}
+		if err := client.StartTLS(p.tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	if p.username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			if err := client.Auth(smtp.PlainAuth("", p.username, p.password, p.host)); err != nil {
+				client.Close()
+				return nil, err
+			}
+		}
+	}
+	return &smtpConn{conn: conn, client: client}, nil
+}
+
+func (c *smtpConn) send(m *gomail.Message, timeout time.Duration) error {
+	from, rcpts, err := envelope(m)
+	if err != nil {
+		return err
+	}
+
+	c.conn.SetDeadline(time.Now().Add(timeout))
+	if err := c.client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range rcpts {
+		if err := c.client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := m.WriteTo(w); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// quit says goodbye politely, but never waits long for the server to acknowledge it.
+func (c *smtpConn) quit() {
+	c.conn.SetDeadline(time.Now().Add(time.Second))
+	if err := c.client.Quit(); err != nil {
+		c.client.Close()
+	}
+}
+
+// envelope extracts the sender and recipient addresses from the message headers.
+func envelope(m *gomail.Message) (string, []string, error) {
+	froms := m.GetHeader("From")
+	if len(froms) == 0 {
+		return "", nil, errors.New("smtp: message has no From header")
+	}
+	from, err := mail.ParseAddress(froms[0])
+	if err != nil {
+		return "", nil, err
+	}
+
+	var rcpts []string
+	for _, field := range []string{"To", "Cc"} {
+		for _, value := range m.GetHeader(field) {
+			addr, err := mail.ParseAddress(value)
+			if err != nil {
+				return "", nil, err
+			}
+			rcpts = append(rcpts, addr.Address)
+		}
+	}
+	if len(rcpts) == 0 {
+		return "", nil, errors.New("smtp: message has no recipients")
+	}
+	return from.Address, rcpts, nil
+}
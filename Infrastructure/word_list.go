@@ -0,0 +1,24 @@
+package infrastructure
+
+import (
+	"os"
+	"strings"
+)
+
+// LoadWordList reads a word list file with one entry per line.
+// Blank lines and lines starting with # are skipped.
+func LoadWordList(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var words []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words, nil
+}
@@ -0,0 +1,24 @@
+package infrastructure_test
+
+import (
+	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadWordList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# Banned words\ndarn\n\n  heck  \r\n#gosh\n"), 0o600))
+
+	words, err := infrastructure.LoadWordList(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"darn", "heck"}, words)
+
+	_, err = infrastructure.LoadWordList(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
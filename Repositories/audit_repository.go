@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditEntryModel is how an audit log entry is stored in MongoDB.
+// Targets may be users or blogs, so IDs are kept as plain strings.
+type AuditEntryModel struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	ActorID   string             `bson:"actor_id"`
+	Action    string             `bson:"action"`
+	TargetID  string             `bson:"target_id,omitempty"`
+	Timestamp time.Time          `bson:"timestamp"`
+	Details   map[string]string  `bson:"details,omitempty"`
+}
+
+// AuditRepository implements the domain.IAuditRepository interface.
+// Entries are only ever appended; there is deliberately no update or delete.
+type AuditRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAuditRepository is the constructor for the audit log repository.
+func NewAuditRepository(col *mongo.Collection) *AuditRepository {
+	return &AuditRepository{
+		collection: col,
+	}
+}
+
+func (r *AuditRepository) CreateAuditIndexes(ctx context.Context) error {
+	// The log is browsed newest first, usually narrowed to one admin or one kind of action.
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "timestamp", Value: -1}}},
+		{Keys: bson.D{{Key: "action", Value: 1}, {Key: "timestamp", Value: -1}}},
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// --- Interface Implementations ---
+
+func (r *AuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	model := fromAuditEntryDomain(entry)
+	model.ID = primitive.NewObjectID()
+
+	if _, err := r.collection.InsertOne(ctx, model); err != nil {
+		return err
+	}
+
+	entry.ID = model.ID.Hex()
+	return nil
+}
+
+// Search returns a page of matching entries, newest first, and the total number of matches.
+func (r *AuditRepository) Search(ctx context.Context, opts domain.AuditFilterOptions) ([]*domain.AuditEntry, int64, error) {
+	filter := bson.M{}
+	if opts.ActorID != nil {
+		filter["actor_id"] = *opts.ActorID
+	}
+	if opts.Action != nil {
+		filter["action"] = string(*opts.Action)
+	}
+	dateFilter := bson.M{}
+	if opts.StartDate != nil {
+		dateFilter["$gte"] = *opts.StartDate
+	}
+	if opts.EndDate != nil {
+		dateFilter["$lte"] = *opts.EndDate
+	}
+	if len(dateFilter) > 0 {
+		filter["timestamp"] = dateFilter
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip((opts.Page - 1) * opts.Limit).
+		SetLimit(opts.Limit)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []*domain.AuditEntry{}
+	for cursor.Next(ctx) {
+		var model AuditEntryModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, toAuditEntryDomain(&model))
+	}
+	return entries, total, cursor.Err()
+}
+
+// --- Mapper Functions ---
+
+func toAuditEntryDomain(model *AuditEntryModel) *domain.AuditEntry {
+	return &domain.AuditEntry{
+		ID:        model.ID.Hex(),
+		ActorID:   model.ActorID,
+		Action:    domain.AuditAction(model.Action),
+		TargetID:  model.TargetID,
+		Timestamp: model.Timestamp,
+		Details:   model.Details,
+	}
+}
+
+func fromAuditEntryDomain(entry *domain.AuditEntry) *AuditEntryModel {
+	return &AuditEntryModel{
+		ActorID:   entry.ActorID,
+		Action:    string(entry.Action),
+		TargetID:  entry.TargetID,
+		Timestamp: entry.Timestamp,
+		Details:   entry.Details,
+	}
+}
@@ -0,0 +1,88 @@
+package repositories_test
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	. "A2SV_Starter_Project_Blog/Repositories"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// AuditRepositoryTestSuite defines the suite for the audit log repository integration tests.
+type AuditRepositoryTestSuite struct {
+	suite.Suite
+	repo           *AuditRepository
+	collectionName string
+}
+
+func (s *AuditRepositoryTestSuite) SetupTest() {
+	s.collectionName = "audit_log_test"
+	s.repo = NewAuditRepository(testDB.Collection(s.collectionName))
+}
+
+func (s *AuditRepositoryTestSuite) TearDownTest() {
+	err := testDB.Collection(s.collectionName).Drop(context.Background())
+	s.Require().NoError(err, "Failed to drop test collection")
+}
+
+func TestAuditRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode.")
+	}
+	t.Parallel()
+	suite.Run(t, new(AuditRepositoryTestSuite))
+}
+
+func (s *AuditRepositoryTestSuite) TestCreateAndSearch() {
+	ctx := context.Background()
+	base := time.Now().UTC().Add(-time.Hour).Truncate(time.Millisecond)
+	entries := []*domain.AuditEntry{
+		{ActorID: "admin-1", Action: domain.AuditActionRoleChange, TargetID: "user-1", Timestamp: base, Details: map[string]string{"from": "user", "to": "admin"}},
+		{ActorID: "admin-1", Action: domain.AuditActionRevokeTokens, TargetID: "user-2", Timestamp: base.Add(time.Minute)},
+		{ActorID: "admin-2", Action: domain.AuditActionRoleChange, TargetID: "user-3", Timestamp: base.Add(2 * time.Minute)},
+	}
+	for _, e := range entries {
+		s.Require().NoError(s.repo.Create(ctx, e))
+		s.Require().NotEmpty(e.ID)
+	}
+
+	s.Run("All entries, newest first", func() {
+		found, total, err := s.repo.Search(ctx, domain.AuditFilterOptions{Page: 1, Limit: 10})
+		s.Require().NoError(err)
+		s.Equal(int64(3), total)
+		s.Require().Len(found, 3)
+		s.Equal(entries[2].ID, found[0].ID)
+		s.Equal(entries[0].ID, found[2].ID)
+		s.Equal("admin", found[2].Details["to"])
+	})
+
+	s.Run("By actor and action", func() {
+		actor := "admin-1"
+		action := domain.AuditActionRoleChange
+		found, total, err := s.repo.Search(ctx, domain.AuditFilterOptions{ActorID: &actor, Action: &action, Page: 1, Limit: 10})
+		s.Require().NoError(err)
+		s.Equal(int64(1), total)
+		s.Require().Len(found, 1)
+		s.Equal("user-1", found[0].TargetID)
+	})
+
+	s.Run("By date range", func() {
+		start := base.Add(30 * time.Second)
+		end := base.Add(90 * time.Second)
+		found, total, err := s.repo.Search(ctx, domain.AuditFilterOptions{StartDate: &start, EndDate: &end, Page: 1, Limit: 10})
+		s.Require().NoError(err)
+		s.Equal(int64(1), total)
+		s.Require().Len(found, 1)
+		s.Equal(domain.AuditActionRevokeTokens, found[0].Action)
+	})
+
+	s.Run("Paginated", func() {
+		found, total, err := s.repo.Search(ctx, domain.AuditFilterOptions{Page: 2, Limit: 2})
+		s.Require().NoError(err)
+		s.Equal(int64(3), total)
+		s.Require().Len(found, 1)
+		s.Equal(entries[0].ID, found[0].ID)
+	})
+}
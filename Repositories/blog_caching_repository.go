@@ -2,28 +2,63 @@ package repositories
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	domain "A2SV_Starter_Project_Blog/Domain"
 )
 
+const (
+	// searchTrackerKey names the set of cached search pages in content order, e.g. by date or title.
+	searchTrackerKey = "tracker:blogs:search"
+	// engagementSearchTrackerKey names the set of cached pages ordered by engagement, which likes,
+	// dislikes and comments reshuffle even when no blog was edited.
+	engagementSearchTrackerKey = "tracker:blogs:search:engagement"
+)
+
+// paginatedBlogResult is a cached page of search results.
+type paginatedBlogResult struct {
+	Blogs []*domain.Blog `json:"blogs"`
+	Total int64          `json:"total"`
+}
+
 type CachingBlogRepository struct {
 	next       domain.IBlogRepository
 	cache      domain.ICacheService
 	defaultTTL time.Duration
+	// searchTTL is how long a page of search results is cached. Zero disables search caching.
+	searchTTL time.Duration
+}
+
+// CachingBlogOption configures optional behaviour of the caching blog repository.
+type CachingBlogOption func(*CachingBlogRepository)
+
+// WithSearchCacheTTL caches SearchAndFilter pages for ttl. Any blog mutation clears them early.
+// Keep it short: engagement counters in cached pages only catch up when the entry expires.
+func WithSearchCacheTTL(ttl time.Duration) CachingBlogOption {
+	return func(r *CachingBlogRepository) {
+		r.searchTTL = ttl
+	}
 }
 
 // NewCachingBlogRepository creates a new caching decorator for the blog repository.
-func NewCachingBlogRepository(next domain.IBlogRepository, cache domain.ICacheService) domain.IBlogRepository {
-	return &CachingBlogRepository{
+// Search results are only cached when enabled with WithSearchCacheTTL.
+func NewCachingBlogRepository(next domain.IBlogRepository, cache domain.ICacheService, opts ...CachingBlogOption) domain.IBlogRepository {
+	r := &CachingBlogRepository{
 		next:       next,
 		cache:      cache,
 		defaultTTL: 5 * time.Minute, // Cache a blog post for 5 minutes
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // GetByID is the primary method we will cache.
@@ -33,14 +68,19 @@ func (r *CachingBlogRepository) GetByID(ctx context.Context, id string) (*domain
 
 	// 2. Try to fetch from the cache.
 	cachedBlog, err := r.cache.Get(ctx, cacheKey)
-	if err == nil {
+	switch {
+	case err == nil:
 		// Cache HIT!
 		var blog domain.Blog
-		if json.Unmarshal(cachedBlog, &blog) == nil {
+		jsonErr := json.Unmarshal(cachedBlog, &blog)
+		if jsonErr == nil {
 			return &blog, nil
 		}
-	}
-	if !errors.Is(err, domain.ErrNotFound) {
+		log.Printf("[CACHE] Discarding corrupt blog entry for key %s: %v", cacheKey, jsonErr)
+	case errors.Is(err, domain.ErrNotFound):
+		// Plain cache miss.
+	default:
+		// The cache is unavailable. Fail open and serve the request from the database.
 		log.Printf("[CACHE] Error getting blog from cache: %v", err)
 	}
 
@@ -64,6 +104,125 @@ func (r *CachingBlogRepository) GetByID(ctx context.Context, id string) (*domain
 	return blog, nil
 }
 
+// SearchAndFilter caches each page of results under a hash of the normalized options. Listings
+// that include drafts depend on who is asking, so they are never cached.
+func (r *CachingBlogRepository) SearchAndFilter(ctx context.Context, opts domain.BlogSearchFilterOptions) ([]*domain.Blog, int64, error) {
+	if r.searchTTL <= 0 || opts.IncludeDrafts {
+		return r.next.SearchAndFilter(ctx, opts)
+	}
+	cacheKey, err := searchCacheKey(opts)
+	if err != nil {
+		log.Printf("[CACHE] Error building search cache key: %v", err)
+		return r.next.SearchAndFilter(ctx, opts)
+	}
+
+	cachedData, err := r.cache.Get(ctx, cacheKey)
+	switch {
+	case err == nil:
+		var result paginatedBlogResult
+		jsonErr := json.Unmarshal(cachedData, &result)
+		if jsonErr == nil {
+			return result.Blogs, result.Total, nil
+		}
+		log.Printf("[CACHE] Discarding corrupt search entry for key %s: %v", cacheKey, jsonErr)
+	case errors.Is(err, domain.ErrNotFound):
+		// Plain cache miss.
+	default:
+		// The cache is unavailable. Fail open and serve the request from the database.
+		log.Printf("[CACHE] Error getting search results from cache: %v", err)
+	}
+
+	blogs, total, err := r.next.SearchAndFilter(ctx, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dataToCache, jsonErr := json.Marshal(paginatedBlogResult{Blogs: blogs, Total: total})
+	if jsonErr == nil {
+		if err := r.cache.Set(ctx, cacheKey, dataToCache, r.searchTTL); err != nil {
+			log.Printf("[CACHE] Error setting search cache for key %s: %v", cacheKey, err)
+		}
+		trackerKey := searchTrackerKey
+		if isEngagementSort(opts.SortBy) {
+			trackerKey = engagementSearchTrackerKey
+		}
+		if err := r.cache.AddToSet(ctx, trackerKey, cacheKey); err != nil {
+			log.Printf("[CACHE] Error adding key to tracker set %s: %v", trackerKey, err)
+		}
+	}
+
+	return blogs, total, nil
+}
+
+// searchCacheKey hashes the options after putting the order-insensitive lists in order, so
+// the same search asked in a different way shares one entry.
+func searchCacheKey(opts domain.BlogSearchFilterOptions) (string, error) {
+	opts.Tags = sortedCopy(opts.Tags)
+	opts.AuthorIDs = sortedCopy(opts.AuthorIDs)
+	normalized, err := json.Marshal(opts)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(normalized)
+	return "blogs:search:" + hex.EncodeToString(sum[:]), nil
+}
+
+func sortedCopy(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// isEngagementSort reports whether results in this order move when a blog's counters change.
+// The activity sort counts because comments bump a blog's last activity.
+func isEngagementSort(sortBy string) bool {
+	switch sortBy {
+	case "popularity", "engagementScore", "activity":
+		return true
+	}
+	return false
+}
+
+// invalidateSearchCache drops every cached search page after a change that may alter results.
+func (r *CachingBlogRepository) invalidateSearchCache(ctx context.Context) {
+	r.invalidateTrackedSearches(ctx, searchTrackerKey, engagementSearchTrackerKey)
+}
+
+// invalidateEngagementSearches drops only the pages ordered by engagement, after a counter
+// changed. Pages in other orders keep showing the old counts until their TTL runs out.
+func (r *CachingBlogRepository) invalidateEngagementSearches(ctx context.Context) {
+	r.invalidateTrackedSearches(ctx, engagementSearchTrackerKey)
+}
+
+// invalidateTrackedSearches deletes the pages in the given tracker sets, and the sets themselves,
+// in one round trip.
+func (r *CachingBlogRepository) invalidateTrackedSearches(ctx context.Context, trackerKeys ...string) {
+	if r.searchTTL <= 0 {
+		return
+	}
+	var keysToDelete []string
+	for _, trackerKey := range trackerKeys {
+		members, err := r.cache.GetSetMembers(ctx, trackerKey)
+		if err != nil {
+			log.Printf("[CACHE] Could not get members of tracker set %s: %v", trackerKey, err)
+			continue
+		}
+		if len(members) > 0 {
+			keysToDelete = append(keysToDelete, members...)
+			keysToDelete = append(keysToDelete, trackerKey)
+		}
+	}
+	if len(keysToDelete) == 0 {
+		return
+	}
+	if err := r.cache.DeleteKeys(ctx, keysToDelete); err != nil {
+		log.Printf("[CACHE] Error invalidating search cache keys: %v", err)
+	}
+}
+
 // Update must invalidate the cache to prevent serving stale content.
 func (r *CachingBlogRepository) Update(ctx context.Context, blog *domain.Blog) error {
 	// 1. Update the primary data source first.
@@ -76,6 +235,7 @@ func (r *CachingBlogRepository) Update(ctx context.Context, blog *domain.Blog) e
 	if err := r.cache.Delete(ctx, cacheKey); err != nil {
 		log.Printf("[CACHE] Error deleting blog cache for key %s: %v", cacheKey, err)
 	}
+	r.invalidateSearchCache(ctx)
 	return nil
 }
 
@@ -91,41 +251,193 @@ func (r *CachingBlogRepository) Delete(ctx context.Context, id string) error {
 	if err := r.cache.Delete(ctx, cacheKey); err != nil {
 		log.Printf("[CACHE] Error deleting blog cache for key %s: %v", cacheKey, err)
 	}
+	r.invalidateSearchCache(ctx)
+	return nil
+}
+
+// MarkPublished changes visibility, so the cached copy must go.
+func (r *CachingBlogRepository) MarkPublished(ctx context.Context, blogID string, publishedAt time.Time) error {
+	if err := r.next.MarkPublished(ctx, blogID, publishedAt); err != nil {
+		return err
+	}
+
+	cacheKey := fmt.Sprintf("blog:id:%s", blogID)
+	if err := r.cache.Delete(ctx, cacheKey); err != nil {
+		log.Printf("[CACHE] Error deleting blog cache for key %s: %v", cacheKey, err)
+	}
+	r.invalidateSearchCache(ctx)
+	return nil
+}
+
+// MarkPending hides the blog from readers, so the cached copy must go.
+func (r *CachingBlogRepository) MarkPending(ctx context.Context, blogID string) error {
+	if err := r.next.MarkPending(ctx, blogID); err != nil {
+		return err
+	}
+
+	cacheKey := fmt.Sprintf("blog:id:%s", blogID)
+	if err := r.cache.Delete(ctx, cacheKey); err != nil {
+		log.Printf("[CACHE] Error deleting blog cache for key %s: %v", cacheKey, err)
+	}
+	r.invalidateSearchCache(ctx)
+	return nil
+}
+
+// ApprovePending changes visibility, so the cached copy must go.
+func (r *CachingBlogRepository) ApprovePending(ctx context.Context, blogID string, publishedAt time.Time) error {
+	if err := r.next.ApprovePending(ctx, blogID, publishedAt); err != nil {
+		return err
+	}
+
+	cacheKey := fmt.Sprintf("blog:id:%s", blogID)
+	if err := r.cache.Delete(ctx, cacheKey); err != nil {
+		log.Printf("[CACHE] Error deleting blog cache for key %s: %v", cacheKey, err)
+	}
+	r.invalidateSearchCache(ctx)
+	return nil
+}
+
+// FetchPending is an admin view that must be current, so it always reads through.
+func (r *CachingBlogRepository) FetchPending(ctx context.Context, page, limit int64) ([]*domain.Blog, int64, error) {
+	return r.next.FetchPending(ctx, page, limit)
+}
+
+// SetPinned invalidates the blog it pins or unpins, and the search pages that order by pinning.
+// A previously pinned blog of the same author may stay cached as pinned until its TTL runs out.
+func (r *CachingBlogRepository) SetPinned(ctx context.Context, blogID, authorID string, pinned bool) error {
+	if err := r.next.SetPinned(ctx, blogID, authorID, pinned); err != nil {
+		return err
+	}
+
+	cacheKey := fmt.Sprintf("blog:id:%s", blogID)
+	if err := r.cache.Delete(ctx, cacheKey); err != nil {
+		log.Printf("[CACHE] Error deleting blog cache for key %s: %v", cacheKey, err)
+	}
+	r.invalidateSearchCache(ctx)
 	return nil
 }
 
 // --- Pass-Through Methods ---
-// For all other methods, we simply pass the call directly to the wrapped repository.
+// ReplaceTag invalidates every blog whose tags it changed.
+func (r *CachingBlogRepository) ReplaceTag(ctx context.Context, from, to string) ([]string, error) {
+	ids, err := r.next.ReplaceTag(ctx, from, to)
+	if err != nil || len(ids) == 0 {
+		return ids, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = fmt.Sprintf("blog:id:%s", id)
+	}
+	if err := r.cache.DeleteKeys(ctx, keys); err != nil {
+		log.Printf("[CACHE] Error deleting blog cache after retagging %d blogs: %v", len(ids), err)
+	}
+	r.invalidateSearchCache(ctx)
+	return ids, nil
+}
 
+// Create adds a blog that cached search pages don't list yet.
 func (r *CachingBlogRepository) Create(ctx context.Context, blog *domain.Blog) error {
-	return r.next.Create(ctx, blog)
+	if err := r.next.Create(ctx, blog); err != nil {
+		return err
+	}
+	r.invalidateSearchCache(ctx)
+	return nil
 }
 
-func (r *CachingBlogRepository) SearchAndFilter(ctx context.Context, opts domain.BlogSearchFilterOptions) ([]*domain.Blog, int64, error) {
-	return r.next.SearchAndFilter(ctx, opts)
+func (r *CachingBlogRepository) CreateMany(ctx context.Context, blogs []*domain.Blog) error {
+	if err := r.next.CreateMany(ctx, blogs); err != nil {
+		return err
+	}
+	r.invalidateSearchCache(ctx)
+	return nil
+}
+
+// For all other methods, we simply pass the call directly to the wrapped repository.
+
+func (r *CachingBlogRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Blog, error) {
+	return r.next.GetByIDs(ctx, ids)
 }
 
 func (r *CachingBlogRepository) IncrementLikes(ctx context.Context, blogID string, value int) error {
-	// We rely on TTL for this to update in the cache.
-	return r.next.IncrementLikes(ctx, blogID, value)
+	// We rely on TTL for the cached blog; only engagement-ordered search pages are cleared.
+	if err := r.next.IncrementLikes(ctx, blogID, value); err != nil {
+		return err
+	}
+	r.invalidateEngagementSearches(ctx)
+	return nil
 }
 
 func (r *CachingBlogRepository) IncrementDislikes(ctx context.Context, blogID string, value int) error {
-	// We rely on TTL for this to update in the cache.
-	return r.next.IncrementDislikes(ctx, blogID, value)
+	// We rely on TTL for the cached blog; only engagement-ordered search pages are cleared.
+	if err := r.next.IncrementDislikes(ctx, blogID, value); err != nil {
+		return err
+	}
+	r.invalidateEngagementSearches(ctx)
+	return nil
 }
 
 func (r *CachingBlogRepository) IncrementViews(ctx context.Context, blogID string) error {
-	// We rely on TTL for this to update in the cache.
+	// We rely on TTL for this to update in the cache. Views come with nearly every read, so
+	// clearing engagement-ordered pages here would leave them hardly ever cached.
 	return r.next.IncrementViews(ctx, blogID)
 }
 
+func (r *CachingBlogRepository) AddViews(ctx context.Context, blogID string, count int64) error {
+	// Like IncrementViews, this relies on TTL to update the cache.
+	return r.next.AddViews(ctx, blogID, count)
+}
+
 func (r *CachingBlogRepository) IncrementCommentCount(ctx context.Context, blogID string, value int) error {
-	// We rely on TTL for this to update in the cache.
-	return r.next.IncrementCommentCount(ctx, blogID, value)
+	// We rely on TTL for the cached blog; only engagement-ordered search pages are cleared.
+	if err := r.next.IncrementCommentCount(ctx, blogID, value); err != nil {
+		return err
+	}
+	r.invalidateEngagementSearches(ctx)
+	return nil
 }
 
 func (r *CachingBlogRepository) UpdateInteractionCounts(ctx context.Context, blogID string, likesInc, dislikesInc int) error {
-	// We rely on TTL for this to update in the cache.
-	return r.next.UpdateInteractionCounts(ctx, blogID, likesInc, dislikesInc)
+	// We rely on TTL for the cached blog; only engagement-ordered search pages are cleared.
+	if err := r.next.UpdateInteractionCounts(ctx, blogID, likesInc, dislikesInc); err != nil {
+		return err
+	}
+	r.invalidateEngagementSearches(ctx)
+	return nil
+}
+
+func (r *CachingBlogRepository) GetCommentsCount(ctx context.Context, blogID string) (int64, error) {
+	// The cached blog may be behind the counter, so this always goes to the database.
+	return r.next.GetCommentsCount(ctx, blogID)
+}
+
+func (r *CachingBlogRepository) GetInteractionCounts(ctx context.Context, blogID string) (int64, int64, error) {
+	// The cached blog may be behind the counters, so this always goes to the database.
+	return r.next.GetInteractionCounts(ctx, blogID)
+}
+
+func (r *CachingBlogRepository) HasTag(ctx context.Context, tag string) (bool, error) {
+	return r.next.HasTag(ctx, tag)
+}
+
+func (r *CachingBlogRepository) GetRandom(ctx context.Context, tag *string) (*domain.Blog, error) {
+	// Every call should give a different blog, so there is nothing worth caching.
+	return r.next.GetRandom(ctx, tag)
+}
+
+func (r *CachingBlogRepository) SuggestTitles(ctx context.Context, query string, limit int64) ([]*domain.BlogTitleSuggestion, error) {
+	// The query changes with every keystroke, so a cached answer would rarely be asked for again.
+	return r.next.SuggestTitles(ctx, query, limit)
+}
+
+func (r *CachingBlogRepository) ListTags(ctx context.Context, page, limit int64, sort domain.TagSort) ([]*domain.TagCount, int64, error) {
+	return r.next.ListTags(ctx, page, limit, sort)
+}
+
+func (r *CachingBlogRepository) ExistsByContentHash(ctx context.Context, authorID, hash string, since time.Time) (bool, error) {
+	return r.next.ExistsByContentHash(ctx, authorID, hash, since)
+}
+
+func (r *CachingBlogRepository) FindDueScheduled(ctx context.Context, now time.Time, limit int64) ([]*domain.Blog, error) {
+	return r.next.FindDueScheduled(ctx, now, limit)
 }
@@ -3,6 +3,7 @@ package repositories_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -24,6 +25,10 @@ func (m *MockBlogRepository) Create(ctx context.Context, blog *domain.Blog) erro
 	args := m.Called(ctx, blog)
 	return args.Error(0)
 }
+func (m *MockBlogRepository) CreateMany(ctx context.Context, blogs []*domain.Blog) error {
+	args := m.Called(ctx, blogs)
+	return args.Error(0)
+}
 func (m *MockBlogRepository) GetByID(ctx context.Context, id string) (*domain.Blog, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -46,6 +51,90 @@ func (m *MockBlogRepository) SearchAndFilter(ctx context.Context, opts domain.Bl
 	}
 	return args.Get(0).([]*domain.Blog), args.Get(1).(int64), args.Error(2)
 }
+func (m *MockBlogRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Blog, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Blog), args.Error(1)
+}
+func (m *MockBlogRepository) FindDueScheduled(ctx context.Context, now time.Time, limit int64) ([]*domain.Blog, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Blog), args.Error(1)
+}
+func (m *MockBlogRepository) MarkPublished(ctx context.Context, blogID string, publishedAt time.Time) error {
+	args := m.Called(ctx, blogID, publishedAt)
+	return args.Error(0)
+}
+func (m *MockBlogRepository) MarkPending(ctx context.Context, blogID string) error {
+	args := m.Called(ctx, blogID)
+	return args.Error(0)
+}
+func (m *MockBlogRepository) ApprovePending(ctx context.Context, blogID string, publishedAt time.Time) error {
+	args := m.Called(ctx, blogID, publishedAt)
+	return args.Error(0)
+}
+func (m *MockBlogRepository) FetchPending(ctx context.Context, page, limit int64) ([]*domain.Blog, int64, error) {
+	args := m.Called(ctx, page, limit)
+	var blogs []*domain.Blog
+	if args.Get(0) != nil {
+		blogs = args.Get(0).([]*domain.Blog)
+	}
+	return blogs, args.Get(1).(int64), args.Error(2)
+}
+func (m *MockBlogRepository) GetCommentsCount(ctx context.Context, blogID string) (int64, error) {
+	args := m.Called(ctx, blogID)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockBlogRepository) GetInteractionCounts(ctx context.Context, blogID string) (int64, int64, error) {
+	args := m.Called(ctx, blogID)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+func (m *MockBlogRepository) ExistsByContentHash(ctx context.Context, authorID, hash string, since time.Time) (bool, error) {
+	args := m.Called(ctx, authorID, hash, since)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockBlogRepository) HasTag(ctx context.Context, tag string) (bool, error) {
+	args := m.Called(ctx, tag)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockBlogRepository) GetRandom(ctx context.Context, tag *string) (*domain.Blog, error) {
+	args := m.Called(ctx, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Blog), args.Error(1)
+}
+func (m *MockBlogRepository) SuggestTitles(ctx context.Context, query string, limit int64) ([]*domain.BlogTitleSuggestion, error) {
+	args := m.Called(ctx, query, limit)
+	var suggestions []*domain.BlogTitleSuggestion
+	if v := args.Get(0); v != nil {
+		suggestions = v.([]*domain.BlogTitleSuggestion)
+	}
+	return suggestions, args.Error(1)
+}
+func (m *MockBlogRepository) ListTags(ctx context.Context, page, limit int64, sort domain.TagSort) ([]*domain.TagCount, int64, error) {
+	args := m.Called(ctx, page, limit, sort)
+	var tags []*domain.TagCount
+	if args.Get(0) != nil {
+		tags = args.Get(0).([]*domain.TagCount)
+	}
+	return tags, args.Get(1).(int64), args.Error(2)
+}
+func (m *MockBlogRepository) ReplaceTag(ctx context.Context, from, to string) ([]string, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+func (m *MockBlogRepository) SetPinned(ctx context.Context, blogID, authorID string, pinned bool) error {
+	args := m.Called(ctx, blogID, authorID, pinned)
+	return args.Error(0)
+}
 func (m *MockBlogRepository) IncrementLikes(ctx context.Context, blogID string, value int) error {
 	args := m.Called(ctx, blogID, value)
 	return args.Error(0)
@@ -58,6 +147,10 @@ func (m *MockBlogRepository) IncrementViews(ctx context.Context, blogID string)
 	args := m.Called(ctx, blogID)
 	return args.Error(0)
 }
+func (m *MockBlogRepository) AddViews(ctx context.Context, blogID string, count int64) error {
+	args := m.Called(ctx, blogID, count)
+	return args.Error(0)
+}
 func (m *MockBlogRepository) IncrementCommentCount(ctx context.Context, blogID string, value int) error {
 	args := m.Called(ctx, blogID, value)
 	return args.Error(0)
@@ -132,6 +225,45 @@ func (s *CachingBlogDecoratorSuite) TestGetByID_CacheHit() {
 	s.mockRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
 }
 
+func (s *CachingBlogDecoratorSuite) TestGetByID_CacheUnavailable_FailsOpen() {
+	ctx := context.Background()
+	blogID := "blog123"
+	cacheKey := "blog:id:blog123"
+	expectedBlog := &domain.Blog{ID: blogID, Title: "A Great Post"}
+	redisDown := errors.New("dial tcp: connection refused")
+
+	// Arrange: Redis is down, so both the read and the write-back fail.
+	s.mockCache.On("Get", ctx, cacheKey).Return(nil, redisDown).Once()
+	s.mockRepo.On("GetByID", ctx, blogID).Return(expectedBlog, nil).Once()
+	s.mockCache.On("Set", ctx, cacheKey, mock.Anything, 5*time.Minute).Return(redisDown).Once()
+
+	// Act
+	resultBlog, err := s.cachingRepo.GetByID(ctx, blogID)
+
+	// Assert: The user still gets the blog from the database.
+	s.NoError(err)
+	s.Equal(expectedBlog, resultBlog)
+	s.mockCache.AssertExpectations(s.T())
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *CachingBlogDecoratorSuite) TestGetByID_CorruptEntry_RefetchesFromDB() {
+	ctx := context.Background()
+	blogID := "blog123"
+	cacheKey := "blog:id:blog123"
+	expectedBlog := &domain.Blog{ID: blogID, Title: "A Great Post"}
+
+	s.mockCache.On("Get", ctx, cacheKey).Return([]byte("{not json"), nil).Once()
+	s.mockRepo.On("GetByID", ctx, blogID).Return(expectedBlog, nil).Once()
+	s.mockCache.On("Set", ctx, cacheKey, mock.Anything, 5*time.Minute).Return(nil).Once()
+
+	resultBlog, err := s.cachingRepo.GetByID(ctx, blogID)
+
+	s.NoError(err)
+	s.Equal(expectedBlog, resultBlog)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
 func (s *CachingBlogDecoratorSuite) TestUpdate_InvalidatesCache() {
 	ctx := context.Background()
 	blogToUpdate := &domain.Blog{ID: "blog123", Title: "An Updated Post"}
@@ -168,6 +300,45 @@ func (s *CachingBlogDecoratorSuite) TestDelete_InvalidatesCache() {
 	s.mockCache.AssertExpectations(s.T())
 }
 
+func (s *CachingBlogDecoratorSuite) TestSetPinned_InvalidatesCache() {
+	ctx := context.Background()
+
+	s.mockRepo.On("SetPinned", ctx, "blog123", "author1", true).Return(nil).Once()
+	s.mockCache.On("Delete", ctx, "blog:id:blog123").Return(nil).Once()
+
+	err := s.cachingRepo.SetPinned(ctx, "blog123", "author1", true)
+
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockCache.AssertExpectations(s.T())
+}
+
+func (s *CachingBlogDecoratorSuite) TestReplaceTag_InvalidatesChangedBlogs() {
+	ctx := context.Background()
+
+	s.mockRepo.On("ReplaceTag", ctx, "go-lang", "golang").Return([]string{"blog1", "blog2"}, nil).Once()
+	s.mockCache.On("DeleteKeys", ctx, []string{"blog:id:blog1", "blog:id:blog2"}).Return(nil).Once()
+
+	ids, err := s.cachingRepo.ReplaceTag(ctx, "go-lang", "golang")
+
+	s.NoError(err)
+	s.Equal([]string{"blog1", "blog2"}, ids)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockCache.AssertExpectations(s.T())
+}
+
+func (s *CachingBlogDecoratorSuite) TestReplaceTag_NothingChanged() {
+	ctx := context.Background()
+
+	s.mockRepo.On("ReplaceTag", ctx, "go-lang", "golang").Return([]string{}, nil).Once()
+
+	ids, err := s.cachingRepo.ReplaceTag(ctx, "go-lang", "golang")
+
+	s.NoError(err)
+	s.Empty(ids)
+	s.mockCache.AssertNotCalled(s.T(), "DeleteKeys", mock.Anything, mock.Anything)
+}
+
 func (s *CachingBlogDecoratorSuite) TestPassThrough_SearchAndFilter() {
 	ctx := context.Background()
 	opts := domain.BlogSearchFilterOptions{Page: 1, Limit: 10}
@@ -190,3 +361,212 @@ func (s *CachingBlogDecoratorSuite) TestPassThrough_SearchAndFilter() {
 	s.mockCache.AssertNotCalled(s.T(), "Set")
 	s.mockCache.AssertNotCalled(s.T(), "Delete")
 }
+
+func (s *CachingBlogDecoratorSuite) TestSearchAndFilter_CacheMiss_StoresAndTracksPage() {
+	ctx := context.Background()
+	cachingRepo := NewCachingBlogRepository(s.mockRepo, s.mockCache, WithSearchCacheTTL(30*time.Second))
+	opts := domain.BlogSearchFilterOptions{Page: 1, Limit: 10, Tags: []string{"go"}}
+	expectedBlogs := []*domain.Blog{{ID: "blog1"}}
+	var cacheKey string
+
+	s.mockCache.On("Get", ctx, mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+		cacheKey = args.String(1)
+	}).Return(nil, domain.ErrNotFound).Once()
+	s.mockRepo.On("SearchAndFilter", ctx, opts).Return(expectedBlogs, int64(1), nil).Once()
+	s.mockCache.On("Set", ctx, mock.AnythingOfType("string"), mock.Anything, 30*time.Second).Return(nil).Once()
+	s.mockCache.On("AddToSet", ctx, "tracker:blogs:search", mock.Anything).Return(nil).Once()
+
+	blogs, total, err := cachingRepo.SearchAndFilter(ctx, opts)
+
+	s.NoError(err)
+	s.Equal(expectedBlogs, blogs)
+	s.Equal(int64(1), total)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockCache.AssertExpectations(s.T())
+	s.mockCache.AssertCalled(s.T(), "Set", ctx, cacheKey, mock.Anything, 30*time.Second)
+	s.mockCache.AssertCalled(s.T(), "AddToSet", ctx, "tracker:blogs:search", []interface{}{cacheKey})
+}
+
+func (s *CachingBlogDecoratorSuite) TestSearchAndFilter_CacheHit() {
+	ctx := context.Background()
+	cachingRepo := NewCachingBlogRepository(s.mockRepo, s.mockCache, WithSearchCacheTTL(30*time.Second))
+	cached, _ := json.Marshal(map[string]any{"blogs": []*domain.Blog{{ID: "blog1", Title: "Cached"}}, "total": 7})
+	var keys []string
+
+	s.mockCache.On("Get", ctx, mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+		keys = append(keys, args.String(1))
+	}).Return(cached, nil).Twice()
+
+	blogs, total, err := cachingRepo.SearchAndFilter(ctx, domain.BlogSearchFilterOptions{Page: 1, Limit: 10, Tags: []string{"go", "db"}})
+	s.NoError(err)
+	_, _, err = cachingRepo.SearchAndFilter(ctx, domain.BlogSearchFilterOptions{Page: 1, Limit: 10, Tags: []string{"db", "go"}})
+	s.NoError(err)
+
+	s.Require().Len(blogs, 1)
+	s.Equal("Cached", blogs[0].Title)
+	s.Equal(int64(7), total)
+	s.Equal(keys[0], keys[1], "the order of tags must not split the cache")
+	s.mockRepo.AssertNotCalled(s.T(), "SearchAndFilter", mock.Anything, mock.Anything)
+}
+
+func (s *CachingBlogDecoratorSuite) TestSearchAndFilter_IncludeDrafts_NotCached() {
+	ctx := context.Background()
+	cachingRepo := NewCachingBlogRepository(s.mockRepo, s.mockCache, WithSearchCacheTTL(30*time.Second))
+	opts := domain.BlogSearchFilterOptions{Page: 1, Limit: 10, AuthorIDs: []string{"author1"}, IncludeDrafts: true}
+
+	s.mockRepo.On("SearchAndFilter", ctx, opts).Return([]*domain.Blog{{ID: "draft1"}}, int64(1), nil).Once()
+
+	_, _, err := cachingRepo.SearchAndFilter(ctx, opts)
+
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockCache.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	s.mockCache.AssertNotCalled(s.T(), "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CachingBlogDecoratorSuite) TestCreate_InvalidatesSearchCache() {
+	ctx := context.Background()
+	cachingRepo := NewCachingBlogRepository(s.mockRepo, s.mockCache, WithSearchCacheTTL(30*time.Second))
+	blog := &domain.Blog{Title: "New Post"}
+
+	s.mockRepo.On("Create", ctx, blog).Return(nil).Once()
+	s.mockCache.On("GetSetMembers", ctx, "tracker:blogs:search").Return([]string{"blogs:search:a", "blogs:search:b"}, nil).Once()
+	s.mockCache.On("GetSetMembers", ctx, "tracker:blogs:search:engagement").Return([]string{"blogs:search:c"}, nil).Once()
+	s.mockCache.On("DeleteKeys", ctx, []string{"blogs:search:a", "blogs:search:b", "tracker:blogs:search", "blogs:search:c", "tracker:blogs:search:engagement"}).Return(nil).Once()
+
+	err := cachingRepo.Create(ctx, blog)
+
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockCache.AssertExpectations(s.T())
+}
+
+func (s *CachingBlogDecoratorSuite) TestCreate_SearchCacheDisabled_TouchesNothing() {
+	ctx := context.Background()
+	blog := &domain.Blog{Title: "New Post"}
+
+	s.mockRepo.On("Create", ctx, blog).Return(nil).Once()
+
+	err := s.cachingRepo.Create(ctx, blog)
+
+	s.NoError(err)
+	s.mockCache.AssertNotCalled(s.T(), "GetSetMembers", mock.Anything, mock.Anything)
+}
+
+func (s *CachingBlogDecoratorSuite) TestSearchAndFilter_EngagementSort_TrackedSeparately() {
+	ctx := context.Background()
+	cachingRepo := NewCachingBlogRepository(s.mockRepo, s.mockCache, WithSearchCacheTTL(30*time.Second))
+	opts := domain.BlogSearchFilterOptions{Page: 1, Limit: 10, SortBy: "popularity"}
+
+	s.mockCache.On("Get", ctx, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+	s.mockRepo.On("SearchAndFilter", ctx, opts).Return([]*domain.Blog{{ID: "blog1"}}, int64(1), nil).Once()
+	s.mockCache.On("Set", ctx, mock.AnythingOfType("string"), mock.Anything, 30*time.Second).Return(nil).Once()
+	s.mockCache.On("AddToSet", ctx, "tracker:blogs:search:engagement", mock.Anything).Return(nil).Once()
+
+	_, _, err := cachingRepo.SearchAndFilter(ctx, opts)
+
+	s.NoError(err)
+	s.mockCache.AssertExpectations(s.T())
+}
+
+func (s *CachingBlogDecoratorSuite) TestMutations_InvalidateSearchCaches() {
+	ctx := context.Background()
+	contentPages := []string{"blogs:search:date"}
+	engagementPages := []string{"blogs:search:popular"}
+	allKeys := []string{"blogs:search:date", "tracker:blogs:search", "blogs:search:popular", "tracker:blogs:search:engagement"}
+	engagementKeys := []string{"blogs:search:popular", "tracker:blogs:search:engagement"}
+	now := time.Now()
+
+	testCases := []struct {
+		name        string
+		arrange     func(repo *MockBlogRepository)
+		act         func(repo domain.IBlogRepository) error
+		clearsAll   bool
+		clearsNone  bool
+		blogIDEvict bool
+	}{
+		{
+			name:        "Update",
+			arrange:     func(repo *MockBlogRepository) { repo.On("Update", ctx, mock.Anything).Return(nil).Once() },
+			act:         func(repo domain.IBlogRepository) error { return repo.Update(ctx, &domain.Blog{ID: "blog1"}) },
+			clearsAll:   true,
+			blogIDEvict: true,
+		},
+		{
+			name:        "Delete",
+			arrange:     func(repo *MockBlogRepository) { repo.On("Delete", ctx, "blog1").Return(nil).Once() },
+			act:         func(repo domain.IBlogRepository) error { return repo.Delete(ctx, "blog1") },
+			clearsAll:   true,
+			blogIDEvict: true,
+		},
+		{
+			name:        "MarkPublished",
+			arrange:     func(repo *MockBlogRepository) { repo.On("MarkPublished", ctx, "blog1", now).Return(nil).Once() },
+			act:         func(repo domain.IBlogRepository) error { return repo.MarkPublished(ctx, "blog1", now) },
+			clearsAll:   true,
+			blogIDEvict: true,
+		},
+		{
+			name:    "IncrementLikes",
+			arrange: func(repo *MockBlogRepository) { repo.On("IncrementLikes", ctx, "blog1", 1).Return(nil).Once() },
+			act:     func(repo domain.IBlogRepository) error { return repo.IncrementLikes(ctx, "blog1", 1) },
+		},
+		{
+			name:    "IncrementDislikes",
+			arrange: func(repo *MockBlogRepository) { repo.On("IncrementDislikes", ctx, "blog1", 1).Return(nil).Once() },
+			act:     func(repo domain.IBlogRepository) error { return repo.IncrementDislikes(ctx, "blog1", 1) },
+		},
+		{
+			name:    "IncrementCommentCount",
+			arrange: func(repo *MockBlogRepository) { repo.On("IncrementCommentCount", ctx, "blog1", 1).Return(nil).Once() },
+			act:     func(repo domain.IBlogRepository) error { return repo.IncrementCommentCount(ctx, "blog1", 1) },
+		},
+		{
+			name: "UpdateInteractionCounts",
+			arrange: func(repo *MockBlogRepository) {
+				repo.On("UpdateInteractionCounts", ctx, "blog1", 1, -1).Return(nil).Once()
+			},
+			act: func(repo domain.IBlogRepository) error { return repo.UpdateInteractionCounts(ctx, "blog1", 1, -1) },
+		},
+		{
+			name:       "IncrementViews",
+			arrange:    func(repo *MockBlogRepository) { repo.On("IncrementViews", ctx, "blog1").Return(nil).Once() },
+			act:        func(repo domain.IBlogRepository) error { return repo.IncrementViews(ctx, "blog1") },
+			clearsNone: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			mockRepo := new(MockBlogRepository)
+			mockCache := new(MockCacheService)
+			cachingRepo := NewCachingBlogRepository(mockRepo, mockCache, WithSearchCacheTTL(30*time.Second))
+			tc.arrange(mockRepo)
+			if tc.blogIDEvict {
+				mockCache.On("Delete", ctx, "blog:id:blog1").Return(nil).Once()
+			}
+			switch {
+			case tc.clearsNone:
+			case tc.clearsAll:
+				mockCache.On("GetSetMembers", ctx, "tracker:blogs:search").Return(contentPages, nil).Once()
+				mockCache.On("GetSetMembers", ctx, "tracker:blogs:search:engagement").Return(engagementPages, nil).Once()
+				mockCache.On("DeleteKeys", ctx, allKeys).Return(nil).Once()
+			default:
+				mockCache.On("GetSetMembers", ctx, "tracker:blogs:search:engagement").Return(engagementPages, nil).Once()
+				mockCache.On("DeleteKeys", ctx, engagementKeys).Return(nil).Once()
+			}
+
+			err := tc.act(cachingRepo)
+
+			s.NoError(err)
+			mockRepo.AssertExpectations(s.T())
+			mockCache.AssertExpectations(s.T())
+			if !tc.clearsAll {
+				mockCache.AssertNotCalled(s.T(), "GetSetMembers", ctx, "tracker:blogs:search")
+			}
+			if tc.clearsNone {
+				mockCache.AssertNotCalled(s.T(), "DeleteKeys", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
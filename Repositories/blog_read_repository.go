@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BlogReadModel is how a user's read of a blog is stored in MongoDB.
+type BlogReadModel struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	UserID primitive.ObjectID `bson:"user_id"`
+	BlogID primitive.ObjectID `bson:"blog_id"`
+	ReadAt time.Time          `bson:"read_at"`
+}
+
+// BlogReadRepository implements the domain.IBlogReadRepository interface.
+type BlogReadRepository struct {
+	collection *mongo.Collection
+}
+
+// NewBlogReadRepository is the constructor for the read tracking repository.
+func NewBlogReadRepository(col *mongo.Collection) *BlogReadRepository {
+	return &BlogReadRepository{
+		collection: col,
+	}
+}
+
+func (r *BlogReadRepository) CreateBlogReadIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		// One document per user and blog, which also serves the IsRead and FindRead lookups.
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "blog_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		// Listing a user's reads, most recent first.
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "read_at", Value: -1}}},
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// --- Interface Implementations ---
+
+func (r *BlogReadRepository) MarkRead(ctx context.Context, userID, blogID string) error {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return usecases.ErrInternal
+	}
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return usecases.ErrNotFound
+	}
+
+	filter := bson.M{"user_id": userObjID, "blog_id": blogObjID}
+	update := bson.M{"$setOnInsert": bson.M{"read_at": time.Now().UTC()}}
+	_, err = r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	// Two concurrent upserts can race on the unique index; the loser's read is already recorded.
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	return err
+}
+
+func (r *BlogReadRepository) IsRead(ctx context.Context, userID, blogID string) (bool, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, nil
+	}
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return false, nil
+	}
+
+	err = r.collection.FindOne(ctx, bson.M{"user_id": userObjID, "blog_id": blogObjID}).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *BlogReadRepository) ListRead(ctx context.Context, userID string, page, limit int64) ([]*domain.BlogRead, int64, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return []*domain.BlogRead{}, 0, nil
+	}
+	filter := bson.M{"user_id": userObjID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "read_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	reads := []*domain.BlogRead{}
+	for cursor.Next(ctx) {
+		var model BlogReadModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, 0, err
+		}
+		reads = append(reads, &domain.BlogRead{
+			UserID: model.UserID.Hex(),
+			BlogID: model.BlogID.Hex(),
+			ReadAt: model.ReadAt,
+		})
+	}
+	return reads, total, cursor.Err()
+}
+
+func (r *BlogReadRepository) FindRead(ctx context.Context, userID string, blogIDs []string) (map[string]bool, error) {
+	read := make(map[string]bool)
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return read, nil
+	}
+	blogObjIDs := make([]primitive.ObjectID, 0, len(blogIDs))
+	for _, id := range blogIDs {
+		// Malformed IDs can't have been read; skip them rather than failing the whole lookup.
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			blogObjIDs = append(blogObjIDs, objID)
+		}
+	}
+	if len(blogObjIDs) == 0 {
+		return read, nil
+	}
+
+	filter := bson.M{"user_id": userObjID, "blog_id": bson.M{"$in": blogObjIDs}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"blog_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var model BlogReadModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, err
+		}
+		read[model.BlogID.Hex()] = true
+	}
+	return read, cursor.Err()
+}
@@ -0,0 +1,104 @@
+package repositories_test
+
+import (
+	. "A2SV_Starter_Project_Blog/Repositories"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BlogReadRepositoryTestSuite defines the suite for the read tracking repository integration tests.
+type BlogReadRepositoryTestSuite struct {
+	suite.Suite
+	repo           *BlogReadRepository
+	collectionName string
+}
+
+func (s *BlogReadRepositoryTestSuite) SetupTest() {
+	s.collectionName = "read_blogs_test"
+	s.repo = NewBlogReadRepository(testDB.Collection(s.collectionName))
+	s.Require().NoError(s.repo.CreateBlogReadIndexes(context.Background()))
+}
+
+func (s *BlogReadRepositoryTestSuite) TearDownTest() {
+	err := testDB.Collection(s.collectionName).Drop(context.Background())
+	s.Require().NoError(err, "Failed to drop test collection")
+}
+
+func TestBlogReadRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode.")
+	}
+	t.Parallel()
+	suite.Run(t, new(BlogReadRepositoryTestSuite))
+}
+
+func (s *BlogReadRepositoryTestSuite) TestMarkRead_Idempotent() {
+	ctx := context.Background()
+	userID := primitive.NewObjectID().Hex()
+	blogID := primitive.NewObjectID().Hex()
+
+	read, err := s.repo.IsRead(ctx, userID, blogID)
+	s.Require().NoError(err)
+	s.False(read)
+
+	s.Require().NoError(s.repo.MarkRead(ctx, userID, blogID))
+	first, _, err := s.repo.ListRead(ctx, userID, 1, 10)
+	s.Require().NoError(err)
+	s.Require().Len(first, 1)
+
+	time.Sleep(10 * time.Millisecond)
+	s.Require().NoError(s.repo.MarkRead(ctx, userID, blogID))
+
+	count, err := testDB.Collection(s.collectionName).CountDocuments(ctx, bson.M{})
+	s.Require().NoError(err)
+	s.Equal(int64(1), count)
+
+	second, _, err := s.repo.ListRead(ctx, userID, 1, 10)
+	s.Require().NoError(err)
+	s.Require().Len(second, 1)
+	s.True(first[0].ReadAt.Equal(second[0].ReadAt), "marking again must keep the first read time")
+
+	read, err = s.repo.IsRead(ctx, userID, blogID)
+	s.Require().NoError(err)
+	s.True(read)
+}
+
+func (s *BlogReadRepositoryTestSuite) TestListAndFindRead() {
+	ctx := context.Background()
+	userID := primitive.NewObjectID().Hex()
+	otherUserID := primitive.NewObjectID().Hex()
+	blogIDs := []string{primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()}
+
+	s.Require().NoError(s.repo.MarkRead(ctx, userID, blogIDs[0]))
+	time.Sleep(10 * time.Millisecond)
+	s.Require().NoError(s.repo.MarkRead(ctx, userID, blogIDs[1]))
+	s.Require().NoError(s.repo.MarkRead(ctx, otherUserID, blogIDs[2]))
+
+	s.Run("ListRead, most recent first", func() {
+		reads, total, err := s.repo.ListRead(ctx, userID, 1, 10)
+		s.Require().NoError(err)
+		s.Equal(int64(2), total)
+		s.Require().Len(reads, 2)
+		s.Equal(blogIDs[1], reads[0].BlogID)
+		s.Equal(blogIDs[0], reads[1].BlogID)
+	})
+
+	s.Run("ListRead paginates", func() {
+		reads, total, err := s.repo.ListRead(ctx, userID, 2, 1)
+		s.Require().NoError(err)
+		s.Equal(int64(2), total)
+		s.Require().Len(reads, 1)
+		s.Equal(blogIDs[0], reads[0].BlogID)
+	})
+
+	s.Run("FindRead only reports the user's own reads", func() {
+		read, err := s.repo.FindRead(ctx, userID, append(blogIDs, "not-an-id"))
+		s.Require().NoError(err)
+		s.Equal(map[string]bool{blogIDs[0]: true, blogIDs[1]: true}, read)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	usecases "A2SV_Starter_Project_Blog/Usecases"
 	"context"
 	"errors"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,14 +19,16 @@ const (
 	DislikeWeight = -10.0
 	ViewWeight    = 1.0
 	CommentWeight = 25.0
-	// Constant for the Hacker News-style popularity formula.
-	Gravity = 1.8
+	// Constant for the Hacker News-style popularity formula, shared with Blog.PopularityScore.
+	Gravity = domain.PopularityGravity
 )
 
 type BlogModel struct {
 	ID              primitive.ObjectID `bson:"_id,omitempty"`
 	Title           string             `bson:"title"`
 	Content         string             `bson:"content"`
+	ContentFormat   string             `bson:"content_format,omitempty"`
+	WordCount       int                `bson:"word_count"`
 	AuthorID        primitive.ObjectID `bson:"author_id"`
 	Tags            []string           `bson:"tags"`
 	Views           int64              `bson:"views"`
@@ -33,13 +36,23 @@ type BlogModel struct {
 	Dislikes        int64              `bson:"dislikes"`
 	CommentsCount   int64              `bson:"comments_count"`
 	EngagementScore float64            `bson:"engagementScore"`
+	Status          string             `bson:"status,omitempty"`
+	ScheduledFor    *time.Time         `bson:"scheduled_for,omitempty"`
+	PublishedAt     *time.Time         `bson:"published_at,omitempty"`
+	PinnedByAuthor  bool               `bson:"pinned_by_author,omitempty"`
+	LastActivityAt  *time.Time         `bson:"last_activity_at,omitempty"`
+	ContentHash     string             `bson:"content_hash,omitempty"`
 	CreatedAt       time.Time          `bson:"created_at"`
 	UpdatedAt       time.Time          `bson:"updated_at"`
 }
 
+// hiddenStatuses are the statuses kept out of public listings.
+var hiddenStatuses = []string{string(domain.BlogStatusDraft), string(domain.BlogStatusPending)}
+
 // BlogRepository implements the domain.BlogRepository interface using MongoDB.
 type BlogRepository struct {
 	collection *mongo.Collection
+	profiler   *QueryProfiler
 }
 
 // NewBlogRepository is the constructor for the blog repository.
@@ -49,6 +62,11 @@ func NewBlogRepository(col *mongo.Collection) *BlogRepository {
 	}
 }
 
+// SetQueryProfiler enables slow query logging for search reads. Pass nil to disable it.
+func (r *BlogRepository) SetQueryProfiler(p *QueryProfiler) {
+	r.profiler = p
+}
+
 func (r *BlogRepository) CreateBlogIndexes(ctx context.Context) error {
 	// Text index for regex/text searches on title and content.
 	textIndex := mongo.IndexModel{
@@ -79,17 +97,95 @@ func (r *BlogRepository) CreateBlogIndexes(ctx context.Context) error {
 		Keys: bson.D{{Key: "created_at", Value: -1}},
 	}
 
+	// Index for the default listing, which shows published blogs newest first.
+	statusDateIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	}
+
 	// Index for sorting by engagement, for "most liked/commented" type queries.
 	engagementIndex := mongo.IndexModel{
 		Keys: bson.D{{Key: "engagementScore", Value: -1}},
 	}
 
+	// Compound indexes for the filtered engagement sorts. Without these, filtering by
+	// author or tag and sorting by engagementScore falls back to an in-memory sort.
+	authorEngagementIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "author_id", Value: 1},
+			{Key: "engagementScore", Value: -1},
+		},
+	}
+	tagsEngagementIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "tags", Value: 1},
+			{Key: "engagementScore", Value: -1},
+		},
+	}
+
+	// Index for the scheduled publisher, which polls for drafts whose time has come.
+	scheduledIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "scheduled_for", Value: 1},
+		},
+	}
+
+	// Index for the recently active sort.
+	activityIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "last_activity_at", Value: -1}},
+	}
+
+	// Index for the long-form filter.
+	wordCountIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "word_count", Value: 1}},
+	}
+
+	// At most one pinned blog per author. The partial filter leaves unpinned blogs out of
+	// the index, so the uniqueness only applies among pinned ones.
+	pinnedIndex := mongo.IndexModel{
+		Keys: bson.D{{Key: "author_id", Value: 1}},
+		Options: options.Index().
+			SetName("author_pinned_unique").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"pinned_by_author": true}),
+	}
+
+	// Index for spotting an author posting the same blog twice in a short time.
+	contentHashIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "author_id", Value: 1},
+			{Key: "content_hash", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	}
+
+	// Index for title autocomplete. The title regex is checked against the index keys, so only
+	// matching blogs are loaded.
+	titleIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "title", Value: 1},
+			{Key: "status", Value: 1},
+		},
+	}
+
 	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
 		textIndex,
 		authorDateIndex,
 		tagsDateIndex,
 		dateIndex,
+		statusDateIndex,
 		engagementIndex,
+		authorEngagementIndex,
+		tagsEngagementIndex,
+		scheduledIndex,
+		wordCountIndex,
+		pinnedIndex,
+		contentHashIndex,
+		activityIndex,
+		titleIndex,
 	})
 	return err
 }
@@ -119,6 +215,42 @@ func (r *BlogRepository) Create(ctx context.Context, blog *domain.Blog) error {
 	return nil
 }
 
+// CreateMany inserts a batch of blogs with a single unordered bulk write.
+// Timestamps on the domain objects are stored as given, which keeps imported content faithful.
+func (r *BlogRepository) CreateMany(ctx context.Context, blogs []*domain.Blog) error {
+	if len(blogs) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(blogs))
+	models := make([]*BlogModel, len(blogs))
+	for i, blog := range blogs {
+		model, err := fromBlogDomain(blog)
+		if err != nil {
+			return err
+		}
+		model.ID = primitive.NewObjectID()
+		// Imported blogs may already carry counters; keep the score consistent with them.
+		model.EngagementScore = float64(blog.Likes)*LikeWeight + float64(blog.Dislikes)*DislikeWeight +
+			float64(blog.Views)*ViewWeight + float64(blog.CommentsCount)*CommentWeight
+		docs[i] = model
+		models[i] = model
+	}
+
+	_, err := r.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return usecases.ErrConflict
+		}
+		return err
+	}
+
+	for i, model := range models {
+		blogs[i].ID = model.ID.Hex()
+	}
+	return nil
+}
+
 func (r *BlogRepository) GetByID(ctx context.Context, id string) (*domain.Blog, error) {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -139,6 +271,35 @@ func (r *BlogRepository) GetByID(ctx context.Context, id string) (*domain.Blog,
 	return toBlogDomain(&model), nil
 }
 
+func (r *BlogRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Blog, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		// Malformed IDs can't match a blog; skip them rather than failing the whole lookup.
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+	if len(objIDs) == 0 {
+		return []*domain.Blog{}, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	blogs := []*domain.Blog{}
+	for cursor.Next(ctx) {
+		var model BlogModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, err
+		}
+		blogs = append(blogs, toBlogDomain(&model))
+	}
+	return blogs, cursor.Err()
+}
+
 // SearchAndFilter is the main public method for querying blogs.
 // It acts as a router, delegating to the most efficient query strategy
 // based on whether the user is sorting by popularity.
@@ -179,12 +340,22 @@ func (r *BlogRepository) executeSimpleFind(ctx context.Context, opts domain.Blog
 		sortDoc = bson.D{{Key: "title", Value: sortValue}}
 	case "engagementScore":
 		sortDoc = bson.D{{Key: "engagementScore", Value: sortValue}}
+	case "activity":
+		// Blogs stored before activity was tracked have no last_activity_at; creation date orders them.
+		sortDoc = bson.D{{Key: "last_activity_at", Value: sortValue}, {Key: "created_at", Value: sortValue}}
 	default: // "date" or any other value defaults to sorting by creation date.
 		sortDoc = bson.D{{Key: "created_at", Value: sortValue}}
 	}
+	if opts.PinnedFirst {
+		sortDoc = append(bson.D{{Key: "pinned_by_author", Value: -1}}, sortDoc...)
+	}
 	findOptions.SetSort(sortDoc)
+	if projection := buildProjection(opts); projection != nil {
+		findOptions.SetProjection(projection)
+	}
 
 	// 4. Execute the find query.
+	started := time.Now()
 	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
 		return nil, 0, err
@@ -200,6 +371,7 @@ func (r *BlogRepository) executeSimpleFind(ctx context.Context, opts domain.Blog
 		}
 		blogs = append(blogs, toBlogDomain(&model))
 	}
+	r.profiler.ObserveFind(ctx, r.collection, filter, sortDoc, *findOptions.Skip, opts.Limit, started)
 
 	return blogs, total, cursor.Err()
 }
@@ -250,14 +422,20 @@ func (r *BlogRepository) executePopularityAggregation(ctx context.Context, opts
 		}}},
 
 		// Stage 3: Sort by the newly calculated popularity field in descending order.
-		bson.D{{Key: "$sort", Value: bson.D{{Key: "popularity", Value: -1}}}},
+		bson.D{{Key: "$sort", Value: popularitySort(opts)}},
 
 		// Stage 4 & 5: Apply pagination to the sorted results.
 		bson.D{{Key: "$skip", Value: (opts.Page - 1) * opts.Limit}},
 		bson.D{{Key: "$limit", Value: opts.Limit}},
 	}
 
+	// Stage 6: Drop fields the caller doesn't need after pagination has trimmed the set.
+	if projection := buildProjection(opts); projection != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: projection}})
+	}
+
 	// 4. Execute the aggregation pipeline.
+	started := time.Now()
 	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
 		return nil, 0, err
@@ -273,10 +451,20 @@ func (r *BlogRepository) executePopularityAggregation(ctx context.Context, opts
 		}
 		blogs = append(blogs, toBlogDomain(&model))
 	}
+	r.profiler.ObserveAggregate(ctx, r.collection, pipeline, started)
 
 	return blogs, total, cursor.Err()
 }
 
+// popularitySort returns the sort stage for the popularity aggregation.
+func popularitySort(opts domain.BlogSearchFilterOptions) bson.D {
+	sortDoc := bson.D{{Key: "popularity", Value: -1}}
+	if opts.PinnedFirst {
+		sortDoc = append(bson.D{{Key: "pinned_by_author", Value: -1}}, sortDoc...)
+	}
+	return sortDoc
+}
+
 // buildFilter is a helper function that constructs the MongoDB filter document
 // from the search options. It is used by both find and aggregation queries.
 func buildFilter(opts domain.BlogSearchFilterOptions) (bson.M, error) {
@@ -318,32 +506,153 @@ func buildFilter(opts domain.BlogSearchFilterOptions) (bson.M, error) {
 		conditions = append(conditions, bson.M{"created_at": dateFilter})
 	}
 
+	if opts.MinWordCount > 0 {
+		conditions = append(conditions, bson.M{"word_count": bson.M{"$gte": opts.MinWordCount}})
+	}
+
+	// Drafts and blogs awaiting moderation are hidden unless explicitly requested. This applies
+	// on top of the user's criteria, so it must not be folded into an OR group.
+	// Blogs created before statuses existed have no status field and count as published.
+	visibility := bson.M{"status": bson.M{"$nin": hiddenStatuses}}
+
 	// Construct the final filter based on the GlobalLogic.
 	if len(conditions) == 0 {
-		return bson.M{}, nil // Empty filter matches all documents.
+		if opts.IncludeDrafts {
+			return bson.M{}, nil // Empty filter matches all documents.
+		}
+		return visibility, nil
 	}
 
 	operator := "$and" // Default to AND logic
 	if opts.GlobalLogic == domain.GlobalLogicOR {
 		operator = "$or"
 	}
-	return bson.M{operator: conditions}, nil
+	filter := bson.M{operator: conditions}
+	if opts.IncludeDrafts {
+		return filter, nil
+	}
+	return bson.M{"$and": []bson.M{visibility, filter}}, nil
 }
 
-func (r *BlogRepository) Update(ctx context.Context, blog *domain.Blog) error {
-	model, err := fromBlogDomain(blog)
+// buildProjection returns the projection for a search, or nil when the full
+// document is required. Lists only need the metadata, so the content field is excluded.
+func buildProjection(opts domain.BlogSearchFilterOptions) bson.M {
+	if opts.IncludeContent {
+		return nil
+	}
+	return bson.M{"content": 0}
+}
+
+// GetRandom draws one published blog with $sample, which picks uniformly without sorting the
+// collection.
+func (r *BlogRepository) GetRandom(ctx context.Context, tag *string) (*domain.Blog, error) {
+	// Blogs created before statuses existed have no status field and count as published.
+	match := bson.M{"status": bson.M{"$nin": hiddenStatuses}}
+	if tag != nil {
+		match["tags"] = *tag
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sample", Value: bson.M{"size": 1}}},
+	}
+
+	started := time.Now()
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []BlogModel
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+	r.profiler.ObserveAggregate(ctx, r.collection, pipeline, started)
+	if len(models) == 0 {
+		return nil, usecases.ErrNotFound
+	}
+	return toBlogDomain(&models[0]), nil
+}
+
+// SuggestTitles looks for titles starting with query first, and only when those don't fill the
+// limit for titles containing it elsewhere. query is matched literally, not as a pattern.
+func (r *BlogRepository) SuggestTitles(ctx context.Context, query string, limit int64) ([]*domain.BlogTitleSuggestion, error) {
+	pattern := regexp.QuoteMeta(query)
+	prefixFilter := bson.M{
+		"title":  bson.M{"$regex": "^" + pattern, "$options": "i"},
+		"status": bson.M{"$nin": hiddenStatuses},
+	}
+	suggestions, ids, err := r.findTitles(ctx, prefixFilter, limit)
+	if err != nil || int64(len(suggestions)) >= limit {
+		return suggestions, err
+	}
+
+	containsFilter := bson.M{
+		"title":  bson.M{"$regex": pattern, "$options": "i"},
+		"status": bson.M{"$nin": hiddenStatuses},
+		"_id":    bson.M{"$nin": ids},
+	}
+	more, _, err := r.findTitles(ctx, containsFilter, limit-int64(len(suggestions)))
+	if err != nil {
+		return nil, err
+	}
+	return append(suggestions, more...), nil
+}
+
+// findTitles runs one title lookup, fetching only the ID and title of each blog.
+func (r *BlogRepository) findTitles(ctx context.Context, filter bson.M, limit int64) ([]*domain.BlogTitleSuggestion, []primitive.ObjectID, error) {
+	sortDoc := bson.D{{Key: "title", Value: 1}}
+	findOptions := options.Find().
+		SetProjection(bson.M{"_id": 1, "title": 1}).
+		SetSort(sortDoc).
+		SetLimit(limit)
+
+	started := time.Now()
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, nil, err
 	}
+	defer cursor.Close(ctx)
+
+	var models []struct {
+		ID    primitive.ObjectID `bson:"_id"`
+		Title string             `bson:"title"`
+	}
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, nil, err
+	}
+	r.profiler.ObserveFind(ctx, r.collection, filter, sortDoc, 0, limit, started)
+
+	suggestions := make([]*domain.BlogTitleSuggestion, len(models))
+	ids := make([]primitive.ObjectID, len(models))
+	for i, m := range models {
+		suggestions[i] = &domain.BlogTitleSuggestion{ID: m.ID.Hex(), Title: m.Title}
+		ids[i] = m.ID
+	}
+	return suggestions, ids, nil
+}
 
+// Update saves an edit: the title, content and tags, with what is derived from them. The blog
+// was read before the edit, so every other field, such as its status, counters or pin, may
+// have been changed since by a publish, moderation or vote, and is left as stored.
+func (r *BlogRepository) Update(ctx context.Context, blog *domain.Blog) error {
 	objID, err := primitive.ObjectIDFromHex(blog.ID)
 	if err != nil {
 		return usecases.ErrNotFound
 	}
-	model.ID = objID // Ensure the model has the correct ObjectID
 
-	filter := bson.M{"_id": model.ID}
-	update := bson.M{"$set": model}
+	set := bson.M{
+		"title":      blog.Title,
+		"content":    blog.Content,
+		"word_count": blog.WordCount,
+		"tags":       blog.Tags,
+		"updated_at": blog.UpdatedAt,
+	}
+	if blog.ContentFormat != "" {
+		set["content_format"] = string(blog.ContentFormat)
+	}
+	filter := bson.M{"_id": objID}
+	update := bson.M{"$set": set}
 
 	res, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -357,6 +666,24 @@ func (r *BlogRepository) Update(ctx context.Context, blog *domain.Blog) error {
 	return nil
 }
 
+func (r *BlogRepository) ExistsByContentHash(ctx context.Context, authorID, hash string, since time.Time) (bool, error) {
+	authorObjID, err := primitive.ObjectIDFromHex(authorID)
+	if err != nil {
+		return false, nil
+	}
+
+	filter := bson.M{
+		"author_id":    authorObjID,
+		"content_hash": hash,
+		"created_at":   bson.M{"$gte": since},
+	}
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func (r *BlogRepository) Delete(ctx context.Context, id string) error {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -375,6 +702,306 @@ func (r *BlogRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// FindDueScheduled returns drafts whose scheduled time is at or before now, oldest first.
+// Because it only looks at state in the database, a restarted worker picks up anything it missed.
+func (r *BlogRepository) FindDueScheduled(ctx context.Context, now time.Time, limit int64) ([]*domain.Blog, error) {
+	filter := bson.M{
+		"status":        string(domain.BlogStatusDraft),
+		"scheduled_for": bson.M{"$lte": now},
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "scheduled_for", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var blogs []*domain.Blog
+	for cursor.Next(ctx) {
+		var model BlogModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, err
+		}
+		blogs = append(blogs, toBlogDomain(&model))
+	}
+	return blogs, cursor.Err()
+}
+
+// MarkPublished flips a draft to published. The update is conditional on the blog still
+// being a draft, so concurrent workers can't publish the same blog twice; the loser gets ErrNotFound.
+func (r *BlogRepository) MarkPublished(ctx context.Context, blogID string, publishedAt time.Time) error {
+	return r.transitionStatus(ctx, blogID, domain.BlogStatusDraft, bson.M{
+		"status":       string(domain.BlogStatusPublished),
+		"published_at": publishedAt,
+	})
+}
+
+// MarkPending moves a draft into the moderation queue, under the same condition as MarkPublished.
+func (r *BlogRepository) MarkPending(ctx context.Context, blogID string) error {
+	return r.transitionStatus(ctx, blogID, domain.BlogStatusDraft, bson.M{
+		"status": string(domain.BlogStatusPending),
+	})
+}
+
+// ApprovePending publishes a blog from the moderation queue. It returns ErrNotFound if the
+// blog is not pending, so two moderators can't approve the same blog twice.
+func (r *BlogRepository) ApprovePending(ctx context.Context, blogID string, publishedAt time.Time) error {
+	return r.transitionStatus(ctx, blogID, domain.BlogStatusPending, bson.M{
+		"status":       string(domain.BlogStatusPublished),
+		"published_at": publishedAt,
+	})
+}
+
+// FetchPending lists the moderation queue, oldest first so nothing waits indefinitely.
+func (r *BlogRepository) FetchPending(ctx context.Context, page, limit int64) ([]*domain.Blog, int64, error) {
+	filter := bson.M{"status": string(domain.BlogStatusPending)}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var blogs []*domain.Blog
+	for cursor.Next(ctx) {
+		var model BlogModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, 0, err
+		}
+		blogs = append(blogs, toBlogDomain(&model))
+	}
+	return blogs, total, cursor.Err()
+}
+
+// transitionStatus applies set to a blog only while it is in status from.
+func (r *BlogRepository) transitionStatus(ctx context.Context, blogID string, from domain.BlogStatus, set bson.M) error {
+	objID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return usecases.ErrNotFound
+	}
+
+	filter := bson.M{"_id": objID, "status": string(from)}
+	update := bson.M{"$set": set}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return usecases.ErrNotFound
+	}
+	return nil
+}
+
+// SetPinned pins or unpins a blog, scoped to its author. Pinning first unpins the author's
+// other blogs; the unique index on pinned blogs turns a concurrent pin into ErrConflict.
+func (r *BlogRepository) SetPinned(ctx context.Context, blogID, authorID string, pinned bool) error {
+	objID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return usecases.ErrNotFound
+	}
+	authorObjID, err := primitive.ObjectIDFromHex(authorID)
+	if err != nil {
+		return usecases.ErrNotFound
+	}
+
+	if pinned {
+		others := bson.M{"author_id": authorObjID, "_id": bson.M{"$ne": objID}, "pinned_by_author": true}
+		if _, err := r.collection.UpdateMany(ctx, others, bson.M{"$unset": bson.M{"pinned_by_author": ""}}); err != nil {
+			return err
+		}
+	}
+
+	update := bson.M{"$unset": bson.M{"pinned_by_author": ""}}
+	if pinned {
+		update = bson.M{"$set": bson.M{"pinned_by_author": true}}
+	}
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID, "author_id": authorObjID}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return usecases.ErrConflict
+		}
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return usecases.ErrNotFound
+	}
+	return nil
+}
+
+// GetCommentsCount returns only the stored comment counter of a blog.
+func (r *BlogRepository) GetCommentsCount(ctx context.Context, blogID string) (int64, error) {
+	objID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return 0, usecases.ErrNotFound
+	}
+
+	var doc struct {
+		CommentsCount int64 `bson:"comments_count"`
+	}
+	findOptions := options.FindOne().SetProjection(bson.M{"comments_count": 1})
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}, findOptions).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, usecases.ErrNotFound
+		}
+		return 0, err
+	}
+	return doc.CommentsCount, nil
+}
+
+// GetInteractionCounts returns only the stored like and dislike counters of a blog.
+func (r *BlogRepository) GetInteractionCounts(ctx context.Context, blogID string) (int64, int64, error) {
+	objID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return 0, 0, usecases.ErrNotFound
+	}
+
+	var doc struct {
+		Likes    int64 `bson:"likes"`
+		Dislikes int64 `bson:"dislikes"`
+	}
+	findOptions := options.FindOne().SetProjection(bson.M{"likes": 1, "dislikes": 1})
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}, findOptions).Decode(&doc)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, 0, usecases.ErrNotFound
+		}
+		return 0, 0, err
+	}
+	return doc.Likes, doc.Dislikes, nil
+}
+
+func (r *BlogRepository) HasTag(ctx context.Context, tag string) (bool, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"tags": tag}, options.Count().SetLimit(1))
+	return count > 0, err
+}
+
+// ReplaceTag rewrites the tags of every blog carrying from in a single bulk update.
+func (r *BlogRepository) ReplaceTag(ctx context.Context, from, to string) ([]string, error) {
+	filter := bson.M{"tags": from}
+
+	// Collect the affected IDs first so the caller can invalidate whatever it cached for them.
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	ids := []string{}
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		ids = append(ids, doc.ID.Hex())
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+
+	// Swap the tag in place, then drop repeats, so the tag order is kept and a blog that
+	// already had both tags ends up with one copy where the first of them was.
+	renamed := bson.M{"$map": bson.M{
+		"input": "$tags",
+		"in":    bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$$this", from}}, to, "$$this"}},
+	}}
+	deduped := bson.M{"$reduce": bson.M{
+		"input":        renamed,
+		"initialValue": bson.A{},
+		"in": bson.M{"$cond": bson.A{
+			bson.M{"$in": bson.A{"$$this", "$$value"}},
+			"$$value",
+			bson.M{"$concatArrays": bson.A{"$$value", bson.A{"$$this"}}},
+		}},
+	}}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{"tags": deduped}}}}
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// tagSortOrders maps each tag sort to its $sort stage. Ties fall back to the tag name so pages are stable.
+var tagSortOrders = map[domain.TagSort]bson.D{
+	domain.TagSortCount: {{Key: "count", Value: -1}, {Key: "_id", Value: 1}},
+	domain.TagSortAlpha: {{Key: "_id", Value: 1}},
+}
+
+// ListTags groups published blogs by tag and sorts, counts and pages the groups in one aggregation.
+func (r *BlogRepository) ListTags(ctx context.Context, page, limit int64, sort domain.TagSort) ([]*domain.TagCount, int64, error) {
+	sortOrder, ok := tagSortOrders[sort]
+	if !ok {
+		sortOrder = tagSortOrders[domain.TagSortCount]
+	}
+
+	pipeline := mongo.Pipeline{
+		// Blogs created before statuses existed have no status field and count as published.
+		{{Key: "$match", Value: bson.M{"status": bson.M{"$nin": hiddenStatuses}}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "n"}},
+			"tags": bson.A{
+				bson.M{"$sort": sortOrder},
+				bson.M{"$skip": (page - 1) * limit},
+				bson.M{"$limit": limit},
+			},
+		}}},
+	}
+
+	started := time.Now()
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Total []struct {
+			N int64 `bson:"n"`
+		} `bson:"total"`
+		Tags []struct {
+			Tag   string `bson:"_id"`
+			Count int64  `bson:"count"`
+		} `bson:"tags"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+	r.profiler.ObserveAggregate(ctx, r.collection, pipeline, started)
+
+	tags := []*domain.TagCount{}
+	if len(result) == 0 {
+		return tags, 0, nil
+	}
+	for _, t := range result[0].Tags {
+		tags = append(tags, &domain.TagCount{Tag: t.Tag, Count: t.Count})
+	}
+	var total int64
+	if len(result[0].Total) > 0 {
+		total = result[0].Total[0].N
+	}
+	return tags, total, nil
+}
+
 func (r *BlogRepository) IncrementLikes(ctx context.Context, blogID string, value int) error {
 	return r.UpdateInteractionCounts(ctx, blogID, value, 0)
 }
@@ -384,14 +1011,19 @@ func (r *BlogRepository) IncrementDislikes(ctx context.Context, blogID string, v
 }
 
 func (r *BlogRepository) IncrementViews(ctx context.Context, blogID string) error {
+	return r.AddViews(ctx, blogID, 1)
+}
+
+// AddViews adds count views, and their weight in the engagement score, with one $inc.
+func (r *BlogRepository) AddViews(ctx context.Context, blogID string, count int64) error {
 	objID, err := primitive.ObjectIDFromHex(blogID)
 	if err != nil {
-		return err
+		return usecases.ErrNotFound
 	}
 	filter := bson.M{"_id": objID}
 	update := bson.M{"$inc": bson.M{
-		"views":           1,
-		"engagementScore": ViewWeight,
+		"views":           count,
+		"engagementScore": float64(count) * ViewWeight,
 	}}
 
 	// Using UpdateOne is fine, it's a "fire-and-forget" operation.
@@ -402,7 +1034,7 @@ func (r *BlogRepository) IncrementViews(ctx context.Context, blogID string) erro
 func (r *BlogRepository) IncrementCommentCount(ctx context.Context, blogID string, value int) error {
 	objID, err := primitive.ObjectIDFromHex(blogID)
 	if err != nil {
-		return err
+		return usecases.ErrNotFound
 	}
 
 	filter := bson.M{"_id": objID}
@@ -412,6 +1044,7 @@ func (r *BlogRepository) IncrementCommentCount(ctx context.Context, blogID strin
 			"comments_count":  value,
 			"engagementScore": float64(value) * CommentWeight,
 		},
+		"$set": bson.M{"last_activity_at": time.Now().UTC()},
 	}
 
 	res, err := r.collection.UpdateOne(ctx, filter, update)
@@ -454,18 +1087,43 @@ func (r *BlogRepository) UpdateInteractionCounts(ctx context.Context, blogID str
 
 // toBlogDomain converts a persistence model (BlogModel) to a domain entity (Blog).
 func toBlogDomain(model *BlogModel) *domain.Blog {
+	// Documents written before statuses existed were always public.
+	status := domain.BlogStatus(model.Status)
+	if status == "" {
+		status = domain.BlogStatusPublished
+	}
+
+	lastActivityAt := model.CreatedAt
+	if model.LastActivityAt != nil {
+		lastActivityAt = *model.LastActivityAt
+	}
+
+	// Blogs written before formats existed are markdown.
+	contentFormat := domain.ContentFormat(model.ContentFormat)
+	if contentFormat == "" {
+		contentFormat = domain.ContentFormatMarkdown
+	}
+
 	return &domain.Blog{
-		ID:            model.ID.Hex(),
-		Title:         model.Title,
-		Content:       model.Content,
-		AuthorID:      model.AuthorID.Hex(),
-		Tags:          model.Tags,
-		Views:         model.Views,
-		Likes:         model.Likes,
-		Dislikes:      model.Dislikes,
-		CommentsCount: model.CommentsCount,
-		CreatedAt:     model.CreatedAt,
-		UpdatedAt:     model.UpdatedAt,
+		ID:              model.ID.Hex(),
+		Title:           model.Title,
+		Content:         model.Content,
+		ContentFormat:   contentFormat,
+		WordCount:       model.WordCount,
+		AuthorID:        model.AuthorID.Hex(),
+		Tags:            model.Tags,
+		Views:           model.Views,
+		Likes:           model.Likes,
+		Dislikes:        model.Dislikes,
+		CommentsCount:   model.CommentsCount,
+		EngagementScore: model.EngagementScore,
+		Status:          status,
+		ScheduledFor:    model.ScheduledFor,
+		PublishedAt:     model.PublishedAt,
+		PinnedByAuthor:  model.PinnedByAuthor,
+		LastActivityAt:  lastActivityAt,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
 	}
 }
 
@@ -477,16 +1135,29 @@ func fromBlogDomain(blog *domain.Blog) (*BlogModel, error) {
 		return nil, usecases.ErrInternal // An invalid AuthorID string is an internal error
 	}
 
+	var lastActivityAt *time.Time
+	if !blog.LastActivityAt.IsZero() {
+		lastActivityAt = &blog.LastActivityAt
+	}
+
 	return &BlogModel{
 		Title:           blog.Title,
 		Content:         blog.Content,
+		ContentFormat:   string(blog.ContentFormat),
+		WordCount:       blog.WordCount,
 		AuthorID:        authorID,
 		Tags:            blog.Tags,
 		Views:           blog.Views,
 		Likes:           blog.Likes,
 		Dislikes:        blog.Dislikes,
 		CommentsCount:   blog.CommentsCount,
-		EngagementScore: 0,
+		EngagementScore: blog.EngagementScore,
+		Status:          string(blog.Status),
+		ScheduledFor:    blog.ScheduledFor,
+		PublishedAt:     blog.PublishedAt,
+		PinnedByAuthor:  blog.PinnedByAuthor,
+		LastActivityAt:  lastActivityAt,
+		ContentHash:     domain.ContentHash(blog.Title, blog.Content),
 		CreatedAt:       blog.CreatedAt,
 		UpdatedAt:       blog.UpdatedAt,
 	}, nil
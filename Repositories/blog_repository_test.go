@@ -5,8 +5,8 @@ import (
 	. "A2SV_Starter_Project_Blog/Repositories"
 	usecases "A2SV_Starter_Project_Blog/Usecases"
 	"context"
-	"math"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -65,12 +65,15 @@ func (s *BlogRepositoryTestSuite) TestCreateBlogIndexes() {
 	err = cursor.All(ctx, &indexes)
 	s.Require().NoError(err, "Failed to decode indexes")
 
-	// We expect the default '_id_' index plus our 5 custom ones.
-	s.Len(indexes, 6, "Expected 6 indexes in total")
+	// We expect the default '_id_' index plus our 14 custom ones.
+	s.Len(indexes, 15, "Expected 15 indexes in total")
 
 	indexNames := make(map[string]bool)
+	indexSpecs := make(map[string]bson.M)
 	for _, idx := range indexes {
-		indexNames[idx["name"].(string)] = true
+		name := idx["name"].(string)
+		indexNames[name] = true
+		indexSpecs[name] = idx
 	}
 
 	s.Run("Text Search Index", func() {
@@ -93,10 +96,53 @@ func (s *BlogRepositoryTestSuite) TestCreateBlogIndexes() {
 		s.True(indexNames[indexName], "Date sorting index should exist")
 	})
 
+	s.Run("Status-Date Index", func() {
+		indexName := "status_1_created_at_-1"
+		s.Require().True(indexNames[indexName], "Status-Date index should exist")
+		s.Equal(bson.M{"status": int32(1), "created_at": int32(-1)}, indexSpecs[indexName]["key"])
+	})
+
 	s.Run("Engagement Score Index", func() {
 		indexName := "engagementScore_-1"
 		s.True(indexNames[indexName], "Engagement score index should exist")
 	})
+
+	s.Run("Author-Engagement Index", func() {
+		indexName := "author_id_1_engagementScore_-1"
+		s.Require().True(indexNames[indexName], "Author-Engagement index should exist")
+		s.Equal(bson.M{"author_id": int32(1), "engagementScore": int32(-1)}, indexSpecs[indexName]["key"])
+	})
+
+	s.Run("Tags-Engagement Index", func() {
+		indexName := "tags_1_engagementScore_-1"
+		s.Require().True(indexNames[indexName], "Tags-Engagement index should exist")
+		s.Equal(bson.M{"tags": int32(1), "engagementScore": int32(-1)}, indexSpecs[indexName]["key"])
+	})
+
+	s.Run("Scheduled Publishing Index", func() {
+		indexName := "status_1_scheduled_for_1"
+		s.Require().True(indexNames[indexName], "Scheduled publishing index should exist")
+		s.Equal(bson.M{"status": int32(1), "scheduled_for": int32(1)}, indexSpecs[indexName]["key"])
+	})
+
+	s.Run("Word Count Index", func() {
+		s.True(indexNames["word_count_1"], "Word count index should exist")
+	})
+
+	s.Run("Pinned Blog Index", func() {
+		indexName := "author_pinned_unique"
+		s.Require().True(indexNames[indexName], "Pinned blog index should exist")
+		s.Equal(true, indexSpecs[indexName]["unique"])
+		s.Equal(bson.M{"pinned_by_author": true}, indexSpecs[indexName]["partialFilterExpression"])
+	})
+
+	s.Run("Content Hash Index", func() {
+		s.True(indexNames["author_id_1_content_hash_1_created_at_-1"], "Content hash index should exist")
+	})
+
+	s.Run("Last Activity Index", func() {
+		s.True(indexNames["last_activity_at_-1"], "Last activity index should exist")
+	})
 }
 
 func (s *BlogRepositoryTestSuite) TestCreate() {
@@ -121,6 +167,73 @@ func (s *BlogRepositoryTestSuite) TestCreate() {
 	s.Equal(int64(0), createdModel.CommentsCount, "CommentsCount should be initialized to 0")
 }
 
+func (s *BlogRepositoryTestSuite) TestExistsByContentHash() {
+	ctx := context.Background()
+	authorID := s.fixedAuthorID.Hex()
+	blog, err := domain.NewBlog("Same Title", "Same content", authorID, nil)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Create(ctx, blog))
+	hash := domain.ContentHash("Same Title", "Same content")
+	recently := blog.CreatedAt.Add(-time.Minute)
+
+	s.Run("Same author, same content, inside the window", func() {
+		exists, err := s.repo.ExistsByContentHash(ctx, authorID, hash, recently)
+		s.Require().NoError(err)
+		s.True(exists)
+	})
+
+	s.Run("Another author", func() {
+		exists, err := s.repo.ExistsByContentHash(ctx, primitive.NewObjectID().Hex(), hash, recently)
+		s.Require().NoError(err)
+		s.False(exists)
+	})
+
+	s.Run("Outside the window", func() {
+		exists, err := s.repo.ExistsByContentHash(ctx, authorID, hash, blog.CreatedAt.Add(time.Minute))
+		s.Require().NoError(err)
+		s.False(exists)
+	})
+
+	s.Run("An edit updates the hash", func() {
+		blog.SetContent("Edited content")
+		s.Require().NoError(s.repo.Update(ctx, blog))
+
+		exists, err := s.repo.ExistsByContentHash(ctx, authorID, hash, recently)
+		s.Require().NoError(err)
+		s.False(exists)
+		exists, err = s.repo.ExistsByContentHash(ctx, authorID, domain.ContentHash("Same Title", "Edited content"), recently)
+		s.Require().NoError(err)
+		s.True(exists)
+	})
+}
+
+func (s *BlogRepositoryTestSuite) TestCreateMany() {
+	ctx := context.Background()
+	createdAt := time.Date(2019, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	first, _ := domain.NewBlog("Imported One", "Content", s.fixedAuthorID.Hex(), []string{"import"})
+	first.CreatedAt, first.UpdatedAt = createdAt, createdAt
+	second, _ := domain.NewBlog("Imported Two", "Content", s.fixedAuthorID.Hex(), nil)
+
+	err := s.repo.CreateMany(ctx, []*domain.Blog{first, second})
+	s.Require().NoError(err)
+	s.NotEmpty(first.ID)
+	s.NotEmpty(second.ID)
+
+	count, err := s.collection.CountDocuments(ctx, bson.M{})
+	s.Require().NoError(err)
+	s.Equal(int64(2), count)
+
+	// The provided timestamp must survive the import untouched.
+	fetched, err := s.repo.GetByID(ctx, first.ID)
+	s.Require().NoError(err)
+	s.True(createdAt.Equal(fetched.CreatedAt), "CreatedAt should be preserved")
+
+	s.Run("Empty batch is a no-op", func() {
+		s.NoError(s.repo.CreateMany(ctx, nil))
+	})
+}
+
 func (s *BlogRepositoryTestSuite) TestGetByID() {
 	ctx := context.Background()
 	originalBlog, _ := domain.NewBlog("Gettable Blog", "Content", s.fixedAuthorID.Hex(), []string{"get"})
@@ -139,6 +252,196 @@ func (s *BlogRepositoryTestSuite) TestGetByID() {
 	s.Equal(int64(0), foundBlog.CommentsCount, "CommentsCount should be mapped correctly")
 }
 
+// TestGetByIDs asserts that only existing blogs come back, and malformed IDs are ignored.
+func (s *BlogRepositoryTestSuite) TestGetByIDs() {
+	ctx := context.Background()
+	first, _ := domain.NewBlog("First", "Content", s.fixedAuthorID.Hex(), nil)
+	second, _ := domain.NewBlog("Second", "Content", s.fixedAuthorID.Hex(), nil)
+	s.Require().NoError(s.repo.Create(ctx, first))
+	s.Require().NoError(s.repo.Create(ctx, second))
+
+	blogs, err := s.repo.GetByIDs(ctx, []string{first.ID, primitive.NewObjectID().Hex(), "not-an-id", second.ID})
+
+	s.Require().NoError(err)
+	s.Require().Len(blogs, 2)
+	s.ElementsMatch([]string{first.ID, second.ID}, []string{blogs[0].ID, blogs[1].ID})
+}
+
+// TestReplaceTag asserts that merging a tag rewrites every blog carrying it, without duplicating the target tag.
+func (s *BlogRepositoryTestSuite) TestReplaceTag() {
+	ctx := context.Background()
+	oldOnly, _ := domain.NewBlog("Old tag", "Content", s.fixedAuthorID.Hex(), []string{"backend", "go-lang", "tips"})
+	both, _ := domain.NewBlog("Both tags", "Content", s.fixedAuthorID.Hex(), []string{"golang", "go-lang"})
+	untouched, _ := domain.NewBlog("Other tag", "Content", s.fixedAuthorID.Hex(), []string{"rust"})
+	for _, blog := range []*domain.Blog{oldOnly, both, untouched} {
+		s.Require().NoError(s.repo.Create(ctx, blog))
+	}
+
+	ids, err := s.repo.ReplaceTag(ctx, "go-lang", "golang")
+
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{oldOnly.ID, both.ID}, ids)
+
+	got, err := s.repo.GetByID(ctx, oldOnly.ID)
+	s.Require().NoError(err)
+	s.Equal([]string{"backend", "golang", "tips"}, got.Tags, "the tag is replaced in place")
+
+	got, err = s.repo.GetByID(ctx, both.ID)
+	s.Require().NoError(err)
+	s.Equal([]string{"golang"}, got.Tags, "a blog with both tags keeps one copy")
+
+	got, err = s.repo.GetByID(ctx, untouched.ID)
+	s.Require().NoError(err)
+	s.Equal([]string{"rust"}, got.Tags)
+
+	hasOld, err := s.repo.HasTag(ctx, "go-lang")
+	s.Require().NoError(err)
+	s.False(hasOld)
+	hasNew, err := s.repo.HasTag(ctx, "golang")
+	s.Require().NoError(err)
+	s.True(hasNew)
+
+	s.Run("Unknown tag changes nothing", func() {
+		ids, err := s.repo.ReplaceTag(ctx, "go-lang", "golang")
+		s.Require().NoError(err)
+		s.Empty(ids)
+	})
+}
+
+func (s *BlogRepositoryTestSuite) TestListTags() {
+	ctx := context.Background()
+	for _, tags := range [][]string{{"go", "web"}, {"go", "api"}, {"go", "web"}, {"rust"}} {
+		blog, err := domain.NewBlog("Tagged", "Content", s.fixedAuthorID.Hex(), tags)
+		s.Require().NoError(err)
+		s.Require().NoError(s.repo.Create(ctx, blog))
+	}
+	draft := s.createScheduled("Scheduled Draft", time.Now().Add(time.Hour))
+	draft.Tags = []string{"secret", "go"}
+	s.Require().NoError(s.repo.Update(ctx, draft))
+
+	names := func(tags []*domain.TagCount) []string {
+		out := make([]string, len(tags))
+		for i, t := range tags {
+			out[i] = t.Tag
+		}
+		return out
+	}
+
+	s.Run("Count", func() {
+		tags, total, err := s.repo.ListTags(ctx, 1, 10, domain.TagSortCount)
+		s.Require().NoError(err)
+		s.Equal(int64(4), total, "drafts' tags are not listed")
+		s.Equal([]string{"go", "web", "api", "rust"}, names(tags), "ties are broken alphabetically")
+		s.Equal(int64(3), tags[0].Count)
+		s.Equal(int64(2), tags[1].Count)
+	})
+
+	s.Run("Alpha", func() {
+		tags, _, err := s.repo.ListTags(ctx, 1, 10, domain.TagSortAlpha)
+		s.Require().NoError(err)
+		s.Equal([]string{"api", "go", "rust", "web"}, names(tags))
+	})
+
+	s.Run("Paginated", func() {
+		tags, total, err := s.repo.ListTags(ctx, 2, 3, domain.TagSortAlpha)
+		s.Require().NoError(err)
+		s.Equal(int64(4), total)
+		s.Equal([]string{"web"}, names(tags))
+	})
+}
+
+func (s *BlogRepositoryTestSuite) TestGetRandom() {
+	ctx := context.Background()
+	goTag, rustTag, secretTag := "go", "rust", "secret"
+
+	s.Run("No Published Blogs", func() {
+		_, err := s.repo.GetRandom(ctx, nil)
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+
+	published := map[string]bool{}
+	for _, tags := range [][]string{{"go"}, {"go", "web"}, {"rust"}} {
+		blog, err := domain.NewBlog("Random", "Content", s.fixedAuthorID.Hex(), tags)
+		s.Require().NoError(err)
+		s.Require().NoError(s.repo.Create(ctx, blog))
+		published[blog.ID] = true
+	}
+	draft := s.createScheduled("Scheduled Draft", time.Now().Add(time.Hour))
+	draft.Tags = []string{"secret", "go"}
+	s.Require().NoError(s.repo.Update(ctx, draft))
+
+	s.Run("Returns A Published Blog", func() {
+		for i := 0; i < 20; i++ {
+			blog, err := s.repo.GetRandom(ctx, nil)
+			s.Require().NoError(err)
+			s.True(published[blog.ID], "drafts are never sampled")
+			s.Equal(domain.BlogStatusPublished, blog.Status)
+		}
+	})
+
+	s.Run("Respects The Tag", func() {
+		for i := 0; i < 10; i++ {
+			blog, err := s.repo.GetRandom(ctx, &goTag)
+			s.Require().NoError(err)
+			s.Contains(blog.Tags, goTag)
+			s.NotEqual(draft.ID, blog.ID)
+		}
+		blog, err := s.repo.GetRandom(ctx, &rustTag)
+		s.Require().NoError(err)
+		s.Equal([]string{"rust"}, blog.Tags)
+	})
+
+	s.Run("Tag Only On Drafts", func() {
+		_, err := s.repo.GetRandom(ctx, &secretTag)
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+}
+
+func (s *BlogRepositoryTestSuite) TestSuggestTitles() {
+	ctx := context.Background()
+	for _, title := range []string{"Learning Go", "go channels", "Let's Go (Part 2)", "Rust for Gophers", "Golang tips", "Python basics"} {
+		blog, err := domain.NewBlog(title, "Content", s.fixedAuthorID.Hex(), nil)
+		s.Require().NoError(err)
+		s.Require().NoError(s.repo.Create(ctx, blog))
+	}
+	s.createScheduled("Go Secret Draft", time.Now().Add(time.Hour))
+
+	titles := func(suggestions []*domain.BlogTitleSuggestion) []string {
+		out := make([]string, len(suggestions))
+		for i, suggestion := range suggestions {
+			s.NotEmpty(suggestion.ID)
+			out[i] = suggestion.Title
+		}
+		return out
+	}
+
+	s.Run("Prefix Matches Come First", func() {
+		suggestions, err := s.repo.SuggestTitles(ctx, "go", 10)
+		s.Require().NoError(err)
+		s.Equal([]string{"Golang tips", "go channels", "Learning Go", "Let's Go (Part 2)", "Rust for Gophers"}, titles(suggestions))
+	})
+
+	s.Run("Result Cap", func() {
+		suggestions, err := s.repo.SuggestTitles(ctx, "go", 3)
+		s.Require().NoError(err)
+		s.Equal([]string{"Golang tips", "go channels", "Learning Go"}, titles(suggestions))
+
+		suggestions, err = s.repo.SuggestTitles(ctx, "go", 1)
+		s.Require().NoError(err)
+		s.Equal([]string{"Golang tips"}, titles(suggestions))
+	})
+
+	s.Run("Query Is Matched Literally", func() {
+		suggestions, err := s.repo.SuggestTitles(ctx, "go (part", 10)
+		s.Require().NoError(err)
+		s.Equal([]string{"Let's Go (Part 2)"}, titles(suggestions))
+
+		suggestions, err = s.repo.SuggestTitles(ctx, ".*", 10)
+		s.Require().NoError(err)
+		s.Empty(suggestions)
+	})
+}
+
 // TestGetByID_NotFound asserts that ErrNotFound is returned for a non-existent ID.
 func (s *BlogRepositoryTestSuite) TestGetByID_NotFound() {
 	ctx := context.Background()
@@ -188,6 +491,36 @@ func (s *BlogRepositoryTestSuite) TestUpdate() {
 	s.WithinDuration(blog.UpdatedAt, updatedBlog.UpdatedAt, time.Second, "UpdatedAt should be close to what was set")
 }
 
+// TestUpdate_KeepsConcurrentChanges asserts that saving an edit made from a stale copy doesn't
+// undo what happened to the blog in the meantime.
+func (s *BlogRepositoryTestSuite) TestUpdate_KeepsConcurrentChanges() {
+	ctx := context.Background()
+	// Arrange: a pending blog, read for editing before it is approved and viewed.
+	blog, _ := domain.NewBlog("Initial Title", "Initial Content", s.fixedAuthorID.Hex(), nil)
+	blog.Status = domain.BlogStatusPending
+	s.Require().NoError(s.repo.Create(ctx, blog))
+	stale, err := s.repo.GetByID(ctx, blog.ID)
+	s.Require().NoError(err)
+
+	publishedAt := time.Now().UTC().Truncate(time.Millisecond)
+	s.Require().NoError(s.repo.ApprovePending(ctx, blog.ID, publishedAt))
+	s.Require().NoError(s.repo.IncrementViews(ctx, blog.ID))
+
+	// Act
+	stale.Title = "Edited Title"
+	stale.UpdatedAt = time.Now()
+	s.Require().NoError(s.repo.Update(ctx, stale))
+
+	// Assert
+	updated, err := s.repo.GetByID(ctx, blog.ID)
+	s.Require().NoError(err)
+	s.Equal("Edited Title", updated.Title)
+	s.Equal(domain.BlogStatusPublished, updated.Status, "the approval is kept")
+	s.Require().NotNil(updated.PublishedAt)
+	s.WithinDuration(publishedAt, *updated.PublishedAt, time.Millisecond)
+	s.Equal(int64(1), updated.Views, "the view is kept")
+}
+
 // TestDelete asserts that a blog can be deleted and is no longer retrievable.
 func (s *BlogRepositoryTestSuite) TestDelete() {
 	ctx := context.Background()
@@ -209,18 +542,7 @@ func (s *BlogRepositoryTestSuite) TestDelete() {
 }
 
 func calculatePopularity(score float64, createdAt time.Time) float64 {
-	// Calculate the age of the post in hours.
-	ageInHours := time.Since(createdAt).Hours()
-
-	// Denominator: (Age in hours + 2) ^ Gravity
-	denominator := math.Pow(ageInHours+2, Gravity)
-
-	// Avoid division by zero, though unlikely with the +2 buffer.
-	if denominator == 0 {
-		return score
-	}
-
-	return score / denominator
+	return (&domain.Blog{EngagementScore: score, CreatedAt: createdAt}).PopularityScore(time.Now())
 }
 
 func (s *BlogRepositoryTestSuite) TestSearchAndFilter() {
@@ -361,6 +683,9 @@ func (s *BlogRepositoryTestSuite) TestSearchAndFilter() {
 		expectedIDs := getBlogIDs(blogsToSort)
 
 		s.Equal(expectedIDs, actualIDs, "The order of blogs returned by the database does not match the expected popularity sort order")
+		for _, blog := range actualBlogs {
+			s.Equal(engagementScores[blog.ID], blog.EngagementScore, "the stored score is loaded with the blog")
+		}
 	})
 
 	s.Run("Pagination", func() {
@@ -376,6 +701,299 @@ func (s *BlogRepositoryTestSuite) TestSearchAndFilter() {
 	})
 }
 
+func (s *BlogRepositoryTestSuite) TestSearchAndFilter_Projection() {
+	ctx := context.Background()
+	blog, err := domain.NewBlog("Projected Blog", "A long body that lists don't need", s.fixedAuthorID.Hex(), []string{"go"})
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Create(ctx, blog))
+
+	s.Run("List mode omits content", func() {
+		for _, sortBy := range []string{"date", "popularity"} {
+			opts := domain.BlogSearchFilterOptions{SortBy: sortBy, Page: 1, Limit: 10}
+			blogs, _, err := s.repo.SearchAndFilter(ctx, opts)
+			s.Require().NoError(err)
+			s.Require().Len(blogs, 1)
+			s.Equal(blog.Title, blogs[0].Title, "metadata should still be decoded (sortBy=%s)", sortBy)
+			s.Empty(blogs[0].Content, "content should be projected out (sortBy=%s)", sortBy)
+		}
+	})
+
+	s.Run("IncludeContent returns the body", func() {
+		opts := domain.BlogSearchFilterOptions{Page: 1, Limit: 10, IncludeContent: true}
+		blogs, _, err := s.repo.SearchAndFilter(ctx, opts)
+		s.Require().NoError(err)
+		s.Require().Len(blogs, 1)
+		s.Equal(blog.Content, blogs[0].Content)
+	})
+
+	s.Run("Detail fetch returns the body", func() {
+		fetched, err := s.repo.GetByID(ctx, blog.ID)
+		s.Require().NoError(err)
+		s.Equal(blog.Content, fetched.Content)
+	})
+}
+
+func (s *BlogRepositoryTestSuite) TestWordCount() {
+	ctx := context.Background()
+	short, err := domain.NewBlog("Short", "just three words", s.fixedAuthorID.Hex(), nil)
+	s.Require().NoError(err)
+	long, err := domain.NewBlog("Long", strings.Repeat("word ", 50), s.fixedAuthorID.Hex(), nil)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Create(ctx, short))
+	s.Require().NoError(s.repo.Create(ctx, long))
+
+	s.Run("Stored with the blog", func() {
+		fetched, err := s.repo.GetByID(ctx, long.ID)
+		s.Require().NoError(err)
+		s.Equal(50, fetched.WordCount)
+	})
+
+	s.Run("MinWordCount filter", func() {
+		for _, sortBy := range []string{"date", "popularity"} {
+			blogs, total, err := s.repo.SearchAndFilter(ctx, domain.BlogSearchFilterOptions{MinWordCount: 10, SortBy: sortBy, Page: 1, Limit: 10})
+			s.Require().NoError(err)
+			s.Equal(int64(1), total, "sortBy=%s", sortBy)
+			s.Require().Len(blogs, 1)
+			s.Equal(long.ID, blogs[0].ID)
+			s.Equal(50, blogs[0].WordCount, "word count should survive the content projection")
+		}
+	})
+
+	s.Run("Recomputed on update", func() {
+		short.SetContent(strings.Repeat("more ", 20))
+		s.Require().NoError(s.repo.Update(ctx, short))
+
+		blogs, total, err := s.repo.SearchAndFilter(ctx, domain.BlogSearchFilterOptions{MinWordCount: 10, Page: 1, Limit: 10})
+		s.Require().NoError(err)
+		s.Equal(int64(2), total)
+		s.Len(blogs, 2)
+	})
+}
+
+// createScheduled stores a draft scheduled for the given time, bypassing the future-time check.
+func (s *BlogRepositoryTestSuite) createScheduled(title string, at time.Time) *domain.Blog {
+	blog, err := domain.NewBlog(title, "Content", s.fixedAuthorID.Hex(), nil)
+	s.Require().NoError(err)
+	blog.Status = domain.BlogStatusDraft
+	blog.ScheduledFor = &at
+	blog.PublishedAt = nil
+	s.Require().NoError(s.repo.Create(context.Background(), blog))
+	return blog
+}
+
+func (s *BlogRepositoryTestSuite) TestFindDueScheduled() {
+	ctx := context.Background()
+	now := time.Now().UTC()
+	older := s.createScheduled("Older Due", now.Add(-2*time.Hour))
+	due := s.createScheduled("Due", now.Add(-time.Minute))
+	s.createScheduled("Future", now.Add(time.Hour))
+	published, err := domain.NewBlog("Already Published", "Content", s.fixedAuthorID.Hex(), nil)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Create(ctx, published))
+
+	blogs, err := s.repo.FindDueScheduled(ctx, now, 10)
+	s.Require().NoError(err)
+	s.Require().Len(blogs, 2, "Only drafts whose time has passed should be returned")
+	s.Equal(older.ID, blogs[0].ID, "The longest overdue blog should come first")
+	s.Equal(due.ID, blogs[1].ID)
+	s.Equal(domain.BlogStatusDraft, blogs[0].Status)
+
+	s.Run("Respects the limit", func() {
+		blogs, err := s.repo.FindDueScheduled(ctx, now, 1)
+		s.Require().NoError(err)
+		s.Len(blogs, 1)
+	})
+}
+
+func (s *BlogRepositoryTestSuite) TestMarkPublished() {
+	ctx := context.Background()
+	blog := s.createScheduled("Scheduled", time.Now().Add(-time.Minute))
+	publishedAt := time.Now().UTC().Truncate(time.Millisecond)
+
+	err := s.repo.MarkPublished(ctx, blog.ID, publishedAt)
+	s.Require().NoError(err)
+
+	fetched, err := s.repo.GetByID(ctx, blog.ID)
+	s.Require().NoError(err)
+	s.Equal(domain.BlogStatusPublished, fetched.Status)
+	s.Require().NotNil(fetched.PublishedAt)
+	s.WithinDuration(publishedAt, *fetched.PublishedAt, time.Millisecond)
+
+	s.Run("Second call loses the race", func() {
+		err := s.repo.MarkPublished(ctx, blog.ID, publishedAt)
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+
+	s.Run("Invalid ID", func() {
+		err := s.repo.MarkPublished(ctx, "not-an-id", publishedAt)
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+}
+
+func (s *BlogRepositoryTestSuite) TestModerationQueue() {
+	ctx := context.Background()
+	pending, err := domain.NewBlog("Awaiting Review", "Content", s.fixedAuthorID.Hex(), nil)
+	s.Require().NoError(err)
+	pending.SubmitForModeration()
+	s.Require().NoError(s.repo.Create(ctx, pending))
+	scheduled := s.createScheduled("Scheduled", time.Now().Add(-time.Minute))
+
+	s.Run("Pending blogs are hidden from listings", func() {
+		blogs, total, err := s.repo.SearchAndFilter(ctx, domain.BlogSearchFilterOptions{Page: 1, Limit: 10})
+		s.Require().NoError(err)
+		s.Zero(total)
+		s.Empty(blogs)
+	})
+
+	s.Run("Scheduler moves a due draft into the queue", func() {
+		s.Require().NoError(s.repo.MarkPending(ctx, scheduled.ID))
+		s.ErrorIs(s.repo.MarkPending(ctx, scheduled.ID), usecases.ErrNotFound)
+	})
+
+	s.Run("Queue lists pending blogs oldest first", func() {
+		blogs, total, err := s.repo.FetchPending(ctx, 1, 10)
+		s.Require().NoError(err)
+		s.Equal(int64(2), total)
+		s.Require().Len(blogs, 2)
+		s.Equal(pending.ID, blogs[0].ID)
+	})
+
+	s.Run("Approval publishes once", func() {
+		publishedAt := time.Now().UTC().Truncate(time.Millisecond)
+		s.Require().NoError(s.repo.ApprovePending(ctx, pending.ID, publishedAt))
+
+		fetched, err := s.repo.GetByID(ctx, pending.ID)
+		s.Require().NoError(err)
+		s.Equal(domain.BlogStatusPublished, fetched.Status)
+		s.Require().NotNil(fetched.PublishedAt)
+		s.WithinDuration(publishedAt, *fetched.PublishedAt, time.Millisecond)
+
+		s.ErrorIs(s.repo.ApprovePending(ctx, pending.ID, publishedAt), usecases.ErrNotFound)
+	})
+}
+
+func (s *BlogRepositoryTestSuite) TestSearchAndFilter_HidesDrafts() {
+	ctx := context.Background()
+	draft := s.createScheduled("Scheduled Draft", time.Now().Add(time.Hour))
+	published, err := domain.NewBlog("Live Blog", "Content", s.fixedAuthorID.Hex(), nil)
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Create(ctx, published))
+
+	for _, sortBy := range []string{"date", "popularity"} {
+		blogs, total, err := s.repo.SearchAndFilter(ctx, domain.BlogSearchFilterOptions{SortBy: sortBy, Page: 1, Limit: 10})
+		s.Require().NoError(err)
+		s.Equal(int64(1), total, "drafts should not be counted (sortBy=%s)", sortBy)
+		s.Require().Len(blogs, 1)
+		s.Equal(published.ID, blogs[0].ID)
+	}
+
+	s.Run("IncludeDrafts", func() {
+		blogs, total, err := s.repo.SearchAndFilter(ctx, domain.BlogSearchFilterOptions{Page: 1, Limit: 10, IncludeDrafts: true})
+		s.Require().NoError(err)
+		s.Equal(int64(2), total)
+		ids := []string{blogs[0].ID, blogs[1].ID}
+		s.Contains(ids, draft.ID)
+	})
+}
+
+func (s *BlogRepositoryTestSuite) TestSetPinned_OnlyOnePerAuthor() {
+	ctx := context.Background()
+	s.Require().NoError(s.repo.CreateBlogIndexes(ctx))
+	authorID := s.fixedAuthorID.Hex()
+	otherAuthorID := primitive.NewObjectID().Hex()
+
+	create := func(title, author string) *domain.Blog {
+		blog, err := domain.NewBlog(title, "Content", author, nil)
+		s.Require().NoError(err)
+		s.Require().NoError(s.repo.Create(ctx, blog))
+		return blog
+	}
+	first := create("First", authorID)
+	second := create("Second", authorID)
+	othersPin := create("Other Author", otherAuthorID)
+	s.Require().NoError(s.repo.SetPinned(ctx, othersPin.ID, otherAuthorID, true))
+
+	isPinned := func(id string) bool {
+		fetched, err := s.repo.GetByID(ctx, id)
+		s.Require().NoError(err)
+		return fetched.PinnedByAuthor
+	}
+
+	s.Require().NoError(s.repo.SetPinned(ctx, first.ID, authorID, true))
+	s.True(isPinned(first.ID))
+
+	// Pinning another blog moves the pin rather than adding a second one.
+	s.Require().NoError(s.repo.SetPinned(ctx, second.ID, authorID, true))
+	s.False(isPinned(first.ID), "The previously pinned blog should be unpinned")
+	s.True(isPinned(second.ID))
+	s.True(isPinned(othersPin.ID), "Other authors' pins must be left alone")
+
+	s.Run("Pinning an already pinned blog is idempotent", func() {
+		s.NoError(s.repo.SetPinned(ctx, second.ID, authorID, true))
+		s.True(isPinned(second.ID))
+	})
+
+	s.Run("Update does not change the pin", func() {
+		// A stale copy still thinks the first blog is pinned.
+		stale, err := s.repo.GetByID(ctx, first.ID)
+		s.Require().NoError(err)
+		stale.PinnedByAuthor = true
+		stale.Title = "Edited"
+		s.Require().NoError(s.repo.Update(ctx, stale))
+		s.False(isPinned(first.ID))
+		s.True(isPinned(second.ID))
+	})
+
+	s.Run("Unpin", func() {
+		s.Require().NoError(s.repo.SetPinned(ctx, second.ID, authorID, false))
+		s.False(isPinned(second.ID))
+	})
+
+	s.Run("Scoped to the author", func() {
+		err := s.repo.SetPinned(ctx, first.ID, otherAuthorID, true)
+		s.ErrorIs(err, usecases.ErrNotFound)
+		s.True(isPinned(othersPin.ID), "A failed pin must not unpin the other blogs")
+	})
+
+	s.Run("Invalid ID", func() {
+		err := s.repo.SetPinned(ctx, "not-an-id", authorID, true)
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+}
+
+func (s *BlogRepositoryTestSuite) TestSearchAndFilter_PinnedFirst() {
+	ctx := context.Background()
+	authorID := s.fixedAuthorID.Hex()
+	base := time.Now().UTC().Add(-time.Hour)
+
+	var blogs []*domain.Blog
+	for i, title := range []string{"Oldest", "Middle", "Newest"} {
+		blog, err := domain.NewBlog(title, "Content", authorID, nil)
+		s.Require().NoError(err)
+		blog.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		s.Require().NoError(s.repo.Create(ctx, blog))
+		blogs = append(blogs, blog)
+	}
+	s.Require().NoError(s.repo.SetPinned(ctx, blogs[0].ID, authorID, true))
+
+	for _, sortBy := range []string{"date", "popularity"} {
+		opts := domain.BlogSearchFilterOptions{AuthorIDs: []string{authorID}, SortBy: sortBy, PinnedFirst: true, Page: 1, Limit: 10}
+		result, _, err := s.repo.SearchAndFilter(ctx, opts)
+		s.Require().NoError(err)
+		s.Require().Len(result, 3)
+		s.Equal(blogs[0].ID, result[0].ID, "The pinned blog should come first (sortBy=%s)", sortBy)
+		s.True(result[0].PinnedByAuthor)
+	}
+
+	s.Run("Without PinnedFirst the usual order applies", func() {
+		opts := domain.BlogSearchFilterOptions{AuthorIDs: []string{authorID}, SortBy: "date", Page: 1, Limit: 10}
+		result, _, err := s.repo.SearchAndFilter(ctx, opts)
+		s.Require().NoError(err)
+		s.Require().Len(result, 3)
+		s.Equal(blogs[2].ID, result[0].ID)
+	})
+}
+
 func (s *BlogRepositoryTestSuite) TestIncrementLikes() {
 	ctx := context.Background()
 	// Arrange: Create a blog with a known number of likes.
@@ -460,6 +1078,23 @@ func (s *BlogRepositoryTestSuite) TestIncrementViews() {
 	s.Equal(2*ViewWeight, updatedBlog.EngagementScore, "Engagement score should be twice the view weight")
 }
 
+func (s *BlogRepositoryTestSuite) TestAddViews() {
+	ctx := context.Background()
+	blog, _ := domain.NewBlog("Title", "Content", s.fixedAuthorID.Hex(), nil)
+	err := s.repo.Create(ctx, blog)
+	s.Require().NoError(err)
+
+	err = s.repo.AddViews(ctx, blog.ID, 5)
+	s.NoError(err)
+
+	var updatedBlog BlogModel
+	objID, _ := primitive.ObjectIDFromHex(blog.ID)
+	err = testDB.Collection(s.collectionName).FindOne(ctx, bson.M{"_id": objID}).Decode(&updatedBlog)
+	s.NoError(err)
+	s.Equal(int64(5), updatedBlog.Views)
+	s.Equal(5*ViewWeight, updatedBlog.EngagementScore)
+}
+
 func (s *BlogRepositoryTestSuite) TestIncrementCommentCount() {
 	ctx := context.Background()
 	// Arrange: Create a blog with an initial comment count.
@@ -485,6 +1120,8 @@ func (s *BlogRepositoryTestSuite) TestIncrementCommentCount() {
 		s.NoError(err)
 		s.Equal(int64(4), updatedBlog.CommentsCount)
 		s.Equal(initialScore+CommentWeight, updatedBlog.EngagementScore)
+		s.Require().NotNil(updatedBlog.LastActivityAt)
+		s.True(updatedBlog.LastActivityAt.After(blog.LastActivityAt), "A new comment should advance the last activity")
 	})
 
 	s.Run("Decrement", func() {
@@ -501,6 +1138,52 @@ func (s *BlogRepositoryTestSuite) TestIncrementCommentCount() {
 	})
 }
 
+func (s *BlogRepositoryTestSuite) TestSearchAndFilter_SortByActivity() {
+	ctx := context.Background()
+	authorID := s.fixedAuthorID.Hex()
+	base := time.Now().UTC().Add(-time.Hour)
+
+	var blogs []*domain.Blog
+	for i, title := range []string{"Oldest", "Middle", "Newest"} {
+		blog, err := domain.NewBlog(title, "Content", authorID, nil)
+		s.Require().NoError(err)
+		blog.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		blog.LastActivityAt = blog.CreatedAt
+		s.Require().NoError(s.repo.Create(ctx, blog))
+		blogs = append(blogs, blog)
+	}
+	// A comment on the oldest blog makes it the most recently active.
+	s.Require().NoError(s.repo.IncrementCommentCount(ctx, blogs[0].ID, 1))
+
+	s.Run("Descending", func() {
+		opts := domain.BlogSearchFilterOptions{AuthorIDs: []string{authorID}, SortBy: "activity", Page: 1, Limit: 10}
+		result, _, err := s.repo.SearchAndFilter(ctx, opts)
+		s.Require().NoError(err)
+		s.Require().Len(result, 3)
+		s.Equal([]string{blogs[0].ID, blogs[2].ID, blogs[1].ID}, []string{result[0].ID, result[1].ID, result[2].ID})
+		s.True(result[0].LastActivityAt.After(result[0].CreatedAt))
+	})
+
+	s.Run("Ascending", func() {
+		opts := domain.BlogSearchFilterOptions{AuthorIDs: []string{authorID}, SortBy: "activity", SortOrder: domain.SortOrderASC, Page: 1, Limit: 10}
+		result, _, err := s.repo.SearchAndFilter(ctx, opts)
+		s.Require().NoError(err)
+		s.Require().Len(result, 3)
+		s.Equal([]string{blogs[1].ID, blogs[2].ID, blogs[0].ID}, []string{result[0].ID, result[1].ID, result[2].ID})
+	})
+
+	s.Run("An edit leaves the activity alone", func() {
+		stale, err := s.repo.GetByID(ctx, blogs[0].ID)
+		s.Require().NoError(err)
+		stale.LastActivityAt = base
+		s.Require().NoError(s.repo.Update(ctx, stale))
+
+		updated, err := s.repo.GetByID(ctx, blogs[0].ID)
+		s.Require().NoError(err)
+		s.True(updated.LastActivityAt.After(blogs[2].CreatedAt))
+	})
+}
+
 func (s *BlogRepositoryTestSuite) TestUpdateInteractionCounts() {
 	ctx := context.Background()
 	// Arrange: Create a blog with initial likes and dislikes.
@@ -526,3 +1209,22 @@ func (s *BlogRepositoryTestSuite) TestUpdateInteractionCounts() {
 	expectedScoreChange := (1 * LikeWeight) + (-1 * DislikeWeight)
 	s.Equal(expectedScoreChange, updatedBlog.EngagementScore, "Engagement score should reflect the combined change")
 }
+
+func (s *BlogRepositoryTestSuite) TestGetInteractionCounts() {
+	ctx := context.Background()
+	blog, _ := domain.NewBlog("Title", "Content", s.fixedAuthorID.Hex(), nil)
+	blog.Likes = 7
+	blog.Dislikes = 3
+	s.Require().NoError(s.repo.Create(ctx, blog))
+	s.Require().NoError(s.repo.IncrementLikes(ctx, blog.ID, 1))
+
+	likes, dislikes, err := s.repo.GetInteractionCounts(ctx, blog.ID)
+	s.NoError(err)
+	s.Equal(int64(8), likes)
+	s.Equal(int64(3), dislikes)
+
+	s.Run("Unknown blog", func() {
+		_, _, err := s.repo.GetInteractionCounts(ctx, primitive.NewObjectID().Hex())
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+}
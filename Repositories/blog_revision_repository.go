@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// BlogRevisionModel is how a snapshot of a blog's editable fields is stored in MongoDB.
+type BlogRevisionModel struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	BlogID    primitive.ObjectID `bson:"blog_id"`
+	Title     string             `bson:"title"`
+	Content   string             `bson:"content"`
+	Tags      []string           `bson:"tags"`
+	EditorID  primitive.ObjectID `bson:"editor_id"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+// BlogRevisionRepository implements the domain.IBlogRevisionRepository interface.
+type BlogRevisionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewBlogRevisionRepository is the constructor for the blog revision repository.
+func NewBlogRevisionRepository(col *mongo.Collection) *BlogRevisionRepository {
+	return &BlogRevisionRepository{
+		collection: col,
+	}
+}
+
+func (r *BlogRevisionRepository) CreateRevisionIndexes(ctx context.Context) error {
+	// Revisions are always listed and pruned per blog, newest first.
+	blogDateIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "blog_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	}
+	_, err := r.collection.Indexes().CreateOne(ctx, blogDateIndex)
+	return err
+}
+
+// --- Interface Implementations ---
+
+func (r *BlogRevisionRepository) Create(ctx context.Context, revision *domain.BlogRevision) error {
+	model, err := fromBlogRevisionDomain(revision)
+	if err != nil {
+		return err
+	}
+	model.ID = primitive.NewObjectID()
+
+	if _, err := r.collection.InsertOne(ctx, model); err != nil {
+		return err
+	}
+
+	revision.ID = model.ID.Hex()
+	return nil
+}
+
+// ListByBlogID returns every stored revision of a blog, newest first.
+func (r *BlogRevisionRepository) ListByBlogID(ctx context.Context, blogID string) ([]*domain.BlogRevision, error) {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, usecases.ErrNotFound
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"blog_id": blogObjID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []*domain.BlogRevision{}
+	for cursor.Next(ctx) {
+		var model BlogRevisionModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, toBlogRevisionDomain(&model))
+	}
+	return revisions, cursor.Err()
+}
+
+// GetByID fetches a single revision, scoped to its blog so IDs can't be mixed across posts.
+func (r *BlogRevisionRepository) GetByID(ctx context.Context, blogID, revisionID string) (*domain.BlogRevision, error) {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, usecases.ErrNotFound
+	}
+	revObjID, err := primitive.ObjectIDFromHex(revisionID)
+	if err != nil {
+		return nil, usecases.ErrNotFound
+	}
+
+	var model BlogRevisionModel
+	err = r.collection.FindOne(ctx, bson.M{"_id": revObjID, "blog_id": blogObjID}).Decode(&model)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, usecases.ErrNotFound
+		}
+		return nil, err
+	}
+	return toBlogRevisionDomain(&model), nil
+}
+
+// PruneOldest deletes all but the newest `keep` revisions of a blog.
+func (r *BlogRevisionRepository) PruneOldest(ctx context.Context, blogID string, keep int) error {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return usecases.ErrNotFound
+	}
+
+	// Find the IDs past the cap, then delete them in one go.
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(keep)).
+		SetProjection(bson.M{"_id": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{"blog_id": blogObjID}, findOptions)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var stale []primitive.ObjectID
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		stale = append(stale, doc.ID)
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": stale}})
+	return err
+}
+
+// --- Mapper Functions ---
+
+func toBlogRevisionDomain(model *BlogRevisionModel) *domain.BlogRevision {
+	return &domain.BlogRevision{
+		ID:        model.ID.Hex(),
+		BlogID:    model.BlogID.Hex(),
+		Title:     model.Title,
+		Content:   model.Content,
+		Tags:      model.Tags,
+		EditorID:  model.EditorID.Hex(),
+		CreatedAt: model.CreatedAt,
+	}
+}
+
+func fromBlogRevisionDomain(revision *domain.BlogRevision) (*BlogRevisionModel, error) {
+	blogID, err := primitive.ObjectIDFromHex(revision.BlogID)
+	if err != nil {
+		return nil, usecases.ErrInternal
+	}
+	editorID, err := primitive.ObjectIDFromHex(revision.EditorID)
+	if err != nil {
+		return nil, usecases.ErrInternal
+	}
+	return &BlogRevisionModel{
+		BlogID:    blogID,
+		Title:     revision.Title,
+		Content:   revision.Content,
+		Tags:      revision.Tags,
+		EditorID:  editorID,
+		CreatedAt: revision.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package repositories_test
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	. "A2SV_Starter_Project_Blog/Repositories"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BlogRevisionRepositoryTestSuite defines the suite for the revision repository integration tests.
+type BlogRevisionRepositoryTestSuite struct {
+	suite.Suite
+	repo           *BlogRevisionRepository
+	collectionName string
+	blogID         string
+	editorID       string
+}
+
+func (s *BlogRevisionRepositoryTestSuite) SetupTest() {
+	s.collectionName = "blog_revisions_test"
+	s.repo = NewBlogRevisionRepository(testDB.Collection(s.collectionName))
+	s.blogID = primitive.NewObjectID().Hex()
+	s.editorID = primitive.NewObjectID().Hex()
+}
+
+func (s *BlogRevisionRepositoryTestSuite) TearDownTest() {
+	err := testDB.Collection(s.collectionName).Drop(context.Background())
+	s.Require().NoError(err, "Failed to drop test collection")
+}
+
+func TestBlogRevisionRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode.")
+	}
+	t.Parallel()
+	suite.Run(t, new(BlogRevisionRepositoryTestSuite))
+}
+
+// createRevisions stores n revisions of the suite's blog, one minute apart, oldest first.
+func (s *BlogRevisionRepositoryTestSuite) createRevisions(n int) []*domain.BlogRevision {
+	base := time.Now().UTC().Add(-time.Hour).Truncate(time.Millisecond)
+	revisions := make([]*domain.BlogRevision, n)
+	for i := 0; i < n; i++ {
+		revisions[i] = &domain.BlogRevision{
+			BlogID:    s.blogID,
+			Title:     fmt.Sprintf("Title v%d", i+1),
+			Content:   "Content",
+			Tags:      []string{"go"},
+			EditorID:  s.editorID,
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		}
+		s.Require().NoError(s.repo.Create(context.Background(), revisions[i]))
+		s.Require().NotEmpty(revisions[i].ID)
+	}
+	return revisions
+}
+
+func (s *BlogRevisionRepositoryTestSuite) TestCreateAndGet() {
+	ctx := context.Background()
+	revisions := s.createRevisions(1)
+
+	fetched, err := s.repo.GetByID(ctx, s.blogID, revisions[0].ID)
+	s.Require().NoError(err)
+	s.Equal(revisions[0].Title, fetched.Title)
+	s.Equal(s.editorID, fetched.EditorID)
+	s.Equal([]string{"go"}, fetched.Tags)
+
+	s.Run("Scoped to its blog", func() {
+		_, err := s.repo.GetByID(ctx, primitive.NewObjectID().Hex(), revisions[0].ID)
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+
+	s.Run("Invalid ID", func() {
+		_, err := s.repo.GetByID(ctx, s.blogID, "not-an-id")
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+}
+
+func (s *BlogRevisionRepositoryTestSuite) TestListByBlogID_NewestFirst() {
+	revisions := s.createRevisions(3)
+
+	listed, err := s.repo.ListByBlogID(context.Background(), s.blogID)
+	s.Require().NoError(err)
+	s.Require().Len(listed, 3)
+	s.Equal(revisions[2].ID, listed[0].ID)
+	s.Equal(revisions[0].ID, listed[2].ID)
+}
+
+func (s *BlogRevisionRepositoryTestSuite) TestPruneOldest() {
+	ctx := context.Background()
+	revisions := s.createRevisions(5)
+	// A revision of another blog must never be touched by pruning.
+	other := &domain.BlogRevision{BlogID: primitive.NewObjectID().Hex(), Title: "Other", EditorID: s.editorID, CreatedAt: time.Now()}
+	s.Require().NoError(s.repo.Create(ctx, other))
+
+	err := s.repo.PruneOldest(ctx, s.blogID, 2)
+	s.Require().NoError(err)
+
+	listed, err := s.repo.ListByBlogID(ctx, s.blogID)
+	s.Require().NoError(err)
+	s.Require().Len(listed, 2, "Only the newest revisions should be kept")
+	s.Equal(revisions[4].ID, listed[0].ID)
+	s.Equal(revisions[3].ID, listed[1].ID)
+
+	_, err = s.repo.GetByID(ctx, other.BlogID, other.ID)
+	s.NoError(err)
+
+	s.Run("Under capacity is a no-op", func() {
+		s.NoError(s.repo.PruneOldest(ctx, s.blogID, 10))
+		listed, err := s.repo.ListByBlogID(ctx, s.blogID)
+		s.NoError(err)
+		s.Len(listed, 2)
+	})
+}
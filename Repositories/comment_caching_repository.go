@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -32,22 +33,31 @@ func NewCachingCommentRepository(next domain.ICommentRepository, cache domain.IC
 	}
 }
 
-// FetchByBlogID caches the first page of top-level comments for a blog.
-func (r *CachingCommentRepository) FetchByBlogID(ctx context.Context, blogID string, page, limit int64) ([]*domain.Comment, int64, error) {
-	cacheKey := fmt.Sprintf("comments:blog:%s:page:%d:limit:%d", blogID, page, limit)
+// FetchByBlogID caches every page of top-level comments for a blog. Each sort is cached under
+// its own key, and all of them are tracked in the blog's set so one Create clears every variant.
+func (r *CachingCommentRepository) FetchByBlogID(ctx context.Context, blogID string, page, limit int64, sort domain.CommentSort) ([]*domain.Comment, int64, error) {
+	cacheKey := fmt.Sprintf("comments:blog:%s:sort:%s:page:%d:limit:%d", blogID, sort, page, limit)
 	trackerKey := fmt.Sprintf("tracker:comments:blog:%s", blogID)
 
 	// Try to get from cache
 	cachedData, err := r.cache.Get(ctx, cacheKey)
-	if err == nil {
+	switch {
+	case err == nil:
 		var result paginatedCommentResult
-		if json.Unmarshal(cachedData, &result) == nil {
+		jsonErr := json.Unmarshal(cachedData, &result)
+		if jsonErr == nil {
 			return result.Comments, result.Total, nil
 		}
+		log.Printf("[CACHE] Discarding corrupt comments entry for key %s: %v", cacheKey, jsonErr)
+	case errors.Is(err, domain.ErrNotFound):
+		// Plain cache miss.
+	default:
+		// The cache is unavailable. Fail open and serve the request from the database.
+		log.Printf("[CACHE] Error getting comments from cache: %v", err)
 	}
 
 	// Cache MISS, fetch from the primary repository.
-	comments, total, err := r.next.FetchByBlogID(ctx, blogID, page, limit)
+	comments, total, err := r.next.FetchByBlogID(ctx, blogID, page, limit, sort)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -74,11 +84,19 @@ func (r *CachingCommentRepository) FetchReplies(ctx context.Context, parentID st
 	trackerKey := fmt.Sprintf("tracker:comments:replies:%s", parentID)
 
 	cachedData, err := r.cache.Get(ctx, cacheKey)
-	if err == nil {
+	switch {
+	case err == nil:
 		var result paginatedCommentResult
-		if json.Unmarshal(cachedData, &result) == nil {
+		jsonErr := json.Unmarshal(cachedData, &result)
+		if jsonErr == nil {
 			return result.Comments, result.Total, nil
 		}
+		log.Printf("[CACHE] Discarding corrupt comments entry for key %s: %v", cacheKey, jsonErr)
+	case errors.Is(err, domain.ErrNotFound):
+		// Plain cache miss.
+	default:
+		// The cache is unavailable. Fail open and serve the request from the database.
+		log.Printf("[CACHE] Error getting comments from cache: %v", err)
 	}
 
 	// Cache MISS
@@ -91,8 +109,12 @@ func (r *CachingCommentRepository) FetchReplies(ctx context.Context, parentID st
 	resultToCache := paginatedCommentResult{Comments: comments, Total: total}
 	dataToCache, jsonErr := json.Marshal(resultToCache)
 	if jsonErr == nil {
-		r.cache.Set(ctx, cacheKey, dataToCache, r.defaultTTL)
-		r.cache.AddToSet(ctx, trackerKey, cacheKey)
+		if err := r.cache.Set(ctx, cacheKey, dataToCache, r.defaultTTL); err != nil {
+			log.Printf("[CACHE] Error setting replies cache for key %s: %v", cacheKey, err)
+		}
+		if err := r.cache.AddToSet(ctx, trackerKey, cacheKey); err != nil {
+			log.Printf("[CACHE] Error adding key to tracker set %s: %v", trackerKey, err)
+		}
 	}
 
 	return comments, total, nil
@@ -104,16 +126,46 @@ func (r *CachingCommentRepository) Create(ctx context.Context, comment *domain.C
 		return err
 	}
 
-	// Determine which tracker set to use.
-	var trackerKey string
-	if comment.ParentID != nil {
-		trackerKey = fmt.Sprintf("tracker:comments:replies:%s", *comment.ParentID)
-	} else {
-		trackerKey = fmt.Sprintf("tracker:comments:blog:%s", comment.BlogID)
+	// Invalidate using the tracker set.
+	return r.invalidateCommentCache(ctx, listTrackerKey(comment))
+}
+
+// Approve makes a pending comment visible, so the list it belongs to must be invalidated.
+func (r *CachingCommentRepository) Approve(ctx context.Context, commentID string) error {
+	if err := r.next.Approve(ctx, commentID); err != nil {
+		return err
 	}
 
-	// Invalidate using the tracker set.
-	return r.invalidateCommentCache(ctx, trackerKey)
+	comment, err := r.next.GetByID(ctx, commentID)
+	if err != nil {
+		log.Printf("[CACHE] Could not load approved comment %s to invalidate its list: %v", commentID, err)
+		return nil
+	}
+	return r.invalidateCommentCache(ctx, listTrackerKey(comment))
+}
+
+// DeleteIfNoReplies takes the comment out of its list, so that list must be invalidated. The
+// comment is loaded first, since afterwards there is nothing left to tell which list it was in.
+func (r *CachingCommentRepository) DeleteIfNoReplies(ctx context.Context, commentID string) error {
+	comment, lookupErr := r.next.GetByID(ctx, commentID)
+	if err := r.next.DeleteIfNoReplies(ctx, commentID); err != nil {
+		return err
+	}
+
+	if lookupErr != nil {
+		log.Printf("[CACHE] Could not load deleted comment %s to invalidate its list: %v", commentID, lookupErr)
+		return nil
+	}
+	return r.invalidateCommentCache(ctx, listTrackerKey(comment))
+}
+
+// listTrackerKey names the tracker set of the list a comment appears in: its parent's
+// replies, or its blog's top-level comments.
+func listTrackerKey(comment *domain.Comment) string {
+	if comment.ParentID != nil {
+		return fmt.Sprintf("tracker:comments:replies:%s", *comment.ParentID)
+	}
+	return fmt.Sprintf("tracker:comments:blog:%s", comment.BlogID)
 }
 
 // --- New Invalidation Helper ---
@@ -161,3 +213,26 @@ func (r *CachingCommentRepository) IncrementReplyCount(ctx context.Context, pare
 	// We rely on TTL for this to update in the cache.
 	return r.next.IncrementReplyCount(ctx, parentID, value)
 }
+
+func (r *CachingCommentRepository) CountByBlogID(ctx context.Context, blogID string) (int64, error) {
+	return r.next.CountByBlogID(ctx, blogID)
+}
+
+// FetchAllByBlogID backs exports, which are rare and must be complete, so it always reads through.
+func (r *CachingCommentRepository) FetchAllByBlogID(ctx context.Context, blogID string) ([]*domain.Comment, error) {
+	return r.next.FetchAllByBlogID(ctx, blogID)
+}
+
+func (r *CachingCommentRepository) SearchInBlog(ctx context.Context, blogID, query string, page, limit int64) ([]*domain.Comment, int64, error) {
+	return r.next.SearchInBlog(ctx, blogID, query, page, limit)
+}
+
+// FetchPending is an admin view that must be current, so it always reads through.
+func (r *CachingCommentRepository) FetchPending(ctx context.Context, page, limit int64) ([]*domain.Comment, int64, error) {
+	return r.next.FetchPending(ctx, page, limit)
+}
+
+// DeletePending removes a comment that was never listed, so there is nothing to invalidate.
+func (r *CachingCommentRepository) DeletePending(ctx context.Context, commentID string) error {
+	return r.next.DeletePending(ctx, commentID)
+}
@@ -3,11 +3,13 @@ package repositories_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
 	domain "A2SV_Starter_Project_Blog/Domain"
 	. "A2SV_Starter_Project_Blog/Repositories"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
@@ -31,8 +33,12 @@ func (m *MockCommentRepository) Create(ctx context.Context, comment *domain.Comm
 	args := m.Called(ctx, comment)
 	return args.Error(0)
 }
-func (m *MockCommentRepository) GetByID(ctx context.Context, commentID string) (*domain.Comment, error) { /* ... */
-	return nil, nil
+func (m *MockCommentRepository) GetByID(ctx context.Context, commentID string) (*domain.Comment, error) {
+	args := m.Called(ctx, commentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Comment), args.Error(1)
 }
 func (m *MockCommentRepository) Update(ctx context.Context, comment *domain.Comment) error { /* ... */
 	return nil
@@ -40,8 +46,8 @@ func (m *MockCommentRepository) Update(ctx context.Context, comment *domain.Comm
 func (m *MockCommentRepository) Anonymize(ctx context.Context, commentID string) error { /* ... */
 	return nil
 }
-func (m *MockCommentRepository) FetchByBlogID(ctx context.Context, blogID string, page, limit int64) ([]*domain.Comment, int64, error) {
-	args := m.Called(ctx, blogID, page, limit)
+func (m *MockCommentRepository) FetchByBlogID(ctx context.Context, blogID string, page, limit int64, sort domain.CommentSort) ([]*domain.Comment, int64, error) {
+	args := m.Called(ctx, blogID, page, limit, sort)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
@@ -57,6 +63,44 @@ func (m *MockCommentRepository) FetchReplies(ctx context.Context, parentID strin
 func (m *MockCommentRepository) IncrementReplyCount(ctx context.Context, parentID string, value int) error { /* ... */
 	return nil
 }
+func (m *MockCommentRepository) CountByBlogID(ctx context.Context, blogID string) (int64, error) {
+	args := m.Called(ctx, blogID)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockCommentRepository) FetchAllByBlogID(ctx context.Context, blogID string) ([]*domain.Comment, error) {
+	args := m.Called(ctx, blogID)
+	var comments []*domain.Comment
+	if args.Get(0) != nil {
+		comments = args.Get(0).([]*domain.Comment)
+	}
+	return comments, args.Error(1)
+}
+func (m *MockCommentRepository) SearchInBlog(ctx context.Context, blogID, query string, page, limit int64) ([]*domain.Comment, int64, error) {
+	args := m.Called(ctx, blogID, query, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.Comment), args.Get(1).(int64), args.Error(2)
+}
+func (m *MockCommentRepository) FetchPending(ctx context.Context, page, limit int64) ([]*domain.Comment, int64, error) {
+	args := m.Called(ctx, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.Comment), args.Get(1).(int64), args.Error(2)
+}
+func (m *MockCommentRepository) Approve(ctx context.Context, commentID string) error {
+	args := m.Called(ctx, commentID)
+	return args.Error(0)
+}
+func (m *MockCommentRepository) DeleteIfNoReplies(ctx context.Context, commentID string) error {
+	args := m.Called(ctx, commentID)
+	return args.Error(0)
+}
+func (m *MockCommentRepository) DeletePending(ctx context.Context, commentID string) error {
+	args := m.Called(ctx, commentID)
+	return args.Error(0)
+}
 
 // --- The Test Suite ---
 
@@ -83,7 +127,7 @@ func (s *CachingCommentDecoratorSuite) TestFetchByBlogID_CacheMiss_CachesAnyPage
 	ctx := context.Background()
 	// Test with a page other than 1 to prove the logic works for all pages.
 	blogID, page, limit := "blog123", int64(2), int64(20)
-	cacheKey := "comments:blog:blog123:page:2:limit:20"
+	cacheKey := "comments:blog:blog123:sort:oldest:page:2:limit:20"
 	trackerKey := "tracker:comments:blog:blog123"
 
 	expectedResult := paginatedCommentResult{
@@ -94,12 +138,12 @@ func (s *CachingCommentDecoratorSuite) TestFetchByBlogID_CacheMiss_CachesAnyPage
 
 	// --- Arrange: Mock expectations for a cache miss ---
 	s.mockCache.On("Get", ctx, cacheKey).Return(nil, domain.ErrNotFound).Once()
-	s.mockRepo.On("FetchByBlogID", ctx, blogID, page, limit).Return(expectedResult.Comments, expectedResult.Total, nil).Once()
+	s.mockRepo.On("FetchByBlogID", ctx, blogID, page, limit, domain.CommentSortOldest).Return(expectedResult.Comments, expectedResult.Total, nil).Once()
 	s.mockCache.On("Set", ctx, cacheKey, resultBytes, 2*time.Minute).Return(nil).Once()
 	s.mockCache.On("AddToSet", ctx, trackerKey, []interface{}{cacheKey}).Return(nil).Once()
 
 	// --- Act ---
-	comments, total, err := s.cachingRepo.FetchByBlogID(ctx, blogID, page, limit)
+	comments, total, err := s.cachingRepo.FetchByBlogID(ctx, blogID, page, limit, domain.CommentSortOldest)
 
 	// --- Assert ---
 	s.NoError(err)
@@ -109,6 +153,38 @@ func (s *CachingCommentDecoratorSuite) TestFetchByBlogID_CacheMiss_CachesAnyPage
 	s.mockRepo.AssertExpectations(s.T())
 }
 
+func (s *CachingCommentDecoratorSuite) TestFetchByBlogID_SortsAreCachedSeparately() {
+	ctx := context.Background()
+	blogID, page, limit := "blog123", int64(1), int64(10)
+	trackerKey := "tracker:comments:blog:blog123"
+	newestKey := "comments:blog:blog123:sort:newest:page:1:limit:10"
+	topKey := "comments:blog:blog123:sort:top:page:1:limit:10"
+	newest := []*domain.Comment{{ID: "c2"}, {ID: "c1"}}
+	top := []*domain.Comment{{ID: "c1", ReplyCount: 3}, {ID: "c2"}}
+
+	// --- Arrange: The newest page is cached; the top page is not, even though it's the same page and limit ---
+	newestBytes, _ := json.Marshal(paginatedCommentResult{Comments: newest, Total: 2})
+	s.mockCache.On("Get", ctx, newestKey).Return(newestBytes, nil).Once()
+	s.mockCache.On("Get", ctx, topKey).Return(nil, domain.ErrNotFound).Once()
+	s.mockRepo.On("FetchByBlogID", ctx, blogID, page, limit, domain.CommentSortTop).Return(top, int64(2), nil).Once()
+	s.mockCache.On("Set", ctx, topKey, mock.Anything, 2*time.Minute).Return(nil).Once()
+	// Both sorts share the blog's tracker set, so a new comment clears every variant.
+	s.mockCache.On("AddToSet", ctx, trackerKey, []interface{}{topKey}).Return(nil).Once()
+
+	// --- Act ---
+	gotNewest, _, err := s.cachingRepo.FetchByBlogID(ctx, blogID, page, limit, domain.CommentSortNewest)
+	s.Require().NoError(err)
+	gotTop, _, err := s.cachingRepo.FetchByBlogID(ctx, blogID, page, limit, domain.CommentSortTop)
+	s.Require().NoError(err)
+
+	// --- Assert ---
+	s.Equal("c2", gotNewest[0].ID)
+	s.Equal("c1", gotTop[0].ID)
+	s.mockCache.AssertExpectations(s.T())
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockRepo.AssertNotCalled(s.T(), "FetchByBlogID", ctx, blogID, page, limit, domain.CommentSortNewest)
+}
+
 func (s *CachingCommentDecoratorSuite) TestFetchReplies_CacheHit() {
 	ctx := context.Background()
 	parentID, page, limit := "parent123", int64(1), int64(10)
@@ -135,15 +211,65 @@ func (s *CachingCommentDecoratorSuite) TestFetchReplies_CacheHit() {
 	s.mockRepo.AssertNotCalled(s.T(), "FetchReplies", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
+func (s *CachingCommentDecoratorSuite) TestFetchByBlogID_CacheUnavailable_FailsOpen() {
+	ctx := context.Background()
+	blogID, page, limit := "blog123", int64(1), int64(10)
+	cacheKey := "comments:blog:blog123:sort:oldest:page:1:limit:10"
+	trackerKey := "tracker:comments:blog:blog123"
+	expectedComments := []*domain.Comment{{ID: "comment1", BlogID: blogID}}
+	redisDown := errors.New("dial tcp: connection refused")
+
+	// --- Arrange: Every cache call fails ---
+	s.mockCache.On("Get", ctx, cacheKey).Return(nil, redisDown).Once()
+	s.mockRepo.On("FetchByBlogID", ctx, blogID, page, limit, domain.CommentSortOldest).Return(expectedComments, int64(1), nil).Once()
+	s.mockCache.On("Set", ctx, cacheKey, mock.Anything, 2*time.Minute).Return(redisDown).Once()
+	s.mockCache.On("AddToSet", ctx, trackerKey, []interface{}{cacheKey}).Return(redisDown).Once()
+
+	// --- Act ---
+	comments, total, err := s.cachingRepo.FetchByBlogID(ctx, blogID, page, limit, domain.CommentSortOldest)
+
+	// --- Assert: The comments still come back from the database ---
+	s.NoError(err)
+	s.Equal(expectedComments, comments)
+	s.Equal(int64(1), total)
+	s.mockCache.AssertExpectations(s.T())
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *CachingCommentDecoratorSuite) TestFetchReplies_CacheUnavailable_FailsOpen() {
+	ctx := context.Background()
+	parentID, page, limit := "parent123", int64(1), int64(10)
+	cacheKey := "comments:replies:parent123:page:1:limit:10"
+	trackerKey := "tracker:comments:replies:parent123"
+	expectedReplies := []*domain.Comment{{ID: "reply1"}}
+	redisDown := errors.New("dial tcp: connection refused")
+
+	// --- Arrange ---
+	s.mockCache.On("Get", ctx, cacheKey).Return(nil, redisDown).Once()
+	s.mockRepo.On("FetchReplies", ctx, parentID, page, limit).Return(expectedReplies, int64(1), nil).Once()
+	s.mockCache.On("Set", ctx, cacheKey, mock.Anything, 2*time.Minute).Return(redisDown).Once()
+	s.mockCache.On("AddToSet", ctx, trackerKey, []interface{}{cacheKey}).Return(redisDown).Once()
+
+	// --- Act ---
+	comments, total, err := s.cachingRepo.FetchReplies(ctx, parentID, page, limit)
+
+	// --- Assert ---
+	s.NoError(err)
+	s.Equal(expectedReplies, comments)
+	s.Equal(int64(1), total)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
 func (s *CachingCommentDecoratorSuite) TestCreate_TopLevelComment_InvalidatesAllCachedLists() {
 	ctx := context.Background()
 	newComment := &domain.Comment{ID: "newComment", BlogID: "blog123", ParentID: nil}
 	trackerKey := "tracker:comments:blog:blog123"
-	// Simulate that multiple pages/limits were cached for this blog.
+	// Simulate that multiple pages, limits and sorts were cached for this blog.
 	keysToInvalidate := []string{
-		"comments:blog:blog123:page:1:limit:10",
-		"comments:blog:blog123:page:2:limit:10",
-		"comments:blog:blog123:page:1:limit:50",
+		"comments:blog:blog123:sort:oldest:page:1:limit:10",
+		"comments:blog:blog123:sort:oldest:page:2:limit:10",
+		"comments:blog:blog123:sort:newest:page:1:limit:10",
+		"comments:blog:blog123:sort:top:page:1:limit:50",
 	}
 
 	// --- Arrange ---
@@ -207,3 +333,57 @@ func (s *CachingCommentDecoratorSuite) TestCreate_Invalidation_NoCachedKeys() {
 	// Assert that DeleteKeys was NOT called, because there was nothing to delete.
 	s.mockCache.AssertNotCalled(s.T(), "DeleteKeys", mock.Anything, mock.Anything)
 }
+
+func (s *CachingCommentDecoratorSuite) TestDeleteIfNoReplies_InvalidatesTheCommentsList() {
+	ctx := context.Background()
+	deleted := &domain.Comment{ID: "comment1", BlogID: "blog123"}
+	trackerKey := "tracker:comments:blog:blog123"
+	keysToInvalidate := []string{"comments:blog:blog123:sort:oldest:page:1:limit:10"}
+
+	s.Run("Deleted", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetByID", ctx, "comment1").Return(deleted, nil).Once()
+		s.mockRepo.On("DeleteIfNoReplies", ctx, "comment1").Return(nil).Once()
+		s.mockCache.On("GetSetMembers", ctx, trackerKey).Return(keysToInvalidate, nil).Once()
+		s.mockCache.On("DeleteKeys", ctx, append(keysToInvalidate, trackerKey)).Return(nil).Once()
+
+		err := s.cachingRepo.DeleteIfNoReplies(ctx, "comment1")
+
+		s.NoError(err)
+		s.mockRepo.AssertExpectations(s.T())
+		s.mockCache.AssertExpectations(s.T())
+	})
+
+	s.Run("Has Replies", func() {
+		s.SetupTest()
+		s.mockRepo.On("GetByID", ctx, "comment1").Return(deleted, nil).Once()
+		s.mockRepo.On("DeleteIfNoReplies", ctx, "comment1").Return(usecases.ErrNotFound).Once()
+
+		err := s.cachingRepo.DeleteIfNoReplies(ctx, "comment1")
+
+		s.ErrorIs(err, usecases.ErrNotFound)
+		s.mockCache.AssertNotCalled(s.T(), "DeleteKeys", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CachingCommentDecoratorSuite) TestApprove_InvalidatesTheCommentsList() {
+	ctx := context.Background()
+	parentID := "parent123"
+	approved := &domain.Comment{ID: "reply1", BlogID: "blog123", ParentID: &parentID}
+	trackerKey := "tracker:comments:replies:parent123"
+	keysToInvalidate := []string{"comments:replies:parent123:page:1:limit:10"}
+
+	// --- Arrange ---
+	s.mockRepo.On("Approve", ctx, "reply1").Return(nil).Once()
+	s.mockRepo.On("GetByID", ctx, "reply1").Return(approved, nil).Once()
+	s.mockCache.On("GetSetMembers", ctx, trackerKey).Return(keysToInvalidate, nil).Once()
+	s.mockCache.On("DeleteKeys", ctx, append(keysToInvalidate, trackerKey)).Return(nil).Once()
+
+	// --- Act ---
+	err := s.cachingRepo.Approve(ctx, "reply1")
+
+	// --- Assert ---
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockCache.AssertExpectations(s.T())
+}
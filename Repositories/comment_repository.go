@@ -22,10 +22,15 @@ type CommentModel struct {
 	ParentID   *primitive.ObjectID `bson:"parent_id,omitempty"` // Pointer for top-level vs. reply distinction
 	Content    string              `bson:"content"`
 	ReplyCount int64               `bson:"reply_count"`
+	Pending    bool                `bson:"pending,omitempty"`
 	CreatedAt  time.Time           `bson:"created_at"`
 	UpdatedAt  time.Time           `bson:"updated_at"`
 }
 
+// notPending matches approved comments. The field is omitted once a comment is approved,
+// and comments written without pre-moderation never had it.
+var notPending = bson.M{"$ne": true}
+
 type CommentRepository struct {
 	collection *mongo.Collection
 }
@@ -42,6 +47,7 @@ func toCommentDomain(model *CommentModel) *domain.Comment {
 		BlogID:     model.BlogID.Hex(),
 		Content:    model.Content,
 		ReplyCount: model.ReplyCount,
+		Pending:    model.Pending,
 		CreatedAt:  model.CreatedAt,
 		UpdatedAt:  model.UpdatedAt,
 	}
@@ -66,6 +72,7 @@ func fromCommentDomain(comment *domain.Comment) (*CommentModel, error) {
 		Content:    comment.Content,
 		BlogID:     blogID,
 		ReplyCount: comment.ReplyCount,
+		Pending:    comment.Pending,
 		CreatedAt:  comment.CreatedAt,
 		UpdatedAt:  comment.UpdatedAt,
 	}
@@ -107,10 +114,30 @@ func (r *CommentRepository) CreateCommentIndexes(ctx context.Context) error {
 		},
 	}
 
+	// Index for listing a blog's top-level comments by most replies first.
+	topCommentsIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "blog_id", Value: 1},
+			{Key: "parent_id", Value: 1},
+			{Key: "reply_count", Value: -1},
+			{Key: "created_at", Value: 1},
+		},
+	}
+
+	// Text index for searching within a blog. The blog_id prefix keeps each search to one blog's comments.
+	contentTextIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "blog_id", Value: 1},
+			{Key: "content", Value: "text"},
+		},
+	}
+
 	// Create the indexes. This command is idempotent.
 	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
 		blogCommentsIndex,
 		repliesIndex,
+		topCommentsIndex,
+		contentTextIndex,
 	})
 	return err
 }
@@ -136,7 +163,7 @@ func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment)
 func (r *CommentRepository) GetByID(ctx context.Context, commentID string) (*domain.Comment, error) {
 	objID, err := primitive.ObjectIDFromHex(commentID)
 	if err != nil {
-		return nil, usecases.ErrInternal
+		return nil, usecases.ErrNotFound // An invalid ID can't match any comment.
 	}
 
 	var model CommentModel
@@ -197,26 +224,95 @@ func (r *CommentRepository) Anonymize(ctx context.Context, commentID string) err
 	return nil
 }
 
-func (r *CommentRepository) FetchByBlogID(ctx context.Context, blogID string, page, limit int64) ([]*domain.Comment, int64, error) {
+func (r *CommentRepository) DeleteIfNoReplies(ctx context.Context, commentID string) error {
+	objID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return usecases.ErrNotFound
+	}
+	// $not also matches comments stored before reply_count existed.
+	filter := bson.M{"_id": objID, "reply_count": bson.M{"$not": bson.M{"$gt": 0}}}
+	res, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return usecases.ErrNotFound
+	}
+	return nil
+}
+
+// commentSortOrders maps each sort to its Mongo sort document. _id breaks ties so pages don't overlap.
+var commentSortOrders = map[domain.CommentSort]bson.D{
+	domain.CommentSortOldest: {{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+	domain.CommentSortNewest: {{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+	domain.CommentSortTop:    {{Key: "reply_count", Value: -1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+}
+
+func (r *CommentRepository) FetchByBlogID(ctx context.Context, blogID string, page, limit int64, sort domain.CommentSort) ([]*domain.Comment, int64, error) {
 	blogObjID, err := primitive.ObjectIDFromHex(blogID)
 	if err != nil {
-		return nil, 0, usecases.ErrInternal
+		return nil, 0, usecases.ErrNotFound // An invalid ID can't match any comment.
 	}
-	filter := bson.M{"blog_id": blogObjID, "parent_id": nil}
-	return r.fetchPaginated(ctx, filter, page, limit)
+	sortOrder, ok := commentSortOrders[sort]
+	if !ok {
+		sortOrder = commentSortOrders[domain.CommentSortOldest]
+	}
+	filter := bson.M{"blog_id": blogObjID, "parent_id": nil, "pending": notPending}
+	return r.fetchPaginated(ctx, filter, page, limit, sortOrder)
 }
 
 func (r *CommentRepository) FetchReplies(ctx context.Context, parentID string, page, limit int64) ([]*domain.Comment, int64, error) {
 	parentObjID, err := primitive.ObjectIDFromHex(parentID)
 	if err != nil {
-		return nil, 0, usecases.ErrInternal
+		return nil, 0, usecases.ErrNotFound // An invalid ID can't match any comment.
 	}
-	filter := bson.M{"parent_id": parentObjID}
-	return r.fetchPaginated(ctx, filter, page, limit)
+	filter := bson.M{"parent_id": parentObjID, "pending": notPending}
+	// Replies always read in conversation order.
+	return r.fetchPaginated(ctx, filter, page, limit, commentSortOrders[domain.CommentSortOldest])
+}
+
+func (r *CommentRepository) FetchAllByBlogID(ctx context.Context, blogID string) ([]*domain.Comment, error) {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, usecases.ErrNotFound // An invalid ID can't match any comment.
+	}
+	// Replies carry their blog's ID too, so one query loads the whole thread.
+	findOptions := options.Find().SetSort(commentSortOrders[domain.CommentSortOldest])
+	cursor, err := r.collection.Find(ctx, bson.M{"blog_id": blogObjID, "pending": notPending}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var comments []*domain.Comment
+	for cursor.Next(ctx) {
+		var model CommentModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, err
+		}
+		comments = append(comments, toCommentDomain(&model))
+	}
+	return comments, cursor.Err()
+}
+
+func (r *CommentRepository) SearchInBlog(ctx context.Context, blogID, query string, page, limit int64) ([]*domain.Comment, int64, error) {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return nil, 0, usecases.ErrNotFound // An invalid ID can't match any comment.
+	}
+	// Deleted comments are anonymized rather than removed; their missing author leaves them out.
+	filter := bson.M{
+		"blog_id":   blogObjID,
+		"author_id": bson.M{"$exists": true},
+		"pending":   notPending,
+		"$text":     bson.M{"$search": query},
+	}
+	sort := bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}
+	return r.fetchPaginated(ctx, filter, page, limit, sort)
 }
 
 // fetchPaginated is a helper to reduce code duplication between FetchByBlogID and FetchReplies.
-func (r *CommentRepository) fetchPaginated(ctx context.Context, filter bson.M, page, limit int64) ([]*domain.Comment, int64, error) {
+func (r *CommentRepository) fetchPaginated(ctx context.Context, filter bson.M, page, limit int64, sort bson.D) ([]*domain.Comment, int64, error) {
 	total, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
 		return nil, 0, err
@@ -225,7 +321,7 @@ func (r *CommentRepository) fetchPaginated(ctx context.Context, filter bson.M, p
 	findOptions := options.Find()
 	findOptions.SetLimit(limit)
 	findOptions.SetSkip((page - 1) * limit)
-	findOptions.SetSort(bson.D{{Key: "created_at", Value: 1}}) // Sort oldest first for conversation flow
+	findOptions.SetSort(sort)
 
 	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
@@ -244,6 +340,19 @@ func (r *CommentRepository) fetchPaginated(ctx context.Context, filter bson.M, p
 	return comments, total, cursor.Err()
 }
 
+// CountByBlogID counts every comment and reply of a blog. Deleted comments are anonymized
+// rather than removed, so they are recognized by their missing author and left out,
+// matching how DeleteComment decrements the blog's counter. Pending comments are left out too,
+// since they only count once approved.
+func (r *CommentRepository) CountByBlogID(ctx context.Context, blogID string) (int64, error) {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return 0, usecases.ErrInternal
+	}
+	filter := bson.M{"blog_id": blogObjID, "author_id": bson.M{"$exists": true}, "pending": notPending}
+	return r.collection.CountDocuments(ctx, filter)
+}
+
 func (r *CommentRepository) IncrementReplyCount(ctx context.Context, parentID string, value int) error {
 	parentObjID, err := primitive.ObjectIDFromHex(parentID)
 	if err != nil {
@@ -260,3 +369,44 @@ func (r *CommentRepository) IncrementReplyCount(ctx context.Context, parentID st
 	}
 	return nil
 }
+
+// FetchPending lists comments awaiting moderation across all blogs, oldest first.
+func (r *CommentRepository) FetchPending(ctx context.Context, page, limit int64) ([]*domain.Comment, int64, error) {
+	return r.fetchPaginated(ctx, bson.M{"pending": true}, page, limit, commentSortOrders[domain.CommentSortOldest])
+}
+
+// Approve clears a comment's pending flag. It returns ErrNotFound if the comment is not pending,
+// so the counters it feeds are only incremented once.
+func (r *CommentRepository) Approve(ctx context.Context, commentID string) error {
+	objID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return usecases.ErrNotFound
+	}
+	filter := bson.M{"_id": objID, "pending": true}
+	update := bson.M{"$unset": bson.M{"pending": ""}}
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return usecases.ErrNotFound
+	}
+	return nil
+}
+
+// DeletePending removes a rejected comment. Only pending comments are removed outright;
+// published ones are anonymized so their replies keep their place in the thread.
+func (r *CommentRepository) DeletePending(ctx context.Context, commentID string) error {
+	objID, err := primitive.ObjectIDFromHex(commentID)
+	if err != nil {
+		return usecases.ErrNotFound
+	}
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": objID, "pending": true})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return usecases.ErrNotFound
+	}
+	return nil
+}
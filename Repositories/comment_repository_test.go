@@ -7,6 +7,7 @@ import (
 
 	domain "A2SV_Starter_Project_Blog/Domain"
 	. "A2SV_Starter_Project_Blog/Repositories"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
 
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
@@ -59,8 +60,8 @@ func (s *CommentRepositoryTestSuite) TestCreateCommentIndexes() {
 	err = cursor.All(ctx, &indexes)
 	s.Require().NoError(err, "Failed to decode indexes")
 
-	// We expect the default '_id_' index plus our 2 custom ones.
-	s.Len(indexes, 3, "Expected 3 indexes in total")
+	// We expect the default '_id_' index plus our 4 custom ones.
+	s.Len(indexes, 5, "Expected 5 indexes in total")
 
 	// Create maps to easily check for the existence of our indexes by name.
 	indexNames := make(map[string]bool)
@@ -91,6 +92,12 @@ func (s *CommentRepositoryTestSuite) TestCreateCommentIndexes() {
 		s.Equal(int32(1), keyDoc["parent_id"], "Index should contain 'parent_id'")
 		s.Equal(int32(1), keyDoc["created_at"], "Index should contain 'created_at'")
 	})
+
+	s.Run("Content Text Index", func() {
+		indexName := "blog_id_1_content_text"
+		s.True(indexNames[indexName], "Text index for searching comments should exist")
+		s.Equal(bson.M{"content": int32(1)}, indexSpecs[indexName]["weights"])
+	})
 }
 
 func (s *CommentRepositoryTestSuite) TestCreateAndGetByID() {
@@ -173,6 +180,32 @@ func (s *CommentRepositoryTestSuite) TestAnonymize() {
 	s.Nil(found.AuthorID, "AuthorID should be nil after anonymization")
 }
 
+func (s *CommentRepositoryTestSuite) TestDeleteIfNoReplies() {
+	ctx := context.Background()
+	parent, _ := domain.NewComment(s.fixedBlogID.Hex(), s.fixedUserID.Hex(), "Parent", nil)
+	s.Require().NoError(s.repo.Create(ctx, parent))
+	reply, _ := domain.NewComment(s.fixedBlogID.Hex(), s.fixedUserID.Hex(), "Reply", &parent.ID)
+	s.Require().NoError(s.repo.Create(ctx, reply))
+	s.Require().NoError(s.repo.IncrementReplyCount(ctx, parent.ID, 1))
+
+	s.Run("Keeps A Comment With Replies", func() {
+		err := s.repo.DeleteIfNoReplies(ctx, parent.ID)
+		s.ErrorIs(err, usecases.ErrNotFound)
+
+		found, err := s.repo.GetByID(ctx, parent.ID)
+		s.Require().NoError(err)
+		s.Equal("Parent", found.Content)
+	})
+
+	s.Run("Removes A Comment Without Replies", func() {
+		s.Require().NoError(s.repo.DeleteIfNoReplies(ctx, reply.ID))
+
+		_, err := s.repo.GetByID(ctx, reply.ID)
+		s.ErrorIs(err, usecases.ErrNotFound)
+		s.ErrorIs(s.repo.DeleteIfNoReplies(ctx, reply.ID), usecases.ErrNotFound)
+	})
+}
+
 func (s *CommentRepositoryTestSuite) TestFetchByBlogID_And_FetchReplies() {
 	ctx := context.Background()
 	// Arrange: Create a nested comment structure
@@ -194,7 +227,7 @@ func (s *CommentRepositoryTestSuite) TestFetchByBlogID_And_FetchReplies() {
 	s.repo.Create(ctx, otherBlogComment)
 
 	s.Run("Fetch Top Level Comments", func() {
-		comments, total, err := s.repo.FetchByBlogID(ctx, s.fixedBlogID.Hex(), 1, 10)
+		comments, total, err := s.repo.FetchByBlogID(ctx, s.fixedBlogID.Hex(), 1, 10, domain.CommentSortOldest)
 		s.NoError(err)
 		s.Equal(int64(2), total, "Should only find 2 top-level comments for this blog")
 		s.Len(comments, 2)
@@ -203,6 +236,24 @@ func (s *CommentRepositoryTestSuite) TestFetchByBlogID_And_FetchReplies() {
 		s.Nil(comments[1].ParentID)
 	})
 
+	s.Run("Sorts Top Level Comments", func() {
+		// Top 2 is newer, but Top 1 has the most replies.
+		s.Require().NoError(s.repo.IncrementReplyCount(ctx, top1.ID, 1))
+
+		order := func(sort domain.CommentSort) []string {
+			comments, _, err := s.repo.FetchByBlogID(ctx, s.fixedBlogID.Hex(), 1, 10, sort)
+			s.Require().NoError(err)
+			ids := make([]string, len(comments))
+			for i, c := range comments {
+				ids[i] = c.ID
+			}
+			return ids
+		}
+		s.Equal([]string{top1.ID, top2.ID}, order(domain.CommentSortOldest))
+		s.Equal([]string{top2.ID, top1.ID}, order(domain.CommentSortNewest))
+		s.Equal([]string{top1.ID, top2.ID}, order(domain.CommentSortTop))
+	})
+
 	s.Run("Fetch Replies", func() {
 		replies, total, err := s.repo.FetchReplies(ctx, top1.ID, 1, 10)
 		s.NoError(err)
@@ -213,6 +264,75 @@ func (s *CommentRepositoryTestSuite) TestFetchByBlogID_And_FetchReplies() {
 	})
 }
 
+func (s *CommentRepositoryTestSuite) TestFetchAllByBlogID() {
+	ctx := context.Background()
+	top, _ := domain.NewComment(s.fixedBlogID.Hex(), s.fixedUserID.Hex(), "Top", nil)
+	s.Require().NoError(s.repo.Create(ctx, top))
+	reply, _ := domain.NewComment(s.fixedBlogID.Hex(), s.fixedUserID.Hex(), "Reply", &top.ID)
+	s.Require().NoError(s.repo.Create(ctx, reply))
+	nested, _ := domain.NewComment(s.fixedBlogID.Hex(), s.fixedUserID.Hex(), "Nested", &reply.ID)
+	s.Require().NoError(s.repo.Create(ctx, nested))
+	other, _ := domain.NewComment(primitive.NewObjectID().Hex(), s.fixedUserID.Hex(), "Other blog", nil)
+	s.Require().NoError(s.repo.Create(ctx, other))
+
+	comments, err := s.repo.FetchAllByBlogID(ctx, s.fixedBlogID.Hex())
+
+	s.Require().NoError(err)
+	ids := make([]string, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+	}
+	s.Equal([]string{top.ID, reply.ID, nested.ID}, ids, "every level of the thread, oldest first, and nothing from other blogs")
+}
+
+func (s *CommentRepositoryTestSuite) TestSearchInBlog() {
+	ctx := context.Background()
+	s.Require().NoError(s.repo.CreateCommentIndexes(ctx))
+
+	blogID := s.fixedBlogID.Hex()
+	first, _ := domain.NewComment(blogID, s.fixedUserID.Hex(), "Goroutines make concurrency easy", nil)
+	s.Require().NoError(s.repo.Create(ctx, first))
+	reply, _ := domain.NewComment(blogID, s.fixedUserID.Hex(), "Channels pair well with goroutines", &first.ID)
+	s.Require().NoError(s.repo.Create(ctx, reply))
+	unrelated, _ := domain.NewComment(blogID, s.fixedUserID.Hex(), "Nice formatting on this post", nil)
+	s.Require().NoError(s.repo.Create(ctx, unrelated))
+	deleted, _ := domain.NewComment(blogID, s.fixedUserID.Hex(), "Goroutines leaked everywhere", nil)
+	s.Require().NoError(s.repo.Create(ctx, deleted))
+	s.Require().NoError(s.repo.Anonymize(ctx, deleted.ID))
+	otherBlog, _ := domain.NewComment(primitive.NewObjectID().Hex(), s.fixedUserID.Hex(), "Goroutines on another blog", nil)
+	s.Require().NoError(s.repo.Create(ctx, otherBlog))
+
+	s.Run("Matching query", func() {
+		comments, total, err := s.repo.SearchInBlog(ctx, blogID, "goroutines", 1, 10)
+		s.Require().NoError(err)
+		s.Equal(int64(2), total, "replies match too; deleted comments and other blogs don't")
+		ids := make([]string, len(comments))
+		for i, c := range comments {
+			ids[i] = c.ID
+		}
+		s.ElementsMatch([]string{first.ID, reply.ID}, ids)
+	})
+
+	s.Run("Paginated", func() {
+		comments, total, err := s.repo.SearchInBlog(ctx, blogID, "goroutines", 2, 1)
+		s.Require().NoError(err)
+		s.Equal(int64(2), total)
+		s.Len(comments, 1)
+	})
+
+	s.Run("Non-matching query", func() {
+		comments, total, err := s.repo.SearchInBlog(ctx, blogID, "kubernetes", 1, 10)
+		s.Require().NoError(err)
+		s.Equal(int64(0), total)
+		s.Empty(comments)
+	})
+
+	s.Run("Invalid blog ID", func() {
+		_, _, err := s.repo.SearchInBlog(ctx, "not-an-id", "goroutines", 1, 10)
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+}
+
 func (s *CommentRepositoryTestSuite) TestIncrementReplyCount() {
 	ctx := context.Background()
 	// Arrange: Create a parent comment
@@ -240,3 +360,53 @@ func (s *CommentRepositoryTestSuite) TestIncrementReplyCount() {
 	s.NoError(err)
 	s.Equal(int64(1), found.ReplyCount, "ReplyCount should be 1")
 }
+
+func (s *CommentRepositoryTestSuite) TestModerationQueue() {
+	ctx := context.Background()
+	approved, _ := domain.NewComment(s.fixedBlogID.Hex(), s.fixedUserID.Hex(), "Approved", nil)
+	s.Require().NoError(s.repo.Create(ctx, approved))
+	pending, _ := domain.NewComment(s.fixedBlogID.Hex(), s.fixedUserID.Hex(), "Pending", nil)
+	pending.Pending = true
+	s.Require().NoError(s.repo.Create(ctx, pending))
+	rejected, _ := domain.NewComment(s.fixedBlogID.Hex(), s.fixedUserID.Hex(), "Rejected", nil)
+	rejected.Pending = true
+	s.Require().NoError(s.repo.Create(ctx, rejected))
+
+	s.Run("Pending comments are hidden and not counted", func() {
+		comments, total, err := s.repo.FetchByBlogID(ctx, s.fixedBlogID.Hex(), 1, 10, domain.CommentSortOldest)
+		s.Require().NoError(err)
+		s.Equal(int64(1), total)
+		s.Require().Len(comments, 1)
+		s.Equal(approved.ID, comments[0].ID)
+
+		count, err := s.repo.CountByBlogID(ctx, s.fixedBlogID.Hex())
+		s.Require().NoError(err)
+		s.Equal(int64(1), count)
+	})
+
+	s.Run("Queue lists pending comments oldest first", func() {
+		comments, total, err := s.repo.FetchPending(ctx, 1, 10)
+		s.Require().NoError(err)
+		s.Equal(int64(2), total)
+		s.Require().Len(comments, 2)
+		s.Equal(pending.ID, comments[0].ID)
+		s.True(comments[0].Pending)
+	})
+
+	s.Run("Approval publishes once", func() {
+		s.Require().NoError(s.repo.Approve(ctx, pending.ID))
+		s.ErrorIs(s.repo.Approve(ctx, pending.ID), usecases.ErrNotFound)
+
+		found, err := s.repo.GetByID(ctx, pending.ID)
+		s.Require().NoError(err)
+		s.False(found.Pending)
+	})
+
+	s.Run("Only pending comments can be deleted", func() {
+		s.ErrorIs(s.repo.DeletePending(ctx, approved.ID), usecases.ErrNotFound)
+		s.Require().NoError(s.repo.DeletePending(ctx, rejected.ID))
+
+		_, err := s.repo.GetByID(ctx, rejected.ID)
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+}
@@ -0,0 +1,227 @@
+package repositories
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FollowModel is how one user following another is stored in MongoDB.
+type FollowModel struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	FollowerID primitive.ObjectID `bson:"follower_id"`
+	FolloweeID primitive.ObjectID `bson:"followee_id"`
+	CreatedAt  time.Time          `bson:"created_at"`
+}
+
+// FollowCountModel caches how many followers a user has and how many users they follow,
+// so profiles don't count the follows collection on every view. It is keyed by the user's ID.
+type FollowCountModel struct {
+	UserID    primitive.ObjectID `bson:"_id"`
+	Followers int64              `bson:"followers"`
+	Following int64              `bson:"following"`
+}
+
+// FollowRepository implements the domain.IFollowRepository interface.
+type FollowRepository struct {
+	collection      *mongo.Collection
+	countCollection *mongo.Collection
+}
+
+// NewFollowRepository is the constructor for the follow repository.
+// Follows are stored in col and the per-user counts in countCol.
+func NewFollowRepository(col, countCol *mongo.Collection) *FollowRepository {
+	return &FollowRepository{
+		collection:      col,
+		countCollection: countCol,
+	}
+}
+
+func (r *FollowRepository) CreateFollowIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		// One document per follower and followee, which also serves IsFollowing and ListFollowing.
+		{
+			Keys:    bson.D{{Key: "follower_id", Value: 1}, {Key: "followee_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		// Listing a user's followers, most recent first.
+		{Keys: bson.D{{Key: "followee_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		// Listing who a user follows, most recent first.
+		{Keys: bson.D{{Key: "follower_id", Value: 1}, {Key: "created_at", Value: -1}}},
+	}
+	_, err := r.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// --- Interface Implementations ---
+
+func (r *FollowRepository) Follow(ctx context.Context, followerID, followeeID string) (bool, error) {
+	filter, err := followFilter(followerID, followeeID)
+	if err != nil {
+		return false, err
+	}
+	update := bson.M{"$setOnInsert": bson.M{"created_at": time.Now().UTC()}}
+	result, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	// Two concurrent upserts can race on the unique index; the loser's follow is already recorded.
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	// Only the request that created the follow moves the counts, so repeats and races count once.
+	if result.UpsertedCount == 0 {
+		return false, nil
+	}
+	return true, r.incrementCounts(ctx, filter, 1)
+}
+
+func (r *FollowRepository) Unfollow(ctx context.Context, followerID, followeeID string) (bool, error) {
+	filter, err := followFilter(followerID, followeeID)
+	if err != nil {
+		return false, err
+	}
+	result, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+	if result.DeletedCount == 0 {
+		return false, nil
+	}
+	return true, r.incrementCounts(ctx, filter, -1)
+}
+
+// incrementCounts moves the follower's following count and the followee's follower count by delta.
+func (r *FollowRepository) incrementCounts(ctx context.Context, filter bson.M, delta int64) error {
+	models := []mongo.WriteModel{
+		mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": filter["follower_id"]}).
+			SetUpdate(bson.M{"$inc": bson.M{"following": delta}}).
+			SetUpsert(true),
+		mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": filter["followee_id"]}).
+			SetUpdate(bson.M{"$inc": bson.M{"followers": delta}}).
+			SetUpsert(true),
+	}
+	_, err := r.countCollection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (r *FollowRepository) GetCounts(ctx context.Context, userID string) (*domain.FollowCounts, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return &domain.FollowCounts{}, nil
+	}
+
+	var model FollowCountModel
+	err = r.countCollection.FindOne(ctx, bson.M{"_id": userObjID}).Decode(&model)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Nobody has followed or been followed by this user yet.
+		return &domain.FollowCounts{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &domain.FollowCounts{Followers: model.Followers, Following: model.Following}, nil
+}
+
+func (r *FollowRepository) IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error) {
+	filter, err := followFilter(followerID, followeeID)
+	if err != nil {
+		return false, nil
+	}
+	err = r.collection.FindOne(ctx, filter).Err()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *FollowRepository) ListFollowers(ctx context.Context, userID string, page, limit int64) ([]*domain.Follow, int64, error) {
+	return r.list(ctx, "followee_id", userID, page, limit)
+}
+
+func (r *FollowRepository) ListFollowing(ctx context.Context, userID string, page, limit int64) ([]*domain.Follow, int64, error) {
+	return r.list(ctx, "follower_id", userID, page, limit)
+}
+
+func (r *FollowRepository) ListFolloweeIDs(ctx context.Context, followerID string) ([]string, error) {
+	followerObjID, err := primitive.ObjectIDFromHex(followerID)
+	if err != nil {
+		return []string{}, nil
+	}
+
+	findOptions := options.Find().SetProjection(bson.M{"followee_id": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{"follower_id": followerObjID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	ids := []string{}
+	for cursor.Next(ctx) {
+		var model FollowModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, err
+		}
+		ids = append(ids, model.FolloweeID.Hex())
+	}
+	return ids, cursor.Err()
+}
+
+// list pages through the follows whose field matches userID, most recent first.
+func (r *FollowRepository) list(ctx context.Context, field, userID string, page, limit int64) ([]*domain.Follow, int64, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return []*domain.Follow{}, 0, nil
+	}
+	filter := bson.M{field: userObjID}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	follows := []*domain.Follow{}
+	for cursor.Next(ctx) {
+		var model FollowModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, 0, err
+		}
+		follows = append(follows, &domain.Follow{
+			FollowerID: model.FollowerID.Hex(),
+			FolloweeID: model.FolloweeID.Hex(),
+			CreatedAt:  model.CreatedAt,
+		})
+	}
+	return follows, total, cursor.Err()
+}
+
+func followFilter(followerID, followeeID string) (bson.M, error) {
+	followerObjID, err := primitive.ObjectIDFromHex(followerID)
+	if err != nil {
+		return nil, usecases.ErrInternal
+	}
+	followeeObjID, err := primitive.ObjectIDFromHex(followeeID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	return bson.M{"follower_id": followerObjID, "followee_id": followeeObjID}, nil
+}
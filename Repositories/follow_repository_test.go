@@ -0,0 +1,223 @@
+package repositories_test
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	. "A2SV_Starter_Project_Blog/Repositories"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FollowRepositoryTestSuite defines the suite for the follow repository integration tests.
+type FollowRepositoryTestSuite struct {
+	suite.Suite
+	repo                *FollowRepository
+	collectionName      string
+	countCollectionName string
+}
+
+func (s *FollowRepositoryTestSuite) SetupTest() {
+	s.collectionName = "follows_test"
+	s.countCollectionName = "follow_counts_test"
+	s.repo = NewFollowRepository(testDB.Collection(s.collectionName), testDB.Collection(s.countCollectionName))
+	s.Require().NoError(s.repo.CreateFollowIndexes(context.Background()))
+}
+
+func (s *FollowRepositoryTestSuite) TearDownTest() {
+	for _, name := range []string{s.collectionName, s.countCollectionName} {
+		err := testDB.Collection(name).Drop(context.Background())
+		s.Require().NoError(err, "Failed to drop test collection")
+	}
+}
+
+func TestFollowRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode.")
+	}
+	t.Parallel()
+	suite.Run(t, new(FollowRepositoryTestSuite))
+}
+
+func (s *FollowRepositoryTestSuite) TestFollow_Idempotent() {
+	ctx := context.Background()
+	followerID := primitive.NewObjectID().Hex()
+	followeeID := primitive.NewObjectID().Hex()
+
+	following, err := s.repo.IsFollowing(ctx, followerID, followeeID)
+	s.Require().NoError(err)
+	s.False(following)
+
+	created, err := s.repo.Follow(ctx, followerID, followeeID)
+	s.Require().NoError(err)
+	s.True(created)
+	first, _, err := s.repo.ListFollowers(ctx, followeeID, 1, 10)
+	s.Require().NoError(err)
+	s.Require().Len(first, 1)
+
+	time.Sleep(10 * time.Millisecond)
+	created, err = s.repo.Follow(ctx, followerID, followeeID)
+	s.Require().NoError(err)
+	s.False(created, "a repeat follow is not new")
+
+	count, err := testDB.Collection(s.collectionName).CountDocuments(ctx, bson.M{})
+	s.Require().NoError(err)
+	s.Equal(int64(1), count)
+
+	second, _, err := s.repo.ListFollowers(ctx, followeeID, 1, 10)
+	s.Require().NoError(err)
+	s.Require().Len(second, 1)
+	s.True(first[0].CreatedAt.Equal(second[0].CreatedAt), "following again must keep the first follow time")
+
+	following, err = s.repo.IsFollowing(ctx, followerID, followeeID)
+	s.Require().NoError(err)
+	s.True(following)
+}
+
+func (s *FollowRepositoryTestSuite) TestUnfollow_Idempotent() {
+	ctx := context.Background()
+	followerID := primitive.NewObjectID().Hex()
+	followeeID := primitive.NewObjectID().Hex()
+
+	_, err := s.repo.Follow(ctx, followerID, followeeID)
+	s.Require().NoError(err)
+	removed, err := s.repo.Unfollow(ctx, followerID, followeeID)
+	s.Require().NoError(err)
+	s.True(removed)
+	removed, err = s.repo.Unfollow(ctx, followerID, followeeID)
+	s.Require().NoError(err)
+	s.False(removed)
+
+	following, err := s.repo.IsFollowing(ctx, followerID, followeeID)
+	s.Require().NoError(err)
+	s.False(following)
+}
+
+func (s *FollowRepositoryTestSuite) TestCounts() {
+	ctx := context.Background()
+	alice := primitive.NewObjectID().Hex()
+	bob := primitive.NewObjectID().Hex()
+	carol := primitive.NewObjectID().Hex()
+	follow := func(followerID, followeeID string) {
+		_, err := s.repo.Follow(ctx, followerID, followeeID)
+		s.Require().NoError(err)
+	}
+	unfollow := func(followerID, followeeID string) {
+		_, err := s.repo.Unfollow(ctx, followerID, followeeID)
+		s.Require().NoError(err)
+	}
+	countsOf := func(userID string) domain.FollowCounts {
+		counts, err := s.repo.GetCounts(ctx, userID)
+		s.Require().NoError(err)
+		return *counts
+	}
+
+	s.Equal(domain.FollowCounts{}, countsOf(alice), "a user nobody has followed has zero counts")
+
+	follow(alice, bob)
+	follow(alice, bob)
+	follow(carol, bob)
+	follow(bob, alice)
+	s.Equal(domain.FollowCounts{Followers: 1, Following: 1}, countsOf(alice))
+	s.Equal(domain.FollowCounts{Followers: 2, Following: 1}, countsOf(bob))
+	s.Equal(domain.FollowCounts{Followers: 0, Following: 1}, countsOf(carol))
+
+	unfollow(alice, bob)
+	unfollow(alice, bob)
+	unfollow(carol, alice)
+	s.Equal(domain.FollowCounts{Followers: 1, Following: 0}, countsOf(alice))
+	s.Equal(domain.FollowCounts{Followers: 1, Following: 1}, countsOf(bob))
+	s.Equal(domain.FollowCounts{Followers: 0, Following: 1}, countsOf(carol))
+}
+
+func (s *FollowRepositoryTestSuite) TestCounts_ConcurrentFollowsCountOnce() {
+	ctx := context.Background()
+	followerID := primitive.NewObjectID().Hex()
+	followeeID := primitive.NewObjectID().Hex()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = s.repo.Follow(ctx, followerID, followeeID)
+		}()
+	}
+	wg.Wait()
+
+	counts, err := s.repo.GetCounts(ctx, followeeID)
+	s.Require().NoError(err)
+	s.Equal(int64(1), counts.Followers)
+}
+
+func (s *FollowRepositoryTestSuite) TestListFollowersAndFollowing() {
+	ctx := context.Background()
+	userID := primitive.NewObjectID().Hex()
+	others := []string{primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()}
+
+	for _, pair := range [][2]string{{others[0], userID}, {others[1], userID}, {userID, others[2]}} {
+		_, err := s.repo.Follow(ctx, pair[0], pair[1])
+		s.Require().NoError(err)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	followers, total, err := s.repo.ListFollowers(ctx, userID, 1, 10)
+	s.Require().NoError(err)
+	s.Equal(int64(2), total)
+	s.Require().Len(followers, 2)
+	s.Equal(others[1], followers[0].FollowerID, "most recent follower comes first")
+	s.Equal(others[0], followers[1].FollowerID)
+
+	page, total, err := s.repo.ListFollowers(ctx, userID, 2, 1)
+	s.Require().NoError(err)
+	s.Equal(int64(2), total)
+	s.Require().Len(page, 1)
+	s.Equal(others[0], page[0].FollowerID)
+
+	following, total, err := s.repo.ListFollowing(ctx, userID, 1, 10)
+	s.Require().NoError(err)
+	s.Equal(int64(1), total)
+	s.Require().Len(following, 1)
+	s.Equal(others[2], following[0].FolloweeID)
+	s.Equal(userID, following[0].FollowerID)
+}
+
+func (s *FollowRepositoryTestSuite) TestListFolloweeIDs() {
+	ctx := context.Background()
+	userID := primitive.NewObjectID().Hex()
+	followees := []string{primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()}
+
+	ids, err := s.repo.ListFolloweeIDs(ctx, userID)
+	s.Require().NoError(err)
+	s.Empty(ids)
+
+	for _, followee := range followees {
+		_, err := s.repo.Follow(ctx, userID, followee)
+		s.Require().NoError(err)
+	}
+	_, err = s.repo.Follow(ctx, followees[0], userID)
+	s.Require().NoError(err)
+
+	ids, err = s.repo.ListFolloweeIDs(ctx, userID)
+	s.Require().NoError(err)
+	s.ElementsMatch(followees, ids)
+}
+
+func (s *FollowRepositoryTestSuite) TestUniqueIndex() {
+	ctx := context.Background()
+	followerID := primitive.NewObjectID()
+	followeeID := primitive.NewObjectID()
+	doc := func() bson.M {
+		return bson.M{"follower_id": followerID, "followee_id": followeeID, "created_at": time.Now()}
+	}
+
+	_, err := testDB.Collection(s.collectionName).InsertOne(ctx, doc())
+	s.Require().NoError(err)
+	_, err = testDB.Collection(s.collectionName).InsertOne(ctx, doc())
+	s.True(mongo.IsDuplicateKeyError(err), "a second follow document for the same pair must be rejected")
+}
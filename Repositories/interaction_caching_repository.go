@@ -35,14 +35,19 @@ func (r *CachingInteractionRepository) Get(ctx context.Context, userID, blogID s
 
 	// 2. Try to fetch from the cache.
 	cachedInteraction, err := r.cache.Get(ctx, cacheKey)
-	if err == nil {
+	switch {
+	case err == nil:
 		// Cache HIT!
 		var interaction domain.BlogInteraction
-		if json.Unmarshal(cachedInteraction, &interaction) == nil {
+		jsonErr := json.Unmarshal(cachedInteraction, &interaction)
+		if jsonErr == nil {
 			return &interaction, nil
 		}
-	}
-	if !errors.Is(err, domain.ErrNotFound) {
+		log.Printf("[CACHE] Discarding corrupt interaction entry for key %s: %v", cacheKey, jsonErr)
+	case errors.Is(err, domain.ErrNotFound):
+		// Plain cache miss.
+	default:
+		// The cache is unavailable. Fail open and serve the request from the database.
 		log.Printf("[CACHE] Error getting interaction from cache: %v", err)
 	}
 
@@ -116,3 +121,11 @@ func (r *CachingInteractionRepository) Create(ctx context.Context, interaction *
 func (r *CachingInteractionRepository) GetByID(ctx context.Context, id string) (*domain.BlogInteraction, error) {
 	return r.next.GetByID(ctx, id)
 }
+
+func (r *CachingInteractionRepository) ListByUser(ctx context.Context, userID string, page, limit int64) ([]*domain.BlogInteraction, int64, error) {
+	return r.next.ListByUser(ctx, userID, page, limit)
+}
+
+func (r *CachingInteractionRepository) ListByBlog(ctx context.Context, blogID string, page, limit int64) ([]*domain.BlogInteraction, int64, error) {
+	return r.next.ListByBlog(ctx, blogID, page, limit)
+}
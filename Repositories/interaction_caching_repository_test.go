@@ -45,6 +45,20 @@ func (m *MockInteractionRepository) Delete(ctx context.Context, interactionID st
 	args := m.Called(ctx, interactionID)
 	return args.Error(0)
 }
+func (m *MockInteractionRepository) ListByUser(ctx context.Context, userID string, page, limit int64) ([]*domain.BlogInteraction, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.BlogInteraction), args.Get(1).(int64), args.Error(2)
+}
+func (m *MockInteractionRepository) ListByBlog(ctx context.Context, blogID string, page, limit int64) ([]*domain.BlogInteraction, int64, error) {
+	args := m.Called(ctx, blogID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.BlogInteraction), args.Get(1).(int64), args.Error(2)
+}
 
 // --- The Test Suite ---
 
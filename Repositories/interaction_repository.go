@@ -79,8 +79,24 @@ func (r *InteractionRepository) CreateInteractionIndexes(ctx context.Context) er
 		Options: options.Index().SetUnique(true),
 	}
 
-	// Create the index. This command is idempotent. Using CreateMany for consistency, but CreateOne is fine too.
-	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{uniqueInteractionIndex})
+	// Serves a user's interaction history, newest first.
+	userHistoryIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "user_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	}
+
+	// Serves the interactions on a blog, newest first.
+	blogHistoryIndex := mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "blog_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		},
+	}
+
+	// Create the indexes. This command is idempotent.
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{uniqueInteractionIndex, userHistoryIndex, blogHistoryIndex})
 	return err
 }
 
@@ -186,3 +202,47 @@ func (r *InteractionRepository) Delete(ctx context.Context, interactionID string
 	}
 	return nil
 }
+
+func (r *InteractionRepository) ListByUser(ctx context.Context, userID string, page, limit int64) ([]*domain.BlogInteraction, int64, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return []*domain.BlogInteraction{}, 0, nil
+	}
+	return r.listNewestFirst(ctx, bson.M{"user_id": userObjID}, page, limit)
+}
+
+func (r *InteractionRepository) ListByBlog(ctx context.Context, blogID string, page, limit int64) ([]*domain.BlogInteraction, int64, error) {
+	blogObjID, err := primitive.ObjectIDFromHex(blogID)
+	if err != nil {
+		return []*domain.BlogInteraction{}, 0, nil
+	}
+	return r.listNewestFirst(ctx, bson.M{"blog_id": blogObjID}, page, limit)
+}
+
+// listNewestFirst returns one page of the interactions matching filter, newest first, and the total.
+func (r *InteractionRepository) listNewestFirst(ctx context.Context, filter bson.M, page, limit int64) ([]*domain.BlogInteraction, int64, error) {
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip((page - 1) * limit).
+		SetLimit(limit)
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	interactions := []*domain.BlogInteraction{}
+	for cursor.Next(ctx) {
+		var model InteractionModel
+		if err := cursor.Decode(&model); err != nil {
+			return nil, 0, err
+		}
+		interactions = append(interactions, toInteractionDomain(&model))
+	}
+	return interactions, total, cursor.Err()
+}
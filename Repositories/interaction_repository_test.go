@@ -6,6 +6,7 @@ import (
 	usecases "A2SV_Starter_Project_Blog/Usecases"
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
@@ -67,28 +68,27 @@ func (s *InteractionRepositoryTestSuite) TestCreateInteractionIndexes() {
 	err = cursor.All(ctx, &indexes)
 	s.Require().NoError(err, "Failed to decode indexes")
 
-	// We expect the default '_id_' index and our custom one.
-	s.Len(indexes, 2, "Expected 2 indexes in total")
+	// We expect the default '_id_' index and our two custom ones.
+	s.Len(indexes, 3, "Expected 3 indexes in total")
 
-	var foundOurIndex bool
+	var foundOurIndex, foundHistoryIndex bool
 	for _, idx := range indexes {
-		// Skip the default index
-		if idx["name"] == "_id_" {
-			continue
-		}
-
-		s.Equal("user_id_1_blog_id_1", idx["name"], "Index name is incorrect")
-		s.True(idx["unique"].(bool), "Index should be unique")
-
 		keyDoc := idx["key"].(bson.M)
-		s.Len(keyDoc, 2, "Compound index should have two keys")
-		s.Equal(int32(1), keyDoc["user_id"], "Index should contain 'user_id'")
-		s.Equal(int32(1), keyDoc["blog_id"], "Index should contain 'blog_id'")
-
-		foundOurIndex = true
+		switch idx["name"] {
+		case "user_id_1_blog_id_1":
+			s.True(idx["unique"].(bool), "Index should be unique")
+			s.Len(keyDoc, 2, "Compound index should have two keys")
+			s.Equal(int32(1), keyDoc["user_id"], "Index should contain 'user_id'")
+			s.Equal(int32(1), keyDoc["blog_id"], "Index should contain 'blog_id'")
+			foundOurIndex = true
+		case "user_id_1_created_at_-1":
+			s.Equal(int32(-1), keyDoc["created_at"], "History index should sort newest first")
+			foundHistoryIndex = true
+		}
 	}
 
 	s.True(foundOurIndex, "The custom compound index was not found")
+	s.True(foundHistoryIndex, "The user history index was not found")
 }
 
 func (s *InteractionRepositoryTestSuite) TestCreateAndGet() {
@@ -253,3 +253,78 @@ func (s *InteractionRepositoryTestSuite) TestDelete() {
 	s.ErrorIs(err, usecases.ErrNotFound)
 	s.Nil(foundInteraction)
 }
+
+func (s *InteractionRepositoryTestSuite) TestListByUser() {
+	ctx := context.Background()
+	// Arrange: Three interactions by our user, created in order, and one by someone else.
+	blogIDs := []string{primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()}
+	for _, blogID := range blogIDs {
+		err := s.repo.Create(ctx, &domain.BlogInteraction{UserID: s.fixedUserID.Hex(), BlogID: blogID, Action: domain.ActionTypeLike})
+		s.Require().NoError(err)
+		time.Sleep(5 * time.Millisecond)
+	}
+	err := s.repo.Create(ctx, &domain.BlogInteraction{UserID: primitive.NewObjectID().Hex(), BlogID: blogIDs[0], Action: domain.ActionTypeDislike})
+	s.Require().NoError(err)
+
+	s.Run("Newest first", func() {
+		interactions, total, err := s.repo.ListByUser(ctx, s.fixedUserID.Hex(), 1, 2)
+		s.Require().NoError(err)
+		s.Equal(int64(3), total)
+		s.Require().Len(interactions, 2)
+		s.Equal(blogIDs[2], interactions[0].BlogID)
+		s.Equal(blogIDs[1], interactions[1].BlogID)
+	})
+
+	s.Run("Second page", func() {
+		interactions, total, err := s.repo.ListByUser(ctx, s.fixedUserID.Hex(), 2, 2)
+		s.Require().NoError(err)
+		s.Equal(int64(3), total)
+		s.Require().Len(interactions, 1)
+		s.Equal(blogIDs[0], interactions[0].BlogID)
+	})
+
+	s.Run("Invalid user ID", func() {
+		interactions, total, err := s.repo.ListByUser(ctx, "not-an-id", 1, 10)
+		s.NoError(err)
+		s.Zero(total)
+		s.Empty(interactions)
+	})
+}
+
+func (s *InteractionRepositoryTestSuite) TestListByBlog() {
+	ctx := context.Background()
+	// Arrange: Three users vote on our blog, in order, and one votes on another blog.
+	blogID := primitive.NewObjectID().Hex()
+	userIDs := []string{primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()}
+	for _, userID := range userIDs {
+		err := s.repo.Create(ctx, &domain.BlogInteraction{UserID: userID, BlogID: blogID, Action: domain.ActionTypeLike})
+		s.Require().NoError(err)
+		time.Sleep(5 * time.Millisecond)
+	}
+	err := s.repo.Create(ctx, &domain.BlogInteraction{UserID: userIDs[0], BlogID: primitive.NewObjectID().Hex(), Action: domain.ActionTypeDislike})
+	s.Require().NoError(err)
+
+	s.Run("Newest first", func() {
+		interactions, total, err := s.repo.ListByBlog(ctx, blogID, 1, 2)
+		s.Require().NoError(err)
+		s.Equal(int64(3), total)
+		s.Require().Len(interactions, 2)
+		s.Equal(userIDs[2], interactions[0].UserID)
+		s.Equal(userIDs[1], interactions[1].UserID)
+	})
+
+	s.Run("Second page", func() {
+		interactions, total, err := s.repo.ListByBlog(ctx, blogID, 2, 2)
+		s.Require().NoError(err)
+		s.Equal(int64(3), total)
+		s.Require().Len(interactions, 1)
+		s.Equal(userIDs[0], interactions[0].UserID)
+	})
+
+	s.Run("Invalid blog ID", func() {
+		interactions, total, err := s.repo.ListByBlog(ctx, "not-an-id", 1, 10)
+		s.NoError(err)
+		s.Zero(total)
+		s.Empty(interactions)
+	})
+}
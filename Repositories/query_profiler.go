@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// QueryProfiler logs the explain output of reads that take longer than a threshold.
+// It is meant for development only: every slow query triggers a second round-trip
+// to run the explain command, so it should never be enabled in production.
+type QueryProfiler struct {
+	threshold time.Duration
+	logger    *log.Logger
+}
+
+// NewQueryProfiler creates a profiler. A nil logger falls back to the standard logger.
+func NewQueryProfiler(threshold time.Duration, logger *log.Logger) *QueryProfiler {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &QueryProfiler{
+		threshold: threshold,
+		logger:    logger,
+	}
+}
+
+// ObserveFind explains a find command if it took longer than the threshold.
+// A nil profiler is a no-op, so repositories can call it unconditionally.
+func (p *QueryProfiler) ObserveFind(ctx context.Context, coll *mongo.Collection, filter, sort interface{}, skip, limit int64, started time.Time) {
+	if p == nil {
+		return
+	}
+	elapsed := time.Since(started)
+	if elapsed < p.threshold {
+		return
+	}
+
+	cmd := bson.D{
+		{Key: "find", Value: coll.Name()},
+		{Key: "filter", Value: filter},
+		{Key: "skip", Value: skip},
+		{Key: "limit", Value: limit},
+	}
+	if sort != nil {
+		cmd = append(cmd, bson.E{Key: "sort", Value: sort})
+	}
+	p.explain(ctx, coll, "find", cmd, elapsed)
+}
+
+// ObserveAggregate explains an aggregation if it took longer than the threshold.
+func (p *QueryProfiler) ObserveAggregate(ctx context.Context, coll *mongo.Collection, pipeline mongo.Pipeline, started time.Time) {
+	if p == nil {
+		return
+	}
+	elapsed := time.Since(started)
+	if elapsed < p.threshold {
+		return
+	}
+
+	cmd := bson.D{
+		{Key: "aggregate", Value: coll.Name()},
+		{Key: "pipeline", Value: pipeline},
+		{Key: "cursor", Value: bson.D{}},
+	}
+	p.explain(ctx, coll, "aggregate", cmd, elapsed)
+}
+
+func (p *QueryProfiler) explain(ctx context.Context, coll *mongo.Collection, op string, cmd bson.D, elapsed time.Duration) {
+	var result bson.M
+	err := coll.Database().RunCommand(ctx, bson.D{
+		{Key: "explain", Value: cmd},
+		{Key: "verbosity", Value: "executionStats"},
+	}).Decode(&result)
+	if err != nil {
+		p.logger.Printf("[SLOW QUERY] %s on %s took %s (explain failed: %v)", op, coll.Name(), elapsed, err)
+		return
+	}
+
+	plan, _ := findNested(result, "winningPlan").(bson.M)
+	stats, _ := findNested(result, "executionStats").(bson.M)
+	p.logger.Printf("[SLOW QUERY] %s on %s took %s: plan=%s returned=%v keysExamined=%v docsExamined=%v",
+		op, coll.Name(), elapsed, planStages(plan), stats["nReturned"], stats["totalKeysExamined"], stats["totalDocsExamined"])
+}
+
+// planStages flattens a winning plan into a readable chain, e.g. "LIMIT > FETCH > IXSCAN".
+func planStages(plan bson.M) string {
+	var stages []string
+	for plan != nil {
+		if stage, ok := plan["stage"].(string); ok {
+			stages = append(stages, stage)
+		}
+		// Newer servers wrap the classic plan in a "queryPlan" document.
+		if inner, ok := plan["queryPlan"].(bson.M); ok {
+			plan = inner
+			continue
+		}
+		plan, _ = plan["inputStage"].(bson.M)
+	}
+	if len(stages) == 0 {
+		return "unknown"
+	}
+	return strings.Join(stages, " > ")
+}
+
+// findNested does a depth-first search for a key in an explain document.
+// The location of the plan differs between find and aggregate explains.
+func findNested(doc interface{}, key string) interface{} {
+	switch v := doc.(type) {
+	case bson.M:
+		if found, ok := v[key]; ok {
+			return found
+		}
+		for _, child := range v {
+			if found := findNested(child, key); found != nil {
+				return found
+			}
+		}
+	case bson.A:
+		for _, child := range v {
+			if found := findNested(child, key); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
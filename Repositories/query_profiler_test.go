@@ -0,0 +1,80 @@
+package repositories_test
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	. "A2SV_Starter_Project_Blog/Repositories"
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type QueryProfilerTestSuite struct {
+	suite.Suite
+	repo           *BlogRepository
+	collectionName string
+	logs           *bytes.Buffer
+}
+
+func (s *QueryProfilerTestSuite) SetupTest() {
+	s.collectionName = "blogs_profiler_test"
+	s.repo = NewBlogRepository(testDB.Collection(s.collectionName))
+	s.logs = &bytes.Buffer{}
+}
+
+func (s *QueryProfilerTestSuite) TearDownTest() {
+	err := testDB.Collection(s.collectionName).Drop(context.Background())
+	s.Require().NoError(err, "Failed to drop test collection")
+}
+
+func TestQueryProfiler(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode.")
+	}
+	t.Parallel()
+	suite.Run(t, new(QueryProfilerTestSuite))
+}
+
+func (s *QueryProfilerTestSuite) seed() {
+	blog, err := domain.NewBlog("Profiled", "Content", primitive.NewObjectID().Hex(), []string{"go"})
+	s.Require().NoError(err)
+	s.Require().NoError(s.repo.Create(context.Background(), blog))
+}
+
+func (s *QueryProfilerTestSuite) TestLogsExplainForSlowQuery() {
+	// A zero threshold treats every query as slow.
+	s.repo.SetQueryProfiler(NewQueryProfiler(0, log.New(s.logs, "", 0)))
+	s.seed()
+
+	// No indexes exist on this collection, so the title sort must scan it.
+	_, _, err := s.repo.SearchAndFilter(context.Background(), domain.BlogSearchFilterOptions{SortBy: "title", Page: 1, Limit: 10})
+	s.Require().NoError(err)
+
+	output := s.logs.String()
+	s.Contains(output, "[SLOW QUERY] find on "+s.collectionName)
+	s.Contains(output, "COLLSCAN", "The explain summary should expose the collection scan")
+}
+
+func (s *QueryProfilerTestSuite) TestLogsExplainForSlowAggregation() {
+	s.repo.SetQueryProfiler(NewQueryProfiler(0, log.New(s.logs, "", 0)))
+	s.seed()
+
+	_, _, err := s.repo.SearchAndFilter(context.Background(), domain.BlogSearchFilterOptions{SortBy: "popularity", Page: 1, Limit: 10})
+	s.Require().NoError(err)
+
+	s.Contains(s.logs.String(), "[SLOW QUERY] aggregate on "+s.collectionName)
+}
+
+func (s *QueryProfilerTestSuite) TestSkipsFastQueries() {
+	s.repo.SetQueryProfiler(NewQueryProfiler(time.Hour, log.New(s.logs, "", 0)))
+	s.seed()
+
+	_, _, err := s.repo.SearchAndFilter(context.Background(), domain.BlogSearchFilterOptions{Page: 1, Limit: 10})
+	s.Require().NoError(err)
+
+	s.Empty(s.logs.String(), "Queries under the threshold should not be logged")
+}
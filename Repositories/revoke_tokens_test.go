@@ -0,0 +1,127 @@
+package repositories_test
+
+import (
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
+	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
+	. "A2SV_Starter_Project_Blog/Repositories"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// RevokeTokensTestSuite drives the admin revoke-tokens endpoint against real repositories.
+type RevokeTokensTestSuite struct {
+	suite.Suite
+	passwords   infrastructure.PasswordService
+	userRepo    *MongoUserRepository
+	userUsecase usecases.UserUsecase
+	jwtService  infrastructure.JWTService
+	router      *gin.Engine
+	collections []string
+}
+
+func (s *RevokeTokensTestSuite) SetupTest() {
+	s.collections = []string{"users_revoke_test", "tokens_revoke_test"}
+	s.userRepo = NewMongoUserRepository(testDB, s.collections[0])
+	tokenRepo := NewMongoTokenRepository(testDB, s.collections[1])
+	s.jwtService = infrastructure.NewJWTService("test-secret", "test-issuer", time.Minute, time.Hour)
+	s.passwords = infrastructure.NewPasswordService(infrastructure.WithCost(bcrypt.MinCost)) // Keeps the tests fast.
+
+	s.userUsecase = usecases.NewUserUsecase(s.userRepo, s.passwords, s.jwtService, tokenRepo, nil, nil, 5*time.Second)
+	controller := controllers.NewUserController(s.userUsecase)
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	admin := s.router.Group("/admin")
+	admin.Use(infrastructure.AuthMiddleware(s.jwtService), infrastructure.RequireRole(domain.RoleAdmin))
+	admin.POST("/users/:userID/revoke-tokens", controller.RevokeTokens)
+}
+
+func (s *RevokeTokensTestSuite) TearDownTest() {
+	for _, name := range s.collections {
+		err := testDB.Collection(name).Drop(context.Background())
+		s.Require().NoError(err, "Failed to drop test collection")
+	}
+}
+
+func TestRevokeTokens(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode.")
+	}
+	t.Parallel()
+	suite.Run(t, new(RevokeTokensTestSuite))
+}
+
+// loginTarget stores an active local user and logs them in, returning their token pair.
+func (s *RevokeTokensTestSuite) loginTarget() (*domain.User, string, string) {
+	ctx := context.Background()
+	hashed, err := s.passwords.HashPassword("password123")
+	s.Require().NoError(err)
+	user := &domain.User{
+		Username:  "target",
+		Email:     "target@test.com",
+		Password:  &hashed,
+		Role:      domain.RoleUser,
+		Provider:  domain.ProviderLocal,
+		IsActive:  true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	s.Require().NoError(s.userRepo.Create(ctx, user))
+
+	access, refresh, err := s.userUsecase.Login(ctx, user.Email, "password123")
+	s.Require().NoError(err)
+	return user, access, refresh
+}
+
+func (s *RevokeTokensTestSuite) revoke(targetUserID string, role domain.Role) *httptest.ResponseRecorder {
+	token, _, err := s.jwtService.GenerateAccessToken("actor-id", role)
+	s.Require().NoError(err)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/"+targetUserID+"/revoke-tokens", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+func (s *RevokeTokensTestSuite) TestAdminRevokes_RefreshFails() {
+	user, access, refresh := s.loginTarget()
+
+	w := s.revoke(user.ID, domain.RoleAdmin)
+
+	s.Require().Equal(http.StatusOK, w.Code)
+	var resp controllers.RevokeTokensResponse
+	s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	s.Equal(user.ID, resp.UserID)
+	s.Equal(int64(2), resp.Revoked, "The access and refresh token from the login should be revoked")
+
+	_, _, err := s.userUsecase.RefreshAccessToken(context.Background(), access, refresh)
+	s.ErrorIs(err, domain.ErrAuthenticationFailed, "A revoked session must not be refreshable")
+}
+
+func (s *RevokeTokensTestSuite) TestNonAdminIsForbidden() {
+	user, _, _ := s.loginTarget()
+
+	w := s.revoke(user.ID, domain.RoleUser)
+
+	s.Equal(http.StatusForbidden, w.Code)
+	count, err := testDB.Collection(s.collections[1]).CountDocuments(context.Background(), bson.M{"user_id": user.ID})
+	s.Require().NoError(err)
+	s.Equal(int64(2), count, "Tokens must be left alone when the caller is not an admin")
+}
+
+func (s *RevokeTokensTestSuite) TestUnknownUser() {
+	w := s.revoke("000000000000000000000000", domain.RoleAdmin)
+	s.Equal(http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// StatsRepository implements the domain.IStatsRepository interface.
+type StatsRepository struct {
+	users        *mongo.Collection
+	blogs        *mongo.Collection
+	comments     *mongo.Collection
+	interactions *mongo.Collection
+}
+
+// NewStatsRepository is the constructor for the dashboard stats repository.
+func NewStatsRepository(users, blogs, comments, interactions *mongo.Collection) *StatsRepository {
+	return &StatsRepository{
+		users:        users,
+		blogs:        blogs,
+		comments:     comments,
+		interactions: interactions,
+	}
+}
+
+// --- Interface Implementations ---
+
+func (r *StatsRepository) CountUsers(ctx context.Context) (int64, error) {
+	return r.users.CountDocuments(ctx, bson.M{})
+}
+
+func (r *StatsRepository) CountActiveUsers(ctx context.Context) (int64, error) {
+	return r.users.CountDocuments(ctx, bson.M{"isActive": true})
+}
+
+func (r *StatsRepository) CountBlogs(ctx context.Context) (int64, error) {
+	return r.blogs.CountDocuments(ctx, bson.M{})
+}
+
+func (r *StatsRepository) CountComments(ctx context.Context) (int64, error) {
+	// Deleted comments are anonymized and lose their author, the same rule CountByBlogID uses.
+	return r.comments.CountDocuments(ctx, bson.M{"author_id": bson.M{"$exists": true}})
+}
+
+func (r *StatsRepository) CountInteractions(ctx context.Context) (int64, error) {
+	return r.interactions.CountDocuments(ctx, bson.M{})
+}
@@ -0,0 +1,77 @@
+package repositories_test
+
+import (
+	. "A2SV_Starter_Project_Blog/Repositories"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StatsRepositoryTestSuite defines the suite for the dashboard stats repository integration tests.
+type StatsRepositoryTestSuite struct {
+	suite.Suite
+	repo        *StatsRepository
+	collections []string
+}
+
+func (s *StatsRepositoryTestSuite) SetupTest() {
+	s.collections = []string{"users_stats_test", "blogs_stats_test", "comments_stats_test", "interactions_stats_test"}
+	s.repo = NewStatsRepository(
+		testDB.Collection(s.collections[0]),
+		testDB.Collection(s.collections[1]),
+		testDB.Collection(s.collections[2]),
+		testDB.Collection(s.collections[3]),
+	)
+}
+
+func (s *StatsRepositoryTestSuite) TearDownTest() {
+	for _, name := range s.collections {
+		s.Require().NoError(testDB.Collection(name).Drop(context.Background()))
+	}
+}
+
+func TestStatsRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode.")
+	}
+	t.Parallel()
+	suite.Run(t, new(StatsRepositoryTestSuite))
+}
+
+func (s *StatsRepositoryTestSuite) insert(collection string, docs ...interface{}) {
+	_, err := testDB.Collection(collection).InsertMany(context.Background(), docs)
+	s.Require().NoError(err)
+}
+
+func (s *StatsRepositoryTestSuite) TestCounts() {
+	ctx := context.Background()
+	authorID := primitive.NewObjectID()
+	s.insert(s.collections[0], bson.M{"isActive": true}, bson.M{"isActive": true}, bson.M{"isActive": false})
+	s.insert(s.collections[1], bson.M{"title": "one"}, bson.M{"title": "two"})
+	// The last comment was deleted, which strips its author.
+	s.insert(s.collections[2], bson.M{"author_id": authorID}, bson.M{"author_id": authorID}, bson.M{"content": "[deleted]"})
+	s.insert(s.collections[3], bson.M{"action": "like"}, bson.M{"action": "dislike"}, bson.M{"action": "like"}, bson.M{"action": "like"})
+
+	users, err := s.repo.CountUsers(ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(3), users)
+
+	active, err := s.repo.CountActiveUsers(ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(2), active)
+
+	blogs, err := s.repo.CountBlogs(ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(2), blogs)
+
+	comments, err := s.repo.CountComments(ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(2), comments)
+
+	interactions, err := s.repo.CountInteractions(ctx)
+	s.Require().NoError(err)
+	s.Equal(int64(4), interactions)
+}
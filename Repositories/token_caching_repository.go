@@ -64,13 +64,18 @@ func (r *CachingTokenRepository) GetByValue(ctx context.Context, tokenValue stri
 
 	// 1. Try to fetch from the cache first.
 	cachedToken, err := r.cache.Get(ctx, cacheKey)
-	if err == nil {
+	switch {
+	case err == nil:
 		var token domain.Token
-		if json.Unmarshal(cachedToken, &token) == nil {
+		jsonErr := json.Unmarshal(cachedToken, &token)
+		if jsonErr == nil {
 			return &token, nil // Cache HIT!
 		}
-	}
-	if !errors.Is(err, domain.ErrNotFound) {
+		log.Printf("[CACHE] Discarding corrupt token entry for key %s: %v", cacheKey, jsonErr)
+	case errors.Is(err, domain.ErrNotFound):
+		// Plain cache miss.
+	default:
+		// The cache is unavailable. Fail open and serve the request from the database.
 		log.Printf("[CACHE] Error getting token from cache: %v", err)
 	}
 
@@ -118,12 +123,40 @@ func (r *CachingTokenRepository) Delete(ctx context.Context, tokenID string) err
 	return nil
 }
 
+// DeleteByUserID must invalidate the cache too, or a revoked token would still be served from it.
+// Like Delete, it fetches the tokens first to learn the values they are cached under.
+func (r *CachingTokenRepository) DeleteByUserID(ctx context.Context, userID string, tokenType domain.TokenType) (int64, error) {
+	tokensToDelete, err := r.next.GetByUserID(ctx, userID, tokenType)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := r.next.DeleteByUserID(ctx, userID, tokenType)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, token := range tokensToDelete {
+		cacheKey := fmt.Sprintf("token:value:%s", token.Value)
+		if err := r.cache.Delete(ctx, cacheKey); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			log.Printf("[CACHE] Error deleting token cache for key %s: %v", cacheKey, err)
+		}
+	}
+
+	return deleted, nil
+}
+
 // --- Pass-Through Methods ---
 
-func (r *CachingTokenRepository) DeleteByUserID(ctx context.Context, userID string, tokenType domain.TokenType) error {
-	return r.next.DeleteByUserID(ctx, userID, tokenType)
+func (r *CachingTokenRepository) GetByUserID(ctx context.Context, userID string, tokenType domain.TokenType) ([]*domain.Token, error) {
+	return r.next.GetByUserID(ctx, userID, tokenType)
 }
 
 func (r *CachingTokenRepository) GetByID(ctx context.Context, tokenID string) (*domain.Token, error) {
 	return r.next.GetByID(ctx, tokenID)
 }
+
+func (r *CachingTokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	// Cached tokens are stored with their remaining lifetime, so they have already expired too.
+	return r.next.DeleteExpired(ctx)
+}
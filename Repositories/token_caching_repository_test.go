@@ -3,6 +3,7 @@ package repositories_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -41,9 +42,20 @@ func (m *MockTokenRepository) Delete(ctx context.Context, tokenID string) error
 	args := m.Called(ctx, tokenID)
 	return args.Error(0)
 }
-func (m *MockTokenRepository) DeleteByUserID(ctx context.Context, userID string, tokenType domain.TokenType) error {
+func (m *MockTokenRepository) GetByUserID(ctx context.Context, userID string, tokenType domain.TokenType) ([]*domain.Token, error) {
 	args := m.Called(ctx, userID, tokenType)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Token), args.Error(1)
+}
+func (m *MockTokenRepository) DeleteByUserID(ctx context.Context, userID string, tokenType domain.TokenType) (int64, error) {
+	args := m.Called(ctx, userID, tokenType)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockTokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
 }
 
 // --- The Test Suite ---
@@ -159,20 +171,45 @@ func (s *CachingTokenDecoratorSuite) TestDelete_InvalidatesCache() {
 	s.mockCache.AssertExpectations(s.T())
 }
 
-func (s *CachingTokenDecoratorSuite) TestDeleteByUserID_PassThrough() {
+func (s *CachingTokenDecoratorSuite) TestDeleteByUserID_InvalidatesCache() {
 	ctx := context.Background()
 	userID := "user123"
 	tokenType := domain.TokenTypeRefresh
+	tokens := []*domain.Token{{ID: "t1", Value: "refresh-1"}, {ID: "t2", Value: "refresh-2"}}
 
-	// Arrange: Expect the call to be passed directly to the wrapped repo.
-	s.mockRepo.On("DeleteByUserID", ctx, userID, tokenType).Return(nil).Once()
+	// Arrange
+	// 1. Expect the tokens to be fetched first, to learn the cache keys.
+	s.mockRepo.On("GetByUserID", ctx, userID, tokenType).Return(tokens, nil).Once()
+	// 2. Expect the actual deletion on the repo.
+	s.mockRepo.On("DeleteByUserID", ctx, userID, tokenType).Return(int64(2), nil).Once()
+	// 3. Expect each deleted token to be evicted from the cache.
+	s.mockCache.On("Delete", ctx, "token:value:refresh-1").Return(nil).Once()
+	s.mockCache.On("Delete", ctx, "token:value:refresh-2").Return(domain.ErrNotFound).Once()
 
 	// Act
-	err := s.cachingRepo.DeleteByUserID(ctx, userID, tokenType)
+	deleted, err := s.cachingRepo.DeleteByUserID(ctx, userID, tokenType)
 
 	// Assert
 	s.NoError(err)
+	s.Equal(int64(2), deleted)
 	s.mockRepo.AssertExpectations(s.T())
-	// Assert that the cache was NOT touched for this operation.
-	s.mockCache.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+	s.mockCache.AssertExpectations(s.T())
+}
+
+func (s *CachingTokenDecoratorSuite) TestDeleteByUserID_DBErrorKeepsCache() {
+	ctx := context.Background()
+	userID := "user-db-error"
+	tokenType := domain.TokenTypeAccessToken
+	dbErr := errors.New("database unavailable")
+
+	// Arrange
+	s.mockRepo.On("GetByUserID", ctx, userID, tokenType).Return([]*domain.Token{{ID: "t3", Value: "access-3"}}, nil).Once()
+	s.mockRepo.On("DeleteByUserID", ctx, userID, tokenType).Return(int64(0), dbErr).Once()
+
+	// Act
+	_, err := s.cachingRepo.DeleteByUserID(ctx, userID, tokenType)
+
+	// Assert
+	s.ErrorIs(err, dbErr)
+	s.mockCache.AssertNotCalled(s.T(), "Delete", ctx, "token:value:access-3")
 }
@@ -51,6 +51,12 @@ func fromTokenDomain(t *domain.Token) (*tokenMongo, error) {
 	}, nil
 }
 
+// MongoDB error codes returned when an index already exists under the same name with other options.
+const (
+	indexOptionsConflictCode  = 85
+	indexKeySpecsConflictCode = 86
+)
+
 // ===== Repository Implementation =====
 
 type MongoTokenRepository struct {
@@ -81,27 +87,26 @@ func (r *MongoTokenRepository) CreateTokenIndexes(ctx context.Context) error {
 	// TTL Index for auto-deletion of expired tokens.
 	// MongoDB will automatically delete documents when their 'expires_at' time is reached.
 	// SetExpireAfterSeconds(0) means it will use the timestamp from the 'expires_at' field.
+	// It covers every token type; access tokens are stored with their refresh token's expiry.
 	ttlIndex := mongo.IndexModel{
-		Keys: bson.D{{Key: "expires_at", Value: 1}},
-		Options: options.Index().SetExpireAfterSeconds(0).
-			SetPartialFilterExpression(bson.M{
-				// Only include documents in this index where the 'type' field
-				// is NOT equal to "access_token".
-				"type": bson.M{"$in": []string{
-					string(domain.TokenTypeRefresh),
-					string(domain.TokenTypeActivation),
-					string(domain.TokenTypePasswordReset),
-				},
-				},
-			}),
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
 	}
 
 	// Create the indexes
-	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
-		valueIndex,
-		userTypeIndex,
-		ttlIndex,
-	})
+	models := []mongo.IndexModel{valueIndex, userTypeIndex, ttlIndex}
+	_, err := r.collection.Indexes().CreateMany(ctx, models)
+
+	// Older deployments have a TTL index that skipped access tokens. MongoDB won't change
+	// an index's options in place, so drop it and create the indexes again.
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && (cmdErr.Code == indexOptionsConflictCode || cmdErr.Code == indexKeySpecsConflictCode) {
+		if _, err := r.collection.Indexes().DropOne(ctx, "expires_at_1"); err != nil {
+			return err
+		}
+		_, err = r.collection.Indexes().CreateMany(ctx, models)
+		return err
+	}
 
 	return err
 }
@@ -163,8 +168,42 @@ func (r *MongoTokenRepository) Delete(ctx context.Context, tokenID string) error
 	return nil
 }
 
-func (r *MongoTokenRepository) DeleteByUserID(ctx context.Context, userID string, tokenType domain.TokenType) error {
+// GetByUserID returns every token of the given type that belongs to a user.
+func (r *MongoTokenRepository) GetByUserID(ctx context.Context, userID string, tokenType domain.TokenType) ([]*domain.Token, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID, "type": string(tokenType)})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
 
-	_, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID, "type": string(tokenType)})
-	return err
+	tokens := []*domain.Token{}
+	for cursor.Next(ctx) {
+		var mongoToken tokenMongo
+		if err := cursor.Decode(&mongoToken); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, toTokenDomain(&mongoToken))
+	}
+	return tokens, cursor.Err()
+}
+
+// DeleteByUserID removes every token of the given type that belongs to a user and returns how many were deleted.
+func (r *MongoTokenRepository) DeleteByUserID(ctx context.Context, userID string, tokenType domain.TokenType) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"user_id": userID, "type": string(tokenType)})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// DeleteExpired removes expired tokens and returns how many were deleted. The TTL index
+// normally does this, but its monitor only runs periodically and the index may be missing,
+// so this serves as a backup.
+func (r *MongoTokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	filter := bson.M{"expires_at": bson.M{"$lt": time.Now().UTC()}}
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
 }
@@ -12,6 +12,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // TokenRepositorySuite defines the test suite
@@ -189,6 +190,25 @@ func (s *TokenRepositorySuite) TestDelete() {
 	})
 }
 
+func (s *TokenRepositorySuite) TestGetByUserID() {
+	ctx := context.Background()
+	s.repository.Store(ctx, &domain.Token{ID: primitive.NewObjectID().Hex(), UserID: "user-abc", Type: domain.TokenTypeRefresh, Value: "abc-refresh-1"})
+	s.repository.Store(ctx, &domain.Token{ID: primitive.NewObjectID().Hex(), UserID: "user-abc", Type: domain.TokenTypeRefresh, Value: "abc-refresh-2"})
+	s.repository.Store(ctx, &domain.Token{ID: primitive.NewObjectID().Hex(), UserID: "user-abc", Type: domain.TokenTypeAccessToken, Value: "abc-access"})
+	s.repository.Store(ctx, &domain.Token{ID: primitive.NewObjectID().Hex(), UserID: "user-xyz", Type: domain.TokenTypeRefresh, Value: "xyz-refresh"})
+
+	tokens, err := s.repository.GetByUserID(ctx, "user-abc", domain.TokenTypeRefresh)
+	s.Require().NoError(err)
+	s.Require().Len(tokens, 2)
+	s.ElementsMatch([]string{"abc-refresh-1", "abc-refresh-2"}, []string{tokens[0].Value, tokens[1].Value})
+
+	s.Run("No Tokens", func() {
+		tokens, err := s.repository.GetByUserID(ctx, "user-none", domain.TokenTypeRefresh)
+		s.NoError(err)
+		s.Empty(tokens)
+	})
+}
+
 func (s *TokenRepositorySuite) TestDeleteByUserID() {
 	ctx := context.Background()
 	// Arrange: Store multiple tokens for multiple users
@@ -198,8 +218,9 @@ func (s *TokenRepositorySuite) TestDeleteByUserID() {
 	s.repository.Store(ctx, &domain.Token{ID: primitive.NewObjectID().Hex(), UserID: "user-xyz", Type: domain.TokenTypeRefresh, Value: "xyz-refresh"})
 
 	// Act
-	err := s.repository.DeleteByUserID(ctx, "user-abc", domain.TokenTypeRefresh)
+	deleted, err := s.repository.DeleteByUserID(ctx, "user-abc", domain.TokenTypeRefresh)
 	s.Require().NoError(err)
+	s.Equal(int64(2), deleted, "Both refresh tokens of user-abc should be reported as deleted")
 
 	// Assert: Check the state of the database
 	var count int64
@@ -217,6 +238,44 @@ func (s *TokenRepositorySuite) TestDeleteByUserID() {
 	s.Equal(int64(1), count, "Token for user-xyz should not have been deleted")
 }
 
+func (s *TokenRepositorySuite) TestDeleteExpired() {
+	ctx := context.Background()
+	past := time.Now().Add(-1 * time.Hour).UTC().Truncate(time.Millisecond)
+	future := time.Now().Add(1 * time.Hour).UTC().Truncate(time.Millisecond)
+
+	tokens := []*domain.Token{
+		{ID: primitive.NewObjectID().Hex(), UserID: "user-1", Type: domain.TokenTypeRefresh, Value: "expired-refresh", ExpiresAt: past},
+		{ID: primitive.NewObjectID().Hex(), UserID: "user-1", Type: domain.TokenTypeActivation, Value: "expired-activation", ExpiresAt: past},
+		{ID: primitive.NewObjectID().Hex(), UserID: "user-1", Type: domain.TokenTypeAccessToken, Value: "expired-access", ExpiresAt: past},
+		{ID: primitive.NewObjectID().Hex(), UserID: "user-1", Type: domain.TokenTypeRefresh, Value: "valid-refresh", ExpiresAt: future},
+	}
+	for _, token := range tokens {
+		s.Require().NoError(s.repository.Store(ctx, token))
+	}
+
+	// Act
+	deleted, err := s.repository.DeleteExpired(ctx)
+
+	// Assert
+	s.Require().NoError(err)
+	s.Equal(int64(3), deleted)
+
+	for _, value := range []string{"expired-refresh", "expired-activation", "expired-access"} {
+		count, err := s.collection.CountDocuments(ctx, bson.M{"value": value})
+		s.Require().NoError(err)
+		s.Zero(count, "Expired token %q should have been removed", value)
+	}
+	count, err := s.collection.CountDocuments(ctx, bson.M{"value": "valid-refresh"})
+	s.Require().NoError(err)
+	s.Equal(int64(1), count, "A token that has not expired should have been kept")
+
+	s.Run("Nothing To Delete", func() {
+		deleted, err := s.repository.DeleteExpired(ctx)
+		s.NoError(err)
+		s.Zero(deleted)
+	})
+}
+
 // TestCreateIndexes verifies that all necessary indexes are created correctly.
 func (s *TokenRepositorySuite) TestCreateIndexes() {
 	ctx := context.Background()
@@ -276,21 +335,44 @@ func (s *TokenRepositorySuite) TestCreateIndexes() {
 		s.Len(keyDoc, 1, "TTL index key should have exactly one field")
 		s.Equal(int32(1), keyDoc["expires_at"], "TTL index should be on 'expires_at'")
 		s.EqualValues(0, ttlIndex["expireAfterSeconds"], "TTL index should expire after 0 seconds")
-		pfe, ok := ttlIndex["partialFilterExpression"].(bson.M)
-		s.True(ok, "TTL index should have a partialFilterExpression")
-		expectedFilter := bson.M{
-			"type": bson.M{
-				"$in": primitive.A{
-					string(domain.TokenTypeRefresh),
-					string(domain.TokenTypeActivation),
-					string(domain.TokenTypePasswordReset),
-				},
-			},
-		}
-		s.Equal(expectedFilter, pfe, "Partial filter expression is incorrect")
+		_, isPartial := ttlIndex["partialFilterExpression"]
+		s.False(isPartial, "TTL index should cover every token type, access tokens included")
 	})
 }
 
+// TestCreateIndexes_ReplacesPartialTTLIndex verifies that the older TTL index, which skipped
+// access tokens, is replaced instead of failing startup.
+func (s *TokenRepositorySuite) TestCreateIndexes_ReplacesPartialTTLIndex() {
+	ctx := context.Background()
+	legacy := mongo.IndexModel{
+		Keys: bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0).
+			SetPartialFilterExpression(bson.M{"type": bson.M{"$in": []string{"refresh", "activation", "password_reset"}}}),
+	}
+	_, err := s.collection.Indexes().CreateOne(ctx, legacy)
+	s.Require().NoError(err)
+
+	// Act
+	err = s.repository.CreateTokenIndexes(ctx)
+	s.Require().NoError(err, "An outdated TTL index should not stop index creation")
+
+	// Assert
+	cursor, err := s.collection.Indexes().List(ctx)
+	s.Require().NoError(err)
+	var indexes []bson.M
+	s.Require().NoError(cursor.All(ctx, &indexes))
+	s.Len(indexes, 4)
+	for _, idx := range indexes {
+		if idx["name"] == "expires_at_1" {
+			s.EqualValues(0, idx["expireAfterSeconds"])
+			_, isPartial := idx["partialFilterExpression"]
+			s.False(isPartial, "The legacy partial TTL index should have been replaced")
+			return
+		}
+	}
+	s.Fail("Index 'expires_at_1' should exist")
+}
+
 // A parallel test out of suite because it may wait 65 seconds
 func TestTokenTTL_Parallel(t *testing.T) {
 	t.Parallel()
@@ -312,24 +394,32 @@ func TestTokenTTL_Parallel(t *testing.T) {
 	err := repo.CreateTokenIndexes(ctx)
 	require.NoError(t, err, "Failed to create indexes for TTL test")
 
-	expiredToken := &domain.Token{
-		ID:        primitive.NewObjectID().Hex(),
-		UserID:    "user-ttl-parallel",
-		Type:      domain.TokenTypeActivation,
-		Value:     "a-parallel-token-that-will-expire",
-		ExpiresAt: time.Now().Add(3 * time.Second).UTC(),
+	// Every token type must honor the TTL index, access and refresh tokens included.
+	tokenTypes := []domain.TokenType{
+		domain.TokenTypeActivation,
+		domain.TokenTypePasswordReset,
+		domain.TokenTypeAccessToken,
+		domain.TokenTypeRefresh,
+	}
+	for _, tokenType := range tokenTypes {
+		expiredToken := &domain.Token{
+			ID:        primitive.NewObjectID().Hex(),
+			UserID:    "user-ttl-parallel",
+			Type:      tokenType,
+			Value:     "a-parallel-token-that-will-expire-" + string(tokenType),
+			ExpiresAt: time.Now().Add(3 * time.Second).UTC(),
+		}
+		err = repo.Store(ctx, expiredToken)
+		require.NoError(t, err, "Failed to store the expired %s token", tokenType)
 	}
 
-	err = repo.Store(ctx, expiredToken)
-	require.NoError(t, err, "Failed to store the expired token")
-
-	count, err := collection.CountDocuments(ctx, bson.M{"value": expiredToken.Value})
+	count, err := collection.CountDocuments(ctx, bson.M{"user_id": "user-ttl-parallel"})
 	require.NoError(t, err)
-	require.Equal(t, int64(1), count, "Token should exist immediately")
+	require.Equal(t, int64(len(tokenTypes)), count, "Tokens should exist immediately")
 
 	require.Eventually(t, func() bool {
-		count, err := collection.CountDocuments(ctx, bson.M{"value": expiredToken.Value})
+		count, err := collection.CountDocuments(ctx, bson.M{"user_id": "user-ttl-parallel"})
 		require.NoError(t, err)
 		return count == 0
-	}, time.Duration(ttlWait+5)*time.Second, 1000*time.Millisecond, "Expired token should have been deleted")
+	}, time.Duration(ttlWait+5)*time.Second, 1000*time.Millisecond, "Expired tokens of every type should have been deleted")
 }
@@ -35,13 +35,18 @@ func (r *CachingUserRepository) GetByID(ctx context.Context, id string) (*domain
 
 	// 2. Try to fetch from the cache.
 	cachedUser, err := r.cache.Get(ctx, cacheKey)
-	if err == nil {
+	switch {
+	case err == nil:
 		var user domain.User
-		if json.Unmarshal(cachedUser, &user) == nil {
+		jsonErr := json.Unmarshal(cachedUser, &user)
+		if jsonErr == nil {
 			return &user, nil
 		}
-	}
-	if !errors.Is(err, domain.ErrNotFound) {
+		log.Printf("[CACHE] Discarding corrupt user entry for key %s: %v", cacheKey, jsonErr)
+	case errors.Is(err, domain.ErrNotFound):
+		// Plain cache miss.
+	default:
+		// The cache is unavailable. Fail open and serve the request from the database.
 		log.Printf("[CACHE] Error getting user from cache: %v", err)
 	}
 	user, err := r.next.GetByID(ctx, id)
@@ -94,10 +99,27 @@ func (r *CachingUserRepository) GetByUsername(ctx context.Context, username stri
 	return r.next.GetByUsername(ctx, username)
 }
 
+// GetByIDs reads through; batches are rare next to single lookups and would each need their own key.
+func (r *CachingUserRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.User, error) {
+	return r.next.GetByIDs(ctx, ids)
+}
+
+func (r *CachingUserRepository) SuggestUsernames(ctx context.Context, prefix string, limit int64) ([]*domain.UsernameSuggestion, error) {
+	return r.next.SuggestUsernames(ctx, prefix, limit)
+}
+
+func (r *CachingUserRepository) GetByUsernames(ctx context.Context, usernames []string) ([]*domain.User, error) {
+	return r.next.GetByUsernames(ctx, usernames)
+}
+
 func (r *CachingUserRepository) FindUserIDsByName(ctx context.Context, authorName string) ([]string, error) {
 	return r.next.FindUserIDsByName(ctx, authorName)
 }
 
+func (r *CachingUserRepository) FindUserIDsByProvider(ctx context.Context, provider domain.AuthProvider) ([]string, error) {
+	return r.next.FindUserIDsByProvider(ctx, provider)
+}
+
 func (r *CachingUserRepository) FindByProviderID(ctx context.Context, provider domain.AuthProvider, providerID string) (*domain.User, error) {
 	return r.next.FindByProviderID(ctx, provider, providerID)
 }
@@ -105,3 +127,7 @@ func (r *CachingUserRepository) FindByProviderID(ctx context.Context, provider d
 func (r *CachingUserRepository) SearchAndFilter(ctx context.Context, opts domain.UserSearchFilterOptions) ([]*domain.User, int64, error) {
 	return r.next.SearchAndFilter(ctx, opts)
 }
+
+func (r *CachingUserRepository) StreamByFilter(ctx context.Context, opts domain.UserSearchFilterOptions, fn func(*domain.User) error) error {
+	return r.next.StreamByFilter(ctx, opts, fn)
+}
@@ -3,6 +3,7 @@ package repositories_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -50,6 +51,27 @@ func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) erro
 	args := m.Called(ctx, user)
 	return args.Error(0)
 }
+func (m *MockUserRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.User, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+func (m *MockUserRepository) SuggestUsernames(ctx context.Context, prefix string, limit int64) ([]*domain.UsernameSuggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.UsernameSuggestion), args.Error(1)
+}
+func (m *MockUserRepository) GetByUsernames(ctx context.Context, usernames []string) ([]*domain.User, error) {
+	args := m.Called(ctx, usernames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
 func (m *MockUserRepository) FindUserIDsByName(ctx context.Context, authorName string) ([]string, error) {
 	args := m.Called(ctx, authorName)
 	if args.Get(0) == nil {
@@ -57,6 +79,13 @@ func (m *MockUserRepository) FindUserIDsByName(ctx context.Context, authorName s
 	}
 	return args.Get(0).([]string), args.Error(1)
 }
+func (m *MockUserRepository) FindUserIDsByProvider(ctx context.Context, provider domain.AuthProvider) ([]string, error) {
+	args := m.Called(ctx, provider)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
 func (m *MockUserRepository) FindByProviderID(ctx context.Context, provider domain.AuthProvider, providerID string) (*domain.User, error) {
 	args := m.Called(ctx, provider, providerID)
 	if args.Get(0) == nil {
@@ -71,6 +100,10 @@ func (m *MockUserRepository) SearchAndFilter(ctx context.Context, opts domain.Us
 	}
 	return args.Get(0).([]*domain.User), args.Get(1).(int64), args.Error(2)
 }
+func (m *MockUserRepository) StreamByFilter(ctx context.Context, opts domain.UserSearchFilterOptions, fn func(*domain.User) error) error {
+	args := m.Called(ctx, opts, fn)
+	return args.Error(0)
+}
 
 // MockCacheService is a mock for domain.ICacheService
 type MockCacheService struct {
@@ -84,10 +117,21 @@ func (m *MockCacheService) Get(ctx context.Context, key string) ([]byte, error)
 	}
 	return args.Get(0).([]byte), args.Error(1)
 }
+func (m *MockCacheService) GetAndDelete(ctx context.Context, key string) ([]byte, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
 func (m *MockCacheService) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
 	args := m.Called(ctx, key, value, expiration)
 	return args.Error(0)
 }
+func (m *MockCacheService) SetIfAbsent(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	args := m.Called(ctx, key, value, expiration)
+	return args.Bool(0), args.Error(1)
+}
 func (m *MockCacheService) Delete(ctx context.Context, key string) error {
 	args := m.Called(ctx, key)
 	return args.Error(0)
@@ -183,6 +227,28 @@ func (s *CachingUserDecoratorSuite) TestGetByID_CacheHit() {
 	s.mockRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything)
 }
 
+func (s *CachingUserDecoratorSuite) TestGetByID_CacheUnavailable_FailsOpen() {
+	ctx := context.Background()
+	userID := "user123"
+	cacheKey := "user:id:user123"
+	expectedUser := &domain.User{ID: userID, Username: "testuser"}
+	redisDown := errors.New("dial tcp: connection refused")
+
+	// --- Arrange: Redis is down, so both the read and the write-back fail ---
+	s.mockCache.On("Get", ctx, cacheKey).Return(nil, redisDown).Once()
+	s.mockRepo.On("GetByID", ctx, userID).Return(expectedUser, nil).Once()
+	s.mockCache.On("Set", ctx, cacheKey, mock.Anything, 1*time.Hour).Return(redisDown).Once()
+
+	// --- Act ---
+	resultUser, err := s.cachingRepo.GetByID(ctx, userID)
+
+	// --- Assert: The error is not propagated and the database result is returned ---
+	s.NoError(err)
+	s.Equal(expectedUser, resultUser)
+	s.mockCache.AssertExpectations(s.T())
+	s.mockRepo.AssertExpectations(s.T())
+}
+
 func (s *CachingUserDecoratorSuite) TestUpdate_InvalidatesCache() {
 	ctx := context.Background()
 	userToUpdate := &domain.User{ID: "user123", Username: "updatedName"}
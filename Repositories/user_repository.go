@@ -5,6 +5,7 @@ import (
 	usecases "A2SV_Starter_Project_Blog/Usecases"
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -18,6 +19,7 @@ type UserMongo struct {
 	ID             primitive.ObjectID `bson:"_id,omitempty"`
 	Username       string             `bson:"username"`
 	Email          string             `bson:"email"`
+	PendingEmail   string             `bson:"pendingEmail"`
 	IsActive       bool               `bson:"isActive"`
 	Password       *string            `bson:"password"`
 	Role           domain.Role        `bson:"role"`
@@ -35,6 +37,7 @@ func toUserDomain(u UserMongo) *domain.User {
 		ID:             u.ID.Hex(),
 		Username:       u.Username,
 		Email:          u.Email,
+		PendingEmail:   u.PendingEmail,
 		Password:       u.Password,
 		Role:           u.Role,
 		Bio:            u.Bio,
@@ -56,6 +59,7 @@ func fromUserDomain(u domain.User) UserMongo {
 		ID:             objectID,
 		Username:       u.Username,
 		Email:          u.Email,
+		PendingEmail:   u.PendingEmail,
 		Password:       u.Password,
 		Role:           u.Role,
 		Bio:            u.Bio,
@@ -168,6 +172,29 @@ func (r *MongoUserRepository) GetByUsername(ctx context.Context, username string
 	return toUserDomain(mongoModel), nil
 }
 
+// GetByUsernames fetches the users with any of the given usernames in one query.
+// Unknown usernames are skipped, so the result may be shorter than the input.
+func (r *MongoUserRepository) GetByUsernames(ctx context.Context, usernames []string) ([]*domain.User, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"username": bson.M{"$in": usernames}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*domain.User
+	for cursor.Next(ctx) {
+		var mongoModel UserMongo
+		if err := cursor.Decode(&mongoModel); err != nil {
+			return nil, err
+		}
+		users = append(users, toUserDomain(mongoModel))
+	}
+	return users, cursor.Err()
+}
+
 func (r *MongoUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -185,6 +212,63 @@ func (r *MongoUserRepository) GetByID(ctx context.Context, id string) (*domain.U
 	return toUserDomain(mongoModel), nil
 }
 
+// SuggestUsernames matches the prefix literally. Only the ID and username of each user are read.
+func (r *MongoUserRepository) SuggestUsernames(ctx context.Context, prefix string, limit int64) ([]*domain.UsernameSuggestion, error) {
+	filter := bson.M{
+		"username": bson.M{"$regex": "^" + regexp.QuoteMeta(prefix), "$options": "i"},
+		"isActive": true,
+	}
+	findOptions := options.Find().
+		SetProjection(bson.M{"_id": 1, "username": 1}).
+		SetSort(bson.D{{Key: "username", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var models []UserMongo
+	if err := cursor.All(ctx, &models); err != nil {
+		return nil, err
+	}
+	suggestions := make([]*domain.UsernameSuggestion, len(models))
+	for i, m := range models {
+		suggestions[i] = &domain.UsernameSuggestion{ID: m.ID.Hex(), Username: m.Username}
+	}
+	return suggestions, nil
+}
+
+// GetByIDs fetches the users with any of the given IDs in one query. Unknown and malformed
+// IDs are skipped, so the result may be shorter than the input.
+func (r *MongoUserRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.User, error) {
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, objectID)
+		}
+	}
+	if len(objectIDs) == 0 {
+		return nil, nil
+	}
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objectIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var users []*domain.User
+	for cursor.Next(ctx) {
+		var mongoModel UserMongo
+		if err := cursor.Decode(&mongoModel); err != nil {
+			return nil, err
+		}
+		users = append(users, toUserDomain(mongoModel))
+	}
+	return users, cursor.Err()
+}
+
 func (r *MongoUserRepository) Update(ctx context.Context, user *domain.User) error {
 	objectID, err := primitive.ObjectIDFromHex(user.ID)
 	if err != nil {
@@ -194,9 +278,17 @@ func (r *MongoUserRepository) Update(ctx context.Context, user *domain.User) err
 	mongoModel := fromUserDomain(*user)
 
 	update := bson.M{"$set": mongoModel}
+	// providerId is omitted when empty, so an unlinked provider has to be removed explicitly.
+	if user.ProviderID == "" {
+		update["$unset"] = bson.M{"providerId": ""}
+	}
 
 	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
 	if err != nil {
+		// The unique indexes on email and username catch a concurrent user taking the same value.
+		if mongo.IsDuplicateKeyError(err) {
+			return usecases.ErrConflict
+		}
 		return err
 	}
 	if res.MatchedCount == 0 {
@@ -206,7 +298,16 @@ func (r *MongoUserRepository) Update(ctx context.Context, user *domain.User) err
 }
 
 func (r *MongoUserRepository) FindUserIDsByName(ctx context.Context, authorName string) ([]string, error) {
-	filter := bson.M{"username": bson.M{"$regex": authorName, "$options": "i"}}
+	return r.findUserIDs(ctx, bson.M{"username": bson.M{"$regex": authorName, "$options": "i"}})
+}
+
+// FindUserIDsByProvider returns the IDs of all users who signed up with the given provider.
+func (r *MongoUserRepository) FindUserIDsByProvider(ctx context.Context, provider domain.AuthProvider) ([]string, error) {
+	return r.findUserIDs(ctx, bson.M{"provider": provider})
+}
+
+// findUserIDs returns the IDs of the users matching filter, fetching only the _id field.
+func (r *MongoUserRepository) findUserIDs(ctx context.Context, filter bson.M) ([]string, error) {
 	projection := options.Find().SetProjection(bson.M{"_id": 1})
 
 	cursor, err := r.collection.Find(ctx, filter, projection)
@@ -264,21 +365,7 @@ func (r *MongoUserRepository) SearchAndFilter(ctx context.Context, opts domain.U
 	findOptions.SetLimit(opts.Limit)
 	findOptions.SetSkip((opts.Page - 1) * opts.Limit)
 
-	sortValue := -1 // Default to DESC
-	if opts.SortOrder == domain.SortOrderASC {
-		sortValue = 1
-	}
-
-	var sortDoc bson.D
-	switch opts.SortBy {
-	case "username":
-		sortDoc = bson.D{{Key: "username", Value: sortValue}}
-	case "email":
-		sortDoc = bson.D{{Key: "email", Value: sortValue}}
-	default: // "createdAt" or any other value defaults to sorting by creation date.
-		sortDoc = bson.D{{Key: "createdAt", Value: sortValue}}
-	}
-	findOptions.SetSort(sortDoc)
+	findOptions.SetSort(buildUserSort(opts))
 
 	cursor, err := r.collection.Find(ctx, filter, findOptions)
 	if err != nil {
@@ -302,6 +389,52 @@ func (r *MongoUserRepository) SearchAndFilter(ctx context.Context, opts domain.U
 	return users, total, nil
 }
 
+// userExportBatchSize is how many users the cursor pulls from the server per round-trip.
+const userExportBatchSize = 500
+
+// StreamByFilter walks every user matching the filter, calling fn for each one.
+// Pagination options are ignored; the cursor fetches documents in batches so the
+// full result set never has to be held in memory. Iteration stops at the first error from fn.
+func (r *MongoUserRepository) StreamByFilter(ctx context.Context, opts domain.UserSearchFilterOptions, fn func(*domain.User) error) error {
+	findOptions := options.Find().
+		SetSort(buildUserSort(opts)).
+		SetBatchSize(userExportBatchSize)
+
+	cursor, err := r.collection.Find(ctx, buildUserFilter(opts), findOptions)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var model UserMongo
+		if err := cursor.Decode(&model); err != nil {
+			return err
+		}
+		if err := fn(toUserDomain(model)); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// buildUserSort maps the requested sort onto a sort document, defaulting to creation date.
+func buildUserSort(opts domain.UserSearchFilterOptions) bson.D {
+	sortValue := -1 // Default to DESC
+	if opts.SortOrder == domain.SortOrderASC {
+		sortValue = 1
+	}
+
+	switch opts.SortBy {
+	case "username":
+		return bson.D{{Key: "username", Value: sortValue}}
+	case "email":
+		return bson.D{{Key: "email", Value: sortValue}}
+	default: // "createdAt" or any other value defaults to sorting by creation date.
+		return bson.D{{Key: "createdAt", Value: sortValue}}
+	}
+}
+
 // buildUserFilter is a helper function that constructs the MongoDB filter document
 // from the search options. It is used by the SearchAndFilter method.
 func buildUserFilter(opts domain.UserSearchFilterOptions) bson.M {
@@ -4,6 +4,8 @@ import (
 	domain "A2SV_Starter_Project_Blog/Domain"
 	repositories "A2SV_Starter_Project_Blog/Repositories"
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -268,6 +270,117 @@ func (s *UserRepositorySuite) TestFindUserIDsByName() {
 	})
 }
 
+func (s *UserRepositorySuite) TestFindUserIDsByProvider() {
+	ids := make(map[string]string)
+	for _, user := range []*domain.User{
+		{Username: "local", Email: "local@test.com", Provider: domain.ProviderLocal},
+		{Username: "google1", Email: "google1@test.com", Provider: domain.ProviderGoogle, ProviderID: "g-1"},
+		{Username: "google2", Email: "google2@test.com", Provider: domain.ProviderGoogle, ProviderID: "g-2"},
+	} {
+		s.Require().NoError(s.repository.Create(context.Background(), user))
+		ids[user.Username] = user.ID
+	}
+
+	googleIDs, err := s.repository.FindUserIDsByProvider(context.Background(), domain.ProviderGoogle)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{ids["google1"], ids["google2"]}, googleIDs)
+
+	localIDs, err := s.repository.FindUserIDsByProvider(context.Background(), domain.ProviderLocal)
+	s.Require().NoError(err)
+	s.Equal([]string{ids["local"]}, localIDs)
+}
+
+func (s *UserRepositorySuite) TestGetByUsernames() {
+	for _, user := range []*domain.User{
+		{Username: "alice", Email: "alice@test.com"},
+		{Username: "bob", Email: "bob@test.com"},
+		{Username: "carol", Email: "carol@test.com"},
+	} {
+		s.Require().NoError(s.repository.Create(context.Background(), user))
+	}
+
+	s.Run("Success - Unknown Usernames Are Skipped", func() {
+		users, err := s.repository.GetByUsernames(context.Background(), []string{"alice", "carol", "ghost"})
+		s.Require().NoError(err)
+		s.Require().Len(users, 2)
+		s.ElementsMatch([]string{"alice", "carol"}, []string{users[0].Username, users[1].Username})
+		s.NotEmpty(users[0].ID)
+	})
+
+	s.Run("Success - Exact Match Only", func() {
+		users, err := s.repository.GetByUsernames(context.Background(), []string{"Alice", "bo"})
+		s.Require().NoError(err)
+		s.Empty(users)
+	})
+
+	s.Run("Success - Empty Input", func() {
+		users, err := s.repository.GetByUsernames(context.Background(), nil)
+		s.Require().NoError(err)
+		s.Empty(users)
+	})
+}
+
+func (s *UserRepositorySuite) TestGetByIDs() {
+	alice := &domain.User{Username: "alice", Email: "alice@test.com"}
+	bob := &domain.User{Username: "bob", Email: "bob@test.com"}
+	for _, user := range []*domain.User{alice, bob} {
+		s.Require().NoError(s.repository.Create(context.Background(), user))
+	}
+
+	s.Run("Success - Unknown And Malformed IDs Are Skipped", func() {
+		users, err := s.repository.GetByIDs(context.Background(), []string{bob.ID, primitive.NewObjectID().Hex(), "not-an-id"})
+		s.Require().NoError(err)
+		s.Require().Len(users, 1)
+		s.Equal("bob", users[0].Username)
+	})
+
+	s.Run("Success - Empty Input", func() {
+		users, err := s.repository.GetByIDs(context.Background(), nil)
+		s.Require().NoError(err)
+		s.Empty(users)
+	})
+}
+
+func (s *UserRepositorySuite) TestSuggestUsernames() {
+	ctx := context.Background()
+	for i, username := range []string{"alice", "Alicia", "al_bundy", "bob", "malice", "alex_inactive"} {
+		user := &domain.User{Username: username, Email: fmt.Sprintf("user%d@test.com", i), IsActive: username != "alex_inactive"}
+		s.Require().NoError(s.repository.Create(ctx, user))
+	}
+	usernames := func(suggestions []*domain.UsernameSuggestion) []string {
+		out := make([]string, len(suggestions))
+		for i, suggestion := range suggestions {
+			s.NotEmpty(suggestion.ID)
+			out[i] = suggestion.Username
+		}
+		return out
+	}
+
+	s.Run("Success - Case Insensitive Prefix", func() {
+		suggestions, err := s.repository.SuggestUsernames(ctx, "ALI", 10)
+		s.Require().NoError(err)
+		s.Equal([]string{"Alicia", "alice"}, usernames(suggestions))
+	})
+
+	s.Run("Success - Inactive Users Are Left Out", func() {
+		suggestions, err := s.repository.SuggestUsernames(ctx, "al", 10)
+		s.Require().NoError(err)
+		s.Equal([]string{"Alicia", "al_bundy", "alice"}, usernames(suggestions))
+	})
+
+	s.Run("Success - Limit", func() {
+		suggestions, err := s.repository.SuggestUsernames(ctx, "al", 2)
+		s.Require().NoError(err)
+		s.Equal([]string{"Alicia", "al_bundy"}, usernames(suggestions))
+	})
+
+	s.Run("Success - No Match", func() {
+		suggestions, err := s.repository.SuggestUsernames(ctx, "zed", 10)
+		s.Require().NoError(err)
+		s.Empty(suggestions)
+	})
+}
+
 func (s *UserRepositorySuite) TestFindByProviderID() {
 	ctx := context.Background()
 	user := &domain.User{
@@ -300,6 +413,34 @@ func (s *UserRepositorySuite) TestFindByProviderID() {
 	})
 }
 
+func (s *UserRepositorySuite) TestUpdate_UnlinkProvider() {
+	ctx := context.Background()
+	hashed := "hashed-password"
+	user := &domain.User{
+		Username:   "linkeduser",
+		Email:      "linked@test.com",
+		Password:   &hashed,
+		Provider:   domain.ProviderGoogle,
+		ProviderID: "google-id-linked",
+	}
+	s.Require().NoError(s.repository.Create(ctx, user))
+
+	user.Provider = domain.ProviderLocal
+	user.ProviderID = ""
+	s.Require().NoError(s.repository.Update(ctx, user))
+
+	// The provider ID is removed from the document, not left behind.
+	var raw bson.M
+	objID, _ := primitive.ObjectIDFromHex(user.ID)
+	s.Require().NoError(s.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&raw))
+	s.Equal(string(domain.ProviderLocal), raw["provider"])
+	s.NotContains(raw, "providerId")
+
+	foundUser, err := s.repository.FindByProviderID(ctx, domain.ProviderGoogle, "google-id-linked")
+	s.NoError(err)
+	s.Nil(foundUser)
+}
+
 func (s *UserRepositorySuite) TestSearchAndFilter() {
 	ctx := context.Background()
 	timeNow := time.Now()
@@ -438,3 +579,45 @@ func (s *UserRepositorySuite) TestSearchAndFilter() {
 		s.Len(users, 4)
 	})
 }
+
+func (s *UserRepositorySuite) TestStreamByFilter() {
+	ctx := context.Background()
+	timeNow := time.Now()
+
+	usersToCreate := []repositories.UserMongo{
+		{ID: primitive.NewObjectID(), Username: "LocalOne", Email: "local1@test.com", Role: domain.RoleUser, IsActive: true, Provider: string(domain.ProviderLocal), CreatedAt: timeNow},
+		{ID: primitive.NewObjectID(), Username: "LocalTwo", Email: "local2@test.com", Role: domain.RoleUser, IsActive: true, Provider: string(domain.ProviderLocal), CreatedAt: timeNow.Add(-time.Hour)},
+		{ID: primitive.NewObjectID(), Username: "GoogleOne", Email: "google1@test.com", Role: domain.RoleUser, IsActive: true, Provider: string(domain.ProviderGoogle), CreatedAt: timeNow},
+	}
+	var docs []interface{}
+	for _, u := range usersToCreate {
+		docs = append(docs, u)
+	}
+	_, err := s.collection.InsertMany(ctx, docs)
+	s.Require().NoError(err)
+
+	s.Run("Streams only matching users, ignoring pagination", func() {
+		provider := domain.ProviderLocal
+		// Page and Limit must not truncate an export.
+		options := domain.UserSearchFilterOptions{Provider: &provider, Page: 1, Limit: 1, SortBy: "username", SortOrder: domain.SortOrderASC}
+
+		var usernames []string
+		err := s.repository.StreamByFilter(ctx, options, func(u *domain.User) error {
+			usernames = append(usernames, u.Username)
+			return nil
+		})
+		s.NoError(err)
+		s.Equal([]string{"LocalOne", "LocalTwo"}, usernames)
+	})
+
+	s.Run("Stops on callback error", func() {
+		stopErr := errors.New("client disconnected")
+		calls := 0
+		err := s.repository.StreamByFilter(ctx, domain.UserSearchFilterOptions{}, func(u *domain.User) error {
+			calls++
+			return stopErr
+		})
+		s.ErrorIs(err, stopErr)
+		s.Equal(1, calls)
+	})
+}
@@ -0,0 +1,134 @@
+package repositories_test
+
+import (
+	"A2SV_Starter_Project_Blog/Delivery/controllers"
+	domain "A2SV_Starter_Project_Blog/Domain"
+	. "A2SV_Starter_Project_Blog/Repositories"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// VerifyCommentCountTestSuite drives the admin verify-counts endpoint against real repositories.
+type VerifyCommentCountTestSuite struct {
+	suite.Suite
+	blogRepo    *BlogRepository
+	commentRepo *CommentRepository
+	router      *gin.Engine
+	collections []string
+}
+
+func (s *VerifyCommentCountTestSuite) SetupTest() {
+	s.collections = []string{"blogs_verify_test", "comments_verify_test", "users_verify_test", "interactions_verify_test", "revisions_verify_test", "reads_verify_test"}
+	s.blogRepo = NewBlogRepository(testDB.Collection(s.collections[0]))
+	s.commentRepo = NewCommentRepository(testDB.Collection(s.collections[1]))
+	userRepo := NewMongoUserRepository(testDB, s.collections[2])
+	interactionRepo := NewInteractionRepository(testDB.Collection(s.collections[3]))
+	revisionRepo := NewBlogRevisionRepository(testDB.Collection(s.collections[4]))
+	readRepo := NewBlogReadRepository(testDB.Collection(s.collections[5]))
+
+	blogUsecase := usecases.NewBlogUsecase(s.blogRepo, userRepo, interactionRepo, revisionRepo, s.commentRepo, readRepo, 5*time.Second)
+	controller := controllers.NewBlogController(blogUsecase)
+
+	gin.SetMode(gin.TestMode)
+	s.router = gin.New()
+	s.router.GET("/admin/blogs/:blogID/verify-counts", controller.VerifyCounts)
+}
+
+func (s *VerifyCommentCountTestSuite) TearDownTest() {
+	for _, name := range s.collections {
+		err := testDB.Collection(name).Drop(context.Background())
+		s.Require().NoError(err, "Failed to drop test collection")
+	}
+}
+
+func TestVerifyCommentCount(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode.")
+	}
+	t.Parallel()
+	suite.Run(t, new(VerifyCommentCountTestSuite))
+}
+
+// seedComments stores a blog with n live comments and one deleted one, keeping the counter in step.
+func (s *VerifyCommentCountTestSuite) seedComments(n int) *domain.Blog {
+	ctx := context.Background()
+	blog, err := domain.NewBlog("Counted", "Content", primitive.NewObjectID().Hex(), nil)
+	s.Require().NoError(err)
+	s.Require().NoError(s.blogRepo.Create(ctx, blog))
+
+	var first *domain.Comment
+	for i := 0; i < n+1; i++ {
+		var parentID *string
+		if first != nil {
+			parentID = &first.ID // Replies count towards the blog's total as well.
+		}
+		comment, err := domain.NewComment(blog.ID, primitive.NewObjectID().Hex(), "A comment", parentID)
+		s.Require().NoError(err)
+		s.Require().NoError(s.commentRepo.Create(ctx, comment))
+		s.Require().NoError(s.blogRepo.IncrementCommentCount(ctx, blog.ID, 1))
+		if first == nil {
+			first = comment
+		}
+	}
+
+	// Deleting anonymizes the comment and decrements the counter, as DeleteComment does.
+	s.Require().NoError(s.commentRepo.Anonymize(ctx, first.ID))
+	s.Require().NoError(s.blogRepo.IncrementCommentCount(ctx, blog.ID, -1))
+	return blog
+}
+
+func (s *VerifyCommentCountTestSuite) verify(blogID string) (int, controllers.CommentCountCheckResponse) {
+	req := httptest.NewRequest(http.MethodGet, "/admin/blogs/"+blogID+"/verify-counts", nil)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+
+	var resp controllers.CommentCountCheckResponse
+	if w.Code == http.StatusOK {
+		s.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w.Code, resp
+}
+
+func (s *VerifyCommentCountTestSuite) TestInSync() {
+	blog := s.seedComments(3)
+
+	code, resp := s.verify(blog.ID)
+
+	s.Require().Equal(http.StatusOK, code)
+	s.Equal(blog.ID, resp.BlogID)
+	s.Equal(int64(3), resp.Stored)
+	s.Equal(int64(3), resp.Actual)
+	s.True(resp.InSync)
+}
+
+func (s *VerifyCommentCountTestSuite) TestReportsDrift() {
+	blog := s.seedComments(3)
+	// Simulate a lost background update: the counter moves without a matching comment.
+	s.Require().NoError(s.blogRepo.IncrementCommentCount(context.Background(), blog.ID, 2))
+
+	code, resp := s.verify(blog.ID)
+
+	s.Require().Equal(http.StatusOK, code)
+	s.Equal(int64(5), resp.Stored)
+	s.Equal(int64(3), resp.Actual)
+	s.False(resp.InSync)
+
+	// The check only reports; the stored counter is left as it was.
+	stored, err := s.blogRepo.GetCommentsCount(context.Background(), blog.ID)
+	s.Require().NoError(err)
+	s.Equal(int64(5), stored)
+}
+
+func (s *VerifyCommentCountTestSuite) TestUnknownBlog() {
+	code, _ := s.verify(primitive.NewObjectID().Hex())
+	s.Equal(http.StatusNotFound, code)
+}
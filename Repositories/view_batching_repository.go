@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	domain "A2SV_Starter_Project_Blog/Domain"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+)
+
+// ViewBatchingBlogRepository buffers IncrementViews in memory and writes them with one AddViews
+// per blog, instead of a database write per view. Buffered views reach the database every flush
+// interval, as soon as one blog collects maxPending of them, and on Flush at shutdown. Views are
+// approximate anyway, so the few that a crash can lose are an accepted trade-off.
+// All other methods go straight to the wrapped repository.
+type ViewBatchingBlogRepository struct {
+	domain.IBlogRepository
+
+	maxPending int64
+	mu         sync.Mutex
+	pending    map[string]int64
+}
+
+// NewViewBatchingBlogRepository wraps next. maxPending of zero or less never flushes early, leaving
+// it to the interval.
+func NewViewBatchingBlogRepository(next domain.IBlogRepository, maxPending int) *ViewBatchingBlogRepository {
+	return &ViewBatchingBlogRepository{
+		IBlogRepository: next,
+		maxPending:      int64(maxPending),
+		pending:         make(map[string]int64),
+	}
+}
+
+// IncrementViews records the view in the buffer. It only touches the database when the blog
+// reached maxPending buffered views.
+func (r *ViewBatchingBlogRepository) IncrementViews(ctx context.Context, blogID string) error {
+	r.mu.Lock()
+	r.pending[blogID]++
+	count := r.pending[blogID]
+	if r.maxPending <= 0 || count < r.maxPending {
+		r.mu.Unlock()
+		return nil
+	}
+	delete(r.pending, blogID)
+	r.mu.Unlock()
+
+	return r.write(ctx, blogID, count)
+}
+
+// Flush writes every buffered view. Views that fail to save are put back for the next flush,
+// and the errors are returned together.
+func (r *ViewBatchingBlogRepository) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	batch := r.pending
+	r.pending = make(map[string]int64)
+	r.mu.Unlock()
+
+	var errs []error
+	for blogID, count := range batch {
+		if err := r.write(ctx, blogID, count); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run flushes the buffer every interval until ctx is cancelled. The final flush is left to the
+// caller, after the server has stopped taking requests.
+func (r *ViewBatchingBlogRepository) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				log.Printf("View batcher failed to flush: %v", err)
+			}
+		}
+	}
+}
+
+// write saves count views, putting them back in the buffer when the database refuses them.
+// Views for an ID the database can't resolve are dropped rather than retried forever.
+func (r *ViewBatchingBlogRepository) write(ctx context.Context, blogID string, count int64) error {
+	err := r.IBlogRepository.AddViews(ctx, blogID, count)
+	if err == nil || errors.Is(err, usecases.ErrNotFound) {
+		return nil
+	}
+	r.mu.Lock()
+	r.pending[blogID] += count
+	r.mu.Unlock()
+	return err
+}
@@ -0,0 +1,97 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	. "A2SV_Starter_Project_Blog/Repositories"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewBatchingBlogRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Flush Writes One Batch Per Blog", func(t *testing.T) {
+		next := new(MockBlogRepository)
+		next.On("AddViews", ctx, "blog1", int64(3)).Return(nil).Once()
+		next.On("AddViews", ctx, "blog2", int64(1)).Return(nil).Once()
+		repo := NewViewBatchingBlogRepository(next, 0)
+
+		for _, id := range []string{"blog1", "blog2", "blog1", "blog1"} {
+			require.NoError(t, repo.IncrementViews(ctx, id))
+		}
+		next.AssertNotCalled(t, "AddViews")
+
+		require.NoError(t, repo.Flush(ctx))
+		require.NoError(t, repo.Flush(ctx), "an empty buffer should flush nothing")
+		next.AssertExpectations(t)
+		next.AssertNotCalled(t, "IncrementViews")
+	})
+
+	t.Run("Writes Early At Max Pending", func(t *testing.T) {
+		next := new(MockBlogRepository)
+		next.On("AddViews", ctx, "blog1", int64(2)).Return(nil).Once()
+		repo := NewViewBatchingBlogRepository(next, 2)
+
+		require.NoError(t, repo.IncrementViews(ctx, "blog1"))
+		next.AssertNotCalled(t, "AddViews")
+		require.NoError(t, repo.IncrementViews(ctx, "blog1"))
+		next.AssertExpectations(t)
+
+		require.NoError(t, repo.Flush(ctx))
+		next.AssertNumberOfCalls(t, "AddViews", 1)
+	})
+
+	t.Run("Failed Flush Keeps Views For Next Time", func(t *testing.T) {
+		dbErr := errors.New("db unavailable")
+		next := new(MockBlogRepository)
+		next.On("AddViews", ctx, "blog1", int64(2)).Return(dbErr).Once()
+		next.On("AddViews", ctx, "blog1", int64(3)).Return(nil).Once()
+		repo := NewViewBatchingBlogRepository(next, 0)
+
+		require.NoError(t, repo.IncrementViews(ctx, "blog1"))
+		require.NoError(t, repo.IncrementViews(ctx, "blog1"))
+		assert.ErrorIs(t, repo.Flush(ctx), dbErr)
+
+		require.NoError(t, repo.IncrementViews(ctx, "blog1"))
+		require.NoError(t, repo.Flush(ctx))
+		next.AssertExpectations(t)
+	})
+
+	t.Run("Drops Views For Missing Blog", func(t *testing.T) {
+		next := new(MockBlogRepository)
+		next.On("AddViews", ctx, "gone", int64(1)).Return(usecases.ErrNotFound).Once()
+		repo := NewViewBatchingBlogRepository(next, 0)
+
+		require.NoError(t, repo.IncrementViews(ctx, "gone"))
+		require.NoError(t, repo.Flush(ctx))
+		require.NoError(t, repo.Flush(ctx))
+		next.AssertExpectations(t)
+	})
+
+	t.Run("Concurrent Views Are All Counted", func(t *testing.T) {
+		next := new(MockBlogRepository)
+		next.On("AddViews", ctx, "blog1", int64(500)).Return(nil).Once()
+		repo := NewViewBatchingBlogRepository(next, 0)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					_ = repo.IncrementViews(ctx, "blog1")
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.NoError(t, repo.Flush(ctx))
+		next.AssertExpectations(t)
+	})
+}
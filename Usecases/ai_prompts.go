@@ -0,0 +1,102 @@
+package usecases
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"text/template"
+)
+
+// Names of the AI prompt templates. Each is read from a file of the same name with a .tmpl extension.
+const (
+	PromptBlogIdeas     = "blog_ideas"
+	PromptRefinePost    = "refine_post"
+	PromptToxicityScore = "toxicity_score"
+)
+
+// DefaultPromptLanguage is the language prompts ask the model to write in when none is configured.
+const DefaultPromptLanguage = "English"
+
+// promptNames lists every template a PromptTemplates must provide.
+var promptNames = []string{PromptBlogIdeas, PromptRefinePost, PromptToxicityScore}
+
+//go:embed prompts/*.tmpl
+var defaultPrompts embed.FS
+
+// PromptData holds the values a prompt template can refer to. Templates use only the fields
+// relevant to them: {{.Topic}} for blog ideas, {{.Content}} for refinement and toxicity scoring,
+// {{.Language}} for ideas and refinement.
+type PromptData struct {
+	Topic    string
+	Content  string
+	Language string
+}
+
+// PromptTemplates are the prompts the AI usecase sends to the model, kept outside the code
+// so they can be tuned without a rebuild.
+type PromptTemplates struct {
+	templates map[string]*template.Template
+}
+
+// DefaultPromptTemplates returns the prompts built into the binary.
+func DefaultPromptTemplates() *PromptTemplates {
+	p, err := parsePrompts(defaultPrompts, "prompts", nil)
+	if err != nil {
+		// The embedded templates are fixed at build time and covered by tests.
+		panic(err)
+	}
+	return p
+}
+
+// LoadPromptTemplates reads overrides from fsys, typically os.DirFS of a configured directory.
+// A template missing from fsys keeps its built-in default; one that fails to parse or render is an error.
+func LoadPromptTemplates(fsys fs.FS) (*PromptTemplates, error) {
+	return parsePrompts(defaultPrompts, "prompts", fsys)
+}
+
+func parsePrompts(defaults fs.FS, dir string, overrides fs.FS) (*PromptTemplates, error) {
+	p := &PromptTemplates{templates: make(map[string]*template.Template)}
+	for _, name := range promptNames {
+		file := name + ".tmpl"
+		text, err := fs.ReadFile(defaults, dir+"/"+file)
+		if err != nil {
+			return nil, err
+		}
+		if overrides != nil {
+			override, err := fs.ReadFile(overrides, file)
+			switch {
+			case err == nil:
+				text = override
+			case !errors.Is(err, fs.ErrNotExist):
+				return nil, fmt.Errorf("reading prompt %s: %w", file, err)
+			}
+		}
+
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(string(text))
+		if err != nil {
+			return nil, fmt.Errorf("parsing prompt %s: %w", file, err)
+		}
+		p.templates[name] = tmpl
+		// A reference to an unknown field only fails when executed, so catch it now rather than on the first request.
+		if _, err := p.Render(name, PromptData{}); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Render fills in the named template with data.
+func (p *PromptTemplates) Render(name string, data PromptData) (string, error) {
+	tmpl, ok := p.templates[name]
+	if !ok {
+		return "", fmt.Errorf("unknown prompt %q", name)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering prompt %s: %w", name, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,75 @@
+package usecases_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	. "A2SV_Starter_Project_Blog/Usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultPromptTemplates_Render(t *testing.T) {
+	prompts := DefaultPromptTemplates()
+	data := PromptData{Topic: `"go", "concurrency"`, Content: "channels are neat. use them.", Language: "Spanish"}
+
+	testCases := []struct {
+		name     string
+		expected []string
+	}{
+		{name: PromptBlogIdeas, expected: []string{data.Topic, data.Language}},
+		{name: PromptRefinePost, expected: []string{data.Content, data.Language}},
+		{name: PromptToxicityScore, expected: []string{data.Content}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prompt, err := prompts.Render(tc.name, data)
+
+			require.NoError(t, err)
+			for _, value := range tc.expected {
+				assert.Contains(t, prompt, value)
+			}
+			assert.NotContains(t, prompt, "{{")
+			assert.NotContains(t, prompt, "<no value>")
+		})
+	}
+
+	t.Run("Unknown Template", func(t *testing.T) {
+		_, err := prompts.Render("summarize", data)
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadPromptTemplates(t *testing.T) {
+	t.Run("Overrides Replace Only Their Own Template", func(t *testing.T) {
+		prompts, err := LoadPromptTemplates(fstest.MapFS{
+			"blog_ideas.tmpl": {Data: []byte("Five titles about {{.Topic}} in {{.Language}}.")},
+		})
+		require.NoError(t, err)
+
+		ideas, err := prompts.Render(PromptBlogIdeas, PromptData{Topic: "go", Language: "English"})
+		require.NoError(t, err)
+		assert.Equal(t, "Five titles about go in English.", ideas)
+
+		refine, err := prompts.Render(PromptRefinePost, PromptData{Content: "draft", Language: "English"})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(refine, "You are an expert copy editor"), "the default refine prompt is kept")
+	})
+
+	t.Run("Rejects A Template That Does Not Parse", func(t *testing.T) {
+		_, err := LoadPromptTemplates(fstest.MapFS{
+			"refine_post.tmpl": {Data: []byte("Refine {{.Content")},
+		})
+		assert.ErrorContains(t, err, "refine_post.tmpl")
+	})
+
+	t.Run("Rejects An Unknown Placeholder", func(t *testing.T) {
+		_, err := LoadPromptTemplates(fstest.MapFS{
+			"blog_ideas.tmpl": {Data: []byte("Titles about {{.Keywords}}")},
+		})
+		assert.Error(t, err)
+	})
+}
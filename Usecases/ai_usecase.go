@@ -3,23 +3,86 @@ package usecases
 import (
 	domain "A2SV_Starter_Project_Blog/Domain"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// toxicityScoreTTL is how long a toxicity score is reused for identical text.
+const toxicityScoreTTL = 24 * time.Hour
+
 type AIUsecase struct {
 	aiService      domain.IAIService
 	contextTimeout time.Duration
+	prompts        *PromptTemplates
+	language       string
+
+	// slots bounds how many upstream calls run at once. Nil means unlimited.
+	slots chan struct{}
+	// queueTimeout is how long a call waits for a free slot before giving up with ErrQuotaExceeded.
+	queueTimeout time.Duration
+	// scoreCache remembers toxicity scores by content hash. Nil scores every call afresh.
+	scoreCache domain.ICacheService
+}
+
+// AIUsecaseOption configures optional behaviour of the AI usecase.
+type AIUsecaseOption func(*AIUsecase)
+
+// WithPromptTemplates replaces the built-in prompts. A nil value keeps them.
+func WithPromptTemplates(prompts *PromptTemplates) AIUsecaseOption {
+	return func(ai *AIUsecase) {
+		if prompts != nil {
+			ai.prompts = prompts
+		}
+	}
+}
+
+// WithPromptLanguage sets the language the model is asked to write in. An empty value keeps DefaultPromptLanguage.
+func WithPromptLanguage(language string) AIUsecaseOption {
+	return func(ai *AIUsecase) {
+		if language != "" {
+			ai.language = language
+		}
+	}
 }
 
-func NewAIUsecase(aiService domain.IAIService, timeOut time.Duration) domain.IAIUsecase {
-	return &AIUsecase{
+// WithMaxConcurrentRequests limits how many calls to the AI service run at once, so bursts of traffic
+// queue here instead of tripping the provider's rate limits. A call waits up to queueTimeout for a
+// free slot and then fails with domain.ErrQuotaExceeded; zero fails at once. Zero or less max means unlimited.
+func WithMaxConcurrentRequests(max int, queueTimeout time.Duration) AIUsecaseOption {
+	return func(ai *AIUsecase) {
+		if max > 0 {
+			ai.slots = make(chan struct{}, max)
+			ai.queueTimeout = queueTimeout
+		}
+	}
+}
+
+// WithToxicityScoreCache reuses the toxicity score of text that was scored before, so reposted
+// content doesn't cost another model call. Scores are kept for a day.
+func WithToxicityScoreCache(cache domain.ICacheService) AIUsecaseOption {
+	return func(ai *AIUsecase) {
+		ai.scoreCache = cache
+	}
+}
+
+func NewAIUsecase(aiService domain.IAIService, timeOut time.Duration, opts ...AIUsecaseOption) domain.IAIUsecase {
+	ai := &AIUsecase{
 		aiService:      aiService,
 		contextTimeout: timeOut,
+		prompts:        DefaultPromptTemplates(),
+		language:       DefaultPromptLanguage,
+	}
+	for _, opt := range opts {
+		opt(ai)
 	}
+	return ai
 }
 
 func (ai *AIUsecase) GenerateBlogIdeas(ctx context.Context, keywords []string) ([]string, error) {
@@ -32,19 +95,20 @@ func (ai *AIUsecase) GenerateBlogIdeas(ctx context.Context, keywords []string) (
 	}
 
 	// 2. Prompt Engineering: This is the core logic.
-	// We give the AI a role, a task, the data, and crucially, a required output format.
+	// The template gives the AI a role, a task, the data, and crucially, a required output format.
 	keywordString := `"` + strings.Join(keywords, `", "`) + `"`
-	prompt := fmt.Sprintf(
-		`You are an expert blogger and content strategist. 
-		Your task is to generate 5 compelling and unique blog post titles.
-		The titles must be based on the following keywords: %s.
-		Return the result ONLY as a raw JSON array of strings, with no other text, commentary, or markdown formatting.
-		Example response: ["Title 1", "Title 2", "Title 3", "Title 4", "Title 5"]`,
-		keywordString,
-	)
+	prompt, err := ai.prompts.Render(PromptBlogIdeas, PromptData{Topic: keywordString, Language: ai.language})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInternal, err)
+	}
 
 	// 3. Call the external AI service via our interface.
+	release, err := ai.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
 	aiResponse, err := ai.aiService.GenerateCompletion(ctx, prompt)
+	release()
 	if err != nil {
 		return nil, err
 	}
@@ -72,25 +136,19 @@ func (ai *AIUsecase) RefineBlogPost(ctx context.Context, content string) (string
 		return "", domain.ErrValidation
 	}
 
-	// 2. Prompt Engineering: Give the AI a clear role and set of instructions.
-	prompt := fmt.Sprintf(
-		`You are an expert copy editor and writer for a blog.
-		Your task is to refine the following blog post content. Your goals are to:
-		1. Improve clarity and readability.
-		2. Fix any spelling and grammatical errors.
-		3. Enhance the tone to be more professional and engaging.
-		4. Ensure technical accuracy where possible, without adding new information.
-		Do not add new sections or radically change the original meaning.
-		Return ONLY the refined text, with no other commentary, explanations, or markdown formatting.
-
-		Original content to refine:
-		---
-		%s`,
-		content,
-	)
+	// 2. Prompt Engineering: The template gives the AI a clear role and set of instructions.
+	prompt, err := ai.prompts.Render(PromptRefinePost, PromptData{Content: content, Language: ai.language})
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInternal, err)
+	}
 
 	// 3. Call the external AI service.
+	release, err := ai.acquire(ctx)
+	if err != nil {
+		return "", err
+	}
 	refinedContent, err := ai.aiService.GenerateCompletion(ctx, prompt)
+	release()
 	if err != nil {
 		return "", err
 	}
@@ -99,3 +157,116 @@ func (ai *AIUsecase) RefineBlogPost(ctx context.Context, content string) (string
 	// Here, we expect the raw string back, so we just need to clean up any extra whitespace.
 	return strings.TrimSpace(refinedContent), nil
 }
+
+// StreamRefineBlogPost is RefineBlogPost for clients that want the text as it is generated.
+// Each chunk is passed to fn in order; an error from fn, or cancelling ctx, stops the upstream stream.
+func (ai *AIUsecase) StreamRefineBlogPost(ctx context.Context, content string, fn func(chunk string) error) error {
+	ctx, cancel := context.WithTimeout(ctx, ai.contextTimeout)
+	defer cancel()
+
+	if strings.TrimSpace(content) == "" {
+		return domain.ErrValidation
+	}
+
+	prompt, err := ai.prompts.Render(PromptRefinePost, PromptData{Content: content, Language: ai.language})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInternal, err)
+	}
+
+	// The slot is held for the whole stream, since the upstream request stays open until it ends.
+	release, err := ai.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return ai.aiService.StreamCompletion(ctx, prompt, func(chunk string) error {
+		// Stop as soon as the caller goes away, even if the service would keep reading.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fn(chunk)
+	})
+}
+
+// ScoreContent asks the model how toxic or spammy text is and returns its answer, a number from
+// 0 to 1. A reply that isn't such a number is an ErrInternal.
+func (ai *AIUsecase) ScoreContent(ctx context.Context, text string) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, ai.contextTimeout)
+	defer cancel()
+
+	if strings.TrimSpace(text) == "" {
+		return 0, domain.ErrValidation
+	}
+
+	sum := sha256.Sum256([]byte(text))
+	cacheKey := "ai:toxicity:" + hex.EncodeToString(sum[:])
+	if ai.scoreCache != nil {
+		cached, err := ai.scoreCache.Get(ctx, cacheKey)
+		switch {
+		case err == nil:
+			if score, parseErr := strconv.ParseFloat(string(cached), 64); parseErr == nil {
+				return score, nil
+			}
+		case !errors.Is(err, domain.ErrNotFound):
+			log.Printf("non-critical error: failed to read cached toxicity score: %v", err)
+		}
+	}
+
+	prompt, err := ai.prompts.Render(PromptToxicityScore, PromptData{Content: text})
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInternal, err)
+	}
+
+	release, err := ai.acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	aiResponse, err := ai.aiService.GenerateCompletion(ctx, prompt)
+	release()
+	if err != nil {
+		return 0, err
+	}
+
+	// The model may still wrap the number in code fences despite the instructions.
+	score, err := strconv.ParseFloat(strings.Trim(aiResponse, " \n\t`"), 64)
+	if err != nil || !(score >= 0 && score <= 1) { // Also rejects NaN.
+		log.Printf("Failed to parse AI toxicity score. Raw response: %s", aiResponse)
+		return 0, fmt.Errorf("%w: failed to parse AI response for toxicity score", ErrInternal)
+	}
+
+	if ai.scoreCache != nil {
+		if err := ai.scoreCache.Set(ctx, cacheKey, []byte(strconv.FormatFloat(score, 'f', -1, 64)), toxicityScoreTTL); err != nil {
+			log.Printf("non-critical error: failed to cache toxicity score: %v", err)
+		}
+	}
+	return score, nil
+}
+
+// acquire waits for a free upstream slot and returns the function that gives it back.
+func (ai *AIUsecase) acquire(ctx context.Context) (func(), error) {
+	if ai.slots == nil {
+		return func() {}, nil
+	}
+	release := func() { <-ai.slots }
+
+	select {
+	case ai.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+	if ai.queueTimeout <= 0 {
+		return nil, domain.ErrQuotaExceeded
+	}
+
+	timer := time.NewTimer(ai.queueTimeout)
+	defer timer.Stop()
+	select {
+	case ai.slots <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		return nil, domain.ErrQuotaExceeded
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
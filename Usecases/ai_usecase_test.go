@@ -3,8 +3,9 @@ package usecases_test
 import (
 	"context"
 	"errors"
-	"fmt"
+	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	domain "A2SV_Starter_Project_Blog/Domain"
@@ -25,6 +26,34 @@ func (m *MockAIService) GenerateCompletion(ctx context.Context, prompt string) (
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockAIService) StreamCompletion(ctx context.Context, prompt string, fn func(chunk string) error) error {
+	args := m.Called(ctx, prompt, fn)
+	return args.Error(0)
+}
+
+// fakeStreamingAIService emits a fixed sequence of chunks, stopping early when the consumer
+// returns an error or the context is cancelled, the way a real streaming client does.
+type fakeStreamingAIService struct {
+	MockAIService
+	chunks []string
+	sent   int
+	prompt string
+}
+
+func (f *fakeStreamingAIService) StreamCompletion(ctx context.Context, prompt string, fn func(chunk string) error) error {
+	f.prompt = prompt
+	for _, chunk := range f.chunks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(chunk); err != nil {
+			return err
+		}
+		f.sent++
+	}
+	return nil
+}
+
 // --- Test Suite Setup ---
 type AIUsecaseTestSuite struct {
 	suite.Suite
@@ -50,16 +79,12 @@ func (s *AIUsecaseTestSuite) TestGenerateBlogIdeas() {
 	s.Run("Success - Valid JSON response", func() {
 		s.SetupTest()
 		// Arrange:
-		// 1. Define the exact prompt we expect the usecase to construct.
-		keywordString := `"go", "rest", "api"`
-		expectedPrompt := fmt.Sprintf(
-			`You are an expert blogger and content strategist. 
-		Your task is to generate 5 compelling and unique blog post titles.
-		The titles must be based on the following keywords: %s.
-		Return the result ONLY as a raw JSON array of strings, with no other text, commentary, or markdown formatting.
-		Example response: ["Title 1", "Title 2", "Title 3", "Title 4", "Title 5"]`,
-			keywordString,
-		)
+		// 1. Define the exact prompt we expect the usecase to render from the default template.
+		expectedPrompt := `You are an expert blogger and content strategist.
+Your task is to generate 5 compelling and unique blog post titles, written in English.
+The titles must be based on the following keywords: "go", "rest", "api".
+Return the result ONLY as a raw JSON array of strings, with no other text, commentary, or markdown formatting.
+Example response: ["Title 1", "Title 2", "Title 3", "Title 4", "Title 5"]`
 
 		// 2. Define the ideal response from the AI.
 		aiResponseJSON := `["Building REST APIs in Go", "Go API Best Practices"]`
@@ -176,6 +201,21 @@ func (s *AIUsecaseTestSuite) TestRefineBlogPost() {
 		s.Equal(expectedCleanContent, refined)
 	})
 
+	s.Run("Success - Custom Template And Language", func() {
+		s.SetupTest()
+		prompts, err := LoadPromptTemplates(fstest.MapFS{
+			"refine_post.tmpl": {Data: []byte("Polish this ({{.Language}}): {{.Content}}")},
+		})
+		s.Require().NoError(err)
+		usecase := NewAIUsecase(s.mockAIService, 45*time.Second, WithPromptTemplates(prompts), WithPromptLanguage("French"))
+		s.mockAIService.On("GenerateCompletion", mock.Anything, "Polish this (French): "+originalContent).Return("ok", nil).Once()
+
+		_, err = usecase.RefineBlogPost(ctx, originalContent)
+
+		s.NoError(err)
+		s.mockAIService.AssertExpectations(s.T())
+	})
+
 	s.Run("Failure - Empty content provided", func() {
 		s.SetupTest()
 		// Act
@@ -188,3 +228,233 @@ func (s *AIUsecaseTestSuite) TestRefineBlogPost() {
 		s.mockAIService.AssertNotCalled(s.T(), "GenerateCompletion", mock.Anything, mock.Anything)
 	})
 }
+
+func (s *AIUsecaseTestSuite) TestScoreContent() {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name     string
+		response string
+		expected float64
+	}{
+		{"Success", "0.82", 0.82},
+		{"Success - Strips code fences and whitespace", "```\n0.1\n```", 0.1},
+	} {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockAIService.On("GenerateCompletion", mock.Anything, mock.Anything).Return(tc.response, nil).Once()
+
+			score, err := s.usecase.ScoreContent(ctx, "some text")
+
+			s.NoError(err)
+			s.InDelta(tc.expected, score, 1e-9)
+		})
+	}
+
+	for _, response := range []string{"very toxic", "1.5", "-0.2", "NaN"} {
+		s.Run("Failure - Invalid score "+response, func() {
+			s.SetupTest()
+			s.mockAIService.On("GenerateCompletion", mock.Anything, mock.Anything).Return(response, nil).Once()
+
+			_, err := s.usecase.ScoreContent(ctx, "some text")
+
+			s.ErrorIs(err, ErrInternal)
+		})
+	}
+
+	s.Run("Failure - Empty text", func() {
+		s.SetupTest()
+
+		_, err := s.usecase.ScoreContent(ctx, "  ")
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.mockAIService.AssertNotCalled(s.T(), "GenerateCompletion", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Success - Scores are cached by content", func() {
+		s.SetupTest()
+		usecase := NewAIUsecase(s.mockAIService, 45*time.Second, WithToxicityScoreCache(newFakeRedisCache()))
+		s.mockAIService.On("GenerateCompletion", mock.Anything, mock.Anything).Return("0.4", nil).Once()
+		s.mockAIService.On("GenerateCompletion", mock.Anything, mock.Anything).Return("0.9", nil).Once()
+
+		first, err := usecase.ScoreContent(ctx, "same text")
+		s.Require().NoError(err)
+		again, err := usecase.ScoreContent(ctx, "same text")
+		s.Require().NoError(err)
+		other, err := usecase.ScoreContent(ctx, "other text")
+		s.Require().NoError(err)
+
+		s.Equal(0.4, first)
+		s.Equal(0.4, again, "the cached score is reused")
+		s.Equal(0.9, other)
+		s.mockAIService.AssertNumberOfCalls(s.T(), "GenerateCompletion", 2)
+	})
+}
+
+func (s *AIUsecaseTestSuite) TestStreamRefineBlogPost() {
+	ctx := context.Background()
+	originalContent := "this is my blog post. it is not very good."
+
+	s.Run("Success - Forwards Chunks In Order", func() {
+		service := &fakeStreamingAIService{chunks: []string{"This ", "is ", "my ", "blog ", "post."}}
+		usecase := NewAIUsecase(service, 45*time.Second)
+
+		var received []string
+		err := usecase.StreamRefineBlogPost(ctx, originalContent, func(chunk string) error {
+			received = append(received, chunk)
+			return nil
+		})
+
+		s.NoError(err)
+		s.Equal(service.chunks, received)
+		s.Contains(service.prompt, originalContent)
+	})
+
+	s.Run("Stops When The Consumer Fails", func() {
+		service := &fakeStreamingAIService{chunks: []string{"one ", "two ", "three"}}
+		usecase := NewAIUsecase(service, 45*time.Second)
+		consumerErr := errors.New("client went away")
+
+		err := usecase.StreamRefineBlogPost(ctx, originalContent, func(chunk string) error {
+			if chunk == "two " {
+				return consumerErr
+			}
+			return nil
+		})
+
+		s.ErrorIs(err, consumerErr)
+		s.Equal(1, service.sent)
+	})
+
+	s.Run("Stops When The Context Is Cancelled", func() {
+		service := &fakeStreamingAIService{chunks: []string{"one ", "two ", "three"}}
+		usecase := NewAIUsecase(service, 45*time.Second)
+		cancelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var received []string
+		err := usecase.StreamRefineBlogPost(cancelCtx, originalContent, func(chunk string) error {
+			received = append(received, chunk)
+			cancel()
+			return nil
+		})
+
+		s.ErrorIs(err, context.Canceled)
+		s.Equal([]string{"one "}, received)
+	})
+
+	s.Run("Failure - Empty content provided", func() {
+		s.SetupTest()
+
+		err := s.usecase.StreamRefineBlogPost(ctx, "  ", func(string) error { return nil })
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.mockAIService.AssertNotCalled(s.T(), "StreamCompletion", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// blockingAIService holds every call open until released, recording how many overlap.
+type blockingAIService struct {
+	MockAIService
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	calls    int
+	started  chan struct{}
+	release  chan struct{}
+}
+
+func newBlockingAIService() *blockingAIService {
+	return &blockingAIService{started: make(chan struct{}, 100), release: make(chan struct{})}
+}
+
+func (b *blockingAIService) GenerateCompletion(ctx context.Context, prompt string) (string, error) {
+	b.mu.Lock()
+	b.inFlight++
+	b.calls++
+	if b.inFlight > b.peak {
+		b.peak = b.inFlight
+	}
+	b.mu.Unlock()
+	b.started <- struct{}{}
+
+	<-b.release
+
+	b.mu.Lock()
+	b.inFlight--
+	b.mu.Unlock()
+	return "refined", nil
+}
+
+func (s *AIUsecaseTestSuite) TestMaxConcurrentRequests() {
+	ctx := context.Background()
+
+	s.Run("No More Than N Calls Reach The Service At Once", func() {
+		const limit, callers = 2, 6
+		service := newBlockingAIService()
+		usecase := NewAIUsecase(service, 45*time.Second, WithMaxConcurrentRequests(limit, 5*time.Second))
+
+		var wg sync.WaitGroup
+		errs := make(chan error, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := usecase.RefineBlogPost(ctx, "some content")
+				errs <- err
+			}()
+		}
+
+		// Let calls through one at a time, giving queued callers a chance to overshoot the limit.
+		for i := 0; i < callers; i++ {
+			<-service.started
+			time.Sleep(10 * time.Millisecond)
+			service.release <- struct{}{}
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			s.NoError(err)
+		}
+		s.Equal(callers, service.calls)
+		s.Equal(limit, service.peak)
+	})
+
+	s.Run("Full Queue Times Out With ErrQuotaExceeded", func() {
+		service := newBlockingAIService()
+		usecase := NewAIUsecase(service, 45*time.Second, WithMaxConcurrentRequests(1, 20*time.Millisecond))
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := usecase.RefineBlogPost(ctx, "first")
+			done <- err
+		}()
+		<-service.started
+
+		_, err := usecase.RefineBlogPost(ctx, "second")
+		s.ErrorIs(err, domain.ErrQuotaExceeded)
+
+		err = usecase.StreamRefineBlogPost(ctx, "third", func(string) error { return nil })
+		s.ErrorIs(err, domain.ErrQuotaExceeded)
+
+		service.release <- struct{}{}
+		s.NoError(<-done)
+		s.Equal(1, service.calls)
+	})
+
+	s.Run("Queued Call Gives Up When Its Context Ends", func() {
+		service := newBlockingAIService()
+		usecase := NewAIUsecase(service, 45*time.Second, WithMaxConcurrentRequests(1, time.Minute))
+
+		go func() { _, _ = usecase.RefineBlogPost(ctx, "first") }()
+		<-service.started
+
+		cancelCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		_, err := usecase.GenerateBlogIdeas(cancelCtx, []string{"go"})
+		s.ErrorIs(err, context.DeadlineExceeded)
+
+		service.release <- struct{}{}
+	})
+}
@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	"context"
+	"log"
+	"time"
+)
+
+// auditUsecase implements the domain.IAuditUsecase interface.
+type auditUsecase struct {
+	auditRepo      domain.IAuditRepository
+	contextTimeout time.Duration
+}
+
+func NewAuditUsecase(auditRepository domain.IAuditRepository, timeout time.Duration) domain.IAuditUsecase {
+	return &auditUsecase{
+		auditRepo:      auditRepository,
+		contextTimeout: timeout,
+	}
+}
+
+func (au *auditUsecase) Search(ctx context.Context, options domain.AuditFilterOptions) ([]*domain.AuditEntry, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, au.contextTimeout)
+	defer cancel()
+
+	if options.Limit <= 0 {
+		options.Limit = 10
+	}
+	if options.Limit > 100 {
+		options.Limit = 100
+	}
+	if options.Page <= 0 {
+		options.Page = 1
+	}
+	if options.StartDate != nil && options.EndDate != nil && options.EndDate.Before(*options.StartDate) {
+		return nil, 0, domain.ErrValidation
+	}
+
+	return au.auditRepo.Search(ctx, options)
+}
+
+// recordAudit writes an audit entry for an action that has already happened.
+// A failed write is logged rather than returned, so it never undoes or hides the action itself.
+// A nil repository turns auditing off.
+func recordAudit(ctx context.Context, auditRepo domain.IAuditRepository, entry *domain.AuditEntry) {
+	if auditRepo == nil {
+		return
+	}
+	entry.Timestamp = time.Now().UTC()
+	if err := auditRepo.Create(ctx, entry); err != nil {
+		log.Printf("ERROR: failed to write audit entry %s by %s on %s: %v", entry.Action, entry.ActorID, entry.TargetID, err)
+	}
+}
@@ -0,0 +1,71 @@
+package usecases_test
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// --- MOCK DEFINITIONS ---
+
+type MockAuditRepository struct{ mock.Mock }
+
+func (m *MockAuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+func (m *MockAuditRepository) Search(ctx context.Context, options domain.AuditFilterOptions) ([]*domain.AuditEntry, int64, error) {
+	args := m.Called(ctx, options)
+	var entries []*domain.AuditEntry
+	if args.Get(0) != nil {
+		entries = args.Get(0).([]*domain.AuditEntry)
+	}
+	return entries, args.Get(1).(int64), args.Error(2)
+}
+
+// --- TEST FUNCTIONS ---
+
+func TestAuditUsecase_Search(t *testing.T) {
+	t.Run("Success - Applies Default Pagination", func(t *testing.T) {
+		mockAuditRepo := new(MockAuditRepository)
+		uc := usecases.NewAuditUsecase(mockAuditRepo, 2*time.Second)
+		entries := []*domain.AuditEntry{{ID: "a1", ActorID: "admin-1", Action: domain.AuditActionRoleChange}}
+
+		mockAuditRepo.On("Search", mock.Anything, domain.AuditFilterOptions{Page: 1, Limit: 10}).Return(entries, int64(1), nil).Once()
+
+		result, total, err := uc.Search(context.Background(), domain.AuditFilterOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, entries, result)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Caps The Page Size", func(t *testing.T) {
+		mockAuditRepo := new(MockAuditRepository)
+		uc := usecases.NewAuditUsecase(mockAuditRepo, 2*time.Second)
+
+		mockAuditRepo.On("Search", mock.Anything, mock.MatchedBy(func(o domain.AuditFilterOptions) bool {
+			return o.Page == 3 && o.Limit == 100
+		})).Return([]*domain.AuditEntry{}, int64(0), nil).Once()
+
+		_, _, err := uc.Search(context.Background(), domain.AuditFilterOptions{Page: 3, Limit: 1000})
+		assert.NoError(t, err)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - End Date Before Start Date", func(t *testing.T) {
+		mockAuditRepo := new(MockAuditRepository)
+		uc := usecases.NewAuditUsecase(mockAuditRepo, 2*time.Second)
+		start := time.Now()
+		end := start.Add(-time.Hour)
+
+		_, _, err := uc.Search(context.Background(), domain.AuditFilterOptions{StartDate: &start, EndDate: &end})
+		assert.ErrorIs(t, err, domain.ErrValidation)
+		mockAuditRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+	})
+}
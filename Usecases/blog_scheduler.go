@@ -0,0 +1,29 @@
+package usecases
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	"context"
+	"log"
+	"time"
+)
+
+// RunScheduledPublisher publishes due blogs every interval until ctx is cancelled.
+// It runs once immediately so posts that fell due while the server was down go out on startup.
+func RunScheduledPublisher(ctx context.Context, blogUsecase domain.IBlogUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := blogUsecase.PublishDueBlogs(ctx); err != nil {
+			log.Printf("Scheduled publisher failed: %v", err)
+		} else if n > 0 {
+			log.Printf("Scheduled publisher published %d blog(s)", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -4,45 +4,239 @@ import (
 	domain "A2SV_Starter_Project_Blog/Domain"
 	"context"
 	"errors"
+	"log"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+// MaxBlogImportBatch caps how many blogs a single admin import may contain.
+const MaxBlogImportBatch = 500
+
+// MaxBlogBatchFetch caps how many blogs can be fetched by ID in one request.
+const MaxBlogBatchFetch = 100
+
+// MaxInteractionBatch caps how many likes and dislikes one batch request may apply.
+const MaxInteractionBatch = 50
+
 var (
 	ErrNotFound = errors.New("not found")
 	ErrConflict = errors.New("resource conflict or already exists")
 	ErrInternal = errors.New("internal server error")
 )
 
+// DefaultMaxRevisions is how many past versions of a blog are kept unless configured otherwise.
+const DefaultMaxRevisions = 20
+
+// DefaultMaxTagListLimit caps a page of the tag listing unless configured otherwise.
+const DefaultMaxTagListLimit = 50
+
+// MaxTitleSuggestions caps how many titles one autocomplete request returns.
+const MaxTitleSuggestions = 10
+
+// DetailCommentsLimit and DetailRelatedLimit size the comments and related blogs sections of a blog's detail view.
+const (
+	DetailCommentsLimit = 10
+	DetailRelatedLimit  = 5
+)
+
+// maxTitleQueryLength is the longest autocomplete query, in characters. Longer ones are cut,
+// since no title needs more to be told apart.
+const maxTitleQueryLength = 100
+
 // blogUsecase implements the domain.BlogUsecase interface.
 // It orchestrates the business logic, using the repository for persistence.
 type blogUsecase struct {
 	blogRepo        domain.IBlogRepository
 	userRepo        UserRepository
 	interactionRepo domain.IInteractionRepository
+	revisionRepo    domain.IBlogRevisionRepository
+	commentRepo     domain.ICommentRepository
+	readRepo        domain.IBlogReadRepository
+	followRepo      domain.IFollowRepository
 	contextTimeout  time.Duration
+
+	maxRevisions int
+	maxTagLimit  int64
+	profanity    *domain.ProfanityFilter
+	imageHosts   *domain.ImageHostPolicy
+	auditRepo    domain.IAuditRepository
+
+	trendingFeedFallback bool
+	// duplicateWindow is how long an identical blog by the same author is rejected. Zero disables the check.
+	duplicateWindow time.Duration
+	// minAccountAge is how old an account must be to create blogs. Zero disables the check.
+	minAccountAge time.Duration
+	// preModeration holds new blogs in the moderation queue instead of publishing them.
+	preModeration bool
+	// defaultSortBy orders blog searches that don't ask for an order. Empty leaves it to the repository.
+	defaultSortBy string
+	similarity    *similarityGuard
+	toxicity      *toxicityGate
+}
+
+// BlogUsecaseOption configures optional behaviour of the blog usecase.
+type BlogUsecaseOption func(*blogUsecase)
+
+// WithMaxRevisions caps how many revisions are stored per blog. Older ones are pruned.
+func WithMaxRevisions(n int) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		if n > 0 {
+			bu.maxRevisions = n
+		}
+	}
+}
+
+// WithMaxTagListLimit caps how many tags one page of the tag listing may hold. Larger requested
+// limits are lowered to it.
+func WithMaxTagListLimit(n int) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		if n > 0 {
+			bu.maxTagLimit = int64(n)
+		}
+	}
+}
+
+// WithBlogProfanityFilter screens blog titles on create and update.
+func WithBlogProfanityFilter(filter *domain.ProfanityFilter) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		bu.profanity = filter
+	}
+}
+
+// WithImageHostPolicy checks the images embedded in blog content on create and update.
+func WithImageHostPolicy(policy *domain.ImageHostPolicy) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		bu.imageHosts = policy
+	}
+}
+
+// WithBlogAuditLog records admin deletions of other users' blogs, bulk imports and tag merges in the audit log.
+func WithBlogAuditLog(auditRepo domain.IAuditRepository) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		bu.auditRepo = auditRepo
+	}
+}
+
+// WithDuplicateWindow rejects a new blog with ErrConflict when its author already posted one with
+// the same title and content within the window, catching double submits. Zero or less disables it.
+func WithDuplicateWindow(window time.Duration) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		if window > 0 {
+			bu.duplicateWindow = window
+		}
+	}
+}
+
+// WithMinAccountAge rejects blogs from accounts younger than minAge with ErrAccountTooNew,
+// to slow down spam from freshly registered bots. Zero or less disables it.
+func WithMinAccountAge(minAge time.Duration) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		if minAge > 0 {
+			bu.minAccountAge = minAge
+		}
+	}
+}
+
+// WithFollowingFeed enables the following feed, built from the authors each user follows.
+// With trendingFallback set, a user who follows nobody gets the most popular blogs instead of an empty feed.
+func WithFollowingFeed(followRepo domain.IFollowRepository, trendingFallback bool) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		bu.followRepo = followRepo
+		bu.trendingFeedFallback = trendingFallback
+	}
+}
+
+// WithBlogSimilarityWindow rejects a new blog with ErrConflict when its author posted a
+// near-identical one within the window. maxWords sets the sensitivity: above zero, only the first
+// maxWords words are compared. A nil cache or a window of zero or less disables it.
+func WithBlogSimilarityWindow(cache domain.ICacheService, window time.Duration, maxWords int) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		bu.similarity = newSimilarityGuard(cache, window, maxWords)
+	}
+}
+
+// WithBlogToxicityThreshold scores each new blog's title and content with the AI and rejects
+// or queues, depending on action, those scoring above threshold. A nil scorer or a threshold of
+// zero or less disables it.
+func WithBlogToxicityThreshold(scorer domain.IContentScorer, threshold float64, action domain.ToxicityAction) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		bu.toxicity = newToxicityGate(scorer, threshold, action)
+	}
+}
+
+// WithPreModeration holds every new blog for an admin to approve before it goes public.
+// Scheduled blogs join the queue when their time comes rather than at creation.
+func WithPreModeration(enabled bool) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		bu.preModeration = enabled
+	}
+}
+
+// WithDefaultBlogSort sets the sortBy applied to blog searches that don't give one, such as
+// "popularity" for a trending-first front page. Without it, blogs are listed newest first.
+func WithDefaultBlogSort(sortBy string) BlogUsecaseOption {
+	return func(bu *blogUsecase) {
+		bu.defaultSortBy = sortBy
+	}
 }
 
 // NewBlogUsecase is the constructor for a blogUsecase.
 // It uses dependency injection to receive its dependencies.
-func NewBlogUsecase(blogRepository domain.IBlogRepository, userRepository UserRepository, interactionRepository domain.IInteractionRepository, timeout time.Duration) domain.IBlogUsecase {
-	return &blogUsecase{
+func NewBlogUsecase(blogRepository domain.IBlogRepository, userRepository UserRepository, interactionRepository domain.IInteractionRepository, revisionRepository domain.IBlogRevisionRepository, commentRepository domain.ICommentRepository, readRepository domain.IBlogReadRepository, timeout time.Duration, opts ...BlogUsecaseOption) domain.IBlogUsecase {
+	bu := &blogUsecase{
 		blogRepo:        blogRepository,
 		userRepo:        userRepository,
 		interactionRepo: interactionRepository,
+		revisionRepo:    revisionRepository,
+		commentRepo:     commentRepository,
+		readRepo:        readRepository,
 		contextTimeout:  timeout,
+		maxRevisions:    DefaultMaxRevisions,
+		maxTagLimit:     DefaultMaxTagListLimit,
+	}
+	for _, opt := range opts {
+		opt(bu)
 	}
+	return bu
 }
 
 // Create handles the business logic for creating a new blog post.
-func (bu *blogUsecase) Create(ctx context.Context, title, content, authorID string, tags []string) (*domain.Blog, error) {
+// A non-nil scheduledFor keeps the post as a draft until that time.
+func (bu *blogUsecase) Create(ctx context.Context, title, content, authorID string, tags []string, scheduledFor *time.Time, format domain.ContentFormat) (*domain.Blog, error) {
+	if format == "" {
+		format = domain.ContentFormatMarkdown
+	}
+	if !format.IsValid() {
+		return nil, domain.ErrValidation
+	}
+
 	// 1. Attempt to create the domain entity using the validating factory.
 	// This enforces the domain's own invariants first.
+	title, err := bu.profanity.Apply(title)
+	if err != nil {
+		return nil, err
+	}
+	content, err = bu.cleanContent(content, format)
+	if err != nil {
+		return nil, err
+	}
 	newBlog, err := domain.NewBlog(title, content, authorID, tags)
 	if err != nil {
 		// The error will be domain.ErrValidation, which we pass up.
 		return nil, err
 	}
+	// Setting the content again counts its words in the chosen format.
+	newBlog.ContentFormat = format
+	newBlog.SetContent(content)
+	if scheduledFor != nil {
+		if err := newBlog.Schedule(*scheduledFor); err != nil {
+			return nil, err
+		}
+	} else if bu.preModeration {
+		newBlog.SubmitForModeration()
+	}
 
 	// 2. The usecase could perform additional, application-specific validation here.
 	// (e.g., check if authorID exists in a user repository).
@@ -53,15 +247,43 @@ func (bu *blogUsecase) Create(ctx context.Context, title, content, authorID stri
 	if author == nil {
 		return nil, domain.ErrUserNotFound
 	}
+	if err := author.CheckAccountAge(bu.minAccountAge, newBlog.CreatedAt); err != nil {
+		return nil, err
+	}
+	// Scored before the repository timeout starts, since the AI usecase applies its own.
+	hold, err := bu.toxicity.screen(ctx, domain.ContentKindBlog, newBlog.Title+"\n"+newBlog.Content)
+	if err != nil {
+		return nil, err
+	}
+	if hold {
+		newBlog.SubmitForModeration()
+	}
 
 	// 3. Set up a context with a timeout for the repository call.
 	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
 	defer cancel()
 
+	if bu.duplicateWindow > 0 {
+		since := newBlog.CreatedAt.Add(-bu.duplicateWindow)
+		duplicate, err := bu.blogRepo.ExistsByContentHash(ctx, authorID, domain.ContentHash(newBlog.Title, newBlog.Content), since)
+		if err != nil {
+			return nil, err
+		}
+		if duplicate {
+			return nil, ErrConflict
+		}
+	}
+
+	similarityKey, err := bu.similarity.claim(ctx, domain.ContentKindBlog, authorID, newBlog.Title+"\n"+newBlog.Content)
+	if err != nil {
+		return nil, err
+	}
+
 	// 4. Call the repository to persist the new blog.
 	// The repository is responsible for generating and setting the final ID on the object.
 	err = bu.blogRepo.Create(ctx, newBlog)
 	if err != nil {
+		bu.similarity.release(ctx, similarityKey)
 		// The repository might return ErrConflict or ErrInternal.
 		return nil, err
 	}
@@ -74,6 +296,10 @@ func (bu *blogUsecase) SearchAndFilter(ctx context.Context, options domain.BlogS
 	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
 	defer cancel()
 
+	if options.SortBy == "" {
+		options.SortBy = bu.defaultSortBy
+	}
+
 	if options.AuthorName != nil && *options.AuthorName != "" {
 		// Find all user IDs that match the provided name.
 		userIDs, err := bu.userRepo.FindUserIDsByName(ctx, *options.AuthorName)
@@ -92,6 +318,24 @@ func (bu *blogUsecase) SearchAndFilter(ctx context.Context, options domain.BlogS
 		options.AuthorIDs = userIDs
 	}
 
+	if options.AuthorProvider != nil {
+		providerIDs, err := bu.userRepo.FindUserIDsByProvider(ctx, *options.AuthorProvider)
+		if err != nil {
+			return nil, 0, ErrInternal
+		}
+
+		// A name and a provider both describe the author, so they narrow each other
+		// whatever the global logic is.
+		if options.AuthorName != nil && *options.AuthorName != "" {
+			providerIDs = intersectIDs(options.AuthorIDs, providerIDs)
+		}
+
+		if len(providerIDs) == 0 && options.GlobalLogic == domain.GlobalLogicAND {
+			return []*domain.Blog{}, 0, nil
+		}
+		options.AuthorIDs = providerIDs
+	}
+
 	if options.Limit <= 0 {
 		options.Limit = 10
 	}
@@ -105,8 +349,83 @@ func (bu *blogUsecase) SearchAndFilter(ctx context.Context, options domain.BlogS
 	return bu.blogRepo.SearchAndFilter(ctx, options)
 }
 
-// GetByID retrieves a single blog post.
-func (bu *blogUsecase) GetByID(ctx context.Context, id string) (*domain.Blog, error) {
+// intersectIDs returns the IDs present in both lists, in the order of the first.
+func intersectIDs(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, id := range b {
+		inB[id] = true
+	}
+	var both []string
+	for _, id := range a {
+		if inB[id] {
+			both = append(both, id)
+		}
+	}
+	return both
+}
+
+// ListByAuthor returns an author's published blogs for their profile, newest first,
+// with the pinned blog, if any, at the top.
+func (bu *blogUsecase) ListByAuthor(ctx context.Context, authorID string, page, limit int64) ([]*domain.Blog, int64, error) {
+	author, err := bu.userRepo.GetByID(ctx, authorID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if author == nil {
+		return nil, 0, domain.ErrUserNotFound
+	}
+
+	return bu.SearchAndFilter(ctx, domain.BlogSearchFilterOptions{
+		AuthorIDs:   []string{authorID},
+		GlobalLogic: domain.GlobalLogicAND,
+		SortBy:      "date",
+		SortOrder:   domain.SortOrderDESC,
+		PinnedFirst: true,
+		Page:        page,
+		Limit:       limit,
+	})
+}
+
+// GetFollowingFeed returns the published blogs of the authors the user follows, newest first.
+// If the user follows nobody the feed is empty, or the trending blogs when the fallback is enabled.
+func (bu *blogUsecase) GetFollowingFeed(ctx context.Context, userID string, page, limit int64) ([]*domain.Blog, int64, error) {
+	var followeeIDs []string
+	if bu.followRepo != nil {
+		lookupCtx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+		defer cancel()
+
+		var err error
+		followeeIDs, err = bu.followRepo.ListFolloweeIDs(lookupCtx, userID)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if len(followeeIDs) == 0 {
+		if !bu.trendingFeedFallback {
+			return []*domain.Blog{}, 0, nil
+		}
+		return bu.SearchAndFilter(ctx, domain.BlogSearchFilterOptions{
+			SortBy:    "popularity",
+			SortOrder: domain.SortOrderDESC,
+			Page:      page,
+			Limit:     limit,
+		})
+	}
+
+	return bu.SearchAndFilter(ctx, domain.BlogSearchFilterOptions{
+		AuthorIDs:   followeeIDs,
+		GlobalLogic: domain.GlobalLogicAND,
+		SortBy:      "date",
+		SortOrder:   domain.SortOrderDESC,
+		Page:        page,
+		Limit:       limit,
+	})
+}
+
+// GetByID retrieves a single blog post. Like GetBlogDetail, it only finds an unpublished blog
+// for its author or an admin, and counts no view for anyone else.
+func (bu *blogUsecase) GetByID(ctx context.Context, id, viewerID string, viewerRole domain.Role) (*domain.Blog, error) {
 	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
 	defer cancel()
 
@@ -114,6 +433,9 @@ func (bu *blogUsecase) GetByID(ctx context.Context, id string) (*domain.Blog, er
 	if err != nil {
 		return nil, err
 	}
+	if !blog.IsPublished() && blog.AuthorID != viewerID && viewerRole != domain.RoleAdmin {
+		return nil, ErrNotFound
+	}
 
 	// Increment the view of the blog by 1 in background
 	go func() {
@@ -123,6 +445,194 @@ func (bu *blogUsecase) GetByID(ctx context.Context, id string) (*domain.Blog, er
 	return blog, nil
 }
 
+// GetByIDs loads a batch of blogs, e.g. a client's bookmarks, in the order they were asked for.
+// Missing IDs are skipped and repeated ones returned once. Drafts are skipped unless the viewer
+// is their author or an admin, like ExportBlog. Views are not counted.
+func (bu *blogUsecase) GetByIDs(ctx context.Context, ids []string, viewerID string, viewerRole domain.Role) ([]*domain.Blog, error) {
+	if len(ids) == 0 || len(ids) > MaxBlogBatchFetch {
+		return nil, domain.ErrValidation
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	found, err := bu.blogRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	blogsByID := make(map[string]*domain.Blog, len(found))
+	for _, blog := range found {
+		blogsByID[blog.ID] = blog
+	}
+
+	blogs := make([]*domain.Blog, 0, len(found))
+	for _, id := range ids {
+		blog, ok := blogsByID[id]
+		if !ok {
+			continue
+		}
+		// Deleting from the map also drops repeats of the same ID.
+		delete(blogsByID, id)
+		if !blog.IsPublished() && blog.AuthorID != viewerID && viewerRole != domain.RoleAdmin {
+			continue
+		}
+		blogs = append(blogs, blog)
+	}
+	return blogs, nil
+}
+
+// GetViewerAction returns how the user has reacted to a blog, or an empty ActionType if they haven't.
+func (bu *blogUsecase) GetViewerAction(ctx context.Context, blogID, userID string) (domain.ActionType, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	interaction, err := bu.interactionRepo.Get(ctx, userID, blogID)
+	if errors.Is(err, ErrNotFound) || (err == nil && interaction == nil) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return interaction.Action, nil
+}
+
+// MarkRead records that the user has read a blog. Marking the same blog again is a no-op.
+func (bu *blogUsecase) MarkRead(ctx context.Context, blogID, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	if _, err := bu.blogRepo.GetByID(ctx, blogID); err != nil {
+		return err
+	}
+	return bu.readRepo.MarkRead(ctx, userID, blogID)
+}
+
+// ListRead returns the blogs the user has marked read, most recent first.
+func (bu *blogUsecase) ListRead(ctx context.Context, userID string, page, limit int64) ([]*domain.BlogRead, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if page <= 0 {
+		page = 1
+	}
+	return bu.readRepo.ListRead(ctx, userID, page, limit)
+}
+
+// ListBlogInteractions returns who liked or disliked a blog, newest first, for its author or an
+// admin. The voters are looked up in one batch; votes by deleted accounts are kept, with no user.
+func (bu *blogUsecase) ListBlogInteractions(ctx context.Context, blogID, userID string, userRole domain.Role, page, limit int64) ([]*domain.BlogInteractionEntry, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	if err := bu.authorizeAuthorOrAdmin(ctx, blogID, userID, userRole); err != nil {
+		return nil, 0, err
+	}
+
+	interactions, total, err := bu.interactionRepo.ListByBlog(ctx, blogID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(interactions) == 0 {
+		return []*domain.BlogInteractionEntry{}, total, nil
+	}
+
+	userIDs := make([]string, len(interactions))
+	for i, interaction := range interactions {
+		userIDs[i] = interaction.UserID
+	}
+	users, err := bu.userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	usersByID := make(map[string]*domain.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	entries := make([]*domain.BlogInteractionEntry, len(interactions))
+	for i, interaction := range interactions {
+		entries[i] = &domain.BlogInteractionEntry{Interaction: interaction, User: usersByID[interaction.UserID]}
+	}
+	return entries, total, nil
+}
+
+// ListInteractions returns the user's likes and dislikes, newest first, each with the blog it was on.
+// Interactions on blogs that have since been deleted are left out of the page, but still count
+// towards the total.
+func (bu *blogUsecase) ListInteractions(ctx context.Context, userID string, page, limit int64) ([]*domain.InteractionHistoryEntry, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	interactions, total, err := bu.interactionRepo.ListByUser(ctx, userID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(interactions) == 0 {
+		return []*domain.InteractionHistoryEntry{}, total, nil
+	}
+
+	blogIDs := make([]string, len(interactions))
+	for i, interaction := range interactions {
+		blogIDs[i] = interaction.BlogID
+	}
+	blogs, err := bu.blogRepo.GetByIDs(ctx, blogIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	blogsByID := make(map[string]*domain.Blog, len(blogs))
+	for _, blog := range blogs {
+		blogsByID[blog.ID] = blog
+	}
+
+	entries := make([]*domain.InteractionHistoryEntry, 0, len(interactions))
+	for _, interaction := range interactions {
+		blog, ok := blogsByID[interaction.BlogID]
+		if !ok {
+			continue
+		}
+		entries = append(entries, &domain.InteractionHistoryEntry{Interaction: interaction, Blog: blog})
+	}
+	return entries, total, nil
+}
+
+// ReadFlags reports which of the given blogs the user has read, for marking up blog lists.
+func (bu *blogUsecase) ReadFlags(ctx context.Context, userID string, blogIDs []string) (map[string]bool, error) {
+	if len(blogIDs) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	return bu.readRepo.FindRead(ctx, userID, blogIDs)
+}
+
 // Update handles the logic for updating a post, including authorization.
 func (bu *blogUsecase) Update(ctx context.Context, blogID, userID string, userRole domain.Role, updates map[string]interface{}) (*domain.Blog, error) {
 	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
@@ -139,34 +649,180 @@ func (bu *blogUsecase) Update(ctx context.Context, blogID, userID string, userRo
 		return nil, domain.ErrPermissionDenied
 	}
 
-	// 3. Apply updates from the map. This is a secure way to handle partial updates.
+	return bu.applyUpdates(ctx, blogToUpdate, userID, updates)
+}
+
+// SetPinned pins a blog to the top of its author's profile, or unpins it.
+// Only the author may do this, and pinning a blog unpins their previously pinned one.
+func (bu *blogUsecase) SetPinned(ctx context.Context, blogID, userID string, pinned bool) (*domain.Blog, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	blog, err := bu.blogRepo.GetByID(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+	if blog.AuthorID != userID {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	if err := bu.blogRepo.SetPinned(ctx, blogID, userID, pinned); err != nil {
+		return nil, err
+	}
+	blog.PinnedByAuthor = pinned
+	return blog, nil
+}
+
+// RestoreRevision rolls a blog back to a stored revision. The restore is applied as a
+// regular update, so the state being replaced is itself kept as a new revision.
+func (bu *blogUsecase) RestoreRevision(ctx context.Context, blogID, revisionID, userID string, userRole domain.Role) (*domain.Blog, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	blogToUpdate, err := bu.blogRepo.GetByID(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restoring is editing, so the same rule applies: only the author may do it.
+	if blogToUpdate.AuthorID != userID {
+		return nil, domain.ErrPermissionDenied
+	}
+
+	revision, err := bu.revisionRepo.GetByID(ctx, blogID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return bu.applyUpdates(ctx, blogToUpdate, userID, map[string]interface{}{
+		"title":   revision.Title,
+		"content": revision.Content,
+		"tags":    revision.Tags,
+	})
+}
+
+// applyUpdates validates and persists a partial update to an already authorized blog,
+// then records the replaced state as a revision.
+func (bu *blogUsecase) applyUpdates(ctx context.Context, blogToUpdate *domain.Blog, userID string, updates map[string]interface{}) (*domain.Blog, error) {
+	// Keep a copy of the current state; it becomes a revision once the update succeeds.
+	revision := &domain.BlogRevision{
+		BlogID:    blogToUpdate.ID,
+		Title:     blogToUpdate.Title,
+		Content:   blogToUpdate.Content,
+		Tags:      blogToUpdate.Tags,
+		EditorID:  userID,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	format := blogToUpdate.ContentFormat
+	if value, ok := updates["content_format"].(string); ok {
+		format = domain.ContentFormat(value)
+		if !format.IsValid() {
+			return nil, domain.ErrValidation
+		}
+	}
+
+	// Apply updates from the map. This is a secure way to handle partial updates.
 	if title, ok := updates["title"].(string); ok {
 		// Also enforce invariants on update. A title cannot be updated to be empty.
 		if strings.TrimSpace(title) == "" {
 			return nil, domain.ErrValidation
 		}
+		title, err := bu.profanity.Apply(title)
+		if err != nil {
+			return nil, err
+		}
 		blogToUpdate.Title = title
 	}
-	if content, ok := updates["content"].(string); ok {
+	content, ok := updates["content"].(string)
+	if !ok && format != blogToUpdate.ContentFormat {
+		// Content kept across a change of format is cleaned again as the new format.
+		content, ok = blogToUpdate.Content, true
+	}
+	blogToUpdate.ContentFormat = format
+	if ok {
+		// Sanitizing or stripping images may leave nothing behind, so the emptiness check comes after it.
+		content, err := bu.cleanContent(content, format)
+		if err != nil {
+			return nil, err
+		}
 		if strings.TrimSpace(content) == "" {
 			return nil, domain.ErrValidation
 		}
-		blogToUpdate.Content = content
+		blogToUpdate.SetContent(content)
 	}
 	if tags, ok := updates["tags"].([]string); ok {
 		blogToUpdate.Tags = tags
 	}
 
-	// 4. Update the timestamp and persist the changes.
+	// Update the timestamp and persist the changes.
 	blogToUpdate.UpdatedAt = time.Now().UTC()
-	err = bu.blogRepo.Update(ctx, blogToUpdate)
+	err := bu.blogRepo.Update(ctx, blogToUpdate)
 	if err != nil {
 		return nil, err
 	}
 
+	// Record the previous version. History is best-effort and must not fail the edit itself.
+	bu.saveRevision(ctx, revision)
+
 	return blogToUpdate, nil
 }
 
+// cleanContent sanitizes content for its format and then applies the image host policy.
+// Plain text can't embed images, so the policy doesn't look at it.
+func (bu *blogUsecase) cleanContent(content string, format domain.ContentFormat) (string, error) {
+	content = domain.SanitizeContent(content, format)
+	if format == domain.ContentFormatPlainText {
+		return content, nil
+	}
+	return bu.imageHosts.Apply(content)
+}
+
+// saveRevision stores a snapshot and prunes the blog's history down to the configured cap.
+func (bu *blogUsecase) saveRevision(ctx context.Context, revision *domain.BlogRevision) {
+	if err := bu.revisionRepo.Create(ctx, revision); err != nil {
+		log.Printf("Failed to save revision for blog %s: %v", revision.BlogID, err)
+		return
+	}
+	if err := bu.revisionRepo.PruneOldest(ctx, revision.BlogID, bu.maxRevisions); err != nil {
+		log.Printf("Failed to prune revisions for blog %s: %v", revision.BlogID, err)
+	}
+}
+
+// ListRevisions returns a blog's stored revisions, newest first. Only the author or an admin may view them.
+func (bu *blogUsecase) ListRevisions(ctx context.Context, blogID, userID string, userRole domain.Role) ([]*domain.BlogRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	if err := bu.authorizeAuthorOrAdmin(ctx, blogID, userID, userRole); err != nil {
+		return nil, err
+	}
+	return bu.revisionRepo.ListByBlogID(ctx, blogID)
+}
+
+// GetRevision returns a single revision of a blog. Only the author or an admin may view it.
+func (bu *blogUsecase) GetRevision(ctx context.Context, blogID, revisionID, userID string, userRole domain.Role) (*domain.BlogRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	if err := bu.authorizeAuthorOrAdmin(ctx, blogID, userID, userRole); err != nil {
+		return nil, err
+	}
+	return bu.revisionRepo.GetByID(ctx, blogID, revisionID)
+}
+
+// authorizeAuthorOrAdmin returns ErrPermissionDenied unless the user wrote the blog or is an admin.
+func (bu *blogUsecase) authorizeAuthorOrAdmin(ctx context.Context, blogID, userID string, userRole domain.Role) error {
+	blog, err := bu.blogRepo.GetByID(ctx, blogID)
+	if err != nil {
+		return err
+	}
+	if blog.AuthorID != userID && userRole != domain.RoleAdmin {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
 // Delete handles the logic for deleting a post, including complex authorization.
 func (bu *blogUsecase) Delete(ctx context.Context, blogID, userID string, userRole domain.Role) error {
 	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
@@ -187,18 +843,48 @@ func (bu *blogUsecase) Delete(ctx context.Context, blogID, userID string, userRo
 	}
 
 	// 3. If authorization passes, call the repository to delete the post.
-	return bu.blogRepo.Delete(ctx, blogID)
+	if err := bu.blogRepo.Delete(ctx, blogID); err != nil {
+		return err
+	}
+
+	// Authors removing their own posts is routine; only moderation deletes are audited.
+	if !isOwner {
+		recordAudit(ctx, bu.auditRepo, &domain.AuditEntry{
+			ActorID:  userID,
+			Action:   domain.AuditActionBlogDelete,
+			TargetID: blogID,
+			Details:  map[string]string{"author_id": blogToDelete.AuthorID, "title": blogToDelete.Title},
+		})
+	}
+	return nil
 }
 
-func (bu *blogUsecase) InteractWithBlog(ctx context.Context, blogID, userID string, newAction domain.ActionType) error {
+// InteractWithBlog records the user's vote and returns the blog's counts afterwards,
+// so clients can show the outcome without fetching the blog again.
+func (bu *blogUsecase) InteractWithBlog(ctx context.Context, blogID, userID string, newAction domain.ActionType) (*domain.InteractionResult, error) {
 	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
 	defer cancel()
 
+	action, err := bu.applyInteraction(ctx, blogID, userID, newAction)
+	if err != nil {
+		return nil, err
+	}
+
+	likes, dislikes, err := bu.blogRepo.GetInteractionCounts(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.InteractionResult{Likes: likes, Dislikes: dislikes, Action: action}, nil
+}
+
+// applyInteraction toggles or switches the user's vote and moves the blog's counters to match.
+// It returns the user's resulting action, empty if the vote was undone.
+func (bu *blogUsecase) applyInteraction(ctx context.Context, blogID, userID string, newAction domain.ActionType) (domain.ActionType, error) {
 	// Step 1: Check if an interaction already exists for this user and blog.
 	interaction, err := bu.interactionRepo.Get(ctx, userID, blogID)
 	// We specifically check for ErrNotFound. Any other error is a real problem.
 	if err != nil && !errors.Is(err, ErrNotFound) {
-		return err // Return on unexpected database errors
+		return "", err // Return on unexpected database errors
 	}
 
 	// --- Scenario 1: No previous interaction exists. ---
@@ -208,14 +894,27 @@ func (bu *blogUsecase) InteractWithBlog(ctx context.Context, blogID, userID stri
 			BlogID: blogID,
 			Action: newAction,
 		}
-		if err := bu.interactionRepo.Create(ctx, newInteraction); err != nil {
-			return err
+		err := bu.interactionRepo.Create(ctx, newInteraction)
+		if err == nil {
+			if newAction == domain.ActionTypeLike {
+				return newAction, bu.blogRepo.IncrementLikes(ctx, blogID, 1)
+			}
+			return newAction, bu.blogRepo.IncrementDislikes(ctx, blogID, 1)
+		}
+		if !errors.Is(err, ErrConflict) {
+			return "", err
 		}
 
-		if newAction == domain.ActionTypeLike {
-			return bu.blogRepo.IncrementLikes(ctx, blogID, 1)
+		// A concurrent request created the interaction between our Get and Create, and the unique
+		// index refused ours. If it cast the same vote, this is a double submit or a retry, which
+		// must not undo it; otherwise switch to this vote, as if they had come in turn.
+		interaction, err = bu.interactionRepo.Get(ctx, userID, blogID)
+		if err != nil {
+			return "", err
+		}
+		if interaction.Action == newAction {
+			return newAction, nil
 		}
-		return bu.blogRepo.IncrementDislikes(ctx, blogID, 1)
 	}
 
 	// --- Scenario 2: The user is repeating the same action (e.g., clicking "like" on an already-liked post). ---
@@ -223,21 +922,21 @@ func (bu *blogUsecase) InteractWithBlog(ctx context.Context, blogID, userID stri
 	if interaction.Action == newAction {
 		// Delete the interaction record to remove their "vote".
 		if err := bu.interactionRepo.Delete(ctx, interaction.ID); err != nil {
-			return err
+			return "", err
 		}
 
 		// Atomically decrement the correct counter.
 		if newAction == domain.ActionTypeLike {
-			return bu.blogRepo.IncrementLikes(ctx, blogID, -1)
+			return "", bu.blogRepo.IncrementLikes(ctx, blogID, -1)
 		}
-		return bu.blogRepo.IncrementDislikes(ctx, blogID, -1)
+		return "", bu.blogRepo.IncrementDislikes(ctx, blogID, -1)
 	}
 
 	// --- Scenario 3: The user is switching their action (e.g., from dislike to like). ---
 	// First, update the action in the interaction record.
 	interaction.Action = newAction
 	if err := bu.interactionRepo.Update(ctx, interaction); err != nil {
-		return err
+		return "", err
 	}
 
 	// Prepare the increments for the single atomic update.
@@ -253,5 +952,429 @@ func (bu *blogUsecase) InteractWithBlog(ctx context.Context, blogID, userID stri
 
 	// Call the single, atomic repository method to update both counts.
 	// This prevents data inconsistency if one of the two updates were to fail.
-	return bu.blogRepo.UpdateInteractionCounts(ctx, blogID, likesIncrement, dislikesIncrement)
+	return newAction, bu.blogRepo.UpdateInteractionCounts(ctx, blogID, likesIncrement, dislikesIncrement)
+}
+
+// ImportBlogs validates a batch of blogs and inserts the valid ones in a single bulk write.
+// Invalid items don't fail the batch; they are reported back in their result entry instead.
+func (bu *blogUsecase) ImportBlogs(ctx context.Context, actorID string, items []domain.BlogImportItem) ([]domain.BlogImportResult, error) {
+	if len(items) == 0 || len(items) > MaxBlogImportBatch {
+		return nil, domain.ErrValidation
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	results := make([]domain.BlogImportResult, len(items))
+	var valid []*domain.Blog
+	var validIndexes []int
+	// Many imported posts share an author, so only look each one up once.
+	knownAuthors := make(map[string]error)
+
+	for i, item := range items {
+		results[i].Index = i
+
+		// Imported posts are screened like new ones: the title by the profanity filter and the
+		// content by the image-host allowlist.
+		title, err := bu.profanity.Apply(item.Title)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		content, err := bu.cleanContent(item.Content, domain.ContentFormatMarkdown)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		blog, err := domain.NewBlog(title, content, item.AuthorID, item.Tags)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		authorErr, seen := knownAuthors[item.AuthorID]
+		if !seen {
+			author, err := bu.userRepo.GetByID(ctx, item.AuthorID)
+			switch {
+			case errors.Is(err, domain.ErrUserNotFound) || (err == nil && author == nil):
+				authorErr = domain.ErrUserNotFound
+			case err != nil:
+				// A lookup failure is not the item's fault; abort the whole import.
+				return nil, err
+			}
+			knownAuthors[item.AuthorID] = authorErr
+		}
+		if authorErr != nil {
+			results[i].Err = authorErr
+			continue
+		}
+
+		// Preserve the original timestamp when migrating content.
+		if item.CreatedAt != nil {
+			blog.CreatedAt = item.CreatedAt.UTC()
+			blog.UpdatedAt = blog.CreatedAt
+			blog.LastActivityAt = blog.CreatedAt
+			blog.Publish(blog.CreatedAt)
+		}
+
+		valid = append(valid, blog)
+		validIndexes = append(validIndexes, i)
+	}
+
+	if err := bu.blogRepo.CreateMany(ctx, valid); err != nil {
+		return nil, err
+	}
+	for j, blog := range valid {
+		results[validIndexes[j]].BlogID = blog.ID
+	}
+
+	recordAudit(ctx, bu.auditRepo, &domain.AuditEntry{
+		ActorID: actorID,
+		Action:  domain.AuditActionBlogImport,
+		Details: map[string]string{
+			"imported": strconv.Itoa(len(valid)),
+			"failed":   strconv.Itoa(len(items) - len(valid)),
+		},
+	})
+	return results, nil
+}
+
+// MergeTags replaces the tag from with the tag to on every blog, e.g. to fold "go-lang" into
+// "golang". Blogs that already carry both keep a single copy.
+func (bu *blogUsecase) MergeTags(ctx context.Context, actorID, from, to string) (int, error) {
+	return bu.retag(ctx, actorID, from, to, domain.AuditActionTagMerge)
+}
+
+// RenameTag works like MergeTags but refuses a new name that is already in use with
+// ErrTagExists, so two tags are never folded together by accident.
+func (bu *blogUsecase) RenameTag(ctx context.Context, actorID, from, to string) (int, error) {
+	return bu.retag(ctx, actorID, from, to, domain.AuditActionTagRename)
+}
+
+func (bu *blogUsecase) retag(ctx context.Context, actorID, from, to string, action domain.AuditAction) (int, error) {
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" || to == "" || from == to {
+		return 0, domain.ErrValidation
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	if action == domain.AuditActionTagRename {
+		exists, err := bu.blogRepo.HasTag(ctx, to)
+		if err != nil {
+			return 0, err
+		}
+		if exists {
+			return 0, domain.ErrTagExists
+		}
+	}
+
+	ids, err := bu.blogRepo.ReplaceTag(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, ErrNotFound
+	}
+
+	recordAudit(ctx, bu.auditRepo, &domain.AuditEntry{
+		ActorID: actorID,
+		Action:  action,
+		Details: map[string]string{
+			"from":    from,
+			"to":      to,
+			"updated": strconv.Itoa(len(ids)),
+		},
+	})
+	return len(ids), nil
+}
+
+// VerifyCommentCount compares a blog's stored comment counter with its actual comments.
+// It only reports drift and never corrects it, so it is safe to call while debugging.
+func (bu *blogUsecase) VerifyCommentCount(ctx context.Context, blogID string) (*domain.CommentCountCheck, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	stored, err := bu.blogRepo.GetCommentsCount(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := bu.commentRepo.CountByBlogID(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CommentCountCheck{BlogID: blogID, Stored: stored, Actual: actual}, nil
+}
+
+// ExportBlog returns the blog with every comment on it, threaded. The whole thread is loaded in a
+// single query rather than reply page by reply page. A draft is hidden from everyone but its author
+// and admins, as if it didn't exist.
+func (bu *blogUsecase) ExportBlog(ctx context.Context, blogID, userID string, userRole domain.Role) (*domain.BlogExport, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	blog, err := bu.blogRepo.GetByID(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+	if !blog.IsPublished() && blog.AuthorID != userID && userRole != domain.RoleAdmin {
+		return nil, ErrNotFound
+	}
+
+	comments, err := bu.commentRepo.FetchAllByBlogID(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.BlogExport{
+		Blog:       blog,
+		Comments:   domain.ThreadComments(comments),
+		ExportedAt: time.Now().UTC(),
+	}, nil
+}
+
+// CloneBlog starts a new draft from an existing blog, for authors drafting a variation of a post.
+// The copy belongs to the requester, even when an admin clones someone else's blog.
+func (bu *blogUsecase) CloneBlog(ctx context.Context, blogID, userID string, userRole domain.Role) (*domain.Blog, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	blog, err := bu.blogRepo.GetByID(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+	if blog.AuthorID != userID && userRole != domain.RoleAdmin {
+		// Someone else's hidden draft must not be revealed, even by a refusal.
+		if !blog.IsPublished() {
+			return nil, ErrNotFound
+		}
+		return nil, domain.ErrPermissionDenied
+	}
+
+	clone, err := blog.Clone(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := bu.blogRepo.Create(ctx, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// PublishDraft publishes a draft straight away, such as a clone or a scheduled blog its author
+// no longer wants to wait for. With pre-moderation on it goes to the moderation queue instead,
+// just as PublishDueBlogs would send it. Only the author or an admin may publish a draft.
+func (bu *blogUsecase) PublishDraft(ctx context.Context, blogID, userID string, userRole domain.Role) (*domain.Blog, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	blog, err := bu.blogRepo.GetByID(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+	if blog.AuthorID != userID && userRole != domain.RoleAdmin {
+		// Someone else's hidden draft must not be revealed, even by a refusal.
+		if !blog.IsPublished() {
+			return nil, ErrNotFound
+		}
+		return nil, domain.ErrPermissionDenied
+	}
+	if blog.Status != domain.BlogStatusDraft {
+		return nil, ErrConflict
+	}
+
+	now := time.Now().UTC()
+	if bu.preModeration {
+		err = bu.blogRepo.MarkPending(ctx, blog.ID)
+	} else {
+		err = bu.blogRepo.MarkPublished(ctx, blog.ID, now)
+	}
+	if errors.Is(err, ErrNotFound) {
+		// The scheduler or another request moved it out of draft since we loaded it.
+		return nil, ErrConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if bu.preModeration {
+		blog.SubmitForModeration()
+	} else {
+		blog.Publish(now)
+	}
+	return blog, nil
+}
+
+// GetBlogDetail assembles a post page in one call. The blog is loaded first, since a draft the
+// viewer can't see hides everything else, as if it didn't exist. The comments, related blogs and
+// viewer's reaction are then loaded at the same time. Related blogs and the reaction are extras,
+// so failing to load them is logged and leaves their section empty. Views are counted like GetByID.
+func (bu *blogUsecase) GetBlogDetail(ctx context.Context, blogID, viewerID string, viewerRole domain.Role) (*domain.BlogDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	blog, err := bu.blogRepo.GetByID(ctx, blogID)
+	if err != nil {
+		return nil, err
+	}
+	if !blog.IsPublished() && blog.AuthorID != viewerID && viewerRole != domain.RoleAdmin {
+		return nil, ErrNotFound
+	}
+
+	go func() {
+		_ = bu.blogRepo.IncrementViews(context.Background(), blogID)
+	}()
+
+	// Each goroutine writes only its own fields, so no locking is needed.
+	detail := &domain.BlogDetail{Blog: blog}
+	var commentsErr error
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		detail.Comments, detail.CommentsTotal, commentsErr = bu.commentRepo.FetchByBlogID(ctx, blogID, 1, DetailCommentsLimit, domain.CommentSortOldest)
+	}()
+	go func() {
+		defer wg.Done()
+		related, err := bu.relatedBlogs(ctx, blog)
+		if err != nil {
+			log.Printf("Failed to load related blogs for blog %s: %v", blogID, err)
+		}
+		detail.Related = related
+	}()
+	go func() {
+		defer wg.Done()
+		if viewerID == "" {
+			return
+		}
+		action, err := bu.GetViewerAction(ctx, blogID, viewerID)
+		if err != nil {
+			log.Printf("Failed to load viewer action for blog %s: %v", blogID, err)
+		}
+		detail.ViewerAction = action
+	}()
+	wg.Wait()
+
+	if commentsErr != nil {
+		return nil, commentsErr
+	}
+	return detail, nil
+}
+
+// relatedBlogs returns the most popular published blogs sharing a tag with blog, without blog itself.
+// A blog without tags has no related blogs.
+func (bu *blogUsecase) relatedBlogs(ctx context.Context, blog *domain.Blog) ([]*domain.Blog, error) {
+	if len(blog.Tags) == 0 {
+		return []*domain.Blog{}, nil
+	}
+	// One extra, in case the blog itself is among the results.
+	candidates, _, err := bu.blogRepo.SearchAndFilter(ctx, domain.BlogSearchFilterOptions{
+		Tags:      blog.Tags,
+		TagLogic:  domain.GlobalLogicOR,
+		SortBy:    "popularity",
+		SortOrder: domain.SortOrderDESC,
+		Page:      1,
+		Limit:     DetailRelatedLimit + 1,
+	})
+	if err != nil {
+		return []*domain.Blog{}, err
+	}
+
+	related := make([]*domain.Blog, 0, DetailRelatedLimit)
+	for _, candidate := range candidates {
+		if candidate.ID != blog.ID && len(related) < DetailRelatedLimit {
+			related = append(related, candidate)
+		}
+	}
+	return related, nil
+}
+
+// ListTags returns a page of the tags on published blogs with how many blogs use each.
+func (bu *blogUsecase) ListTags(ctx context.Context, page, limit int64, sort domain.TagSort) (*domain.TagPage, error) {
+	if sort == "" {
+		sort = domain.TagSortCount
+	}
+	if !sort.IsValid() || page < 1 || limit < 1 {
+		return nil, domain.ErrValidation
+	}
+	limit = min(limit, bu.maxTagLimit)
+
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	tags, total, err := bu.blogRepo.ListTags(ctx, page, limit, sort)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TagPage{Tags: tags, Total: total, Limit: limit}, nil
+}
+
+// GetRandom returns one published blog at random for discovery, optionally from a single tag.
+// Like GetByIDs, it does not count a view.
+func (bu *blogUsecase) GetRandom(ctx context.Context, tag string) (*domain.Blog, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	var tagFilter *string
+	if tag = strings.TrimSpace(tag); tag != "" {
+		tagFilter = &tag
+	}
+	return bu.blogRepo.GetRandom(ctx, tagFilter)
+}
+
+// SuggestTitles offers published titles for a search box as the user types. Runs of whitespace
+// in the query count as a single space, and a blank query gets no suggestions.
+func (bu *blogUsecase) SuggestTitles(ctx context.Context, query string, limit int) ([]*domain.BlogTitleSuggestion, error) {
+	query = strings.Join(strings.Fields(query), " ")
+	if query == "" {
+		return []*domain.BlogTitleSuggestion{}, nil
+	}
+	if runes := []rune(query); len(runes) > maxTitleQueryLength {
+		query = string(runes[:maxTitleQueryLength])
+	}
+	if limit <= 0 || limit > MaxTitleSuggestions {
+		limit = MaxTitleSuggestions
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	return bu.blogRepo.SuggestTitles(ctx, query, int64(limit))
+}
+
+// publishBatchSize bounds how many due blogs a single publisher run handles.
+const publishBatchSize = 100
+
+// PublishDueBlogs publishes every scheduled blog whose time has passed and returns how many it published.
+// With pre-moderation on, due blogs are moved to the moderation queue instead, and counted all the same.
+// It is driven by a background ticker and is safe to run from several instances at once.
+func (bu *blogUsecase) PublishDueBlogs(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, bu.contextTimeout)
+	defer cancel()
+
+	now := time.Now().UTC()
+	due, err := bu.blogRepo.FindDueScheduled(ctx, now, publishBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, blog := range due {
+		var err error
+		if bu.preModeration {
+			err = bu.blogRepo.MarkPending(ctx, blog.ID)
+		} else {
+			err = bu.blogRepo.MarkPublished(ctx, blog.ID, now)
+		}
+		if errors.Is(err, ErrNotFound) {
+			continue // Another worker got there first, or the blog was deleted.
+		}
+		if err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
 }
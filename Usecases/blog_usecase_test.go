@@ -5,6 +5,8 @@ import (
 	usecases "A2SV_Starter_Project_Blog/Usecases"
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -31,6 +33,15 @@ func (m *MockBlogRepository) Create(ctx context.Context, blog *domain.Blog) erro
 	}
 	return args.Error(0)
 }
+func (m *MockBlogRepository) CreateMany(ctx context.Context, blogs []*domain.Blog) error {
+	args := m.Called(ctx, blogs)
+	if args.Error(0) == nil {
+		for i, blog := range blogs {
+			blog.ID = fmt.Sprintf("mock-generated-id-%d", i)
+		}
+	}
+	return args.Error(0)
+}
 func (m *MockBlogRepository) SearchAndFilter(ctx context.Context, options domain.BlogSearchFilterOptions) ([]*domain.Blog, int64, error) {
 	args := m.Called(ctx, options)
 	var blogs []*domain.Blog
@@ -71,10 +82,129 @@ func (m *MockBlogRepository) IncrementViews(ctx context.Context, blogID string)
 	args := m.Called(ctx, blogID)
 	return args.Error(0)
 }
+func (m *MockBlogRepository) AddViews(ctx context.Context, blogID string, count int64) error {
+	args := m.Called(ctx, blogID, count)
+	return args.Error(0)
+}
 func (m *MockBlogRepository) IncrementCommentCount(ctx context.Context, blogID string, value int) error {
 	args := m.Called(ctx, blogID, value)
 	return args.Error(0)
 }
+func (m *MockBlogRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Blog, error) {
+	args := m.Called(ctx, ids)
+	var blogs []*domain.Blog
+	if args.Get(0) != nil {
+		blogs = args.Get(0).([]*domain.Blog)
+	}
+	return blogs, args.Error(1)
+}
+func (m *MockBlogRepository) FindDueScheduled(ctx context.Context, now time.Time, limit int64) ([]*domain.Blog, error) {
+	args := m.Called(ctx, now, limit)
+	var blogs []*domain.Blog
+	if args.Get(0) != nil {
+		blogs = args.Get(0).([]*domain.Blog)
+	}
+	return blogs, args.Error(1)
+}
+func (m *MockBlogRepository) MarkPublished(ctx context.Context, blogID string, publishedAt time.Time) error {
+	args := m.Called(ctx, blogID, publishedAt)
+	return args.Error(0)
+}
+func (m *MockBlogRepository) MarkPending(ctx context.Context, blogID string) error {
+	args := m.Called(ctx, blogID)
+	return args.Error(0)
+}
+func (m *MockBlogRepository) ApprovePending(ctx context.Context, blogID string, publishedAt time.Time) error {
+	args := m.Called(ctx, blogID, publishedAt)
+	return args.Error(0)
+}
+func (m *MockBlogRepository) FetchPending(ctx context.Context, page, limit int64) ([]*domain.Blog, int64, error) {
+	args := m.Called(ctx, page, limit)
+	var blogs []*domain.Blog
+	if args.Get(0) != nil {
+		blogs = args.Get(0).([]*domain.Blog)
+	}
+	return blogs, args.Get(1).(int64), args.Error(2)
+}
+func (m *MockBlogRepository) GetCommentsCount(ctx context.Context, blogID string) (int64, error) {
+	args := m.Called(ctx, blogID)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockBlogRepository) GetInteractionCounts(ctx context.Context, blogID string) (int64, int64, error) {
+	args := m.Called(ctx, blogID)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+func (m *MockBlogRepository) ExistsByContentHash(ctx context.Context, authorID, hash string, since time.Time) (bool, error) {
+	args := m.Called(ctx, authorID, hash, since)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockBlogRepository) HasTag(ctx context.Context, tag string) (bool, error) {
+	args := m.Called(ctx, tag)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockBlogRepository) GetRandom(ctx context.Context, tag *string) (*domain.Blog, error) {
+	args := m.Called(ctx, tag)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Blog), args.Error(1)
+}
+func (m *MockBlogRepository) SuggestTitles(ctx context.Context, query string, limit int64) ([]*domain.BlogTitleSuggestion, error) {
+	args := m.Called(ctx, query, limit)
+	var suggestions []*domain.BlogTitleSuggestion
+	if v := args.Get(0); v != nil {
+		suggestions = v.([]*domain.BlogTitleSuggestion)
+	}
+	return suggestions, args.Error(1)
+}
+func (m *MockBlogRepository) ListTags(ctx context.Context, page, limit int64, sort domain.TagSort) ([]*domain.TagCount, int64, error) {
+	args := m.Called(ctx, page, limit, sort)
+	var tags []*domain.TagCount
+	if args.Get(0) != nil {
+		tags = args.Get(0).([]*domain.TagCount)
+	}
+	return tags, args.Get(1).(int64), args.Error(2)
+}
+func (m *MockBlogRepository) ReplaceTag(ctx context.Context, from, to string) ([]string, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+func (m *MockBlogRepository) SetPinned(ctx context.Context, blogID, authorID string, pinned bool) error {
+	args := m.Called(ctx, blogID, authorID, pinned)
+	return args.Error(0)
+}
+
+type MockBlogRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockBlogRevisionRepository) Create(ctx context.Context, revision *domain.BlogRevision) error {
+	args := m.Called(ctx, revision)
+	return args.Error(0)
+}
+func (m *MockBlogRevisionRepository) ListByBlogID(ctx context.Context, blogID string) ([]*domain.BlogRevision, error) {
+	args := m.Called(ctx, blogID)
+	var revisions []*domain.BlogRevision
+	if args.Get(0) != nil {
+		revisions = args.Get(0).([]*domain.BlogRevision)
+	}
+	return revisions, args.Error(1)
+}
+func (m *MockBlogRevisionRepository) GetByID(ctx context.Context, blogID, revisionID string) (*domain.BlogRevision, error) {
+	args := m.Called(ctx, blogID, revisionID)
+	var revision *domain.BlogRevision
+	if args.Get(0) != nil {
+		revision = args.Get(0).(*domain.BlogRevision)
+	}
+	return revision, args.Error(1)
+}
+func (m *MockBlogRevisionRepository) PruneOldest(ctx context.Context, blogID string, keep int) error {
+	args := m.Called(ctx, blogID, keep)
+	return args.Error(0)
+}
 
 type MockInteractionRepository struct {
 	mock.Mock
@@ -107,6 +237,51 @@ func (m *MockInteractionRepository) Delete(ctx context.Context, interactionID st
 	args := m.Called(ctx, interactionID)
 	return args.Error(0)
 }
+func (m *MockInteractionRepository) ListByUser(ctx context.Context, userID string, page, limit int64) ([]*domain.BlogInteraction, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	var interactions []*domain.BlogInteraction
+	if args.Get(0) != nil {
+		interactions = args.Get(0).([]*domain.BlogInteraction)
+	}
+	return interactions, args.Get(1).(int64), args.Error(2)
+}
+func (m *MockInteractionRepository) ListByBlog(ctx context.Context, blogID string, page, limit int64) ([]*domain.BlogInteraction, int64, error) {
+	args := m.Called(ctx, blogID, page, limit)
+	var interactions []*domain.BlogInteraction
+	if args.Get(0) != nil {
+		interactions = args.Get(0).([]*domain.BlogInteraction)
+	}
+	return interactions, args.Get(1).(int64), args.Error(2)
+}
+
+type MockBlogReadRepository struct {
+	mock.Mock
+}
+
+func (m *MockBlogReadRepository) MarkRead(ctx context.Context, userID, blogID string) error {
+	args := m.Called(ctx, userID, blogID)
+	return args.Error(0)
+}
+func (m *MockBlogReadRepository) IsRead(ctx context.Context, userID, blogID string) (bool, error) {
+	args := m.Called(ctx, userID, blogID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockBlogReadRepository) ListRead(ctx context.Context, userID string, page, limit int64) ([]*domain.BlogRead, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	var reads []*domain.BlogRead
+	if args.Get(0) != nil {
+		reads = args.Get(0).([]*domain.BlogRead)
+	}
+	return reads, args.Get(1).(int64), args.Error(2)
+}
+func (m *MockBlogReadRepository) FindRead(ctx context.Context, userID string, blogIDs []string) (map[string]bool, error) {
+	args := m.Called(ctx, userID, blogIDs)
+	var read map[string]bool
+	if args.Get(0) != nil {
+		read = args.Get(0).(map[string]bool)
+	}
+	return read, args.Error(1)
+}
 
 // --- Test Suite Setup ---
 
@@ -115,6 +290,9 @@ type BlogUsecaseTestSuite struct {
 	mockBlogRepo        *MockBlogRepository
 	mockInteractionRepo *MockInteractionRepository
 	mockUserRepo        *MockUserRepository // Added mock for user repository
+	mockRevisionRepo    *MockBlogRevisionRepository
+	mockCommentRepo     *MockCommentRepository
+	mockReadRepo        *MockBlogReadRepository
 	usecase             domain.IBlogUsecase
 }
 
@@ -124,10 +302,13 @@ func (s *BlogUsecaseTestSuite) SetupTest() {
 	s.mockBlogRepo = new(MockBlogRepository)
 	s.mockInteractionRepo = new(MockInteractionRepository)
 	s.mockUserRepo = new(MockUserRepository) // Initialize the new mock
+	s.mockRevisionRepo = new(MockBlogRevisionRepository)
+	s.mockCommentRepo = new(MockCommentRepository)
+	s.mockReadRepo = new(MockBlogReadRepository)
 
 	// Use a short, fixed timeout for tests.
 	// The constructor call is updated to include the user repository.
-	s.usecase = usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, 2*time.Second)
+	s.usecase = usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second)
 }
 
 // TestBlogUsecaseTestSuite is the entry point for running the suite.
@@ -149,7 +330,7 @@ func (s *BlogUsecaseTestSuite) TestCreate() {
 		s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(nil).Once()
 
 		// Act
-		blog, err := s.usecase.Create(context.Background(), "A Valid Title", "Valid Content", authorID, nil)
+		blog, err := s.usecase.Create(context.Background(), "A Valid Title", "Valid Content", authorID, nil, nil, "")
 
 		// Assert
 		s.NoError(err)
@@ -163,7 +344,7 @@ func (s *BlogUsecaseTestSuite) TestCreate() {
 		// No mock setup is needed because the usecase should fail before calling any repository.
 
 		// Act
-		blog, err := s.usecase.Create(context.Background(), "", "Content", authorID, nil)
+		blog, err := s.usecase.Create(context.Background(), "", "Content", authorID, nil, nil, "")
 
 		// Assert
 		s.Error(err)
@@ -178,7 +359,7 @@ func (s *BlogUsecaseTestSuite) TestCreate() {
 		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(nil, nil).Once()
 
 		// Act
-		blog, err := s.usecase.Create(context.Background(), "A Valid Title", "Valid Content", authorID, nil)
+		blog, err := s.usecase.Create(context.Background(), "A Valid Title", "Valid Content", authorID, nil, nil, "")
 
 		// Assert
 		s.Error(err)
@@ -194,7 +375,7 @@ func (s *BlogUsecaseTestSuite) TestCreate() {
 		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(nil, expectedErr).Once()
 
 		// Act
-		blog, err := s.usecase.Create(context.Background(), "A Valid Title", "Valid Content", authorID, nil)
+		blog, err := s.usecase.Create(context.Background(), "A Valid Title", "Valid Content", authorID, nil, nil, "")
 
 		// Assert
 		s.Error(err)
@@ -212,7 +393,7 @@ func (s *BlogUsecaseTestSuite) TestCreate() {
 		s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(errors.New("db error")).Once()
 
 		// Act
-		blog, err := s.usecase.Create(context.Background(), "A Valid Title", "Valid Content", authorID, nil)
+		blog, err := s.usecase.Create(context.Background(), "A Valid Title", "Valid Content", authorID, nil, nil, "")
 
 		// Assert
 		s.Error(err)
@@ -240,7 +421,7 @@ func (s *BlogUsecaseTestSuite) TestGetByID() {
 			Once()
 
 		// Act
-		blog, err := s.usecase.GetByID(context.Background(), "blog-1")
+		blog, err := s.usecase.GetByID(context.Background(), "blog-1", "", "")
 
 		// Assert
 		s.NoError(err)
@@ -256,7 +437,7 @@ func (s *BlogUsecaseTestSuite) TestGetByID() {
 		s.mockBlogRepo.On("GetByID", mock.Anything, "not-found-id").Return(nil, usecases.ErrNotFound).Once()
 
 		// Act
-		blog, err := s.usecase.GetByID(context.Background(), "not-found-id")
+		blog, err := s.usecase.GetByID(context.Background(), "not-found-id", "", "")
 
 		// Assert
 		s.Error(err)
@@ -267,6 +448,100 @@ func (s *BlogUsecaseTestSuite) TestGetByID() {
 		s.mockBlogRepo.AssertExpectations(s.T())
 		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementViews", mock.Anything, mock.Anything)
 	})
+
+	s.Run("Pending_HiddenFromOthers", func() {
+		s.SetupTest()
+		// Arrange
+		pending, _ := domain.NewBlog("Title", "Content", "author", nil)
+		pending.ID = "pending-1"
+		pending.Status = domain.BlogStatusPending
+		s.mockBlogRepo.On("GetByID", mock.Anything, "pending-1").Return(pending, nil)
+
+		// Act
+		blog, err := s.usecase.GetByID(context.Background(), "pending-1", "other", domain.RoleUser)
+
+		// Assert
+		s.ErrorIs(err, usecases.ErrNotFound)
+		s.Nil(blog)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementViews", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Draft_HiddenFromOthers", func() {
+		s.SetupTest()
+		// Arrange
+		draft, _ := domain.NewBlog("Title", "Content", "author", nil)
+		draft.ID = "draft-1"
+		draft.Status = domain.BlogStatusDraft
+		s.mockBlogRepo.On("GetByID", mock.Anything, "draft-1").Return(draft, nil)
+
+		for _, viewer := range []struct {
+			id   string
+			role domain.Role
+		}{{"", ""}, {"other", domain.RoleUser}} {
+			// Act
+			blog, err := s.usecase.GetByID(context.Background(), "draft-1", viewer.id, viewer.role)
+
+			// Assert
+			s.ErrorIs(err, usecases.ErrNotFound, viewer.id)
+			s.Nil(blog)
+		}
+		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementViews", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Draft_VisibleToAuthorAndAdmin", func() {
+		s.SetupTest()
+		// Arrange
+		draft, _ := domain.NewBlog("Title", "Content", "author", nil)
+		draft.ID = "draft-1"
+		draft.Status = domain.BlogStatusDraft
+		var wg sync.WaitGroup
+		wg.Add(2)
+		s.mockBlogRepo.On("GetByID", mock.Anything, "draft-1").Return(draft, nil)
+		s.mockBlogRepo.On("IncrementViews", mock.Anything, "draft-1").Run(func(mock.Arguments) { wg.Done() }).Return(nil).Twice()
+
+		// Act
+		byAuthor, errAuthor := s.usecase.GetByID(context.Background(), "draft-1", "author", domain.RoleUser)
+		byAdmin, errAdmin := s.usecase.GetByID(context.Background(), "draft-1", "admin", domain.RoleAdmin)
+
+		// Assert
+		s.NoError(errAuthor)
+		s.Equal(draft, byAuthor)
+		s.NoError(errAdmin)
+		s.Equal(draft, byAdmin)
+		wg.Wait()
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestGetViewerAction() {
+	s.Run("Success_Interacted", func() {
+		s.SetupTest()
+		s.mockInteractionRepo.On("Get", mock.Anything, "user-1", "blog-1").Return(&domain.BlogInteraction{Action: domain.ActionTypeDislike}, nil).Once()
+
+		action, err := s.usecase.GetViewerAction(context.Background(), "blog-1", "user-1")
+
+		s.NoError(err)
+		s.Equal(domain.ActionTypeDislike, action)
+	})
+
+	s.Run("Success_NoInteraction", func() {
+		s.SetupTest()
+		s.mockInteractionRepo.On("Get", mock.Anything, "user-1", "blog-1").Return(nil, usecases.ErrNotFound).Once()
+
+		action, err := s.usecase.GetViewerAction(context.Background(), "blog-1", "user-1")
+
+		s.NoError(err)
+		s.Empty(action)
+	})
+
+	s.Run("Failure_RepositoryError", func() {
+		s.SetupTest()
+		dbErr := errors.New("db down")
+		s.mockInteractionRepo.On("Get", mock.Anything, "user-1", "blog-1").Return(nil, dbErr).Once()
+
+		_, err := s.usecase.GetViewerAction(context.Background(), "blog-1", "user-1")
+
+		s.ErrorIs(err, dbErr)
+	})
 }
 
 func (s *BlogUsecaseTestSuite) TestDelete() {
@@ -300,6 +575,25 @@ func (s *BlogUsecaseTestSuite) TestDelete() {
 		s.mockBlogRepo.AssertExpectations(s.T())
 	})
 
+	s.Run("Success_AsAdmin_WritesAuditEntry", func() {
+		// Arrange
+		mockAuditRepo := new(MockAuditRepository)
+		usecase := usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second, usecases.WithBlogAuditLog(mockAuditRepo))
+		s.mockBlogRepo.On("GetByID", mock.Anything, mockBlog.ID).Return(mockBlog, nil).Once()
+		s.mockBlogRepo.On("Delete", mock.Anything, mockBlog.ID).Return(nil).Once()
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.ActorID == "admin-id" && e.Action == domain.AuditActionBlogDelete &&
+				e.TargetID == mockBlog.ID && e.Details["author_id"] == "owner-id"
+		})).Return(nil).Once()
+
+		// Act
+		err := usecase.Delete(context.Background(), mockBlog.ID, "admin-id", domain.RoleAdmin)
+
+		// Assert
+		s.NoError(err)
+		mockAuditRepo.AssertExpectations(s.T())
+	})
+
 	s.Run("Failure_PermissionDenied", func() {
 		// Arrange
 		s.mockBlogRepo.On("GetByID", mock.Anything, mockBlog.ID).Return(mockBlog, nil).Once()
@@ -337,6 +631,11 @@ func (s *BlogUsecaseTestSuite) TestUpdate() {
 		// Arrange
 		s.mockBlogRepo.On("GetByID", mock.Anything, mockBlog.ID).Return(mockBlog, nil).Once()
 		s.mockBlogRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(nil).Once()
+		// The pre-update state is recorded as a revision.
+		s.mockRevisionRepo.On("Create", mock.Anything, mock.MatchedBy(func(rev *domain.BlogRevision) bool {
+			return rev.BlogID == mockBlog.ID && rev.Title == "Old Title" && rev.Content == "Old Content" && rev.EditorID == "owner-id"
+		})).Return(nil).Once()
+		s.mockRevisionRepo.On("PruneOldest", mock.Anything, mockBlog.ID, usecases.DefaultMaxRevisions).Return(nil).Once()
 
 		// Act
 		updatedBlog, err := s.usecase.Update(context.Background(), mockBlog.ID, "owner-id", domain.RoleUser, updates)
@@ -346,6 +645,7 @@ func (s *BlogUsecaseTestSuite) TestUpdate() {
 		s.NotNil(updatedBlog)
 		s.Equal("New Valid Title", updatedBlog.Title) // Verify the title was updated.
 		s.mockBlogRepo.AssertExpectations(s.T())
+		s.mockRevisionRepo.AssertExpectations(s.T())
 	})
 
 	s.Run("Failure_PermissionDenied", func() {
@@ -494,6 +794,86 @@ func (s *BlogUsecaseTestSuite) TestSearchAndFilter() {
 		s.mockUserRepo.AssertExpectations(s.T())
 		s.mockBlogRepo.AssertNotCalled(s.T(), "SearchAndFilter")
 	})
+
+	s.Run("Success_ConfiguredDefaultSort", func() {
+		s.SetupTest()
+		// Arrange
+		uc := usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+			usecases.WithDefaultBlogSort("popularity"))
+		s.mockBlogRepo.On("SearchAndFilter", mock.Anything, domain.BlogSearchFilterOptions{Page: 1, Limit: 10, SortBy: "popularity"}).Return([]*domain.Blog{}, int64(0), nil).Once()
+		s.mockBlogRepo.On("SearchAndFilter", mock.Anything, domain.BlogSearchFilterOptions{Page: 1, Limit: 10, SortBy: "title"}).Return([]*domain.Blog{}, int64(0), nil).Once()
+
+		// Act
+		_, _, errDefault := uc.SearchAndFilter(context.Background(), domain.BlogSearchFilterOptions{Page: 1, Limit: 10})
+		_, _, errExplicit := uc.SearchAndFilter(context.Background(), domain.BlogSearchFilterOptions{Page: 1, Limit: 10, SortBy: "title"})
+
+		// Assert
+		s.NoError(errDefault)
+		s.NoError(errExplicit, "a sortBy given by the client wins")
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestSearchAndFilter_AuthorProvider() {
+	google := domain.ProviderGoogle
+
+	s.Run("Success_ResolvesProviderToAuthorIDs", func() {
+		s.SetupTest()
+		opts := domain.BlogSearchFilterOptions{AuthorProvider: &google, GlobalLogic: domain.GlobalLogicAND}
+		s.mockUserRepo.On("FindUserIDsByProvider", mock.Anything, google).Return([]string{"g-1", "g-2"}, nil).Once()
+		s.mockBlogRepo.On("SearchAndFilter", mock.Anything, mock.MatchedBy(func(o domain.BlogSearchFilterOptions) bool {
+			return s.Equal([]string{"g-1", "g-2"}, o.AuthorIDs)
+		})).Return([]*domain.Blog{{ID: "blog-1"}}, int64(1), nil).Once()
+
+		blogs, total, err := s.usecase.SearchAndFilter(context.Background(), opts)
+
+		s.NoError(err)
+		s.Len(blogs, 1)
+		s.Equal(int64(1), total)
+		s.mockUserRepo.AssertExpectations(s.T())
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Success_IntersectsWithAuthorName", func() {
+		s.SetupTest()
+		authorName := "john"
+		// Even under OR logic, the name and the provider must describe the same author.
+		opts := domain.BlogSearchFilterOptions{AuthorName: &authorName, AuthorProvider: &google, GlobalLogic: domain.GlobalLogicOR}
+		s.mockUserRepo.On("FindUserIDsByName", mock.Anything, authorName).Return([]string{"local-john", "g-john"}, nil).Once()
+		s.mockUserRepo.On("FindUserIDsByProvider", mock.Anything, google).Return([]string{"g-jane", "g-john"}, nil).Once()
+		s.mockBlogRepo.On("SearchAndFilter", mock.Anything, mock.MatchedBy(func(o domain.BlogSearchFilterOptions) bool {
+			return s.Equal([]string{"g-john"}, o.AuthorIDs)
+		})).Return([]*domain.Blog{}, int64(0), nil).Once()
+
+		_, _, err := s.usecase.SearchAndFilter(context.Background(), opts)
+
+		s.NoError(err)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Success_ShortCircuit_WhenNoAuthorsUseProvider", func() {
+		s.SetupTest()
+		opts := domain.BlogSearchFilterOptions{AuthorProvider: &google, GlobalLogic: domain.GlobalLogicAND}
+		s.mockUserRepo.On("FindUserIDsByProvider", mock.Anything, google).Return([]string{}, nil).Once()
+
+		blogs, total, err := s.usecase.SearchAndFilter(context.Background(), opts)
+
+		s.NoError(err)
+		s.Empty(blogs)
+		s.Equal(int64(0), total)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "SearchAndFilter", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure_WhenUserRepoFails", func() {
+		s.SetupTest()
+		opts := domain.BlogSearchFilterOptions{AuthorProvider: &google}
+		s.mockUserRepo.On("FindUserIDsByProvider", mock.Anything, google).Return(nil, errors.New("user db down")).Once()
+
+		_, _, err := s.usecase.SearchAndFilter(context.Background(), opts)
+
+		s.ErrorIs(err, usecases.ErrInternal)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "SearchAndFilter", mock.Anything, mock.Anything)
+	})
 }
 
 func (s *BlogUsecaseTestSuite) TestInteractWithBlog() {
@@ -512,12 +892,15 @@ func (s *BlogUsecaseTestSuite) TestInteractWithBlog() {
 		s.mockInteractionRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.BlogInteraction")).Return(nil).Once()
 		// 3. Expect IncrementLikes to be called
 		s.mockBlogRepo.On("IncrementLikes", mock.Anything, blogID, 1).Return(nil).Once()
+		// 4. The counts are read back after the write
+		s.mockBlogRepo.On("GetInteractionCounts", mock.Anything, blogID).Return(int64(6), int64(2), nil).Once()
 
 		// Act
-		err := s.usecase.InteractWithBlog(ctx, blogID, userID, action)
+		result, err := s.usecase.InteractWithBlog(ctx, blogID, userID, action)
 
 		// Assert
 		s.NoError(err)
+		s.Equal(&domain.InteractionResult{Likes: 6, Dislikes: 2, Action: domain.ActionTypeLike}, result)
 		s.mockInteractionRepo.AssertExpectations(s.T())
 		s.mockBlogRepo.AssertExpectations(s.T())
 	})
@@ -533,12 +916,14 @@ func (s *BlogUsecaseTestSuite) TestInteractWithBlog() {
 		s.mockInteractionRepo.On("Delete", mock.Anything, existingInteraction.ID).Return(nil).Once()
 		// 3. Expect IncrementLikes with a negative value
 		s.mockBlogRepo.On("IncrementLikes", mock.Anything, blogID, -1).Return(nil).Once()
+		s.mockBlogRepo.On("GetInteractionCounts", mock.Anything, blogID).Return(int64(5), int64(2), nil).Once()
 
 		// Act
-		err := s.usecase.InteractWithBlog(ctx, blogID, userID, action)
+		result, err := s.usecase.InteractWithBlog(ctx, blogID, userID, action)
 
 		// Assert
 		s.NoError(err)
+		s.Equal(&domain.InteractionResult{Likes: 5, Dislikes: 2}, result, "An undone vote leaves no action")
 		s.mockInteractionRepo.AssertExpectations(s.T())
 		s.mockBlogRepo.AssertExpectations(s.T())
 	})
@@ -554,14 +939,40 @@ func (s *BlogUsecaseTestSuite) TestInteractWithBlog() {
 		s.mockInteractionRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.BlogInteraction")).Return(nil).Once()
 		// 3. Expect the atomic SwapCounts method to be called with the correct values
 		s.mockBlogRepo.On("UpdateInteractionCounts", mock.Anything, blogID, 1, -1).Return(nil).Once()
+		s.mockBlogRepo.On("GetInteractionCounts", mock.Anything, blogID).Return(int64(6), int64(1), nil).Once()
+
+		// Act
+		result, err := s.usecase.InteractWithBlog(ctx, blogID, userID, action)
+
+		// Assert
+		s.NoError(err)
+		s.Equal(&domain.InteractionResult{Likes: 6, Dislikes: 1, Action: domain.ActionTypeLike}, result)
+		s.mockInteractionRepo.AssertExpectations(s.T())
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Success - First vote races another request", func() {
+		s.SetupTest()
+		// Arrange:
+		// 1. Get finds nothing, but another request creates a dislike before our Create.
+		s.mockInteractionRepo.On("Get", mock.Anything, userID, blogID).Return(nil, usecases.ErrNotFound).Once()
+		s.mockInteractionRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.BlogInteraction")).Return(usecases.ErrConflict).Once()
+		// 2. The winner's interaction is fetched again and switched, rather than counted twice.
+		winner := &domain.BlogInteraction{ID: "interaction-xyz", UserID: userID, BlogID: blogID, Action: domain.ActionTypeDislike}
+		s.mockInteractionRepo.On("Get", mock.Anything, userID, blogID).Return(winner, nil).Once()
+		s.mockInteractionRepo.On("Update", mock.Anything, winner).Return(nil).Once()
+		s.mockBlogRepo.On("UpdateInteractionCounts", mock.Anything, blogID, 1, -1).Return(nil).Once()
+		s.mockBlogRepo.On("GetInteractionCounts", mock.Anything, blogID).Return(int64(1), int64(0), nil).Once()
 
 		// Act
-		err := s.usecase.InteractWithBlog(ctx, blogID, userID, action)
+		result, err := s.usecase.InteractWithBlog(ctx, blogID, userID, domain.ActionTypeLike)
 
 		// Assert
 		s.NoError(err)
+		s.Equal(&domain.InteractionResult{Likes: 1, Dislikes: 0, Action: domain.ActionTypeLike}, result)
 		s.mockInteractionRepo.AssertExpectations(s.T())
 		s.mockBlogRepo.AssertExpectations(s.T())
+		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementLikes", mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	s.Run("Failure - Interaction repo Get fails", func() {
@@ -573,13 +984,1930 @@ func (s *BlogUsecaseTestSuite) TestInteractWithBlog() {
 		s.mockInteractionRepo.On("Get", mock.Anything, userID, blogID).Return(nil, expectedErr).Once()
 
 		// Act
-		err := s.usecase.InteractWithBlog(ctx, blogID, userID, domain.ActionTypeLike)
+		result, err := s.usecase.InteractWithBlog(ctx, blogID, userID, domain.ActionTypeLike)
 
 		// Assert
 		s.Error(err)
 		s.ErrorIs(err, expectedErr)
+		s.Nil(result)
 		// Ensure no other repository methods were called
 		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementLikes")
 		s.mockBlogRepo.AssertNotCalled(s.T(), "UpdateInteractionCounts")
+		s.mockBlogRepo.AssertNotCalled(s.T(), "GetInteractionCounts", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure - Counter update fails", func() {
+		s.SetupTest()
+		expectedErr := errors.New("blog db down")
+		s.mockInteractionRepo.On("Get", mock.Anything, userID, blogID).Return(nil, usecases.ErrNotFound).Once()
+		s.mockInteractionRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.BlogInteraction")).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementDislikes", mock.Anything, blogID, 1).Return(expectedErr).Once()
+
+		result, err := s.usecase.InteractWithBlog(ctx, blogID, userID, domain.ActionTypeDislike)
+
+		s.ErrorIs(err, expectedErr)
+		s.Nil(result)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "GetInteractionCounts", mock.Anything, mock.Anything)
+	})
+}
+
+// racingInteractionRepository keeps interactions in memory behind a unique user and blog key,
+// like the real index. The first two Gets return only once both have looked, so two first
+// votes both see nothing and race to Create.
+type racingInteractionRepository struct {
+	domain.IInteractionRepository
+
+	mu           sync.Mutex
+	interactions map[string]*domain.BlogInteraction
+	nextID       int
+	gets         int
+	bothGot      sync.WaitGroup
+}
+
+func newRacingInteractionRepository() *racingInteractionRepository {
+	r := &racingInteractionRepository{interactions: make(map[string]*domain.BlogInteraction)}
+	r.bothGot.Add(2)
+	return r
+}
+
+func (r *racingInteractionRepository) Get(ctx context.Context, userID, blogID string) (*domain.BlogInteraction, error) {
+	r.mu.Lock()
+	r.gets++
+	racing := r.gets <= 2
+	interaction, ok := r.interactions[userID+"/"+blogID]
+	var copied domain.BlogInteraction
+	if ok {
+		copied = *interaction
+	}
+	r.mu.Unlock()
+	if racing {
+		r.bothGot.Done()
+		r.bothGot.Wait()
+	}
+
+	if !ok {
+		return nil, usecases.ErrNotFound
+	}
+	return &copied, nil
+}
+
+func (r *racingInteractionRepository) Create(ctx context.Context, interaction *domain.BlogInteraction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := interaction.UserID + "/" + interaction.BlogID
+	if _, taken := r.interactions[key]; taken {
+		return usecases.ErrConflict
+	}
+	r.nextID++
+	interaction.ID = fmt.Sprintf("interaction-%d", r.nextID)
+	copied := *interaction
+	r.interactions[key] = &copied
+	return nil
+}
+
+func (r *racingInteractionRepository) Update(ctx context.Context, interaction *domain.BlogInteraction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *interaction
+	r.interactions[interaction.UserID+"/"+interaction.BlogID] = &copied
+	return nil
+}
+
+func (r *racingInteractionRepository) Delete(ctx context.Context, interactionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, interaction := range r.interactions {
+		if interaction.ID == interactionID {
+			delete(r.interactions, key)
+			return nil
+		}
+	}
+	return usecases.ErrNotFound
+}
+
+func (s *BlogUsecaseTestSuite) TestInteractWithBlog_ConcurrentFirstVotes() {
+	blogID := "blog-123"
+	userID := "user-abc"
+
+	for _, votes := range [][2]domain.ActionType{
+		{domain.ActionTypeLike, domain.ActionTypeDislike},
+		{domain.ActionTypeLike, domain.ActionTypeLike},
+	} {
+		s.Run(fmt.Sprintf("%s and %s", votes[0], votes[1]), func() {
+			s.SetupTest()
+			repo := newRacingInteractionRepository()
+			var mu sync.Mutex
+			var likes, dislikes int
+			s.mockBlogRepo.On("IncrementLikes", mock.Anything, blogID, mock.AnythingOfType("int")).Run(func(args mock.Arguments) {
+				mu.Lock()
+				likes += args.Int(2)
+				mu.Unlock()
+			}).Return(nil).Maybe()
+			s.mockBlogRepo.On("IncrementDislikes", mock.Anything, blogID, mock.AnythingOfType("int")).Run(func(args mock.Arguments) {
+				mu.Lock()
+				dislikes += args.Int(2)
+				mu.Unlock()
+			}).Return(nil).Maybe()
+			s.mockBlogRepo.On("UpdateInteractionCounts", mock.Anything, blogID, mock.AnythingOfType("int"), mock.AnythingOfType("int")).Run(func(args mock.Arguments) {
+				mu.Lock()
+				likes += args.Int(2)
+				dislikes += args.Int(3)
+				mu.Unlock()
+			}).Return(nil).Maybe()
+			s.mockBlogRepo.On("GetInteractionCounts", mock.Anything, blogID).Return(int64(0), int64(0), nil)
+			uc := usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, repo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second)
+
+			// Act
+			var wg sync.WaitGroup
+			errs := make([]error, len(votes))
+			for i, vote := range votes {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, errs[i] = uc.InteractWithBlog(context.Background(), blogID, userID, vote)
+				}()
+			}
+			wg.Wait()
+
+			// Assert: neither vote fails, and the counters match the stored interaction.
+			s.NoError(errs[0])
+			s.NoError(errs[1])
+			var wantLikes, wantDislikes int
+			if stored, ok := repo.interactions[userID+"/"+blogID]; ok {
+				if stored.Action == domain.ActionTypeLike {
+					wantLikes = 1
+				} else {
+					wantDislikes = 1
+				}
+			}
+			s.Equal(wantLikes, likes)
+			s.Equal(wantDislikes, dislikes)
+		})
+	}
+}
+
+func (s *BlogUsecaseTestSuite) TestInteractWithBlog_ConcurrentIdenticalVotes() {
+	// Arrange: a double-submitted like, both requests seeing no vote yet.
+	s.SetupTest()
+	blogID := "blog-123"
+	userID := "user-abc"
+	repo := newRacingInteractionRepository()
+	var mu sync.Mutex
+	likes := 0
+	s.mockBlogRepo.On("IncrementLikes", mock.Anything, blogID, mock.AnythingOfType("int")).Run(func(args mock.Arguments) {
+		mu.Lock()
+		likes += args.Int(2)
+		mu.Unlock()
+	}).Return(nil)
+	s.mockBlogRepo.On("GetInteractionCounts", mock.Anything, blogID).Return(int64(1), int64(0), nil)
+	uc := usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, repo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second)
+
+	// Act
+	var wg sync.WaitGroup
+	results := make([]*domain.InteractionResult, 2)
+	errs := make([]error, 2)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = uc.InteractWithBlog(context.Background(), blogID, userID, domain.ActionTypeLike)
+		}()
+	}
+	wg.Wait()
+
+	// Assert: the like stands, counted once, and neither request reports it undone.
+	for i := range results {
+		s.Require().NoError(errs[i])
+		s.Equal(domain.ActionTypeLike, results[i].Action)
+	}
+	s.Require().Contains(repo.interactions, userID+"/"+blogID)
+	s.Equal(domain.ActionTypeLike, repo.interactions[userID+"/"+blogID].Action)
+	s.Equal(1, likes)
+	s.mockBlogRepo.AssertNotCalled(s.T(), "UpdateInteractionCounts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *BlogUsecaseTestSuite) TestImportBlogs() {
+	s.Run("Success_MixedValidAndInvalid", func() {
+		// Arrange
+		s.SetupTest()
+		createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		items := []domain.BlogImportItem{
+			{Title: "Valid", Content: "Body", AuthorID: "author-1", CreatedAt: &createdAt},
+			{Title: "", Content: "Body", AuthorID: "author-1"},
+			{Title: "Orphan", Content: "Body", AuthorID: "ghost"},
+			{Title: "Also Valid", Content: "Body", AuthorID: "author-1"},
+		}
+		// The author is looked up once even though three items share it.
+		s.mockUserRepo.On("GetByID", mock.Anything, "author-1").Return(&domain.User{ID: "author-1"}, nil).Once()
+		s.mockUserRepo.On("GetByID", mock.Anything, "ghost").Return(nil, domain.ErrUserNotFound).Once()
+		s.mockBlogRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(blogs []*domain.Blog) bool {
+			return len(blogs) == 2 && blogs[0].Title == "Valid" && blogs[0].CreatedAt.Equal(createdAt) && blogs[1].Title == "Also Valid"
+		})).Return(nil).Once()
+
+		// Act
+		results, err := s.usecase.ImportBlogs(context.Background(), "admin-1", items)
+
+		// Assert
+		s.Require().NoError(err)
+		s.Require().Len(results, 4)
+		s.Equal("mock-generated-id-0", results[0].BlogID)
+		s.NoError(results[0].Err)
+		s.ErrorIs(results[1].Err, domain.ErrValidation)
+		s.Empty(results[1].BlogID)
+		s.ErrorIs(results[2].Err, domain.ErrUserNotFound)
+		s.Equal("mock-generated-id-1", results[3].BlogID)
+		s.mockUserRepo.AssertExpectations(s.T())
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_EmptyBatch", func() {
+		s.SetupTest()
+
+		results, err := s.usecase.ImportBlogs(context.Background(), "admin-1", nil)
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.Nil(results)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "CreateMany", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure_UserRepoError", func() {
+		s.SetupTest()
+		dbErr := errors.New("user db down")
+		s.mockUserRepo.On("GetByID", mock.Anything, "author-1").Return(nil, dbErr).Once()
+
+		results, err := s.usecase.ImportBlogs(context.Background(), "admin-1", []domain.BlogImportItem{{Title: "T", Content: "C", AuthorID: "author-1"}})
+
+		s.ErrorIs(err, dbErr)
+		s.Nil(results)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "CreateMany", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestUpdate_RecomputesWordCount() {
+	s.SetupTest()
+	blog, _ := domain.NewBlog("Title", "Two words", "owner-id", nil)
+	blog.ID = "blog-1"
+	s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(blog, nil).Once()
+	s.mockBlogRepo.On("Update", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+		return b.WordCount == 5
+	})).Return(nil).Once()
+	s.mockRevisionRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	s.mockRevisionRepo.On("PruneOldest", mock.Anything, "blog-1", mock.Anything).Return(nil).Once()
+
+	updated, err := s.usecase.Update(context.Background(), "blog-1", "owner-id", domain.RoleUser, map[string]interface{}{"content": "Now it has five words"})
+
+	s.Require().NoError(err)
+	s.Equal(5, updated.WordCount)
+	s.mockBlogRepo.AssertExpectations(s.T())
+}
+
+func (s *BlogUsecaseTestSuite) TestUpdate_Revisions() {
+	newBlog := func() *domain.Blog {
+		blog, _ := domain.NewBlog("Old Title", "Old Content", "owner-id", nil)
+		blog.ID = "blog-1"
+		return blog
+	}
+	updates := map[string]interface{}{"content": "New Content"}
+
+	s.Run("Prunes to the configured capacity", func() {
+		s.SetupTest()
+		uc := usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second, usecases.WithMaxRevisions(3))
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(newBlog(), nil).Once()
+		s.mockBlogRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		s.mockRevisionRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		s.mockRevisionRepo.On("PruneOldest", mock.Anything, "blog-1", 3).Return(nil).Once()
+
+		_, err := uc.Update(context.Background(), "blog-1", "owner-id", domain.RoleUser, updates)
+
+		s.NoError(err)
+		s.mockRevisionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Revision failure does not fail the update", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(newBlog(), nil).Once()
+		s.mockBlogRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		s.mockRevisionRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db down")).Once()
+
+		updated, err := s.usecase.Update(context.Background(), "blog-1", "owner-id", domain.RoleUser, updates)
+
+		s.NoError(err)
+		s.Equal("New Content", updated.Content)
+		s.mockRevisionRepo.AssertNotCalled(s.T(), "PruneOldest", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("No revision when the update fails", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(newBlog(), nil).Once()
+		s.mockBlogRepo.On("Update", mock.Anything, mock.Anything).Return(errors.New("db down")).Once()
+
+		_, err := s.usecase.Update(context.Background(), "blog-1", "owner-id", domain.RoleUser, updates)
+
+		s.Error(err)
+		s.mockRevisionRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestListRevisions() {
+	blog, _ := domain.NewBlog("Title", "Content", "owner-id", nil)
+	blog.ID = "blog-1"
+	revisions := []*domain.BlogRevision{{ID: "rev-2", BlogID: "blog-1"}, {ID: "rev-1", BlogID: "blog-1"}}
+
+	s.Run("Author can list", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(blog, nil).Once()
+		s.mockRevisionRepo.On("ListByBlogID", mock.Anything, "blog-1").Return(revisions, nil).Once()
+
+		result, err := s.usecase.ListRevisions(context.Background(), "blog-1", "owner-id", domain.RoleUser)
+
+		s.NoError(err)
+		s.Equal(revisions, result)
+	})
+
+	s.Run("Admin can list", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(blog, nil).Once()
+		s.mockRevisionRepo.On("ListByBlogID", mock.Anything, "blog-1").Return(revisions, nil).Once()
+
+		_, err := s.usecase.ListRevisions(context.Background(), "blog-1", "admin-id", domain.RoleAdmin)
+
+		s.NoError(err)
+	})
+
+	s.Run("Other users are denied", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(blog, nil).Once()
+
+		_, err := s.usecase.ListRevisions(context.Background(), "blog-1", "stranger", domain.RoleUser)
+
+		s.ErrorIs(err, domain.ErrPermissionDenied)
+		s.mockRevisionRepo.AssertNotCalled(s.T(), "ListByBlogID", mock.Anything, mock.Anything)
+	})
+
+	s.Run("GetRevision checks access too", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(blog, nil).Once()
+
+		_, err := s.usecase.GetRevision(context.Background(), "blog-1", "rev-1", "stranger", domain.RoleUser)
+
+		s.ErrorIs(err, domain.ErrPermissionDenied)
+		s.mockRevisionRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestRestoreRevision() {
+	newBlog := func() *domain.Blog {
+		blog, _ := domain.NewBlog("Current Title", "Current Content", "owner-id", []string{"new"})
+		blog.ID = "blog-1"
+		return blog
+	}
+	stored := &domain.BlogRevision{ID: "rev-1", BlogID: "blog-1", Title: "Old Title", Content: "Old Content", Tags: []string{"old"}}
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(newBlog(), nil).Once()
+		s.mockRevisionRepo.On("GetByID", mock.Anything, "blog-1", "rev-1").Return(stored, nil).Once()
+		s.mockBlogRepo.On("Update", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.Title == "Old Title" && b.Content == "Old Content" && len(b.Tags) == 1 && b.Tags[0] == "old"
+		})).Return(nil).Once()
+		// Restoring must snapshot the state it replaces, so it can be undone.
+		s.mockRevisionRepo.On("Create", mock.Anything, mock.MatchedBy(func(rev *domain.BlogRevision) bool {
+			return rev.Title == "Current Title" && rev.Content == "Current Content" && rev.Tags[0] == "new"
+		})).Return(nil).Once()
+		s.mockRevisionRepo.On("PruneOldest", mock.Anything, "blog-1", usecases.DefaultMaxRevisions).Return(nil).Once()
+
+		restored, err := s.usecase.RestoreRevision(context.Background(), "blog-1", "rev-1", "owner-id", domain.RoleUser)
+
+		s.Require().NoError(err)
+		s.Equal("Old Title", restored.Title)
+		s.mockBlogRepo.AssertExpectations(s.T())
+		s.mockRevisionRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_NotAuthor", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(newBlog(), nil).Once()
+
+		_, err := s.usecase.RestoreRevision(context.Background(), "blog-1", "rev-1", "someone-else", domain.RoleUser)
+
+		s.ErrorIs(err, domain.ErrPermissionDenied)
+		s.mockRevisionRepo.AssertNotCalled(s.T(), "GetByID", mock.Anything, mock.Anything, mock.Anything)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure_RevisionNotFound", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(newBlog(), nil).Once()
+		s.mockRevisionRepo.On("GetByID", mock.Anything, "blog-1", "missing").Return(nil, usecases.ErrNotFound).Once()
+
+		_, err := s.usecase.RestoreRevision(context.Background(), "blog-1", "missing", "owner-id", domain.RoleUser)
+
+		s.ErrorIs(err, usecases.ErrNotFound)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestCreate_DuplicateWindow() {
+	authorID := "author-1"
+	hash := domain.ContentHash("Title", "Content")
+	newUsecase := func() domain.IBlogUsecase {
+		return usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+			usecases.WithDuplicateWindow(10*time.Minute))
+	}
+	withinWindow := mock.MatchedBy(func(since time.Time) bool {
+		age := time.Since(since)
+		return age >= 10*time.Minute && age < 11*time.Minute
+	})
+
+	s.Run("Failure_RecentDuplicate", func() {
+		s.SetupTest()
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(&domain.User{ID: authorID}, nil).Once()
+		s.mockBlogRepo.On("ExistsByContentHash", mock.Anything, authorID, hash, withinWindow).Return(true, nil).Once()
+
+		blog, err := newUsecase().Create(context.Background(), "Title", "Content", authorID, nil, nil, "")
+
+		s.ErrorIs(err, usecases.ErrConflict)
+		s.Nil(blog)
+		s.mockBlogRepo.AssertExpectations(s.T())
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Success_SameContentByAnotherAuthor", func() {
+		s.SetupTest()
+		otherAuthorID := "author-2"
+		s.mockUserRepo.On("GetByID", mock.Anything, otherAuthorID).Return(&domain.User{ID: otherAuthorID}, nil).Once()
+		// The lookup is scoped to the author, so another author's identical post is not found.
+		s.mockBlogRepo.On("ExistsByContentHash", mock.Anything, otherAuthorID, hash, withinWindow).Return(false, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(nil).Once()
+
+		blog, err := newUsecase().Create(context.Background(), "Title", "Content", otherAuthorID, nil, nil, "")
+
+		s.Require().NoError(err)
+		s.Equal(otherAuthorID, blog.AuthorID)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Success_CheckDisabledByDefault", func() {
+		s.SetupTest()
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(&domain.User{ID: authorID}, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(nil).Once()
+
+		_, err := s.usecase.Create(context.Background(), "Title", "Content", authorID, nil, nil, "")
+
+		s.Require().NoError(err)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "ExistsByContentHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestCreate_MinAccountAge() {
+	authorID := "author-1"
+	newUsecase := func() domain.IBlogUsecase {
+		return usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+			usecases.WithMinAccountAge(time.Hour))
+	}
+
+	s.Run("Failure_AccountTooNew", func() {
+		s.SetupTest()
+		author := &domain.User{ID: authorID, CreatedAt: time.Now().UTC().Add(-10 * time.Minute)}
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(author, nil).Once()
+
+		blog, err := newUsecase().Create(context.Background(), "Title", "Content", authorID, nil, nil, "")
+
+		s.ErrorIs(err, domain.ErrAccountTooNew)
+		s.Nil(blog)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Success_AgedAccount", func() {
+		s.SetupTest()
+		author := &domain.User{ID: authorID, CreatedAt: time.Now().UTC().Add(-2 * time.Hour)}
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(author, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(nil).Once()
+
+		blog, err := newUsecase().Create(context.Background(), "Title", "Content", authorID, nil, nil, "")
+
+		s.Require().NoError(err)
+		s.Equal(authorID, blog.AuthorID)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestCreate_SimilarityWindow() {
+	authorID := "author-1"
+	var cache *fakeRedisCache
+	newUsecase := func() domain.IBlogUsecase {
+		return usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+			usecases.WithBlogSimilarityWindow(cache, time.Minute, 0))
+	}
+	expectTwoCreates := func() {
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(&domain.User{ID: authorID}, nil).Twice()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(nil).Twice()
+	}
+
+	s.Run("Failure_NearDuplicateWithinWindow", func() {
+		s.SetupTest()
+		cache = newFakeRedisCache()
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(&domain.User{ID: authorID}, nil).Twice()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(nil).Once()
+		uc := newUsecase()
+
+		_, err := uc.Create(context.Background(), "Cheap Watches", "Buy cheap watches now!", authorID, nil, nil, "")
+		s.Require().NoError(err)
+		blog, err := uc.Create(context.Background(), "cheap watches", "Buy cheap watches now!!! 2", authorID, nil, nil, "")
+
+		s.ErrorIs(err, usecases.ErrConflict)
+		s.Nil(blog)
+		s.mockBlogRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	})
+
+	s.Run("Success_DistinctContent", func() {
+		s.SetupTest()
+		cache = newFakeRedisCache()
+		expectTwoCreates()
+		uc := newUsecase()
+
+		_, err := uc.Create(context.Background(), "Cheap Watches", "Buy cheap watches now!", authorID, nil, nil, "")
+		s.Require().NoError(err)
+		_, err = uc.Create(context.Background(), "Watch Care", "How to look after a mechanical watch.", authorID, nil, nil, "")
+
+		s.NoError(err)
+		s.mockBlogRepo.AssertNumberOfCalls(s.T(), "Create", 2)
+	})
+
+	s.Run("Success_AfterWindow", func() {
+		s.SetupTest()
+		cache = newFakeRedisCache()
+		expectTwoCreates()
+		uc := newUsecase()
+
+		_, err := uc.Create(context.Background(), "Cheap Watches", "Buy cheap watches now!", authorID, nil, nil, "")
+		s.Require().NoError(err)
+		cache.advance(time.Minute)
+		_, err = uc.Create(context.Background(), "Cheap Watches", "Buy cheap watches now!", authorID, nil, nil, "")
+
+		s.NoError(err)
+	})
+
+	s.Run("Success_FailedSaveCanBeRetried", func() {
+		s.SetupTest()
+		cache = newFakeRedisCache()
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(&domain.User{ID: authorID}, nil).Twice()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(errors.New("db down")).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(nil).Once()
+		uc := newUsecase()
+
+		_, err := uc.Create(context.Background(), "Cheap Watches", "Buy cheap watches now!", authorID, nil, nil, "")
+		s.Require().Error(err)
+		_, err = uc.Create(context.Background(), "Cheap Watches", "Buy cheap watches now!", authorID, nil, nil, "")
+
+		s.NoError(err)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestCreate_Scheduled() {
+	authorID := "author-1"
+
+	s.Run("Success_FutureTime", func() {
+		s.SetupTest()
+		at := time.Now().Add(time.Hour)
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(&domain.User{ID: authorID}, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.Status == domain.BlogStatusDraft && b.ScheduledFor != nil && b.PublishedAt == nil
+		})).Return(nil).Once()
+
+		blog, err := s.usecase.Create(context.Background(), "Title", "Content", authorID, nil, &at, "")
+
+		s.Require().NoError(err)
+		s.False(blog.IsPublished())
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_PastTime", func() {
+		s.SetupTest()
+		at := time.Now().Add(-time.Hour)
+
+		blog, err := s.usecase.Create(context.Background(), "Title", "Content", authorID, nil, &at, "")
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.Nil(blog)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestPublishDueBlogs() {
+	due := func() []*domain.Blog {
+		return []*domain.Blog{{ID: "blog-1"}, {ID: "blog-2"}, {ID: "blog-3"}}
+	}
+
+	s.Run("Success_SkipsLostRaces", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("FindDueScheduled", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("int64")).Return(due(), nil).Once()
+		s.mockBlogRepo.On("MarkPublished", mock.Anything, "blog-1", mock.Anything).Return(nil).Once()
+		s.mockBlogRepo.On("MarkPublished", mock.Anything, "blog-2", mock.Anything).Return(usecases.ErrNotFound).Once()
+		s.mockBlogRepo.On("MarkPublished", mock.Anything, "blog-3", mock.Anything).Return(nil).Once()
+
+		count, err := s.usecase.PublishDueBlogs(context.Background())
+
+		s.NoError(err)
+		s.Equal(2, count)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Success_NothingDue", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("FindDueScheduled", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+
+		count, err := s.usecase.PublishDueBlogs(context.Background())
+
+		s.NoError(err)
+		s.Zero(count)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "MarkPublished", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure_RepositoryError", func() {
+		s.SetupTest()
+		dbErr := errors.New("db down")
+		s.mockBlogRepo.On("FindDueScheduled", mock.Anything, mock.Anything, mock.Anything).Return(due(), nil).Once()
+		s.mockBlogRepo.On("MarkPublished", mock.Anything, "blog-1", mock.Anything).Return(dbErr).Once()
+
+		count, err := s.usecase.PublishDueBlogs(context.Background())
+
+		s.ErrorIs(err, dbErr)
+		s.Zero(count)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "MarkPublished", mock.Anything, "blog-2", mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestPreModeration() {
+	authorID := "author-1"
+	newUsecase := func() domain.IBlogUsecase {
+		return usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+			usecases.WithPreModeration(true))
+	}
+
+	s.Run("Create_HoldsBlogForApproval", func() {
+		s.SetupTest()
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(&domain.User{ID: authorID}, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.Status == domain.BlogStatusPending && b.PublishedAt == nil
+		})).Return(nil).Once()
+
+		blog, err := newUsecase().Create(context.Background(), "Title", "Content", authorID, nil, nil, "")
+
+		s.Require().NoError(err)
+		s.False(blog.IsPublished())
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Create_ScheduledStaysDraft", func() {
+		s.SetupTest()
+		at := time.Now().Add(time.Hour)
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(&domain.User{ID: authorID}, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.Status == domain.BlogStatusDraft && b.ScheduledFor != nil
+		})).Return(nil).Once()
+
+		_, err := newUsecase().Create(context.Background(), "Title", "Content", authorID, nil, &at, "")
+
+		s.Require().NoError(err)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("PublishDueBlogs_QueuesInsteadOfPublishing", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("FindDueScheduled", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Blog{{ID: "blog-1"}}, nil).Once()
+		s.mockBlogRepo.On("MarkPending", mock.Anything, "blog-1").Return(nil).Once()
+
+		count, err := newUsecase().PublishDueBlogs(context.Background())
+
+		s.NoError(err)
+		s.Equal(1, count)
+		s.mockBlogRepo.AssertExpectations(s.T())
+		s.mockBlogRepo.AssertNotCalled(s.T(), "MarkPublished", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Disabled_PublishesImmediately", func() {
+		s.SetupTest()
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(&domain.User{ID: authorID}, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.Status == domain.BlogStatusPublished
+		})).Return(nil).Once()
+
+		blog, err := s.usecase.Create(context.Background(), "Title", "Content", authorID, nil, nil, "")
+
+		s.Require().NoError(err)
+		s.True(blog.IsPublished())
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestSetPinned() {
+	s.Run("Success_AsAuthor", func() {
+		// Arrange
+		blog, _ := domain.NewBlog("Title", "Content", "author-1", nil)
+		blog.ID = "blog-1"
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(blog, nil).Once()
+		s.mockBlogRepo.On("SetPinned", mock.Anything, "blog-1", "author-1", true).Return(nil).Once()
+
+		// Act
+		pinned, err := s.usecase.SetPinned(context.Background(), "blog-1", "author-1", true)
+
+		// Assert
+		s.Require().NoError(err)
+		s.True(pinned.PinnedByAuthor)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_NotAuthor", func() {
+		// Arrange
+		blog, _ := domain.NewBlog("Title", "Content", "author-1", nil)
+		blog.ID = "blog-1"
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(blog, nil).Once()
+
+		// Act
+		_, err := s.usecase.SetPinned(context.Background(), "blog-1", "someone-else", true)
+
+		// Assert
+		s.ErrorIs(err, domain.ErrPermissionDenied)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "SetPinned", mock.Anything, "blog-1", "someone-else", true)
+	})
+
+	s.Run("Failure_BlogNotFound", func() {
+		// Arrange
+		s.mockBlogRepo.On("GetByID", mock.Anything, "missing").Return(nil, usecases.ErrNotFound).Once()
+
+		// Act
+		_, err := s.usecase.SetPinned(context.Background(), "missing", "author-1", false)
+
+		// Assert
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestListByAuthor() {
+	s.Run("Success_PinnedFirstForAuthor", func() {
+		// Arrange
+		s.mockUserRepo.On("GetByID", mock.Anything, "author-1").Return(&domain.User{ID: "author-1"}, nil).Once()
+		expected := domain.BlogSearchFilterOptions{
+			AuthorIDs:   []string{"author-1"},
+			GlobalLogic: domain.GlobalLogicAND,
+			SortBy:      "date",
+			SortOrder:   domain.SortOrderDESC,
+			PinnedFirst: true,
+			Page:        1,
+			Limit:       10,
+		}
+		blogs := []*domain.Blog{{ID: "pinned", PinnedByAuthor: true}, {ID: "newest"}}
+		s.mockBlogRepo.On("SearchAndFilter", mock.Anything, expected).Return(blogs, int64(2), nil).Once()
+
+		// Act
+		result, total, err := s.usecase.ListByAuthor(context.Background(), "author-1", 0, 0)
+
+		// Assert
+		s.Require().NoError(err)
+		s.Equal(int64(2), total)
+		s.Equal("pinned", result[0].ID)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_UnknownAuthor", func() {
+		// Arrange
+		s.mockUserRepo.On("GetByID", mock.Anything, "ghost").Return(nil, domain.ErrUserNotFound).Once()
+
+		// Act
+		_, _, err := s.usecase.ListByAuthor(context.Background(), "ghost", 1, 10)
+
+		// Assert
+		s.ErrorIs(err, domain.ErrUserNotFound)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestGetFollowingFeed() {
+	newUsecase := func(fallback bool) (domain.IBlogUsecase, *MockBlogRepository, *MockFollowRepository) {
+		blogRepo := new(MockBlogRepository)
+		followRepo := new(MockFollowRepository)
+		uc := usecases.NewBlogUsecase(blogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+			usecases.WithFollowingFeed(followRepo, fallback))
+		return uc, blogRepo, followRepo
+	}
+
+	s.Run("Success_NewestBlogsOfFollowedAuthors", func() {
+		// Arrange
+		uc, blogRepo, followRepo := newUsecase(false)
+		followRepo.On("ListFolloweeIDs", mock.Anything, "user-1").Return([]string{"author-1", "author-2"}, nil).Once()
+		expected := domain.BlogSearchFilterOptions{
+			AuthorIDs:   []string{"author-1", "author-2"},
+			GlobalLogic: domain.GlobalLogicAND,
+			SortBy:      "date",
+			SortOrder:   domain.SortOrderDESC,
+			Page:        2,
+			Limit:       5,
+		}
+		blogs := []*domain.Blog{{ID: "newest", AuthorID: "author-2"}, {ID: "older", AuthorID: "author-1"}}
+		blogRepo.On("SearchAndFilter", mock.Anything, expected).Return(blogs, int64(7), nil).Once()
+
+		// Act
+		result, total, err := uc.GetFollowingFeed(context.Background(), "user-1", 2, 5)
+
+		// Assert
+		s.Require().NoError(err)
+		s.Equal(int64(7), total)
+		s.Equal(blogs, result)
+		followRepo.AssertExpectations(s.T())
+		blogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("FollowsNobody_EmptyFeed", func() {
+		// Arrange
+		uc, blogRepo, followRepo := newUsecase(false)
+		followRepo.On("ListFolloweeIDs", mock.Anything, "user-1").Return([]string{}, nil).Once()
+
+		// Act
+		result, total, err := uc.GetFollowingFeed(context.Background(), "user-1", 1, 10)
+
+		// Assert
+		s.Require().NoError(err)
+		s.Empty(result)
+		s.NotNil(result)
+		s.Zero(total)
+		blogRepo.AssertNotCalled(s.T(), "SearchAndFilter", mock.Anything, mock.Anything)
+	})
+
+	s.Run("FollowsNobody_TrendingFallback", func() {
+		// Arrange
+		uc, blogRepo, followRepo := newUsecase(true)
+		followRepo.On("ListFolloweeIDs", mock.Anything, "user-1").Return([]string{}, nil).Once()
+		expected := domain.BlogSearchFilterOptions{
+			SortBy:    "popularity",
+			SortOrder: domain.SortOrderDESC,
+			Page:      1,
+			Limit:     10,
+		}
+		blogs := []*domain.Blog{{ID: "trending"}}
+		blogRepo.On("SearchAndFilter", mock.Anything, expected).Return(blogs, int64(1), nil).Once()
+
+		// Act
+		result, total, err := uc.GetFollowingFeed(context.Background(), "user-1", 1, 10)
+
+		// Assert
+		s.Require().NoError(err)
+		s.Equal(int64(1), total)
+		s.Equal(blogs, result)
+		blogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_FolloweeLookup", func() {
+		// Arrange
+		uc, blogRepo, followRepo := newUsecase(true)
+		followRepo.On("ListFolloweeIDs", mock.Anything, "user-1").Return(nil, errors.New("db down")).Once()
+
+		// Act
+		_, _, err := uc.GetFollowingFeed(context.Background(), "user-1", 1, 10)
+
+		// Assert
+		s.Error(err)
+		blogRepo.AssertNotCalled(s.T(), "SearchAndFilter", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestMarkRead() {
+	s.Run("Success", func() {
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(&domain.Blog{ID: "blog-1"}, nil).Once()
+		s.mockReadRepo.On("MarkRead", mock.Anything, "user-1", "blog-1").Return(nil).Once()
+
+		err := s.usecase.MarkRead(context.Background(), "blog-1", "user-1")
+
+		s.NoError(err)
+		s.mockReadRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("BlogNotFound", func() {
+		s.mockBlogRepo.On("GetByID", mock.Anything, "missing").Return(nil, usecases.ErrNotFound).Once()
+
+		err := s.usecase.MarkRead(context.Background(), "missing", "user-1")
+
+		s.ErrorIs(err, usecases.ErrNotFound)
+		s.mockReadRepo.AssertNotCalled(s.T(), "MarkRead", mock.Anything, "user-1", "missing")
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestListRead() {
+	s.Run("DefaultsAndCapsPagination", func() {
+		reads := []*domain.BlogRead{{UserID: "user-1", BlogID: "blog-1", ReadAt: time.Now()}}
+		s.mockReadRepo.On("ListRead", mock.Anything, "user-1", int64(1), int64(100)).Return(reads, int64(1), nil).Once()
+
+		result, total, err := s.usecase.ListRead(context.Background(), "user-1", 0, 500)
+
+		s.Require().NoError(err)
+		s.Equal(int64(1), total)
+		s.Equal(reads, result)
+		s.mockReadRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestListInteractions() {
+	now := time.Now()
+	interactions := []*domain.BlogInteraction{
+		{ID: "i-3", UserID: "user-1", BlogID: "blog-3", Action: domain.ActionTypeLike, CreatedAt: now},
+		{ID: "i-2", UserID: "user-1", BlogID: "deleted-blog", Action: domain.ActionTypeDislike, CreatedAt: now.Add(-time.Hour)},
+		{ID: "i-1", UserID: "user-1", BlogID: "blog-1", Action: domain.ActionTypeLike, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	s.Run("DefaultsAndCapsPagination", func() {
+		s.mockInteractionRepo.On("ListByUser", mock.Anything, "user-2", int64(1), int64(100)).Return([]*domain.BlogInteraction{}, int64(0), nil).Once()
+
+		entries, total, err := s.usecase.ListInteractions(context.Background(), "user-2", -1, 1000)
+
+		s.Require().NoError(err)
+		s.Zero(total)
+		s.Empty(entries)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "GetByIDs", mock.Anything, mock.Anything)
+	})
+
+	s.Run("SkipsDeletedBlogs", func() {
+		s.mockInteractionRepo.On("ListByUser", mock.Anything, "user-1", int64(2), int64(3)).Return(interactions, int64(6), nil).Once()
+		s.mockBlogRepo.On("GetByIDs", mock.Anything, []string{"blog-3", "deleted-blog", "blog-1"}).
+			Return([]*domain.Blog{{ID: "blog-1", Title: "First"}, {ID: "blog-3", Title: "Third"}}, nil).Once()
+
+		entries, total, err := s.usecase.ListInteractions(context.Background(), "user-1", 2, 3)
+
+		s.Require().NoError(err)
+		s.Equal(int64(6), total)
+		s.Require().Len(entries, 2)
+		s.Equal("i-3", entries[0].Interaction.ID)
+		s.Equal("Third", entries[0].Blog.Title)
+		s.Equal("i-1", entries[1].Interaction.ID)
+		s.Equal("First", entries[1].Blog.Title)
+	})
+
+	s.Run("BlogLookupFails", func() {
+		s.mockInteractionRepo.On("ListByUser", mock.Anything, "user-3", int64(1), int64(10)).Return(interactions, int64(3), nil).Once()
+		s.mockBlogRepo.On("GetByIDs", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+		entries, _, err := s.usecase.ListInteractions(context.Background(), "user-3", 1, 10)
+
+		s.Error(err)
+		s.Nil(entries)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestListBlogInteractions() {
+	now := time.Now()
+	blog := &domain.Blog{ID: "blog-1", AuthorID: "author-1"}
+	interactions := []*domain.BlogInteraction{
+		{ID: "i-2", UserID: "user-2", BlogID: "blog-1", Action: domain.ActionTypeDislike, CreatedAt: now},
+		{ID: "i-1", UserID: "deleted-user", BlogID: "blog-1", Action: domain.ActionTypeLike, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	s.Run("NonOwnerIsRejected", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(blog, nil).Once()
+
+		entries, _, err := s.usecase.ListBlogInteractions(context.Background(), "blog-1", "user-9", domain.RoleUser, 1, 10)
+
+		s.ErrorIs(err, domain.ErrPermissionDenied)
+		s.Nil(entries)
+		s.mockInteractionRepo.AssertNotCalled(s.T(), "ListByBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("AuthorSeesVotersWithUserInfo", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(blog, nil).Once()
+		s.mockInteractionRepo.On("ListByBlog", mock.Anything, "blog-1", int64(2), int64(2)).Return(interactions, int64(5), nil).Once()
+		s.mockUserRepo.On("GetByIDs", mock.Anything, []string{"user-2", "deleted-user"}).
+			Return([]*domain.User{{ID: "user-2", Username: "bob"}}, nil).Once()
+
+		entries, total, err := s.usecase.ListBlogInteractions(context.Background(), "blog-1", "author-1", domain.RoleUser, 2, 2)
+
+		s.Require().NoError(err)
+		s.Equal(int64(5), total)
+		s.Require().Len(entries, 2)
+		s.Equal("i-2", entries[0].Interaction.ID)
+		s.Equal("bob", entries[0].User.Username)
+		s.Equal("i-1", entries[1].Interaction.ID)
+		s.Nil(entries[1].User, "votes by deleted accounts are kept without a user")
+	})
+
+	s.Run("AdminWithCappedPagination", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(blog, nil).Once()
+		s.mockInteractionRepo.On("ListByBlog", mock.Anything, "blog-1", int64(1), int64(100)).Return([]*domain.BlogInteraction{}, int64(0), nil).Once()
+
+		entries, total, err := s.usecase.ListBlogInteractions(context.Background(), "blog-1", "admin-1", domain.RoleAdmin, 0, 1000)
+
+		s.Require().NoError(err)
+		s.Zero(total)
+		s.Empty(entries)
+		s.mockUserRepo.AssertNotCalled(s.T(), "GetByIDs", mock.Anything, mock.Anything)
+	})
+
+	s.Run("BlogNotFound", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "missing").Return(nil, usecases.ErrNotFound).Once()
+
+		_, _, err := s.usecase.ListBlogInteractions(context.Background(), "missing", "author-1", domain.RoleUser, 1, 10)
+
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestReadFlags() {
+	s.Run("EmptyInputSkipsLookup", func() {
+		flags, err := s.usecase.ReadFlags(context.Background(), "user-1", nil)
+
+		s.NoError(err)
+		s.Empty(flags)
+		s.mockReadRepo.AssertNotCalled(s.T(), "FindRead", mock.Anything, "user-1", mock.Anything)
+	})
+
+	s.Run("Success", func() {
+		blogIDs := []string{"blog-1", "blog-2"}
+		s.mockReadRepo.On("FindRead", mock.Anything, "user-1", blogIDs).Return(map[string]bool{"blog-1": true}, nil).Once()
+
+		flags, err := s.usecase.ReadFlags(context.Background(), "user-1", blogIDs)
+
+		s.Require().NoError(err)
+		s.True(flags["blog-1"])
+		s.False(flags["blog-2"])
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestMergeTags() {
+	s.Run("Failure_InvalidInput", func() {
+		s.SetupTest()
+		for _, pair := range [][2]string{{"", "golang"}, {"go-lang", "  "}, {"golang", " golang "}} {
+			_, err := s.usecase.MergeTags(context.Background(), "admin-1", pair[0], pair[1])
+			s.ErrorIs(err, domain.ErrValidation)
+		}
+		s.mockBlogRepo.AssertNotCalled(s.T(), "ReplaceTag", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Success_WritesAuditEntry", func() {
+		s.SetupTest()
+		mockAuditRepo := new(MockAuditRepository)
+		usecase := usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second, usecases.WithBlogAuditLog(mockAuditRepo))
+		s.mockBlogRepo.On("ReplaceTag", mock.Anything, "go-lang", "golang").Return([]string{"blog-1", "blog-2"}, nil).Once()
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.ActorID == "admin-1" && e.Action == domain.AuditActionTagMerge &&
+				e.Details["from"] == "go-lang" && e.Details["to"] == "golang" && e.Details["updated"] == "2"
+		})).Return(nil).Once()
+
+		updated, err := usecase.MergeTags(context.Background(), "admin-1", " go-lang ", "golang")
+
+		s.NoError(err)
+		s.Equal(2, updated)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "HasTag", mock.Anything, mock.Anything)
+		mockAuditRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_UnknownTag", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("ReplaceTag", mock.Anything, "nope", "golang").Return([]string{}, nil).Once()
+
+		_, err := s.usecase.MergeTags(context.Background(), "admin-1", "nope", "golang")
+
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestRenameTag() {
+	s.Run("Failure_TargetInUse", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("HasTag", mock.Anything, "javascript").Return(true, nil).Once()
+
+		_, err := s.usecase.RenameTag(context.Background(), "admin-1", "js", "javascript")
+
+		s.ErrorIs(err, domain.ErrTagExists)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "ReplaceTag", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("HasTag", mock.Anything, "javascript").Return(false, nil).Once()
+		s.mockBlogRepo.On("ReplaceTag", mock.Anything, "js", "javascript").Return([]string{"blog-1"}, nil).Once()
+
+		updated, err := s.usecase.RenameTag(context.Background(), "admin-1", "js", "javascript")
+
+		s.NoError(err)
+		s.Equal(1, updated)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestVerifyCommentCount() {
+	s.Run("InSync", func() {
+		s.mockBlogRepo.On("GetCommentsCount", mock.Anything, "blog-1").Return(int64(3), nil).Once()
+		s.mockCommentRepo.On("CountByBlogID", mock.Anything, "blog-1").Return(int64(3), nil).Once()
+
+		check, err := s.usecase.VerifyCommentCount(context.Background(), "blog-1")
+
+		s.Require().NoError(err)
+		s.Equal(int64(3), check.Stored)
+		s.Equal(int64(3), check.Actual)
+		s.True(check.InSync())
+	})
+
+	s.Run("Drifted", func() {
+		s.mockBlogRepo.On("GetCommentsCount", mock.Anything, "blog-2").Return(int64(5), nil).Once()
+		s.mockCommentRepo.On("CountByBlogID", mock.Anything, "blog-2").Return(int64(2), nil).Once()
+
+		check, err := s.usecase.VerifyCommentCount(context.Background(), "blog-2")
+
+		s.Require().NoError(err)
+		s.Equal("blog-2", check.BlogID)
+		s.False(check.InSync())
+	})
+
+	s.Run("BlogNotFound", func() {
+		s.mockBlogRepo.On("GetCommentsCount", mock.Anything, "missing").Return(int64(0), usecases.ErrNotFound).Once()
+
+		_, err := s.usecase.VerifyCommentCount(context.Background(), "missing")
+
+		s.ErrorIs(err, usecases.ErrNotFound)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "CountByBlogID", mock.Anything, "missing")
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestListTags() {
+	tags := []*domain.TagCount{{Tag: "go", Count: 3}, {Tag: "web", Count: 1}}
+
+	s.Run("DefaultsToCountSort", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("ListTags", mock.Anything, int64(1), int64(10), domain.TagSortCount).Return(tags, int64(2), nil).Once()
+
+		result, err := s.usecase.ListTags(context.Background(), 1, 10, "")
+
+		s.Require().NoError(err)
+		s.Equal(tags, result.Tags)
+		s.Equal(int64(2), result.Total)
+		s.Equal(int64(10), result.Limit)
+	})
+
+	s.Run("AlphaSort", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("ListTags", mock.Anything, int64(2), int64(5), domain.TagSortAlpha).Return(tags, int64(7), nil).Once()
+
+		_, err := s.usecase.ListTags(context.Background(), 2, 5, domain.TagSortAlpha)
+
+		s.Require().NoError(err)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("LimitIsCappedByConfiguration", func() {
+		s.SetupTest()
+		uc := usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+			usecases.WithMaxTagListLimit(5))
+		s.mockBlogRepo.On("ListTags", mock.Anything, int64(1), int64(5), domain.TagSortCount).Return(tags, int64(2), nil).Once()
+
+		result, err := uc.ListTags(context.Background(), 1, 100, domain.TagSortCount)
+
+		s.Require().NoError(err)
+		s.Equal(int64(5), result.Limit)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("DefaultCap", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("ListTags", mock.Anything, int64(1), int64(usecases.DefaultMaxTagListLimit), domain.TagSortCount).Return(tags, int64(2), nil).Once()
+
+		result, err := s.usecase.ListTags(context.Background(), 1, 100, "")
+
+		s.Require().NoError(err)
+		s.Equal(int64(usecases.DefaultMaxTagListLimit), result.Limit)
+	})
+
+	s.Run("InvalidSort", func() {
+		s.SetupTest()
+
+		_, err := s.usecase.ListTags(context.Background(), 1, 10, domain.TagSort("popular"))
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "ListTags", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestGetRandom() {
+	blog := &domain.Blog{ID: "blog-1", Tags: []string{"go"}, Status: domain.BlogStatusPublished}
+
+	s.Run("AnyTag", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetRandom", mock.Anything, (*string)(nil)).Return(blog, nil).Once()
+
+		result, err := s.usecase.GetRandom(context.Background(), "  ")
+
+		s.Require().NoError(err)
+		s.Equal(blog, result)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementViews", mock.Anything, mock.Anything)
+	})
+
+	s.Run("WithTag", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetRandom", mock.Anything, mock.MatchedBy(func(tag *string) bool {
+			return tag != nil && *tag == "go"
+		})).Return(blog, nil).Once()
+
+		result, err := s.usecase.GetRandom(context.Background(), " go ")
+
+		s.Require().NoError(err)
+		s.Equal(blog, result)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("NoneFound", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetRandom", mock.Anything, mock.Anything).Return(nil, usecases.ErrNotFound).Once()
+
+		_, err := s.usecase.GetRandom(context.Background(), "rust")
+
+		s.ErrorIs(err, usecases.ErrNotFound)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestSuggestTitles() {
+	suggestions := []*domain.BlogTitleSuggestion{{ID: "blog-1", Title: "Learning Go"}}
+
+	s.Run("NormalizesQuery", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("SuggestTitles", mock.Anything, "learning go", int64(5)).Return(suggestions, nil).Once()
+
+		result, err := s.usecase.SuggestTitles(context.Background(), "  learning \t  go ", 5)
+
+		s.Require().NoError(err)
+		s.Equal(suggestions, result)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("CapsLimit", func() {
+		for _, limit := range []int{0, -3, usecases.MaxTitleSuggestions + 1} {
+			s.SetupTest()
+			s.mockBlogRepo.On("SuggestTitles", mock.Anything, "go", int64(usecases.MaxTitleSuggestions)).Return(suggestions, nil).Once()
+
+			_, err := s.usecase.SuggestTitles(context.Background(), "go", limit)
+
+			s.Require().NoError(err)
+			s.mockBlogRepo.AssertExpectations(s.T())
+		}
+	})
+
+	s.Run("TruncatesLongQuery", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("SuggestTitles", mock.Anything, mock.MatchedBy(func(query string) bool {
+			return query == strings.Repeat("é", 100)
+		}), int64(usecases.MaxTitleSuggestions)).Return(nil, nil).Once()
+
+		_, err := s.usecase.SuggestTitles(context.Background(), strings.Repeat("é", 150), 0)
+
+		s.Require().NoError(err)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("BlankQuery", func() {
+		s.SetupTest()
+
+		result, err := s.usecase.SuggestTitles(context.Background(), " \n ", 5)
+
+		s.Require().NoError(err)
+		s.NotNil(result)
+		s.Empty(result)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "SuggestTitles", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestGetByIDs() {
+	first := &domain.Blog{ID: "blog-1", AuthorID: "author-1", Status: domain.BlogStatusPublished}
+	second := &domain.Blog{ID: "blog-2", AuthorID: "author-2", Status: domain.BlogStatusPublished}
+	draft := &domain.Blog{ID: "draft-1", AuthorID: "author-1", Status: domain.BlogStatusDraft}
+	ids := []string{"blog-2", "missing", "draft-1", "blog-1", "blog-2"}
+
+	s.Run("Success_RequestOrderWithoutMissingOrRepeats", func() {
+		s.SetupTest()
+		// The repository returns the blogs in no particular order.
+		s.mockBlogRepo.On("GetByIDs", mock.Anything, ids).Return([]*domain.Blog{first, draft, second}, nil).Once()
+
+		blogs, err := s.usecase.GetByIDs(context.Background(), ids, "", "")
+
+		s.Require().NoError(err)
+		s.Equal([]*domain.Blog{second, first}, blogs, "the draft is hidden from anonymous viewers")
+		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementViews", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Success_DraftsShownToAuthorAndAdmins", func() {
+		for _, viewer := range []struct {
+			id   string
+			role domain.Role
+		}{{"author-1", domain.RoleUser}, {"admin-1", domain.RoleAdmin}} {
+			s.SetupTest()
+			s.mockBlogRepo.On("GetByIDs", mock.Anything, ids).Return([]*domain.Blog{first, draft, second}, nil).Once()
+
+			blogs, err := s.usecase.GetByIDs(context.Background(), ids, viewer.id, viewer.role)
+
+			s.Require().NoError(err)
+			s.Equal([]*domain.Blog{second, draft, first}, blogs, "viewer %s", viewer.id)
+		}
+	})
+
+	s.Run("Failure_TooManyIDs", func() {
+		s.SetupTest()
+
+		_, err := s.usecase.GetByIDs(context.Background(), make([]string, usecases.MaxBlogBatchFetch+1), "", "")
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "GetByIDs", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestExportBlog() {
+	parentID := "c1"
+	published := &domain.Blog{ID: "blog-1", AuthorID: "author-1", Status: domain.BlogStatusPublished}
+	draft := &domain.Blog{ID: "draft-1", AuthorID: "author-1", Status: domain.BlogStatusDraft}
+
+	s.Run("Success_ThreadsCommentsFromOneBatch", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(published, nil).Once()
+		s.mockCommentRepo.On("FetchAllByBlogID", mock.Anything, "blog-1").Return([]*domain.Comment{
+			{ID: "c1", BlogID: "blog-1"},
+			{ID: "r1", BlogID: "blog-1", ParentID: &parentID},
+			{ID: "c2", BlogID: "blog-1"},
+		}, nil).Once()
+
+		export, err := s.usecase.ExportBlog(context.Background(), "blog-1", "", "")
+
+		s.Require().NoError(err)
+		s.Equal(published, export.Blog)
+		s.Require().Len(export.Comments, 2)
+		s.Equal("c1", export.Comments[0].ID)
+		s.Require().Len(export.Comments[0].Replies, 1)
+		s.Equal("r1", export.Comments[0].Replies[0].ID)
+		s.Equal("c2", export.Comments[1].ID)
+		s.False(export.ExportedAt.IsZero())
+		s.mockCommentRepo.AssertNotCalled(s.T(), "FetchReplies", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Draft_HiddenFromOtherUsers", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "draft-1").Return(draft, nil).Once()
+
+		export, err := s.usecase.ExportBlog(context.Background(), "draft-1", "someone-else", domain.RoleUser)
+
+		s.ErrorIs(err, usecases.ErrNotFound)
+		s.Nil(export)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "FetchAllByBlogID", mock.Anything, mock.Anything)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		userID string
+		role   domain.Role
+	}{
+		{"Draft_ExportedForAuthor", "author-1", domain.RoleUser},
+		{"Draft_ExportedForAdmin", "admin-1", domain.RoleAdmin},
+	} {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockBlogRepo.On("GetByID", mock.Anything, "draft-1").Return(draft, nil).Once()
+			s.mockCommentRepo.On("FetchAllByBlogID", mock.Anything, "draft-1").Return(nil, nil).Once()
+
+			export, err := s.usecase.ExportBlog(context.Background(), "draft-1", tc.userID, tc.role)
+
+			s.Require().NoError(err)
+			s.Equal(draft, export.Blog)
+			s.Empty(export.Comments)
+		})
+	}
+}
+
+func (s *BlogUsecaseTestSuite) TestGetBlogDetail() {
+	author := "author-1"
+	published := &domain.Blog{ID: "blog-1", AuthorID: author, Tags: []string{"go", "web"}, Status: domain.BlogStatusPublished}
+	draft := &domain.Blog{ID: "draft-1", AuthorID: author, Status: domain.BlogStatusDraft}
+	// expectViews waits for the view counted in the background, so it doesn't leak into the next case.
+	expectViews := func(blogID string) *sync.WaitGroup {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		s.mockBlogRepo.On("IncrementViews", mock.Anything, blogID).Run(func(mock.Arguments) { wg.Done() }).Return(nil).Once()
+		return &wg
+	}
+
+	s.Run("Success_AllSections", func() {
+		s.SetupTest()
+		views := expectViews("blog-1")
+		comments := []*domain.Comment{{ID: "c1", BlogID: "blog-1", AuthorID: &author}}
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(published, nil).Once()
+		s.mockCommentRepo.On("FetchByBlogID", mock.Anything, "blog-1", int64(1), int64(usecases.DetailCommentsLimit), domain.CommentSortOldest).Return(comments, int64(14), nil).Once()
+		s.mockBlogRepo.On("SearchAndFilter", mock.Anything, mock.MatchedBy(func(opts domain.BlogSearchFilterOptions) bool {
+			return len(opts.Tags) == 2 && opts.TagLogic == domain.GlobalLogicOR && !opts.IncludeDrafts
+		})).Return([]*domain.Blog{{ID: "blog-2"}, published, {ID: "blog-3"}}, int64(3), nil).Once()
+		s.mockInteractionRepo.On("Get", mock.Anything, "viewer-1", "blog-1").Return(&domain.BlogInteraction{Action: domain.ActionTypeLike}, nil).Once()
+
+		detail, err := s.usecase.GetBlogDetail(context.Background(), "blog-1", "viewer-1", domain.RoleUser)
+
+		s.Require().NoError(err)
+		s.Equal(published, detail.Blog)
+		s.Equal(comments, detail.Comments)
+		s.Equal(int64(14), detail.CommentsTotal)
+		s.Require().Len(detail.Related, 2, "the blog itself is not related to itself")
+		s.Equal("blog-2", detail.Related[0].ID)
+		s.Equal("blog-3", detail.Related[1].ID)
+		s.Equal(domain.ActionTypeLike, detail.ViewerAction)
+		views.Wait()
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Success_AnonymousViewerAndOptionalSectionsFailing", func() {
+		s.SetupTest()
+		views := expectViews("blog-1")
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(published, nil).Once()
+		s.mockCommentRepo.On("FetchByBlogID", mock.Anything, "blog-1", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Comment{}, int64(0), nil).Once()
+		s.mockBlogRepo.On("SearchAndFilter", mock.Anything, mock.Anything).Return(nil, int64(0), errors.New("db down")).Once()
+
+		detail, err := s.usecase.GetBlogDetail(context.Background(), "blog-1", "", "")
+
+		s.Require().NoError(err)
+		s.Empty(detail.Related)
+		s.Empty(detail.ViewerAction)
+		views.Wait()
+		s.mockInteractionRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure_CommentsUnavailable", func() {
+		s.SetupTest()
+		views := expectViews("blog-1")
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(published, nil).Once()
+		s.mockCommentRepo.On("FetchByBlogID", mock.Anything, "blog-1", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Comment(nil), int64(0), usecases.ErrInternal).Once()
+		s.mockBlogRepo.On("SearchAndFilter", mock.Anything, mock.Anything).Return([]*domain.Blog{}, int64(0), nil).Once()
+
+		detail, err := s.usecase.GetBlogDetail(context.Background(), "blog-1", "", "")
+
+		s.ErrorIs(err, usecases.ErrInternal)
+		s.Nil(detail)
+		views.Wait()
+	})
+
+	s.Run("Draft_HiddenFromOtherUsers", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "draft-1").Return(draft, nil).Once()
+
+		detail, err := s.usecase.GetBlogDetail(context.Background(), "draft-1", "someone-else", domain.RoleUser)
+
+		s.ErrorIs(err, usecases.ErrNotFound)
+		s.Nil(detail)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "FetchByBlogID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementViews", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Draft_ShownToItsAuthor", func() {
+		s.SetupTest()
+		views := expectViews("draft-1")
+		s.mockBlogRepo.On("GetByID", mock.Anything, "draft-1").Return(draft, nil).Once()
+		s.mockCommentRepo.On("FetchByBlogID", mock.Anything, "draft-1", mock.Anything, mock.Anything, mock.Anything).Return([]*domain.Comment{}, int64(0), nil).Once()
+		s.mockInteractionRepo.On("Get", mock.Anything, author, "draft-1").Return(nil, usecases.ErrNotFound).Once()
+
+		detail, err := s.usecase.GetBlogDetail(context.Background(), "draft-1", author, domain.RoleUser)
+
+		s.Require().NoError(err)
+		s.Equal(draft, detail.Blog)
+		s.Empty(detail.Related, "a blog without tags has no related blogs")
+		views.Wait()
+		s.mockBlogRepo.AssertNotCalled(s.T(), "SearchAndFilter", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestCloneBlog() {
+	published := &domain.Blog{ID: "blog-1", AuthorID: "author-1", Title: "Hello", Content: "Body", Tags: []string{"go"}, Views: 7, Status: domain.BlogStatusPublished}
+	draft := &domain.Blog{ID: "draft-1", AuthorID: "author-1", Title: "Draft", Content: "Body", Status: domain.BlogStatusDraft}
+
+	s.Run("Success_Author", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(published, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(nil).Once()
+
+		clone, err := s.usecase.CloneBlog(context.Background(), "blog-1", "author-1", domain.RoleUser)
+
+		s.Require().NoError(err)
+		s.Equal("Hello (copy)", clone.Title)
+		s.Equal("Body", clone.Content)
+		s.Equal([]string{"go"}, clone.Tags)
+		s.Equal("author-1", clone.AuthorID)
+		s.Equal(domain.BlogStatusDraft, clone.Status)
+		s.Zero(clone.Views)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Success_AdminOwnsTheCopy", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "draft-1").Return(draft, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.AuthorID == "admin-1"
+		})).Return(nil).Once()
+
+		clone, err := s.usecase.CloneBlog(context.Background(), "draft-1", "admin-1", domain.RoleAdmin)
+
+		s.Require().NoError(err)
+		s.Equal("admin-1", clone.AuthorID)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure_NotAuthor", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(published, nil).Once()
+
+		clone, err := s.usecase.CloneBlog(context.Background(), "blog-1", "someone-else", domain.RoleUser)
+
+		s.ErrorIs(err, domain.ErrPermissionDenied)
+		s.Nil(clone)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure_OtherUsersDraftHidden", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "draft-1").Return(draft, nil).Once()
+
+		clone, err := s.usecase.CloneBlog(context.Background(), "draft-1", "someone-else", domain.RoleUser)
+
+		s.ErrorIs(err, usecases.ErrNotFound)
+		s.Nil(clone)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestPublishDraft() {
+	published := &domain.Blog{ID: "blog-1", AuthorID: "author-1", Title: "Hello", Content: "Body", Status: domain.BlogStatusPublished}
+
+	s.Run("Success_CloneReachesPublished", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(published, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(nil).Once()
+
+		clone, err := s.usecase.CloneBlog(context.Background(), "blog-1", "author-1", domain.RoleUser)
+		s.Require().NoError(err)
+		s.Require().Equal(domain.BlogStatusDraft, clone.Status)
+
+		s.mockBlogRepo.On("GetByID", mock.Anything, clone.ID).Return(clone, nil).Once()
+		s.mockBlogRepo.On("MarkPublished", mock.Anything, clone.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		blog, err := s.usecase.PublishDraft(context.Background(), clone.ID, "author-1", domain.RoleUser)
+
+		s.Require().NoError(err)
+		s.Equal(domain.BlogStatusPublished, blog.Status)
+		s.NotNil(blog.PublishedAt)
+		s.True(blog.IsPublished())
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Success_PreModerationQueuesIt", func() {
+		s.SetupTest()
+		moderated := usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+			usecases.WithPreModeration(true))
+		draft := &domain.Blog{ID: "draft-1", AuthorID: "author-1", Title: "Draft", Status: domain.BlogStatusDraft}
+		s.mockBlogRepo.On("GetByID", mock.Anything, "draft-1").Return(draft, nil).Once()
+		s.mockBlogRepo.On("MarkPending", mock.Anything, "draft-1").Return(nil).Once()
+
+		blog, err := moderated.PublishDraft(context.Background(), "draft-1", "author-1", domain.RoleUser)
+
+		s.Require().NoError(err)
+		s.Equal(domain.BlogStatusPending, blog.Status)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "MarkPublished", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure_OtherUsersDraftHidden", func() {
+		s.SetupTest()
+		draft := &domain.Blog{ID: "draft-1", AuthorID: "author-1", Title: "Draft", Status: domain.BlogStatusDraft}
+		s.mockBlogRepo.On("GetByID", mock.Anything, "draft-1").Return(draft, nil).Once()
+
+		blog, err := s.usecase.PublishDraft(context.Background(), "draft-1", "someone-else", domain.RoleUser)
+
+		s.ErrorIs(err, usecases.ErrNotFound)
+		s.Nil(blog)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "MarkPublished", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure_AlreadyPublished", func() {
+		s.SetupTest()
+		s.mockBlogRepo.On("GetByID", mock.Anything, "blog-1").Return(published, nil).Once()
+
+		blog, err := s.usecase.PublishDraft(context.Background(), "blog-1", "author-1", domain.RoleUser)
+
+		s.ErrorIs(err, usecases.ErrConflict)
+		s.Nil(blog)
+	})
+
+	s.Run("Failure_LostRaceToScheduler", func() {
+		s.SetupTest()
+		draft := &domain.Blog{ID: "draft-1", AuthorID: "author-1", Title: "Draft", Status: domain.BlogStatusDraft}
+		s.mockBlogRepo.On("GetByID", mock.Anything, "draft-1").Return(draft, nil).Once()
+		s.mockBlogRepo.On("MarkPublished", mock.Anything, "draft-1", mock.AnythingOfType("time.Time")).Return(usecases.ErrNotFound).Once()
+
+		blog, err := s.usecase.PublishDraft(context.Background(), "draft-1", "author-1", domain.RoleUser)
+
+		s.ErrorIs(err, usecases.ErrConflict)
+		s.Nil(blog)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestProfanityFilter() {
+	reject := usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+		usecases.WithBlogProfanityFilter(domain.NewProfanityFilter([]string{"darn"}, domain.ProfanityModeReject)))
+	mask := usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+		usecases.WithBlogProfanityFilter(domain.NewProfanityFilter([]string{"darn"}, domain.ProfanityModeMask)))
+
+	s.Run("Create_RejectsTitle", func() {
+		blog, err := reject.Create(context.Background(), "Darn Good Title", "Content", "author-id", nil, nil, "")
+
+		s.ErrorIs(err, domain.ErrContentRejected)
+		s.Nil(blog)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Create_MasksTitle", func() {
+		s.mockUserRepo.On("GetByID", mock.Anything, "author-id").Return(&domain.User{ID: "author-id"}, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.Title == "**** Good Title"
+		})).Return(nil).Once()
+
+		blog, err := mask.Create(context.Background(), "Darn Good Title", "Only the title is screened: darn", "author-id", nil, nil, "")
+
+		s.Require().NoError(err)
+		s.Equal("**** Good Title", blog.Title)
+		s.Equal("Only the title is screened: darn", blog.Content)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Update_RejectsTitle", func() {
+		existing, _ := domain.NewBlog("Old Title", "Content", "owner-id", nil)
+		existing.ID = "blog-profane"
+		s.mockBlogRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil).Once()
+
+		blog, err := reject.Update(context.Background(), existing.ID, "owner-id", domain.RoleUser, map[string]interface{}{"title": "darn"})
+
+		s.ErrorIs(err, domain.ErrContentRejected)
+		s.Nil(blog)
+		s.Equal("Old Title", existing.Title)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Import_RejectsTitlePerItem", func() {
+		items := []domain.BlogImportItem{
+			{Title: "Darn Good Title", Content: "Body", AuthorID: "importer-1"},
+			{Title: "Good Title", Content: "Body", AuthorID: "importer-1"},
+		}
+		s.mockUserRepo.On("GetByID", mock.Anything, "importer-1").Return(&domain.User{ID: "importer-1"}, nil).Once()
+		s.mockBlogRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(blogs []*domain.Blog) bool {
+			return len(blogs) == 1 && blogs[0].Title == "Good Title"
+		})).Return(nil).Once()
+
+		results, err := reject.ImportBlogs(context.Background(), "admin-1", items)
+
+		s.Require().NoError(err)
+		s.Require().Len(results, 2)
+		s.ErrorIs(results[0].Err, domain.ErrContentRejected)
+		s.Empty(results[0].BlogID)
+		s.NoError(results[1].Err)
+	})
+
+	s.Run("Import_MasksTitle", func() {
+		s.mockUserRepo.On("GetByID", mock.Anything, "importer-2").Return(&domain.User{ID: "importer-2"}, nil).Once()
+		s.mockBlogRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(blogs []*domain.Blog) bool {
+			return len(blogs) == 1 && blogs[0].Title == "**** Good Title"
+		})).Return(nil).Once()
+
+		results, err := mask.ImportBlogs(context.Background(), "admin-1",
+			[]domain.BlogImportItem{{Title: "Darn Good Title", Content: "Body", AuthorID: "importer-2"}})
+
+		s.Require().NoError(err)
+		s.NoError(results[0].Err)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestImageHostPolicy() {
+	// Built after SetupTest, so each case gets fresh mocks.
+	newUsecase := func(mode domain.ImageHostMode) domain.IBlogUsecase {
+		return usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+			usecases.WithImageHostPolicy(domain.NewImageHostPolicy([]string{"cloudinary.com"}, mode)))
+	}
+	external := "Hello ![pixel](https://tracker.example.com/p.gif)"
+
+	s.Run("Create_AllowedHost", func() {
+		s.SetupTest()
+		content := "Chart: ![chart](https://res.cloudinary.com/demo/chart.png)"
+		s.mockUserRepo.On("GetByID", mock.Anything, "author-id").Return(&domain.User{ID: "author-id"}, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.Content == content
+		})).Return(nil).Once()
+
+		blog, err := newUsecase(domain.ImageHostModeReject).Create(context.Background(), "Title", content, "author-id", nil, nil, "")
+
+		s.Require().NoError(err)
+		s.Equal(content, blog.Content)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Create_RejectsExternalImage", func() {
+		s.SetupTest()
+
+		blog, err := newUsecase(domain.ImageHostModeReject).Create(context.Background(), "Title", external, "author-id", nil, nil, "")
+
+		s.ErrorIs(err, domain.ErrImageHostNotAllowed)
+		s.Nil(blog)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Import_RejectsExternalImagePerItem", func() {
+		s.SetupTest()
+		items := []domain.BlogImportItem{
+			{Title: "Tracked", Content: external, AuthorID: "author-1"},
+			{Title: "Clean", Content: "Body", AuthorID: "author-1"},
+		}
+		s.mockUserRepo.On("GetByID", mock.Anything, "author-1").Return(&domain.User{ID: "author-1"}, nil).Once()
+		s.mockBlogRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(blogs []*domain.Blog) bool {
+			return len(blogs) == 1 && blogs[0].Title == "Clean"
+		})).Return(nil).Once()
+
+		results, err := newUsecase(domain.ImageHostModeReject).ImportBlogs(context.Background(), "admin-1", items)
+
+		s.Require().NoError(err)
+		s.Require().Len(results, 2)
+		s.ErrorIs(results[0].Err, domain.ErrImageHostNotAllowed)
+		s.Empty(results[0].BlogID)
+		s.NoError(results[1].Err)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Import_StripsExternalImage", func() {
+		s.SetupTest()
+		s.mockUserRepo.On("GetByID", mock.Anything, "author-1").Return(&domain.User{ID: "author-1"}, nil).Once()
+		s.mockBlogRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(blogs []*domain.Blog) bool {
+			return len(blogs) == 1 && blogs[0].Content == "Hello pixel"
+		})).Return(nil).Once()
+
+		results, err := newUsecase(domain.ImageHostModeStrip).ImportBlogs(context.Background(), "admin-1",
+			[]domain.BlogImportItem{{Title: "Tracked", Content: external, AuthorID: "author-1"}})
+
+		s.Require().NoError(err)
+		s.NoError(results[0].Err)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Update_StripsExternalImage", func() {
+		s.SetupTest()
+		existing, _ := domain.NewBlog("Title", "Old content", "owner-id", nil)
+		existing.ID = "blog-images"
+		s.mockBlogRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil).Once()
+		s.mockBlogRepo.On("Update", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.Content == "Hello pixel"
+		})).Return(nil).Once()
+		s.mockRevisionRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		s.mockRevisionRepo.On("PruneOldest", mock.Anything, existing.ID, mock.Anything).Return(nil).Once()
+
+		blog, err := newUsecase(domain.ImageHostModeStrip).Update(context.Background(), existing.ID, "owner-id", domain.RoleUser, map[string]interface{}{"content": external})
+
+		s.Require().NoError(err)
+		s.Equal("Hello pixel", blog.Content)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Update_RejectsExternalImage", func() {
+		s.SetupTest()
+		existing, _ := domain.NewBlog("Title", "Old content", "owner-id", nil)
+		existing.ID = "blog-images"
+		s.mockBlogRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil).Once()
+
+		blog, err := newUsecase(domain.ImageHostModeReject).Update(context.Background(), existing.ID, "owner-id", domain.RoleUser, map[string]interface{}{"content": external})
+
+		s.ErrorIs(err, domain.ErrImageHostNotAllowed)
+		s.Nil(blog)
+		s.Equal("Old content", existing.Content)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *BlogUsecaseTestSuite) TestContentFormat() {
+	newUsecase := func() domain.IBlogUsecase {
+		return usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second)
+	}
+	content := `<p onclick="steal()">Hi</p><script>alert(1)</script>`
+
+	s.Run("Create_DefaultsToMarkdown", func() {
+		s.SetupTest()
+		s.mockUserRepo.On("GetByID", mock.Anything, "author-id").Return(&domain.User{ID: "author-id"}, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.ContentFormat == domain.ContentFormatMarkdown && b.Content == content
+		})).Return(nil).Once()
+
+		_, err := newUsecase().Create(context.Background(), "Title", content, "author-id", nil, nil, "")
+
+		s.Require().NoError(err)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Create_SanitizesHTML", func() {
+		s.SetupTest()
+		s.mockUserRepo.On("GetByID", mock.Anything, "author-id").Return(&domain.User{ID: "author-id"}, nil).Once()
+		s.mockBlogRepo.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.ContentFormat == domain.ContentFormatHTML && b.Content == "<p>Hi</p>" && b.WordCount == 1
+		})).Return(nil).Once()
+
+		_, err := newUsecase().Create(context.Background(), "Title", content, "author-id", nil, nil, domain.ContentFormatHTML)
+
+		s.Require().NoError(err)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Create_RejectsMarkupOnlyHTML", func() {
+		s.SetupTest()
+
+		_, err := newUsecase().Create(context.Background(), "Title", "<script>alert(1)</script>", "author-id", nil, nil, domain.ContentFormatHTML)
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Create_InvalidFormat", func() {
+		s.SetupTest()
+
+		_, err := newUsecase().Create(context.Background(), "Title", content, "author-id", nil, nil, "rtf")
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Update_SwitchingFormatRecleansContent", func() {
+		s.SetupTest()
+		existing, _ := domain.NewBlog("Title", "a < b", "owner-id", nil)
+		existing.ID = "blog-format"
+		s.mockBlogRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil).Once()
+		s.mockBlogRepo.On("Update", mock.Anything, mock.MatchedBy(func(b *domain.Blog) bool {
+			return b.ContentFormat == domain.ContentFormatPlainText && b.Content == "a &lt; b"
+		})).Return(nil).Once()
+		s.mockRevisionRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		s.mockRevisionRepo.On("PruneOldest", mock.Anything, existing.ID, mock.Anything).Return(nil).Once()
+
+		_, err := newUsecase().Update(context.Background(), existing.ID, "owner-id", domain.RoleUser, map[string]interface{}{"content_format": "plaintext"})
+
+		s.Require().NoError(err)
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Update_InvalidFormat", func() {
+		s.SetupTest()
+		existing, _ := domain.NewBlog("Title", "Old content", "owner-id", nil)
+		existing.ID = "blog-format"
+		s.mockBlogRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil).Once()
+
+		_, err := newUsecase().Update(context.Background(), existing.ID, "owner-id", domain.RoleUser, map[string]interface{}{"content_format": "rtf"})
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.Equal(domain.ContentFormatMarkdown, existing.ContentFormat)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+}
+
+// stubContentScorer returns a fixed toxicity score, or err, for any text.
+type stubContentScorer struct {
+	score float64
+	err   error
+}
+
+func (s stubContentScorer) ScoreContent(ctx context.Context, text string) (float64, error) {
+	return s.score, s.err
+}
+
+func (s *BlogUsecaseTestSuite) TestCreate_ToxicityThreshold() {
+	authorID := "author-1"
+	newUsecase := func(scorer domain.IContentScorer, action domain.ToxicityAction) domain.IBlogUsecase {
+		return usecases.NewBlogUsecase(s.mockBlogRepo, s.mockUserRepo, s.mockInteractionRepo, s.mockRevisionRepo, s.mockCommentRepo, s.mockReadRepo, 2*time.Second,
+			usecases.WithBlogToxicityThreshold(scorer, 0.7, action))
+	}
+
+	for _, tc := range []struct {
+		name   string
+		scorer stubContentScorer
+		status domain.BlogStatus
+	}{
+		{"Success_BelowThreshold", stubContentScorer{score: 0.7}, domain.BlogStatusPublished},
+		{"Success_AboveThresholdIsQueued", stubContentScorer{score: 0.71}, domain.BlogStatusPending},
+		{"Success_ScoringUnavailable", stubContentScorer{err: errors.New("AI service down")}, domain.BlogStatusPublished},
+	} {
+		s.Run(tc.name, func() {
+			s.SetupTest()
+			s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(&domain.User{ID: authorID}, nil).Once()
+			s.mockBlogRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Blog")).Return(nil).Once()
+
+			blog, err := newUsecase(tc.scorer, domain.ToxicityActionQueue).Create(context.Background(), "Title", "Content", authorID, nil, nil, "")
+
+			s.Require().NoError(err)
+			s.Equal(tc.status, blog.Status)
+		})
+	}
+
+	s.Run("Failure_AboveThresholdIsRejected", func() {
+		s.SetupTest()
+		s.mockUserRepo.On("GetByID", mock.Anything, authorID).Return(&domain.User{ID: authorID}, nil).Once()
+
+		blog, err := newUsecase(stubContentScorer{score: 0.9}, domain.ToxicityActionReject).Create(context.Background(), "Title", "Content", authorID, nil, nil, "")
+
+		s.ErrorIs(err, domain.ErrContentRejected)
+		s.Nil(blog)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
 	})
 }
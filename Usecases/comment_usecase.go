@@ -4,63 +4,255 @@ import (
 	"context"
 	"errors"
 	"log"
+	"strings"
+	"sync"
 	"time"
 
 	domain "A2SV_Starter_Project_Blog/Domain"
+	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
 )
 
+// MaxCommentThreadDepth caps how many ancestors GetThreadPath will follow.
+const MaxCommentThreadDepth = 100
+
 type commentUsecase struct {
 	blogRepo    domain.IBlogRepository
 	commentRepo domain.ICommentRepository
-	timeout     time.Duration
+	// Used to notify users mentioned in new comments. Either may be nil to disable mentions.
+	userRepo     UserRepository
+	emailService infrastructure.EmailService
+	timeout      time.Duration
+	limits       domain.CommentLengthLimits
+	profanity    *domain.ProfanityFilter
+	// maxPerBlog caps the comments and replies on a single blog. Zero means unlimited.
+	maxPerBlog int64
+	// cooldown is the minimum time between two comments by one user, tracked in cache.
+	// A nil cache or zero cooldown disables it.
+	cache    domain.ICacheService
+	cooldown time.Duration
+	// embeddedReplies is how many replies GetCommentsForBlog embeds under each comment. Zero embeds none.
+	embeddedReplies int64
+	// minAccountAge is how old an account must be to comment. Zero disables the check.
+	minAccountAge time.Duration
+	// preModeration holds new comments in the moderation queue until an admin approves them.
+	preModeration bool
+	similarity    *similarityGuard
+	toxicity      *toxicityGate
+	deletePolicy  domain.CommentDeletePolicy
+	// deleteWindow is how long after posting an author may still delete a comment. Zero means any time.
+	deleteWindow time.Duration
+}
+
+// CommentUsecaseOption configures optional behaviour of the comment usecase.
+type CommentUsecaseOption func(*commentUsecase)
+
+// WithCommentLengthLimits bounds the length of comment content in characters.
+// Limits with min below 1 or max below min are ignored.
+func WithCommentLengthLimits(min, max int) CommentUsecaseOption {
+	return func(cu *commentUsecase) {
+		if min >= 1 && max >= min {
+			cu.limits = domain.CommentLengthLimits{Min: min, Max: max}
+		}
+	}
+}
+
+// WithCommentProfanityFilter screens comment content on create and update.
+func WithCommentProfanityFilter(filter *domain.ProfanityFilter) CommentUsecaseOption {
+	return func(cu *commentUsecase) {
+		cu.profanity = filter
+	}
+}
+
+// WithMaxCommentsPerBlog caps how many comments a blog can receive. Zero or less means unlimited.
+func WithMaxCommentsPerBlog(max int) CommentUsecaseOption {
+	return func(cu *commentUsecase) {
+		if max > 0 {
+			cu.maxPerBlog = int64(max)
+		}
+	}
+}
+
+// WithCommentCooldown makes users wait between comments. Zero or less disables the cooldown.
+func WithCommentCooldown(cache domain.ICacheService, cooldown time.Duration) CommentUsecaseOption {
+	return func(cu *commentUsecase) {
+		if cache != nil && cooldown > 0 {
+			cu.cache = cache
+			cu.cooldown = cooldown
+		}
+	}
+}
+
+// WithEmbeddedReplies embeds the first n replies of each top-level comment in blog comment
+// listings, so clients can render short threads without a request per comment. Zero or less disables it.
+func WithEmbeddedReplies(n int) CommentUsecaseOption {
+	return func(cu *commentUsecase) {
+		if n > 0 {
+			cu.embeddedReplies = int64(n)
+		}
+	}
+}
+
+// WithCommentMinAccountAge rejects comments from accounts younger than minAge with ErrAccountTooNew.
+// Zero or less disables it, as does constructing the usecase without a user repository.
+func WithCommentMinAccountAge(minAge time.Duration) CommentUsecaseOption {
+	return func(cu *commentUsecase) {
+		if minAge > 0 {
+			cu.minAccountAge = minAge
+		}
+	}
+}
+
+// WithCommentSimilarityWindow rejects a new comment with ErrConflict when its author posted a
+// near-identical one within the window, on any blog. maxWords sets the sensitivity: above zero,
+// only the first maxWords words are compared. A nil cache or a window of zero or less disables it.
+func WithCommentSimilarityWindow(cache domain.ICacheService, window time.Duration, maxWords int) CommentUsecaseOption {
+	return func(cu *commentUsecase) {
+		cu.similarity = newSimilarityGuard(cache, window, maxWords)
+	}
+}
+
+// WithCommentPreModeration holds every new comment for an admin to approve. Pending comments
+// are hidden, can't be replied to, don't count towards the blog's counters and don't notify
+// mentioned users.
+func WithCommentPreModeration(enabled bool) CommentUsecaseOption {
+	return func(cu *commentUsecase) {
+		cu.preModeration = enabled
+	}
+}
+
+// WithCommentToxicityThreshold scores each new comment with the AI and rejects or queues,
+// depending on action, those scoring above threshold. A nil scorer or a threshold of zero or
+// less disables it.
+func WithCommentToxicityThreshold(scorer domain.IContentScorer, threshold float64, action domain.ToxicityAction) CommentUsecaseOption {
+	return func(cu *commentUsecase) {
+		cu.toxicity = newToxicityGate(scorer, threshold, action)
+	}
+}
+
+// WithCommentDeletePolicy sets what DeleteComment does to a published comment. Invalid
+// policies are ignored, leaving the default of CommentDeleteAnonymize.
+func WithCommentDeletePolicy(policy domain.CommentDeletePolicy) CommentUsecaseOption {
+	return func(cu *commentUsecase) {
+		if policy.IsValid() {
+			cu.deletePolicy = policy
+		}
+	}
+}
+
+// WithCommentDeleteWindow lets authors delete their published comments only for window after
+// posting, so a reply can't be pulled out from under an argument later. Admins can always delete.
+// Zero or less leaves no limit.
+func WithCommentDeleteWindow(window time.Duration) CommentUsecaseOption {
+	return func(cu *commentUsecase) {
+		if window > 0 {
+			cu.deleteWindow = window
+		}
+	}
 }
 
 func NewCommentUsecase(
 	blogRepo domain.IBlogRepository,
 	commentRepo domain.ICommentRepository,
+	userRepo UserRepository,
+	emailService infrastructure.EmailService,
 	timeout time.Duration,
+	opts ...CommentUsecaseOption,
 ) domain.ICommentUsecase {
-	return &commentUsecase{
-		blogRepo:    blogRepo,
-		commentRepo: commentRepo,
-		timeout:     timeout,
+	cu := &commentUsecase{
+		blogRepo:     blogRepo,
+		commentRepo:  commentRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+		timeout:      timeout,
+		limits:       domain.DefaultCommentLengthLimits,
+		deletePolicy: domain.CommentDeleteAnonymize,
 	}
+	for _, opt := range opts {
+		opt(cu)
+	}
+	return cu
 }
 
-func (cu *commentUsecase) CreateComment(ctx context.Context, userID, blogID, content string, parentID *string) (*domain.Comment, error) {
+func (cu *commentUsecase) CreateComment(ctx context.Context, userID, blogID, content string, parentID *string, userRole domain.Role) (*domain.Comment, error) {
 	ctx, cancel := context.WithTimeout(ctx, cu.timeout)
 	defer cancel()
 
 	// 1. Usecase-level validation: Check if referenced entities exist.
-	if _, err := cu.blogRepo.GetByID(ctx, blogID); err != nil {
+	blog, err := cu.blogRepo.GetByID(ctx, blogID)
+	if err != nil {
 		if errors.Is(err, ErrNotFound) {
 			return nil, ErrNotFound // Or a more specific "blog not found" error
 		}
 		return nil, err
 	}
+	// Drafts and blogs awaiting moderation are hidden, so they can't be commented on either.
+	if !blog.IsPublished() && blog.AuthorID != userID && userRole != domain.RoleAdmin {
+		return nil, ErrNotFound
+	}
+	if cu.maxPerBlog > 0 && blog.CommentsCount >= cu.maxPerBlog {
+		return nil, domain.ErrCommentLimitReached
+	}
 
 	// If it's a reply, check if the parent comment exists.
 	if parentID != nil && *parentID != "" {
-		if _, err := cu.commentRepo.GetByID(ctx, *parentID); err != nil {
+		parent, err := cu.commentRepo.GetByID(ctx, *parentID)
+		if err != nil {
 			if errors.Is(err, ErrNotFound) {
 				return nil, ErrNotFound // Or "parent comment not found"
 			}
 			return nil, err
 		}
+		if parent.Pending {
+			return nil, ErrNotFound // Not visible until approved.
+		}
+	}
+
+	if err := cu.checkAccountAge(ctx, userID); err != nil {
+		return nil, err
 	}
 
-	// 2. Create the domain entity using the factory. This enforces domain invariants.
-	comment, err := domain.NewComment(blogID, userID, content, parentID)
+	// 2. Screen the content, then create the domain entity using the factory. This enforces domain invariants.
+	content, err = cu.profanity.Apply(content)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := domain.NewCommentWithLimits(blogID, userID, content, parentID, cu.limits)
 	if err != nil {
 		return nil, err // Pass up domain.ErrValidation
 	}
+	comment.Pending = cu.preModeration
+	hold, err := cu.toxicity.screen(ctx, domain.ContentKindComment, comment.Content)
+	if err != nil {
+		return nil, err
+	}
+	if hold {
+		comment.Pending = true
+	}
 
-	// 3. Persist the new comment.
+	// 3. Enforce the similarity check and the cooldown only once the comment is known to be
+	// valid, so a rejected attempt doesn't make the user wait.
+	similarityKey, err := cu.similarity.claim(ctx, domain.ContentKindComment, userID, comment.Content)
+	if err != nil {
+		return nil, err
+	}
+	if err := cu.startCooldown(ctx, userID); err != nil {
+		cu.similarity.release(ctx, similarityKey)
+		return nil, err
+	}
+
+	// 4. Persist the new comment.
 	if err := cu.commentRepo.Create(ctx, comment); err != nil {
+		cu.clearCooldown(ctx, userID)
+		cu.similarity.release(ctx, similarityKey)
 		return nil, err
 	}
 
-	// 4. After successfully creating the comment, update the counters.
+	// A pending comment is counted, and its mentions notified, only once it is approved.
+	if comment.Pending {
+		return comment, nil
+	}
+
+	// 5. After successfully creating the comment, update the counters.
 	go func() {
 		// Increment the total comment count on the blog post.
 		if err := cu.blogRepo.IncrementCommentCount(context.Background(), blogID, 1); err != nil {
@@ -76,6 +268,10 @@ func (cu *commentUsecase) CreateComment(ctx context.Context, userID, blogID, con
 			}
 		}()
 	}
+	if mentions := domain.ExtractMentions(comment.Content); len(mentions) > 0 {
+		go cu.notifyMentions(userID, blogID, mentions)
+	}
+
 	// Note: We don't wait for the WaitGroup here (`wg.Wait()`) because these are non-critical
 	// background updates. We want to return the created comment to the user immediately.
 
@@ -97,9 +293,14 @@ func (cu *commentUsecase) UpdateComment(ctx context.Context, userID, commentID,
 		return nil, domain.ErrPermissionDenied
 	}
 
-	// 3. Update the content and timestamp.
-	comment.Content = content
-	comment.UpdatedAt = time.Now().UTC()
+	// 3. Update the content and timestamp, enforcing the same rules as on creation.
+	content, err = cu.profanity.Apply(content)
+	if err != nil {
+		return nil, err
+	}
+	if err := comment.SetContent(content, cu.limits); err != nil {
+		return nil, err
+	}
 
 	// 4. Persist the changes.
 	if err := cu.commentRepo.Update(ctx, comment); err != nil {
@@ -109,7 +310,7 @@ func (cu *commentUsecase) UpdateComment(ctx context.Context, userID, commentID,
 	return comment, nil
 }
 
-func (cu *commentUsecase) DeleteComment(ctx context.Context, userID, commentID string) error {
+func (cu *commentUsecase) DeleteComment(ctx context.Context, userID, commentID string, userRole domain.Role) error {
 	ctx, cancel := context.WithTimeout(ctx, cu.timeout)
 	defer cancel()
 
@@ -118,34 +319,110 @@ func (cu *commentUsecase) DeleteComment(ctx context.Context, userID, commentID s
 	if err != nil {
 		return err
 	}
+	// An anonymized comment was deleted already; doing it again would lower the counters twice.
+	if comment.AuthorID == nil {
+		return ErrNotFound
+	}
 
-	// 2. Authorization: Only the author can delete.
+	// 2. Authorization: Only the author or an admin can delete.
 	isOwner := comment.AuthorID != nil && *comment.AuthorID == userID
+	isAdmin := userRole == domain.RoleAdmin
 
-	if !isOwner {
+	if !isOwner && !isAdmin {
 		return domain.ErrPermissionDenied
 	}
 
-	// 3. Anonymize the comment.
-	if err := cu.commentRepo.Anonymize(ctx, commentID); err != nil {
+	// A pending comment was never counted or shown, so withdrawing it simply removes it.
+	if comment.Pending {
+		return cu.commentRepo.DeletePending(ctx, commentID)
+	}
+
+	if !isAdmin && cu.deleteWindow > 0 && time.Since(comment.CreatedAt) > cu.deleteWindow {
+		return domain.ErrDeleteWindowExpired
+	}
+
+	// 3. Remove the comment, or anonymize it when it has replies or the policy keeps every comment.
+	removed, err := cu.removeComment(ctx, comment)
+	if err != nil {
 		return err
 	}
 
-	// 4. After anonymizing, decrement the relevant counters.
+	// 4. Afterwards, decrement the relevant counters. An anonymized reply still sits under its
+	// parent, so only a removed one lowers the parent's reply count.
 	go func() {
 		if err := cu.blogRepo.IncrementCommentCount(context.Background(), comment.BlogID, -1); err != nil {
 			log.Printf("non-critical error: failed to decrement comment count for blog %s: %v", comment.BlogID, err)
 		}
+		if removed && comment.ParentID != nil {
+			if err := cu.commentRepo.IncrementReplyCount(context.Background(), *comment.ParentID, -1); err != nil {
+				log.Printf("non-critical error: failed to decrement reply count for parent comment %s: %v", *comment.ParentID, err)
+			}
+		}
 	}()
 
 	return nil
 }
 
-func (cu *commentUsecase) GetCommentsForBlog(ctx context.Context, blogID string, page, limit int64) ([]*domain.Comment, int64, error) {
+// removeComment deletes the comment outright under CommentDeleteHard when it has no replies, and
+// anonymizes it otherwise. It reports whether the comment was removed.
+func (cu *commentUsecase) removeComment(ctx context.Context, comment *domain.Comment) (bool, error) {
+	if cu.deletePolicy == domain.CommentDeleteHard && comment.ReplyCount <= 0 {
+		err := cu.commentRepo.DeleteIfNoReplies(ctx, comment.ID)
+		if err == nil {
+			return true, nil
+		}
+		// A reply may have arrived since the comment was loaded; anonymize it after all.
+		if !errors.Is(err, ErrNotFound) {
+			return false, err
+		}
+	}
+	return false, cu.commentRepo.Anonymize(ctx, comment.ID)
+}
+
+func (cu *commentUsecase) GetCommentsForBlog(ctx context.Context, blogID string, page, limit int64, sort domain.CommentSort) ([]*domain.Comment, int64, error) {
+	// Resolve the default here so "" and "oldest" share one cache entry.
+	if sort == "" {
+		sort = domain.CommentSortOldest
+	}
+	if !sort.IsValid() {
+		return nil, 0, domain.ErrValidation
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, cu.timeout)
 	defer cancel()
 
-	return cu.commentRepo.FetchByBlogID(ctx, blogID, page, limit)
+	comments, total, err := cu.commentRepo.FetchByBlogID(ctx, blogID, page, limit, sort)
+	if err != nil {
+		return nil, 0, err
+	}
+	cu.embedReplies(ctx, comments)
+	return comments, total, nil
+}
+
+// embedReplies attaches the first page of replies to each comment that has any, fetching them
+// concurrently. A failed fetch leaves that comment without replies; HasMoreReplies then tells
+// the client to page through them itself.
+func (cu *commentUsecase) embedReplies(ctx context.Context, comments []*domain.Comment) {
+	if cu.embeddedReplies == 0 {
+		return
+	}
+	var wg sync.WaitGroup
+	for _, comment := range comments {
+		if comment.ReplyCount == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			replies, _, err := cu.commentRepo.FetchReplies(ctx, comment.ID, 1, cu.embeddedReplies)
+			if err != nil {
+				log.Printf("non-critical error: failed to embed replies of comment %s: %v", comment.ID, err)
+				return
+			}
+			comment.Replies = replies
+		}()
+	}
+	wg.Wait()
 }
 
 func (cu *commentUsecase) GetRepliesForComment(ctx context.Context, parentID string, page, limit int64) ([]*domain.Comment, int64, error) {
@@ -154,3 +431,132 @@ func (cu *commentUsecase) GetRepliesForComment(ctx context.Context, parentID str
 
 	return cu.commentRepo.FetchReplies(ctx, parentID, page, limit)
 }
+
+// checkAccountAge returns ErrAccountTooNew if the commenter's account is younger than the configured minimum.
+func (cu *commentUsecase) checkAccountAge(ctx context.Context, userID string) error {
+	if cu.minAccountAge == 0 || cu.userRepo == nil {
+		return nil
+	}
+	user, err := cu.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, ErrNotFound) || (err == nil && user == nil) {
+		return domain.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return user.CheckAccountAge(cu.minAccountAge, time.Now().UTC())
+}
+
+// SearchCommentsInBlog finds the comments and replies of a blog that match query. A blank query is a validation error.
+func (cu *commentUsecase) SearchCommentsInBlog(ctx context.Context, blogID, query string, page, limit int64) ([]*domain.Comment, int64, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, 0, domain.ErrValidation
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cu.timeout)
+	defer cancel()
+
+	return cu.commentRepo.SearchInBlog(ctx, blogID, query, page, limit)
+}
+
+// GetThreadPath walks up from a comment to its top-level ancestor and returns the chain,
+// root first, so clients can render a deep-linked reply in context.
+func (cu *commentUsecase) GetThreadPath(ctx context.Context, commentID string) ([]*domain.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, cu.timeout)
+	defer cancel()
+
+	comment, err := cu.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Pending {
+		return nil, ErrNotFound
+	}
+
+	path := []*domain.Comment{}
+	seen := map[string]bool{comment.ID: true}
+	for comment.ParentID != nil {
+		// Corrupt parent links could form a cycle or an absurdly deep chain; never follow them forever.
+		if len(path) >= MaxCommentThreadDepth || seen[*comment.ParentID] {
+			log.Printf("ERROR: comment %s has a broken thread path (depth %d)", commentID, len(path))
+			return nil, ErrInternal
+		}
+		parent, err := cu.commentRepo.GetByID(ctx, *comment.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		seen[parent.ID] = true
+		path = append(path, parent)
+		comment = parent
+	}
+
+	// The walk collected ancestors nearest first; callers want them from the root down.
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, nil
+}
+
+// notifyMentions emails the users mentioned in a new comment. Unknown usernames,
+// inactive accounts and the author mentioning themselves are skipped.
+// It runs in the background, so failures are only logged.
+func (cu *commentUsecase) notifyMentions(authorID, blogID string, usernames []string) {
+	if cu.userRepo == nil || cu.emailService == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cu.timeout)
+	defer cancel()
+
+	users, err := cu.userRepo.GetByUsernames(ctx, usernames)
+	if err != nil {
+		log.Printf("non-critical error: failed to resolve mentions on blog %s: %v", blogID, err)
+		return
+	}
+
+	mentionedBy := "Someone"
+	if author, err := cu.userRepo.GetByID(ctx, authorID); err == nil && author != nil {
+		mentionedBy = author.Username
+	}
+
+	for _, user := range users {
+		if user.ID == authorID || !user.IsActive {
+			continue
+		}
+		if err := cu.emailService.SendMentionEmail(user.Email, user.Username, mentionedBy, blogID); err != nil {
+			log.Printf("non-critical error: failed to notify user %s of a mention: %v", user.ID, err)
+		}
+	}
+}
+
+func commentCooldownKey(userID string) string {
+	return "comment:cooldown:" + userID
+}
+
+// startCooldown claims the user's cooldown slot, or returns ErrTooManyRequests when a recent
+// comment still holds it. Claiming is a single check-and-set, so concurrent requests can't both pass.
+// An unavailable cache lets the comment through rather than blocking all commenting.
+func (cu *commentUsecase) startCooldown(ctx context.Context, userID string) error {
+	if cu.cache == nil {
+		return nil
+	}
+	claimed, err := cu.cache.SetIfAbsent(ctx, commentCooldownKey(userID), []byte("1"), cu.cooldown)
+	if err != nil {
+		log.Printf("non-critical error: failed to check comment cooldown for user %s: %v", userID, err)
+		return nil
+	}
+	if !claimed {
+		return domain.ErrTooManyRequests
+	}
+	return nil
+}
+
+// clearCooldown releases the slot claimed for a comment that could not be saved.
+func (cu *commentUsecase) clearCooldown(ctx context.Context, userID string) {
+	if cu.cache == nil {
+		return
+	}
+	if err := cu.cache.Delete(ctx, commentCooldownKey(userID)); err != nil {
+		log.Printf("non-critical error: failed to clear comment cooldown for user %s: %v", userID, err)
+	}
+}
@@ -2,6 +2,8 @@ package usecases_test
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -37,8 +39,8 @@ func (m *MockCommentRepository) Anonymize(ctx context.Context, commentID string)
 	args := m.Called(ctx, commentID)
 	return args.Error(0)
 }
-func (m *MockCommentRepository) FetchByBlogID(ctx context.Context, blogID string, page, limit int64) ([]*domain.Comment, int64, error) {
-	args := m.Called(ctx, blogID, page, limit)
+func (m *MockCommentRepository) FetchByBlogID(ctx context.Context, blogID string, page, limit int64, sort domain.CommentSort) ([]*domain.Comment, int64, error) {
+	args := m.Called(ctx, blogID, page, limit, sort)
 	return args.Get(0).([]*domain.Comment), args.Get(1).(int64), args.Error(2)
 }
 func (m *MockCommentRepository) FetchReplies(ctx context.Context, parentID string, page, limit int64) ([]*domain.Comment, int64, error) {
@@ -49,19 +51,154 @@ func (m *MockCommentRepository) IncrementReplyCount(ctx context.Context, parentI
 	args := m.Called(ctx, parentID, value)
 	return args.Error(0)
 }
+func (m *MockCommentRepository) CountByBlogID(ctx context.Context, blogID string) (int64, error) {
+	args := m.Called(ctx, blogID)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockCommentRepository) FetchAllByBlogID(ctx context.Context, blogID string) ([]*domain.Comment, error) {
+	args := m.Called(ctx, blogID)
+	var comments []*domain.Comment
+	if args.Get(0) != nil {
+		comments = args.Get(0).([]*domain.Comment)
+	}
+	return comments, args.Error(1)
+}
+func (m *MockCommentRepository) SearchInBlog(ctx context.Context, blogID, query string, page, limit int64) ([]*domain.Comment, int64, error) {
+	args := m.Called(ctx, blogID, query, page, limit)
+	var comments []*domain.Comment
+	if args.Get(0) != nil {
+		comments = args.Get(0).([]*domain.Comment)
+	}
+	return comments, args.Get(1).(int64), args.Error(2)
+}
+func (m *MockCommentRepository) FetchPending(ctx context.Context, page, limit int64) ([]*domain.Comment, int64, error) {
+	args := m.Called(ctx, page, limit)
+	var comments []*domain.Comment
+	if args.Get(0) != nil {
+		comments = args.Get(0).([]*domain.Comment)
+	}
+	return comments, args.Get(1).(int64), args.Error(2)
+}
+func (m *MockCommentRepository) Approve(ctx context.Context, commentID string) error {
+	args := m.Called(ctx, commentID)
+	return args.Error(0)
+}
+func (m *MockCommentRepository) DeleteIfNoReplies(ctx context.Context, commentID string) error {
+	args := m.Called(ctx, commentID)
+	return args.Error(0)
+}
+func (m *MockCommentRepository) DeletePending(ctx context.Context, commentID string) error {
+	args := m.Called(ctx, commentID)
+	return args.Error(0)
+}
+
+// fakeRedisCache is an in-memory stand-in for Redis with a clock the test controls,
+// so key expiry can be tested without sleeping.
+type fakeRedisCache struct {
+	mu      sync.Mutex
+	now     time.Time
+	values  map[string][]byte
+	expires map[string]time.Time
+}
+
+func newFakeRedisCache() *fakeRedisCache {
+	return &fakeRedisCache{now: time.Now(), values: map[string][]byte{}, expires: map[string]time.Time{}}
+}
+
+func (f *fakeRedisCache) advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// live reports whether the key is set and unexpired. The caller must hold the lock.
+func (f *fakeRedisCache) live(key string) bool {
+	if _, ok := f.values[key]; !ok {
+		return false
+	}
+	if exp, ok := f.expires[key]; ok && !f.now.Before(exp) {
+		delete(f.values, key)
+		delete(f.expires, key)
+		return false
+	}
+	return true
+}
+
+func (f *fakeRedisCache) set(key string, value []byte, expiration time.Duration) {
+	f.values[key] = value
+	delete(f.expires, key)
+	if expiration > 0 {
+		f.expires[key] = f.now.Add(expiration)
+	}
+}
+
+func (f *fakeRedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.live(key) {
+		return nil, domain.ErrNotFound
+	}
+	return f.values[key], nil
+}
+func (f *fakeRedisCache) GetAndDelete(ctx context.Context, key string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.live(key) {
+		return nil, domain.ErrNotFound
+	}
+	value := f.values[key]
+	delete(f.values, key)
+	delete(f.expires, key)
+	return value, nil
+}
+func (f *fakeRedisCache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.set(key, value, expiration)
+	return nil
+}
+func (f *fakeRedisCache) SetIfAbsent(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.live(key) {
+		return false, nil
+	}
+	f.set(key, value, expiration)
+	return true, nil
+}
+func (f *fakeRedisCache) Delete(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.values, key)
+	delete(f.expires, key)
+	return nil
+}
+func (f *fakeRedisCache) AddToSet(ctx context.Context, key string, members ...any) error {
+	return nil
+}
+func (f *fakeRedisCache) GetSetMembers(ctx context.Context, key string) ([]string, error) {
+	return nil, nil
+}
+func (f *fakeRedisCache) DeleteKeys(ctx context.Context, keys []string) error {
+	return nil
+}
 
 // --- Test Suite Setup ---
 type CommentUsecaseTestSuite struct {
 	suite.Suite
 	mockBlogRepo    *MockBlogRepository
 	mockCommentRepo *MockCommentRepository
+	mockUserRepo    *MockUserRepository
+	mockEmailSvc    *MockEmailService
 	usecase         domain.ICommentUsecase
 }
 
 func (s *CommentUsecaseTestSuite) SetupTest() {
 	s.mockBlogRepo = new(MockBlogRepository)
 	s.mockCommentRepo = new(MockCommentRepository)
-	s.usecase = NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, 2*time.Second)
+	s.mockUserRepo = new(MockUserRepository)
+	s.mockEmailSvc = new(MockEmailService)
+	s.usecase = NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, s.mockUserRepo, s.mockEmailSvc, 2*time.Second)
 }
 
 func TestCommentUsecaseTestSuite(t *testing.T) {
@@ -86,7 +223,7 @@ func (s *CommentUsecaseTestSuite) TestCreateComment() {
 			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
 
 		// Act
-		comment, err := s.usecase.CreateComment(ctx, userID, blogID, content, nil)
+		comment, err := s.usecase.CreateComment(ctx, userID, blogID, content, nil, domain.RoleUser)
 
 		// Assert
 		s.NoError(err)
@@ -113,7 +250,7 @@ func (s *CommentUsecaseTestSuite) TestCreateComment() {
 			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
 
 		// Act
-		comment, err := s.usecase.CreateComment(ctx, userID, blogID, content, &parentID)
+		comment, err := s.usecase.CreateComment(ctx, userID, blogID, content, &parentID, domain.RoleUser)
 
 		// Assert
 		s.NoError(err)
@@ -123,13 +260,50 @@ func (s *CommentUsecaseTestSuite) TestCreateComment() {
 		s.mockCommentRepo.AssertExpectations(s.T())
 	})
 
+	s.Run("Failure - Blog not published", func() {
+		for _, status := range []domain.BlogStatus{domain.BlogStatusDraft, domain.BlogStatusPending} {
+			s.SetupTest()
+			// Arrange
+			s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{AuthorID: "author-1", Status: status}, nil).Once()
+
+			// Act
+			comment, err := s.usecase.CreateComment(ctx, userID, blogID, content, nil, domain.RoleUser)
+
+			// Assert
+			s.ErrorIs(err, ErrNotFound, status)
+			s.Nil(comment)
+			s.mockCommentRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+			s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementCommentCount", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	s.Run("Success - Author comments on their pending blog", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		// Arrange
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{AuthorID: userID, Status: domain.BlogStatusPending}, nil).Once()
+		s.mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, 1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		// Act
+		comment, err := s.usecase.CreateComment(ctx, userID, blogID, content, nil, domain.RoleUser)
+
+		// Assert
+		s.NoError(err)
+		s.NotNil(comment)
+		wg.Wait()
+	})
+
 	s.Run("Failure - Blog not found", func() {
 		s.SetupTest()
 		// Arrange
 		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(nil, ErrNotFound).Once()
 
 		// Act
-		comment, err := s.usecase.CreateComment(ctx, userID, blogID, content, nil)
+		comment, err := s.usecase.CreateComment(ctx, userID, blogID, content, nil, domain.RoleUser)
 
 		// Assert
 		s.Error(err)
@@ -137,156 +311,1019 @@ func (s *CommentUsecaseTestSuite) TestCreateComment() {
 		s.Nil(comment)
 		s.mockCommentRepo.AssertNotCalled(s.T(), "Create")
 	})
-}
 
-func (s *CommentUsecaseTestSuite) TestUpdateComment() {
-	ctx := context.Background()
-	userID := "user-123"
-	commentID := "comment-abc"
-	newContent := "This content has been updated."
+	s.Run("Failure - Content outside configured limits", func() {
+		s.SetupTest()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithCommentLengthLimits(5, 10))
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Twice()
 
-	s.Run("Success - As Owner", func() {
+		_, err := usecase.CreateComment(ctx, userID, blogID, "tiny", nil, domain.RoleUser)
+		s.ErrorIs(err, domain.ErrValidation)
+		_, err = usecase.CreateComment(ctx, userID, blogID, "far too long for the limit", nil, domain.RoleUser)
+		s.ErrorIs(err, domain.ErrValidation)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Create")
+	})
+
+	s.Run("Failure - Blog at its comment limit", func() {
 		s.SetupTest()
-		// Arrange
-		mockComment := &domain.Comment{ID: commentID, AuthorID: &userID, Content: "Original"}
-		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
-		s.mockCommentRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Comment")).
-			// We can assert that the content was updated before the repo call
-			Run(func(args mock.Arguments) {
-				commentArg := args.Get(1).(*domain.Comment)
-				s.Equal(newContent, commentArg.Content)
-			}).
-			Return(nil).Once()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithMaxCommentsPerBlog(3))
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID, CommentsCount: 3}, nil).Once()
 
-		// Act
-		updatedComment, err := s.usecase.UpdateComment(ctx, userID, commentID, newContent)
+		comment, err := usecase.CreateComment(ctx, userID, blogID, content, nil, domain.RoleUser)
 
-		// Assert
-		s.NoError(err)
-		s.NotNil(updatedComment)
-		s.Equal(newContent, updatedComment.Content)
-		s.mockCommentRepo.AssertExpectations(s.T())
+		s.ErrorIs(err, domain.ErrCommentLimitReached)
+		s.Nil(comment)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Create")
 	})
 
-	s.Run("Failure - Not the owner", func() {
+	s.Run("Success - Blog just under its comment limit", func() {
 		s.SetupTest()
-		// Arrange
-		otherUserID := "user-456"
-		mockComment := &domain.Comment{ID: commentID, AuthorID: &otherUserID, Content: "Original"}
-		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		var wg sync.WaitGroup
+		wg.Add(1)
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithMaxCommentsPerBlog(3))
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID, CommentsCount: 2}, nil).Once()
+		s.mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, 1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
 
-		// Act
-		updatedComment, err := s.usecase.UpdateComment(ctx, userID, commentID, newContent)
+		comment, err := usecase.CreateComment(ctx, userID, blogID, content, nil, domain.RoleUser)
 
-		// Assert
-		s.Error(err)
-		s.ErrorIs(err, domain.ErrPermissionDenied)
-		s.Nil(updatedComment)
-		s.mockCommentRepo.AssertNotCalled(s.T(), "Update")
+		s.NoError(err)
+		s.NotNil(comment)
+		wg.Wait()
 	})
 
-	s.Run("Failure - Comment is anonymized (deleted)", func() {
+	s.Run("Success - Unlimited by default", func() {
 		s.SetupTest()
-		// Arrange: A comment with a nil AuthorID cannot be updated.
-		mockComment := &domain.Comment{ID: commentID, AuthorID: nil, Content: "[deleted]"}
-		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		var wg sync.WaitGroup
+		wg.Add(1)
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithMaxCommentsPerBlog(0))
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID, CommentsCount: 1_000_000}, nil).Once()
+		s.mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, 1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
 
-		// Act
-		_, err := s.usecase.UpdateComment(ctx, userID, commentID, newContent)
+		comment, err := usecase.CreateComment(ctx, userID, blogID, content, nil, domain.RoleUser)
 
-		// Assert
-		s.Error(err)
-		s.ErrorIs(err, domain.ErrPermissionDenied)
+		s.NoError(err)
+		s.NotNil(comment)
+		wg.Wait()
 	})
 }
 
-func (s *CommentUsecaseTestSuite) TestDeleteComment() {
+func (s *CommentUsecaseTestSuite) TestCreateComment_MinAccountAge() {
 	ctx := context.Background()
 	userID := "user-123"
-	commentID := "comment-abc"
-	blogID := "blog-xyz"
+	blogID := "blog-abc"
+	newUsecase := func() domain.ICommentUsecase {
+		return NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, s.mockUserRepo, nil, 2*time.Second, WithCommentMinAccountAge(time.Hour))
+	}
 
-	s.Run("Success - As Owner", func() {
+	s.Run("Failure - Account too new", func() {
 		s.SetupTest()
-		var wg sync.WaitGroup
-		wg.Add(1) // For the blog counter decrement
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		s.mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, CreatedAt: time.Now().UTC().Add(-5 * time.Minute)}, nil).Once()
 
-		// Arrange
-		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID}
-		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
-		s.mockCommentRepo.On("Anonymize", mock.Anything, commentID).Return(nil).Once()
-		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, -1).
+		comment, err := newUsecase().CreateComment(ctx, userID, blogID, "Hello", nil, domain.RoleUser)
+
+		s.ErrorIs(err, domain.ErrAccountTooNew)
+		s.Nil(comment)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Success - Aged account", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(1)
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		s.mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, CreatedAt: time.Now().UTC().Add(-2 * time.Hour)}, nil).Once()
+		s.mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, 1).
 			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
 
-		// Act
-		err := s.usecase.DeleteComment(ctx, userID, commentID)
+		comment, err := newUsecase().CreateComment(ctx, userID, blogID, "Hello", nil, domain.RoleUser)
 
-		// Assert
-		s.NoError(err)
+		s.Require().NoError(err)
+		s.NotNil(comment)
 		wg.Wait()
 		s.mockCommentRepo.AssertExpectations(s.T())
-		s.mockBlogRepo.AssertExpectations(s.T())
 	})
 
-	s.Run("Failure - Not the owner", func() {
+	s.Run("Failure - Unknown user", func() {
 		s.SetupTest()
-		otherUserID := "user-456"
-		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &otherUserID}
-		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		s.mockUserRepo.On("GetByID", mock.Anything, userID).Return(nil, ErrNotFound).Once()
 
-		// Act
-		err := s.usecase.DeleteComment(ctx, userID, commentID)
+		_, err := newUsecase().CreateComment(ctx, userID, blogID, "Hello", nil, domain.RoleUser)
 
-		// Assert
-		s.Error(err)
-		s.ErrorIs(err, domain.ErrPermissionDenied)
-		s.mockCommentRepo.AssertNotCalled(s.T(), "Anonymize")
-		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementCommentCount")
+		s.ErrorIs(err, domain.ErrUserNotFound)
 	})
 }
 
-func (s *CommentUsecaseTestSuite) TestGetCommentsForBlog() {
+func (s *CommentUsecaseTestSuite) TestCreateComment_PreModeration() {
 	ctx := context.Background()
-	blogID := "blog-123"
-	page, limit := int64(1), int64(10)
+	userID := "user-123"
+	blogID := "blog-abc"
+	newUsecase := func() domain.ICommentUsecase {
+		return NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, s.mockUserRepo, s.mockEmailSvc, 2*time.Second, WithCommentPreModeration(true))
+	}
 
-	s.Run("Success", func() {
+	s.Run("Success - Held as pending without counting or notifying", func() {
 		s.SetupTest()
-		// Arrange
-		mockComments := []*domain.Comment{{ID: "c1"}, {ID: "c2"}}
-		mockTotal := int64(2)
-		s.mockCommentRepo.On("FetchByBlogID", mock.Anything, blogID, page, limit).Return(mockComments, mockTotal, nil).Once()
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		s.mockCommentRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.Comment) bool {
+			return c.Pending
+		})).Return(nil).Once()
 
-		// Act
-		comments, total, err := s.usecase.GetCommentsForBlog(ctx, blogID, page, limit)
+		comment, err := newUsecase().CreateComment(ctx, userID, blogID, "Thanks @bob", nil, domain.RoleUser)
+
+		s.Require().NoError(err)
+		s.True(comment.Pending)
+		s.mockCommentRepo.AssertExpectations(s.T())
+		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementCommentCount", mock.Anything, mock.Anything, mock.Anything)
+		s.mockUserRepo.AssertNotCalled(s.T(), "GetByUsernames", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure - Reply to a pending comment", func() {
+		s.SetupTest()
+		parentID := "parent-xyz"
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		s.mockCommentRepo.On("GetByID", mock.Anything, parentID).Return(&domain.Comment{ID: parentID, Pending: true}, nil).Once()
+
+		comment, err := newUsecase().CreateComment(ctx, userID, blogID, "Hello", &parentID, domain.RoleUser)
+
+		s.ErrorIs(err, ErrNotFound)
+		s.Nil(comment)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Success - Withdrawing a pending comment removes it", func() {
+		s.SetupTest()
+		commentID := "comment-abc"
+		pending := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID, Pending: true}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(pending, nil).Once()
+		s.mockCommentRepo.On("DeletePending", mock.Anything, commentID).Return(nil).Once()
+
+		err := newUsecase().DeleteComment(ctx, userID, commentID, domain.RoleUser)
 
-		// Assert
 		s.NoError(err)
-		s.Equal(mockTotal, total)
-		s.Equal(mockComments, comments)
 		s.mockCommentRepo.AssertExpectations(s.T())
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Anonymize", mock.Anything, mock.Anything)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementCommentCount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure - Thread path of a pending comment", func() {
+		s.SetupTest()
+		s.mockCommentRepo.On("GetByID", mock.Anything, "comment-abc").Return(&domain.Comment{ID: "comment-abc", Pending: true}, nil).Once()
+
+		path, err := newUsecase().GetThreadPath(ctx, "comment-abc")
+
+		s.ErrorIs(err, ErrNotFound)
+		s.Nil(path)
 	})
 }
 
-func (s *CommentUsecaseTestSuite) TestGetRepliesForComment() {
+func (s *CommentUsecaseTestSuite) TestCreateComment_SimilarityWindow() {
 	ctx := context.Background()
-	parentID := "parent-comment-123"
-	page, limit := int64(1), int64(10)
+	userID := "user-123"
+	blogID, otherBlogID := "blog-abc", "blog-def"
 
-	s.Run("Success", func() {
+	// expectCreate sets up one successful create on blogID and returns a wait for its counter goroutine.
+	expectCreate := func(blogID string) *sync.WaitGroup {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		s.mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, 1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+		return &wg
+	}
+
+	s.Run("Failure - Near-duplicate on another blog within the window", func() {
 		s.SetupTest()
-		// Arrange
-		mockReplies := []*domain.Comment{{ID: "r1"}, {ID: "r2"}}
-		mockTotal := int64(2)
-		s.mockCommentRepo.On("FetchReplies", mock.Anything, parentID, page, limit).Return(mockReplies, mockTotal, nil).Once()
+		cache := newFakeRedisCache()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithCommentSimilarityWindow(cache, time.Minute, 0))
 
-		// Act
-		comments, total, err := s.usecase.GetRepliesForComment(ctx, parentID, page, limit)
+		wg := expectCreate(blogID)
+		_, err := usecase.CreateComment(ctx, userID, blogID, "Great post, visit my site!", nil, domain.RoleUser)
+		s.Require().NoError(err)
+		wg.Wait()
 
-		// Assert
+		s.mockBlogRepo.On("GetByID", mock.Anything, otherBlogID).Return(&domain.Blog{ID: otherBlogID}, nil).Once()
+		comment, err := usecase.CreateComment(ctx, userID, otherBlogID, "GREAT post... visit my site 2", nil, domain.RoleUser)
+
+		s.ErrorIs(err, ErrConflict)
+		s.Nil(comment)
+		s.mockCommentRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	})
+
+	s.Run("Success - Distinct content", func() {
+		s.SetupTest()
+		cache := newFakeRedisCache()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithCommentSimilarityWindow(cache, time.Minute, 0))
+
+		wg := expectCreate(blogID)
+		_, err := usecase.CreateComment(ctx, userID, blogID, "Great post, visit my site!", nil, domain.RoleUser)
+		s.Require().NoError(err)
+		wg.Wait()
+
+		wg = expectCreate(blogID)
+		_, err = usecase.CreateComment(ctx, userID, blogID, "On second thought, the conclusion is off.", nil, domain.RoleUser)
 		s.NoError(err)
-		s.Equal(mockTotal, total)
-		s.Equal(mockReplies, comments)
-		s.mockCommentRepo.AssertExpectations(s.T())
+		wg.Wait()
+	})
+
+	s.Run("Success - Another user may say the same", func() {
+		s.SetupTest()
+		cache := newFakeRedisCache()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithCommentSimilarityWindow(cache, time.Minute, 0))
+
+		wg := expectCreate(blogID)
+		_, err := usecase.CreateComment(ctx, userID, blogID, "Thanks!", nil, domain.RoleUser)
+		s.Require().NoError(err)
+		wg.Wait()
+
+		wg = expectCreate(blogID)
+		_, err = usecase.CreateComment(ctx, "user-456", blogID, "Thanks!", nil, domain.RoleUser)
+		s.NoError(err)
+		wg.Wait()
+	})
+
+	s.Run("Sensitivity - Only the first words are compared", func() {
+		s.SetupTest()
+		cache := newFakeRedisCache()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithCommentSimilarityWindow(cache, time.Minute, 3))
+
+		wg := expectCreate(blogID)
+		_, err := usecase.CreateComment(ctx, userID, blogID, "Check out cheapwatches dot com", nil, domain.RoleUser)
+		s.Require().NoError(err)
+		wg.Wait()
+
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		_, err = usecase.CreateComment(ctx, userID, blogID, "Check out cheapwatches, now with free shipping", nil, domain.RoleUser)
+		s.ErrorIs(err, ErrConflict)
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestCreateComment_Cooldown() {
+	ctx := context.Background()
+	userID := "user-123"
+	blogID := "blog-abc"
+
+	// expectCreate sets up one successful create and returns a wait for its counter goroutine.
+	expectCreate := func() *sync.WaitGroup {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		s.mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, 1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+		return &wg
+	}
+
+	s.Run("Failure - Second comment during cooldown", func() {
+		s.SetupTest()
+		cache := newFakeRedisCache()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithCommentCooldown(cache, 30*time.Second))
+
+		wg := expectCreate()
+		_, err := usecase.CreateComment(ctx, userID, blogID, "first", nil, domain.RoleUser)
+		s.Require().NoError(err)
+		wg.Wait()
+
+		cache.advance(10 * time.Second)
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		comment, err := usecase.CreateComment(ctx, userID, blogID, "second", nil, domain.RoleUser)
+
+		s.ErrorIs(err, domain.ErrTooManyRequests)
+		s.Nil(comment)
+		s.mockCommentRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	})
+
+	s.Run("Success - Comment after cooldown expires", func() {
+		s.SetupTest()
+		cache := newFakeRedisCache()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithCommentCooldown(cache, 30*time.Second))
+
+		wg := expectCreate()
+		_, err := usecase.CreateComment(ctx, userID, blogID, "first", nil, domain.RoleUser)
+		s.Require().NoError(err)
+		wg.Wait()
+
+		cache.advance(30 * time.Second)
+		wg = expectCreate()
+		comment, err := usecase.CreateComment(ctx, userID, blogID, "second", nil, domain.RoleUser)
+
+		s.NoError(err)
+		s.NotNil(comment)
+		wg.Wait()
+	})
+
+	s.Run("Success - Cooldown is per user", func() {
+		s.SetupTest()
+		cache := newFakeRedisCache()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithCommentCooldown(cache, 30*time.Second))
+
+		wg := expectCreate()
+		_, err := usecase.CreateComment(ctx, userID, blogID, "first", nil, domain.RoleUser)
+		s.Require().NoError(err)
+		wg.Wait()
+
+		wg = expectCreate()
+		_, err = usecase.CreateComment(ctx, "someone-else", blogID, "hello", nil, domain.RoleUser)
+		s.NoError(err)
+		wg.Wait()
+	})
+
+	s.Run("Success - Rejected comment does not start the cooldown", func() {
+		s.SetupTest()
+		cache := newFakeRedisCache()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithCommentCooldown(cache, 30*time.Second))
+
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		_, err := usecase.CreateComment(ctx, userID, blogID, "   ", nil, domain.RoleUser)
+		s.Require().ErrorIs(err, domain.ErrValidation)
+
+		wg := expectCreate()
+		_, err = usecase.CreateComment(ctx, userID, blogID, "a real comment", nil, domain.RoleUser)
+		s.NoError(err)
+		wg.Wait()
+	})
+
+	s.Run("Success - Zero interval disables the cooldown", func() {
+		s.SetupTest()
+		cache := newFakeRedisCache()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second, WithCommentCooldown(cache, 0))
+
+		for _, content := range []string{"first", "second"} {
+			wg := expectCreate()
+			_, err := usecase.CreateComment(ctx, userID, blogID, content, nil, domain.RoleUser)
+			s.NoError(err)
+			wg.Wait()
+		}
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestCreateComment_Mentions() {
+	ctx := context.Background()
+	userID := "user-123"
+	blogID := "blog-abc"
+
+	s.Run("Only valid, non-self users are notified", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(2) // The blog counter and the one valid mention
+
+		author := &domain.User{ID: userID, Username: "author", IsActive: true}
+		bob := &domain.User{ID: "user-bob", Username: "bob", Email: "bob@test.com", IsActive: true}
+		dormant := &domain.User{ID: "user-dormant", Username: "dormant", Email: "dormant@test.com", IsActive: false}
+		content := "Thanks @author, @bob and @dormant! Also @ghost and @bob again, mail me at me@example.com"
+
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{}, nil).Once()
+		s.mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, 1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+		// The unknown @ghost is simply missing from the batch result.
+		s.mockUserRepo.On("GetByUsernames", mock.Anything, []string{"author", "bob", "dormant", "ghost"}).
+			Return([]*domain.User{author, bob, dormant}, nil).Once()
+		s.mockUserRepo.On("GetByID", mock.Anything, userID).Return(author, nil).Once()
+		s.mockEmailSvc.On("SendMentionEmail", bob.Email, bob.Username, author.Username, blogID).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		_, err := s.usecase.CreateComment(ctx, userID, blogID, content, nil, domain.RoleUser)
+
+		s.NoError(err)
+		wg.Wait()
+		s.mockUserRepo.AssertExpectations(s.T())
+		s.mockEmailSvc.AssertExpectations(s.T())
+		s.mockEmailSvc.AssertNumberOfCalls(s.T(), "SendMentionEmail", 1)
+	})
+
+	s.Run("No mentions skips the lookup", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{}, nil).Once()
+		s.mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, 1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		_, err := s.usecase.CreateComment(ctx, userID, blogID, "No one to notify, email me@example.com", nil, domain.RoleUser)
+
+		s.NoError(err)
+		wg.Wait()
+		s.mockUserRepo.AssertNotCalled(s.T(), "GetByUsernames", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestProfanityFilter() {
+	ctx := context.Background()
+	userID := "user-123"
+	blogID := "blog-abc"
+
+	s.Run("Create - Rejected", func() {
+		s.SetupTest()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second,
+			WithCommentProfanityFilter(domain.NewProfanityFilter([]string{"darn"}, domain.ProfanityModeReject)))
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+
+		comment, err := usecase.CreateComment(ctx, userID, blogID, "Oh darn", nil, domain.RoleUser)
+
+		s.ErrorIs(err, domain.ErrContentRejected)
+		s.Nil(comment)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Create")
+	})
+
+	s.Run("Update - Masked", func() {
+		s.SetupTest()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second,
+			WithCommentProfanityFilter(domain.NewProfanityFilter([]string{"darn"}, domain.ProfanityModeMask)))
+		existing := &domain.Comment{ID: "comment-abc", AuthorID: &userID, Content: "Original"}
+		s.mockCommentRepo.On("GetByID", mock.Anything, existing.ID).Return(existing, nil).Once()
+		s.mockCommentRepo.On("Update", mock.Anything, existing).Return(nil).Once()
+
+		comment, err := usecase.UpdateComment(ctx, userID, existing.ID, "Oh darn")
+
+		s.Require().NoError(err)
+		s.Equal("Oh ****", comment.Content)
+		s.mockCommentRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestUpdateComment() {
+	ctx := context.Background()
+	userID := "user-123"
+	commentID := "comment-abc"
+	newContent := "This content has been updated."
+
+	s.Run("Success - As Owner", func() {
+		s.SetupTest()
+		// Arrange
+		mockComment := &domain.Comment{ID: commentID, AuthorID: &userID, Content: "Original"}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		s.mockCommentRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Comment")).
+			// We can assert that the content was updated before the repo call
+			Run(func(args mock.Arguments) {
+				commentArg := args.Get(1).(*domain.Comment)
+				s.Equal(newContent, commentArg.Content)
+			}).
+			Return(nil).Once()
+
+		// Act
+		updatedComment, err := s.usecase.UpdateComment(ctx, userID, commentID, newContent)
+
+		// Assert
+		s.NoError(err)
+		s.NotNil(updatedComment)
+		s.Equal(newContent, updatedComment.Content)
+		s.mockCommentRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure - Not the owner", func() {
+		s.SetupTest()
+		// Arrange
+		otherUserID := "user-456"
+		mockComment := &domain.Comment{ID: commentID, AuthorID: &otherUserID, Content: "Original"}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+
+		// Act
+		updatedComment, err := s.usecase.UpdateComment(ctx, userID, commentID, newContent)
+
+		// Assert
+		s.Error(err)
+		s.ErrorIs(err, domain.ErrPermissionDenied)
+		s.Nil(updatedComment)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Update")
+	})
+
+	s.Run("Failure - Comment is anonymized (deleted)", func() {
+		s.SetupTest()
+		// Arrange: A comment with a nil AuthorID cannot be updated.
+		mockComment := &domain.Comment{ID: commentID, AuthorID: nil, Content: "[deleted]"}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+
+		// Act
+		_, err := s.usecase.UpdateComment(ctx, userID, commentID, newContent)
+
+		// Assert
+		s.Error(err)
+		s.ErrorIs(err, domain.ErrPermissionDenied)
+	})
+
+	s.Run("Failure - Content too long", func() {
+		s.SetupTest()
+		mockComment := &domain.Comment{ID: commentID, AuthorID: &userID, Content: "Original"}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+
+		tooLong := strings.Repeat("a", domain.DefaultCommentLengthLimits.Max+1)
+		_, err := s.usecase.UpdateComment(ctx, userID, commentID, tooLong)
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.Equal("Original", mockComment.Content, "A rejected update must leave the comment untouched")
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Update")
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestDeleteComment() {
+	ctx := context.Background()
+	userID := "user-123"
+	commentID := "comment-abc"
+	blogID := "blog-xyz"
+
+	s.Run("Success - As Owner", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(1) // For the blog counter decrement
+
+		// Arrange
+		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		s.mockCommentRepo.On("Anonymize", mock.Anything, commentID).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, -1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		// Act
+		err := s.usecase.DeleteComment(ctx, userID, commentID, domain.RoleUser)
+
+		// Assert
+		s.NoError(err)
+		wg.Wait()
+		s.mockCommentRepo.AssertExpectations(s.T())
+		s.mockBlogRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure - Not the owner", func() {
+		s.SetupTest()
+		otherUserID := "user-456"
+		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &otherUserID}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+
+		// Act
+		err := s.usecase.DeleteComment(ctx, userID, commentID, domain.RoleUser)
+
+		// Assert
+		s.Error(err)
+		s.ErrorIs(err, domain.ErrPermissionDenied)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Anonymize")
+		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementCommentCount")
+	})
+
+	s.Run("Failure - Admin deletes an already deleted comment", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		// Arrange: the first delete anonymizes the comment, so the second loads it without an author.
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(&domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID}, nil).Once()
+		s.mockCommentRepo.On("Anonymize", mock.Anything, commentID).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, -1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(&domain.Comment{ID: commentID, BlogID: blogID, Content: "[deleted]"}, nil).Once()
+
+		// Act
+		firstErr := s.usecase.DeleteComment(ctx, "admin-1", commentID, domain.RoleAdmin)
+		wg.Wait()
+		secondErr := s.usecase.DeleteComment(ctx, "admin-1", commentID, domain.RoleAdmin)
+
+		// Assert
+		s.NoError(firstErr)
+		s.ErrorIs(secondErr, ErrNotFound)
+		s.mockCommentRepo.AssertNumberOfCalls(s.T(), "Anonymize", 1)
+		s.mockBlogRepo.AssertNumberOfCalls(s.T(), "IncrementCommentCount", 1)
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestDeleteComment_HardDeletePolicy() {
+	ctx := context.Background()
+	userID := "user-123"
+	commentID := "comment-abc"
+	blogID := "blog-xyz"
+	parentID := "parent-1"
+	newUsecase := func() domain.ICommentUsecase {
+		return NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, s.mockUserRepo, s.mockEmailSvc, 2*time.Second,
+			WithCommentDeletePolicy(domain.CommentDeleteHard))
+	}
+
+	s.Run("No Replies - Hard Deletes And Decrements Counts", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(2) // For the blog and parent counter decrements
+
+		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID, ParentID: &parentID}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		s.mockCommentRepo.On("DeleteIfNoReplies", mock.Anything, commentID).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, -1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+		s.mockCommentRepo.On("IncrementReplyCount", mock.Anything, parentID, -1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		err := newUsecase().DeleteComment(ctx, userID, commentID, domain.RoleUser)
+
+		s.NoError(err)
+		wg.Wait()
+		s.mockCommentRepo.AssertExpectations(s.T())
+		s.mockBlogRepo.AssertExpectations(s.T())
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Anonymize", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Has Replies - Anonymizes", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID, ReplyCount: 2}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		s.mockCommentRepo.On("Anonymize", mock.Anything, commentID).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, -1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		err := newUsecase().DeleteComment(ctx, userID, commentID, domain.RoleUser)
+
+		s.NoError(err)
+		wg.Wait()
+		s.mockCommentRepo.AssertExpectations(s.T())
+		s.mockCommentRepo.AssertNotCalled(s.T(), "DeleteIfNoReplies", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Reply Arrived Meanwhile - Anonymizes Reply Without Touching Parent", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID, ParentID: &parentID}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		s.mockCommentRepo.On("DeleteIfNoReplies", mock.Anything, commentID).Return(ErrNotFound).Once()
+		s.mockCommentRepo.On("Anonymize", mock.Anything, commentID).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, -1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		err := newUsecase().DeleteComment(ctx, userID, commentID, domain.RoleUser)
+
+		s.NoError(err)
+		wg.Wait()
+		s.mockCommentRepo.AssertExpectations(s.T())
+		s.mockCommentRepo.AssertNotCalled(s.T(), "IncrementReplyCount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Anonymize Policy - Never Hard Deletes", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		s.mockCommentRepo.On("Anonymize", mock.Anything, commentID).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, -1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		err := s.usecase.DeleteComment(ctx, userID, commentID, domain.RoleUser)
+
+		s.NoError(err)
+		wg.Wait()
+		s.mockCommentRepo.AssertNotCalled(s.T(), "DeleteIfNoReplies", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestDeleteComment_DeleteWindow() {
+	ctx := context.Background()
+	userID := "user-123"
+	commentID := "comment-abc"
+	blogID := "blog-xyz"
+	newUsecase := func() domain.ICommentUsecase {
+		return NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, s.mockUserRepo, s.mockEmailSvc, 2*time.Second,
+			WithCommentDeleteWindow(15*time.Minute))
+	}
+
+	s.Run("Within The Window - Author Deletes", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID, CreatedAt: time.Now().Add(-5 * time.Minute)}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		s.mockCommentRepo.On("Anonymize", mock.Anything, commentID).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, -1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		err := newUsecase().DeleteComment(ctx, userID, commentID, domain.RoleUser)
+
+		s.NoError(err)
+		wg.Wait()
+		s.mockCommentRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Past The Window - Author Is Refused", func() {
+		s.SetupTest()
+		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID, CreatedAt: time.Now().Add(-time.Hour)}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+
+		err := newUsecase().DeleteComment(ctx, userID, commentID, domain.RoleUser)
+
+		s.ErrorIs(err, domain.ErrDeleteWindowExpired)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Anonymize", mock.Anything, mock.Anything)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementCommentCount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Past The Window - Admin Deletes Anyone's Comment", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID, CreatedAt: time.Now().Add(-time.Hour)}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		s.mockCommentRepo.On("Anonymize", mock.Anything, commentID).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, -1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		err := newUsecase().DeleteComment(ctx, "admin-1", commentID, domain.RoleAdmin)
+
+		s.NoError(err)
+		wg.Wait()
+		s.mockCommentRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Past The Window - Pending Comment Can Still Be Withdrawn", func() {
+		s.SetupTest()
+		pending := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID, Pending: true, CreatedAt: time.Now().Add(-time.Hour)}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(pending, nil).Once()
+		s.mockCommentRepo.On("DeletePending", mock.Anything, commentID).Return(nil).Once()
+
+		err := newUsecase().DeleteComment(ctx, userID, commentID, domain.RoleUser)
+
+		s.NoError(err)
+		s.mockCommentRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("No Window - Old Comments Stay Deletable", func() {
+		s.SetupTest()
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		mockComment := &domain.Comment{ID: commentID, BlogID: blogID, AuthorID: &userID, CreatedAt: time.Now().Add(-24 * time.Hour)}
+		s.mockCommentRepo.On("GetByID", mock.Anything, commentID).Return(mockComment, nil).Once()
+		s.mockCommentRepo.On("Anonymize", mock.Anything, commentID).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, -1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		err := s.usecase.DeleteComment(ctx, userID, commentID, domain.RoleUser)
+
+		s.NoError(err)
+		wg.Wait()
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestGetCommentsForBlog() {
+	ctx := context.Background()
+	blogID := "blog-123"
+	page, limit := int64(1), int64(10)
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		// Arrange
+		mockComments := []*domain.Comment{{ID: "c1"}, {ID: "c2"}}
+		mockTotal := int64(2)
+		s.mockCommentRepo.On("FetchByBlogID", mock.Anything, blogID, page, limit, domain.CommentSortNewest).Return(mockComments, mockTotal, nil).Once()
+
+		// Act
+		comments, total, err := s.usecase.GetCommentsForBlog(ctx, blogID, page, limit, domain.CommentSortNewest)
+
+		// Assert
+		s.NoError(err)
+		s.Equal(mockTotal, total)
+		s.Equal(mockComments, comments)
+		s.mockCommentRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Success - Empty Sort Defaults To Oldest", func() {
+		s.SetupTest()
+		s.mockCommentRepo.On("FetchByBlogID", mock.Anything, blogID, page, limit, domain.CommentSortOldest).Return([]*domain.Comment{}, int64(0), nil).Once()
+
+		_, _, err := s.usecase.GetCommentsForBlog(ctx, blogID, page, limit, "")
+
+		s.NoError(err)
+		s.mockCommentRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure - Unknown Sort", func() {
+		s.SetupTest()
+
+		_, _, err := s.usecase.GetCommentsForBlog(ctx, blogID, page, limit, "loudest")
+
+		s.ErrorIs(err, domain.ErrValidation)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "FetchByBlogID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestGetCommentsForBlog_EmbedsReplies() {
+	ctx := context.Background()
+	blogID := "blog-123"
+	page, limit := int64(1), int64(10)
+	newUsecase := func() domain.ICommentUsecase {
+		return NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, s.mockUserRepo, s.mockEmailSvc, 2*time.Second, WithEmbeddedReplies(2))
+	}
+
+	s.Run("Success - Embeds The First Replies Of Each Thread", func() {
+		s.SetupTest()
+		comments := []*domain.Comment{{ID: "c1", ReplyCount: 3}, {ID: "c2", ReplyCount: 1}, {ID: "c3"}}
+		s.mockCommentRepo.On("FetchByBlogID", mock.Anything, blogID, page, limit, domain.CommentSortOldest).Return(comments, int64(3), nil).Once()
+		s.mockCommentRepo.On("FetchReplies", mock.Anything, "c1", int64(1), int64(2)).Return([]*domain.Comment{{ID: "r1"}, {ID: "r2"}}, int64(3), nil).Once()
+		s.mockCommentRepo.On("FetchReplies", mock.Anything, "c2", int64(1), int64(2)).Return([]*domain.Comment{{ID: "r3"}}, int64(1), nil).Once()
+
+		result, _, err := newUsecase().GetCommentsForBlog(ctx, blogID, page, limit, domain.CommentSortOldest)
+
+		s.Require().NoError(err)
+		s.Len(result[0].Replies, 2)
+		s.True(result[0].HasMoreReplies())
+		s.Len(result[1].Replies, 1)
+		s.False(result[1].HasMoreReplies())
+		s.Empty(result[2].Replies)
+		s.False(result[2].HasMoreReplies())
+		s.mockCommentRepo.AssertExpectations(s.T())
+		// Comments without replies don't cost a query.
+		s.mockCommentRepo.AssertNotCalled(s.T(), "FetchReplies", mock.Anything, "c3", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Success - A Failed Reply Fetch Leaves The Thread Unexpanded", func() {
+		s.SetupTest()
+		comments := []*domain.Comment{{ID: "c1", ReplyCount: 2}}
+		s.mockCommentRepo.On("FetchByBlogID", mock.Anything, blogID, page, limit, domain.CommentSortOldest).Return(comments, int64(1), nil).Once()
+		s.mockCommentRepo.On("FetchReplies", mock.Anything, "c1", int64(1), int64(2)).Return([]*domain.Comment(nil), int64(0), errors.New("db down")).Once()
+
+		result, _, err := newUsecase().GetCommentsForBlog(ctx, blogID, page, limit, domain.CommentSortOldest)
+
+		s.Require().NoError(err)
+		s.Empty(result[0].Replies)
+		s.True(result[0].HasMoreReplies())
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestGetRepliesForComment() {
+	ctx := context.Background()
+	parentID := "parent-comment-123"
+	page, limit := int64(1), int64(10)
+
+	s.Run("Success", func() {
+		s.SetupTest()
+		// Arrange
+		mockReplies := []*domain.Comment{{ID: "r1"}, {ID: "r2"}}
+		mockTotal := int64(2)
+		s.mockCommentRepo.On("FetchReplies", mock.Anything, parentID, page, limit).Return(mockReplies, mockTotal, nil).Once()
+
+		// Act
+		comments, total, err := s.usecase.GetRepliesForComment(ctx, parentID, page, limit)
+
+		// Assert
+		s.NoError(err)
+		s.Equal(mockTotal, total)
+		s.Equal(mockReplies, comments)
+		s.mockCommentRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestGetThreadPath() {
+	ctx := context.Background()
+	strPtr := func(v string) *string { return &v }
+
+	s.Run("Success - Multi-level chain is returned root first", func() {
+		s.SetupTest()
+		// Arrange
+		root := &domain.Comment{ID: "root"}
+		middle := &domain.Comment{ID: "middle", ParentID: strPtr("root")}
+		leaf := &domain.Comment{ID: "leaf", ParentID: strPtr("middle")}
+		s.mockCommentRepo.On("GetByID", mock.Anything, "leaf").Return(leaf, nil).Once()
+		s.mockCommentRepo.On("GetByID", mock.Anything, "middle").Return(middle, nil).Once()
+		s.mockCommentRepo.On("GetByID", mock.Anything, "root").Return(root, nil).Once()
+
+		// Act
+		path, err := s.usecase.GetThreadPath(ctx, "leaf")
+
+		// Assert
+		s.NoError(err)
+		s.Equal([]*domain.Comment{root, middle}, path)
+		s.mockCommentRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Success - Top-level comment has an empty path", func() {
+		s.SetupTest()
+		// Arrange
+		s.mockCommentRepo.On("GetByID", mock.Anything, "root").Return(&domain.Comment{ID: "root"}, nil).Once()
+
+		// Act
+		path, err := s.usecase.GetThreadPath(ctx, "root")
+
+		// Assert
+		s.NoError(err)
+		s.NotNil(path)
+		s.Empty(path)
+	})
+
+	s.Run("Failure - Comment not found", func() {
+		s.SetupTest()
+		// Arrange
+		s.mockCommentRepo.On("GetByID", mock.Anything, "missing").Return(nil, ErrNotFound).Once()
+
+		// Act
+		_, err := s.usecase.GetThreadPath(ctx, "missing")
+
+		// Assert
+		s.ErrorIs(err, ErrNotFound)
+	})
+
+	s.Run("Failure - Parent cycle stops the walk", func() {
+		s.SetupTest()
+		// Arrange
+		a := &domain.Comment{ID: "a", ParentID: strPtr("b")}
+		b := &domain.Comment{ID: "b", ParentID: strPtr("a")}
+		s.mockCommentRepo.On("GetByID", mock.Anything, "a").Return(a, nil).Once()
+		s.mockCommentRepo.On("GetByID", mock.Anything, "b").Return(b, nil).Once()
+
+		// Act
+		_, err := s.usecase.GetThreadPath(ctx, "a")
+
+		// Assert
+		s.ErrorIs(err, ErrInternal)
+		s.mockCommentRepo.AssertExpectations(s.T())
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestSearchCommentsInBlog() {
+	ctx := context.Background()
+
+	s.Run("Success - Query is trimmed and passed through", func() {
+		s.SetupTest()
+		// Arrange
+		matches := []*domain.Comment{{ID: "c1", Content: "Goroutines are great"}}
+		s.mockCommentRepo.On("SearchInBlog", mock.Anything, "blog-1", "goroutines", int64(1), int64(10)).Return(matches, int64(1), nil).Once()
+
+		// Act
+		comments, total, err := s.usecase.SearchCommentsInBlog(ctx, "blog-1", "  goroutines ", 1, 10)
+
+		// Assert
+		s.NoError(err)
+		s.Equal(int64(1), total)
+		s.Equal(matches, comments)
+		s.mockCommentRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure - Blank query", func() {
+		s.SetupTest()
+
+		// Act
+		_, _, err := s.usecase.SearchCommentsInBlog(ctx, "blog-1", "   ", 1, 10)
+
+		// Assert
+		s.ErrorIs(err, domain.ErrValidation)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "SearchInBlog", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CommentUsecaseTestSuite) TestCreateComment_ToxicityThreshold() {
+	ctx := context.Background()
+	userID, blogID := "user-123", "blog-abc"
+
+	s.Run("Success - Below the threshold is published", func() {
+		s.SetupTest()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second,
+			WithCommentToxicityThreshold(stubContentScorer{score: 0.2}, 0.7, domain.ToxicityActionQueue))
+		var wg sync.WaitGroup
+		wg.Add(1)
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		s.mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Comment")).Return(nil).Once()
+		s.mockBlogRepo.On("IncrementCommentCount", mock.Anything, blogID, 1).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		comment, err := usecase.CreateComment(ctx, userID, blogID, "Nice write-up.", nil, domain.RoleUser)
+
+		s.Require().NoError(err)
+		s.False(comment.Pending)
+		wg.Wait()
+	})
+
+	s.Run("Success - Above the threshold is queued", func() {
+		s.SetupTest()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second,
+			WithCommentToxicityThreshold(stubContentScorer{score: 0.95}, 0.7, domain.ToxicityActionQueue))
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+		s.mockCommentRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.Comment) bool { return c.Pending })).Return(nil).Once()
+
+		comment, err := usecase.CreateComment(ctx, userID, blogID, "You are all idiots.", nil, domain.RoleUser)
+
+		s.Require().NoError(err)
+		s.True(comment.Pending)
+		s.mockBlogRepo.AssertNotCalled(s.T(), "IncrementCommentCount", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure - Above the threshold is rejected", func() {
+		s.SetupTest()
+		usecase := NewCommentUsecase(s.mockBlogRepo, s.mockCommentRepo, nil, nil, 2*time.Second,
+			WithCommentToxicityThreshold(stubContentScorer{score: 0.95}, 0.7, domain.ToxicityActionReject))
+		s.mockBlogRepo.On("GetByID", mock.Anything, blogID).Return(&domain.Blog{ID: blogID}, nil).Once()
+
+		comment, err := usecase.CreateComment(ctx, userID, blogID, "You are all idiots.", nil, domain.RoleUser)
+
+		s.ErrorIs(err, domain.ErrContentRejected)
+		s.Nil(comment)
+		s.mockCommentRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
 	})
 }
@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+// followUsecase implements the domain.IFollowUsecase interface.
+type followUsecase struct {
+	followRepo     domain.IFollowRepository
+	userRepo       UserRepository
+	contextTimeout time.Duration
+
+	// Used to tell users they gained a follower. Nil disables the notification.
+	emailService infrastructure.EmailService
+}
+
+// FollowUsecaseOption configures optional behaviour of the follow usecase.
+type FollowUsecaseOption func(*followUsecase)
+
+// WithFollowNotifications emails users when someone new starts following them.
+func WithFollowNotifications(emailService infrastructure.EmailService) FollowUsecaseOption {
+	return func(fu *followUsecase) {
+		fu.emailService = emailService
+	}
+}
+
+func NewFollowUsecase(followRepository domain.IFollowRepository, userRepository UserRepository, timeout time.Duration, opts ...FollowUsecaseOption) domain.IFollowUsecase {
+	fu := &followUsecase{
+		followRepo:     followRepository,
+		userRepo:       userRepository,
+		contextTimeout: timeout,
+	}
+	for _, opt := range opts {
+		opt(fu)
+	}
+	return fu
+}
+
+// Follow makes followerID follow followeeID. Following someone already followed is a no-op.
+func (fu *followUsecase) Follow(ctx context.Context, followerID, followeeID string) error {
+	if followerID == followeeID {
+		return domain.ErrCannotFollowSelf
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fu.contextTimeout)
+	defer cancel()
+
+	followee, err := fu.getUser(ctx, followeeID)
+	if err != nil {
+		return err
+	}
+	created, err := fu.followRepo.Follow(ctx, followerID, followeeID)
+	if err != nil {
+		return err
+	}
+	// Decided here rather than in the goroutine, so a follow that notifies no one starts none.
+	if created && fu.emailService != nil && followee.IsActive {
+		go fu.notifyNewFollower(followerID, followee)
+	}
+	return nil
+}
+
+// Unfollow stops followerID following followeeID. Unfollowing someone not followed is a no-op.
+func (fu *followUsecase) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	if followerID == followeeID {
+		return domain.ErrCannotFollowSelf
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fu.contextTimeout)
+	defer cancel()
+
+	_, err := fu.followRepo.Unfollow(ctx, followerID, followeeID)
+	return err
+}
+
+func (fu *followUsecase) ListFollowers(ctx context.Context, userID string, page, limit int64) ([]*domain.Follow, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, fu.contextTimeout)
+	defer cancel()
+
+	if _, err := fu.getUser(ctx, userID); err != nil {
+		return nil, 0, err
+	}
+	return fu.followRepo.ListFollowers(ctx, userID, page, limit)
+}
+
+func (fu *followUsecase) ListFollowing(ctx context.Context, userID string, page, limit int64) ([]*domain.Follow, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, fu.contextTimeout)
+	defer cancel()
+
+	if _, err := fu.getUser(ctx, userID); err != nil {
+		return nil, 0, err
+	}
+	return fu.followRepo.ListFollowing(ctx, userID, page, limit)
+}
+
+func (fu *followUsecase) getUser(ctx context.Context, userID string) (*domain.User, error) {
+	user, err := fu.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, ErrNotFound) || (err == nil && user == nil) {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, err
+}
+
+// notifyNewFollower emails the followee that they have a new follower. Follow only calls it for active accounts.
+// It runs in the background, so failures are only logged.
+func (fu *followUsecase) notifyNewFollower(followerID string, followee *domain.User) {
+	ctx, cancel := context.WithTimeout(context.Background(), fu.contextTimeout)
+	defer cancel()
+
+	followedBy := "Someone"
+	if follower, err := fu.userRepo.GetByID(ctx, followerID); err == nil && follower != nil {
+		followedBy = follower.Username
+	}
+	if err := fu.emailService.SendNewFollowerEmail(followee.Email, followee.Username, followedBy, followerID); err != nil {
+		log.Printf("non-critical error: failed to notify user %s of a new follower: %v", followee.ID, err)
+	}
+}
@@ -0,0 +1,211 @@
+package usecases_test
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- MOCK DEFINITIONS ---
+
+type MockFollowRepository struct{ mock.Mock }
+
+func (m *MockFollowRepository) Follow(ctx context.Context, followerID, followeeID string) (bool, error) {
+	args := m.Called(ctx, followerID, followeeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockFollowRepository) Unfollow(ctx context.Context, followerID, followeeID string) (bool, error) {
+	args := m.Called(ctx, followerID, followeeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockFollowRepository) GetCounts(ctx context.Context, userID string) (*domain.FollowCounts, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FollowCounts), args.Error(1)
+}
+func (m *MockFollowRepository) IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error) {
+	args := m.Called(ctx, followerID, followeeID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockFollowRepository) ListFollowers(ctx context.Context, userID string, page, limit int64) ([]*domain.Follow, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.Follow), args.Get(1).(int64), args.Error(2)
+}
+func (m *MockFollowRepository) ListFollowing(ctx context.Context, userID string, page, limit int64) ([]*domain.Follow, int64, error) {
+	args := m.Called(ctx, userID, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*domain.Follow), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockFollowRepository) ListFolloweeIDs(ctx context.Context, followerID string) ([]string, error) {
+	args := m.Called(ctx, followerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func setupFollowUsecase() (domain.IFollowUsecase, *MockFollowRepository, *MockUserRepository) {
+	followRepo := new(MockFollowRepository)
+	userRepo := new(MockUserRepository)
+	return usecases.NewFollowUsecase(followRepo, userRepo, 2*time.Second), followRepo, userRepo
+}
+
+func TestFollowUsecase_Follow(t *testing.T) {
+	t.Run("Cannot Follow Self", func(t *testing.T) {
+		uc, followRepo, userRepo := setupFollowUsecase()
+
+		err := uc.Follow(context.Background(), "user-1", "user-1")
+
+		assert.ErrorIs(t, err, domain.ErrCannotFollowSelf)
+		followRepo.AssertNotCalled(t, "Follow", mock.Anything, mock.Anything, mock.Anything)
+		userRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Followee Not Found", func(t *testing.T) {
+		uc, followRepo, userRepo := setupFollowUsecase()
+		userRepo.On("GetByID", mock.Anything, "missing").Return(nil, usecases.ErrNotFound).Once()
+
+		err := uc.Follow(context.Background(), "user-1", "missing")
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		followRepo.AssertNotCalled(t, "Follow", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Following Twice Is Idempotent", func(t *testing.T) {
+		uc, followRepo, userRepo := setupFollowUsecase()
+		userRepo.On("GetByID", mock.Anything, "user-2").Return(&domain.User{ID: "user-2"}, nil).Twice()
+		followRepo.On("Follow", mock.Anything, "user-1", "user-2").Return(true, nil).Once()
+		followRepo.On("Follow", mock.Anything, "user-1", "user-2").Return(false, nil).Once()
+
+		require.NoError(t, uc.Follow(context.Background(), "user-1", "user-2"))
+		require.NoError(t, uc.Follow(context.Background(), "user-1", "user-2"))
+
+		followRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+}
+
+func TestFollowUsecase_Follow_Notifications(t *testing.T) {
+	setup := func() (domain.IFollowUsecase, *MockFollowRepository, *MockUserRepository, *MockEmailService) {
+		followRepo := new(MockFollowRepository)
+		userRepo := new(MockUserRepository)
+		emailSvc := new(MockEmailService)
+		uc := usecases.NewFollowUsecase(followRepo, userRepo, 2*time.Second, usecases.WithFollowNotifications(emailSvc))
+		return uc, followRepo, userRepo, emailSvc
+	}
+	follower := &domain.User{ID: "user-1", Username: "alice", IsActive: true}
+	followee := &domain.User{ID: "user-2", Username: "bob", Email: "bob@test.com", IsActive: true}
+
+	t.Run("New Follower Notifies The Followee", func(t *testing.T) {
+		uc, followRepo, userRepo, emailSvc := setup()
+		var wg sync.WaitGroup
+		wg.Add(1)
+		userRepo.On("GetByID", mock.Anything, followee.ID).Return(followee, nil).Once()
+		userRepo.On("GetByID", mock.Anything, follower.ID).Return(follower, nil).Once()
+		followRepo.On("Follow", mock.Anything, follower.ID, followee.ID).Return(true, nil).Once()
+		emailSvc.On("SendNewFollowerEmail", followee.Email, followee.Username, follower.Username, follower.ID).
+			Run(func(args mock.Arguments) { wg.Done() }).Return(nil).Once()
+
+		require.NoError(t, uc.Follow(context.Background(), follower.ID, followee.ID))
+
+		wg.Wait()
+		emailSvc.AssertExpectations(t)
+	})
+
+	t.Run("Repeat Follow Does Not Notify", func(t *testing.T) {
+		uc, followRepo, userRepo, emailSvc := setup()
+		userRepo.On("GetByID", mock.Anything, followee.ID).Return(followee, nil).Once()
+		followRepo.On("Follow", mock.Anything, follower.ID, followee.ID).Return(false, nil).Once()
+
+		require.NoError(t, uc.Follow(context.Background(), follower.ID, followee.ID))
+
+		emailSvc.AssertNotCalled(t, "SendNewFollowerEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Inactive Followee Is Not Notified", func(t *testing.T) {
+		uc, followRepo, userRepo, emailSvc := setup()
+		dormant := &domain.User{ID: "user-3", Username: "dormant", Email: "dormant@test.com"}
+		userRepo.On("GetByID", mock.Anything, dormant.ID).Return(dormant, nil).Once()
+		followRepo.On("Follow", mock.Anything, follower.ID, dormant.ID).Return(true, nil).Once()
+
+		require.NoError(t, uc.Follow(context.Background(), follower.ID, dormant.ID))
+
+		// No notification is started for an inactive followee, so there is nothing to wait for.
+		userRepo.AssertNumberOfCalls(t, "GetByID", 1)
+		emailSvc.AssertNotCalled(t, "SendNewFollowerEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestFollowUsecase_Unfollow(t *testing.T) {
+	t.Run("Cannot Unfollow Self", func(t *testing.T) {
+		uc, followRepo, _ := setupFollowUsecase()
+
+		err := uc.Unfollow(context.Background(), "user-1", "user-1")
+
+		assert.ErrorIs(t, err, domain.ErrCannotFollowSelf)
+		followRepo.AssertNotCalled(t, "Unfollow", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		uc, followRepo, _ := setupFollowUsecase()
+		followRepo.On("Unfollow", mock.Anything, "user-1", "user-2").Return(true, nil).Once()
+
+		assert.NoError(t, uc.Unfollow(context.Background(), "user-1", "user-2"))
+		followRepo.AssertExpectations(t)
+	})
+}
+
+func TestFollowUsecase_ListFollowers(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		uc, followRepo, userRepo := setupFollowUsecase()
+		follows := []*domain.Follow{{FollowerID: "user-2", FolloweeID: "user-1", CreatedAt: time.Now()}}
+		userRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil).Once()
+		followRepo.On("ListFollowers", mock.Anything, "user-1", int64(1), int64(10)).Return(follows, int64(1), nil).Once()
+
+		result, total, err := uc.ListFollowers(context.Background(), "user-1", 1, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, follows, result)
+		assert.Equal(t, int64(1), total)
+		followRepo.AssertExpectations(t)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		uc, followRepo, userRepo := setupFollowUsecase()
+		userRepo.On("GetByID", mock.Anything, "missing").Return(nil, usecases.ErrNotFound).Once()
+
+		_, _, err := uc.ListFollowers(context.Background(), "missing", 1, 10)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		followRepo.AssertNotCalled(t, "ListFollowers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestFollowUsecase_ListFollowing(t *testing.T) {
+	uc, followRepo, userRepo := setupFollowUsecase()
+	follows := []*domain.Follow{{FollowerID: "user-1", FolloweeID: "user-3", CreatedAt: time.Now()}}
+	userRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil).Once()
+	followRepo.On("ListFollowing", mock.Anything, "user-1", int64(2), int64(5)).Return(follows, int64(6), nil).Once()
+
+	result, total, err := uc.ListFollowing(context.Background(), "user-1", 2, 5)
+
+	require.NoError(t, err)
+	assert.Equal(t, follows, result)
+	assert.Equal(t, int64(6), total)
+	followRepo.AssertExpectations(t)
+}
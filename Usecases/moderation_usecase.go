@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	"context"
+	"log"
+	"time"
+)
+
+// moderationUsecase implements the domain.IModerationUsecase interface.
+type moderationUsecase struct {
+	blogRepo       domain.IBlogRepository
+	commentRepo    domain.ICommentRepository
+	auditRepo      domain.IAuditRepository
+	contextTimeout time.Duration
+}
+
+// ModerationUsecaseOption configures optional behaviour of the moderation usecase.
+type ModerationUsecaseOption func(*moderationUsecase)
+
+// WithModerationAuditLog records every approval and rejection in the audit log.
+func WithModerationAuditLog(auditRepo domain.IAuditRepository) ModerationUsecaseOption {
+	return func(mu *moderationUsecase) {
+		mu.auditRepo = auditRepo
+	}
+}
+
+func NewModerationUsecase(blogRepository domain.IBlogRepository, commentRepository domain.ICommentRepository, timeout time.Duration, opts ...ModerationUsecaseOption) domain.IModerationUsecase {
+	mu := &moderationUsecase{
+		blogRepo:       blogRepository,
+		commentRepo:    commentRepository,
+		contextTimeout: timeout,
+	}
+	for _, opt := range opts {
+		opt(mu)
+	}
+	return mu
+}
+
+// GetQueue lists the content of one kind waiting for approval, oldest first.
+func (mu *moderationUsecase) GetQueue(ctx context.Context, kind domain.ContentKind, page, limit int64) ([]*domain.ModerationItem, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, mu.contextTimeout)
+	defer cancel()
+
+	items := []*domain.ModerationItem{}
+	switch kind {
+	case domain.ContentKindBlog:
+		blogs, total, err := mu.blogRepo.FetchPending(ctx, page, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, blog := range blogs {
+			items = append(items, &domain.ModerationItem{Kind: kind, Blog: blog})
+		}
+		return items, total, nil
+	case domain.ContentKindComment:
+		comments, total, err := mu.commentRepo.FetchPending(ctx, page, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, comment := range comments {
+			items = append(items, &domain.ModerationItem{Kind: kind, Comment: comment})
+		}
+		return items, total, nil
+	}
+	return nil, 0, domain.ErrValidation
+}
+
+// ApproveContent publishes a pending blog or comment. Content that isn't pending, including
+// content another admin already handled, is ErrNotFound.
+func (mu *moderationUsecase) ApproveContent(ctx context.Context, actorID string, kind domain.ContentKind, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, mu.contextTimeout)
+	defer cancel()
+
+	switch kind {
+	case domain.ContentKindBlog:
+		if err := mu.blogRepo.ApprovePending(ctx, id, time.Now().UTC()); err != nil {
+			return err
+		}
+	case domain.ContentKindComment:
+		if err := mu.commentRepo.Approve(ctx, id); err != nil {
+			return err
+		}
+		mu.countApprovedComment(ctx, id)
+	default:
+		return domain.ErrValidation
+	}
+
+	recordAudit(ctx, mu.auditRepo, &domain.AuditEntry{
+		ActorID:  actorID,
+		Action:   domain.AuditActionApprove,
+		TargetID: id,
+		Details:  map[string]string{"kind": string(kind)},
+	})
+	return nil
+}
+
+// RejectContent removes a pending blog or comment for good. Content that isn't pending is
+// ErrNotFound, so published content can't be removed through the queue.
+func (mu *moderationUsecase) RejectContent(ctx context.Context, actorID string, kind domain.ContentKind, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, mu.contextTimeout)
+	defer cancel()
+
+	details := map[string]string{"kind": string(kind)}
+	switch kind {
+	case domain.ContentKindBlog:
+		blog, err := mu.blogRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if blog.Status != domain.BlogStatusPending {
+			return ErrNotFound
+		}
+		if err := mu.blogRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		details["author_id"] = blog.AuthorID
+		details["title"] = blog.Title
+	case domain.ContentKindComment:
+		if err := mu.commentRepo.DeletePending(ctx, id); err != nil {
+			return err
+		}
+	default:
+		return domain.ErrValidation
+	}
+
+	recordAudit(ctx, mu.auditRepo, &domain.AuditEntry{
+		ActorID:  actorID,
+		Action:   domain.AuditActionReject,
+		TargetID: id,
+		Details:  details,
+	})
+	return nil
+}
+
+// countApprovedComment applies the counter updates CreateComment skipped while the comment was
+// pending. The comment is already public, so failures are only logged.
+func (mu *moderationUsecase) countApprovedComment(ctx context.Context, commentID string) {
+	comment, err := mu.commentRepo.GetByID(ctx, commentID)
+	if err != nil {
+		log.Printf("non-critical error: failed to load approved comment %s to update counters: %v", commentID, err)
+		return
+	}
+	if err := mu.blogRepo.IncrementCommentCount(ctx, comment.BlogID, 1); err != nil {
+		log.Printf("non-critical error: failed to increment comment count for blog %s: %v", comment.BlogID, err)
+	}
+	if comment.ParentID != nil {
+		if err := mu.commentRepo.IncrementReplyCount(ctx, *comment.ParentID, 1); err != nil {
+			log.Printf("non-critical error: failed to increment reply count for parent comment %s: %v", *comment.ParentID, err)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package usecases_test
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupModerationUsecase() (domain.IModerationUsecase, *MockBlogRepository, *MockCommentRepository, *MockAuditRepository) {
+	blogRepo := new(MockBlogRepository)
+	commentRepo := new(MockCommentRepository)
+	auditRepo := new(MockAuditRepository)
+	uc := usecases.NewModerationUsecase(blogRepo, commentRepo, 2*time.Second, usecases.WithModerationAuditLog(auditRepo))
+	return uc, blogRepo, commentRepo, auditRepo
+}
+
+func TestModerationUsecase_GetQueue(t *testing.T) {
+	t.Run("Blogs", func(t *testing.T) {
+		uc, blogRepo, _, _ := setupModerationUsecase()
+		blogRepo.On("FetchPending", mock.Anything, int64(1), int64(10)).Return([]*domain.Blog{{ID: "b1"}}, int64(1), nil).Once()
+
+		items, total, err := uc.GetQueue(context.Background(), domain.ContentKindBlog, 1, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, items, 1)
+		assert.Equal(t, domain.ContentKindBlog, items[0].Kind)
+		assert.Equal(t, "b1", items[0].Blog.ID)
+		assert.Nil(t, items[0].Comment)
+	})
+
+	t.Run("Comments", func(t *testing.T) {
+		uc, _, commentRepo, _ := setupModerationUsecase()
+		commentRepo.On("FetchPending", mock.Anything, int64(1), int64(10)).Return([]*domain.Comment{{ID: "c1"}}, int64(1), nil).Once()
+
+		items, _, err := uc.GetQueue(context.Background(), domain.ContentKindComment, 1, 10)
+
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, "c1", items[0].Comment.ID)
+		assert.Nil(t, items[0].Blog)
+	})
+
+	t.Run("Unknown Kind", func(t *testing.T) {
+		uc, _, _, _ := setupModerationUsecase()
+
+		_, _, err := uc.GetQueue(context.Background(), domain.ContentKind("user"), 1, 10)
+
+		assert.ErrorIs(t, err, domain.ErrValidation)
+	})
+}
+
+func TestModerationUsecase_ApproveContent(t *testing.T) {
+	t.Run("Blog Is Published And Audited", func(t *testing.T) {
+		uc, blogRepo, _, auditRepo := setupModerationUsecase()
+		blogRepo.On("ApprovePending", mock.Anything, "b1", mock.AnythingOfType("time.Time")).Return(nil).Once()
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditActionApprove && e.ActorID == "admin-1" && e.TargetID == "b1" && e.Details["kind"] == "blog"
+		})).Return(nil).Once()
+
+		err := uc.ApproveContent(context.Background(), "admin-1", domain.ContentKindBlog, "b1")
+
+		require.NoError(t, err)
+		blogRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reply Is Counted Once Approved", func(t *testing.T) {
+		uc, blogRepo, commentRepo, auditRepo := setupModerationUsecase()
+		parentID := "c0"
+		commentRepo.On("Approve", mock.Anything, "c1").Return(nil).Once()
+		commentRepo.On("GetByID", mock.Anything, "c1").Return(&domain.Comment{ID: "c1", BlogID: "b1", ParentID: &parentID}, nil).Once()
+		blogRepo.On("IncrementCommentCount", mock.Anything, "b1", 1).Return(nil).Once()
+		commentRepo.On("IncrementReplyCount", mock.Anything, parentID, 1).Return(nil).Once()
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := uc.ApproveContent(context.Background(), "admin-1", domain.ContentKindComment, "c1")
+
+		require.NoError(t, err)
+		blogRepo.AssertExpectations(t)
+		commentRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Pending", func(t *testing.T) {
+		uc, blogRepo, _, auditRepo := setupModerationUsecase()
+		blogRepo.On("ApprovePending", mock.Anything, "b1", mock.Anything).Return(usecases.ErrNotFound).Once()
+
+		err := uc.ApproveContent(context.Background(), "admin-1", domain.ContentKindBlog, "b1")
+
+		assert.ErrorIs(t, err, usecases.ErrNotFound)
+		auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Comment Already Approved Is Not Counted Again", func(t *testing.T) {
+		uc, blogRepo, commentRepo, _ := setupModerationUsecase()
+		commentRepo.On("Approve", mock.Anything, "c1").Return(usecases.ErrNotFound).Once()
+
+		err := uc.ApproveContent(context.Background(), "admin-1", domain.ContentKindComment, "c1")
+
+		assert.ErrorIs(t, err, usecases.ErrNotFound)
+		blogRepo.AssertNotCalled(t, "IncrementCommentCount", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestModerationUsecase_RejectContent(t *testing.T) {
+	t.Run("Pending Blog Is Deleted And Audited", func(t *testing.T) {
+		uc, blogRepo, _, auditRepo := setupModerationUsecase()
+		blogRepo.On("GetByID", mock.Anything, "b1").Return(&domain.Blog{ID: "b1", AuthorID: "author-1", Title: "Spam", Status: domain.BlogStatusPending}, nil).Once()
+		blogRepo.On("Delete", mock.Anything, "b1").Return(nil).Once()
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.Action == domain.AuditActionReject && e.TargetID == "b1" && e.Details["author_id"] == "author-1"
+		})).Return(nil).Once()
+
+		err := uc.RejectContent(context.Background(), "admin-1", domain.ContentKindBlog, "b1")
+
+		require.NoError(t, err)
+		blogRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Published Blog Is Left Alone", func(t *testing.T) {
+		uc, blogRepo, _, _ := setupModerationUsecase()
+		blogRepo.On("GetByID", mock.Anything, "b1").Return(&domain.Blog{ID: "b1", Status: domain.BlogStatusPublished}, nil).Once()
+
+		err := uc.RejectContent(context.Background(), "admin-1", domain.ContentKindBlog, "b1")
+
+		assert.ErrorIs(t, err, usecases.ErrNotFound)
+		blogRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Pending Comment Is Deleted", func(t *testing.T) {
+		uc, _, commentRepo, auditRepo := setupModerationUsecase()
+		commentRepo.On("DeletePending", mock.Anything, "c1").Return(nil).Once()
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+		err := uc.RejectContent(context.Background(), "admin-1", domain.ContentKindComment, "c1")
+
+		require.NoError(t, err)
+		commentRepo.AssertExpectations(t)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		uc, _, commentRepo, auditRepo := setupModerationUsecase()
+		dbErr := errors.New("db down")
+		commentRepo.On("DeletePending", mock.Anything, "c1").Return(dbErr).Once()
+
+		err := uc.RejectContent(context.Background(), "admin-1", domain.ContentKindComment, "c1")
+
+		assert.ErrorIs(t, err, dbErr)
+		auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
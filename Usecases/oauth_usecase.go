@@ -2,21 +2,57 @@ package usecases
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"time"
 
 	domain "A2SV_Starter_Project_Blog/Domain"
 	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
 
 	"github.com/google/uuid"
+	"golang.org/x/oauth2"
 )
 
+// OAuthStateTTL is how long a user has to complete the Google consent page.
+const OAuthStateTTL = 10 * time.Minute
+
+// oauthState is what is remembered about a started flow, keyed by its state token.
+// The PKCE verifier never leaves the server; Google only sees its S256 challenge until the exchange.
+type oauthState struct {
+	RedirectURI  string `json:"redirect_uri"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+func oauthStateKey(state string) string {
+	return "oauth:state:" + state
+}
+
 // oauthUsecase implements the domain.IOAuthUsecase interface.
 type oauthUsecase struct {
 	userRepo   UserRepository
 	tokenRepo  TokenRepository
 	jwtService infrastructure.JWTService
 	googleSvc  domain.IGoogleOAuthService
+	// Holds the state of started flows until their callback, so forged callbacks can be rejected.
+	stateStore domain.ICacheService
 	timeout    time.Duration
+	// Redirect URIs a client may ask for besides the configured default.
+	allowedRedirects map[string]bool
+}
+
+// OAuthUsecaseOption configures optional behaviour of the OAuth usecase.
+type OAuthUsecaseOption func(*oauthUsecase)
+
+// WithAllowedRedirectURIs lets clients send Google's response to one of uris instead of the
+// configured default, so that several frontends can share this backend.
+func WithAllowedRedirectURIs(uris ...string) OAuthUsecaseOption {
+	return func(uc *oauthUsecase) {
+		for _, uri := range uris {
+			uc.allowedRedirects[uri] = true
+		}
+	}
 }
 
 // NewOAuthUsecase is the constructor for the OAuth usecase.
@@ -25,24 +61,96 @@ func NewOAuthUsecase(
 	tokenRepo TokenRepository,
 	jwtService infrastructure.JWTService,
 	googleSvc domain.IGoogleOAuthService,
+	stateStore domain.ICacheService,
 	timeout time.Duration,
+	opts ...OAuthUsecaseOption,
 ) domain.IOAuthUsecase {
-	return &oauthUsecase{
-		userRepo:   userRepo,
-		tokenRepo:  tokenRepo,
-		jwtService: jwtService,
-		googleSvc:  googleSvc,
-		timeout:    timeout,
+	uc := &oauthUsecase{
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		jwtService:       jwtService,
+		googleSvc:        googleSvc,
+		stateStore:       stateStore,
+		timeout:          timeout,
+		allowedRedirects: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// checkRedirectURI rejects redirect URIs that are not on the allowlist. Empty means the default.
+func (uc *oauthUsecase) checkRedirectURI(redirectURI string) error {
+	if redirectURI != "" && !uc.allowedRedirects[redirectURI] {
+		return domain.ErrRedirectNotAllowed
 	}
+	return nil
+}
+
+// GoogleAuthURL starts the flow by returning the Google consent page URL for redirectURI.
+// The URL carries a fresh state token that the callback has to present.
+func (uc *oauthUsecase) GoogleAuthURL(c context.Context, redirectURI string) (string, error) {
+	if err := uc.checkRedirectURI(redirectURI); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(c, uc.timeout)
+	defer cancel()
+
+	state, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+	data, err := json.Marshal(oauthState{RedirectURI: redirectURI, CodeVerifier: verifier})
+	if err != nil {
+		return "", err
+	}
+	if err := uc.stateStore.Set(ctx, oauthStateKey(state), data, OAuthStateTTL); err != nil {
+		return "", err
+	}
+	return uc.googleSvc.AuthCodeURL(redirectURI, state, oauth2.S256ChallengeFromVerifier(verifier)), nil
+}
+
+// consumeState looks up a started flow and forgets it, so each state works only once. The
+// callback must use the redirect URI the flow was started with. It returns the flow's PKCE verifier.
+func (uc *oauthUsecase) consumeState(ctx context.Context, state, redirectURI string) (string, error) {
+	if state == "" {
+		return "", domain.ErrInvalidOAuthState
+	}
+	data, err := uc.stateStore.GetAndDelete(ctx, oauthStateKey(state))
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", domain.ErrInvalidOAuthState
+	}
+	if err != nil {
+		return "", err
+	}
+	var started oauthState
+	if err := json.Unmarshal(data, &started); err != nil || started.RedirectURI != redirectURI || started.CodeVerifier == "" {
+		return "", domain.ErrInvalidOAuthState
+	}
+	return started.CodeVerifier, nil
 }
 
 // HandleGoogleCallback orchestrates the entire Google OAuth2 flow.
-func (uc *oauthUsecase) HandleGoogleCallback(c context.Context, code string) (string, string, error) {
+func (uc *oauthUsecase) HandleGoogleCallback(c context.Context, code, state, redirectURI string) (string, string, error) {
+	if err := uc.checkRedirectURI(redirectURI); err != nil {
+		return "", "", err
+	}
+
 	ctx, cancel := context.WithTimeout(c, uc.timeout)
 	defer cancel()
 
-	// 1. Exchange the authorization code for an OAuth2 token from Google.
-	googleToken, err := uc.googleSvc.ExchangeCodeForToken(ctx, code)
+	// 0. Only accept callbacks for flows this server started.
+	verifier, err := uc.consumeState(ctx, state, redirectURI)
+	if err != nil {
+		return "", "", err
+	}
+
+	// 1. Exchange the authorization code for an OAuth2 token from Google. Google rejects the
+	// code unless the verifier matches the challenge the flow started with.
+	googleToken, err := uc.googleSvc.ExchangeCodeForToken(ctx, code, redirectURI, verifier)
 	if err != nil {
 		return "", "", err
 	}
@@ -125,22 +233,24 @@ func (uc *oauthUsecase) generateAndStoreTokenPair(ctx context.Context, user *dom
 	if err != nil {
 		return "", "", err
 	}
+	// Refresh token
+	refreshToken, refreshClaims, err := uc.jwtService.GenerateRefreshToken(user.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// The access token's record lives as long as its refresh token, so it can still be refreshed.
 	accessTokenModel := &domain.Token{
 		ID:        accessClaims.ID,
 		UserID:    user.ID,
 		Type:      domain.TokenTypeAccessToken,
 		Value:     accessToken,
-		ExpiresAt: accessClaims.ExpiresAt.Time,
+		ExpiresAt: refreshClaims.ExpiresAt.Time,
 	}
 	if err := uc.tokenRepo.Store(ctx, accessTokenModel); err != nil {
 		return "", "", err
 	}
 
-	// Refresh token
-	refreshToken, refreshClaims, err := uc.jwtService.GenerateRefreshToken(user.ID)
-	if err != nil {
-		return "", "", err
-	}
 	refreshTokenModel := &domain.Token{
 		ID:        refreshClaims.ID,
 		UserID:    user.ID,
@@ -154,3 +264,12 @@ func (uc *oauthUsecase) generateAndStoreTokenPair(ctx context.Context, user *dom
 
 	return accessToken, refreshToken, nil
 }
+
+// randomToken returns 32 random bytes, URL-safe encoded.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -3,6 +3,7 @@ package usecases_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,8 +25,11 @@ type MockGoogleOAuthService struct {
 	mock.Mock
 }
 
-func (m *MockGoogleOAuthService) ExchangeCodeForToken(ctx context.Context, code string) (*oauth2.Token, error) {
-	args := m.Called(ctx, code)
+func (m *MockGoogleOAuthService) AuthCodeURL(redirectURI, state, codeChallenge string) string {
+	return m.Called(redirectURI, state, codeChallenge).String(0)
+}
+func (m *MockGoogleOAuthService) ExchangeCodeForToken(ctx context.Context, code, redirectURI, codeVerifier string) (*oauth2.Token, error) {
+	args := m.Called(ctx, code, redirectURI, codeVerifier)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -46,6 +50,7 @@ type OAuthUsecaseTestSuite struct {
 	mockTokenRepo  *MockTokenRepository
 	mockJwtService *MockJWTService
 	mockGoogleSvc  *MockGoogleOAuthService
+	mockCache      *MockCacheService
 	usecase        domain.IOAuthUsecase
 }
 
@@ -54,13 +59,16 @@ func (s *OAuthUsecaseTestSuite) SetupTest() {
 	s.mockTokenRepo = new(MockTokenRepository)
 	s.mockJwtService = new(MockJWTService)
 	s.mockGoogleSvc = new(MockGoogleOAuthService)
+	s.mockCache = new(MockCacheService)
 
 	s.usecase = NewOAuthUsecase(
 		s.mockUserRepo,
 		s.mockTokenRepo,
 		s.mockJwtService,
 		s.mockGoogleSvc,
+		s.mockCache,
 		2*time.Second,
+		WithAllowedRedirectURIs("https://web.example.com/oauth", "app://oauth"),
 	)
 }
 
@@ -68,6 +76,13 @@ func TestOAuthUsecaseTestSuite(t *testing.T) {
 	suite.Run(t, new(OAuthUsecaseTestSuite))
 }
 
+// expectState makes state a started flow for redirectURI, with PKCE verifier "verifier",
+// that the next callback consumes.
+func (s *OAuthUsecaseTestSuite) expectState(state, redirectURI string) {
+	data := []byte(`{"redirect_uri":"` + redirectURI + `","code_verifier":"verifier"}`)
+	s.mockCache.On("GetAndDelete", mock.Anything, "oauth:state:"+state).Return(data, nil).Once()
+}
+
 // --- Tests ---
 
 func (s *OAuthUsecaseTestSuite) TestHandleGoogleCallback() {
@@ -91,15 +106,16 @@ func (s *OAuthUsecaseTestSuite) TestHandleGoogleCallback() {
 
 	s.Run("Success - Sign In existing Google user", func() {
 		s.SetupTest()
+		s.expectState("valid-state", "")
 		// Arrange
 		existingUser := &domain.User{ID: "our-user-id-abc", Role: domain.RoleUser, IsActive: true}
-		s.mockGoogleSvc.On("ExchangeCodeForToken", mock.Anything, authCode).Return(googleToken, nil).Once()
+		s.mockGoogleSvc.On("ExchangeCodeForToken", mock.Anything, authCode, "", "verifier").Return(googleToken, nil).Once()
 		s.mockGoogleSvc.On("GetUserInfo", mock.Anything, googleToken).Return(googleUserInfo, nil).Once()
 		s.mockUserRepo.On("FindByProviderID", mock.Anything, domain.ProviderGoogle, googleUserInfo.ID).Return(existingUser, nil).Once()
 		setupTokenGenerationMocks(s, existingUser.ID)
 
 		// Act
-		accessToken, refreshToken, err := s.usecase.HandleGoogleCallback(ctx, authCode)
+		accessToken, refreshToken, err := s.usecase.HandleGoogleCallback(ctx, authCode, "valid-state", "")
 
 		// Assert
 		s.NoError(err)
@@ -112,8 +128,9 @@ func (s *OAuthUsecaseTestSuite) TestHandleGoogleCallback() {
 
 	s.Run("Success - Sign Up new Google user", func() {
 		s.SetupTest()
+		s.expectState("valid-state", "")
 		generatedUserID := "new-generated-id" // Arrange
-		s.mockGoogleSvc.On("ExchangeCodeForToken", mock.Anything, authCode).Return(googleToken, nil).Once()
+		s.mockGoogleSvc.On("ExchangeCodeForToken", mock.Anything, authCode, "", "verifier").Return(googleToken, nil).Once()
 		s.mockGoogleSvc.On("GetUserInfo", mock.Anything, googleToken).Return(googleUserInfo, nil).Once()
 		// 1. FindByProviderID returns not found
 		s.mockUserRepo.On("FindByProviderID", mock.Anything, domain.ProviderGoogle, googleUserInfo.ID).Return(nil, nil).Once()
@@ -132,7 +149,7 @@ func (s *OAuthUsecaseTestSuite) TestHandleGoogleCallback() {
 		setupTokenGenerationMocks(s, generatedUserID) // User ID is generated by repo
 
 		// Act
-		_, _, err := s.usecase.HandleGoogleCallback(ctx, authCode)
+		_, _, err := s.usecase.HandleGoogleCallback(ctx, authCode, "valid-state", "")
 
 		// Assert
 		s.NoError(err)
@@ -142,9 +159,10 @@ func (s *OAuthUsecaseTestSuite) TestHandleGoogleCallback() {
 
 	s.Run("Failure - Email already exists with local provider", func() {
 		s.SetupTest()
+		s.expectState("valid-state", "")
 		// Arrange
 		existingLocalUser := &domain.User{Provider: domain.ProviderLocal}
-		s.mockGoogleSvc.On("ExchangeCodeForToken", mock.Anything, authCode).Return(googleToken, nil).Once()
+		s.mockGoogleSvc.On("ExchangeCodeForToken", mock.Anything, authCode, "", "verifier").Return(googleToken, nil).Once()
 		s.mockGoogleSvc.On("GetUserInfo", mock.Anything, googleToken).Return(googleUserInfo, nil).Once()
 		// 1. FindByProviderID returns not found
 		s.mockUserRepo.On("FindByProviderID", mock.Anything, domain.ProviderGoogle, googleUserInfo.ID).Return(nil, nil).Once()
@@ -152,7 +170,7 @@ func (s *OAuthUsecaseTestSuite) TestHandleGoogleCallback() {
 		s.mockUserRepo.On("GetByEmail", mock.Anything, googleUserInfo.Email).Return(existingLocalUser, nil).Once()
 
 		// Act
-		_, _, err := s.usecase.HandleGoogleCallback(ctx, authCode)
+		_, _, err := s.usecase.HandleGoogleCallback(ctx, authCode, "valid-state", "")
 
 		// Assert
 		s.Error(err)
@@ -164,12 +182,13 @@ func (s *OAuthUsecaseTestSuite) TestHandleGoogleCallback() {
 
 	s.Run("Failure - Google service fails to exchange code", func() {
 		s.SetupTest()
+		s.expectState("valid-state", "")
 		// Arrange
 		expectedErr := errors.New("invalid_grant")
-		s.mockGoogleSvc.On("ExchangeCodeForToken", mock.Anything, authCode).Return(nil, expectedErr).Once()
+		s.mockGoogleSvc.On("ExchangeCodeForToken", mock.Anything, authCode, "", "verifier").Return(nil, expectedErr).Once()
 
 		// Act
-		_, _, err := s.usecase.HandleGoogleCallback(ctx, authCode)
+		_, _, err := s.usecase.HandleGoogleCallback(ctx, authCode, "valid-state", "")
 
 		// Assert
 		s.Error(err)
@@ -179,3 +198,229 @@ func (s *OAuthUsecaseTestSuite) TestHandleGoogleCallback() {
 		s.mockUserRepo.AssertNotCalled(s.T(), "FindByProviderID")
 	})
 }
+
+func (s *OAuthUsecaseTestSuite) TestGoogleAuthURL() {
+	s.Run("Success - Default redirect URI", func() {
+		s.SetupTest()
+		s.mockCache.On("Set", mock.Anything, mock.AnythingOfType("string"), mock.Anything, OAuthStateTTL).Return(nil).Once()
+		s.mockGoogleSvc.On("AuthCodeURL", "", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("https://accounts.google.com/default").Once()
+
+		url, err := s.usecase.GoogleAuthURL(context.Background(), "")
+
+		s.NoError(err)
+		s.Equal("https://accounts.google.com/default", url)
+	})
+
+	s.Run("Success - Allowed redirect URI", func() {
+		s.SetupTest()
+		s.mockCache.On("Set", mock.Anything, mock.AnythingOfType("string"), mock.MatchedBy(func(data []byte) bool {
+			return strings.Contains(string(data), `"redirect_uri":"app://oauth"`)
+		}), OAuthStateTTL).Return(nil).Once()
+		s.mockGoogleSvc.On("AuthCodeURL", "app://oauth", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return("https://accounts.google.com/mobile").Once()
+
+		url, err := s.usecase.GoogleAuthURL(context.Background(), "app://oauth")
+
+		s.NoError(err)
+		s.Equal("https://accounts.google.com/mobile", url)
+	})
+
+	s.Run("Success - Each flow gets its own state", func() {
+		s.SetupTest()
+		var states []string
+		s.mockCache.On("Set", mock.Anything, mock.AnythingOfType("string"), mock.Anything, OAuthStateTTL).Return(nil).Twice()
+		s.mockGoogleSvc.On("AuthCodeURL", "", mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { states = append(states, args.String(1)) }).
+			Return("https://accounts.google.com/default").Twice()
+
+		_, err := s.usecase.GoogleAuthURL(context.Background(), "")
+		s.Require().NoError(err)
+		_, err = s.usecase.GoogleAuthURL(context.Background(), "")
+		s.Require().NoError(err)
+
+		s.Require().Len(states, 2)
+		s.NotEmpty(states[0])
+		s.NotEqual(states[0], states[1])
+		s.mockCache.AssertCalled(s.T(), "Set", mock.Anything, "oauth:state:"+states[0], mock.Anything, OAuthStateTTL)
+	})
+
+	s.Run("Failure - Unlisted redirect URI", func() {
+		s.SetupTest()
+
+		_, err := s.usecase.GoogleAuthURL(context.Background(), "https://evil.example.com/oauth")
+
+		s.ErrorIs(err, domain.ErrRedirectNotAllowed)
+		s.mockCache.AssertNotCalled(s.T(), "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		s.mockGoogleSvc.AssertNotCalled(s.T(), "AuthCodeURL", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *OAuthUsecaseTestSuite) TestHandleGoogleCallback_RedirectURI() {
+	s.Run("Success - Exchanges the code for the allowed redirect URI", func() {
+		s.SetupTest()
+		s.expectState("valid-state", "https://web.example.com/oauth")
+		expectedErr := errors.New("invalid_grant")
+		s.mockGoogleSvc.On("ExchangeCodeForToken", mock.Anything, "code", "https://web.example.com/oauth", "verifier").Return(nil, expectedErr).Once()
+
+		_, _, err := s.usecase.HandleGoogleCallback(context.Background(), "code", "valid-state", "https://web.example.com/oauth")
+
+		s.ErrorIs(err, expectedErr)
+		s.mockGoogleSvc.AssertExpectations(s.T())
+	})
+
+	s.Run("Failure - Unlisted redirect URI", func() {
+		s.SetupTest()
+
+		_, _, err := s.usecase.HandleGoogleCallback(context.Background(), "code", "valid-state", "https://evil.example.com/oauth")
+
+		s.ErrorIs(err, domain.ErrRedirectNotAllowed)
+		s.mockGoogleSvc.AssertNotCalled(s.T(), "ExchangeCodeForToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *OAuthUsecaseTestSuite) TestHandleGoogleCallback_State() {
+	ctx := context.Background()
+
+	s.Run("Success - State issued at login start is accepted once", func() {
+		s.SetupTest()
+		// Keep what the login start stores, and hand it back on the first lookup only.
+		var stored []byte
+		var key string
+		s.mockCache.On("Set", mock.Anything, mock.AnythingOfType("string"), mock.Anything, OAuthStateTTL).
+			Run(func(args mock.Arguments) { key, stored = args.String(1), args.Get(2).([]byte) }).
+			Return(nil).Once()
+		var state string
+		s.mockGoogleSvc.On("AuthCodeURL", "app://oauth", mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { state = args.String(1) }).
+			Return("https://accounts.google.com/mobile").Once()
+
+		_, err := s.usecase.GoogleAuthURL(ctx, "app://oauth")
+		s.Require().NoError(err)
+		s.Require().Equal("oauth:state:"+state, key)
+
+		s.mockCache.On("GetAndDelete", mock.Anything, key).Return(stored, nil).Once()
+		s.mockCache.On("GetAndDelete", mock.Anything, key).Return(nil, domain.ErrNotFound).Once()
+		exchangeErr := errors.New("invalid_grant")
+		s.mockGoogleSvc.On("ExchangeCodeForToken", mock.Anything, "code", "app://oauth", mock.AnythingOfType("string")).Return(nil, exchangeErr).Once()
+
+		_, _, err = s.usecase.HandleGoogleCallback(ctx, "code", state, "app://oauth")
+		s.ErrorIs(err, exchangeErr, "the state check passes and the flow reaches the code exchange")
+
+		_, _, err = s.usecase.HandleGoogleCallback(ctx, "code", state, "app://oauth")
+		s.ErrorIs(err, domain.ErrInvalidOAuthState, "a replayed state is rejected")
+		s.mockGoogleSvc.AssertNumberOfCalls(s.T(), "ExchangeCodeForToken", 1)
+	})
+
+	s.Run("Failure - Missing state", func() {
+		s.SetupTest()
+
+		_, _, err := s.usecase.HandleGoogleCallback(ctx, "code", "", "")
+
+		s.ErrorIs(err, domain.ErrInvalidOAuthState)
+		s.mockCache.AssertNotCalled(s.T(), "GetAndDelete", mock.Anything, mock.Anything)
+		s.mockGoogleSvc.AssertNotCalled(s.T(), "ExchangeCodeForToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure - Tampered or expired state", func() {
+		s.SetupTest()
+		s.mockCache.On("GetAndDelete", mock.Anything, "oauth:state:tampered").Return(nil, domain.ErrNotFound).Once()
+
+		_, _, err := s.usecase.HandleGoogleCallback(ctx, "code", "tampered", "")
+
+		s.ErrorIs(err, domain.ErrInvalidOAuthState)
+		s.mockGoogleSvc.AssertNotCalled(s.T(), "ExchangeCodeForToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure - Expired state", func() {
+		cache := newFakeRedisCache()
+		usecase := NewOAuthUsecase(s.mockUserRepo, s.mockTokenRepo, s.mockJwtService, s.mockGoogleSvc, cache, 2*time.Second)
+		var state string
+		s.mockGoogleSvc.On("AuthCodeURL", "", mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { state = args.String(1) }).
+			Return("https://accounts.google.com/default").Once()
+
+		_, err := usecase.GoogleAuthURL(ctx, "")
+		s.Require().NoError(err)
+		cache.advance(OAuthStateTTL + time.Second)
+
+		_, _, err = usecase.HandleGoogleCallback(ctx, "code", state, "")
+
+		s.ErrorIs(err, domain.ErrInvalidOAuthState)
+	})
+
+	s.Run("Failure - State of a flow started for another redirect URI", func() {
+		s.SetupTest()
+		s.expectState("web-state", "https://web.example.com/oauth")
+
+		_, _, err := s.usecase.HandleGoogleCallback(ctx, "code", "web-state", "app://oauth")
+
+		s.ErrorIs(err, domain.ErrInvalidOAuthState)
+		s.mockGoogleSvc.AssertNotCalled(s.T(), "ExchangeCodeForToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure - State without a PKCE verifier", func() {
+		s.SetupTest()
+		s.mockCache.On("GetAndDelete", mock.Anything, "oauth:state:old-state").Return([]byte(`{"redirect_uri":""}`), nil).Once()
+
+		_, _, err := s.usecase.HandleGoogleCallback(ctx, "code", "old-state", "")
+
+		s.ErrorIs(err, domain.ErrInvalidOAuthState)
+		s.mockGoogleSvc.AssertNotCalled(s.T(), "ExchangeCodeForToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Failure - State store unavailable", func() {
+		s.SetupTest()
+		s.mockCache.On("GetAndDelete", mock.Anything, "oauth:state:valid-state").Return(nil, domain.ErrCacheUnavailable).Once()
+
+		_, _, err := s.usecase.HandleGoogleCallback(ctx, "code", "valid-state", "")
+
+		s.ErrorIs(err, domain.ErrCacheUnavailable)
+		s.mockGoogleSvc.AssertNotCalled(s.T(), "ExchangeCodeForToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func (s *OAuthUsecaseTestSuite) TestPKCE() {
+	ctx := context.Background()
+
+	s.Run("Success - Exchange presents the verifier behind the challenge", func() {
+		s.SetupTest()
+		cache := newFakeRedisCache()
+		usecase := NewOAuthUsecase(s.mockUserRepo, s.mockTokenRepo, s.mockJwtService, s.mockGoogleSvc, cache, 2*time.Second)
+		var state, challenge string
+		s.mockGoogleSvc.On("AuthCodeURL", "", mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { state, challenge = args.String(1), args.String(2) }).
+			Return("https://accounts.google.com/default").Once()
+		var verifier string
+		exchangeErr := errors.New("stop after the exchange")
+		s.mockGoogleSvc.On("ExchangeCodeForToken", mock.Anything, "code", "", mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { verifier = args.String(3) }).
+			Return(nil, exchangeErr).Once()
+
+		_, err := usecase.GoogleAuthURL(ctx, "")
+		s.Require().NoError(err)
+		_, _, err = usecase.HandleGoogleCallback(ctx, "code", state, "")
+
+		s.ErrorIs(err, exchangeErr)
+		// RFC 7636 verifiers are 43 to 128 characters, and the S256 challenge is their hashed form.
+		s.GreaterOrEqual(len(verifier), 43)
+		s.LessOrEqual(len(verifier), 128)
+		s.Equal(oauth2.S256ChallengeFromVerifier(verifier), challenge)
+		s.NotEqual(verifier, challenge, "the verifier itself must not be sent with the consent URL")
+	})
+
+	s.Run("Success - Each flow gets its own verifier", func() {
+		s.SetupTest()
+		var challenges []string
+		s.mockCache.On("Set", mock.Anything, mock.AnythingOfType("string"), mock.Anything, OAuthStateTTL).Return(nil).Twice()
+		s.mockGoogleSvc.On("AuthCodeURL", "", mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) { challenges = append(challenges, args.String(2)) }).
+			Return("https://accounts.google.com/default").Twice()
+
+		_, err := s.usecase.GoogleAuthURL(ctx, "")
+		s.Require().NoError(err)
+		_, err = s.usecase.GoogleAuthURL(ctx, "")
+		s.Require().NoError(err)
+
+		s.Require().Len(challenges, 2)
+		s.NotEqual(challenges[0], challenges[1])
+	})
+}
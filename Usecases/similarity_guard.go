@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	"context"
+	"log"
+	"time"
+)
+
+// similarityGuard rejects a user's submission with ErrConflict when they posted near-identical
+// content within the window, which stops spam loops that a plain cooldown would only slow down.
+// Submissions are remembered by domain.SimilarityFingerprint in the cache, so every instance
+// sees them. A nil guard lets everything through.
+type similarityGuard struct {
+	cache  domain.ICacheService
+	window time.Duration
+	// maxWords is passed to domain.SimilarityFingerprint. Fewer words make the guard more sensitive.
+	maxWords int
+}
+
+// newSimilarityGuard returns nil, disabling the check, for a nil cache or a window of zero or less.
+func newSimilarityGuard(cache domain.ICacheService, window time.Duration, maxWords int) *similarityGuard {
+	if cache == nil || window <= 0 {
+		return nil
+	}
+	return &similarityGuard{cache: cache, window: window, maxWords: maxWords}
+}
+
+// claim records the submission and returns its cache key, or ErrConflict when a similar one is
+// still remembered. The key is empty when nothing was recorded. An unavailable cache lets the
+// submission through rather than blocking all posting.
+func (g *similarityGuard) claim(ctx context.Context, kind domain.ContentKind, userID, text string) (string, error) {
+	if g == nil {
+		return "", nil
+	}
+	fingerprint := domain.SimilarityFingerprint(text, g.maxWords)
+	if fingerprint == "" {
+		return "", nil
+	}
+	key := "similar:" + string(kind) + ":" + userID + ":" + fingerprint
+	claimed, err := g.cache.SetIfAbsent(ctx, key, []byte("1"), g.window)
+	if err != nil {
+		log.Printf("non-critical error: failed to check similar %s content for user %s: %v", kind, userID, err)
+		return "", nil
+	}
+	if !claimed {
+		return "", ErrConflict
+	}
+	return key, nil
+}
+
+// release forgets a claimed submission that could not be saved, so the user can retry it.
+func (g *similarityGuard) release(ctx context.Context, key string) {
+	if g == nil || key == "" {
+		return
+	}
+	if err := g.cache.Delete(ctx, key); err != nil {
+		log.Printf("non-critical error: failed to release similarity key %s: %v", key, err)
+	}
+}
@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultSummaryCacheTTL keeps the dashboard numbers briefly so repeated refreshes don't recount every collection.
+const DefaultSummaryCacheTTL = 30 * time.Second
+
+const summaryCacheKey = "admin:summary"
+
+// summaryUsecase implements the domain.ISummaryUsecase interface.
+type summaryUsecase struct {
+	statsRepo      domain.IStatsRepository
+	cache          domain.ICacheService
+	cacheTTL       time.Duration
+	contextTimeout time.Duration
+}
+
+// NewSummaryUsecase builds the dashboard summary usecase. A nil cache turns caching off.
+func NewSummaryUsecase(statsRepository domain.IStatsRepository, cache domain.ICacheService, cacheTTL, timeout time.Duration) domain.ISummaryUsecase {
+	return &summaryUsecase{
+		statsRepo:      statsRepository,
+		cache:          cache,
+		cacheTTL:       cacheTTL,
+		contextTimeout: timeout,
+	}
+}
+
+// GetSummary returns the headline counts, running every count at the same time.
+func (su *summaryUsecase) GetSummary(ctx context.Context) (*domain.DashboardSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, su.contextTimeout)
+	defer cancel()
+
+	if summary := su.cachedSummary(ctx); summary != nil {
+		return summary, nil
+	}
+
+	summary := &domain.DashboardSummary{}
+	counts := []struct {
+		dst   *int64
+		count func(context.Context) (int64, error)
+	}{
+		{&summary.TotalUsers, su.statsRepo.CountUsers},
+		{&summary.ActiveUsers, su.statsRepo.CountActiveUsers},
+		{&summary.TotalBlogs, su.statsRepo.CountBlogs},
+		{&summary.TotalComments, su.statsRepo.CountComments},
+		{&summary.TotalInteractions, su.statsRepo.CountInteractions},
+	}
+
+	// Each goroutine writes only its own field and error slot, so no locking is needed.
+	errs := make([]error, len(counts))
+	var wg sync.WaitGroup
+	for i, c := range counts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			*c.dst, errs[i] = c.count(ctx)
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	summary.GeneratedAt = time.Now().UTC()
+
+	su.cacheSummary(ctx, summary)
+	return summary, nil
+}
+
+// cachedSummary returns the cached summary, or nil on a miss. Cache errors fail open.
+func (su *summaryUsecase) cachedSummary(ctx context.Context) *domain.DashboardSummary {
+	if su.cache == nil {
+		return nil
+	}
+	data, err := su.cache.Get(ctx, summaryCacheKey)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			log.Printf("[CACHE] Error getting dashboard summary from cache: %v", err)
+		}
+		return nil
+	}
+	var summary domain.DashboardSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		log.Printf("[CACHE] Discarding corrupt dashboard summary: %v", err)
+		return nil
+	}
+	return &summary
+}
+
+func (su *summaryUsecase) cacheSummary(ctx context.Context, summary *domain.DashboardSummary) {
+	if su.cache == nil {
+		return
+	}
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return
+	}
+	if err := su.cache.Set(ctx, summaryCacheKey, data, su.cacheTTL); err != nil {
+		log.Printf("[CACHE] Error setting dashboard summary cache: %v", err)
+	}
+}
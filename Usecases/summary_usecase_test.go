@@ -0,0 +1,257 @@
+package usecases_test
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	usecases "A2SV_Starter_Project_Blog/Usecases"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// --- MOCK DEFINITIONS ---
+
+type MockStatsRepository struct{ mock.Mock }
+
+func (m *MockStatsRepository) CountUsers(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockStatsRepository) CountActiveUsers(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockStatsRepository) CountBlogs(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockStatsRepository) CountComments(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockStatsRepository) CountInteractions(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockCacheService struct{ mock.Mock }
+
+func (m *MockCacheService) Get(ctx context.Context, key string) ([]byte, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+func (m *MockCacheService) GetAndDelete(ctx context.Context, key string) ([]byte, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+func (m *MockCacheService) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	args := m.Called(ctx, key, value, expiration)
+	return args.Error(0)
+}
+func (m *MockCacheService) SetIfAbsent(ctx context.Context, key string, value []byte, expiration time.Duration) (bool, error) {
+	args := m.Called(ctx, key, value, expiration)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockCacheService) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+func (m *MockCacheService) AddToSet(ctx context.Context, key string, members ...interface{}) error {
+	args := m.Called(ctx, key, members)
+	return args.Error(0)
+}
+func (m *MockCacheService) GetSetMembers(ctx context.Context, key string) ([]string, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+func (m *MockCacheService) DeleteKeys(ctx context.Context, keys []string) error {
+	args := m.Called(ctx, keys)
+	return args.Error(0)
+}
+
+// barrierStatsRepository only answers once every count is in flight, so a summary that counts
+// one collection after another times out instead of completing.
+type barrierStatsRepository struct {
+	mu      sync.Mutex
+	waiting int
+	release chan struct{}
+}
+
+func newBarrierStatsRepository() *barrierStatsRepository {
+	return &barrierStatsRepository{release: make(chan struct{})}
+}
+
+func (r *barrierStatsRepository) wait(ctx context.Context, n int64) (int64, error) {
+	r.mu.Lock()
+	r.waiting++
+	if r.waiting == 5 {
+		close(r.release)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.release:
+		return n, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func (r *barrierStatsRepository) CountUsers(ctx context.Context) (int64, error) {
+	return r.wait(ctx, 10)
+}
+func (r *barrierStatsRepository) CountActiveUsers(ctx context.Context) (int64, error) {
+	return r.wait(ctx, 7)
+}
+func (r *barrierStatsRepository) CountBlogs(ctx context.Context) (int64, error) {
+	return r.wait(ctx, 25)
+}
+func (r *barrierStatsRepository) CountComments(ctx context.Context) (int64, error) {
+	return r.wait(ctx, 40)
+}
+func (r *barrierStatsRepository) CountInteractions(ctx context.Context) (int64, error) {
+	return r.wait(ctx, 90)
+}
+
+func expectCounts(repo *MockStatsRepository) {
+	repo.On("CountUsers", mock.Anything).Return(int64(10), nil)
+	repo.On("CountActiveUsers", mock.Anything).Return(int64(7), nil)
+	repo.On("CountBlogs", mock.Anything).Return(int64(25), nil)
+	repo.On("CountComments", mock.Anything).Return(int64(40), nil)
+	repo.On("CountInteractions", mock.Anything).Return(int64(90), nil)
+}
+
+func assertCounts(t *testing.T, summary *domain.DashboardSummary) {
+	t.Helper()
+	assert.Equal(t, int64(10), summary.TotalUsers)
+	assert.Equal(t, int64(7), summary.ActiveUsers)
+	assert.Equal(t, int64(25), summary.TotalBlogs)
+	assert.Equal(t, int64(40), summary.TotalComments)
+	assert.Equal(t, int64(90), summary.TotalInteractions)
+}
+
+// --- TEST FUNCTIONS ---
+
+func TestSummaryUsecase_GetSummary(t *testing.T) {
+	t.Run("Success - Aggregates Every Count", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		uc := usecases.NewSummaryUsecase(mockStatsRepo, nil, usecases.DefaultSummaryCacheTTL, 2*time.Second)
+		expectCounts(mockStatsRepo)
+
+		summary, err := uc.GetSummary(context.Background())
+
+		require.NoError(t, err)
+		assertCounts(t, summary)
+		assert.False(t, summary.GeneratedAt.IsZero())
+		mockStatsRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Counts Run Concurrently", func(t *testing.T) {
+		uc := usecases.NewSummaryUsecase(newBarrierStatsRepository(), nil, usecases.DefaultSummaryCacheTTL, time.Second)
+
+		summary, err := uc.GetSummary(context.Background())
+
+		require.NoError(t, err)
+		assertCounts(t, summary)
+	})
+
+	t.Run("Success - Concurrent Callers Get Correct Totals", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		uc := usecases.NewSummaryUsecase(mockStatsRepo, nil, usecases.DefaultSummaryCacheTTL, 2*time.Second)
+		expectCounts(mockStatsRepo)
+
+		var wg sync.WaitGroup
+		summaries := make([]*domain.DashboardSummary, 20)
+		errs := make([]error, len(summaries))
+		for i := range summaries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				summaries[i], errs[i] = uc.GetSummary(context.Background())
+			}()
+		}
+		wg.Wait()
+
+		for i := range summaries {
+			require.NoError(t, errs[i])
+			assertCounts(t, summaries[i])
+		}
+	})
+
+	t.Run("Failure - A Count Fails", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		uc := usecases.NewSummaryUsecase(mockStatsRepo, nil, usecases.DefaultSummaryCacheTTL, 2*time.Second)
+		dbErr := errors.New("db down")
+		mockStatsRepo.On("CountUsers", mock.Anything).Return(int64(10), nil)
+		mockStatsRepo.On("CountActiveUsers", mock.Anything).Return(int64(7), nil)
+		mockStatsRepo.On("CountBlogs", mock.Anything).Return(int64(0), dbErr)
+		mockStatsRepo.On("CountComments", mock.Anything).Return(int64(40), nil)
+		mockStatsRepo.On("CountInteractions", mock.Anything).Return(int64(90), nil)
+
+		summary, err := uc.GetSummary(context.Background())
+
+		assert.ErrorIs(t, err, dbErr)
+		assert.Nil(t, summary)
+	})
+}
+
+func TestSummaryUsecase_Cache(t *testing.T) {
+	t.Run("Cache Hit - Skips Counting", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		mockCache := new(MockCacheService)
+		uc := usecases.NewSummaryUsecase(mockStatsRepo, mockCache, time.Minute, 2*time.Second)
+		cached, _ := json.Marshal(domain.DashboardSummary{TotalUsers: 3, TotalBlogs: 5})
+		mockCache.On("Get", mock.Anything, "admin:summary").Return(cached, nil).Once()
+
+		summary, err := uc.GetSummary(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), summary.TotalUsers)
+		assert.Equal(t, int64(5), summary.TotalBlogs)
+		mockStatsRepo.AssertNotCalled(t, "CountUsers", mock.Anything)
+	})
+
+	t.Run("Cache Miss - Counts And Stores The Result", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		mockCache := new(MockCacheService)
+		uc := usecases.NewSummaryUsecase(mockStatsRepo, mockCache, time.Minute, 2*time.Second)
+		expectCounts(mockStatsRepo)
+		mockCache.On("Get", mock.Anything, "admin:summary").Return(nil, domain.ErrNotFound).Once()
+		mockCache.On("Set", mock.Anything, "admin:summary", mock.Anything, time.Minute).Return(nil).Once()
+
+		summary, err := uc.GetSummary(context.Background())
+
+		require.NoError(t, err)
+		assertCounts(t, summary)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("Cache Down - Fails Open", func(t *testing.T) {
+		mockStatsRepo := new(MockStatsRepository)
+		mockCache := new(MockCacheService)
+		uc := usecases.NewSummaryUsecase(mockStatsRepo, mockCache, time.Minute, 2*time.Second)
+		expectCounts(mockStatsRepo)
+		mockCache.On("Get", mock.Anything, "admin:summary").Return(nil, domain.ErrCacheUnavailable).Once()
+		mockCache.On("Set", mock.Anything, "admin:summary", mock.Anything, time.Minute).Return(domain.ErrCacheUnavailable).Once()
+
+		summary, err := uc.GetSummary(context.Background())
+
+		require.NoError(t, err)
+		assertCounts(t, summary)
+	})
+}
@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunExpiredTokenCleanup purges expired tokens every interval until ctx is cancelled.
+// The TTL index on tokens is the primary mechanism; this is a backup for when it lags or is missing.
+func RunExpiredTokenCleanup(ctx context.Context, tokenRepo TokenRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := tokenRepo.DeleteExpired(ctx); err != nil {
+			log.Printf("Expired token cleanup failed: %v", err)
+		} else if n > 0 {
+			log.Printf("Expired token cleanup removed %d token(s)", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
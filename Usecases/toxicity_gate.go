@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	domain "A2SV_Starter_Project_Blog/Domain"
+	"context"
+	"log"
+)
+
+// toxicityGate screens new content with the AI toxicity score. Content scoring above the
+// threshold is rejected or held for moderation, depending on the action. A nil gate lets
+// everything through.
+type toxicityGate struct {
+	scorer    domain.IContentScorer
+	threshold float64
+	action    domain.ToxicityAction
+}
+
+// newToxicityGate returns nil, disabling the check, for a nil scorer or a threshold of zero or less.
+func newToxicityGate(scorer domain.IContentScorer, threshold float64, action domain.ToxicityAction) *toxicityGate {
+	if scorer == nil || threshold <= 0 {
+		return nil
+	}
+	if !action.IsValid() {
+		action = domain.ToxicityActionQueue
+	}
+	return &toxicityGate{scorer: scorer, threshold: threshold, action: action}
+}
+
+// screen reports whether the content must wait for an admin, or returns ErrContentRejected when
+// the action is to reject it. The AI being unavailable lets the content through rather than
+// blocking all posting.
+func (g *toxicityGate) screen(ctx context.Context, kind domain.ContentKind, text string) (bool, error) {
+	if g == nil {
+		return false, nil
+	}
+	score, err := g.scorer.ScoreContent(ctx, text)
+	if err != nil {
+		log.Printf("non-critical error: failed to score %s content for toxicity: %v", kind, err)
+		return false, nil
+	}
+	if score <= g.threshold {
+		return false, nil
+	}
+	if g.action == domain.ToxicityActionReject {
+		return false, domain.ErrContentRejected
+	}
+	return true, nil
+}
@@ -4,9 +4,13 @@ import (
 	domain "A2SV_Starter_Project_Blog/Domain"
 	infrastructure "A2SV_Starter_Project_Blog/Infrastructure"
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"mime/multipart"
 	"net/mail"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -17,11 +21,19 @@ type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	GetByUsername(ctx context.Context, username string) (*domain.User, error)
+	GetByUsernames(ctx context.Context, usernames []string) ([]*domain.User, error)
 	GetByID(ctx context.Context, id string) (*domain.User, error)
+	// GetByIDs returns the users that exist among the given IDs, in no particular order.
+	GetByIDs(ctx context.Context, ids []string) ([]*domain.User, error)
+	// SuggestUsernames returns up to limit active users whose username starts with prefix,
+	// ignoring case, in username order.
+	SuggestUsernames(ctx context.Context, prefix string, limit int64) ([]*domain.UsernameSuggestion, error)
 	Update(ctx context.Context, user *domain.User) error
 	FindUserIDsByName(ctx context.Context, authorName string) ([]string, error)
+	FindUserIDsByProvider(ctx context.Context, provider domain.AuthProvider) ([]string, error)
 	FindByProviderID(ctx context.Context, provider domain.AuthProvider, providerID string) (*domain.User, error)
 	SearchAndFilter(ctx context.Context, options domain.UserSearchFilterOptions) ([]*domain.User, int64, error)
+	StreamByFilter(ctx context.Context, options domain.UserSearchFilterOptions, fn func(*domain.User) error) error
 }
 
 type TokenRepository interface {
@@ -29,7 +41,9 @@ type TokenRepository interface {
 	GetByValue(ctx context.Context, tokenValue string) (*domain.Token, error)
 	GetByID(ctx context.Context, tokenID string) (*domain.Token, error)
 	Delete(ctx context.Context, tokenID string) error
-	DeleteByUserID(ctx context.Context, userID string, tokenType domain.TokenType) error
+	GetByUserID(ctx context.Context, userID string, tokenType domain.TokenType) ([]*domain.Token, error)
+	DeleteByUserID(ctx context.Context, userID string, tokenType domain.TokenType) (int64, error)
+	DeleteExpired(ctx context.Context) (int64, error)
 }
 
 type UserUsecase interface {
@@ -38,6 +52,7 @@ type UserUsecase interface {
 	Login(ctx context.Context, identifier, password string) (accessToken, refreshToken string, err error)
 	Logout(ctx context.Context, refreshToken string) error
 	RefreshAccessToken(ctx context.Context, refreshToken, accessToken string) (newAccessToken, newRefreshToken string, err error)
+	IntrospectToken(ctx context.Context, accessToken string) (*domain.TokenIntrospection, error)
 
 	// Password Management
 	ForgetPassword(ctx context.Context, email string) error
@@ -45,13 +60,39 @@ type UserUsecase interface {
 
 	//Profile Management
 	UpdateProfile(c context.Context, userID, bio string, profilePicFile multipart.File, profilePicHeader *multipart.FileHeader) (*domain.User, error)
+	UpdateUsername(c context.Context, userID, newUsername string) (*domain.User, error)
+	RequestEmailChange(c context.Context, userID, newEmail string) error
+	ConfirmEmailChange(c context.Context, changeTokenValue string) error
 	GetProfile(c context.Context, userID string) (*domain.User, error)
+	// UnlinkProvider detaches an external sign-in provider, leaving the account to its local password.
+	UnlinkProvider(c context.Context, userID string) error
+	// GetPublicProfile returns what anyone may see of an activated user, with their follow counts.
+	GetPublicProfile(c context.Context, userID string) (*domain.PublicProfile, error)
+	// SuggestUsernames completes an @mention. A limit of zero or less, or one above the
+	// maximum, gets the maximum.
+	SuggestUsernames(c context.Context, prefix string, limit int) ([]*domain.UsernameSuggestion, error)
 
 	// User Management
 	SearchAndFilter(ctx context.Context, options domain.UserSearchFilterOptions) ([]*domain.User, int64, error)
 	SetUserRole(ctx context.Context, actorUserID string, actorRole domain.Role, targetUserID string, newRole domain.Role) (*domain.User, error)
+	RevokeTokens(ctx context.Context, actorUserID string, actorRole domain.Role, targetUserID string) (int64, error)
+	Impersonate(ctx context.Context, actorUserID string, actorRole domain.Role, targetUserID string) (token string, expiresAt time.Time, err error)
+	ExportUsers(ctx context.Context, options domain.UserSearchFilterOptions, fn func(*domain.User) error) error
 }
 
+// Default lifetimes of the single-use tokens sent by email.
+const (
+	DefaultActivationTokenTTL = 24 * time.Hour
+	DefaultResetTokenTTL      = 15 * time.Minute
+)
+
+// ImpersonationTokenTTL keeps impersonation sessions short; they cannot be refreshed.
+const ImpersonationTokenTTL = 15 * time.Minute
+
+// MaxUsernameSuggestions caps how many users one mention lookup returns, so the endpoint
+// can't page through every username.
+const MaxUsernameSuggestions = 10
+
 type userUsecase struct {
 	userRepo             UserRepository
 	tokenRepo            TokenRepository
@@ -60,10 +101,50 @@ type userUsecase struct {
 	emailService         infrastructure.EmailService
 	imageUploaderService domain.ImageUploaderService
 	contextTimeout       time.Duration
+
+	activationTokenTTL time.Duration
+	resetTokenTTL      time.Duration
+	auditRepo          domain.IAuditRepository
+	followRepo         domain.IFollowRepository
+}
+
+// UserUsecaseOption configures optional behaviour of the user usecase.
+type UserUsecaseOption func(*userUsecase)
+
+// WithActivationTokenTTL sets how long account activation links stay valid.
+func WithActivationTokenTTL(ttl time.Duration) UserUsecaseOption {
+	return func(uc *userUsecase) {
+		if ttl > 0 {
+			uc.activationTokenTTL = ttl
+		}
+	}
+}
+
+// WithResetTokenTTL sets how long password reset links stay valid.
+func WithResetTokenTTL(ttl time.Duration) UserUsecaseOption {
+	return func(uc *userUsecase) {
+		if ttl > 0 {
+			uc.resetTokenTTL = ttl
+		}
+	}
+}
+
+// WithUserAuditLog records role changes, token revocations and impersonations in the audit log.
+func WithUserAuditLog(auditRepo domain.IAuditRepository) UserUsecaseOption {
+	return func(uc *userUsecase) {
+		uc.auditRepo = auditRepo
+	}
+}
+
+// WithFollowCounts shows follower and following counts on public profiles.
+func WithFollowCounts(followRepo domain.IFollowRepository) UserUsecaseOption {
+	return func(uc *userUsecase) {
+		uc.followRepo = followRepo
+	}
 }
 
-func NewUserUsecase(ur UserRepository, ps infrastructure.PasswordService, js infrastructure.JWTService, tr TokenRepository, es infrastructure.EmailService, ius domain.ImageUploaderService, timeout time.Duration) UserUsecase {
-	return &userUsecase{
+func NewUserUsecase(ur UserRepository, ps infrastructure.PasswordService, js infrastructure.JWTService, tr TokenRepository, es infrastructure.EmailService, ius domain.ImageUploaderService, timeout time.Duration, opts ...UserUsecaseOption) UserUsecase {
+	uc := &userUsecase{
 		userRepo:             ur,
 		tokenRepo:            tr,
 		passwordService:      ps,
@@ -71,7 +152,13 @@ func NewUserUsecase(ur UserRepository, ps infrastructure.PasswordService, js inf
 		emailService:         es,
 		imageUploaderService: ius,
 		contextTimeout:       timeout,
+		activationTokenTTL:   DefaultActivationTokenTTL,
+		resetTokenTTL:        DefaultResetTokenTTL,
 	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
 }
 
 func (uc *userUsecase) Register(c context.Context, user *domain.User) error {
@@ -110,7 +197,7 @@ func (uc *userUsecase) Register(c context.Context, user *domain.User) error {
 		UserID:    user.ID,
 		Type:      domain.TokenTypeActivation,
 		Value:     primitive.NewObjectID().Hex(),
-		ExpiresAt: time.Now().Add(24 * time.Hour),
+		ExpiresAt: time.Now().Add(uc.activationTokenTTL),
 	}
 
 	if err := uc.tokenRepo.Store(ctx, activationToken); err != nil {
@@ -177,9 +264,31 @@ func (uc *userUsecase) Login(c context.Context, identifier, password string) (st
 		return "", "", domain.ErrAuthenticationFailed
 	}
 
+	uc.rehashIfNeeded(ctx, user, password)
+
 	return uc.generateAndStoreTokenPair(ctx, user)
 }
 
+// rehashIfNeeded upgrades a password hash made with a lower cost than the configured one.
+// The plain-text password is only known at login, so this is the only place it can happen.
+// A failure is logged and otherwise ignored: the old hash still works.
+func (uc *userUsecase) rehashIfNeeded(ctx context.Context, user *domain.User, password string) {
+	if !uc.passwordService.NeedsRehash(*user.Password) {
+		return
+	}
+	hashed, err := uc.passwordService.HashPassword(password)
+	if err != nil {
+		log.Printf("Failed to rehash password for user %s: %v", user.ID, err)
+		return
+	}
+	oldHash := user.Password
+	user.Password = &hashed
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		user.Password = oldHash
+		log.Printf("Failed to save rehashed password for user %s: %v", user.ID, err)
+	}
+}
+
 func (uc *userUsecase) Logout(c context.Context, refreshToken string) error {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
@@ -228,6 +337,40 @@ func (uc *userUsecase) RefreshAccessToken(c context.Context, accessToken, refres
 	return uc.generateAndStoreTokenPair(ctx, user)
 }
 
+// IntrospectToken reports whether an access token is currently accepted and what it claims.
+// A token that is malformed, expired, revoked or not an access token is reported as inactive.
+func (uc *userUsecase) IntrospectToken(c context.Context, accessToken string) (*domain.TokenIntrospection, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	inactive := &domain.TokenIntrospection{Active: false}
+
+	claims, err := uc.jwtService.ValidateToken(accessToken)
+	if err != nil || claims == nil {
+		return inactive, nil
+	}
+
+	// Logging out, refreshing and revoking delete the stored record, so its absence means revoked.
+	dbToken, err := uc.tokenRepo.GetByValue(ctx, accessToken)
+	if err != nil || dbToken == nil || dbToken.Type != domain.TokenTypeAccessToken || dbToken.UserID != claims.UserID {
+		return inactive, nil
+	}
+
+	introspection := &domain.TokenIntrospection{
+		Active:  true,
+		TokenID: claims.ID,
+		UserID:  claims.UserID,
+		Role:    claims.Role,
+	}
+	if claims.IssuedAt != nil {
+		introspection.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		introspection.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return introspection, nil
+}
+
 func (uc *userUsecase) ForgetPassword(c context.Context, email string) error {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
@@ -241,7 +384,7 @@ func (uc *userUsecase) ForgetPassword(c context.Context, email string) error {
 		return nil
 	}
 
-	if err := uc.tokenRepo.DeleteByUserID(ctx, user.ID, domain.TokenTypePasswordReset); err != nil {
+	if _, err := uc.tokenRepo.DeleteByUserID(ctx, user.ID, domain.TokenTypePasswordReset); err != nil {
 		return err
 	}
 	resetToken := &domain.Token{
@@ -249,7 +392,7 @@ func (uc *userUsecase) ForgetPassword(c context.Context, email string) error {
 		UserID:    user.ID,
 		Type:      domain.TokenTypePasswordReset,
 		Value:     primitive.NewObjectID().Hex(),
-		ExpiresAt: time.Now().Add(15 * time.Minute),
+		ExpiresAt: time.Now().Add(uc.resetTokenTTL),
 	}
 
 	if err := uc.tokenRepo.Store(ctx, resetToken); err != nil {
@@ -320,6 +463,158 @@ func (uc *userUsecase) UpdateProfile(c context.Context, userID, bio string, prof
 	return user, nil
 }
 
+// UnlinkProvider turns an account linked to an external provider back into a local one. This is
+// only allowed when the user has a password to sign in with afterwards.
+func (uc *userUsecase) UnlinkProvider(c context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return domain.ErrUserNotFound
+	}
+	if user.Provider == domain.ProviderLocal && user.ProviderID == "" {
+		return nil // Nothing is linked.
+	}
+	if !user.HasPassword() {
+		return domain.ErrCannotUnlink
+	}
+
+	user.Provider = domain.ProviderLocal
+	user.ProviderID = ""
+	user.UpdatedAt = time.Now()
+	return uc.userRepo.Update(ctx, user)
+}
+
+// UpdateUsername changes the user's username, which must be well-formed and not taken by anyone else.
+func (uc *userUsecase) UpdateUsername(c context.Context, userID, newUsername string) (*domain.User, error) {
+	newUsername = strings.TrimSpace(newUsername)
+	if err := domain.ValidateUsername(newUsername); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	if user.Username == newUsername {
+		return user, nil
+	}
+
+	existingUser, err := uc.userRepo.GetByUsername(ctx, newUsername)
+	if err != nil {
+		return nil, err
+	}
+	if existingUser != nil {
+		return nil, domain.ErrUsernameExists
+	}
+
+	user.Username = newUsername
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		// Someone else claimed the name between the check and the update.
+		if errors.Is(err, ErrConflict) {
+			return nil, domain.ErrUsernameExists
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// RequestEmailChange emails a confirmation link to newEmail. The account keeps using its current
+// address until ConfirmEmailChange is called with the token from that link, so a mistyped
+// address can't lock the user out. A new request replaces any earlier pending change.
+func (uc *userUsecase) RequestEmailChange(c context.Context, userID, newEmail string) error {
+	newEmail = strings.TrimSpace(newEmail)
+	if err := domain.ValidateEmail(newEmail); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil {
+		return domain.ErrUserNotFound
+	}
+	if user.Provider != domain.ProviderLocal {
+		return domain.ErrOAuthUser
+	}
+
+	existingUser, err := uc.userRepo.GetByEmail(ctx, newEmail)
+	if err != nil {
+		return err
+	}
+	if existingUser != nil || strings.EqualFold(user.Email, newEmail) {
+		return domain.ErrEmailExists
+	}
+
+	if _, err := uc.tokenRepo.DeleteByUserID(ctx, user.ID, domain.TokenTypeEmailChange); err != nil {
+		return err
+	}
+	user.PendingEmail = newEmail
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	// Confirming proves ownership of the address just like activation does, so the link lives as long.
+	changeToken := &domain.Token{
+		ID:        primitive.NewObjectID().Hex(),
+		UserID:    user.ID,
+		Type:      domain.TokenTypeEmailChange,
+		Value:     primitive.NewObjectID().Hex(),
+		ExpiresAt: time.Now().Add(uc.activationTokenTTL),
+	}
+	if err := uc.tokenRepo.Store(ctx, changeToken); err != nil {
+		return err
+	}
+
+	return uc.emailService.SendEmailChangeEmail(newEmail, user.Username, changeToken.Value)
+}
+
+// ConfirmEmailChange switches the account to the pending email address the token was sent to.
+func (uc *userUsecase) ConfirmEmailChange(c context.Context, changeTokenValue string) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	changeToken, err := uc.tokenRepo.GetByValue(ctx, changeTokenValue)
+	if err != nil || changeToken == nil || changeToken.IsExpired() || changeToken.Type != domain.TokenTypeEmailChange {
+		return domain.ErrInvalidEmailChangeToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, changeToken.UserID)
+	if err != nil || user == nil {
+		return domain.ErrUserNotFound
+	}
+	if user.PendingEmail == "" {
+		return domain.ErrInvalidEmailChangeToken
+	}
+
+	// The address may have been registered by someone else since the change was requested.
+	existingUser, err := uc.userRepo.GetByEmail(ctx, user.PendingEmail)
+	if err != nil {
+		return err
+	}
+	if existingUser != nil && existingUser.ID != user.ID {
+		return domain.ErrEmailExists
+	}
+
+	user.Email = user.PendingEmail
+	user.PendingEmail = ""
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		if errors.Is(err, ErrConflict) {
+			return domain.ErrEmailExists
+		}
+		return err
+	}
+	return uc.tokenRepo.Delete(ctx, changeToken.ID)
+}
+
 func (uc *userUsecase) GetProfile(c context.Context, userID string) (*domain.User, error) {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
@@ -332,18 +627,62 @@ func (uc *userUsecase) GetProfile(c context.Context, userID string) (*domain.Use
 	return user, nil
 }
 
+func (uc *userUsecase) GetPublicProfile(c context.Context, userID string) (*domain.PublicProfile, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil || user == nil || !user.IsActive {
+		return nil, domain.ErrUserNotFound
+	}
+
+	profile := &domain.PublicProfile{User: user}
+	if uc.followRepo != nil {
+		counts, err := uc.followRepo.GetCounts(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		profile.Counts = *counts
+	}
+	return profile, nil
+}
+
+// SuggestUsernames offers active users for an @mention. A leading "@" is ignored, and a prefix
+// that no mentionable username could start with gets no suggestions.
+func (uc *userUsecase) SuggestUsernames(c context.Context, prefix string, limit int) ([]*domain.UsernameSuggestion, error) {
+	prefix = strings.TrimPrefix(strings.TrimSpace(prefix), "@")
+	if domain.ValidateUsername(prefix) != nil {
+		return []*domain.UsernameSuggestion{}, nil
+	}
+	if limit <= 0 || limit > MaxUsernameSuggestions {
+		limit = MaxUsernameSuggestions
+	}
+
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	return uc.userRepo.SuggestUsernames(ctx, prefix, int64(limit))
+}
+
 func (uc *userUsecase) generateAndStoreTokenPair(ctx context.Context, user *domain.User) (string, string, error) {
 	accessToken, accessClaims, err := uc.jwtService.GenerateAccessToken(user.ID, user.Role)
 	if err != nil {
 		return "", "", err
 	}
 	fmt.Println(accessClaims.ExpiresAt)
+	refreshToken, refreshClaims, err := uc.jwtService.GenerateRefreshToken(user.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// The access token's record is kept until its refresh token expires, not the JWT itself:
+	// refreshing looks it up after the JWT has expired, and the TTL index removes it afterwards.
 	accessTokenModel := &domain.Token{
 		ID:        accessClaims.ID,
 		UserID:    user.ID,
 		Type:      domain.TokenTypeAccessToken,
 		Value:     accessToken,
-		ExpiresAt: accessClaims.ExpiresAt.Time,
+		ExpiresAt: refreshClaims.ExpiresAt.Time,
 	}
 
 	if err := uc.tokenRepo.Store(ctx, accessTokenModel); err != nil {
@@ -351,10 +690,6 @@ func (uc *userUsecase) generateAndStoreTokenPair(ctx context.Context, user *doma
 		return "", "", err
 	}
 
-	refreshToken, refreshClaims, err := uc.jwtService.GenerateRefreshToken(user.ID)
-	if err != nil {
-		return "", "", err
-	}
 	refreshTokenModel := &domain.Token{
 		ID:        refreshClaims.ID,
 		UserID:    user.ID,
@@ -387,6 +722,13 @@ func (uc *userUsecase) SearchAndFilter(c context.Context, options domain.UserSea
 	return uc.userRepo.SearchAndFilter(ctx, options)
 }
 
+// ExportUsers streams every user matching the filter to fn, ignoring pagination.
+// No usecase timeout is applied: a large export can legitimately outlive it,
+// and the caller's context is cancelled if the client goes away.
+func (uc *userUsecase) ExportUsers(ctx context.Context, options domain.UserSearchFilterOptions, fn func(*domain.User) error) error {
+	return uc.userRepo.StreamByFilter(ctx, options, fn)
+}
+
 func (uc *userUsecase) SetUserRole(ctx context.Context, actorUserID string, actorRole domain.Role, targetUserID string, newRole domain.Role) (*domain.User, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
 	defer cancel()
@@ -413,6 +755,7 @@ func (uc *userUsecase) SetUserRole(ctx context.Context, actorUserID string, acto
 		return targetUser, nil
 	}
 
+	oldRole := targetUser.Role
 	targetUser.Role = newRole
 	targetUser.UpdatedAt = time.Now()
 
@@ -420,5 +763,80 @@ func (uc *userUsecase) SetUserRole(ctx context.Context, actorUserID string, acto
 		return nil, err
 	}
 
+	recordAudit(ctx, uc.auditRepo, &domain.AuditEntry{
+		ActorID:  actorUserID,
+		Action:   domain.AuditActionRoleChange,
+		TargetID: targetUserID,
+		Details:  map[string]string{"from": string(oldRole), "to": string(newRole)},
+	})
 	return targetUser, nil
 }
+
+// RevokeTokens deletes every access and refresh token of a user, forcing them to log in again.
+// It returns how many tokens were revoked.
+func (uc *userUsecase) RevokeTokens(ctx context.Context, actorUserID string, actorRole domain.Role, targetUserID string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if actorRole != domain.RoleAdmin {
+		return 0, domain.ErrPermissionDenied
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, targetUserID); err != nil {
+		return 0, err
+	}
+
+	var revoked int64
+	for _, tokenType := range []domain.TokenType{domain.TokenTypeRefresh, domain.TokenTypeAccessToken} {
+		n, err := uc.tokenRepo.DeleteByUserID(ctx, targetUserID, tokenType)
+		if err != nil {
+			return 0, err
+		}
+		revoked += n
+	}
+
+	recordAudit(ctx, uc.auditRepo, &domain.AuditEntry{
+		ActorID:  actorUserID,
+		Action:   domain.AuditActionRevokeTokens,
+		TargetID: targetUserID,
+		Details:  map[string]string{"revoked": strconv.FormatInt(revoked, 10)},
+	})
+	return revoked, nil
+}
+
+// Impersonate issues an admin a short-lived, read-only access token for another user,
+// so support can see what that user sees. Admins cannot impersonate themselves or other admins.
+func (uc *userUsecase) Impersonate(ctx context.Context, actorUserID string, actorRole domain.Role, targetUserID string) (string, time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.contextTimeout)
+	defer cancel()
+
+	if actorRole != domain.RoleAdmin || actorUserID == targetUserID {
+		return "", time.Time{}, domain.ErrPermissionDenied
+	}
+
+	target, err := uc.userRepo.GetByID(ctx, targetUserID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if target == nil {
+		return "", time.Time{}, domain.ErrUserNotFound
+	}
+	if target.Role == domain.RoleAdmin {
+		return "", time.Time{}, domain.ErrPermissionDenied
+	}
+
+	token, claims, err := uc.jwtService.GenerateImpersonationToken(target.ID, target.Role, actorUserID, ImpersonationTokenTTL)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := claims.ExpiresAt.Time
+	log.Printf("AUDIT: admin %s started impersonating user %s (token %s, expires %s)", actorUserID, target.ID, claims.ID, expiresAt.Format(time.RFC3339))
+	recordAudit(ctx, uc.auditRepo, &domain.AuditEntry{
+		ActorID:  actorUserID,
+		Action:   domain.AuditActionImpersonate,
+		TargetID: target.ID,
+		Details:  map[string]string{"token_id": claims.ID, "expires_at": expiresAt.Format(time.RFC3339)},
+	})
+	return token, expiresAt, nil
+}
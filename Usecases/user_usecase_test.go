@@ -14,6 +14,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // --- MOCK DEFINITIONS ---
@@ -49,6 +50,13 @@ func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) erro
 	args := m.Called(ctx, user)
 	return args.Error(0)
 }
+func (m *MockUserRepository) FindUserIDsByProvider(ctx context.Context, provider domain.AuthProvider) ([]string, error) {
+	args := m.Called(ctx, provider)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
 func (m *MockUserRepository) FindByProviderID(ctx context.Context, provider domain.AuthProvider, providerID string) (*domain.User, error) {
 	args := m.Called(ctx, provider, providerID)
 	if args.Get(0) == nil {
@@ -56,6 +64,27 @@ func (m *MockUserRepository) FindByProviderID(ctx context.Context, provider doma
 	}
 	return args.Get(0).(*domain.User), args.Error(1)
 }
+func (m *MockUserRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.User, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+func (m *MockUserRepository) SuggestUsernames(ctx context.Context, prefix string, limit int64) ([]*domain.UsernameSuggestion, error) {
+	args := m.Called(ctx, prefix, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.UsernameSuggestion), args.Error(1)
+}
+func (m *MockUserRepository) GetByUsernames(ctx context.Context, usernames []string) ([]*domain.User, error) {
+	args := m.Called(ctx, usernames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
 func (m *MockUserRepository) FindUserIDsByName(ctx context.Context, name string) ([]string, error) {
 	args := m.Called(ctx, name)
 	if args.Get(0) == nil {
@@ -70,6 +99,18 @@ func (m *MockUserRepository) SearchAndFilter(ctx context.Context, options domain
 	}
 	return args.Get(0).([]*domain.User), int64(args.Int(1)), args.Error(2)
 }
+func (m *MockUserRepository) StreamByFilter(ctx context.Context, options domain.UserSearchFilterOptions, fn func(*domain.User) error) error {
+	args := m.Called(ctx, options, fn)
+	// Feed any canned users through the callback, like a real cursor would.
+	if users, ok := args.Get(0).([]*domain.User); ok {
+		for _, u := range users {
+			if err := fn(u); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
 
 type MockTokenRepository struct{ mock.Mock }
 
@@ -95,9 +136,20 @@ func (m *MockTokenRepository) Delete(ctx context.Context, tokenID string) error
 	args := m.Called(ctx, tokenID)
 	return args.Error(0)
 }
-func (m *MockTokenRepository) DeleteByUserID(ctx context.Context, userID string, tokenType domain.TokenType) error {
+func (m *MockTokenRepository) GetByUserID(ctx context.Context, userID string, tokenType domain.TokenType) ([]*domain.Token, error) {
 	args := m.Called(ctx, userID, tokenType)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Token), args.Error(1)
+}
+func (m *MockTokenRepository) DeleteByUserID(ctx context.Context, userID string, tokenType domain.TokenType) (int64, error) {
+	args := m.Called(ctx, userID, tokenType)
+	return args.Get(0).(int64), args.Error(1)
+}
+func (m *MockTokenRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
 }
 
 type MockPasswordService struct{ mock.Mock }
@@ -110,6 +162,10 @@ func (m *MockPasswordService) ComparePassword(hashedPassword, password string) e
 	args := m.Called(hashedPassword, password)
 	return args.Error(0)
 }
+func (m *MockPasswordService) NeedsRehash(hashedPassword string) bool {
+	args := m.Called(hashedPassword)
+	return args.Bool(0)
+}
 
 type MockJWTService struct{ mock.Mock }
 
@@ -127,6 +183,13 @@ func (m *MockJWTService) GenerateRefreshToken(userID string) (string, *infrastru
 	}
 	return args.String(0), args.Get(1).(*infrastructure.JWTClaims), args.Error(2)
 }
+func (m *MockJWTService) GenerateImpersonationToken(userID string, role domain.Role, adminID string, ttl time.Duration) (string, *infrastructure.JWTClaims, error) {
+	args := m.Called(userID, role, adminID, ttl)
+	if args.Get(1) == nil {
+		return args.String(0), nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).(*infrastructure.JWTClaims), args.Error(2)
+}
 func (m *MockJWTService) ValidateToken(tokenString string) (*infrastructure.JWTClaims, error) {
 	args := m.Called(tokenString)
 	if args.Get(0) == nil {
@@ -145,6 +208,13 @@ func (m *MockJWTService) GetRefreshTokenExpiry() time.Duration {
 	args := m.Called()
 	return args.Get(0).(time.Duration)
 }
+func (m *MockJWTService) JWKS() *infrastructure.JWKSet {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*infrastructure.JWKSet)
+}
 
 type MockEmailService struct{ mock.Mock }
 
@@ -156,6 +226,18 @@ func (m *MockEmailService) SendActivationEmail(to, user, token string) error {
 	args := m.Called(to, user, token)
 	return args.Error(0)
 }
+func (m *MockEmailService) SendMentionEmail(to, user, mentionedBy, blogID string) error {
+	args := m.Called(to, user, mentionedBy, blogID)
+	return args.Error(0)
+}
+func (m *MockEmailService) SendNewFollowerEmail(to, user, followedBy, followerID string) error {
+	args := m.Called(to, user, followedBy, followerID)
+	return args.Error(0)
+}
+func (m *MockEmailService) SendEmailChangeEmail(to, user, token string) error {
+	args := m.Called(to, user, token)
+	return args.Error(0)
+}
 
 type MockImageUploaderService struct{ mock.Mock }
 
@@ -200,6 +282,35 @@ func TestUserUsecase_Register(t *testing.T) {
 		mockPassSvc.AssertExpectations(t)
 		mockEmailSvc.AssertExpectations(t)
 	})
+
+	t.Run("Success - Uses Configured Activation Expiry", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockPassSvc := new(MockPasswordService)
+		mockEmailSvc := new(MockEmailService)
+		uc := usecases.NewUserUsecase(mockUserRepo, mockPassSvc, nil, mockTokenRepo, mockEmailSvc, nil, 2*time.Second,
+			usecases.WithActivationTokenTTL(2*time.Hour))
+
+		password := "password123"
+		user := &domain.User{Username: "test", Email: "test@test.com", Password: &password}
+
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(nil, nil).Once()
+		mockUserRepo.On("GetByUsername", mock.Anything, user.Username).Return(nil, nil).Once()
+		mockPassSvc.On("HashPassword", *user.Password).Return("hashed_password", nil).Once()
+		mockUserRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+		var stored *domain.Token
+		mockTokenRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Token")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.Token) }).Return(nil).Once()
+		mockEmailSvc.On("SendActivationEmail", user.Email, user.Username, mock.AnythingOfType("string")).Return(nil).Once()
+
+		err := uc.Register(context.Background(), user)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, stored) {
+			assert.Equal(t, domain.TokenTypeActivation, stored.Type)
+			assert.WithinDuration(t, time.Now().Add(2*time.Hour), stored.ExpiresAt, 5*time.Second)
+		}
+	})
 }
 
 func TestUserUsecase_Login(t *testing.T) {
@@ -225,6 +336,7 @@ func TestUserUsecase_Login(t *testing.T) {
 
 		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
 		mockPassSvc.On("ComparePassword", *user.Password, "password123").Return(nil).Once()
+		mockPassSvc.On("NeedsRehash", *user.Password).Return(false).Once()
 		mockJwtSvc.On("GenerateAccessToken", user.ID, user.Role).Return("access.token", accessClaims, nil).Once()
 		mockJwtSvc.On("GenerateRefreshToken", user.ID).Return("refresh.token", refreshClaims, nil).Once()
 		mockTokenRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Token")).Return(nil).Twice()
@@ -240,6 +352,35 @@ func TestUserUsecase_Login(t *testing.T) {
 		mockTokenRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success - Access Token Record Expires With Refresh Token", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockPassSvc := new(MockPasswordService)
+		mockJwtSvc := new(MockJWTService)
+		uc := usecases.NewUserUsecase(mockUserRepo, mockPassSvc, mockJwtSvc, mockTokenRepo, nil, nil, 2*time.Second)
+
+		stored := map[domain.TokenType]*domain.Token{}
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockPassSvc.On("ComparePassword", *user.Password, "password123").Return(nil).Once()
+		mockPassSvc.On("NeedsRehash", *user.Password).Return(false).Once()
+		mockJwtSvc.On("GenerateAccessToken", user.ID, user.Role).Return("access.token", accessClaims, nil).Once()
+		mockJwtSvc.On("GenerateRefreshToken", user.ID).Return("refresh.token", refreshClaims, nil).Once()
+		mockTokenRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Token")).
+			Run(func(args mock.Arguments) {
+				token := args.Get(1).(*domain.Token)
+				stored[token.Type] = token
+			}).Return(nil).Twice()
+
+		_, _, err := uc.Login(context.Background(), user.Email, "password123")
+
+		assert.NoError(t, err)
+		// Refreshing needs the access token's record after the JWT expires, so the TTL index
+		// must not remove it before the refresh token.
+		assert.Equal(t, refreshClaims.ExpiresAt.Time, stored[domain.TokenTypeAccessToken].ExpiresAt)
+		assert.Equal(t, refreshClaims.ExpiresAt.Time, stored[domain.TokenTypeRefresh].ExpiresAt)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
 	t.Run("Success - Login with Username", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
 		mockTokenRepo := new(MockTokenRepository)
@@ -249,6 +390,7 @@ func TestUserUsecase_Login(t *testing.T) {
 
 		mockUserRepo.On("GetByUsername", mock.Anything, user.Username).Return(user, nil).Once()
 		mockPassSvc.On("ComparePassword", *user.Password, "password123").Return(nil).Once()
+		mockPassSvc.On("NeedsRehash", *user.Password).Return(false).Once()
 		mockJwtSvc.On("GenerateAccessToken", user.ID, user.Role).Return("access.token", accessClaims, nil).Once()
 		mockJwtSvc.On("GenerateRefreshToken", user.ID).Return("refresh.token", refreshClaims, nil).Once()
 		mockTokenRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Token")).Return(nil).Twice()
@@ -320,6 +462,64 @@ func TestUserUsecase_Login(t *testing.T) {
 		assert.Equal(t, domain.ErrAccountNotActive, err)
 		mockUserRepo.AssertExpectations(t)
 	})
+
+	t.Run("Success - Under-Cost Hash Is Rehashed", func(t *testing.T) {
+		// Real bcrypt, so the test covers detecting the cost as well as saving the new hash.
+		weakHash, err := infrastructure.NewPasswordService(infrastructure.WithCost(bcrypt.MinCost)).HashPassword("password123")
+		assert.NoError(t, err)
+		weakUser := &domain.User{ID: "user-weak", Email: "weak@test.com", Password: &weakHash, IsActive: true, Role: domain.RoleUser, Provider: domain.ProviderLocal}
+
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockJwtSvc := new(MockJWTService)
+		passwords := infrastructure.NewPasswordService(infrastructure.WithCost(bcrypt.MinCost + 1))
+		uc := usecases.NewUserUsecase(mockUserRepo, passwords, mockJwtSvc, mockTokenRepo, nil, nil, 2*time.Second)
+
+		var savedHash string
+		mockUserRepo.On("GetByEmail", mock.Anything, weakUser.Email).Return(weakUser, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.User")).
+			Run(func(args mock.Arguments) { savedHash = *args.Get(1).(*domain.User).Password }).
+			Return(nil).Once()
+		mockJwtSvc.On("GenerateAccessToken", weakUser.ID, weakUser.Role).Return("access.token", accessClaims, nil).Once()
+		mockJwtSvc.On("GenerateRefreshToken", weakUser.ID).Return("refresh.token", refreshClaims, nil).Once()
+		mockTokenRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Token")).Return(nil).Twice()
+
+		_, _, err = uc.Login(context.Background(), weakUser.Email, "password123")
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		cost, err := bcrypt.Cost([]byte(savedHash))
+		assert.NoError(t, err)
+		assert.Equal(t, bcrypt.MinCost+1, cost, "The password should be saved with the configured cost")
+		assert.NoError(t, passwords.ComparePassword(savedHash, "password123"))
+	})
+
+	t.Run("Success - Failed Rehash Does Not Block Login", func(t *testing.T) {
+		oldHash := "old-hash"
+		weakUser := &domain.User{ID: "user-weak", Email: "weak@test.com", Password: &oldHash, IsActive: true, Role: domain.RoleUser, Provider: domain.ProviderLocal}
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockPassSvc := new(MockPasswordService)
+		mockJwtSvc := new(MockJWTService)
+		uc := usecases.NewUserUsecase(mockUserRepo, mockPassSvc, mockJwtSvc, mockTokenRepo, nil, nil, 2*time.Second)
+
+		mockUserRepo.On("GetByEmail", mock.Anything, weakUser.Email).Return(weakUser, nil).Once()
+		mockPassSvc.On("ComparePassword", oldHash, "password123").Return(nil).Once()
+		mockPassSvc.On("NeedsRehash", oldHash).Return(true).Once()
+		mockPassSvc.On("HashPassword", "password123").Return("new-hash", nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.User")).Return(errors.New("database write error")).Once()
+		mockJwtSvc.On("GenerateAccessToken", weakUser.ID, weakUser.Role).Return("access.token", accessClaims, nil).Once()
+		mockJwtSvc.On("GenerateRefreshToken", weakUser.ID).Return("refresh.token", refreshClaims, nil).Once()
+		mockTokenRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Token")).Return(nil).Twice()
+
+		access, _, err := uc.Login(context.Background(), weakUser.Email, "password123")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "access.token", access)
+		assert.Equal(t, "old-hash", *weakUser.Password, "The unsaved hash must not linger on the user")
+		mockPassSvc.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
 }
 
 func TestUserUsecase_Logout(t *testing.T) {
@@ -357,6 +557,84 @@ func TestUserUsecase_ActivateAccount(t *testing.T) {
 	})
 }
 
+func TestUserUsecase_IntrospectToken(t *testing.T) {
+	// A real JWT service, so expired and malformed tokens are exercised for real.
+	jwtSvc := infrastructure.NewJWTService("introspect-secret", "test-issuer", 15*time.Minute, 24*time.Hour)
+	expiredJwtSvc := infrastructure.NewJWTService("introspect-secret", "test-issuer", -5*time.Minute, 24*time.Hour)
+	userID := "user-123"
+
+	t.Run("Success - Valid Token Is Active", func(t *testing.T) {
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(nil, nil, jwtSvc, mockTokenRepo, nil, nil, 2*time.Second)
+		accessToken, claims, err := jwtSvc.GenerateAccessToken(userID, domain.RoleAdmin)
+		assert.NoError(t, err)
+		stored := &domain.Token{ID: claims.ID, UserID: userID, Type: domain.TokenTypeAccessToken, Value: accessToken}
+		mockTokenRepo.On("GetByValue", mock.Anything, accessToken).Return(stored, nil).Once()
+
+		result, err := uc.IntrospectToken(context.Background(), accessToken)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Active)
+		assert.Equal(t, claims.ID, result.TokenID)
+		assert.Equal(t, userID, result.UserID)
+		assert.Equal(t, domain.RoleAdmin, result.Role)
+		assert.Equal(t, claims.ExpiresAt.Time, result.ExpiresAt)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Inactive - Expired Token", func(t *testing.T) {
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(nil, nil, jwtSvc, mockTokenRepo, nil, nil, 2*time.Second)
+		accessToken, _, err := expiredJwtSvc.GenerateAccessToken(userID, domain.RoleUser)
+		assert.NoError(t, err)
+
+		result, err := uc.IntrospectToken(context.Background(), accessToken)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &domain.TokenIntrospection{Active: false}, result, "No claims should be returned for an inactive token")
+		mockTokenRepo.AssertNotCalled(t, "GetByValue", mock.Anything, accessToken)
+	})
+
+	t.Run("Inactive - Malformed Token", func(t *testing.T) {
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(nil, nil, jwtSvc, mockTokenRepo, nil, nil, 2*time.Second)
+
+		result, err := uc.IntrospectToken(context.Background(), "not-a-jwt")
+
+		assert.NoError(t, err)
+		assert.False(t, result.Active)
+		mockTokenRepo.AssertNotCalled(t, "GetByValue", mock.Anything, "not-a-jwt")
+	})
+
+	t.Run("Inactive - Revoked Token", func(t *testing.T) {
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(nil, nil, jwtSvc, mockTokenRepo, nil, nil, 2*time.Second)
+		accessToken, _, err := jwtSvc.GenerateAccessToken(userID, domain.RoleUser)
+		assert.NoError(t, err)
+		mockTokenRepo.On("GetByValue", mock.Anything, accessToken).Return(nil, errors.New("token not found")).Once()
+
+		result, err := uc.IntrospectToken(context.Background(), accessToken)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Active)
+		assert.Empty(t, result.UserID)
+	})
+
+	t.Run("Inactive - Refresh Token", func(t *testing.T) {
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(nil, nil, jwtSvc, mockTokenRepo, nil, nil, 2*time.Second)
+		refreshToken, claims, err := jwtSvc.GenerateRefreshToken(userID)
+		assert.NoError(t, err)
+		stored := &domain.Token{ID: claims.ID, UserID: userID, Type: domain.TokenTypeRefresh, Value: refreshToken}
+		mockTokenRepo.On("GetByValue", mock.Anything, refreshToken).Return(stored, nil).Once()
+
+		result, err := uc.IntrospectToken(context.Background(), refreshToken)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Active)
+	})
+}
+
 func TestUserUsecase_ForgetPassword(t *testing.T) {
 	user := &domain.User{ID: "user-123", Email: "user@example.com", Username: "testuser", Provider: domain.ProviderLocal}
 
@@ -367,7 +645,7 @@ func TestUserUsecase_ForgetPassword(t *testing.T) {
 		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockEmailSvc, nil, 2*time.Second)
 
 		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
-		mockTokenRepo.On("DeleteByUserID", mock.Anything, user.ID, domain.TokenTypePasswordReset).Return(nil).Once()
+		mockTokenRepo.On("DeleteByUserID", mock.Anything, user.ID, domain.TokenTypePasswordReset).Return(int64(1), nil).Once()
 		mockTokenRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Token")).Return(nil).Once()
 		mockEmailSvc.On("SendPasswordResetEmail", user.Email, user.Username, mock.Anything).Return(nil).Once()
 
@@ -378,6 +656,51 @@ func TestUserUsecase_ForgetPassword(t *testing.T) {
 		mockEmailSvc.AssertExpectations(t)
 	})
 
+	t.Run("Success - Uses Configured Reset Expiry", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockEmailSvc := new(MockEmailService)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockEmailSvc, nil, 2*time.Second,
+			usecases.WithResetTokenTTL(45*time.Minute))
+
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockTokenRepo.On("DeleteByUserID", mock.Anything, user.ID, domain.TokenTypePasswordReset).Return(int64(1), nil).Once()
+		var stored *domain.Token
+		mockTokenRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Token")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.Token) }).Return(nil).Once()
+		mockEmailSvc.On("SendPasswordResetEmail", user.Email, user.Username, mock.Anything).Return(nil).Once()
+
+		err := uc.ForgetPassword(context.Background(), user.Email)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, stored) {
+			assert.Equal(t, domain.TokenTypePasswordReset, stored.Type)
+			assert.WithinDuration(t, time.Now().Add(45*time.Minute), stored.ExpiresAt, 5*time.Second)
+		}
+	})
+
+	t.Run("Non-Positive Expiry Keeps The Default", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockEmailSvc := new(MockEmailService)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockEmailSvc, nil, 2*time.Second,
+			usecases.WithResetTokenTTL(0))
+
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockTokenRepo.On("DeleteByUserID", mock.Anything, user.ID, domain.TokenTypePasswordReset).Return(int64(1), nil).Once()
+		var stored *domain.Token
+		mockTokenRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.Token")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.Token) }).Return(nil).Once()
+		mockEmailSvc.On("SendPasswordResetEmail", user.Email, user.Username, mock.Anything).Return(nil).Once()
+
+		err := uc.ForgetPassword(context.Background(), user.Email)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, stored) {
+			assert.WithinDuration(t, time.Now().Add(usecases.DefaultResetTokenTTL), stored.ExpiresAt, 5*time.Second)
+		}
+	})
+
 	t.Run("Success - Google User (No Action)", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
 		mockTokenRepo := new(MockTokenRepository)
@@ -496,6 +819,345 @@ func TestUserUsecase_UpdateProfile(t *testing.T) {
 	})
 }
 
+func TestUserUsecase_UpdateUsername(t *testing.T) {
+	userID := "user-123"
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Username: "old_name"}, nil).Once()
+		mockUserRepo.On("GetByUsername", mock.Anything, "new_name").Return(nil, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.ID == userID && u.Username == "new_name"
+		})).Return(nil).Once()
+
+		updatedUser, err := uc.UpdateUsername(context.Background(), userID, " new_name ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new_name", updatedUser.Username)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Invalid Format", func(t *testing.T) {
+		testCases := []struct {
+			username string
+			err      error
+		}{
+			{"", domain.ErrUsernameEmpty},
+			{strings.Repeat("a", 51), domain.ErrUsernameTooLong},
+			{"has space", domain.ErrUsernameInvalid},
+			{"dots.and-dashes", domain.ErrUsernameInvalid},
+		}
+		for _, tc := range testCases {
+			mockUserRepo := new(MockUserRepository)
+			uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+
+			_, err := uc.UpdateUsername(context.Background(), userID, tc.username)
+
+			assert.ErrorIs(t, err, tc.err, tc.username)
+			mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("Failure - Username Taken", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Username: "old_name"}, nil).Once()
+		mockUserRepo.On("GetByUsername", mock.Anything, "taken").Return(&domain.User{ID: "someone-else", Username: "taken"}, nil).Once()
+
+		_, err := uc.UpdateUsername(context.Background(), userID, "taken")
+
+		assert.ErrorIs(t, err, domain.ErrUsernameExists)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - Username Taken Concurrently", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Username: "old_name"}, nil).Once()
+		mockUserRepo.On("GetByUsername", mock.Anything, "new_name").Return(nil, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(usecases.ErrConflict).Once()
+
+		_, err := uc.UpdateUsername(context.Background(), userID, "new_name")
+
+		assert.ErrorIs(t, err, domain.ErrUsernameExists)
+	})
+
+	t.Run("Success - Unchanged Name Is A No-Op", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Username: "same"}, nil).Once()
+
+		_, err := uc.UpdateUsername(context.Background(), userID, "same")
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertNotCalled(t, "GetByUsername", mock.Anything, mock.Anything)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_UnlinkProvider(t *testing.T) {
+	userID := "user-123"
+	hashed := "hashed-password"
+
+	t.Run("Success - Has Password", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+		mockUserRepo.On("GetByID", mock.Anything, userID).
+			Return(&domain.User{ID: userID, Password: &hashed, Provider: domain.ProviderGoogle, ProviderID: "google-123"}, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.ID == userID && u.Provider == domain.ProviderLocal && u.ProviderID == "" && u.Password == &hashed
+		})).Return(nil).Once()
+
+		err := uc.UnlinkProvider(context.Background(), userID)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - No Password", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+		mockUserRepo.On("GetByID", mock.Anything, userID).
+			Return(&domain.User{ID: userID, Provider: domain.ProviderGoogle, ProviderID: "google-123"}, nil).Once()
+
+		err := uc.UnlinkProvider(context.Background(), userID)
+
+		assert.ErrorIs(t, err, domain.ErrCannotUnlink)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Nothing Linked Is A No-Op", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+		mockUserRepo.On("GetByID", mock.Anything, userID).
+			Return(&domain.User{ID: userID, Password: &hashed, Provider: domain.ProviderLocal}, nil).Once()
+
+		err := uc.UnlinkProvider(context.Background(), userID)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - User Not Found", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(nil, usecases.ErrNotFound).Once()
+
+		err := uc.UnlinkProvider(context.Background(), userID)
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestUserUsecase_EmailChange(t *testing.T) {
+	userID := "user-123"
+	newUser := func() *domain.User {
+		return &domain.User{ID: userID, Username: "alice", Email: "old@example.com", Provider: domain.ProviderLocal}
+	}
+
+	t.Run("Request - Success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockEmailSvc := new(MockEmailService)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockEmailSvc, nil, 2*time.Second)
+		var stored *domain.Token
+
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, "new@example.com").Return(nil, nil).Once()
+		mockTokenRepo.On("DeleteByUserID", mock.Anything, userID, domain.TokenTypeEmailChange).Return(int64(1), nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			// The old address stays active until the new one is confirmed.
+			return u.Email == "old@example.com" && u.PendingEmail == "new@example.com"
+		})).Return(nil).Once()
+		mockTokenRepo.On("Store", mock.Anything, mock.MatchedBy(func(tok *domain.Token) bool {
+			return tok.UserID == userID && tok.Type == domain.TokenTypeEmailChange && !tok.IsExpired()
+		})).Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.Token) }).Return(nil).Once()
+		mockEmailSvc.On("SendEmailChangeEmail", "new@example.com", "alice", mock.AnythingOfType("string")).Return(nil).Once()
+
+		err := uc.RequestEmailChange(context.Background(), userID, " new@example.com ")
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+		mockEmailSvc.AssertCalled(t, "SendEmailChangeEmail", "new@example.com", "alice", stored.Value)
+	})
+
+	t.Run("Request - Email Taken", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockEmailSvc := new(MockEmailService)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, mockEmailSvc, nil, 2*time.Second)
+
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(newUser(), nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, "taken@example.com").Return(&domain.User{ID: "someone-else"}, nil).Once()
+
+		err := uc.RequestEmailChange(context.Background(), userID, "taken@example.com")
+
+		assert.ErrorIs(t, err, domain.ErrEmailExists)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockTokenRepo.AssertNotCalled(t, "Store", mock.Anything, mock.Anything)
+		mockEmailSvc.AssertNotCalled(t, "SendEmailChangeEmail", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Request - Invalid Email", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+
+		err := uc.RequestEmailChange(context.Background(), userID, "not-an-email")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidEmailFormat)
+		mockUserRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Request - OAuth User", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+		user := newUser()
+		user.Provider = domain.ProviderGoogle
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+
+		err := uc.RequestEmailChange(context.Background(), userID, "new@example.com")
+
+		assert.ErrorIs(t, err, domain.ErrOAuthUser)
+	})
+
+	t.Run("Confirm - Success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, nil, nil, 2*time.Second)
+		token := &domain.Token{ID: "token-id", UserID: userID, Type: domain.TokenTypeEmailChange, ExpiresAt: time.Now().Add(time.Hour)}
+		user := newUser()
+		user.PendingEmail = "new@example.com"
+
+		mockTokenRepo.On("GetByValue", mock.Anything, "change.token").Return(token, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, "new@example.com").Return(nil, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Email == "new@example.com" && u.PendingEmail == ""
+		})).Return(nil).Once()
+		mockTokenRepo.On("Delete", mock.Anything, "token-id").Return(nil).Once()
+
+		err := uc.ConfirmEmailChange(context.Background(), "change.token")
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Confirm - Wrong Token Type", func(t *testing.T) {
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(new(MockUserRepository), nil, nil, mockTokenRepo, nil, nil, 2*time.Second)
+		token := &domain.Token{ID: "token-id", UserID: userID, Type: domain.TokenTypeActivation, ExpiresAt: time.Now().Add(time.Hour)}
+		mockTokenRepo.On("GetByValue", mock.Anything, "activation.token").Return(token, nil).Once()
+
+		err := uc.ConfirmEmailChange(context.Background(), "activation.token")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidEmailChangeToken)
+	})
+
+	t.Run("Confirm - Email Taken Since Request", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, nil, nil, 2*time.Second)
+		token := &domain.Token{ID: "token-id", UserID: userID, Type: domain.TokenTypeEmailChange, ExpiresAt: time.Now().Add(time.Hour)}
+		user := newUser()
+		user.PendingEmail = "new@example.com"
+
+		mockTokenRepo.On("GetByValue", mock.Anything, "change.token").Return(token, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, "new@example.com").Return(&domain.User{ID: "someone-else"}, nil).Once()
+
+		err := uc.ConfirmEmailChange(context.Background(), "change.token")
+
+		assert.ErrorIs(t, err, domain.ErrEmailExists)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_SuggestUsernames(t *testing.T) {
+	suggestions := []*domain.UsernameSuggestion{{ID: "user-1", Username: "alice"}}
+
+	t.Run("Success - Strips The Mention Sign", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+		mockUserRepo.On("SuggestUsernames", mock.Anything, "ali", int64(5)).Return(suggestions, nil).Once()
+
+		result, err := uc.SuggestUsernames(context.Background(), " @ali ", 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, suggestions, result)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Limit Is Capped", func(t *testing.T) {
+		for _, limit := range []int{0, -1, usecases.MaxUsernameSuggestions + 1} {
+			mockUserRepo := new(MockUserRepository)
+			uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+			mockUserRepo.On("SuggestUsernames", mock.Anything, "ali", int64(usecases.MaxUsernameSuggestions)).Return(suggestions, nil).Once()
+
+			_, err := uc.SuggestUsernames(context.Background(), "ali", limit)
+
+			assert.NoError(t, err)
+			mockUserRepo.AssertExpectations(t)
+		}
+	})
+
+	t.Run("Success - Unmentionable Prefix Matches Nobody", func(t *testing.T) {
+		for _, prefix := range []string{"", "@", "a.*", "al ice", strings.Repeat("a", 51)} {
+			mockUserRepo := new(MockUserRepository)
+			uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+
+			result, err := uc.SuggestUsernames(context.Background(), prefix, 5)
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Empty(t, result)
+			mockUserRepo.AssertNotCalled(t, "SuggestUsernames", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+}
+
+func TestUserUsecase_GetPublicProfile(t *testing.T) {
+	t.Run("Success - With Follow Counts", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockFollowRepo := new(MockFollowRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second, usecases.WithFollowCounts(mockFollowRepo))
+		user := &domain.User{ID: "user-1", Username: "alice", IsActive: true}
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(user, nil).Once()
+		mockFollowRepo.On("GetCounts", mock.Anything, "user-1").Return(&domain.FollowCounts{Followers: 3, Following: 5}, nil).Once()
+
+		profile, err := uc.GetPublicProfile(context.Background(), "user-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, user, profile.User)
+		assert.Equal(t, domain.FollowCounts{Followers: 3, Following: 5}, profile.Counts)
+	})
+
+	t.Run("Success - Counts Disabled", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1", IsActive: true}, nil).Once()
+
+		profile, err := uc.GetPublicProfile(context.Background(), "user-1")
+
+		assert.NoError(t, err)
+		assert.Zero(t, profile.Counts)
+	})
+
+	t.Run("Failure - Inactive User Is Hidden", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockFollowRepo := new(MockFollowRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second, usecases.WithFollowCounts(mockFollowRepo))
+		mockUserRepo.On("GetByID", mock.Anything, "user-1").Return(&domain.User{ID: "user-1"}, nil).Once()
+
+		_, err := uc.GetPublicProfile(context.Background(), "user-1")
+
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockFollowRepo.AssertNotCalled(t, "GetCounts", mock.Anything, mock.Anything)
+	})
+}
+
 func TestUserUsecase_SearchAndFilter(t *testing.T) {
 	t.Run("Success - Basic Search with Defaults", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
@@ -600,6 +1262,44 @@ func TestUserUsecase_SetUserRole(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success - Role change is written to the audit log", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second, usecases.WithUserAuditLog(mockAuditRepo))
+		targetUser := &domain.User{ID: "target-789", Role: domain.RoleUser}
+
+		mockUserRepo.On("GetByID", mock.Anything, "target-789").Return(targetUser, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		mockAuditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *domain.AuditEntry) bool {
+			return e.ActorID == adminUser.ID &&
+				e.Action == domain.AuditActionRoleChange &&
+				e.TargetID == "target-789" &&
+				e.Details["from"] == string(domain.RoleUser) &&
+				e.Details["to"] == string(domain.RoleAdmin) &&
+				!e.Timestamp.IsZero()
+		})).Return(nil).Once()
+
+		_, err := uc.SetUserRole(context.Background(), adminUser.ID, adminUser.Role, targetUser.ID, domain.RoleAdmin)
+		assert.NoError(t, err)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Audit write failure does not fail the role change", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockAuditRepo := new(MockAuditRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second, usecases.WithUserAuditLog(mockAuditRepo))
+		targetUser := &domain.User{ID: "target-789", Role: domain.RoleUser}
+
+		mockUserRepo.On("GetByID", mock.Anything, "target-789").Return(targetUser, nil).Once()
+		mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		mockAuditRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("audit store down")).Once()
+
+		updatedUser, err := uc.SetUserRole(context.Background(), adminUser.ID, adminUser.Role, targetUser.ID, domain.RoleAdmin)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleAdmin, updatedUser.Role)
+		mockAuditRepo.AssertExpectations(t)
+	})
+
 	t.Run("Success - No update needed if role is already correct", func(t *testing.T) {
 		mockUserRepo := new(MockUserRepository)
 		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, nil, nil, nil, 2*time.Second)
@@ -661,3 +1361,115 @@ func TestUserUsecase_SetUserRole(t *testing.T) {
 		mockUserRepo.AssertExpectations(t)
 	})
 }
+
+func TestUserUsecase_RevokeTokens(t *testing.T) {
+	targetUser := &domain.User{ID: "target-789", Role: domain.RoleUser}
+
+	t.Run("Success - Revokes Access And Refresh Tokens", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, nil, nil, 2*time.Second)
+
+		mockUserRepo.On("GetByID", mock.Anything, targetUser.ID).Return(targetUser, nil).Once()
+		mockTokenRepo.On("DeleteByUserID", mock.Anything, targetUser.ID, domain.TokenTypeRefresh).Return(int64(2), nil).Once()
+		mockTokenRepo.On("DeleteByUserID", mock.Anything, targetUser.ID, domain.TokenTypeAccessToken).Return(int64(1), nil).Once()
+
+		revoked, err := uc.RevokeTokens(context.Background(), "admin-1", domain.RoleAdmin, targetUser.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), revoked)
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Actor is not an Admin", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, nil, nil, 2*time.Second)
+
+		_, err := uc.RevokeTokens(context.Background(), "admin-1", domain.RoleUser, targetUser.ID)
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+		mockTokenRepo.AssertNotCalled(t, "DeleteByUserID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - Target user not found", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, nil, nil, 2*time.Second)
+		mockUserRepo.On("GetByID", mock.Anything, "non-existent-id").Return(nil, domain.ErrUserNotFound).Once()
+
+		_, err := uc.RevokeTokens(context.Background(), "admin-1", domain.RoleAdmin, "non-existent-id")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockTokenRepo.AssertNotCalled(t, "DeleteByUserID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure - Repository error", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, nil, mockTokenRepo, nil, nil, 2*time.Second)
+		expectedError := errors.New("database write error")
+
+		mockUserRepo.On("GetByID", mock.Anything, targetUser.ID).Return(targetUser, nil).Once()
+		mockTokenRepo.On("DeleteByUserID", mock.Anything, targetUser.ID, domain.TokenTypeRefresh).Return(int64(0), expectedError).Once()
+
+		_, err := uc.RevokeTokens(context.Background(), "admin-1", domain.RoleAdmin, targetUser.ID)
+		assert.Equal(t, expectedError, err)
+		mockTokenRepo.AssertExpectations(t)
+	})
+}
+
+func TestUserUsecase_Impersonate(t *testing.T) {
+	adminID := "admin-1"
+	targetUser := &domain.User{ID: "target-789", Role: domain.RoleUser}
+
+	t.Run("Success - Issues A Flagged Short-Lived Token", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockJwtSvc := new(MockJWTService)
+		uc := usecases.NewUserUsecase(mockUserRepo, nil, mockJwtSvc, nil, nil, nil, 2*time.Second)
+		expiresAt := time.Now().Add(usecases.ImpersonationTokenTTL)
+		claims := &infrastructure.JWTClaims{
+			UserID:           targetUser.ID,
+			ImpersonatedBy:   adminID,
+			RegisteredClaims: jwt.RegisteredClaims{ID: "jti-1", ExpiresAt: jwt.NewNumericDate(expiresAt)},
+		}
+
+		mockUserRepo.On("GetByID", mock.Anything, targetUser.ID).Return(targetUser, nil).Once()
+		mockJwtSvc.On("GenerateImpersonationToken", targetUser.ID, targetUser.Role, adminID, usecases.ImpersonationTokenTTL).
+			Return("impersonation.token", claims, nil).Once()
+
+		token, gotExpiry, err := uc.Impersonate(context.Background(), adminID, domain.RoleAdmin, targetUser.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "impersonation.token", token)
+		assert.WithinDuration(t, expiresAt, gotExpiry, time.Second)
+		mockJwtSvc.AssertExpectations(t)
+	})
+
+	testCases := []struct {
+		name        string
+		actorRole   domain.Role
+		targetID    string
+		target      *domain.User
+		lookupErr   error
+		expectedErr error
+	}{
+		{name: "Failure - Actor is not an Admin", actorRole: domain.RoleUser, targetID: targetUser.ID, expectedErr: domain.ErrPermissionDenied},
+		{name: "Failure - Admin impersonating themselves", actorRole: domain.RoleAdmin, targetID: adminID, expectedErr: domain.ErrPermissionDenied},
+		{name: "Failure - Target is an Admin", actorRole: domain.RoleAdmin, targetID: "other-admin", target: &domain.User{ID: "other-admin", Role: domain.RoleAdmin}, expectedErr: domain.ErrPermissionDenied},
+		{name: "Failure - Target user not found", actorRole: domain.RoleAdmin, targetID: "missing", lookupErr: domain.ErrUserNotFound, expectedErr: domain.ErrUserNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockJwtSvc := new(MockJWTService)
+			uc := usecases.NewUserUsecase(mockUserRepo, nil, mockJwtSvc, nil, nil, nil, 2*time.Second)
+			if tc.target != nil || tc.lookupErr != nil {
+				mockUserRepo.On("GetByID", mock.Anything, tc.targetID).Return(tc.target, tc.lookupErr).Once()
+			}
+
+			_, _, err := uc.Impersonate(context.Background(), adminID, tc.actorRole, tc.targetID)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			mockJwtSvc.AssertNotCalled(t, "GenerateImpersonationToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
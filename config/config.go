@@ -1,14 +1,25 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// blogSortFields are the sortBy values the blog list accepts, any of which may be its default.
+var blogSortFields = []string{"date", "title", "popularity", "engagementScore", "activity"}
+
+// maxPageLimit mirrors controllers.MaxPageLimit. The pagination middleware lowers any limit to
+// it before the tag listing's own cap applies, so a higher TAG_LIST_MAX_LIMIT would never be used.
+const maxPageLimit = 100
+
 // Config holds all configuration for the application.
 // Values are read from environment variables.
 type Config struct {
@@ -16,9 +27,88 @@ type Config struct {
 	ServerPort     string
 	UsecaseTimeout time.Duration
 
+	MaxBlogRevisions int
+	// Most tags the tag listing returns per page, whatever limit the client asks for. At most 100.
+	MaxTagListLimit int
+	// How long the same title and content from one author is rejected as a duplicate. Zero disables the check.
+	BlogDuplicateWindow time.Duration
+	// Serve trending blogs to users who follow nobody, instead of an empty feed.
+	FeedTrendingFallback bool
+	// Bounds on the length of comment content, in characters.
+	CommentMinLength int
+	CommentMaxLength int
+	// Cap on the comments a single blog can receive, to curb runaway threads. Zero means unlimited.
+	MaxCommentsPerBlog int
+	// Minimum time between two comments by the same user. Zero disables the cooldown.
+	CommentCooldown time.Duration
+	// How many replies are embedded under each comment in a blog's comment listing. Zero embeds none.
+	EmbeddedReplies int
+	// Word-list profanity filter for comments and blog titles: the listed words, an optional
+	// file with one word per line, and whether matches are rejected or masked.
+	ProfanityWords     []string
+	ProfanityWordsFile string
+	ProfanityMode      string
+	// How often the background worker looks for scheduled blogs that are due.
+	PublishInterval time.Duration
+	// How often expired tokens are purged, as a backup to the TTL index.
+	CleanupInterval time.Duration
+	// How old an account must be before it can post blogs or comments. Zero disables the check.
+	MinAccountAge time.Duration
+	// Hold new blogs and comments for an admin to approve before they are public.
+	PreModeration bool
+	// How long near-identical blogs or comments from one user are rejected, to stop spam loops.
+	// Zero disables the check. Above zero, SimilarityMaxWords compares only the first words,
+	// making the check more sensitive.
+	SimilarityWindow   time.Duration
+	SimilarityMaxWords int
+	// How long a page of blog search results is cached. Blog changes clear it early. Zero disables it.
+	SearchCacheTTL time.Duration
+	// New blogs and comments the AI scores above this toxicity, from 0 to 1, are rejected or
+	// queued for moderation depending on ToxicityAction ("reject" or "queue"). Zero disables scoring.
+	ToxicityThreshold float64
+	ToxicityAction    string
+	// Blog views are buffered and written every ViewFlushInterval, or once a blog has
+	// ViewFlushMaxPending unsaved views. A zero interval writes every view straight away.
+	ViewFlushInterval   time.Duration
+	ViewFlushMaxPending int
+	// BlogDefaultSort is the sortBy used for blog listings that don't specify one.
+	BlogDefaultSort string
+	// Hosts blog content may embed images from, subdomains included. An empty list allows any
+	// host. ImageHostMode is "reject" to refuse the content or "strip" to drop the image.
+	ImageAllowedHosts []string
+	ImageHostMode     string
+	// CommentDeletePolicy is "anonymize" to keep deleted comments as "[deleted]", or "hard" to
+	// remove those without replies.
+	CommentDeletePolicy string
+	// AI suggestions get their own per-user limit of AISuggestRateLimit requests every
+	// AISuggestRateWindow, on top of the general AI quota. A zero limit turns it off.
+	AISuggestRateLimit  int
+	AISuggestRateWindow time.Duration
+	// SSEBufferSize is how many events a streaming response holds for a client that reads slowly.
+	// A client that lets it fill up is disconnected.
+	SSEBufferSize int
+	// CommentDeleteWindow is how long after posting authors may delete their comments. Admins
+	// always can. Zero means no limit.
+	CommentDeleteWindow time.Duration
+	// Start with the API read-only. Admins switch it back off through the maintenance endpoint.
+	MaintenanceMode bool
+
+	// CORS policies. The admin routes get their own origin and method lists, which may not
+	// contain the "*" wildcard. CORSMaxAge is how long browsers may cache a preflight.
+	CORSAllowedOrigins      []string
+	CORSAllowedMethods      []string
+	CORSAllowedHeaders      []string
+	CORSAdminAllowedOrigins []string
+	CORSAdminAllowedMethods []string
+	CORSMaxAge              time.Duration
+
 	MongoURI string
 	DBName   string
 
+	// Development-only explain logging for slow reads. Ignored in production.
+	QueryProfiling     bool
+	SlowQueryThreshold time.Duration
+
 	RedisUrl      string
 	RedisAddr     string
 	RedisPassword string
@@ -28,9 +118,32 @@ type Config struct {
 	JWTIssuer     string
 	JWTAccessTTL  time.Duration
 	JWTRefreshTTL time.Duration
+	// Key rotation: the kid of JWTSecret, and earlier secrets by kid that are still accepted.
+	JWTKeyID        string
+	JWTPreviousKeys map[string]string
+	// Signing algorithm, HS256 or RS256. RS256 signs with the PEM private key in JWTPrivateKeyFile
+	// and also accepts the PEM public keys of previous rotations, given as file paths by kid.
+	JWTAlgorithm      string
+	JWTPrivateKeyFile string
+	JWTPublicKeyFiles map[string]string
+
+	// bcrypt cost of new password hashes. Raise it on fast production hardware, lower it in tests.
+	BcryptCost int
+
+	// Lifetimes of the activation and password reset links sent by email.
+	ActivationTokenTTL time.Duration
+	ResetTokenTTL      time.Duration
 
 	GeminiAPIKey string
 	GeminiModel  string
+	// Optional directory of AI prompt templates (blog_ideas.tmpl, refine_post.tmpl) overriding
+	// the built-in ones, and the language the model is asked to write in.
+	AIPromptDir      string
+	AIPromptLanguage string
+	// Cap on concurrent calls to the AI provider, and how long a call waits for a free slot
+	// before failing with 503. Zero max means unlimited.
+	AIMaxConcurrent int
+	AIQueueTimeout  time.Duration
 
 	CloudinaryCloudName string
 	CloudinaryAPIKey    string
@@ -39,12 +152,24 @@ type Config struct {
 	GoogleClientID     string
 	GoogleClientSecret string
 	GoogleRedirectURI  string
+	// Further redirect URIs that clients may ask for, e.g. one per frontend.
+	GoogleRedirectURIs []string
 
 	SMTPHost string
 	SMTPPort int
 	SMTPUser string
 	SMTPPass string
 	SMTPFrom string
+	// How the mail server connection is secured (starttls, implicit or none), how long connecting
+	// and sending one message may take, and how many idle connections are kept for reuse.
+	SMTPTLSMode     string
+	SMTPDialTimeout time.Duration
+	SMTPSendTimeout time.Duration
+	SMTPIdleTimeout time.Duration
+	SMTPPoolSize    int
+	// "smtp" sends real emails; "log" only captures them for the admin debug endpoint.
+	// Defaults to log everywhere but production.
+	EmailMode string
 }
 
 // Load loads the configuration from .env files and environment variables.
@@ -61,13 +186,75 @@ func Load() *Config {
 	refreshTTL, _ := strconv.Atoi(getEnv("JWT_REFRESH_TTL_HR", "72"))
 	redisDB, _ := strconv.Atoi(getEnv("REDIS_DB", "0"))
 	smtpPort, _ := strconv.Atoi(getEnv("SMTP_PORT", "2525"))
+	activationTTL, _ := strconv.Atoi(getEnv("ACTIVATION_TOKEN_TTL_HR", "24"))
+	resetTTL, _ := strconv.Atoi(getEnv("RESET_TOKEN_TTL_MIN", "15"))
+	bcryptCost, _ := strconv.Atoi(getEnv("BCRYPT_COST", "10"))
+	maxBlogRevisions, _ := strconv.Atoi(getEnv("MAX_BLOG_REVISIONS", "20"))
+	maxTagListLimit, _ := strconv.Atoi(getEnv("TAG_LIST_MAX_LIMIT", "50"))
+	commentMinLength, _ := strconv.Atoi(getEnv("COMMENT_MIN_LENGTH", "1"))
+	commentMaxLength, _ := strconv.Atoi(getEnv("COMMENT_MAX_LENGTH", "2000"))
+	maxCommentsPerBlog, _ := strconv.Atoi(getEnv("MAX_COMMENTS_PER_BLOG", "0"))
+	commentCooldownSec, _ := strconv.Atoi(getEnv("COMMENT_COOLDOWN_SEC", "0"))
+	embeddedReplies, _ := strconv.Atoi(getEnv("COMMENT_EMBEDDED_REPLIES", "3"))
+	publishIntervalSec, _ := strconv.Atoi(getEnv("PUBLISH_INTERVAL_SEC", "60"))
+	if publishIntervalSec <= 0 {
+		publishIntervalSec = 60
+	}
+	cleanupIntervalMin, _ := strconv.Atoi(getEnv("TOKEN_CLEANUP_INTERVAL_MIN", "60"))
+	if cleanupIntervalMin <= 0 {
+		cleanupIntervalMin = 60
+	}
+	queryProfiling, _ := strconv.ParseBool(getEnv("QUERY_PROFILING", "false"))
+	feedTrendingFallback, _ := strconv.ParseBool(getEnv("FEED_TRENDING_FALLBACK", "false"))
+	aiMaxConcurrent, _ := strconv.Atoi(getEnv("AI_MAX_CONCURRENT", "4"))
+	aiQueueTimeoutSec, _ := strconv.Atoi(getEnv("AI_QUEUE_TIMEOUT_SEC", "10"))
+	blogDuplicateWindowMin, _ := strconv.Atoi(getEnv("BLOG_DUPLICATE_WINDOW_MIN", "10"))
+	maintenanceMode, _ := strconv.ParseBool(getEnv("MAINTENANCE_MODE", "false"))
+	minAccountAgeMin, _ := strconv.Atoi(getEnv("MIN_ACCOUNT_AGE_MIN", "0"))
+	preModeration, _ := strconv.ParseBool(getEnv("PRE_MODERATION", "false"))
+	similarityWindowSec, _ := strconv.Atoi(getEnv("SIMILAR_CONTENT_WINDOW_SEC", "0"))
+	similarityMaxWords, _ := strconv.Atoi(getEnv("SIMILAR_CONTENT_MAX_WORDS", "0"))
+	searchCacheTTLSec, _ := strconv.Atoi(getEnv("SEARCH_CACHE_TTL_SEC", "30"))
+	toxicityThreshold, _ := strconv.ParseFloat(getEnv("TOXICITY_THRESHOLD", "0"), 64)
+	viewFlushIntervalSec, _ := strconv.Atoi(getEnv("VIEW_FLUSH_INTERVAL_SEC", "5"))
+	viewFlushMaxPending, _ := strconv.Atoi(getEnv("VIEW_FLUSH_MAX_PENDING", "100"))
+	aiSuggestRateLimit, _ := strconv.Atoi(getEnv("AI_SUGGEST_RATE_LIMIT", "5"))
+	aiSuggestRateWindowMin, _ := strconv.Atoi(getEnv("AI_SUGGEST_RATE_WINDOW_MIN", "60"))
+	sseBufferSize, _ := strconv.Atoi(getEnv("SSE_BUFFER_SIZE", "64"))
+	commentDelWindowMin, _ := strconv.Atoi(getEnv("COMMENT_DEL_WINDOW_MIN", "0"))
+	slowQueryMs, _ := strconv.Atoi(getEnv("SLOW_QUERY_THRESHOLD_MS", "100"))
+	corsMaxAgeMin, _ := strconv.Atoi(getEnv("CORS_MAX_AGE_MIN", "720"))
+	// Port 465 is the implicit TLS port; everything else is expected to offer STARTTLS.
+	smtpTLSMode := "starttls"
+	if smtpPort == 465 {
+		smtpTLSMode = "implicit"
+	}
+	smtpDialTimeoutSec, _ := strconv.Atoi(getEnv("SMTP_DIAL_TIMEOUT_SEC", "10"))
+	smtpSendTimeoutSec, _ := strconv.Atoi(getEnv("SMTP_SEND_TIMEOUT_SEC", "30"))
+	smtpIdleTimeoutSec, _ := strconv.Atoi(getEnv("SMTP_IDLE_TIMEOUT_SEC", "30"))
+	smtpPoolSize, _ := strconv.Atoi(getEnv("SMTP_POOL_SIZE", "2"))
+	appEnv := getEnv("APP_ENV", "development")
+	emailMode := "log"
+	if appEnv == "production" {
+		emailMode = "smtp"
+	}
 
 	return &Config{
-		AppEnv:              getEnv("APP_ENV", "development"),
+		AppEnv:              appEnv,
 		ServerPort:          getEnv("PORT", "8080"),
 		UsecaseTimeout:      5 * time.Second,
+		MaxBlogRevisions:    maxBlogRevisions,
+		CommentMinLength:    commentMinLength,
+		CommentMaxLength:    commentMaxLength,
+		ProfanityWords:      parseList(getEnv("PROFANITY_WORDS", "")),
+		ProfanityWordsFile:  getEnv("PROFANITY_WORDS_FILE", ""),
+		ProfanityMode:       strings.ToLower(getEnv("PROFANITY_MODE", "reject")),
+		PublishInterval:     time.Duration(publishIntervalSec) * time.Second,
+		CleanupInterval:     time.Duration(cleanupIntervalMin) * time.Minute,
 		MongoURI:            getEnv("MONGO_URI", "mongodb://localhost:27017"),
 		DBName:              getEnv("DB_NAME", "g6-blog-db"),
+		QueryProfiling:      queryProfiling,
+		SlowQueryThreshold:  time.Duration(slowQueryMs) * time.Millisecond,
 		RedisUrl:            getEnv("REDIS_URI", ""),
 		RedisAddr:           getEnv("REDIS_ADDR", "localhost:6379"),
 		RedisPassword:       getEnv("REDIS_PASSWORD", ""),
@@ -76,6 +263,14 @@ func Load() *Config {
 		JWTIssuer:           "g6-blog-api",
 		JWTAccessTTL:        time.Duration(accessTTL) * time.Minute,
 		JWTRefreshTTL:       time.Duration(refreshTTL) * time.Hour,
+		JWTKeyID:            getEnv("JWT_KEY_ID", ""),
+		JWTPreviousKeys:     parseKeys(getEnv("JWT_PREVIOUS_KEYS", "")),
+		JWTAlgorithm:        strings.ToUpper(getEnv("JWT_ALGORITHM", "HS256")),
+		JWTPrivateKeyFile:   getEnv("JWT_PRIVATE_KEY_FILE", ""),
+		JWTPublicKeyFiles:   parseKeys(getEnv("JWT_PREVIOUS_PUBLIC_KEYS", "")),
+		BcryptCost:          bcryptCost,
+		ActivationTokenTTL:  time.Duration(activationTTL) * time.Hour,
+		ResetTokenTTL:       time.Duration(resetTTL) * time.Minute,
 		GeminiAPIKey:        getEnv("GEMINI_API_KEY", ""),
 		GeminiModel:         getEnv("GEMINI_MODEL", "gemini-2.5-pro"),
 		CloudinaryCloudName: getEnv("CLOUDINARY_CLOUD_NAME", ""),
@@ -89,7 +284,213 @@ func Load() *Config {
 		SMTPUser:            getEnv("SMTP_USER", ""),
 		SMTPPass:            getEnv("SMTP_PASSWORD", ""),
 		SMTPFrom:            getEnv("SMTP_FROM_EMAIL", "no-reply@example.com"),
+		// CORS
+		CORSAllowedOrigins:      parseList(getEnv("CORS_ALLOWED_ORIGINS", "*")),
+		CORSAllowedMethods:      parseList(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE")),
+		CORSAllowedHeaders:      parseList(getEnv("CORS_ALLOWED_HEADERS", "Authorization,Content-Type,Accept-Language")),
+		CORSAdminAllowedOrigins: parseList(getEnv("CORS_ADMIN_ALLOWED_ORIGINS", "")),
+		CORSAdminAllowedMethods: parseList(getEnv("CORS_ADMIN_ALLOWED_METHODS", "GET,POST,PATCH")),
+		CORSMaxAge:              time.Duration(corsMaxAgeMin) * time.Minute,
+		MaxCommentsPerBlog:      maxCommentsPerBlog,
+		CommentCooldown:         time.Duration(commentCooldownSec) * time.Second,
+		SMTPTLSMode:             strings.ToLower(getEnv("SMTP_TLS_MODE", smtpTLSMode)),
+		SMTPDialTimeout:         time.Duration(smtpDialTimeoutSec) * time.Second,
+		SMTPSendTimeout:         time.Duration(smtpSendTimeoutSec) * time.Second,
+		SMTPIdleTimeout:         time.Duration(smtpIdleTimeoutSec) * time.Second,
+		SMTPPoolSize:            smtpPoolSize,
+		EmailMode:               strings.ToLower(getEnv("EMAIL_MODE", emailMode)),
+		EmbeddedReplies:         embeddedReplies,
+		AIPromptDir:             getEnv("AI_PROMPT_DIR", ""),
+		AIPromptLanguage:        getEnv("AI_PROMPT_LANGUAGE", "English"),
+		MaxTagListLimit:         maxTagListLimit,
+		FeedTrendingFallback:    feedTrendingFallback,
+		AIMaxConcurrent:         aiMaxConcurrent,
+		AIQueueTimeout:          time.Duration(aiQueueTimeoutSec) * time.Second,
+		BlogDuplicateWindow:     time.Duration(blogDuplicateWindowMin) * time.Minute,
+		MaintenanceMode:         maintenanceMode,
+		MinAccountAge:           time.Duration(minAccountAgeMin) * time.Minute,
+		GoogleRedirectURIs:      parseList(getEnv("GOOGLE_ALLOWED_REDIRECT_URIS", "")),
+		PreModeration:           preModeration,
+		SimilarityWindow:        time.Duration(similarityWindowSec) * time.Second,
+		SimilarityMaxWords:      similarityMaxWords,
+		SearchCacheTTL:          time.Duration(searchCacheTTLSec) * time.Second,
+		ToxicityThreshold:       toxicityThreshold,
+		ToxicityAction:          strings.ToLower(getEnv("TOXICITY_ACTION", "queue")),
+		ViewFlushInterval:       time.Duration(viewFlushIntervalSec) * time.Second,
+		ViewFlushMaxPending:     viewFlushMaxPending,
+		BlogDefaultSort:         getEnv("BLOG_DEFAULT_SORT", "date"),
+		ImageAllowedHosts:       parseList(getEnv("IMAGE_ALLOWED_HOSTS", "")),
+		ImageHostMode:           strings.ToLower(getEnv("IMAGE_HOST_MODE", "reject")),
+		CommentDeletePolicy:     strings.ToLower(getEnv("COMMENT_DELETE_POLICY", "anonymize")),
+		AISuggestRateLimit:      aiSuggestRateLimit,
+		AISuggestRateWindow:     time.Duration(aiSuggestRateWindowMin) * time.Minute,
+		SSEBufferSize:           sseBufferSize,
+		CommentDeleteWindow:     time.Duration(commentDelWindowMin) * time.Minute,
+	}
+}
+
+// Validate reports settings that would leave the application in a broken state.
+// It is called once at startup so a bad deployment fails fast.
+func (c *Config) Validate() error {
+	if c.ActivationTokenTTL <= 0 {
+		return errors.New("ACTIVATION_TOKEN_TTL_HR must be a positive number of hours")
+	}
+	if c.ResetTokenTTL <= 0 {
+		return errors.New("RESET_TOKEN_TTL_MIN must be a positive number of minutes")
+	}
+	if c.BcryptCost < 4 || c.BcryptCost > 31 {
+		return fmt.Errorf("BCRYPT_COST must be between 4 and 31, got %d", c.BcryptCost)
+	}
+	if c.CommentMinLength < 1 || c.CommentMaxLength < c.CommentMinLength {
+		return fmt.Errorf("COMMENT_MIN_LENGTH must be at least 1 and no greater than COMMENT_MAX_LENGTH, got %d and %d", c.CommentMinLength, c.CommentMaxLength)
+	}
+	if c.MaxCommentsPerBlog < 0 {
+		return errors.New("MAX_COMMENTS_PER_BLOG must not be negative; use 0 for unlimited")
+	}
+	if c.CommentCooldown < 0 {
+		return errors.New("COMMENT_COOLDOWN_SEC must not be negative; use 0 to disable the cooldown")
+	}
+	if c.EmbeddedReplies < 0 || c.EmbeddedReplies > 20 {
+		return fmt.Errorf("COMMENT_EMBEDDED_REPLIES must be between 0 and 20, got %d", c.EmbeddedReplies)
+	}
+	if c.MaxTagListLimit < 1 || c.MaxTagListLimit > maxPageLimit {
+		return fmt.Errorf("TAG_LIST_MAX_LIMIT must be between 1 and %d, got %d", maxPageLimit, c.MaxTagListLimit)
+	}
+	if c.BlogDuplicateWindow < 0 {
+		return errors.New("BLOG_DUPLICATE_WINDOW_MIN must not be negative; use 0 to disable the duplicate check")
+	}
+	if c.MinAccountAge < 0 {
+		return errors.New("MIN_ACCOUNT_AGE_MIN must not be negative; use 0 to disable the account age check")
+	}
+	if c.SimilarityWindow < 0 {
+		return errors.New("SIMILAR_CONTENT_WINDOW_SEC must not be negative; use 0 to disable the similarity check")
+	}
+	if c.SimilarityMaxWords < 0 {
+		return errors.New("SIMILAR_CONTENT_MAX_WORDS must not be negative; use 0 to compare all words")
+	}
+	if c.SearchCacheTTL < 0 {
+		return errors.New("SEARCH_CACHE_TTL_SEC must not be negative; use 0 to disable search caching")
+	}
+	if c.ToxicityThreshold < 0 || c.ToxicityThreshold > 1 {
+		return fmt.Errorf("TOXICITY_THRESHOLD must be between 0 and 1, got %v; use 0 to disable scoring", c.ToxicityThreshold)
+	}
+	if c.ToxicityAction != "reject" && c.ToxicityAction != "queue" {
+		return fmt.Errorf("TOXICITY_ACTION must be reject or queue, got %q", c.ToxicityAction)
+	}
+	if c.ViewFlushInterval < 0 {
+		return errors.New("VIEW_FLUSH_INTERVAL_SEC must not be negative; use 0 to write every view straight away")
+	}
+	if c.ViewFlushMaxPending < 0 {
+		return errors.New("VIEW_FLUSH_MAX_PENDING must not be negative; use 0 to flush on the interval only")
+	}
+	if !slices.Contains(blogSortFields, c.BlogDefaultSort) {
+		return fmt.Errorf("BLOG_DEFAULT_SORT must be one of %s, got %q", strings.Join(blogSortFields, ", "), c.BlogDefaultSort)
+	}
+	if c.ImageHostMode != "reject" && c.ImageHostMode != "strip" {
+		return fmt.Errorf("IMAGE_HOST_MODE must be reject or strip, got %q", c.ImageHostMode)
+	}
+	if c.CommentDeletePolicy != "anonymize" && c.CommentDeletePolicy != "hard" {
+		return fmt.Errorf("COMMENT_DELETE_POLICY must be anonymize or hard, got %q", c.CommentDeletePolicy)
+	}
+	if c.AISuggestRateLimit < 0 {
+		return errors.New("AI_SUGGEST_RATE_LIMIT must not be negative; use 0 to turn it off")
+	}
+	if c.AISuggestRateLimit > 0 && c.AISuggestRateWindow <= 0 {
+		return errors.New("AI_SUGGEST_RATE_WINDOW_MIN must be positive when AI_SUGGEST_RATE_LIMIT is set")
+	}
+	if c.SSEBufferSize <= 0 {
+		return errors.New("SSE_BUFFER_SIZE must be a positive number of events")
+	}
+	if c.CommentDeleteWindow < 0 {
+		return errors.New("COMMENT_DEL_WINDOW_MIN must not be negative; use 0 for no limit")
+	}
+	if c.AIMaxConcurrent < 0 {
+		return errors.New("AI_MAX_CONCURRENT must not be negative; use 0 for unlimited")
+	}
+	if c.AIQueueTimeout < 0 {
+		return errors.New("AI_QUEUE_TIMEOUT_SEC must not be negative; use 0 to fail at once when all slots are busy")
+	}
+	if c.SMTPDialTimeout <= 0 || c.SMTPSendTimeout <= 0 || c.SMTPIdleTimeout <= 0 {
+		return errors.New("SMTP_DIAL_TIMEOUT_SEC, SMTP_SEND_TIMEOUT_SEC and SMTP_IDLE_TIMEOUT_SEC must be positive numbers of seconds")
+	}
+	if c.SMTPPoolSize < 0 {
+		return errors.New("SMTP_POOL_SIZE must not be negative; use 0 to dial for every email")
+	}
+	switch c.SMTPTLSMode {
+	case "starttls", "implicit", "none":
+	default:
+		return fmt.Errorf("SMTP_TLS_MODE must be starttls, implicit or none, got %q", c.SMTPTLSMode)
+	}
+	switch c.EmailMode {
+	case "smtp":
+	case "log":
+		// The debug endpoint would hand out every reset and activation token.
+		if c.AppEnv == "production" {
+			return errors.New("EMAIL_MODE=log is not allowed in production")
+		}
+	default:
+		return fmt.Errorf("EMAIL_MODE must be smtp or log, got %q", c.EmailMode)
+	}
+	if c.ProfanityMode != "reject" && c.ProfanityMode != "mask" {
+		return fmt.Errorf("PROFANITY_MODE must be reject or mask, got %q", c.ProfanityMode)
+	}
+	for _, origin := range c.CORSAdminAllowedOrigins {
+		if origin == "*" {
+			return errors.New("CORS_ADMIN_ALLOWED_ORIGINS must list explicit origins, not *")
+		}
+	}
+	if c.CORSMaxAge < 0 {
+		return errors.New("CORS_MAX_AGE_MIN must not be negative")
+	}
+	for kid, secret := range c.JWTPreviousKeys {
+		if kid == "" || secret == "" {
+			return errors.New("JWT_PREVIOUS_KEYS must be a comma-separated list of kid:secret pairs")
+		}
+		if kid == c.JWTKeyID {
+			return fmt.Errorf("JWT_PREVIOUS_KEYS must not reuse the current JWT_KEY_ID %q", kid)
+		}
+	}
+	switch c.JWTAlgorithm {
+	case "HS256":
+	case "RS256":
+		if c.JWTPrivateKeyFile == "" {
+			return errors.New("JWT_PRIVATE_KEY_FILE is required when JWT_ALGORITHM is RS256")
+		}
+	default:
+		return fmt.Errorf("JWT_ALGORITHM must be HS256 or RS256, got %q", c.JWTAlgorithm)
+	}
+	for kid, path := range c.JWTPublicKeyFiles {
+		if kid == "" || path == "" {
+			return errors.New("JWT_PREVIOUS_PUBLIC_KEYS must be a comma-separated list of kid:path pairs")
+		}
+	}
+	return nil
+}
+
+// parseKeys reads a list like "2024-01:old-secret,2023-12:older-secret" into secrets by kid.
+// A malformed entry is kept with an empty secret so Validate can report it.
+func parseKeys(value string) map[string]string {
+	keys := map[string]string{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kid, secret, _ := strings.Cut(entry, ":")
+		keys[strings.TrimSpace(kid)] = secret
+	}
+	return keys
+}
+
+// parseList reads a comma-separated list, dropping blank entries.
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
 }
 
 // getEnv is a helper to read an environment variable or return a fallback.